@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"Go-Next-WebRTC/pkg/webpush"
+)
+
+// Web Push用のVAPIDの鍵ペアを生成して .env に設定する形式で出力する
+func main() {
+	keys, err := webpush.GenerateVAPIDKeys()
+	if err != nil {
+		log.Fatalf("Failed to generate VAPID keys: %v", err)
+	}
+
+	fmt.Printf("VAPID_PRIVATE_KEY=%s\n", keys.PrivateKey())
+	fmt.Printf("# public key (served from /api/push/public-key): %s\n", keys.PublicKey())
+}
@@ -0,0 +1,17 @@
+-- 定例会議シリーズテーブルの作成
+CREATE TABLE IF NOT EXISTS call_series (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    series_id VARCHAR(36) UNIQUE NOT NULL COMMENT 'UUID形式のシリーズID',
+    name VARCHAR(255) NOT NULL COMMENT '会議名',
+    created_by BIGINT NOT NULL COMMENT '作成者のユーザーID',
+    rrule VARCHAR(500) NOT NULL COMMENT 'RFC 5545 RRULE',
+    start_at TIMESTAMP NOT NULL COMMENT '初回開始日時 (DTSTART, UTC)',
+    duration_minutes INT NOT NULL DEFAULT 30 COMMENT '1回あたりの会議時間 (分)',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC' COMMENT '繰り返し計算に使うタイムゾーン (IANA)',
+    max_participants INT NOT NULL DEFAULT 10 COMMENT '最大参加者数',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_series_id (series_id),
+    INDEX idx_created_by (created_by),
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
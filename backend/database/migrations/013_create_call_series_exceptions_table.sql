@@ -0,0 +1,14 @@
+-- 定例会議シリーズの例外テーブルの作成 (EXDATE / 個別回の上書き)
+CREATE TABLE IF NOT EXISTS call_series_exceptions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    series_id BIGINT NOT NULL COMMENT 'シリーズID',
+    original_start TIMESTAMP NOT NULL COMMENT '本来の開始日時 (RECURRENCE-ID, UTC)',
+    cancelled BOOLEAN NOT NULL DEFAULT FALSE COMMENT '中止 (EXDATE) かどうか',
+    start_at TIMESTAMP NULL COMMENT '上書き後の開始日時',
+    duration_minutes INT NULL COMMENT '上書き後の会議時間 (分)',
+    name VARCHAR(255) NULL COMMENT '上書き後の会議名',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_series_occurrence (series_id, original_start),
+    FOREIGN KEY (series_id) REFERENCES call_series(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,7 @@
+-- 通話ルームに定例会議シリーズの開催回情報を追加
+ALTER TABLE call_rooms
+ADD COLUMN series_id BIGINT NULL COMMENT '生成元のシリーズID' AFTER max_participants,
+ADD COLUMN occurrence_start TIMESTAMP NULL COMMENT 'シリーズ上の本来の開始日時 (RECURRENCE-ID)' AFTER series_id,
+ADD COLUMN scheduled_at TIMESTAMP NULL COMMENT '開始予定日時' AFTER occurrence_start,
+ADD UNIQUE KEY unique_series_occurrence (series_id, occurrence_start),
+ADD CONSTRAINT fk_call_rooms_series FOREIGN KEY (series_id) REFERENCES call_series(id) ON DELETE SET NULL;
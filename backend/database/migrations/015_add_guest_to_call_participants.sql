@@ -0,0 +1,7 @@
+-- 通話参加者にゲスト（アカウントなし）の情報を追加
+ALTER TABLE call_participants
+MODIFY COLUMN user_id BIGINT NULL COMMENT 'ユーザーID（ゲストの場合はNULL）',
+ADD COLUMN guest_id VARCHAR(36) NULL COMMENT '招待リンクから参加したゲストの識別子' AFTER user_id,
+ADD COLUMN guest_name VARCHAR(50) NULL COMMENT 'ゲストの表示名' AFTER guest_id,
+ADD INDEX idx_guest_id (guest_id),
+ADD CONSTRAINT chk_participant_identity CHECK (user_id IS NOT NULL OR guest_id IS NOT NULL);
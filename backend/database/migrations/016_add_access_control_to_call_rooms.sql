@@ -0,0 +1,5 @@
+-- 通話ルームに公開範囲とパスコードを追加
+ALTER TABLE call_rooms
+ADD COLUMN visibility ENUM('public', 'private', 'invite_only') NOT NULL DEFAULT 'public' COMMENT '公開範囲' AFTER max_participants,
+ADD COLUMN passcode_hash VARCHAR(255) NULL COMMENT 'bcryptでハッシュ化したパスコード' AFTER visibility,
+ADD INDEX idx_visibility (visibility);
@@ -0,0 +1,14 @@
+-- 通話ルームの許可リストテーブルの作成
+CREATE TABLE IF NOT EXISTS call_room_access_rules (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    room_id BIGINT NOT NULL COMMENT '通話ルームID',
+    user_id BIGINT NULL COMMENT '許可するユーザーID',
+    email_domain VARCHAR(255) NULL COMMENT '許可するメールドメイン',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_room_id (room_id),
+    UNIQUE KEY unique_room_user (room_id, user_id),
+    UNIQUE KEY unique_room_domain (room_id, email_domain),
+    CONSTRAINT chk_access_rule_target CHECK (user_id IS NOT NULL OR email_domain IS NOT NULL),
+    FOREIGN KEY (room_id) REFERENCES call_rooms(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+-- 通話参加者にルーム内のロールを追加
+ALTER TABLE call_participants
+ADD COLUMN role ENUM('host', 'co_host', 'presenter', 'participant', 'viewer') NOT NULL DEFAULT 'participant' COMMENT 'ルーム内のロール' AFTER guest_name;
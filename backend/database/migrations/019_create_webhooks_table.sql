@@ -0,0 +1,13 @@
+-- Webhookエンドポイントテーブルの作成
+CREATE TABLE IF NOT EXISTS webhooks (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL COMMENT '登録したユーザーID',
+    url VARCHAR(2048) NOT NULL COMMENT '配信先URL',
+    secret VARCHAR(255) NOT NULL COMMENT '署名用の共有シークレット',
+    events TEXT NOT NULL COMMENT '購読イベント (JSON形式)',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE COMMENT '配信を有効にするか',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
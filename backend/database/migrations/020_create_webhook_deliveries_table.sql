@@ -0,0 +1,21 @@
+-- Webhook配信記録テーブルの作成
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    webhook_id BIGINT NOT NULL COMMENT 'WebhookID',
+    event_id VARCHAR(36) NOT NULL COMMENT 'UUID形式のイベントID (再送時も同じ)',
+    event VARCHAR(64) NOT NULL COMMENT 'イベント種別',
+    payload MEDIUMTEXT NOT NULL COMMENT '送信する本文 (JSON)',
+    status ENUM('pending', 'succeeded', 'failed') NOT NULL DEFAULT 'pending' COMMENT '配信ステータス',
+    attempts INT NOT NULL DEFAULT 0 COMMENT '送信試行回数',
+    next_attempt_at TIMESTAMP NULL COMMENT '次回の送信予定時刻',
+    last_status_code INT NULL COMMENT '最後に受信したHTTPステータス',
+    last_error TEXT NULL COMMENT '最後のエラー内容',
+    delivered_at TIMESTAMP NULL COMMENT '配信成功時刻',
+    replay_of BIGINT NULL COMMENT '再送元の配信ID',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_webhook_id_created (webhook_id, created_at),
+    INDEX idx_status_next_attempt (status, next_attempt_at),
+    INDEX idx_event_id (event_id),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
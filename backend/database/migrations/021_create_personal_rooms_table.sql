@@ -0,0 +1,11 @@
+-- 個人ルームテーブルの作成（ユーザーごとの固定URLの会議室）
+CREATE TABLE IF NOT EXISTS personal_rooms (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT UNIQUE NOT NULL COMMENT '所有者のユーザーID',
+    slug VARCHAR(32) UNIQUE NOT NULL COMMENT 'URLに使う識別子 (/r/{slug})',
+    name VARCHAR(255) NOT NULL COMMENT '会議室名',
+    max_participants INT NOT NULL DEFAULT 10 COMMENT '最大参加者数',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,6 @@
+-- 通話ルームに個人ルームのセッション情報を追加
+ALTER TABLE call_rooms
+ADD COLUMN personal_room_id BIGINT NULL COMMENT 'セッションを開いた個人ルームID' AFTER scheduled_at,
+ADD COLUMN session_number INT NULL COMMENT '個人ルーム内のセッション番号' AFTER personal_room_id,
+ADD UNIQUE KEY unique_personal_room_session (personal_room_id, session_number),
+ADD CONSTRAINT fk_call_rooms_personal_room FOREIGN KEY (personal_room_id) REFERENCES personal_rooms(id) ON DELETE SET NULL;
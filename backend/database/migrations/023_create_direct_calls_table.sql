@@ -0,0 +1,18 @@
+-- 1対1通話テーブルの作成
+CREATE TABLE IF NOT EXISTS direct_calls (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    call_id VARCHAR(36) UNIQUE NOT NULL COMMENT 'UUID形式の通話ID',
+    caller_id BIGINT NOT NULL COMMENT '発信者のユーザーID',
+    callee_id BIGINT NOT NULL COMMENT '着信者のユーザーID',
+    room_id BIGINT NOT NULL COMMENT '通話に使う通話ルームID',
+    status ENUM('ringing', 'accepted', 'declined', 'missed') NOT NULL DEFAULT 'ringing' COMMENT '状態',
+    ring_expires_at TIMESTAMP NOT NULL COMMENT 'この時刻までに応答がなければ不在着信',
+    answered_at TIMESTAMP NULL COMMENT '応答日時',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_callee_status (callee_id, status, created_at),
+    INDEX idx_status_expires (status, ring_expires_at),
+    FOREIGN KEY (caller_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (callee_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (room_id) REFERENCES call_rooms(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,9 @@
+-- ユーザーの在席状態テーブルの作成
+-- オンライン・通話中は接続状況から決まるため、ユーザーが設定した状態と最終接続日時のみ保存する
+CREATE TABLE IF NOT EXISTS user_presences (
+    user_id BIGINT PRIMARY KEY,
+    status ENUM('online', 'away', 'dnd') NOT NULL DEFAULT 'online' COMMENT 'ユーザーが設定した状態',
+    last_seen_at TIMESTAMP NULL COMMENT '最終接続日時',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
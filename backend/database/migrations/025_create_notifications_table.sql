@@ -0,0 +1,14 @@
+-- 通知テーブルの作成
+CREATE TABLE IF NOT EXISTS notifications (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL COMMENT '通知先のユーザーID',
+    type VARCHAR(50) NOT NULL COMMENT '通知の種類（call-invite, missed-call など）',
+    title VARCHAR(255) NOT NULL,
+    body TEXT NOT NULL,
+    data JSON NULL COMMENT '種類ごとの付加情報',
+    read_at TIMESTAMP NULL COMMENT '既読日時',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_user_id (user_id, id),
+    INDEX idx_user_read (user_id, read_at),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,12 @@
+-- ログインに使われた端末テーブルの作成（新しい端末からのログイン通知用）
+CREATE TABLE IF NOT EXISTS user_devices (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    device_hash CHAR(64) NOT NULL COMMENT 'User-AgentのSHA-256',
+    user_agent VARCHAR(512) NOT NULL,
+    ip_address VARCHAR(45) NOT NULL DEFAULT '' COMMENT '最後にログインしたIPアドレス',
+    first_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_user_device (user_id, device_hash),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,14 @@
+-- Web Push購読テーブルの作成（端末・ブラウザごとに1件）
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id BIGINT NOT NULL,
+    endpoint TEXT NOT NULL COMMENT 'プッシュサービスのURL',
+    endpoint_hash CHAR(64) UNIQUE NOT NULL COMMENT 'endpointのSHA-256（一意制約用）',
+    p256dh VARCHAR(255) NOT NULL COMMENT '受信側の公開鍵',
+    auth VARCHAR(255) NOT NULL COMMENT '認証用シークレット',
+    user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_user_id (user_id),
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
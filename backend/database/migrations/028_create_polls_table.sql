@@ -0,0 +1,16 @@
+-- 通話中の投票テーブルの作成
+CREATE TABLE IF NOT EXISTS polls (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    room_id BIGINT NOT NULL COMMENT '通話ルームID',
+    question VARCHAR(255) NOT NULL,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE COMMENT '複数選択',
+    anonymous BOOLEAN NOT NULL DEFAULT FALSE COMMENT '投票者を公開しない',
+    created_by BIGINT NULL COMMENT '作成したユーザーID（ゲストの場合はNULL）',
+    status ENUM('open', 'closed') NOT NULL DEFAULT 'open',
+    closed_at TIMESTAMP NULL COMMENT '締め切り日時',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_room_id (room_id, id),
+    FOREIGN KEY (room_id) REFERENCES call_rooms(id) ON DELETE CASCADE,
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
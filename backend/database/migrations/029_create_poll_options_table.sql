@@ -0,0 +1,10 @@
+-- 投票の選択肢テーブルの作成
+CREATE TABLE IF NOT EXISTS poll_options (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    poll_id BIGINT NOT NULL,
+    position INT NOT NULL COMMENT '表示順',
+    text VARCHAR(255) NOT NULL,
+    vote_count INT NOT NULL DEFAULT 0 COMMENT '締め切り時の最終得票数',
+    UNIQUE KEY uk_poll_position (poll_id, position),
+    FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,13 @@
+-- 投票テーブルの作成（複数選択の場合は選択肢ごとに1行）
+CREATE TABLE IF NOT EXISTS poll_votes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    poll_id BIGINT NOT NULL,
+    option_id BIGINT NOT NULL,
+    voter_key VARCHAR(100) NOT NULL COMMENT '参加者の識別子（user-{id} / guest-{id}）',
+    user_id BIGINT NULL COMMENT 'ゲストの場合はNULL',
+    voter_name VARCHAR(100) NOT NULL DEFAULT '' COMMENT '投票時の表示名',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_poll_voter_option (poll_id, voter_key, option_id),
+    FOREIGN KEY (poll_id) REFERENCES polls(id) ON DELETE CASCADE,
+    FOREIGN KEY (option_id) REFERENCES poll_options(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+-- 議事録に投票結果を追加
+ALTER TABLE call_minutes
+ADD COLUMN poll_results TEXT NULL COMMENT '締め切った投票の結果 (整形済み)' AFTER participants_list;
@@ -0,0 +1,9 @@
+-- 通話ルームごとの共有ノートテーブルの作成
+CREATE TABLE IF NOT EXISTS call_notes (
+    room_id BIGINT PRIMARY KEY COMMENT '通話ルームID',
+    content LONGTEXT NOT NULL COMMENT '表示されるテキスト',
+    state LONGTEXT NOT NULL COMMENT 'CRDTの要素列（削除済みを含む、JSON）',
+    version BIGINT NOT NULL DEFAULT 0 COMMENT 'スナップショットの版数',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    FOREIGN KEY (room_id) REFERENCES call_rooms(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+-- 議事録に共有ノートを追加
+ALTER TABLE call_minutes
+ADD COLUMN notes LONGTEXT NULL COMMENT '通話終了時点の共有ノート' AFTER poll_results;
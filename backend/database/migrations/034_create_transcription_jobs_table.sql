@@ -0,0 +1,23 @@
+-- 文字起こしジョブテーブルの作成
+CREATE TABLE IF NOT EXISTS transcription_jobs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    room_id BIGINT NOT NULL COMMENT '通話ルームID',
+    requested_by BIGINT NULL COMMENT '依頼したユーザーID (自動実行の場合NULL)',
+    status ENUM('queued', 'running', 'succeeded', 'failed') NOT NULL DEFAULT 'queued' COMMENT 'ジョブステータス',
+    attempts INT NOT NULL DEFAULT 0 COMMENT '実行回数',
+    items TEXT NOT NULL COMMENT '録音ごとの進捗 (JSON形式)',
+    next_attempt_at TIMESTAMP NULL COMMENT '次回の実行予定時刻',
+    lease_owner VARCHAR(64) NULL COMMENT '実行中のワーカー',
+    lease_expires_at TIMESTAMP NULL COMMENT 'リースの期限 (過ぎたら他のワーカーが引き継ぐ)',
+    last_error TEXT NULL COMMENT '最後のエラー内容',
+    minutes_id BIGINT NULL COMMENT '作成した議事録ID',
+    started_at TIMESTAMP NULL COMMENT '初回の実行開始時刻',
+    finished_at TIMESTAMP NULL COMMENT '完了・失敗した時刻',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_room_id_created (room_id, created_at),
+    INDEX idx_status_next_attempt (status, next_attempt_at),
+    INDEX idx_status_lease (status, lease_expires_at),
+    FOREIGN KEY (room_id) REFERENCES call_rooms(id) ON DELETE CASCADE,
+    FOREIGN KEY (requested_by) REFERENCES users(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
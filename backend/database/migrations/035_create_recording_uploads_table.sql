@@ -0,0 +1,19 @@
+-- 再開可能な録音アップロード（tus）テーブルの作成
+CREATE TABLE IF NOT EXISTS recording_uploads (
+    id CHAR(32) PRIMARY KEY COMMENT 'アップロードID (URLに含めるランダムな値)',
+    room_id BIGINT NOT NULL COMMENT '通話ルームID',
+    user_id BIGINT NOT NULL COMMENT 'アップロードするユーザーID',
+    upload_length BIGINT NOT NULL COMMENT '全体のサイズ (bytes)',
+    upload_offset BIGINT NOT NULL DEFAULT 0 COMMENT '受信済みのサイズ (bytes)',
+    chunks MEDIUMTEXT NOT NULL COMMENT 'ストレージに保存済みのチャンク (JSON形式)',
+    content_type VARCHAR(100) NOT NULL COMMENT '音声のContent-Type',
+    duration_seconds INT NULL COMMENT '録音時間 (秒)',
+    recording_id BIGINT NULL COMMENT '完了して作成した録音ID',
+    expires_at TIMESTAMP NOT NULL COMMENT '期限 (過ぎたら未完了のチャンクを削除する)',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_expires_at (expires_at),
+    FOREIGN KEY (room_id) REFERENCES call_rooms(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (recording_id) REFERENCES call_recordings(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
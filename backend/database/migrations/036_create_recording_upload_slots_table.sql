@@ -0,0 +1,18 @@
+-- ストレージへ直接アップロードする録音の枠テーブルの作成
+CREATE TABLE IF NOT EXISTS recording_upload_slots (
+    id CHAR(32) PRIMARY KEY COMMENT '枠ID (URLに含めるランダムな値)',
+    room_id BIGINT NOT NULL COMMENT '通話ルームID',
+    user_id BIGINT NOT NULL COMMENT 'アップロードするユーザーID',
+    object_key VARCHAR(512) NOT NULL COMMENT 'アップロード先のキー (recordings/{room_id}/...)',
+    content_type VARCHAR(100) NOT NULL COMMENT '音声のContent-Type',
+    size BIGINT NOT NULL COMMENT 'アップロードするサイズ (bytes)',
+    duration_seconds INT NULL COMMENT '録音時間 (秒)',
+    finalizing_at TIMESTAMP NULL COMMENT '完了の処理を始めた日時',
+    recording_id BIGINT NULL COMMENT '完了して作成した録音ID',
+    expires_at TIMESTAMP NOT NULL COMMENT '期限 (過ぎたら未完了のオブジェクトを削除する)',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_expires_at (expires_at),
+    FOREIGN KEY (room_id) REFERENCES call_rooms(id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    FOREIGN KEY (recording_id) REFERENCES call_recordings(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
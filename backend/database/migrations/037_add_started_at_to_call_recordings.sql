@@ -0,0 +1,3 @@
+-- 録音を開始した時刻を追加（複数の参加者の録音を実時間で揃えるため）
+ALTER TABLE call_recordings
+ADD COLUMN started_at TIMESTAMP(3) NULL COMMENT '録音を開始した時刻 (クライアントの時計)' AFTER format;
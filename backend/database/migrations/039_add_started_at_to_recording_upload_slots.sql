@@ -0,0 +1,3 @@
+-- 直接アップロードの枠に録音を開始した時刻を追加（完了時に録音へ引き継ぐ）
+ALTER TABLE recording_upload_slots
+ADD COLUMN started_at TIMESTAMP(3) NULL COMMENT '録音を開始した時刻 (クライアントの時計)' AFTER duration_seconds;
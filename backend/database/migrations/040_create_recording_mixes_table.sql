@@ -0,0 +1,23 @@
+-- 録音のミックス（参加者ごとの録音を1つにまとめたルームの録音）テーブルの作成
+CREATE TABLE IF NOT EXISTS recording_mixes (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    room_id BIGINT NOT NULL COMMENT '通話ルームID',
+    requested_by BIGINT NULL COMMENT '依頼したユーザーID (自動実行の場合NULL)',
+    status ENUM('queued', 'running', 'succeeded', 'failed') NOT NULL DEFAULT 'queued' COMMENT 'ステータス',
+    attempts INT NOT NULL DEFAULT 0 COMMENT '実行回数',
+    tracks TEXT NOT NULL COMMENT 'ミックスした録音と開始位置・正規化したコピー (JSON形式)',
+    recording_started_at TIMESTAMP(3) NULL COMMENT 'ミックスの先頭の時刻 (最も早く録音を開始した時刻)',
+    file_path VARCHAR(500) NULL COMMENT 'ミックスした録音のキー',
+    file_size BIGINT NULL COMMENT 'ファイルサイズ (bytes)',
+    format VARCHAR(50) NOT NULL DEFAULT 'ogg' COMMENT '音声フォーマット',
+    lease_owner VARCHAR(64) NULL COMMENT '実行中のワーカー',
+    lease_expires_at TIMESTAMP NULL COMMENT 'リースの期限 (過ぎたら他のワーカーが引き継ぐ)',
+    last_error TEXT NULL COMMENT '最後のエラー内容',
+    finished_at TIMESTAMP NULL COMMENT '完了・失敗した時刻',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_room_id_created (room_id, created_at),
+    INDEX idx_status_lease (status, lease_expires_at),
+    FOREIGN KEY (room_id) REFERENCES call_rooms(id) ON DELETE CASCADE,
+    FOREIGN KEY (requested_by) REFERENCES users(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,5 @@
+-- 文字起こしに話者のユーザーIDを追加（録音は参加者ごとのため、録音した参加者を話者とする）
+ALTER TABLE call_transcriptions
+ADD COLUMN user_id BIGINT NULL COMMENT '話者のユーザーID (録音した参加者)' AFTER recording_id,
+ADD INDEX idx_user_id (user_id),
+ADD CONSTRAINT fk_call_transcriptions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE SET NULL;
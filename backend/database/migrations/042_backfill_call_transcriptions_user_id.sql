@@ -0,0 +1,5 @@
+-- 既存の文字起こしは録音から話者を埋める
+UPDATE call_transcriptions t
+JOIN call_recordings r ON t.recording_id = r.id
+SET t.user_id = r.user_id
+WHERE t.user_id IS NULL;
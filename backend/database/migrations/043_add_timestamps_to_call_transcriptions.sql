@@ -0,0 +1,5 @@
+-- 文字起こしに発話の時刻を追加（複数の録音の区間を1つの時系列にまとめるため）
+ALTER TABLE call_transcriptions
+ADD COLUMN started_at TIMESTAMP(3) NULL COMMENT '話し始めた時刻 (録音の開始時刻 + start_time)' AFTER end_time,
+ADD COLUMN ended_at TIMESTAMP(3) NULL COMMENT '話し終えた時刻 (録音の開始時刻 + end_time)' AFTER started_at,
+ADD INDEX idx_room_started_at (room_id, started_at);
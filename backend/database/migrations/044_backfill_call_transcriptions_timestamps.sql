@@ -0,0 +1,8 @@
+-- 既存の文字起こしは録音の開始時刻から埋める（開始時刻がない録音はアップロード時刻から録音時間を引いて推定する）
+UPDATE call_transcriptions t
+JOIN call_recordings r ON t.recording_id = r.id
+SET t.started_at = TIMESTAMPADD(MICROSECOND, ROUND(t.start_time * 1000) * 1000,
+        COALESCE(r.started_at, TIMESTAMPADD(SECOND, -COALESCE(r.duration_seconds, 0), r.uploaded_at))),
+    t.ended_at = TIMESTAMPADD(MICROSECOND, ROUND(COALESCE(t.end_time, t.start_time) * 1000) * 1000,
+        COALESCE(r.started_at, TIMESTAMPADD(SECOND, -COALESCE(r.duration_seconds, 0), r.uploaded_at)))
+WHERE t.start_time IS NOT NULL AND t.started_at IS NULL;
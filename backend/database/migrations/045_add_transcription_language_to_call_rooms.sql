@@ -0,0 +1,4 @@
+-- ルームごとの文字起こしの言語設定（auto は候補の言語から自動判定）
+ALTER TABLE call_rooms
+ADD COLUMN transcription_language VARCHAR(35) NOT NULL DEFAULT 'ja-JP' COMMENT '文字起こしの言語 (BCP-47、auto は自動判定)' AFTER session_number,
+ADD COLUMN transcription_alternative_languages TEXT NULL COMMENT '他に話される可能性のある言語 (JSON配列)' AFTER transcription_language;
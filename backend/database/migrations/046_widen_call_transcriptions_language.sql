@@ -0,0 +1,3 @@
+-- 区間ごとに判定した言語を保存するため、地域や文字を含む言語コードも入る長さにする
+ALTER TABLE call_transcriptions
+MODIFY COLUMN language VARCHAR(35) NOT NULL DEFAULT 'ja-JP' COMMENT '話された言語 (BCP-47、判定できない場合は und)';
@@ -0,0 +1,3 @@
+-- 議事録に文字起こしで話された言語を記録
+ALTER TABLE call_minutes
+ADD COLUMN languages TEXT NULL COMMENT '文字起こしで話された言語 (JSON配列、多い順)' AFTER notes;
@@ -0,0 +1,24 @@
+-- 文字起こしの実行テーブルの作成（再実行しても前回の結果を残し、ルームごとに1つを現在の実行とする）
+CREATE TABLE IF NOT EXISTS call_transcription_runs (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    room_id BIGINT NOT NULL COMMENT '通話ルームID',
+    job_id BIGINT NULL COMMENT '実行した文字起こしジョブID',
+    engine VARCHAR(32) NOT NULL COMMENT '文字起こしエンジン (google, whisper)',
+    language VARCHAR(35) NOT NULL COMMENT '文字起こしの言語 (auto は自動判定)',
+    alternative_languages TEXT NULL COMMENT '他に話される可能性のある言語 (JSON配列)',
+    status ENUM('running', 'succeeded', 'failed') NOT NULL DEFAULT 'running' COMMENT '実行の状態',
+    is_current BOOLEAN NOT NULL DEFAULT FALSE COMMENT '議事録の作成に使っている実行か',
+    -- 現在の実行のみルームIDを持たせ、ルームごとに1つに制限する（NULLは一意制約の対象外）
+    current_room_id BIGINT AS (IF(is_current, room_id, NULL)) STORED,
+    segments INT NOT NULL DEFAULT 0 COMMENT '保存した区間数',
+    recordings INT NOT NULL DEFAULT 0 COMMENT '文字起こしした録音の数',
+    error TEXT NULL COMMENT '失敗した場合のエラー内容',
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP COMMENT '開始時刻',
+    finished_at TIMESTAMP NULL COMMENT '完了・失敗した時刻',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_current_room (current_room_id),
+    INDEX idx_room_id_created (room_id, created_at),
+    FOREIGN KEY (room_id) REFERENCES call_rooms(id) ON DELETE CASCADE,
+    FOREIGN KEY (job_id) REFERENCES transcription_jobs(id) ON DELETE SET NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
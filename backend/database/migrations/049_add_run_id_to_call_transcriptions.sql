@@ -0,0 +1,5 @@
+-- 文字起こしの区間を実行ごとに保存
+ALTER TABLE call_transcriptions
+ADD COLUMN run_id BIGINT NULL COMMENT '文字起こしの実行ID' AFTER room_id,
+ADD INDEX idx_run_started_at (run_id, started_at),
+ADD CONSTRAINT fk_call_transcriptions_run FOREIGN KEY (run_id) REFERENCES call_transcription_runs(id) ON DELETE CASCADE;
@@ -0,0 +1,4 @@
+-- 議事録の作成に使った実行
+ALTER TABLE call_minutes
+ADD COLUMN run_id BIGINT NULL COMMENT '作成に使った文字起こしの実行ID' AFTER languages,
+ADD CONSTRAINT fk_call_minutes_run FOREIGN KEY (run_id) REFERENCES call_transcription_runs(id) ON DELETE SET NULL;
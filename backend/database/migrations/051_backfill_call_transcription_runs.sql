@@ -0,0 +1,8 @@
+-- 既存の文字起こしはルームごとに1つの実行（現在の実行）にまとめる
+INSERT INTO call_transcription_runs (room_id, engine, language, status, is_current, segments, recordings, started_at, finished_at)
+SELECT t.room_id, 'unknown', r.transcription_language, 'succeeded', TRUE, COUNT(*), COUNT(DISTINCT t.recording_id),
+    MIN(t.created_at), MAX(t.created_at)
+FROM call_transcriptions t
+JOIN call_rooms r ON t.room_id = r.id
+WHERE t.run_id IS NULL
+GROUP BY t.room_id, r.transcription_language;
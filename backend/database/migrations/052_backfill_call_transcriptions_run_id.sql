@@ -0,0 +1,5 @@
+-- 既存の文字起こしをルームの現在の実行に紐づける
+UPDATE call_transcriptions t
+JOIN call_transcription_runs run ON run.room_id = t.room_id AND run.is_current = TRUE
+SET t.run_id = run.id
+WHERE t.run_id IS NULL;
@@ -0,0 +1,5 @@
+-- 既存の議事録をルームの現在の実行に紐づける
+UPDATE call_minutes m
+JOIN call_transcription_runs run ON run.room_id = m.room_id AND run.is_current = TRUE
+SET m.run_id = run.id
+WHERE m.run_id IS NULL;
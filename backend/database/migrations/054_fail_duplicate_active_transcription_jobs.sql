@@ -0,0 +1,14 @@
+-- 同じルームの実行待ち・実行中のジョブが複数ある場合は最も古いものを残して失敗にする（次のマイグレーションの一意制約のため）
+UPDATE transcription_jobs j
+JOIN (
+    SELECT room_id, MIN(id) AS keep_id
+    FROM transcription_jobs
+    WHERE status IN ('queued', 'running')
+    GROUP BY room_id
+) active ON active.room_id = j.room_id
+SET j.status = 'failed',
+    j.last_error = 'duplicate active job',
+    j.lease_owner = NULL,
+    j.lease_expires_at = NULL,
+    j.finished_at = CURRENT_TIMESTAMP
+WHERE j.status IN ('queued', 'running') AND j.id <> active.keep_id;
@@ -0,0 +1,4 @@
+-- 実行待ち・実行中のジョブのみルームIDを持たせ、ルームごとに1つに制限する（NULLは一意制約の対象外）
+ALTER TABLE transcription_jobs
+    ADD COLUMN active_room_id BIGINT AS (IF(status IN ('queued', 'running'), room_id, NULL)) STORED AFTER status,
+    ADD UNIQUE KEY unique_active_room (active_room_id);
@@ -0,0 +1,6 @@
+-- 個人ルームにアクセス設定を追加（開いたセッションに適用する）
+ALTER TABLE personal_rooms
+ADD COLUMN visibility ENUM('public', 'private', 'invite_only') NOT NULL DEFAULT 'public' COMMENT 'セッションの公開範囲' AFTER max_participants,
+ADD COLUMN passcode_hash VARCHAR(255) NULL COMMENT 'bcryptでハッシュ化したセッションのパスコード' AFTER visibility,
+ADD COLUMN allowed_user_ids TEXT NULL COMMENT 'セッションへの参加を許可するユーザーID (JSON配列)' AFTER passcode_hash,
+ADD COLUMN allowed_email_domains TEXT NULL COMMENT 'セッションへの参加を許可するメールドメイン (JSON配列)' AFTER allowed_user_ids;
@@ -0,0 +1,5 @@
+-- Todoに担当者を追加（担当者に割り当てると通知する）
+ALTER TABLE todos
+ADD COLUMN assignee_id BIGINT NULL COMMENT '担当者のユーザーID' AFTER user_id,
+ADD INDEX idx_assignee_id (assignee_id),
+ADD CONSTRAINT fk_todos_assignee FOREIGN KEY (assignee_id) REFERENCES users(id) ON DELETE SET NULL;
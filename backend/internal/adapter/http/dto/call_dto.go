@@ -4,8 +4,15 @@ import "time"
 
 // CreateRoomRequest 通話ルーム作成リクエスト
 type CreateRoomRequest struct {
-	Name            string `json:"name"`
-	MaxParticipants int    `json:"max_participants"`
+	Name                string   `json:"name"`
+	MaxParticipants     int      `json:"max_participants"`
+	Visibility          string   `json:"visibility,omitempty"`
+	Passcode            string   `json:"passcode,omitempty"`
+	AllowedUserIDs      []int64  `json:"allowed_user_ids,omitempty"`
+	AllowedEmailDomains []string `json:"allowed_email_domains,omitempty"`
+	// 文字起こしの言語（省略時は ja-JP、auto で自動判定）と他に話される可能性のある言語
+	TranscriptionLanguage string   `json:"transcription_language,omitempty"`
+	AlternativeLanguages  []string `json:"alternative_languages,omitempty"`
 }
 
 // CreateRoomResponse 通話ルーム作成レスポンス
@@ -17,24 +24,52 @@ type CreateRoomResponse struct {
 
 // GetRoomResponse 通話ルーム取得レスポンス
 type GetRoomResponse struct {
-	RoomID       string            `json:"room_id"`
-	Name         string            `json:"name"`
-	Status       string            `json:"status"`
-	Participants []ParticipantInfo `json:"participants"`
-	StartedAt    *time.Time        `json:"started_at,omitempty"`
+	RoomID                string            `json:"room_id"`
+	Name                  string            `json:"name"`
+	Status                string            `json:"status"`
+	Visibility            string            `json:"visibility"`
+	HasPasscode           bool              `json:"has_passcode"`
+	TranscriptionLanguage string            `json:"transcription_language"`
+	AlternativeLanguages  []string          `json:"alternative_languages"`
+	Participants          []ParticipantInfo `json:"participants"`
+	StartedAt             *time.Time        `json:"started_at,omitempty"`
 }
 
 // ParticipantInfo 参加者情報
 type ParticipantInfo struct {
-	UserID   int64     `json:"user_id"`
-	Name     string    `json:"name,omitempty"`
-	JoinedAt time.Time `json:"joined_at"`
+	ParticipantID int64     `json:"participant_id"`
+	UserID        int64     `json:"user_id"`
+	GuestID       string    `json:"guest_id,omitempty"`
+	Name          string    `json:"name,omitempty"`
+	Role          string    `json:"role"`
+	JoinedAt      time.Time `json:"joined_at"`
+}
+
+// JoinRoomRequest 通話ルーム参加リクエスト
+type JoinRoomRequest struct {
+	Passcode string `json:"passcode,omitempty"`
 }
 
 // JoinRoomResponse 通話ルーム参加レスポンス
 type JoinRoomResponse struct {
-	Success       bool  `json:"success"`
-	ParticipantID int64 `json:"participant_id"`
+	Success       bool   `json:"success"`
+	ParticipantID int64  `json:"participant_id"`
+	Role          string `json:"role"`
+}
+
+// ChangeRoleRequest 参加者のロール変更リクエスト
+type ChangeRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// ChangeRoleResponse 参加者のロール変更レスポンス（ホスト移譲時は元のホストも含む）
+type ChangeRoleResponse struct {
+	Participants []ParticipantInfo `json:"participants"`
+}
+
+// KickParticipantResponse 参加者の強制退出レスポンス
+type KickParticipantResponse struct {
+	Success bool `json:"success"`
 }
 
 // LeaveRoomResponse 通話ルーム退出レスポンス
@@ -48,16 +83,99 @@ type UploadRecordingResponse struct {
 	FilePath    string `json:"file_path"`
 }
 
-// TranscribeResponse 文字起こしレスポンス
-type TranscribeResponse struct {
-	Success bool   `json:"success"`
-	Status  string `json:"status"`
-}
-
 // GetMinutesResponse 議事録取得レスポンス
 type GetMinutesResponse struct {
 	Title        string    `json:"title"`
 	Participants []string  `json:"participants"`
 	Transcript   string    `json:"transcript"`
+	PollResults  string    `json:"poll_results,omitempty"` // 通話中に締め切った投票の結果
+	Notes        string    `json:"notes,omitempty"`        // 通話終了時点の共有ノート
+	Languages    []string  `json:"languages"`              // 文字起こしで話された言語（多い順）
+	RunID        *int64    `json:"run_id,omitempty"`       // 作成に使った文字起こしの実行（ルームの現在の実行）
 	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"` // 再実行・実行の切り替えで作り直した時刻
+}
+
+// CreateInviteRequest 招待リンク作成リクエスト
+type CreateInviteRequest struct {
+	ExpiresInMinutes int `json:"expires_in_minutes"`
+}
+
+// CreateInviteResponse 招待リンク作成レスポンス
+type CreateInviteResponse struct {
+	Token     string    `json:"token"`
+	InviteURL string    `json:"invite_url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// InviteUserRequest 登録ユーザーの招待リクエスト
+type InviteUserRequest struct {
+	UserID int64 `json:"user_id"`
+}
+
+// AcceptInviteRequest 招待受諾リクエスト
+type AcceptInviteRequest struct {
+	Token       string `json:"token"`
+	DisplayName string `json:"display_name"`
+}
+
+// AcceptInviteResponse 招待受諾レスポンス
+type AcceptInviteResponse struct {
+	AccessToken string    `json:"access_token"`
+	RoomID      string    `json:"room_id"`
+	GuestID     string    `json:"guest_id"`
+	DisplayName string    `json:"display_name"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// RoomAccessRequest ルームのアクセス設定更新リクエスト
+// passcodeは省略時は変更なし、空文字でパスコード解除
+type RoomAccessRequest struct {
+	Visibility          string   `json:"visibility"`
+	Passcode            *string  `json:"passcode,omitempty"`
+	AllowedUserIDs      []int64  `json:"allowed_user_ids"`
+	AllowedEmailDomains []string `json:"allowed_email_domains"`
+}
+
+// RoomAccessResponse ルームのアクセス設定レスポンス
+type RoomAccessResponse struct {
+	Visibility          string   `json:"visibility"`
+	HasPasscode         bool     `json:"has_passcode"`
+	AllowedUserIDs      []int64  `json:"allowed_user_ids"`
+	AllowedEmailDomains []string `json:"allowed_email_domains"`
+}
+
+// TranscriptionSettingsRequest ルームの文字起こしの言語設定更新リクエスト
+// languageは省略時は既定の言語（ja-JP）、auto で alternative_languages の中から話された言語を判定する
+type TranscriptionSettingsRequest struct {
+	Language             string   `json:"language"`
+	AlternativeLanguages []string `json:"alternative_languages"`
+}
+
+// TranscriptionSettingsResponse ルームの文字起こしの言語設定レスポンス
+type TranscriptionSettingsResponse struct {
+	Language             string   `json:"language"`
+	AlternativeLanguages []string `json:"alternative_languages"`
+	AutoDetect           bool     `json:"auto_detect"` // 話された言語を判定するか（auto または候補の言語がある）
+}
+
+// LobbyRoomResponse ロビーのルーム情報
+type LobbyRoomResponse struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	CreatedBy        int64     `json:"createdBy"`
+	CreatedAt        time.Time `json:"createdAt"`
+	MaxParticipants  int       `json:"maxParticipants"`
+	Visibility       string    `json:"visibility"`
+	HasPasscode      bool      `json:"hasPasscode"`
+	IsActive         bool      `json:"isActive"`
+	ParticipantCount int       `json:"participantCount"`
+}
+
+// LobbyMessage ロビーのWebSocketメッセージ
+// 接続直後にsnapshot（rooms）、以降はroom-created/room-updated/participant-count/room-ended（room）を送る
+type LobbyMessage struct {
+	Type  string              `json:"type"`
+	Rooms []LobbyRoomResponse `json:"rooms,omitempty"`
+	Room  *LobbyRoomResponse  `json:"room,omitempty"`
 }
@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// CallHistoryResponse 通話履歴レスポンス
+type CallHistoryResponse struct {
+	Calls  []CallHistoryItem `json:"calls"`
+	Total  int               `json:"total"`
+	Limit  int               `json:"limit"`
+	Offset int               `json:"offset"`
+}
+
+// CallHistoryItem 通話履歴の1件
+type CallHistoryItem struct {
+	RoomID          string                   `json:"room_id"`
+	Name            string                   `json:"name"`
+	Status          string                   `json:"status"`
+	CreatedBy       int64                    `json:"created_by"`
+	StartedAt       *time.Time               `json:"started_at,omitempty"`
+	EndedAt         *time.Time               `json:"ended_at,omitempty"`
+	DurationSeconds int64                    `json:"duration_seconds"`
+	Participants    []CallHistoryParticipant `json:"participants"`
+	HasRecordings   bool                     `json:"has_recordings"`
+	HasMinutes      bool                     `json:"has_minutes"`
+	CreatedAt       time.Time                `json:"created_at"`
+}
+
+// CallHistoryParticipant 通話履歴の参加者
+type CallHistoryParticipant struct {
+	UserID          int64                   `json:"user_id,omitempty"`
+	GuestID         string                  `json:"guest_id,omitempty"`
+	Name            string                  `json:"name"`
+	DurationSeconds int64                   `json:"duration_seconds"`
+	Intervals       []ParticipationInterval `json:"intervals"`
+}
+
+// ParticipationInterval 参加区間
+type ParticipationInterval struct {
+	JoinedAt time.Time  `json:"joined_at"`
+	LeftAt   *time.Time `json:"left_at,omitempty"`
+}
@@ -0,0 +1,35 @@
+package dto
+
+import "time"
+
+// StartDirectCallRequest 1対1通話の発信リクエスト
+type StartDirectCallRequest struct {
+	CalleeID int64 `json:"callee_id"`
+}
+
+// DirectCallResponse 1対1通話レスポンス
+// room_idの通話ルームに参加して通話する
+type DirectCallResponse struct {
+	CallID     string     `json:"call_id"`
+	RoomID     string     `json:"room_id"`
+	CallerID   int64      `json:"caller_id"`
+	CalleeID   int64      `json:"callee_id"`
+	Status     string     `json:"status"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// MissedCallResponse 不在着信レスポンス
+type MissedCallResponse struct {
+	CallID     string    `json:"call_id"`
+	CallerID   int64     `json:"caller_id"`
+	CallerName string    `json:"caller_name"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// UserEventMessage ユーザー単位のWebSocketで配信するメッセージ
+type UserEventMessage struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data,omitempty"`
+}
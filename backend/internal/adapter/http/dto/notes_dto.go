@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+// CallNotesResponse 共有ノートの内容
+// 通話中の編集はシグナリング（notes-sync / notes-op）で同期する
+type CallNotesResponse struct {
+	Content   string     `json:"content"`
+	Version   int64      `json:"version"`              // 保存済みのスナップショットの版数
+	UpdatedAt *time.Time `json:"updated_at,omitempty"` // まだ編集されていない場合は省略
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+// NotificationResponse 通知レスポンス
+type NotificationResponse struct {
+	ID        int64                  `json:"id"`
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Body      string                 `json:"body"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	ReadAt    *time.Time             `json:"read_at,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// NotificationListResponse 通知一覧レスポンス
+type NotificationListResponse struct {
+	Notifications []NotificationResponse `json:"notifications"`
+	UnreadCount   int                    `json:"unread_count"`
+	// 次のページを取得する場合のbeforeパラメータ（最後のページでは省略）
+	NextBefore int64 `json:"next_before,omitempty"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+// PersonalRoomResponse 個人ルームレスポンス
+type PersonalRoomResponse struct {
+	Slug            string             `json:"slug"`
+	Name            string             `json:"name"`
+	MaxParticipants int                `json:"max_participants"`
+	Access          RoomAccessResponse `json:"access"`
+}
+
+// UpdatePersonalRoomRequest 個人ルーム更新リクエスト（省略した項目は変更しない）
+// accessはセッションのアクセス設定（進行中と以降のセッションに適用）
+type UpdatePersonalRoomRequest struct {
+	Slug            *string            `json:"slug,omitempty"`
+	Name            *string            `json:"name,omitempty"`
+	MaxParticipants *int               `json:"max_participants,omitempty"`
+	Access          *RoomAccessRequest `json:"access,omitempty"`
+}
+
+// PersonalRoomSessionResponse スラッグで開いた個人ルームと進行中のセッション
+// セッションがない場合はroom_idを省略する
+type PersonalRoomSessionResponse struct {
+	Slug          string `json:"slug"`
+	Name          string `json:"name"`
+	OwnerID       int64  `json:"owner_id"`
+	RoomID        string `json:"room_id,omitempty"`
+	Status        string `json:"status,omitempty"`
+	SessionNumber int    `json:"session_number,omitempty"`
+}
+
+// SessionInfo 個人ルームのセッション情報
+type SessionInfo struct {
+	RoomID        string     `json:"room_id"`
+	SessionNumber int        `json:"session_number"`
+	Status        string     `json:"status"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	EndedAt       *time.Time `json:"ended_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
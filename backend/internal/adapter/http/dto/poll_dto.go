@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+// CreatePollRequest 投票作成リクエスト
+type CreatePollRequest struct {
+	Question       string   `json:"question"`
+	Options        []string `json:"options"`
+	MultipleChoice bool     `json:"multiple_choice"`
+	Anonymous      bool     `json:"anonymous"`
+}
+
+// VotePollRequest 投票リクエスト（単一選択の場合は1つだけ指定する）
+type VotePollRequest struct {
+	OptionIDs []int64 `json:"option_ids"`
+}
+
+// PollOptionResponse 選択肢ごとの集計結果
+type PollOptionResponse struct {
+	ID     int64    `json:"id"`
+	Text   string   `json:"text"`
+	Votes  int      `json:"votes"`
+	Voters []string `json:"voters,omitempty"` // 匿名投票では省略
+}
+
+// PollResponse 投票と集計結果
+type PollResponse struct {
+	ID             int64                `json:"id"`
+	Question       string               `json:"question"`
+	MultipleChoice bool                 `json:"multiple_choice"`
+	Anonymous      bool                 `json:"anonymous"`
+	Status         string               `json:"status"`
+	TotalVoters    int                  `json:"total_voters"`
+	Options        []PollOptionResponse `json:"options"`
+	// 自分が選んだ選択肢（シグナリングで配信する結果には含まない）
+	MyChoices []int64    `json:"my_choices,omitempty"`
+	ClosedAt  *time.Time `json:"closed_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// PollListResponse 投票一覧レスポンス
+type PollListResponse struct {
+	Polls []PollResponse `json:"polls"`
+}
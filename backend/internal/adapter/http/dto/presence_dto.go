@@ -0,0 +1,15 @@
+package dto
+
+import "time"
+
+// PresenceResponse 在席状態レスポンス
+type PresenceResponse struct {
+	UserID     int64      `json:"user_id"`
+	Status     string     `json:"status"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+}
+
+// UpdatePresenceRequest 自分の在席状態の設定リクエスト（online/away/dnd）
+type UpdatePresenceRequest struct {
+	Status string `json:"status"`
+}
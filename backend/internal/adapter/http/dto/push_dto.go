@@ -0,0 +1,23 @@
+package dto
+
+// PushPublicKeyResponse VAPIDの公開鍵レスポンス
+type PushPublicKeyResponse struct {
+	PublicKey string `json:"public_key"`
+}
+
+// PushSubscriptionKeys ブラウザの購読の鍵（PushSubscription.toJSON() の keys）
+type PushSubscriptionKeys struct {
+	P256dh string `json:"p256dh"`
+	Auth   string `json:"auth"`
+}
+
+// PushSubscriptionRequest 購読登録リクエスト（PushSubscription.toJSON() の形式）
+type PushSubscriptionRequest struct {
+	Endpoint string               `json:"endpoint"`
+	Keys     PushSubscriptionKeys `json:"keys"`
+}
+
+// UnsubscribePushRequest 購読解除リクエスト
+type UnsubscribePushRequest struct {
+	Endpoint string `json:"endpoint"`
+}
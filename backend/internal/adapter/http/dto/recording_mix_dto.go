@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// RecordingMixResponse 参加者ごとの録音を1つにまとめたミックス
+// 進捗は /ws/user の recording-mix イベントでも配信する
+type RecordingMixResponse struct {
+	ID                 int64                   `json:"id"`
+	Status             string                  `json:"status"` // queued / running / succeeded / failed
+	Attempts           int                     `json:"attempts"`
+	Format             string                  `json:"format"`
+	DownloadURL        *string                 `json:"download_url,omitempty"` // 成功した場合のみ（署名付きURL）
+	FileSize           *int64                  `json:"file_size,omitempty"`
+	RecordingStartedAt *time.Time              `json:"recording_started_at,omitempty"` // ミックスの先頭の時刻
+	Tracks             []RecordingMixTrackInfo `json:"tracks"`
+	LastError          *string                 `json:"last_error,omitempty"`
+	FinishedAt         *time.Time              `json:"finished_at,omitempty"`
+	CreatedAt          time.Time               `json:"created_at"`
+	UpdatedAt          time.Time               `json:"updated_at"`
+}
+
+// RecordingMixTrackInfo ミックスした録音
+type RecordingMixTrackInfo struct {
+	RecordingID   int64   `json:"recording_id"`
+	UserID        int64   `json:"user_id"`
+	OffsetMs      int64   `json:"offset_ms"`                // ミックスの先頭から再生を始める位置
+	NormalizedURL *string `json:"normalized_url,omitempty"` // 音量を正規化したコピー（署名付きURL）
+}
@@ -0,0 +1,24 @@
+package dto
+
+import "time"
+
+// CreateRecordingSlotRequest 直接アップロードの枠の作成リクエスト
+type CreateRecordingSlotRequest struct {
+	Size            int64      `json:"size"`         // アップロードするサイズ (bytes)
+	ContentType     string     `json:"content_type"` // audio/webm など
+	DurationSeconds *int       `json:"duration_seconds,omitempty"`
+	StartedAt       *time.Time `json:"started_at,omitempty"` // 録音を開始した時刻
+}
+
+// RecordingSlotResponse 直接アップロードの枠
+// クライアントは upload_url へ method・headers のとおりに録音を送信し、finalize_url へ完了を通知する
+type RecordingSlotResponse struct {
+	SlotID      string            `json:"slot_id"`
+	UploadURL   string            `json:"upload_url"`
+	Method      string            `json:"method"`
+	Headers     map[string]string `json:"headers"`
+	ObjectKey   string            `json:"object_key"`
+	Size        int64             `json:"size"`
+	FinalizeURL string            `json:"finalize_url"`
+	ExpiresAt   time.Time         `json:"expires_at"` // 署名付きURLの期限
+}
@@ -0,0 +1,47 @@
+package dto
+
+import "time"
+
+// CreateSeriesRequest 定例会議シリーズ作成リクエスト
+type CreateSeriesRequest struct {
+	Name            string      `json:"name"`
+	RRule           string      `json:"rrule"`
+	StartAt         time.Time   `json:"start_at"`
+	DurationMinutes int         `json:"duration_minutes"`
+	Timezone        string      `json:"timezone"`
+	MaxParticipants int         `json:"max_participants"`
+	ExDates         []time.Time `json:"exdates,omitempty"`
+}
+
+// SeriesResponse 定例会議シリーズレスポンス
+type SeriesResponse struct {
+	SeriesID        string    `json:"series_id"`
+	Name            string    `json:"name"`
+	RRule           string    `json:"rrule"`
+	StartAt         time.Time `json:"start_at"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Timezone        string    `json:"timezone"`
+	MaxParticipants int       `json:"max_participants"`
+	CreatedBy       int64     `json:"created_by"`
+}
+
+// OccurrenceResponse 開催回レスポンス
+type OccurrenceResponse struct {
+	OccurrenceID  string    `json:"occurrence_id"`
+	OriginalStart time.Time `json:"original_start"`
+	StartAt       time.Time `json:"start_at"`
+	EndAt         time.Time `json:"end_at"`
+	Name          string    `json:"name"`
+	RoomID        string    `json:"room_id,omitempty"`
+	Status        string    `json:"status,omitempty"`
+}
+
+// EditOccurrenceRequest 開催回編集リクエスト
+type EditOccurrenceRequest struct {
+	Scope           string     `json:"scope"` // this, following, all
+	Name            *string    `json:"name,omitempty"`
+	StartAt         *time.Time `json:"start_at,omitempty"`
+	DurationMinutes *int       `json:"duration_minutes,omitempty"`
+	RRule           *string    `json:"rrule,omitempty"`
+	Cancelled       *bool      `json:"cancelled,omitempty"`
+}
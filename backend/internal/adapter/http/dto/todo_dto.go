@@ -8,6 +8,7 @@ import (
 // TodoResponse はTodoのレスポンスDTO
 type TodoResponse struct {
 	ID          int       `json:"id"`
+	AssigneeID  *int64    `json:"assignee_id"`
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
 	Completed   bool      `json:"completed"`
@@ -19,6 +20,7 @@ type TodoResponse struct {
 type CreateTodoRequest struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
+	AssigneeID  *int64 `json:"assignee_id,omitempty"`
 }
 
 // UpdateTodoRequest はTodo更新時のリクエストDTO
@@ -26,6 +28,7 @@ type UpdateTodoRequest struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
 	Completed   *bool   `json:"completed,omitempty"`
+	AssigneeID  *int64  `json:"assignee_id,omitempty"` // 0で割り当てを解除
 }
 
 // ToEntity はDTOからエンティティに変換する
@@ -37,6 +40,7 @@ func (r *CreateTodoRequest) ToEntity(userID int64) *entity.Todo {
 func FromEntity(todo *entity.Todo) *TodoResponse {
 	return &TodoResponse{
 		ID:          todo.ID,
+		AssigneeID:  todo.AssigneeID,
 		Title:       todo.Title,
 		Description: todo.Description,
 		Completed:   todo.Completed,
@@ -0,0 +1,79 @@
+package dto
+
+import "time"
+
+// TranscriptionJobResponse 文字起こしジョブ
+// 進捗は /ws/user の transcription-job イベントでも配信する
+type TranscriptionJobResponse struct {
+	ID            int64                         `json:"id"`
+	Status        string                        `json:"status"` // queued / running / succeeded / failed
+	Attempts      int                           `json:"attempts"`
+	Progress      TranscriptionProgress         `json:"progress"`
+	Recordings    []TranscriptionRecordingState `json:"recordings"`
+	LastError     *string                       `json:"last_error,omitempty"`
+	MinutesID     *int64                        `json:"minutes_id,omitempty"`      // 成功した場合のみ
+	NextAttemptAt *time.Time                    `json:"next_attempt_at,omitempty"` // 再試行待ちの場合の実行予定時刻
+	StartedAt     *time.Time                    `json:"started_at,omitempty"`
+	FinishedAt    *time.Time                    `json:"finished_at,omitempty"`
+	CreatedAt     time.Time                     `json:"created_at"`
+	UpdatedAt     time.Time                     `json:"updated_at"`
+}
+
+// TranscriptionProgress 処理を終えた録音の数
+type TranscriptionProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+// TranscriptionRecordingState 録音ごとの進捗
+type TranscriptionRecordingState struct {
+	RecordingID int64   `json:"recording_id"`
+	Status      string  `json:"status"` // pending / running / done / skipped / failed
+	Segments    int     `json:"segments"`
+	Error       *string `json:"error,omitempty"`
+}
+
+// TranscriptionJobListResponse 文字起こしジョブ一覧（新しい順）
+type TranscriptionJobListResponse struct {
+	Jobs []TranscriptionJobResponse `json:"jobs"`
+}
+
+// TranscriptionRunResponse 文字起こしの実行（ルームの録音を1回文字起こしした結果）
+type TranscriptionRunResponse struct {
+	ID                   int64      `json:"id"`
+	JobID                *int64     `json:"job_id,omitempty"` // 文字起こしジョブから実行した場合
+	Engine               string     `json:"engine"`           // google / whisper
+	Language             string     `json:"language"`         // 実行時のルームの文字起こしの言語（auto は自動判定）
+	AlternativeLanguages []string   `json:"alternative_languages"`
+	Status               string     `json:"status"`     // running / succeeded / failed
+	IsCurrent            bool       `json:"is_current"` // 議事録の作成に使っている実行か
+	Segments             int        `json:"segments"`
+	Recordings           int        `json:"recordings"` // 文字起こしした録音の数
+	Error                *string    `json:"error,omitempty"`
+	StartedAt            time.Time  `json:"started_at"`
+	FinishedAt           *time.Time `json:"finished_at,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+}
+
+// TranscriptionRunListResponse 文字起こしの実行一覧（新しい順）
+type TranscriptionRunListResponse struct {
+	Runs []TranscriptionRunResponse `json:"runs"`
+}
+
+// TranscriptionSegmentResponse 文字起こしの1区間
+type TranscriptionSegmentResponse struct {
+	ID          int64      `json:"id"`
+	RecordingID *int64     `json:"recording_id,omitempty"`
+	UserID      *int64     `json:"user_id,omitempty"` // 録音した参加者
+	Text        string     `json:"text"`
+	Confidence  *float64   `json:"confidence,omitempty"`
+	Language    string     `json:"language"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+}
+
+// TranscriptionRunDetailResponse 文字起こしの実行とその区間（実行どうしの比較に使う）
+type TranscriptionRunDetailResponse struct {
+	TranscriptionRunResponse
+	Transcriptions []TranscriptionSegmentResponse `json:"transcriptions"`
+}
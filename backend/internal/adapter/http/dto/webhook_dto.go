@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CreateWebhookRequest Webhook登録リクエスト
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+}
+
+// UpdateWebhookRequest Webhook更新リクエスト（省略した項目は変更しない）
+type UpdateWebhookRequest struct {
+	URL          *string  `json:"url,omitempty"`
+	Events       []string `json:"events,omitempty"`
+	IsActive     *bool    `json:"is_active,omitempty"`
+	RotateSecret bool     `json:"rotate_secret,omitempty"`
+}
+
+// WebhookResponse Webhookレスポンス
+// シークレットは登録時と再生成時のみ返す
+type WebhookResponse struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Events    []string  `json:"events"`
+	IsActive  bool      `json:"is_active"`
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookDeliveryResponse Webhook配信記録レスポンス
+type WebhookDeliveryResponse struct {
+	ID             int64           `json:"id"`
+	EventID        string          `json:"event_id"`
+	Event          string          `json:"event"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *time.Time      `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	ReplayOf       *int64          `json:"replay_of,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
@@ -2,7 +2,9 @@ package handler
 
 import (
 	"encoding/json"
+	"net"
 	"net/http"
+	"strings"
 
 	"Go-Next-WebRTC/internal/adapter/http/dto"
 	"Go-Next-WebRTC/internal/adapter/http/middleware"
@@ -75,7 +77,8 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokens, err := h.authUseCase.Login(r.Context(), req.Email, req.Password)
+	device := &entity.LoginDevice{UserAgent: r.UserAgent(), IPAddress: clientIP(r)}
+	tokens, err := h.authUseCase.Login(r.Context(), req.Email, req.Password, device)
 	if err != nil {
 		switch err {
 		case entity.ErrInvalidCredentials:
@@ -368,6 +371,19 @@ func (h *AuthHandler) getUserIDFromContext(r *http.Request) int64 {
 	return userID
 }
 
+// clientIP リクエスト元のIPアドレス（プロキシ経由の場合はX-Forwarded-Forの先頭）
+// 新しい端末からのログイン通知に表示するだけなので、ヘッダーの偽装は考慮しない
+func clientIP(r *http.Request) string {
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		return strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 func (h *AuthHandler) respondWithError(w http.ResponseWriter, code int, message string, details map[string]string) {
 	h.respondWithJSON(w, code, dto.ErrorResponse{
 		Error:   message,
@@ -3,6 +3,7 @@ package handler
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -16,6 +17,7 @@ import (
 	"Go-Next-WebRTC/internal/adapter/websocket"
 	"Go-Next-WebRTC/internal/application/usecase"
 	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
 	"Go-Next-WebRTC/pkg/jwt"
 )
 
@@ -23,6 +25,7 @@ import (
 type CallHandler struct {
 	callUsecase       usecase.CallUsecase
 	recordingUsecase  usecase.RecordingUsecase
+	inviteUsecase     usecase.InviteUsecase
 	signalingServer   *websocket.SignalingServer
 	jwtService        *jwt.Service
 }
@@ -31,12 +34,14 @@ type CallHandler struct {
 func NewCallHandler(
 	callUsecase usecase.CallUsecase,
 	recordingUsecase usecase.RecordingUsecase,
+	inviteUsecase usecase.InviteUsecase,
 	signalingServer *websocket.SignalingServer,
 	jwtService *jwt.Service,
 ) *CallHandler {
 	return &CallHandler{
 		callUsecase:      callUsecase,
 		recordingUsecase: recordingUsecase,
+		inviteUsecase:    inviteUsecase,
 		signalingServer:  signalingServer,
 		jwtService:       jwtService,
 	}
@@ -78,10 +83,33 @@ func (h *CallHandler) CreateRoom(w http.ResponseWriter, r *http.Request) {
 		MaxParticipants: req.MaxParticipants,
 	}
 
+	settings := &entity.RoomAccessSettings{
+		Visibility:          entity.RoomVisibility(req.Visibility),
+		AllowedUserIDs:      req.AllowedUserIDs,
+		AllowedEmailDomains: req.AllowedEmailDomains,
+	}
+	if req.Passcode != "" {
+		settings.Passcode = &req.Passcode
+	}
+
+	// 文字起こしの言語設定（省略時は既定の言語）
+	languageSettings := &entity.TranscriptionLanguageSettings{
+		Language:             req.TranscriptionLanguage,
+		AlternativeLanguages: req.AlternativeLanguages,
+	}
+	if err := languageSettings.Apply(room); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := h.callUsecase.CreateRoom(ctx, room); err != nil {
+	if err := h.callUsecase.CreateRoom(ctx, room, settings); err != nil {
+		if isRoomAccessSettingsError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		slog.Error("Failed to create room", slog.String("error", err.Error()))
 		http.Error(w, "Failed to create room", http.StatusInternalServerError)
 		return
@@ -108,33 +136,18 @@ func (h *CallHandler) GetActiveRooms(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// アクティブなルームを取得（status='active'または'waiting'）
-	rooms, err := h.callUsecase.GetActiveRooms(ctx)
+	// 表示可能なアクティブルームを取得（status='active'または'waiting'）
+	rooms, err := h.callUsecase.GetVisibleRooms(ctx, principalFromContext(r.Context()))
 	if err != nil {
 		slog.Error("Failed to get active rooms", slog.String("error", err.Error()))
 		http.Error(w, "Failed to get rooms", http.StatusInternalServerError)
 		return
 	}
 
-	// レスポンスを構築
-	response := make([]map[string]interface{}, len(rooms))
-	for i, room := range rooms {
-		// 参加者数を取得
-		participants, err := h.callUsecase.GetActiveParticipants(ctx, room.ID)
-		participantCount := 0
-		if err == nil {
-			participantCount = len(participants)
-		}
-
-		response[i] = map[string]interface{}{
-			"id":               room.RoomID,
-			"name":             room.Name,
-			"createdBy":        room.CreatedBy,
-			"createdAt":        room.CreatedAt,
-			"maxParticipants":  room.MaxParticipants,
-			"isActive":         room.Status == entity.CallRoomStatusActive,
-			"participantCount": participantCount,
-		}
+	// 参加者数は一覧の取得時にまとめて集計済み
+	response := make([]dto.LobbyRoomResponse, len(rooms))
+	for i, lobbyRoom := range rooms {
+		response[i] = toLobbyRoomResponse(lobbyRoom)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -156,6 +169,12 @@ func (h *CallHandler) GetRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// アクセス権を確認（パスコードはヘッダーで受け取る）
+	err = h.callUsecase.CheckRoomAccess(ctx, room, principalFromContext(r.Context()), r.Header.Get("X-Room-Passcode"))
+	if writeRoomAccessError(w, err) {
+		return
+	}
+
 	// 参加者情報を取得
 	participants, err := h.callUsecase.GetActiveParticipants(ctx, room.ID)
 	if err != nil {
@@ -165,18 +184,19 @@ func (h *CallHandler) GetRoom(w http.ResponseWriter, r *http.Request) {
 	}
 
 	resp := dto.GetRoomResponse{
-		RoomID:       room.RoomID,
-		Name:         room.Name,
-		Status:       string(room.Status),
-		Participants: make([]dto.ParticipantInfo, len(participants)),
-		StartedAt:    room.StartedAt,
+		RoomID:                room.RoomID,
+		Name:                  room.Name,
+		Status:                string(room.Status),
+		Visibility:            string(room.Visibility),
+		HasPasscode:           room.HasPasscode(),
+		TranscriptionLanguage: room.TranscriptionLanguageOrDefault(),
+		AlternativeLanguages:  nonNilStrings(room.TranscriptionAlternativeLanguages),
+		Participants:          make([]dto.ParticipantInfo, len(participants)),
+		StartedAt:             room.StartedAt,
 	}
 
 	for i, p := range participants {
-		resp.Participants[i] = dto.ParticipantInfo{
-			UserID:   p.UserID,
-			JoinedAt: p.JoinedAt,
-		}
+		resp.Participants[i] = toParticipantInfo(p)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -206,18 +226,14 @@ func (h *CallHandler) DeleteRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// ルームの作成者のみが削除可能
-	if room.CreatedBy != userID {
-		http.Error(w, "Forbidden: only room creator can delete", http.StatusForbidden)
+	// 通話を終了する権限があるか確認
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionEndCall)
+	if writeRoleError(w, err) {
 		return
 	}
 
-	// ルームのステータスを終了に変更
-	room.Status = entity.CallRoomStatusEnded
-	now := time.Now()
-	room.EndedAt = &now
-
-	if err := h.callUsecase.UpdateRoomStatus(ctx, room); err != nil {
+	// ルームのステータスを終了に変更（参加中の参加者も退出済みにする）
+	if err := h.callUsecase.EndRoom(ctx, room); err != nil {
 		slog.Error("Failed to delete room", slog.String("error", err.Error()))
 		http.Error(w, "Failed to delete room", http.StatusInternalServerError)
 		return
@@ -239,15 +255,24 @@ func (h *CallHandler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 		slog.String("room_id", roomID),
 		slog.String("path", r.URL.Path))
 
-	// ユーザーIDをコンテキストから取得
-	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	// 参加者（ユーザーまたはゲスト）をコンテキストから取得
+	participant, ok := participantFromContext(r.Context())
 	if !ok {
 		slog.Error("Failed to get user ID from context")
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	slog.Info("User authenticated", slog.Int64("user_id", userID))
+	slog.Info("User authenticated", slog.Int64("user_id", participant.UserID))
+
+	// ボディは省略可能（パスコード付きルームの場合のみ必要）
+	var req dto.JoinRoomRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -273,13 +298,15 @@ func (h *CallHandler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 参加者を追加
-	participant := &entity.CallParticipant{
-		RoomID:   room.ID,
-		UserID:   userID,
-		IsActive: true,
+	// アクセス権を確認
+	err = h.callUsecase.CheckRoomAccess(ctx, room, principalFromContext(r.Context()), req.Passcode)
+	if writeRoomAccessError(w, err) {
+		return
 	}
 
+	// 参加者を追加
+	participant.RoomID = room.ID
+
 	if err := h.callUsecase.JoinRoom(ctx, participant); err != nil {
 		slog.Error("Failed to join room", slog.String("error", err.Error()))
 		http.Error(w, "Failed to join room", http.StatusInternalServerError)
@@ -288,10 +315,7 @@ func (h *CallHandler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 
 	// ルームをアクティブに変更（まだwaitingの場合）
 	if room.Status == entity.CallRoomStatusWaiting {
-		now := time.Now()
-		room.Status = entity.CallRoomStatusActive
-		room.StartedAt = &now
-		if err := h.callUsecase.UpdateRoomStatus(ctx, room); err != nil {
+		if err := h.callUsecase.StartRoom(ctx, room); err != nil {
 			slog.Error("Failed to update room status", slog.String("error", err.Error()))
 		}
 	}
@@ -299,6 +323,7 @@ func (h *CallHandler) JoinRoom(w http.ResponseWriter, r *http.Request) {
 	resp := dto.JoinRoomResponse{
 		Success:       true,
 		ParticipantID: participant.ID,
+		Role:          string(participant.Role),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -311,8 +336,8 @@ func (h *CallHandler) LeaveRoom(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
 	roomID := strings.TrimSuffix(path, "/leave")
 
-	// ユーザーIDをコンテキストから取得
-	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	// 参加者（ユーザーまたはゲスト）をコンテキストから取得
+	participant, ok := participantFromContext(r.Context())
 	if !ok {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
@@ -329,7 +354,12 @@ func (h *CallHandler) LeaveRoom(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.callUsecase.LeaveRoom(ctx, room.ID, userID); err != nil {
+	if participant.IsGuest() {
+		err = h.callUsecase.LeaveRoomAsGuest(ctx, room.ID, *participant.GuestID)
+	} else {
+		err = h.callUsecase.LeaveRoom(ctx, room.ID, participant.UserID)
+	}
+	if err != nil {
 		slog.Error("Failed to leave room", slog.String("error", err.Error()))
 		http.Error(w, "Failed to leave room", http.StatusInternalServerError)
 		return
@@ -356,15 +386,21 @@ func (h *CallHandler) HandleSignaling(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// トークンを検証
-	claims, err := h.jwtService.ValidateAccessToken(tokenString)
+	// トークンを検証（ユーザートークン、だめならゲストトークン）
+	principal, err := principalFromToken(h.jwtService, h.inviteUsecase, tokenString)
 	if err != nil {
 		slog.Error("Token validation failed", slog.String("error", err.Error()))
 		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
 		return
 	}
-
-	userID := claims.UserID
+	var userID int64
+	var clientID string
+	if principal.Guest != nil {
+		clientID = "guest-" + principal.Guest.GuestID
+	} else {
+		userID = principal.UserID
+		clientID = "user-" + strconv.FormatInt(userID, 10)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -383,11 +419,20 @@ func (h *CallHandler) HandleSignaling(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// クライアントIDを生成
-	clientID := "user-" + strconv.FormatInt(userID, 10)
+	// アクセス権を確認（パスコード付きルームは事前にJoinRoomで参加している必要がある）
+	err = h.callUsecase.CheckRoomAccess(ctx, room, principal, "")
+	if writeRoomAccessError(w, err) {
+		return
+	}
+
+	// 画面共有などの権限判定に使うロールを取得
+	role, err := h.callUsecase.GetRole(ctx, room, principal)
+	if writeRoleError(w, err) {
+		return
+	}
 
 	// WebSocket接続を処理
-	h.signalingServer.HandleWebSocket(w, r, roomID, clientID, userID)
+	h.signalingServer.HandleWebSocket(w, r, roomID, clientID, userID, role)
 }
 
 // UploadRecording 録音ファイルをアップロード
@@ -414,6 +459,12 @@ func (h *CallHandler) UploadRecording(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// 録音する権限があるか確認
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionRecord)
+	if writeRoleError(w, err) {
+		return
+	}
+
 	// ファイルサイズ制限チェック (100MB)
 	r.Body = http.MaxBytesReader(w, r.Body, 100*1024*1024)
 
@@ -443,6 +494,8 @@ func (h *CallHandler) UploadRecording(w http.ResponseWriter, r *http.Request) {
 			duration = &d
 		}
 	}
+	// 録音を開始した時刻（オプション・RFC 3339）
+	startedAt := parseStartedAt(r.FormValue("started_at"))
 
 	slog.Info("Recording upload started",
 		slog.String("room_id", roomID),
@@ -452,7 +505,7 @@ func (h *CallHandler) UploadRecording(w http.ResponseWriter, r *http.Request) {
 	)
 
 	// 録音をアップロード
-	recording, err := h.recordingUsecase.UploadRecording(ctx, room.ID, userID, file, header.Size, duration)
+	recording, err := h.recordingUsecase.UploadRecording(ctx, room.ID, userID, file, header.Size, duration, startedAt)
 	if err != nil {
 		slog.Error("Failed to upload recording", slog.String("error", err.Error()))
 		http.Error(w, "Failed to upload recording", http.StatusInternalServerError)
@@ -468,13 +521,13 @@ func (h *CallHandler) UploadRecording(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(resp)
 }
 
-// TranscribeCall 通話を文字起こし
-func (h *CallHandler) TranscribeCall(w http.ResponseWriter, r *http.Request) {
+// GetMinutes 議事録を取得
+func (h *CallHandler) GetMinutes(w http.ResponseWriter, r *http.Request) {
 	// URLからroom_idを取得
 	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
-	roomID := strings.TrimSuffix(path, "/transcribe")
+	roomID := strings.TrimSuffix(path, "/minutes")
 
-	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*15)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	// ルーム存在確認
@@ -485,34 +538,59 @@ func (h *CallHandler) TranscribeCall(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	slog.Info("Transcription started", slog.String("room_id", roomID))
+	// 議事録を閲覧する権限があるか確認
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionReadMinutes)
+	if writeRoleError(w, err) {
+		return
+	}
 
-	// 文字起こし実行
-	if err := h.recordingUsecase.TranscribeAndCreateMinutes(ctx, room.ID); err != nil {
-		slog.Error("Failed to transcribe", slog.String("error", err.Error()))
-		http.Error(w, "Failed to transcribe", http.StatusInternalServerError)
+	// 議事録を取得
+	minutes, err := h.recordingUsecase.GetMinutes(ctx, room.ID)
+	if err != nil {
+		slog.Error("Failed to get minutes", slog.String("error", err.Error()))
+		http.Error(w, "Minutes not found", http.StatusNotFound)
 		return
 	}
 
-	resp := dto.TranscribeResponse{
-		Success: true,
-		Status:  "completed",
+	// 参加者情報を取得
+	participants, err := h.callUsecase.GetActiveParticipants(ctx, room.ID)
+	if err != nil {
+		slog.Error("Failed to get participants", slog.String("error", err.Error()))
+	}
+
+	participantNames := make([]string, len(participants))
+	for i, p := range participants {
+		participantNames[i] = "User " + strconv.FormatInt(p.UserID, 10) // TODO: ユーザー名取得
+	}
+
+	resp := dto.GetMinutesResponse{
+		Title:        minutes.Title,
+		Participants: participantNames,
+		Transcript:   *minutes.FullTranscript,
+		Languages:    nonNilStrings(minutes.Languages),
+		RunID:        minutes.RunID,
+		CreatedAt:    minutes.CreatedAt,
+		UpdatedAt:    minutes.UpdatedAt,
+	}
+	if minutes.PollResults != nil {
+		resp.PollResults = *minutes.PollResults
+	}
+	if minutes.Notes != nil {
+		resp.Notes = *minutes.Notes
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
 
-// GetMinutes 議事録を取得
-func (h *CallHandler) GetMinutes(w http.ResponseWriter, r *http.Request) {
-	// URLからroom_idを取得
+// GetRoomAccess ルームのアクセス設定を取得（ホストのみ）
+func (h *CallHandler) GetRoomAccess(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
-	roomID := strings.TrimSuffix(path, "/minutes")
+	roomID := strings.TrimSuffix(path, "/access")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// ルーム存在確認
 	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
 	if err != nil {
 		slog.Error("Failed to get room", slog.String("error", err.Error()))
@@ -520,32 +598,432 @@ func (h *CallHandler) GetMinutes(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 議事録を取得
-	minutes, err := h.recordingUsecase.GetMinutes(ctx, room.ID)
+	// ルームを管理する権限があるか確認
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionManageRoom)
+	if writeRoleError(w, err) {
+		return
+	}
+
+	rules, err := h.callUsecase.GetRoomAccessRules(ctx, room.ID)
 	if err != nil {
-		slog.Error("Failed to get minutes", slog.String("error", err.Error()))
-		http.Error(w, "Minutes not found", http.StatusNotFound)
+		slog.Error("Failed to get access rules", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get access settings", http.StatusInternalServerError)
 		return
 	}
 
-	// 参加者情報を取得
-	participants, err := h.callUsecase.GetActiveParticipants(ctx, room.ID)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toRoomAccessResponse(room, rules))
+}
+
+// UpdateRoomAccess ルームのアクセス設定を更新（ホストのみ）
+func (h *CallHandler) UpdateRoomAccess(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/access")
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.RoomAccessRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
 	if err != nil {
-		slog.Error("Failed to get participants", slog.String("error", err.Error()))
+		slog.Error("Failed to get room", slog.String("error", err.Error()))
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
 	}
 
-	participantNames := make([]string, len(participants))
-	for i, p := range participants {
-		participantNames[i] = "User " + strconv.FormatInt(p.UserID, 10) // TODO: ユーザー名取得
+	// ルームを管理する権限があるか確認
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionManageRoom)
+	if writeRoleError(w, err) {
+		return
 	}
 
-	resp := dto.GetMinutesResponse{
-		Title:        minutes.Title,
-		Participants: participantNames,
-		Transcript:   *minutes.FullTranscript,
-		CreatedAt:    minutes.CreatedAt,
+	settings := &entity.RoomAccessSettings{
+		Visibility:          entity.RoomVisibility(req.Visibility),
+		Passcode:            req.Passcode,
+		AllowedUserIDs:      req.AllowedUserIDs,
+		AllowedEmailDomains: req.AllowedEmailDomains,
 	}
 
+	if err := h.callUsecase.UpdateRoomAccess(ctx, room, settings); err != nil {
+		if isRoomAccessSettingsError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to update access settings", slog.String("error", err.Error()))
+		http.Error(w, "Failed to update access settings", http.StatusInternalServerError)
+		return
+	}
+
+	rules, err := h.callUsecase.GetRoomAccessRules(ctx, room.ID)
+	if err != nil {
+		slog.Error("Failed to get access rules", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get access settings", http.StatusInternalServerError)
+		return
+	}
+
+	slog.Info("Room access updated",
+		slog.String("room_id", roomID),
+		slog.String("visibility", string(room.Visibility)),
+		slog.Int("rules", len(rules)),
+		slog.Int64("user_id", userID))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toRoomAccessResponse(room, rules))
+}
+
+// GetTranscriptionSettings ルームの文字起こしの言語設定を取得（ホストのみ）
+func (h *CallHandler) GetTranscriptionSettings(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/transcription-settings")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Error("Failed to get room", slog.String("error", err.Error()))
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	// ルームを管理する権限があるか確認
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionManageRoom)
+	if writeRoleError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toTranscriptionSettingsResponse(room))
+}
+
+// UpdateTranscriptionSettings ルームの文字起こしの言語設定を更新（ホストのみ）
+func (h *CallHandler) UpdateTranscriptionSettings(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/transcription-settings")
+
+	var req dto.TranscriptionSettingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Error("Failed to get room", slog.String("error", err.Error()))
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	// ルームを管理する権限があるか確認
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionManageRoom)
+	if writeRoleError(w, err) {
+		return
+	}
+
+	settings := &entity.TranscriptionLanguageSettings{
+		Language:             req.Language,
+		AlternativeLanguages: req.AlternativeLanguages,
+	}
+	if err := h.callUsecase.UpdateTranscriptionSettings(ctx, room, settings); err != nil {
+		if errors.Is(err, entity.ErrInvalidTranscriptionLanguage) || errors.Is(err, entity.ErrTooManyAlternativeLanguages) ||
+			errors.Is(err, port.ErrLanguageNotSupported) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to update transcription settings", slog.String("error", err.Error()))
+		http.Error(w, "Failed to update transcription settings", http.StatusInternalServerError)
+		return
+	}
+
+	slog.Info("Room transcription settings updated",
+		slog.String("room_id", roomID),
+		slog.String("language", room.TranscriptionLanguage),
+		slog.Int("alternatives", len(room.TranscriptionAlternativeLanguages)))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toTranscriptionSettingsResponse(room))
+}
+
+// ChangeParticipantRole 参加者のロールを変更（ホスト・共同ホストのみ）
+func (h *CallHandler) ChangeParticipantRole(w http.ResponseWriter, r *http.Request) {
+	// URLからroom_idと参加者IDを取得（/api/calls/rooms/{room_id}/participants/{id}/role）
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID, participantID, ok := parseParticipantPath(strings.TrimSuffix(path, "/role"))
+	if !ok {
+		http.Error(w, "Invalid participant ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.ChangeRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Error("Failed to get room", slog.String("error", err.Error()))
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	changed, err := h.callUsecase.ChangeRole(ctx, room, principalFromContext(r.Context()), participantID, entity.ParticipantRole(req.Role))
+	if writeRoleError(w, err) {
+		return
+	}
+
+	// 通話中の参加者にロール変更を通知
+	resp := dto.ChangeRoleResponse{Participants: make([]dto.ParticipantInfo, len(changed))}
+	for i, p := range changed {
+		h.signalingServer.SetClientRole(room.RoomID, signalingClientID(p), p.Role)
+		resp.Participants[i] = toParticipantInfo(p)
+	}
+
+	slog.Info("Participant role changed",
+		slog.String("room_id", roomID),
+		slog.Int64("participant_id", participantID),
+		slog.String("role", req.Role))
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(resp)
 }
+
+// KickParticipant 参加者を通話から退出させる（ホスト・共同ホストのみ）
+func (h *CallHandler) KickParticipant(w http.ResponseWriter, r *http.Request) {
+	// URLからroom_idと参加者IDを取得（/api/calls/rooms/{room_id}/participants/{id}）
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID, participantID, ok := parseParticipantPath(path)
+	if !ok {
+		http.Error(w, "Invalid participant ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Error("Failed to get room", slog.String("error", err.Error()))
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	kicked, err := h.callUsecase.KickParticipant(ctx, room, principalFromContext(r.Context()), participantID)
+	if writeRoleError(w, err) {
+		return
+	}
+
+	// シグナリング接続を切断
+	h.signalingServer.KickClient(room.RoomID, signalingClientID(kicked))
+
+	slog.Info("Participant kicked",
+		slog.String("room_id", roomID),
+		slog.Int64("participant_id", participantID))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.KickParticipantResponse{Success: true})
+}
+
+// parseParticipantPath "{room_id}/participants/{id}" を分解
+func parseParticipantPath(path string) (string, int64, bool) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[1] != "participants" {
+		return "", 0, false
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return parts[0], id, true
+}
+
+// writeRoleError ロール・権限判定のエラーをレスポンスに書き込む（書き込んだらtrue）
+func writeRoleError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, entity.ErrPermissionDenied):
+		http.Error(w, "Forbidden: your role does not allow this action", http.StatusForbidden)
+	case errors.Is(err, entity.ErrNotRoomMember):
+		http.Error(w, "Forbidden: you are not a member of this room", http.StatusForbidden)
+	case errors.Is(err, entity.ErrParticipantNotFound):
+		http.Error(w, "Participant not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrInvalidRole), errors.Is(err, entity.ErrCannotTargetSelf):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		slog.Error("Failed to check role", slog.String("error", err.Error()))
+		http.Error(w, "Failed to check permission", http.StatusInternalServerError)
+	}
+	return true
+}
+
+// toParticipantInfo 参加記録をレスポンスに変換
+func toParticipantInfo(p *entity.CallParticipant) dto.ParticipantInfo {
+	info := dto.ParticipantInfo{
+		ParticipantID: p.ID,
+		UserID:        p.UserID,
+		Role:          string(p.Role),
+		JoinedAt:      p.JoinedAt,
+	}
+	if p.IsGuest() {
+		info.GuestID = *p.GuestID
+		if p.GuestName != nil {
+			info.Name = *p.GuestName
+		}
+	}
+	return info
+}
+
+// signalingClientID 参加記録に対応するシグナリングのクライアントID
+func signalingClientID(p *entity.CallParticipant) string {
+	if p.IsGuest() {
+		return "guest-" + *p.GuestID
+	}
+	return "user-" + strconv.FormatInt(p.UserID, 10)
+}
+
+// principalFromContext コンテキストの認証情報からアクセス主体を作成
+func principalFromContext(ctx context.Context) *entity.RoomPrincipal {
+	principal := &entity.RoomPrincipal{}
+	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+		principal.UserID = userID
+		principal.Email, _ = middleware.GetUserEmailFromContext(ctx)
+	}
+	if guest, ok := middleware.GetGuestFromContext(ctx); ok {
+		principal.Guest = guest
+	}
+	return principal
+}
+
+// principalFromToken WebSocket接続のトークンから主体を取得（ユーザートークン、だめならゲストトークン）
+func principalFromToken(jwtService *jwt.Service, inviteUsecase usecase.InviteUsecase, tokenString string) (*entity.RoomPrincipal, error) {
+	claims, err := jwtService.ValidateAccessToken(tokenString)
+	if err == nil {
+		return &entity.RoomPrincipal{UserID: claims.UserID, Email: claims.Email}, nil
+	}
+	guest, guestErr := inviteUsecase.ValidateGuestToken(tokenString)
+	if guestErr != nil {
+		return nil, err
+	}
+	return &entity.RoomPrincipal{Guest: guest}, nil
+}
+
+// toLobbyRoomResponse ロビーのルーム情報をレスポンスに変換
+func toLobbyRoomResponse(lobbyRoom *entity.LobbyRoom) dto.LobbyRoomResponse {
+	room := lobbyRoom.Room
+	return dto.LobbyRoomResponse{
+		ID:               room.RoomID,
+		Name:             room.Name,
+		CreatedBy:        room.CreatedBy,
+		CreatedAt:        room.CreatedAt,
+		MaxParticipants:  room.MaxParticipants,
+		Visibility:       string(room.Visibility),
+		HasPasscode:      room.HasPasscode(),
+		IsActive:         room.Status == entity.CallRoomStatusActive,
+		ParticipantCount: lobbyRoom.ParticipantCount,
+	}
+}
+
+// writeRoomAccessError アクセス判定のエラーをレスポンスに書き込む（書き込んだらtrue）
+func writeRoomAccessError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, entity.ErrPasscodeRequired):
+		http.Error(w, "Passcode required", http.StatusUnauthorized)
+	case errors.Is(err, entity.ErrInvalidPasscode):
+		http.Error(w, "Invalid passcode", http.StatusForbidden)
+	case errors.Is(err, entity.ErrRoomAccessDenied):
+		http.Error(w, "Forbidden: you are not allowed to access this room", http.StatusForbidden)
+	default:
+		slog.Error("Failed to check room access", slog.String("error", err.Error()))
+		http.Error(w, "Failed to check room access", http.StatusInternalServerError)
+	}
+	return true
+}
+
+// isRoomAccessSettingsError アクセス設定の入力エラーか判定
+func isRoomAccessSettingsError(err error) bool {
+	return errors.Is(err, entity.ErrInvalidVisibility) ||
+		errors.Is(err, entity.ErrPasscodeLength) ||
+		errors.Is(err, entity.ErrInvalidDomain)
+}
+
+// toTranscriptionSettingsResponse 文字起こしの言語設定をレスポンスに変換
+func toTranscriptionSettingsResponse(room *entity.CallRoom) dto.TranscriptionSettingsResponse {
+	return dto.TranscriptionSettingsResponse{
+		Language:             room.TranscriptionLanguageOrDefault(),
+		AlternativeLanguages: nonNilStrings(room.TranscriptionAlternativeLanguages),
+		AutoDetect:           room.DetectsTranscriptionLanguage(),
+	}
+}
+
+// nonNilStrings JSONで null ではなく空配列にする
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// nonNilInt64s JSONで null ではなく空配列にする
+func nonNilInt64s(values []int64) []int64 {
+	if values == nil {
+		return []int64{}
+	}
+	return values
+}
+
+// toRoomAccessResponse アクセス設定をレスポンスに変換
+func toRoomAccessResponse(room *entity.CallRoom, rules []*entity.CallRoomAccessRule) dto.RoomAccessResponse {
+	resp := dto.RoomAccessResponse{
+		Visibility:          string(room.Visibility),
+		HasPasscode:         room.HasPasscode(),
+		AllowedUserIDs:      []int64{},
+		AllowedEmailDomains: []string{},
+	}
+	for _, rule := range rules {
+		if rule.UserID != nil {
+			resp.AllowedUserIDs = append(resp.AllowedUserIDs, *rule.UserID)
+		}
+		if rule.EmailDomain != nil {
+			resp.AllowedEmailDomains = append(resp.AllowedEmailDomains, *rule.EmailDomain)
+		}
+	}
+	return resp
+}
+
+// participantFromContext コンテキストの認証情報から参加者を作成
+func participantFromContext(ctx context.Context) (*entity.CallParticipant, bool) {
+	if userID, ok := middleware.GetUserIDFromContext(ctx); ok {
+		return &entity.CallParticipant{UserID: userID, IsActive: true}, true
+	}
+	if guest, ok := middleware.GetGuestFromContext(ctx); ok {
+		return &entity.CallParticipant{
+			GuestID:   &guest.GuestID,
+			GuestName: &guest.DisplayName,
+			IsActive:  true,
+		}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// CallHistoryHandler 通話履歴関連のHTTPハンドラー
+type CallHistoryHandler struct {
+	historyUsecase usecase.CallHistoryUsecase
+}
+
+// NewCallHistoryHandler 新しい通話履歴ハンドラーを作成
+func NewCallHistoryHandler(historyUsecase usecase.CallHistoryUsecase) *CallHistoryHandler {
+	return &CallHistoryHandler{historyUsecase: historyUsecase}
+}
+
+// GetHistory 作成または参加した通話の履歴を取得
+// クエリ: scope(all/created/joined), status, from, to, q, limit, offset
+func (h *CallHistoryHandler) GetHistory(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := &entity.CallHistoryFilter{
+		UserID: userID,
+		Scope:  entity.CallHistoryScope(query.Get("scope")),
+		Status: entity.CallRoomStatus(query.Get("status")),
+		Query:  query.Get("q"),
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  **time.Time
+	}{{"from", &filter.From}, {"to", &filter.To}} {
+		v := query.Get(p.name)
+		if v == "" {
+			continue
+		}
+		t, err := parseHistoryTime(v)
+		if err != nil {
+			http.Error(w, "Invalid "+p.name+" parameter", http.StatusBadRequest)
+			return
+		}
+		*p.dst = &t
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"limit", &filter.Limit}, {"offset", &filter.Offset}} {
+		v := query.Get(p.name)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			http.Error(w, "Invalid "+p.name+" parameter", http.StatusBadRequest)
+			return
+		}
+		*p.dst = n
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	page, err := h.historyUsecase.GetHistory(ctx, filter)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidHistoryScope) ||
+			errors.Is(err, entity.ErrInvalidHistoryStatus) ||
+			errors.Is(err, entity.ErrInvalidHistoryRange) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to get call history", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get call history", http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	resp := dto.CallHistoryResponse{
+		Calls:  make([]dto.CallHistoryItem, len(page.Entries)),
+		Total:  page.Total,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	for i, e := range page.Entries {
+		resp.Calls[i] = toCallHistoryItem(e, now)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// parseHistoryTime RFC3339または日付（YYYY-MM-DD）をパース
+func parseHistoryTime(v string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", v)
+}
+
+// toCallHistoryItem 通話履歴をレスポンスに変換
+func toCallHistoryItem(e *entity.CallHistoryEntry, now time.Time) dto.CallHistoryItem {
+	item := dto.CallHistoryItem{
+		RoomID:          e.Room.RoomID,
+		Name:            e.Room.Name,
+		Status:          string(e.Room.Status),
+		CreatedBy:       e.Room.CreatedBy,
+		StartedAt:       e.Room.StartedAt,
+		EndedAt:         e.Room.EndedAt,
+		DurationSeconds: int64(e.Room.Duration(now).Seconds()),
+		Participants:    make([]dto.CallHistoryParticipant, len(e.Participants)),
+		HasRecordings:   e.HasRecordings,
+		HasMinutes:      e.HasMinutes,
+		CreatedAt:       e.Room.CreatedAt,
+	}
+
+	for i, p := range e.Participants {
+		hp := dto.CallHistoryParticipant{
+			UserID:          p.UserID,
+			Name:            p.Name,
+			DurationSeconds: int64(p.TotalDuration(now).Seconds()),
+			Intervals:       make([]dto.ParticipationInterval, len(p.Intervals)),
+		}
+		if p.GuestID != nil {
+			hp.GuestID = *p.GuestID
+		}
+		for j, interval := range p.Intervals {
+			hp.Intervals[j] = dto.ParticipationInterval{
+				JoinedAt: interval.JoinedAt,
+				LeftAt:   interval.LeftAt,
+			}
+		}
+		item.Participants[i] = hp
+	}
+
+	return item
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// DirectCallHandler 1対1通話関連のHTTPハンドラー
+type DirectCallHandler struct {
+	directCallUsecase usecase.DirectCallUsecase
+}
+
+// NewDirectCallHandler 新しい1対1通話ハンドラーを作成
+func NewDirectCallHandler(directCallUsecase usecase.DirectCallUsecase) *DirectCallHandler {
+	return &DirectCallHandler{directCallUsecase: directCallUsecase}
+}
+
+// StartCall 相手を指定して発信
+func (h *DirectCallHandler) StartCall(w http.ResponseWriter, r *http.Request) {
+	var req dto.StartDirectCallRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.CalleeID <= 0 {
+		http.Error(w, "callee_id is required", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	call, room, err := h.directCallUsecase.StartCall(ctx, userID, req.CalleeID)
+	if writeDirectCallError(w, err) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to start direct call", slog.String("error", err.Error()))
+		http.Error(w, "Failed to start call", http.StatusInternalServerError)
+		return
+	}
+
+	slog.Info("Direct call started",
+		slog.String("call_id", call.CallID),
+		slog.Int64("caller_id", call.CallerID),
+		slog.Int64("callee_id", call.CalleeID))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toDirectCallResponse(call, room))
+}
+
+// Accept 着信に応答
+func (h *DirectCallHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	call, room, err := h.directCallUsecase.Accept(ctx, userID, directCallIDFromPath(r.URL.Path, "/accept"))
+	if writeDirectCallError(w, err) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to accept direct call", slog.String("error", err.Error()))
+		http.Error(w, "Failed to accept call", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toDirectCallResponse(call, room))
+}
+
+// Decline 着信を拒否
+func (h *DirectCallHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := h.directCallUsecase.Decline(ctx, userID, directCallIDFromPath(r.URL.Path, "/decline"))
+	if writeDirectCallError(w, err) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to decline direct call", slog.String("error", err.Error()))
+		http.Error(w, "Failed to decline call", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Cancel 発信を取り消し
+func (h *DirectCallHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := h.directCallUsecase.Cancel(ctx, userID, directCallIDFromPath(r.URL.Path, "/cancel"))
+	if writeDirectCallError(w, err) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to cancel direct call", slog.String("error", err.Error()))
+		http.Error(w, "Failed to cancel call", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetMissedCalls 自分宛ての不在着信一覧を取得
+func (h *DirectCallHandler) GetMissedCalls(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	missed, err := h.directCallUsecase.GetMissedCalls(ctx, userID, limit)
+	if err != nil {
+		slog.Error("Failed to get missed calls", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get missed calls", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.MissedCallResponse, len(missed))
+	for i, m := range missed {
+		resp[i] = dto.MissedCallResponse{
+			CallID:     m.Call.CallID,
+			CallerID:   m.Call.CallerID,
+			CallerName: m.CallerName,
+			CreatedAt:  m.Call.CreatedAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// directCallIDFromPath /api/calls/direct/{call_id}/{action} から通話IDを取り出す
+func directCallIDFromPath(path, action string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(path, "/api/calls/direct/"), action)
+}
+
+// writeDirectCallError 1対1通話のエラーをレスポンスに書き込む（書き込んだらtrue）
+func writeDirectCallError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, entity.ErrDirectCallNotFound):
+		http.Error(w, "Call not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrUserNotFound):
+		http.Error(w, "User not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrDirectCallNotRinging), errors.Is(err, entity.ErrUserDoNotDisturb):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, entity.ErrCannotCallSelf):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
+
+// toDirectCallResponse 1対1通話をレスポンスに変換
+func toDirectCallResponse(call *entity.DirectCall, room *entity.CallRoom) dto.DirectCallResponse {
+	return dto.DirectCallResponse{
+		CallID:     call.CallID,
+		RoomID:     room.RoomID,
+		CallerID:   call.CallerID,
+		CalleeID:   call.CalleeID,
+		Status:     string(call.Status),
+		ExpiresAt:  call.RingExpiresAt,
+		AnsweredAt: call.AnsweredAt,
+		CreatedAt:  call.CreatedAt,
+	}
+}
@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// DirectUploadHandler ストレージへ直接アップロードする録音のHTTPハンドラー
+// 録音の本文はこのサーバーを経由せず、発行した署名付きURLでストレージへ送信する
+type DirectUploadHandler struct {
+	callUsecase   usecase.CallUsecase
+	directUsecase usecase.DirectUploadUsecase
+}
+
+// NewDirectUploadHandler 新しい直接アップロードハンドラーを作成
+func NewDirectUploadHandler(callUsecase usecase.CallUsecase, directUsecase usecase.DirectUploadUsecase) *DirectUploadHandler {
+	return &DirectUploadHandler{
+		callUsecase:   callUsecase,
+		directUsecase: directUsecase,
+	}
+}
+
+// CreateSlot アップロードの枠を作成して署名付きURLを返す
+func (h *DirectUploadHandler) CreateSlot(w http.ResponseWriter, r *http.Request) {
+	// URLからroom_idを取得
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/recording-slots")
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.CreateRecordingSlotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	room, ok := h.authorizedRoom(ctx, w, r, roomID)
+	if !ok {
+		return
+	}
+
+	upload, err := h.directUsecase.CreateSlot(ctx, room.ID, userID, req.Size, req.ContentType, req.DurationSeconds, req.StartedAt)
+	if writeDirectUploadError(w, err) {
+		return
+	}
+
+	slog.Info("Recording upload slot created",
+		slog.String("room_id", roomID),
+		slog.String("slot_id", upload.Slot.ID),
+		slog.Int64("user_id", userID),
+		slog.Int64("size", upload.Slot.Size))
+
+	resp := dto.RecordingSlotResponse{
+		SlotID:      upload.Slot.ID,
+		UploadURL:   upload.URL,
+		Method:      upload.Method,
+		Headers:     upload.Headers,
+		ObjectKey:   upload.Slot.ObjectKey,
+		Size:        upload.Slot.Size,
+		FinalizeURL: fmt.Sprintf("/api/calls/rooms/%s/recording-slots/%s/finalize", room.RoomID, upload.Slot.ID),
+		ExpiresAt:   upload.ExpiresAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Finalize アップロードされた録音を確認して登録
+func (h *DirectUploadHandler) Finalize(w http.ResponseWriter, r *http.Request) {
+	roomID, slotID, ok := parseRecordingSlotPath(r.URL.Path)
+	if !ok {
+		http.Error(w, "Invalid recording slot path", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	room, ok := h.authorizedRoom(ctx, w, r, roomID)
+	if !ok {
+		return
+	}
+
+	recording, err := h.directUsecase.Finalize(ctx, room.ID, slotID, userID)
+	if writeDirectUploadError(w, err) {
+		return
+	}
+
+	resp := dto.UploadRecordingResponse{
+		RecordingID: recording.ID,
+		FilePath:    recording.FilePath,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// authorizedRoom ルームを取得し、録音する権限があるか確認（書き込んだ場合はfalse）
+func (h *DirectUploadHandler) authorizedRoom(ctx context.Context, w http.ResponseWriter, r *http.Request, roomID string) (*entity.CallRoom, bool) {
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Error("Failed to get room", slog.String("error", err.Error()))
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionRecord)
+	if writeRoleError(w, err) {
+		return nil, false
+	}
+	return room, true
+}
+
+// parseRecordingSlotPath "/api/calls/rooms/{room_id}/recording-slots/{id}/finalize" を分解
+func parseRecordingSlotPath(path string) (string, string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/api/calls/rooms/"), "/")
+	if len(parts) != 4 || parts[1] != "recording-slots" || parts[3] != "finalize" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[2], true
+}
+
+// writeDirectUploadError 直接アップロードのエラーをレスポンスに書き込む（書き込んだらtrue）
+func writeDirectUploadError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, entity.ErrUploadNotFound):
+		http.Error(w, "Recording slot not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrUploadExpired):
+		http.Error(w, "Recording slot has expired", http.StatusGone)
+	case errors.Is(err, entity.ErrUploadNotReceived):
+		http.Error(w, "Recording has not been uploaded yet", http.StatusConflict)
+	case errors.Is(err, entity.ErrUploadFinalizing):
+		http.Error(w, "Recording is being finalized", http.StatusConflict)
+	case errors.Is(err, entity.ErrUploadSizeMismatch):
+		http.Error(w, "Uploaded recording size does not match the slot", http.StatusUnprocessableEntity)
+	case errors.Is(err, entity.ErrUploadTooLarge):
+		http.Error(w, "Recording exceeds the allowed size", http.StatusRequestEntityTooLarge)
+	case errors.Is(err, entity.ErrInvalidUploadLength):
+		http.Error(w, "Size must be positive", http.StatusBadRequest)
+	case errors.Is(err, entity.ErrUnsupportedAudioType):
+		http.Error(w, "Unsupported recording content type", http.StatusUnsupportedMediaType)
+	default:
+		slog.Error("Failed to handle direct recording upload", slog.String("error", err.Error()))
+		http.Error(w, "Failed to handle recording upload", http.StatusInternalServerError)
+	}
+	return true
+}
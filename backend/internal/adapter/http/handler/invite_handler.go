@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// InviteHandler 招待リンク・ゲスト参加関連のHTTPハンドラー
+type InviteHandler struct {
+	inviteUsecase usecase.InviteUsecase
+}
+
+// NewInviteHandler 新しい招待ハンドラーを作成
+func NewInviteHandler(inviteUsecase usecase.InviteUsecase) *InviteHandler {
+	return &InviteHandler{inviteUsecase: inviteUsecase}
+}
+
+// CreateInvite ゲスト用の招待リンクを作成
+func (h *InviteHandler) CreateInvite(w http.ResponseWriter, r *http.Request) {
+	// URLからroom_idを取得
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/invites")
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// ボディは省略可能（省略時はデフォルトの有効期限）
+	var req dto.CreateInviteRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			slog.Error("Failed to decode request", slog.String("error", err.Error()))
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	inv, err := h.inviteUsecase.CreateInvite(ctx, roomID, userID, time.Duration(req.ExpiresInMinutes)*time.Minute)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrRoomEnded):
+			http.Error(w, "Room has ended", http.StatusBadRequest)
+		case errors.Is(err, entity.ErrNotRoomMember):
+			http.Error(w, "Forbidden: only room members can invite", http.StatusForbidden)
+		default:
+			slog.Error("Failed to create invite", slog.String("error", err.Error()))
+			http.Error(w, "Room not found", http.StatusNotFound)
+		}
+		return
+	}
+
+	slog.Info("Invite created",
+		slog.String("room_id", roomID),
+		slog.Int64("created_by", userID),
+		slog.Time("expires_at", inv.ExpiresAt))
+
+	resp := dto.CreateInviteResponse{
+		Token:     inv.Token,
+		InviteURL: inv.URL,
+		ExpiresAt: inv.ExpiresAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// InviteUser 登録ユーザーを通話ルームに招待（相手の全端末へ通知）
+func (h *InviteHandler) InviteUser(w http.ResponseWriter, r *http.Request) {
+	// URLからroom_idを取得
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/invites/users")
+
+	var req dto.InviteUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.UserID <= 0 {
+		http.Error(w, "user_id is required", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.inviteUsecase.InviteUser(ctx, roomID, userID, req.UserID); err != nil {
+		switch {
+		case errors.Is(err, entity.ErrRoomEnded), errors.Is(err, entity.ErrCannotInviteSelf):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, entity.ErrNotRoomMember):
+			http.Error(w, "Forbidden: only room members can invite", http.StatusForbidden)
+		case errors.Is(err, entity.ErrUserDoNotDisturb):
+			http.Error(w, err.Error(), http.StatusConflict)
+		case errors.Is(err, entity.ErrUserNotFound):
+			http.Error(w, "User not found", http.StatusNotFound)
+		default:
+			slog.Error("Failed to invite user", slog.String("error", err.Error()))
+			http.Error(w, "Room not found", http.StatusNotFound)
+		}
+		return
+	}
+
+	slog.Info("User invited",
+		slog.String("room_id", roomID),
+		slog.Int64("invited_by", userID),
+		slog.Int64("user_id", req.UserID))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AcceptInvite 招待を受諾してゲストトークンを発行（認証不要）
+func (h *InviteHandler) AcceptInvite(w http.ResponseWriter, r *http.Request) {
+	var req dto.AcceptInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.Token == "" {
+		http.Error(w, "Token is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	session, err := h.inviteUsecase.AcceptInvite(ctx, req.Token, req.DisplayName)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrInvalidInvite), errors.Is(err, entity.ErrInviteExpired):
+			http.Error(w, "Invalid or expired invite", http.StatusUnauthorized)
+		case errors.Is(err, entity.ErrInvalidDisplayName), errors.Is(err, entity.ErrRoomEnded):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("Failed to accept invite", slog.String("error", err.Error()))
+			http.Error(w, "Room not found", http.StatusNotFound)
+		}
+		return
+	}
+
+	slog.Info("Invite accepted",
+		slog.String("room_id", session.Guest.RoomID),
+		slog.String("guest_id", session.Guest.GuestID))
+
+	resp := dto.AcceptInviteResponse{
+		AccessToken: session.AccessToken,
+		RoomID:      session.Guest.RoomID,
+		GuestID:     session.Guest.GuestID,
+		DisplayName: session.Guest.DisplayName,
+		ExpiresAt:   session.Guest.ExpiresAt,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/websocket"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/pkg/jwt"
+)
+
+// LobbyHandler ロビーのリアルタイム配信ハンドラー
+type LobbyHandler struct {
+	lobbyUsecase  usecase.LobbyUsecase
+	inviteUsecase usecase.InviteUsecase
+	jwtService    *jwt.Service
+}
+
+// NewLobbyHandler 新しいロビーハンドラーを作成
+func NewLobbyHandler(lobbyUsecase usecase.LobbyUsecase, inviteUsecase usecase.InviteUsecase, jwtService *jwt.Service) *LobbyHandler {
+	return &LobbyHandler{
+		lobbyUsecase:  lobbyUsecase,
+		inviteUsecase: inviteUsecase,
+		jwtService:    jwtService,
+	}
+}
+
+// HandleLobby ロビーのWebSocket接続を処理
+// 接続直後に表示可能なルーム一覧を送り、以降はルームの変更を配信する
+func (h *LobbyHandler) HandleLobby(w http.ResponseWriter, r *http.Request) {
+	// クエリパラメータからトークンを取得
+	tokenString := r.URL.Query().Get("token")
+	if tokenString == "" {
+		http.Error(w, "Token required", http.StatusUnauthorized)
+		return
+	}
+
+	principal, err := principalFromToken(h.jwtService, h.inviteUsecase, tokenString)
+	if err != nil {
+		slog.Error("Token validation failed", slog.String("error", err.Error()))
+		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rooms, sub, err := h.lobbyUsecase.Subscribe(ctx, principal)
+	if err != nil {
+		slog.Error("Failed to subscribe lobby", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get rooms", http.StatusInternalServerError)
+		return
+	}
+
+	snapshot := dto.LobbyMessage{Type: "snapshot", Rooms: make([]dto.LobbyRoomResponse, len(rooms))}
+	for i, lobbyRoom := range rooms {
+		snapshot.Rooms[i] = toLobbyRoomResponse(lobbyRoom)
+	}
+
+	// 購読が閉じられたら送信側も閉じる
+	messages := make(chan interface{})
+	go func() {
+		defer close(messages)
+		messages <- snapshot
+		for event := range sub.Events {
+			messages <- toLobbyMessage(event)
+		}
+	}()
+
+	websocket.HandleStream(w, r, messages, func() {
+		h.lobbyUsecase.Unsubscribe(sub)
+	})
+}
+
+// toLobbyMessage ロビーのイベントをメッセージに変換
+func toLobbyMessage(event entity.LobbyEvent) dto.LobbyMessage {
+	room := toLobbyRoomResponse(&event.Room)
+	return dto.LobbyMessage{Type: string(event.Type), Room: &room}
+}
@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// NotesHandler 共有ノートのHTTPハンドラー
+type NotesHandler struct {
+	callUsecase  usecase.CallUsecase
+	notesUsecase usecase.NotesUsecase
+}
+
+// NewNotesHandler 新しい共有ノートハンドラーを作成
+func NewNotesHandler(callUsecase usecase.CallUsecase, notesUsecase usecase.NotesUsecase) *NotesHandler {
+	return &NotesHandler{
+		callUsecase:  callUsecase,
+		notesUsecase: notesUsecase,
+	}
+}
+
+// GetNotes 共有ノートの現在の内容を取得（通話後の閲覧用）
+func (h *NotesHandler) GetNotes(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/notes")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	// 議事録と同じく閲覧権限を確認
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionReadMinutes)
+	if writeRoleError(w, err) {
+		return
+	}
+
+	notes, err := h.notesUsecase.GetNotes(ctx, room)
+	if err != nil {
+		slog.Error("Failed to get notes", slog.String("room_id", roomID), slog.String("error", err.Error()))
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	resp := dto.CallNotesResponse{Content: notes.Content, Version: notes.Version}
+	if !notes.UpdatedAt.IsZero() {
+		resp.UpdatedAt = &notes.UpdatedAt
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
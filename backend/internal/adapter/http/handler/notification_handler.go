@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// NotificationHandler 通知関連のHTTPハンドラー
+type NotificationHandler struct {
+	notificationUsecase usecase.NotificationUsecase
+}
+
+// NewNotificationHandler 新しい通知ハンドラーを作成
+func NewNotificationHandler(notificationUsecase usecase.NotificationUsecase) *NotificationHandler {
+	return &NotificationHandler{notificationUsecase: notificationUsecase}
+}
+
+// List 自分の通知一覧を取得（?unread=true で未読のみ、?before={id} で続きを取得）
+func (h *NotificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := &entity.NotificationFilter{UserID: userID, UnreadOnly: query.Get("unread") == "true"}
+	if v := query.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = n
+	}
+	if v := query.Get("before"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid before parameter", http.StatusBadRequest)
+			return
+		}
+		filter.BeforeID = n
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	notifications, unread, err := h.notificationUsecase.List(ctx, filter)
+	if err != nil {
+		slog.Error("Failed to get notifications", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get notifications", http.StatusInternalServerError)
+		return
+	}
+
+	resp := dto.NotificationListResponse{
+		Notifications: make([]dto.NotificationResponse, len(notifications)),
+		UnreadCount:   unread,
+	}
+	for i, notification := range notifications {
+		resp.Notifications[i] = dto.NotificationResponse{
+			ID:        notification.ID,
+			Type:      string(notification.Type),
+			Title:     notification.Title,
+			Body:      notification.Body,
+			Data:      notification.Data,
+			ReadAt:    notification.ReadAt,
+			CreatedAt: notification.CreatedAt,
+		}
+	}
+	if len(notifications) == filter.Limit {
+		resp.NextBefore = notifications[len(notifications)-1].ID
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// MarkRead 通知を既読にする
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/notifications/")
+	notificationID, err := strconv.ParseInt(strings.TrimSuffix(path, "/read"), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid notification ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = h.notificationUsecase.MarkRead(ctx, userID, notificationID)
+	if errors.Is(err, entity.ErrNotificationNotFound) {
+		http.Error(w, "Notification not found", http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to mark notification as read", slog.String("error", err.Error()))
+		http.Error(w, "Failed to mark notification as read", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// MarkAllRead 未読の通知をすべて既読にする
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.notificationUsecase.MarkAllRead(ctx, userID); err != nil {
+		slog.Error("Failed to mark all notifications as read", slog.String("error", err.Error()))
+		http.Error(w, "Failed to mark notifications as read", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
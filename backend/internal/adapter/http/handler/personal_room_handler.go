@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// PersonalRoomHandler 個人ルーム関連のHTTPハンドラー
+type PersonalRoomHandler struct {
+	personalRoomUsecase usecase.PersonalRoomUsecase
+}
+
+// NewPersonalRoomHandler 新しい個人ルームハンドラーを作成
+func NewPersonalRoomHandler(personalRoomUsecase usecase.PersonalRoomUsecase) *PersonalRoomHandler {
+	return &PersonalRoomHandler{personalRoomUsecase: personalRoomUsecase}
+}
+
+// GetMyRoom 自分の個人ルームを取得（未作成の場合は作成）
+func (h *PersonalRoomHandler) GetMyRoom(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.personalRoomUsecase.GetMyRoom(ctx, userID)
+	if err != nil {
+		slog.Error("Failed to get personal room", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get personal room", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPersonalRoomResponse(room))
+}
+
+// UpdateMyRoom 自分の個人ルームの設定を更新
+func (h *PersonalRoomHandler) UpdateMyRoom(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpdatePersonalRoomRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	update := &usecase.PersonalRoomUpdate{
+		Slug:            req.Slug,
+		Name:            req.Name,
+		MaxParticipants: req.MaxParticipants,
+	}
+	if req.Access != nil {
+		update.Access = &entity.RoomAccessSettings{
+			Visibility:          entity.RoomVisibility(req.Access.Visibility),
+			Passcode:            req.Access.Passcode,
+			AllowedUserIDs:      req.Access.AllowedUserIDs,
+			AllowedEmailDomains: req.Access.AllowedEmailDomains,
+		}
+	}
+
+	room, err := h.personalRoomUsecase.UpdateMyRoom(ctx, userID, update)
+	if writePersonalRoomError(w, err) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to update personal room", slog.String("error", err.Error()))
+		http.Error(w, "Failed to update personal room", http.StatusInternalServerError)
+		return
+	}
+
+	slog.Info("Personal room updated",
+		slog.Int64("user_id", userID),
+		slog.String("slug", room.Slug))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPersonalRoomResponse(room))
+}
+
+// GetMySessions 自分の個人ルームのセッション一覧を取得
+func (h *PersonalRoomHandler) GetMySessions(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	sessions, err := h.personalRoomUsecase.GetSessions(ctx, userID, limit)
+	if err != nil {
+		slog.Error("Failed to get sessions", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get sessions", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.SessionInfo, len(sessions))
+	for i, session := range sessions {
+		resp[i] = dto.SessionInfo{
+			RoomID:    session.RoomID,
+			Status:    string(session.Status),
+			StartedAt: session.StartedAt,
+			EndedAt:   session.EndedAt,
+			CreatedAt: session.CreatedAt,
+		}
+		if session.SessionNumber != nil {
+			resp[i].SessionNumber = *session.SessionNumber
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetBySlug スラッグで個人ルームと進行中のセッションを取得
+func (h *PersonalRoomHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := strings.TrimPrefix(r.URL.Path, "/api/r/")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, session, err := h.personalRoomUsecase.GetBySlug(ctx, slug)
+	if writePersonalRoomError(w, err) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to get personal room", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get personal room", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPersonalRoomSessionResponse(room, session))
+}
+
+// OpenSession 個人ルームのセッションを開く（進行中のセッションがなければ作成）
+func (h *PersonalRoomHandler) OpenSession(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/r/")
+	slug := strings.TrimSuffix(path, "/session")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, session, err := h.personalRoomUsecase.OpenSession(ctx, slug)
+	if writePersonalRoomError(w, err) {
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to open session", slog.String("error", err.Error()))
+		http.Error(w, "Failed to open session", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPersonalRoomSessionResponse(room, session))
+}
+
+// writePersonalRoomError 個人ルームのエラーをレスポンスに書き込む（書き込んだらtrue）
+func writePersonalRoomError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, entity.ErrPersonalRoomNotFound):
+		http.Error(w, "Personal room not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrSlugTaken):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, entity.ErrInvalidSlug), errors.Is(err, entity.ErrNameRequired), isRoomAccessSettingsError(err):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
+
+// toPersonalRoomResponse 個人ルームをレスポンスに変換
+func toPersonalRoomResponse(room *entity.PersonalRoom) dto.PersonalRoomResponse {
+	return dto.PersonalRoomResponse{
+		Slug:            room.Slug,
+		Name:            room.Name,
+		MaxParticipants: room.MaxParticipants,
+		Access: dto.RoomAccessResponse{
+			Visibility:          string(room.Visibility),
+			HasPasscode:         room.HasPasscode(),
+			AllowedUserIDs:      nonNilInt64s(room.AllowedUserIDs),
+			AllowedEmailDomains: nonNilStrings(room.AllowedEmailDomains),
+		},
+	}
+}
+
+// toPersonalRoomSessionResponse 個人ルームと進行中のセッションをレスポンスに変換
+func toPersonalRoomSessionResponse(room *entity.PersonalRoom, session *entity.CallRoom) dto.PersonalRoomSessionResponse {
+	resp := dto.PersonalRoomSessionResponse{
+		Slug:    room.Slug,
+		Name:    room.Name,
+		OwnerID: room.UserID,
+	}
+	if session != nil {
+		resp.RoomID = session.RoomID
+		resp.Status = string(session.Status)
+		if session.SessionNumber != nil {
+			resp.SessionNumber = *session.SessionNumber
+		}
+	}
+	return resp
+}
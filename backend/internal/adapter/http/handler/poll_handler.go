@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/websocket"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// PollHandler 通話中の投票のHTTPハンドラー
+// 投票の作成・集計結果・締め切りはシグナリングでルーム全体に配信する
+type PollHandler struct {
+	callUsecase     usecase.CallUsecase
+	pollUsecase     usecase.PollUsecase
+	signalingServer *websocket.SignalingServer
+}
+
+// NewPollHandler 新しい投票ハンドラーを作成
+func NewPollHandler(callUsecase usecase.CallUsecase, pollUsecase usecase.PollUsecase, signalingServer *websocket.SignalingServer) *PollHandler {
+	return &PollHandler{
+		callUsecase:     callUsecase,
+		pollUsecase:     pollUsecase,
+		signalingServer: signalingServer,
+	}
+}
+
+// ListPolls ルームの投票一覧と集計結果を取得
+func (h *PollHandler) ListPolls(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/polls")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	polls, err := h.pollUsecase.ListPolls(ctx, room, principalFromContext(r.Context()))
+	if writePollError(w, err) {
+		return
+	}
+
+	resp := dto.PollListResponse{Polls: make([]dto.PollResponse, len(polls))}
+	for i, results := range polls {
+		resp.Polls[i] = toPollResponse(results, true)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// CreatePoll 投票を作成（ホスト・共同ホストのみ）
+func (h *PollHandler) CreatePoll(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/polls")
+
+	var req dto.CreatePollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	poll := &entity.Poll{
+		Question:       req.Question,
+		MultipleChoice: req.MultipleChoice,
+		Anonymous:      req.Anonymous,
+		Options:        make([]*entity.PollOption, len(req.Options)),
+	}
+	for i, text := range req.Options {
+		poll.Options[i] = &entity.PollOption{Text: text}
+	}
+
+	results, err := h.pollUsecase.CreatePoll(ctx, room, principalFromContext(r.Context()), poll)
+	if writePollError(w, err) {
+		return
+	}
+
+	h.signalingServer.BroadcastEvent(room.RoomID, "poll-created", toPollResponse(results, false))
+
+	slog.Info("Poll created",
+		slog.String("room_id", roomID),
+		slog.Int64("poll_id", results.PollID))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toPollResponse(results, true))
+}
+
+// Vote 投票する（締め切りまでは投票し直せる）
+func (h *PollHandler) Vote(w http.ResponseWriter, r *http.Request) {
+	roomID, pollID, ok := parsePollPath(r.URL.Path, "/votes")
+	if !ok {
+		http.Error(w, "Invalid poll ID", http.StatusBadRequest)
+		return
+	}
+
+	var req dto.VotePollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	results, err := h.pollUsecase.Vote(ctx, room, principalFromContext(r.Context()), pollID, req.OptionIDs)
+	if writePollError(w, err) {
+		return
+	}
+
+	h.signalingServer.BroadcastEvent(room.RoomID, "poll-results", toPollResponse(results, false))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPollResponse(results, true))
+}
+
+// ClosePoll 投票を締め切る（ホスト・共同ホストのみ）
+func (h *PollHandler) ClosePoll(w http.ResponseWriter, r *http.Request) {
+	roomID, pollID, ok := parsePollPath(r.URL.Path, "/close")
+	if !ok {
+		http.Error(w, "Invalid poll ID", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	results, err := h.pollUsecase.ClosePoll(ctx, room, principalFromContext(r.Context()), pollID)
+	if writePollError(w, err) {
+		return
+	}
+
+	h.signalingServer.BroadcastEvent(room.RoomID, "poll-closed", toPollResponse(results, false))
+
+	slog.Info("Poll closed",
+		slog.String("room_id", roomID),
+		slog.Int64("poll_id", pollID),
+		slog.Int("voters", results.TotalVoters))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPollResponse(results, true))
+}
+
+// parsePollPath "/api/calls/rooms/{room_id}/polls/{id}{suffix}" を分解
+func parsePollPath(path string, suffix string) (string, int64, bool) {
+	path = strings.TrimSuffix(strings.TrimPrefix(path, "/api/calls/rooms/"), suffix)
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[1] != "polls" {
+		return "", 0, false
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return parts[0], id, true
+}
+
+// writePollError 投票のエラーをレスポンスに書き込む（書き込んだらtrue）
+func writePollError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, entity.ErrPollNotFound):
+		http.Error(w, "Poll not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrPollClosed):
+		http.Error(w, "Poll is closed", http.StatusConflict)
+	case errors.Is(err, entity.ErrRoomEnded):
+		http.Error(w, "Room has ended", http.StatusBadRequest)
+	case errors.Is(err, entity.ErrInvalidPoll), errors.Is(err, entity.ErrInvalidPollVote):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		return writeRoleError(w, err)
+	}
+	return true
+}
+
+// toPollResponse 集計結果をレスポンスに変換（ルーム全体への配信には自分の投票を含めない）
+func toPollResponse(results *entity.PollResults, includeMine bool) dto.PollResponse {
+	resp := dto.PollResponse{
+		ID:             results.PollID,
+		Question:       results.Question,
+		MultipleChoice: results.MultipleChoice,
+		Anonymous:      results.Anonymous,
+		Status:         string(results.Status),
+		TotalVoters:    results.TotalVoters,
+		Options:        make([]dto.PollOptionResponse, len(results.Options)),
+		ClosedAt:       results.ClosedAt,
+		CreatedAt:      results.CreatedAt,
+	}
+	for i, option := range results.Options {
+		resp.Options[i] = dto.PollOptionResponse{
+			ID:     option.OptionID,
+			Text:   option.Text,
+			Votes:  option.Votes,
+			Voters: option.Voters,
+		}
+	}
+	if includeMine {
+		resp.MyChoices = results.MyChoices
+	}
+	return resp
+}
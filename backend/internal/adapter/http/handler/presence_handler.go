@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// PresenceHandler 在席状態関連のHTTPハンドラー
+type PresenceHandler struct {
+	presenceUsecase usecase.PresenceUsecase
+}
+
+// NewPresenceHandler 新しい在席状態ハンドラーを作成
+func NewPresenceHandler(presenceUsecase usecase.PresenceUsecase) *PresenceHandler {
+	return &PresenceHandler{presenceUsecase: presenceUsecase}
+}
+
+// GetMyPresence 自分の在席状態を取得
+func (h *PresenceHandler) GetMyPresence(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	presence, err := h.presenceUsecase.GetPresence(ctx, userID)
+	if err != nil {
+		slog.Error("Failed to get presence", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get presence", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPresenceResponse(presence))
+}
+
+// UpdateMyPresence 自分の在席状態を設定（online/away/dnd）
+func (h *PresenceHandler) UpdateMyPresence(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpdatePresenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	presence, err := h.presenceUsecase.SetStatus(ctx, userID, entity.PresenceStatus(req.Status))
+	if errors.Is(err, entity.ErrInvalidPresenceStatus) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to update presence", slog.String("error", err.Error()))
+		http.Error(w, "Failed to update presence", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPresenceResponse(presence))
+}
+
+// GetContacts 連絡先（同じ通話に参加したことのあるユーザー）の在席状態一覧を取得
+func (h *PresenceHandler) GetContacts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	presences, err := h.presenceUsecase.GetContacts(ctx, userID)
+	if err != nil {
+		slog.Error("Failed to get contacts", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get contacts", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toPresenceResponses(presences))
+}
+
+// toPresenceResponse 在席状態をレスポンスに変換
+func toPresenceResponse(presence *entity.Presence) dto.PresenceResponse {
+	return dto.PresenceResponse{
+		UserID:     presence.UserID,
+		Status:     string(presence.Status),
+		LastSeenAt: presence.LastSeenAt,
+	}
+}
+
+// toPresenceResponses 在席状態の一覧をレスポンスに変換
+func toPresenceResponses(presences []*entity.Presence) []dto.PresenceResponse {
+	resp := make([]dto.PresenceResponse, len(presences))
+	for i, presence := range presences {
+		resp[i] = toPresenceResponse(presence)
+	}
+	return resp
+}
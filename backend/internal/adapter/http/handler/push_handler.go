@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// PushHandler Web Push関連のHTTPハンドラー
+type PushHandler struct {
+	pushUsecase usecase.PushUsecase
+}
+
+// NewPushHandler 新しいWeb Pushハンドラーを作成
+func NewPushHandler(pushUsecase usecase.PushUsecase) *PushHandler {
+	return &PushHandler{pushUsecase: pushUsecase}
+}
+
+// GetPublicKey ブラウザの購読登録に使うVAPIDの公開鍵を取得
+func (h *PushHandler) GetPublicKey(w http.ResponseWriter, r *http.Request) {
+	publicKey, err := h.pushUsecase.PublicKey()
+	if errors.Is(err, entity.ErrPushNotConfigured) {
+		http.Error(w, "Web push is not configured", http.StatusServiceUnavailable)
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to get push public key", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get public key", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(dto.PushPublicKeyResponse{PublicKey: publicKey})
+}
+
+// Subscribe 端末の購読を登録
+func (h *PushHandler) Subscribe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.PushSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := h.pushUsecase.Subscribe(ctx, &entity.PushSubscription{
+		UserID:    userID,
+		Endpoint:  req.Endpoint,
+		P256dh:    req.Keys.P256dh,
+		Auth:      req.Keys.Auth,
+		UserAgent: r.UserAgent(),
+	})
+	switch {
+	case errors.Is(err, entity.ErrInvalidPushSubscription):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, entity.ErrPushNotConfigured):
+		http.Error(w, "Web push is not configured", http.StatusServiceUnavailable)
+		return
+	case err != nil:
+		slog.Error("Failed to save push subscription", slog.String("error", err.Error()))
+		http.Error(w, "Failed to save push subscription", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Unsubscribe 端末の購読を解除
+func (h *PushHandler) Unsubscribe(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.UnsubscribePushRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Endpoint == "" {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.pushUsecase.Unsubscribe(ctx, userID, req.Endpoint); err != nil {
+		slog.Error("Failed to delete push subscription", slog.String("error", err.Error()))
+		http.Error(w, "Failed to delete push subscription", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,166 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// RecordingMixHandler 録音のミックスのHTTPハンドラー
+// ミックスはワーカーが非同期に実行し、進捗はミックスの取得または /ws/user のイベントで確認する
+type RecordingMixHandler struct {
+	callUsecase usecase.CallUsecase
+	mixUsecase  usecase.RecordingMixUsecase
+}
+
+// NewRecordingMixHandler 新しい録音のミックスハンドラーを作成
+func NewRecordingMixHandler(callUsecase usecase.CallUsecase, mixUsecase usecase.RecordingMixUsecase) *RecordingMixHandler {
+	return &RecordingMixHandler{
+		callUsecase: callUsecase,
+		mixUsecase:  mixUsecase,
+	}
+}
+
+// RequestMixdown ミックスを依頼（202でミックスを返す）
+func (h *RecordingMixHandler) RequestMixdown(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/mixdown")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	room, ok := h.authorizedRoom(ctx, w, r, roomID, entity.PermissionRecord)
+	if !ok {
+		return
+	}
+
+	var requestedBy *int64
+	if userID, ok := middleware.GetUserIDFromContext(r.Context()); ok {
+		requestedBy = &userID
+	}
+
+	mix, err := h.mixUsecase.Enqueue(ctx, room.ID, requestedBy)
+	if writeRecordingMixError(w, err) {
+		return
+	}
+
+	slog.Info("Recording mix requested",
+		slog.String("room_id", roomID),
+		slog.Int64("mix_id", mix.ID),
+		slog.String("status", string(mix.Status)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("/api/calls/rooms/%s/mixdown", room.RoomID))
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(h.toRecordingMixResponse(ctx, mix))
+}
+
+// GetMixdown ルームの最新のミックスを取得
+func (h *RecordingMixHandler) GetMixdown(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/mixdown")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	room, ok := h.authorizedRoom(ctx, w, r, roomID, entity.PermissionReadMinutes)
+	if !ok {
+		return
+	}
+
+	mix, err := h.mixUsecase.GetLatest(ctx, room.ID)
+	if writeRecordingMixError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(h.toRecordingMixResponse(ctx, mix))
+}
+
+// authorizedRoom ルームを取得し、権限があるか確認（書き込んだ場合はfalse）
+func (h *RecordingMixHandler) authorizedRoom(ctx context.Context, w http.ResponseWriter, r *http.Request, roomID string, permission entity.Permission) (*entity.CallRoom, bool) {
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Error("Failed to get room", slog.String("error", err.Error()))
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), permission)
+	if writeRoleError(w, err) {
+		return nil, false
+	}
+	return room, true
+}
+
+// toRecordingMixResponse ミックスをレスポンスに変換（保存したファイルには署名付きURLを付ける）
+func (h *RecordingMixHandler) toRecordingMixResponse(ctx context.Context, mix *entity.RecordingMix) dto.RecordingMixResponse {
+	resp := dto.RecordingMixResponse{
+		ID:                 mix.ID,
+		Status:             string(mix.Status),
+		Attempts:           mix.Attempts,
+		Format:             mix.Format,
+		FileSize:           mix.FileSize,
+		RecordingStartedAt: mix.RecordingStartedAt,
+		Tracks:             make([]dto.RecordingMixTrackInfo, len(mix.Tracks)),
+		LastError:          mix.LastError,
+		FinishedAt:         mix.FinishedAt,
+		CreatedAt:          mix.CreatedAt,
+		UpdatedAt:          mix.UpdatedAt,
+	}
+	succeeded := mix.Status == entity.RecordingMixSucceeded
+	if succeeded && mix.FilePath != nil {
+		resp.DownloadURL = h.downloadURL(ctx, *mix.FilePath)
+	}
+	for i, track := range mix.Tracks {
+		resp.Tracks[i] = dto.RecordingMixTrackInfo{
+			RecordingID: track.RecordingID,
+			UserID:      track.UserID,
+			OffsetMs:    track.Offset.Milliseconds(),
+		}
+		if succeeded && track.NormalizedPath != nil {
+			resp.Tracks[i].NormalizedURL = h.downloadURL(ctx, *track.NormalizedPath)
+		}
+	}
+	return resp
+}
+
+// downloadURL 署名付きURLを発行（発行できない場合はnil）
+func (h *RecordingMixHandler) downloadURL(ctx context.Context, key string) *string {
+	url, err := h.mixUsecase.DownloadURL(ctx, key)
+	if err != nil {
+		slog.Warn("Failed to presign mix download",
+			slog.String("key", key),
+			slog.String("error", err.Error()))
+		return nil
+	}
+	return &url
+}
+
+// writeRecordingMixError ミックスのエラーをレスポンスに書き込む（書き込んだらtrue）
+func writeRecordingMixError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, entity.ErrRecordingMixNotFound):
+		http.Error(w, "Recording mix not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrNoRecordings):
+		http.Error(w, "No recordings to mix", http.StatusBadRequest)
+	case errors.Is(err, entity.ErrAudioMixerNotConfigured):
+		http.Error(w, "Recording mix is not available", http.StatusServiceUnavailable)
+	default:
+		slog.Error("Failed to handle recording mix", slog.String("error", err.Error()))
+		http.Error(w, "Failed to handle recording mix", http.StatusInternalServerError)
+	}
+	return true
+}
@@ -0,0 +1,299 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/pkg/tus"
+)
+
+// uploadChunkTimeout 1回のPATCH（チャンクの受信と、最後のチャンクでは録音の作成）にかける時間の上限
+// サーバー全体の読み書きのタイムアウトより長いため、PATCHでは期限を延長する
+const uploadChunkTimeout = 10 * time.Minute
+
+// defaultUploadContentType Upload-Metadataにfiletypeがない場合のContent-Type（MediaRecorderの既定）
+const defaultUploadContentType = "audio/webm"
+
+// RecordingUploadHandler 再開可能な録音アップロード（tus 1.0.0）のHTTPハンドラー
+// 作成: POST /api/calls/rooms/{room_id}/uploads
+// 確認・送信・中止: HEAD / PATCH / DELETE /api/uploads/{id}
+type RecordingUploadHandler struct {
+	callUsecase   usecase.CallUsecase
+	uploadUsecase usecase.RecordingUploadUsecase
+}
+
+// NewRecordingUploadHandler 新しい録音アップロードハンドラーを作成
+func NewRecordingUploadHandler(callUsecase usecase.CallUsecase, uploadUsecase usecase.RecordingUploadUsecase) *RecordingUploadHandler {
+	return &RecordingUploadHandler{
+		callUsecase:   callUsecase,
+		uploadUsecase: uploadUsecase,
+	}
+}
+
+// Options サーバーが対応しているtusのバージョン・拡張を返す
+func (h *RecordingUploadHandler) Options(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set(tus.HeaderResumable, tus.Version)
+	w.Header().Set(tus.HeaderVersion, tus.Version)
+	w.Header().Set(tus.HeaderExtension, "creation,expiration,checksum,termination")
+	w.Header().Set(tus.HeaderMaxSize, strconv.FormatInt(h.uploadUsecase.MaxSize(), 10))
+	w.Header().Set(tus.HeaderChecksumAlgorithm, tus.ChecksumAlgorithms())
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateUpload アップロードを作成（201でLocationを返す）
+// Upload-Metadataのfiletypeで音声のContent-Type、durationで録音時間（秒）、
+// started_atで録音を開始した時刻（RFC 3339）を指定する
+func (h *RecordingUploadHandler) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	if !checkTusVersion(w, r) {
+		return
+	}
+
+	// URLからroom_idを取得
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/uploads")
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Header.Get(tus.HeaderUploadDeferLength) != "" {
+		http.Error(w, "Upload-Defer-Length is not supported", http.StatusBadRequest)
+		return
+	}
+	length, err := strconv.ParseInt(r.Header.Get(tus.HeaderUploadLength), 10, 64)
+	if err != nil {
+		http.Error(w, "Invalid Upload-Length", http.StatusBadRequest)
+		return
+	}
+	metadata, err := tus.ParseMetadata(r.Header.Get(tus.HeaderUploadMetadata))
+	if err != nil {
+		http.Error(w, "Invalid Upload-Metadata", http.StatusBadRequest)
+		return
+	}
+
+	contentType := metadata["filetype"]
+	if contentType == "" {
+		contentType = defaultUploadContentType
+	}
+	var duration *int
+	if d, err := strconv.Atoi(metadata["duration"]); err == nil && d >= 0 {
+		duration = &d
+	}
+	startedAt := parseStartedAt(metadata["started_at"])
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Error("Failed to get room", slog.String("error", err.Error()))
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	// 録音する権限があるか確認
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionRecord)
+	if writeRoleError(w, err) {
+		return
+	}
+
+	upload, err := h.uploadUsecase.Create(ctx, room.ID, userID, length, contentType, duration, startedAt)
+	if writeUploadError(w, err) {
+		return
+	}
+
+	slog.Info("Recording upload created",
+		slog.String("room_id", roomID),
+		slog.String("upload_id", upload.ID),
+		slog.Int64("user_id", userID),
+		slog.Int64("length", upload.Length))
+
+	w.Header().Set("Location", "/api/uploads/"+upload.ID)
+	w.Header().Set(tus.HeaderUploadOffset, "0")
+	w.Header().Set(tus.HeaderUploadExpires, tus.FormatExpires(upload.ExpiresAt))
+	w.WriteHeader(http.StatusCreated)
+}
+
+// GetOffset 受信済みのサイズを返す（中断したアップロードの再開位置）
+func (h *RecordingUploadHandler) GetOffset(w http.ResponseWriter, r *http.Request) {
+	if !checkTusVersion(w, r) {
+		return
+	}
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	upload, err := h.uploadUsecase.Get(ctx, uploadIDFromPath(r.URL.Path), userID)
+	if writeUploadError(w, err) {
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeUploadState(w, upload)
+	w.Header().Set(tus.HeaderUploadLength, strconv.FormatInt(upload.Length, 10))
+	w.WriteHeader(http.StatusOK)
+}
+
+// WriteChunk Upload-Offsetからの続きを受信
+// 本文はストレージへそのまま流し、全体を受信したら録音を作成してX-Recording-Idを返す
+func (h *RecordingUploadHandler) WriteChunk(w http.ResponseWriter, r *http.Request) {
+	if !checkTusVersion(w, r) {
+		return
+	}
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Header.Get("Content-Type") != tus.ContentTypeOffset {
+		http.Error(w, "Content-Type must be "+tus.ContentTypeOffset, http.StatusUnsupportedMediaType)
+		return
+	}
+	offset, err := strconv.ParseInt(r.Header.Get(tus.HeaderUploadOffset), 10, 64)
+	if err != nil || offset < 0 {
+		http.Error(w, "Invalid Upload-Offset", http.StatusBadRequest)
+		return
+	}
+
+	var checksum *usecase.UploadChecksum
+	parsed, err := tus.ParseChecksum(r.Header.Get(tus.HeaderUploadChecksum))
+	if errors.Is(err, tus.ErrUnsupportedChecksum) {
+		http.Error(w, "Unsupported checksum algorithm", http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Invalid Upload-Checksum", http.StatusBadRequest)
+		return
+	}
+	if parsed != nil {
+		checksum = &usecase.UploadChecksum{Hash: parsed.NewHash(), Sum: parsed.Sum}
+	}
+
+	// 遅い回線でもチャンクを受信しきれるよう、サーバー全体のタイムアウトを延長する
+	deadline := time.Now().Add(uploadChunkTimeout)
+	rc := http.NewResponseController(w)
+	if err := rc.SetReadDeadline(deadline); err != nil {
+		slog.Warn("Failed to extend read deadline", slog.String("error", err.Error()))
+	}
+	if err := rc.SetWriteDeadline(deadline); err != nil {
+		slog.Warn("Failed to extend write deadline", slog.String("error", err.Error()))
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), uploadChunkTimeout)
+	defer cancel()
+
+	upload, err := h.uploadUsecase.WriteChunk(ctx, uploadIDFromPath(r.URL.Path), userID, offset, r.Body, checksum)
+	// MAX_REQUEST_BODY_SIZEを超えるチャンク
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		http.Error(w, fmt.Sprintf("Chunk exceeds %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if writeUploadError(w, err) {
+		return
+	}
+
+	writeUploadState(w, upload)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Terminate アップロードを中止
+func (h *RecordingUploadHandler) Terminate(w http.ResponseWriter, r *http.Request) {
+	if !checkTusVersion(w, r) {
+		return
+	}
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 30*time.Second)
+	defer cancel()
+
+	err := h.uploadUsecase.Terminate(ctx, uploadIDFromPath(r.URL.Path), userID)
+	if writeUploadError(w, err) {
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkTusVersion Tus-Resumableを確認し、レスポンスにも付ける（対応していない場合は412を書き込んでfalse）
+func checkTusVersion(w http.ResponseWriter, r *http.Request) bool {
+	w.Header().Set(tus.HeaderResumable, tus.Version)
+	if r.Header.Get(tus.HeaderResumable) != tus.Version {
+		w.Header().Set(tus.HeaderVersion, tus.Version)
+		http.Error(w, "Unsupported Tus-Resumable version", http.StatusPreconditionFailed)
+		return false
+	}
+	return true
+}
+
+// writeUploadState 受信済みのサイズ・期限・作成した録音をヘッダーに書き込む
+func writeUploadState(w http.ResponseWriter, upload *entity.RecordingUpload) {
+	w.Header().Set(tus.HeaderUploadOffset, strconv.FormatInt(upload.Offset, 10))
+	w.Header().Set(tus.HeaderUploadExpires, tus.FormatExpires(upload.ExpiresAt))
+	if upload.RecordingID != nil {
+		w.Header().Set("X-Recording-Id", strconv.FormatInt(*upload.RecordingID, 10))
+	}
+}
+
+// parseStartedAt 録音を開始した時刻（RFC 3339）を解析（空・不正な値はnil）
+func parseStartedAt(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// uploadIDFromPath "/api/uploads/{id}" からアップロードIDを取得
+func uploadIDFromPath(path string) string {
+	return strings.TrimPrefix(path, "/api/uploads/")
+}
+
+// writeUploadError アップロードのエラーをレスポンスに書き込む（書き込んだらtrue）
+func writeUploadError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, entity.ErrUploadNotFound):
+		http.Error(w, "Upload not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrUploadExpired):
+		http.Error(w, "Upload has expired", http.StatusGone)
+	case errors.Is(err, entity.ErrUploadOffsetMismatch):
+		http.Error(w, "Upload-Offset does not match the current offset", http.StatusConflict)
+	case errors.Is(err, entity.ErrUploadTooLarge):
+		http.Error(w, "Upload exceeds the allowed size", http.StatusRequestEntityTooLarge)
+	case errors.Is(err, entity.ErrUploadChecksumMismatch):
+		http.Error(w, "Checksum mismatch", tus.StatusChecksumMismatch)
+	case errors.Is(err, entity.ErrInvalidUploadLength):
+		http.Error(w, "Invalid Upload-Length", http.StatusBadRequest)
+	case errors.Is(err, entity.ErrUnsupportedAudioType):
+		http.Error(w, "Unsupported recording content type", http.StatusUnsupportedMediaType)
+	default:
+		slog.Error("Failed to handle recording upload", slog.String("error", err.Error()))
+		http.Error(w, "Failed to handle recording upload", http.StatusInternalServerError)
+	}
+	return true
+}
@@ -0,0 +1,381 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/pkg/rrule"
+)
+
+// 開催回一覧の取得件数
+const (
+	defaultOccurrenceLimit = 10
+	maxOccurrenceLimit     = 50
+)
+
+// SeriesHandler 定例会議シリーズ関連のHTTPハンドラー
+type SeriesHandler struct {
+	seriesUsecase usecase.SeriesUsecase
+}
+
+// NewSeriesHandler 新しい定例会議シリーズハンドラーを作成
+func NewSeriesHandler(seriesUsecase usecase.SeriesUsecase) *SeriesHandler {
+	return &SeriesHandler{seriesUsecase: seriesUsecase}
+}
+
+// CreateSeries 定例会議シリーズを作成
+func (h *SeriesHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if req.StartAt.IsZero() {
+		http.Error(w, "start_at is required", http.StatusBadRequest)
+		return
+	}
+
+	series := &entity.CallSeries{
+		Name:            req.Name,
+		CreatedBy:       userID,
+		RRule:           req.RRule,
+		StartAt:         req.StartAt,
+		DurationMinutes: req.DurationMinutes,
+		Timezone:        req.Timezone,
+		MaxParticipants: req.MaxParticipants,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.seriesUsecase.CreateSeries(ctx, series, req.ExDates); err != nil {
+		if isSeriesClientError(err) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		slog.Error("Failed to create series", slog.String("error", err.Error()))
+		http.Error(w, "Failed to create series", http.StatusInternalServerError)
+		return
+	}
+
+	slog.Info("Series created successfully",
+		slog.String("series_id", series.SeriesID),
+		slog.String("rrule", series.RRule),
+		slog.Int64("created_by", userID))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(toSeriesResponse(series))
+}
+
+// GetMySeries 自分が作成したシリーズ一覧を取得
+func (h *SeriesHandler) GetMySeries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	list, err := h.seriesUsecase.GetSeriesByUser(ctx, userID)
+	if err != nil {
+		slog.Error("Failed to get series", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get series", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.SeriesResponse, len(list))
+	for i, s := range list {
+		resp[i] = toSeriesResponse(s)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetSeries シリーズ情報を取得
+func (h *SeriesHandler) GetSeries(w http.ResponseWriter, r *http.Request) {
+	seriesID := strings.TrimPrefix(r.URL.Path, "/api/calls/series/")
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	series, err := h.seriesUsecase.GetSeries(ctx, seriesID, userID)
+	if err != nil {
+		writeSeriesLookupError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toSeriesResponse(series))
+}
+
+// ListOccurrences 今後の開催回一覧を取得
+func (h *SeriesHandler) ListOccurrences(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/series/")
+	seriesID := strings.TrimSuffix(path, "/occurrences")
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	from := time.Now()
+	if v := r.URL.Query().Get("from"); v != "" {
+		parsed, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			http.Error(w, "Invalid from parameter", http.StatusBadRequest)
+			return
+		}
+		from = parsed
+	}
+
+	limit := defaultOccurrenceLimit
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		if n > maxOccurrenceLimit {
+			n = maxOccurrenceLimit
+		}
+		limit = n
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	series, err := h.seriesUsecase.GetSeries(ctx, seriesID, userID)
+	if err != nil {
+		writeSeriesLookupError(w, err)
+		return
+	}
+
+	occurrences, err := h.seriesUsecase.ListOccurrences(ctx, series, from, limit)
+	if err != nil {
+		slog.Error("Failed to list occurrences", slog.String("error", err.Error()))
+		http.Error(w, "Failed to list occurrences", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.OccurrenceResponse, len(occurrences))
+	for i, occ := range occurrences {
+		resp[i] = toOccurrenceResponse(occ)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// EditOccurrence 開催回を編集（この回のみ・これ以降・全て）
+func (h *SeriesHandler) EditOccurrence(w http.ResponseWriter, r *http.Request) {
+	// /api/calls/series/{seriesID}/occurrences/{occurrenceID}
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/series/")
+	parts := strings.Split(path, "/occurrences/")
+	if len(parts) != 2 {
+		http.Error(w, "Occurrence not found", http.StatusNotFound)
+		return
+	}
+	seriesID := parts[0]
+
+	originalStart, err := time.Parse(rrule.DateTimeFormat, parts[1])
+	if err != nil {
+		http.Error(w, "Invalid occurrence ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.EditOccurrenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	series, err := h.seriesUsecase.GetSeries(ctx, seriesID, userID)
+	if err != nil {
+		writeSeriesLookupError(w, err)
+		return
+	}
+
+	// シリーズの作成者のみが編集可能
+	if series.CreatedBy != userID {
+		http.Error(w, "Forbidden: only series creator can edit", http.StatusForbidden)
+		return
+	}
+
+	edit := &usecase.SeriesEdit{
+		Name:            req.Name,
+		StartAt:         req.StartAt,
+		DurationMinutes: req.DurationMinutes,
+		RRule:           req.RRule,
+		Cancelled:       req.Cancelled,
+	}
+
+	updated, err := h.seriesUsecase.EditOccurrence(ctx, series, originalStart, entity.SeriesEditScope(req.Scope), edit)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrOccurrenceNotFound):
+			http.Error(w, "Occurrence not found", http.StatusNotFound)
+		case isSeriesClientError(err):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("Failed to edit occurrence", slog.String("error", err.Error()))
+			http.Error(w, "Failed to edit occurrence", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	slog.Info("Series occurrence edited",
+		slog.String("series_id", seriesID),
+		slog.String("occurrence", parts[1]),
+		slog.String("scope", req.Scope),
+		slog.Int64("user_id", userID))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toSeriesResponse(updated))
+}
+
+// OpenOccurrence 開催回の通話ルームを取得または作成
+func (h *SeriesHandler) OpenOccurrence(w http.ResponseWriter, r *http.Request) {
+	// /api/calls/series/{seriesID}/occurrences/{occurrenceID}/room
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/series/")
+	parts := strings.Split(strings.TrimSuffix(path, "/room"), "/occurrences/")
+	if len(parts) != 2 {
+		http.Error(w, "Occurrence not found", http.StatusNotFound)
+		return
+	}
+	seriesID := parts[0]
+
+	originalStart, err := time.Parse(rrule.DateTimeFormat, parts[1])
+	if err != nil {
+		http.Error(w, "Invalid occurrence ID", http.StatusBadRequest)
+		return
+	}
+
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	series, err := h.seriesUsecase.GetSeries(ctx, seriesID, userID)
+	if err != nil {
+		writeSeriesLookupError(w, err)
+		return
+	}
+
+	// 開催回のルームはシリーズの作成者のみが作成可能
+	if series.CreatedBy != userID {
+		http.Error(w, "Forbidden: only series creator can open occurrences", http.StatusForbidden)
+		return
+	}
+
+	occ, err := h.seriesUsecase.OpenOccurrence(ctx, series, originalStart)
+	if err != nil {
+		switch {
+		case errors.Is(err, entity.ErrOccurrenceNotFound):
+			http.Error(w, "Occurrence not found", http.StatusNotFound)
+		case isSeriesClientError(err):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			slog.Error("Failed to open occurrence", slog.String("error", err.Error()))
+			http.Error(w, "Failed to open occurrence", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toOccurrenceResponse(occ))
+}
+
+// writeSeriesLookupError シリーズ取得エラーをレスポンスに変換
+func writeSeriesLookupError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, entity.ErrSeriesAccessDenied):
+		http.Error(w, "Forbidden: no access to this series", http.StatusForbidden)
+	case errors.Is(err, entity.ErrSeriesNotFound):
+		http.Error(w, "Series not found", http.StatusNotFound)
+	default:
+		slog.Error("Failed to get series", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get series", http.StatusInternalServerError)
+	}
+}
+
+// isSeriesClientError 入力内容に起因するシリーズのエラーか判定
+func isSeriesClientError(err error) bool {
+	return errors.Is(err, entity.ErrInvalidRecurrence) ||
+		errors.Is(err, entity.ErrInvalidTimezone) ||
+		errors.Is(err, entity.ErrInvalidDuration) ||
+		errors.Is(err, entity.ErrInvalidEditScope) ||
+		errors.Is(err, entity.ErrNameRequired)
+}
+
+// toSeriesResponse シリーズをレスポンスに変換
+func toSeriesResponse(s *entity.CallSeries) dto.SeriesResponse {
+	return dto.SeriesResponse{
+		SeriesID:        s.SeriesID,
+		Name:            s.Name,
+		RRule:           s.RRule,
+		StartAt:         s.StartAt,
+		DurationMinutes: s.DurationMinutes,
+		Timezone:        s.Timezone,
+		MaxParticipants: s.MaxParticipants,
+		CreatedBy:       s.CreatedBy,
+	}
+}
+
+// toOccurrenceResponse 開催回をレスポンスに変換
+func toOccurrenceResponse(occ *entity.CallOccurrence) dto.OccurrenceResponse {
+	resp := dto.OccurrenceResponse{
+		OccurrenceID:  occ.OriginalStart.UTC().Format(rrule.DateTimeFormat),
+		OriginalStart: occ.OriginalStart,
+		StartAt:       occ.StartAt,
+		EndAt:         occ.EndAt,
+		Name:          occ.Name,
+	}
+	if occ.Room != nil {
+		resp.RoomID = occ.Room.RoomID
+		resp.Status = string(occ.Room.Status)
+	}
+	return resp
+}
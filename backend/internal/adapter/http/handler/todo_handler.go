@@ -78,12 +78,16 @@ func (h *TodoHandler) CreateTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	todo, err := h.usecase.CreateTodo(r.Context(), userID, req.Title, req.Description)
+	todo, err := h.usecase.CreateTodo(r.Context(), userID, req.Title, req.Description, req.AssigneeID)
 	if err != nil {
 		if err == entity.ErrTitleRequired || err == entity.ErrTitleTooLong {
 			respondWithError(w, http.StatusBadRequest, err.Error())
 			return
 		}
+		if err == entity.ErrUserNotFound {
+			respondWithError(w, http.StatusBadRequest, "Assignee not found")
+			return
+		}
 		respondWithError(w, http.StatusInternalServerError, "Failed to create todo")
 		return
 	}
@@ -110,7 +114,7 @@ func (h *TodoHandler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	todo, err := h.usecase.UpdateTodo(r.Context(), id, userID, req.Title, req.Description, req.Completed)
+	todo, err := h.usecase.UpdateTodo(r.Context(), id, userID, req.Title, req.Description, req.Completed, req.AssigneeID)
 	if err != nil {
 		if err == entity.ErrTodoNotFound {
 			respondWithError(w, http.StatusNotFound, "Todo not found")
@@ -120,6 +124,10 @@ func (h *TodoHandler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
 			respondWithError(w, http.StatusBadRequest, err.Error())
 			return
 		}
+		if err == entity.ErrUserNotFound {
+			respondWithError(w, http.StatusBadRequest, "Assignee not found")
+			return
+		}
 		respondWithError(w, http.StatusInternalServerError, "Failed to update todo")
 		return
 	}
@@ -0,0 +1,347 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// TranscriptionHandler 文字起こしジョブ・文字起こしの実行のHTTPハンドラー
+// 文字起こしはワーカーが非同期に実行し、進捗はジョブの取得または /ws/user のイベントで確認する
+type TranscriptionHandler struct {
+	callUsecase      usecase.CallUsecase
+	jobUsecase       usecase.TranscriptionJobUsecase
+	recordingUsecase usecase.RecordingUsecase
+}
+
+// NewTranscriptionHandler 新しい文字起こしハンドラーを作成
+func NewTranscriptionHandler(callUsecase usecase.CallUsecase, jobUsecase usecase.TranscriptionJobUsecase, recordingUsecase usecase.RecordingUsecase) *TranscriptionHandler {
+	return &TranscriptionHandler{
+		callUsecase:      callUsecase,
+		jobUsecase:       jobUsecase,
+		recordingUsecase: recordingUsecase,
+	}
+}
+
+// TranscribeCall 文字起こしを依頼（202でジョブを返す）
+// 議事録がある場合も依頼でき、新しい実行が成功すると現在の実行になって議事録を作り直す
+func (h *TranscriptionHandler) TranscribeCall(w http.ResponseWriter, r *http.Request) {
+	// URLからroom_idを取得
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/transcribe")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	room, ok := h.authorizedRoom(ctx, w, r, roomID)
+	if !ok {
+		return
+	}
+
+	var requestedBy *int64
+	if userID, ok := middleware.GetUserIDFromContext(r.Context()); ok {
+		requestedBy = &userID
+	}
+
+	job, err := h.jobUsecase.Enqueue(ctx, room.ID, requestedBy)
+	if writeTranscriptionError(w, err) {
+		return
+	}
+
+	slog.Info("Transcription requested",
+		slog.String("room_id", roomID),
+		slog.Int64("job_id", job.ID),
+		slog.String("status", string(job.Status)))
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", fmt.Sprintf("/api/calls/rooms/%s/transcription-jobs/%d", room.RoomID, job.ID))
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(toTranscriptionJobResponse(job))
+}
+
+// ListTranscriptionJobs ルームの文字起こしジョブ一覧を取得
+func (h *TranscriptionHandler) ListTranscriptionJobs(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/transcription-jobs")
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	room, ok := h.authorizedRoom(ctx, w, r, roomID)
+	if !ok {
+		return
+	}
+
+	jobs, err := h.jobUsecase.GetJobs(ctx, room.ID, limit)
+	if writeTranscriptionError(w, err) {
+		return
+	}
+
+	resp := dto.TranscriptionJobListResponse{Jobs: make([]dto.TranscriptionJobResponse, len(jobs))}
+	for i, job := range jobs {
+		resp.Jobs[i] = toTranscriptionJobResponse(job)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetTranscriptionJob 文字起こしジョブを取得
+func (h *TranscriptionHandler) GetTranscriptionJob(w http.ResponseWriter, r *http.Request) {
+	roomID, jobID, ok := parseTranscriptionJobPath(r.URL.Path)
+	if !ok {
+		http.Error(w, "Invalid transcription job path", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	room, ok := h.authorizedRoom(ctx, w, r, roomID)
+	if !ok {
+		return
+	}
+
+	job, err := h.jobUsecase.GetJob(ctx, room.ID, jobID)
+	if writeTranscriptionError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toTranscriptionJobResponse(job))
+}
+
+// ListTranscriptionRuns ルームの文字起こしの実行一覧を取得（新しい順）
+func (h *TranscriptionHandler) ListTranscriptionRuns(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/api/calls/rooms/")
+	roomID := strings.TrimSuffix(path, "/transcription-runs")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	room, ok := h.authorizedRoom(ctx, w, r, roomID)
+	if !ok {
+		return
+	}
+
+	runs, err := h.recordingUsecase.GetTranscriptionRuns(ctx, room.ID)
+	if writeTranscriptionError(w, err) {
+		return
+	}
+
+	resp := dto.TranscriptionRunListResponse{Runs: make([]dto.TranscriptionRunResponse, len(runs))}
+	for i, run := range runs {
+		resp.Runs[i] = toTranscriptionRunResponse(run)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetTranscriptionRun 文字起こしの実行とその区間を取得
+func (h *TranscriptionHandler) GetTranscriptionRun(w http.ResponseWriter, r *http.Request) {
+	roomID, runID, ok := parseTranscriptionRunPath(r.URL.Path, "")
+	if !ok {
+		http.Error(w, "Invalid transcription run path", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	room, ok := h.authorizedRoom(ctx, w, r, roomID)
+	if !ok {
+		return
+	}
+
+	run, transcriptions, err := h.recordingUsecase.GetTranscriptionRun(ctx, room.ID, runID)
+	if writeTranscriptionError(w, err) {
+		return
+	}
+
+	resp := dto.TranscriptionRunDetailResponse{
+		TranscriptionRunResponse: toTranscriptionRunResponse(run),
+		Transcriptions:           make([]dto.TranscriptionSegmentResponse, len(transcriptions)),
+	}
+	for i, t := range transcriptions {
+		resp.Transcriptions[i] = dto.TranscriptionSegmentResponse{
+			ID:          t.ID,
+			RecordingID: t.RecordingID,
+			UserID:      t.UserID,
+			Text:        t.Text,
+			Confidence:  t.Confidence,
+			Language:    t.Language,
+			StartedAt:   t.StartedAt,
+			EndedAt:     t.EndedAt,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// SetCurrentTranscriptionRun 現在の実行を切り替えて議事録を作り直す（成功した実行のみ）
+func (h *TranscriptionHandler) SetCurrentTranscriptionRun(w http.ResponseWriter, r *http.Request) {
+	roomID, runID, ok := parseTranscriptionRunPath(r.URL.Path, "/current")
+	if !ok {
+		http.Error(w, "Invalid transcription run path", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	room, ok := h.authorizedRoom(ctx, w, r, roomID)
+	if !ok {
+		return
+	}
+
+	if _, err := h.recordingUsecase.SetCurrentTranscriptionRun(ctx, room.ID, runID); writeTranscriptionError(w, err) {
+		return
+	}
+
+	slog.Info("Current transcription run changed",
+		slog.String("room_id", roomID),
+		slog.Int64("run_id", runID))
+
+	// 切り替え後の実行を返す
+	run, _, err := h.recordingUsecase.GetTranscriptionRun(ctx, room.ID, runID)
+	if writeTranscriptionError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toTranscriptionRunResponse(run))
+}
+
+// authorizedRoom ルームを取得し、文字起こしする権限があるか確認（書き込んだ場合はfalse）
+func (h *TranscriptionHandler) authorizedRoom(ctx context.Context, w http.ResponseWriter, r *http.Request, roomID string) (*entity.CallRoom, bool) {
+	room, err := h.callUsecase.GetRoomByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Error("Failed to get room", slog.String("error", err.Error()))
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return nil, false
+	}
+
+	err = h.callUsecase.Authorize(ctx, room, principalFromContext(r.Context()), entity.PermissionTranscribe)
+	if writeRoleError(w, err) {
+		return nil, false
+	}
+	return room, true
+}
+
+// parseTranscriptionJobPath "/api/calls/rooms/{room_id}/transcription-jobs/{id}" を分解
+func parseTranscriptionJobPath(path string) (string, int64, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/api/calls/rooms/"), "/")
+	if len(parts) != 3 || parts[1] != "transcription-jobs" {
+		return "", 0, false
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return parts[0], id, true
+}
+
+// parseTranscriptionRunPath "/api/calls/rooms/{room_id}/transcription-runs/{id}{suffix}" を分解
+func parseTranscriptionRunPath(path string, suffix string) (string, int64, bool) {
+	if suffix != "" {
+		if !strings.HasSuffix(path, suffix) {
+			return "", 0, false
+		}
+		path = strings.TrimSuffix(path, suffix)
+	}
+	parts := strings.Split(strings.TrimPrefix(path, "/api/calls/rooms/"), "/")
+	if len(parts) != 3 || parts[1] != "transcription-runs" {
+		return "", 0, false
+	}
+	id, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return parts[0], id, true
+}
+
+// writeTranscriptionError 文字起こしのエラーをレスポンスに書き込む（書き込んだらtrue）
+func writeTranscriptionError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.Is(err, entity.ErrTranscriptionJobNotFound):
+		http.Error(w, "Transcription job not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrNoRecordings):
+		http.Error(w, "No recordings to transcribe", http.StatusBadRequest)
+	case errors.Is(err, entity.ErrTranscriptionRunNotFound):
+		http.Error(w, "Transcription run not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrTranscriptionRunNotSucceeded):
+		http.Error(w, "Transcription run has not succeeded", http.StatusConflict)
+	default:
+		slog.Error("Failed to handle transcription job", slog.String("error", err.Error()))
+		http.Error(w, "Failed to handle transcription job", http.StatusInternalServerError)
+	}
+	return true
+}
+
+// toTranscriptionJobResponse ジョブをレスポンスに変換
+func toTranscriptionJobResponse(job *entity.TranscriptionJob) dto.TranscriptionJobResponse {
+	completed, total := job.Progress()
+	resp := dto.TranscriptionJobResponse{
+		ID:            job.ID,
+		Status:        string(job.Status),
+		Attempts:      job.Attempts,
+		Progress:      dto.TranscriptionProgress{Completed: completed, Total: total},
+		Recordings:    make([]dto.TranscriptionRecordingState, len(job.Items)),
+		LastError:     job.LastError,
+		MinutesID:     job.MinutesID,
+		NextAttemptAt: job.NextAttemptAt,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+		CreatedAt:     job.CreatedAt,
+		UpdatedAt:     job.UpdatedAt,
+	}
+	for i, item := range job.Items {
+		resp.Recordings[i] = dto.TranscriptionRecordingState{
+			RecordingID: item.RecordingID,
+			Status:      string(item.Status),
+			Segments:    item.Segments,
+			Error:       item.Error,
+		}
+	}
+	// 実行中のジョブは予定時刻を持たない
+	if job.Status != entity.TranscriptionJobQueued {
+		resp.NextAttemptAt = nil
+	}
+	return resp
+}
+
+// toTranscriptionRunResponse 文字起こしの実行をレスポンスに変換
+func toTranscriptionRunResponse(run *entity.TranscriptionRun) dto.TranscriptionRunResponse {
+	return dto.TranscriptionRunResponse{
+		ID:                   run.ID,
+		JobID:                run.JobID,
+		Engine:               run.Engine,
+		Language:             run.Language,
+		AlternativeLanguages: nonNilStrings(run.AlternativeLanguages),
+		Status:               string(run.Status),
+		IsCurrent:            run.IsCurrent,
+		Segments:             run.Segments,
+		Recordings:           run.Recordings,
+		Error:                run.Error,
+		StartedAt:            run.StartedAt,
+		FinishedAt:           run.FinishedAt,
+		CreatedAt:            run.CreatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/websocket"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/pkg/jwt"
+)
+
+// UserEventHandler ユーザー単位のリアルタイム配信ハンドラー
+type UserEventHandler struct {
+	presenceUsecase usecase.PresenceUsecase
+	jwtService      *jwt.Service
+}
+
+// NewUserEventHandler 新しいユーザーイベントハンドラーを作成
+func NewUserEventHandler(presenceUsecase usecase.PresenceUsecase, jwtService *jwt.Service) *UserEventHandler {
+	return &UserEventHandler{presenceUsecase: presenceUsecase, jwtService: jwtService}
+}
+
+// HandleUserEvents ユーザー単位のWebSocket接続を処理
+// 端末ごとに接続し、接続している間はオンラインとして扱う（ゲストは接続できない）。
+// 接続直後に連絡先の在席状態を送り、以降は着信などそのユーザー宛てのイベントを配信する
+func (h *UserEventHandler) HandleUserEvents(w http.ResponseWriter, r *http.Request) {
+	// クエリパラメータからトークンを取得
+	tokenString := r.URL.Query().Get("token")
+	if tokenString == "" {
+		http.Error(w, "Token required", http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := h.jwtService.ValidateAccessToken(tokenString)
+	if err != nil {
+		slog.Error("Token validation failed", slog.String("error", err.Error()))
+		http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	contacts, sub, err := h.presenceUsecase.Connect(ctx, claims.UserID)
+	if err != nil {
+		slog.Error("Failed to connect user events", slog.String("error", err.Error()))
+		http.Error(w, "Failed to connect", http.StatusInternalServerError)
+		return
+	}
+
+	// 購読が閉じられたら送信側も閉じる
+	messages := make(chan interface{})
+	go func() {
+		defer close(messages)
+		messages <- dto.UserEventMessage{Type: "presence-snapshot", Data: toPresenceResponses(contacts)}
+		for event := range sub.Events {
+			messages <- dto.UserEventMessage{Type: string(event.Type), Data: event.Data}
+		}
+	}()
+
+	websocket.HandleStream(w, r, messages, func() {
+		disconnectCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		h.presenceUsecase.Disconnect(disconnectCtx, sub)
+	})
+}
@@ -0,0 +1,383 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/adapter/http/dto"
+	"Go-Next-WebRTC/internal/adapter/http/middleware"
+	"Go-Next-WebRTC/internal/application/usecase"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// WebhookHandler Webhook関連のHTTPハンドラー
+type WebhookHandler struct {
+	webhookUsecase usecase.WebhookUsecase
+}
+
+// NewWebhookHandler 新しいWebhookハンドラーを作成
+func NewWebhookHandler(webhookUsecase usecase.WebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{webhookUsecase: webhookUsecase}
+}
+
+// CreateWebhook Webhookを登録
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var req dto.CreateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	webhook := &entity.Webhook{
+		UserID: userID,
+		URL:    req.URL,
+		Events: toWebhookEvents(req.Events),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.webhookUsecase.CreateWebhook(ctx, webhook); err != nil {
+		if writeWebhookError(w, err) {
+			return
+		}
+		slog.Error("Failed to create webhook", slog.String("error", err.Error()))
+		http.Error(w, "Failed to create webhook", http.StatusInternalServerError)
+		return
+	}
+
+	slog.Info("Webhook created",
+		slog.Int64("webhook_id", webhook.ID),
+		slog.Int64("user_id", userID))
+
+	// シークレットは登録時のみ返す
+	resp := toWebhookResponse(webhook)
+	resp.Secret = webhook.Secret
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetWebhooks 自分が登録したWebhook一覧を取得
+func (h *WebhookHandler) GetWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhooks, err := h.webhookUsecase.GetWebhooks(ctx, userID)
+	if err != nil {
+		slog.Error("Failed to get webhooks", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get webhooks", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		resp[i] = toWebhookResponse(webhook)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// GetWebhook Webhookを取得
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	webhookID, _, ok := parseWebhookPath(r.URL.Path)
+	if !ok {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhook, err := h.webhookUsecase.GetWebhook(ctx, userID, webhookID)
+	if err != nil {
+		if writeWebhookError(w, err) {
+			return
+		}
+		slog.Error("Failed to get webhook", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get webhook", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(toWebhookResponse(webhook))
+}
+
+// UpdateWebhook Webhookを更新（URL・購読イベント・有効/無効・シークレットの再生成）
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	webhookID, _, ok := parseWebhookPath(r.URL.Path)
+	if !ok {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		slog.Error("Failed to decode request", slog.String("error", err.Error()))
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	update := &usecase.WebhookUpdate{
+		URL:          req.URL,
+		IsActive:     req.IsActive,
+		RotateSecret: req.RotateSecret,
+	}
+	if req.Events != nil {
+		update.Events = toWebhookEvents(req.Events)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhook, err := h.webhookUsecase.UpdateWebhook(ctx, userID, webhookID, update)
+	if err != nil {
+		if writeWebhookError(w, err) {
+			return
+		}
+		slog.Error("Failed to update webhook", slog.String("error", err.Error()))
+		http.Error(w, "Failed to update webhook", http.StatusInternalServerError)
+		return
+	}
+
+	resp := toWebhookResponse(webhook)
+	if req.RotateSecret {
+		resp.Secret = webhook.Secret
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// DeleteWebhook Webhookを削除
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	webhookID, _, ok := parseWebhookPath(r.URL.Path)
+	if !ok {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.webhookUsecase.DeleteWebhook(ctx, userID, webhookID); err != nil {
+		if writeWebhookError(w, err) {
+			return
+		}
+		slog.Error("Failed to delete webhook", slog.String("error", err.Error()))
+		http.Error(w, "Failed to delete webhook", http.StatusInternalServerError)
+		return
+	}
+
+	slog.Info("Webhook deleted",
+		slog.Int64("webhook_id", webhookID),
+		slog.Int64("user_id", userID))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetDeliveries Webhookの配信記録一覧を取得（新しい順）
+// クエリ: limit
+func (h *WebhookHandler) GetDeliveries(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	webhookID, _, ok := parseWebhookPath(r.URL.Path)
+	if !ok {
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+		return
+	}
+
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	deliveries, err := h.webhookUsecase.GetDeliveries(ctx, userID, webhookID, limit)
+	if err != nil {
+		if writeWebhookError(w, err) {
+			return
+		}
+		slog.Error("Failed to get webhook deliveries", slog.String("error", err.Error()))
+		http.Error(w, "Failed to get webhook deliveries", http.StatusInternalServerError)
+		return
+	}
+
+	resp := make([]dto.WebhookDeliveryResponse, len(deliveries))
+	for i, d := range deliveries {
+		resp[i] = toWebhookDeliveryResponse(d)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// ReplayDelivery 配信を再送
+func (h *WebhookHandler) ReplayDelivery(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.GetUserIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// /api/webhooks/{webhookID}/deliveries/{deliveryID}/replay
+	webhookID, deliveryID, ok := parseWebhookPath(strings.TrimSuffix(r.URL.Path, "/replay"))
+	if !ok || deliveryID == 0 {
+		http.Error(w, "Delivery not found", http.StatusNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	delivery, err := h.webhookUsecase.ReplayDelivery(ctx, userID, webhookID, deliveryID)
+	if err != nil {
+		if writeWebhookError(w, err) {
+			return
+		}
+		slog.Error("Failed to replay webhook delivery", slog.String("error", err.Error()))
+		http.Error(w, "Failed to replay webhook delivery", http.StatusInternalServerError)
+		return
+	}
+
+	slog.Info("Webhook delivery replayed",
+		slog.Int64("webhook_id", webhookID),
+		slog.Int64("delivery_id", deliveryID),
+		slog.Int64("replay_id", delivery.ID))
+
+	// 送信は配信ワーカーで行う
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(toWebhookDeliveryResponse(delivery))
+}
+
+// parseWebhookPath /api/webhooks/{webhookID}[/deliveries[/{deliveryID}]] からIDを取得
+func parseWebhookPath(path string) (int64, int64, bool) {
+	path = strings.TrimPrefix(path, "/api/webhooks/")
+	parts := strings.Split(path, "/")
+
+	webhookID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	if len(parts) < 3 || parts[1] != "deliveries" {
+		return webhookID, 0, true
+	}
+
+	deliveryID, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return webhookID, deliveryID, true
+}
+
+// writeWebhookError Webhookのエラーをレスポンスに書き込む（書き込んだ場合true）
+func writeWebhookError(w http.ResponseWriter, err error) bool {
+	switch {
+	case errors.Is(err, entity.ErrWebhookNotFound):
+		http.Error(w, "Webhook not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrWebhookDeliveryNotFound):
+		http.Error(w, "Delivery not found", http.StatusNotFound)
+	case errors.Is(err, entity.ErrInvalidWebhookURL),
+		errors.Is(err, entity.ErrPrivateWebhookURL),
+		errors.Is(err, entity.ErrInvalidWebhookEvent),
+		errors.Is(err, entity.ErrWebhookEventsRequired):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		return false
+	}
+	return true
+}
+
+// toWebhookEvents リクエストのイベント名を変換
+func toWebhookEvents(events []string) []entity.WebhookEvent {
+	result := make([]entity.WebhookEvent, len(events))
+	for i, e := range events {
+		result[i] = entity.WebhookEvent(e)
+	}
+	return result
+}
+
+// toWebhookResponse Webhookをレスポンスに変換（シークレットは含めない）
+func toWebhookResponse(webhook *entity.Webhook) dto.WebhookResponse {
+	events := make([]string, len(webhook.Events))
+	for i, e := range webhook.Events {
+		events[i] = string(e)
+	}
+	return dto.WebhookResponse{
+		ID:        webhook.ID,
+		URL:       webhook.URL,
+		Events:    events,
+		IsActive:  webhook.IsActive,
+		CreatedAt: webhook.CreatedAt,
+		UpdatedAt: webhook.UpdatedAt,
+	}
+}
+
+// toWebhookDeliveryResponse 配信記録をレスポンスに変換
+func toWebhookDeliveryResponse(d *entity.WebhookDelivery) dto.WebhookDeliveryResponse {
+	return dto.WebhookDeliveryResponse{
+		ID:             d.ID,
+		EventID:        d.EventID,
+		Event:          string(d.Event),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		DeliveredAt:    d.DeliveredAt,
+		ReplayOf:       d.ReplayOf,
+		Payload:        json.RawMessage(d.Payload),
+		CreatedAt:      d.CreatedAt,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	"Go-Next-WebRTC/internal/domain/entity"
 	"Go-Next-WebRTC/pkg/jwt"
 )
 
@@ -17,16 +18,27 @@ const (
 	UserIDKey contextKey = "userID"
 	// UserEmailKey コンテキストからユーザーメールを取得するためのキー
 	UserEmailKey contextKey = "userEmail"
+	// GuestKey コンテキストからゲスト情報を取得するためのキー
+	GuestKey contextKey = "guest"
 )
 
+// GuestTokenValidator ゲストトークンの検証
+type GuestTokenValidator interface {
+	ValidateGuestToken(token string) (*entity.GuestPrincipal, error)
+}
+
 // Auth 認証ミドルウェアのファクトリー
 type Auth struct {
-	jwtService *jwt.Service
+	jwtService     *jwt.Service
+	guestValidator GuestTokenValidator
 }
 
 // NewAuth 認証ミドルウェアを作成
-func NewAuth(jwtService *jwt.Service) *Auth {
-	return &Auth{jwtService: jwtService}
+func NewAuth(jwtService *jwt.Service, guestValidator GuestTokenValidator) *Auth {
+	return &Auth{
+		jwtService:     jwtService,
+		guestValidator: guestValidator,
+	}
 }
 
 // Middleware 認証ミドルウェア
@@ -88,6 +100,55 @@ func (a *Auth) OptionalMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// GuestMiddleware ユーザーまたはゲストの認証ミドルウェア
+// ゲストトークンの場合は特定ルームに限定されたゲスト情報をコンテキストに設定する
+func (a *Auth) GuestMiddleware(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tokenString := extractBearerToken(r.Header.Get("Authorization"))
+		if tokenString == "" {
+			println("[AUTH] Missing or invalid Authorization header for", r.URL.Path)
+			respondWithError(w, http.StatusUnauthorized, "Authorization header required")
+			return
+		}
+
+		// 通常のユーザートークンを優先
+		if claims, err := a.jwtService.ValidateAccessToken(tokenString); err == nil {
+			ctx := context.WithValue(r.Context(), UserIDKey, claims.UserID)
+			ctx = context.WithValue(ctx, UserEmailKey, claims.Email)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return
+		}
+
+		if a.guestValidator == nil {
+			respondWithError(w, http.StatusUnauthorized, "Invalid or expired token")
+			return
+		}
+
+		guest, err := a.guestValidator.ValidateGuestToken(tokenString)
+		if err != nil {
+			println("[AUTH] Guest token validation failed for", r.URL.Path, ":", err.Error())
+			respondWithError(w, http.StatusUnauthorized, "Invalid or expired token")
+			return
+		}
+
+		println("[AUTH] Guest authentication successful for", guest.GuestID, "at", r.URL.Path)
+
+		ctx := context.WithValue(r.Context(), GuestKey, guest)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	}
+}
+
+// RequireUser ゲストを拒否し、アカウントを持つユーザーのみ通す
+func RequireUser(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := GetUserIDFromContext(r.Context()); !ok {
+			respondWithError(w, http.StatusForbidden, "Guests are not allowed")
+			return
+		}
+		next.ServeHTTP(w, r)
+	}
+}
+
 // GetUserIDFromContext コンテキストからユーザーIDを取得
 func GetUserIDFromContext(ctx context.Context) (int64, bool) {
 	userID, ok := ctx.Value(UserIDKey).(int64)
@@ -100,6 +161,12 @@ func GetUserEmailFromContext(ctx context.Context) (string, bool) {
 	return email, ok
 }
 
+// GetGuestFromContext コンテキストからゲスト情報を取得
+func GetGuestFromContext(ctx context.Context) (*entity.GuestPrincipal, bool) {
+	guest, ok := ctx.Value(GuestKey).(*entity.GuestPrincipal)
+	return guest, ok
+}
+
 // extractBearerToken Bearerトークンを抽出
 func extractBearerToken(authHeader string) string {
 	const bearerPrefix = "Bearer "
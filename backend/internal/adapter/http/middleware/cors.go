@@ -6,6 +6,14 @@ import (
 	"strings"
 )
 
+// allowedHeaders クライアントが送信できるヘッダー（Upload-*・Tus-Resumableは再開可能なアップロード用）
+const allowedHeaders = "Content-Type, Authorization, X-Room-Passcode, " +
+	"Tus-Resumable, Upload-Length, Upload-Offset, Upload-Metadata, Upload-Checksum"
+
+// exposedHeaders クライアントが読み取れるレスポンスヘッダー
+const exposedHeaders = "Location, Tus-Resumable, Tus-Version, Tus-Extension, Tus-Max-Size, Tus-Checksum-Algorithm, " +
+	"Upload-Length, Upload-Offset, Upload-Expires, X-Recording-Id"
+
 // CORS はCORSヘッダーを追加するミドルウェア
 // 環境変数ALLOWED_ORIGINSで許可するオリジンを指定（カンマ区切り）
 func CORS(next http.Handler) http.Handler {
@@ -34,12 +42,14 @@ func CORS(next http.Handler) http.Handler {
 			w.Header().Set("Access-Control-Allow-Credentials", "true")
 		}
 
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, HEAD, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
+		w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
 		w.Header().Set("Access-Control-Max-Age", "86400") // 24時間キャッシュ
 
 		// Preflightリクエストの処理
-		if r.Method == http.MethodOptions {
+		// tusのOPTIONS（サーバーの対応機能の問い合わせ）はハンドラーに渡す
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
 			w.WriteHeader(http.StatusOK)
 			return
 		}
@@ -1,14 +1,36 @@
 package types
 
 import (
+	"net/http"
+
 	"Go-Next-WebRTC/internal/adapter/http/handler"
 	"Go-Next-WebRTC/internal/adapter/http/middleware"
 )
 
 // Handlers HTTPハンドラー
 type Handlers struct {
-	TodoHandler    *handler.TodoHandler
-	AuthHandler    *handler.AuthHandler
-	CallHandler    *handler.CallHandler
-	AuthMiddleware *middleware.Auth
+	TodoHandler            *handler.TodoHandler
+	AuthHandler            *handler.AuthHandler
+	CallHandler            *handler.CallHandler
+	SeriesHandler          *handler.SeriesHandler
+	InviteHandler          *handler.InviteHandler
+	HistoryHandler         *handler.CallHistoryHandler
+	WebhookHandler         *handler.WebhookHandler
+	LobbyHandler           *handler.LobbyHandler
+	PersonalRoomHandler    *handler.PersonalRoomHandler
+	DirectCallHandler      *handler.DirectCallHandler
+	UserEventHandler       *handler.UserEventHandler
+	PresenceHandler        *handler.PresenceHandler
+	NotificationHandler    *handler.NotificationHandler
+	PushHandler            *handler.PushHandler
+	PollHandler            *handler.PollHandler
+	NotesHandler           *handler.NotesHandler
+	TranscriptionHandler   *handler.TranscriptionHandler
+	RecordingUploadHandler *handler.RecordingUploadHandler
+	DirectUploadHandler    *handler.DirectUploadHandler
+	RecordingMixHandler    *handler.RecordingMixHandler
+	AuthMiddleware         *middleware.Auth
+
+	// ローカルストレージの署名付きURLを処理するハンドラー（他のバックエンドではnil）
+	StorageHandler http.Handler
 }
@@ -0,0 +1,102 @@
+package mixer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/ffmpeg"
+)
+
+// FFmpegMixer ffmpegをローカルで実行して音声をミックス・正規化する
+// ffmpegは入力をシークしながら読むため、トラックを一時ファイルに書き出してから渡す
+type FFmpegMixer struct {
+	client *ffmpeg.Client
+}
+
+// NewFFmpegMixer 新しいFFmpegMixerを作成
+func NewFFmpegMixer(client *ffmpeg.Client) port.AudioMixer {
+	return &FFmpegMixer{client: client}
+}
+
+// Mix トラックを一時ファイルに書き出してミックス
+func (m *FFmpegMixer) Mix(ctx context.Context, tracks []port.AudioTrack) (io.ReadCloser, error) {
+	dir, err := os.MkdirTemp("", "mix-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	inputs := make([]ffmpeg.Input, 0, len(tracks))
+	for i, track := range tracks {
+		path, err := spool(ctx, dir, i, track)
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		inputs = append(inputs, ffmpeg.Input{Path: path, Offset: track.Offset})
+	}
+
+	out, err := m.client.Mix(ctx, inputs)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return &tempOutput{ReadCloser: out, dir: dir}, nil
+}
+
+// Normalize トラックを一時ファイルに書き出して正規化
+func (m *FFmpegMixer) Normalize(ctx context.Context, track port.AudioTrack) (io.ReadCloser, error) {
+	dir, err := os.MkdirTemp("", "mix-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	path, err := spool(ctx, dir, 0, track)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+
+	out, err := m.client.Normalize(ctx, path)
+	if err != nil {
+		os.RemoveAll(dir)
+		return nil, err
+	}
+	return &tempOutput{ReadCloser: out, dir: dir}, nil
+}
+
+// spool トラックをdirに書き出してファイルのパスを返す
+func spool(ctx context.Context, dir string, index int, track port.AudioTrack) (string, error) {
+	body, err := track.Open(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to open audio: %w", err)
+	}
+	defer body.Close()
+
+	path := filepath.Join(dir, fmt.Sprintf("input-%d", index))
+	file, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err := io.Copy(file, body); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to read audio: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to write temp file: %w", err)
+	}
+	return path, nil
+}
+
+// tempOutput 閉じたときに入力の一時ファイルを削除する出力
+type tempOutput struct {
+	io.ReadCloser
+	dir string
+}
+
+func (o *tempOutput) Close() error {
+	err := o.ReadCloser.Close()
+	os.RemoveAll(o.dir)
+	return err
+}
@@ -0,0 +1,189 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// GCSStorage Google Cloud Storageに保存するオブジェクトストレージ
+type GCSStorage struct {
+	client *storage.Client
+	bucket *storage.BucketHandle
+	name   string
+}
+
+// NewGCSStorage 新しいGCSStorageを作成（credentialsFileはサービスアカウントのJSON）
+func NewGCSStorage(ctx context.Context, bucketName, credentialsFile string) (*GCSStorage, error) {
+	client, err := storage.NewClient(ctx, option.WithCredentialsFile(credentialsFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCS client: %w", err)
+	}
+	return &GCSStorage{
+		client: client,
+		bucket: client.Bucket(bucketName),
+		name:   bucketName,
+	}, nil
+}
+
+// Close クライアントを閉じる
+func (s *GCSStorage) Close() error {
+	return s.client.Close()
+}
+
+// Put オブジェクトを保存
+func (s *GCSStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*port.ObjectInfo, error) {
+	key, err := s.objectKey(key)
+	if err != nil {
+		return nil, err
+	}
+	// Closeするまでオブジェクトは確定しないため、失敗時はCloseせずにキャンセルして途中までの内容を残さない
+	writeCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	writer := s.bucket.Object(key).NewWriter(writeCtx)
+	writer.ContentType = contentType
+
+	written, err := io.Copy(writer, body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 && written != size {
+		return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrSizeMismatch, written, size)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return s.objectInfo(writer.Attrs()), nil
+}
+
+// Get オブジェクトを取得
+func (s *GCSStorage) Get(ctx context.Context, key string) (io.ReadCloser, *port.ObjectInfo, error) {
+	key, err := s.objectKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	reader, err := s.bucket.Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, nil, mapGCSError(err)
+	}
+	return reader, &port.ObjectInfo{
+		Key:         key,
+		Size:        reader.Attrs.Size,
+		ContentType: reader.Attrs.ContentType,
+		URI:         s.uri(key),
+		UpdatedAt:   reader.Attrs.LastModified,
+	}, nil
+}
+
+// Stat オブジェクトの情報を取得
+func (s *GCSStorage) Stat(ctx context.Context, key string) (*port.ObjectInfo, error) {
+	key, err := s.objectKey(key)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := s.bucket.Object(key).Attrs(ctx)
+	if err != nil {
+		return nil, mapGCSError(err)
+	}
+	return s.objectInfo(attrs), nil
+}
+
+// Delete オブジェクトを削除
+func (s *GCSStorage) Delete(ctx context.Context, key string) error {
+	key, err := s.objectKey(key)
+	if err != nil {
+		return err
+	}
+	if err := s.bucket.Object(key).Delete(ctx); err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List プレフィックスに一致するオブジェクトを取得
+func (s *GCSStorage) List(ctx context.Context, prefix string) ([]*port.ObjectInfo, error) {
+	var objects []*port.ObjectInfo
+	it := s.bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return objects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, s.objectInfo(attrs))
+	}
+}
+
+// PresignURL 署名付きURL（V4）を発行
+// サイズはContent-Lengthを署名できないため、x-goog-content-length-range を署名に含めて強制する
+func (s *GCSStorage) PresignURL(ctx context.Context, key string, opts port.PresignOptions) (*port.PresignedURL, error) {
+	key, err := s.objectKey(key)
+	if err != nil {
+		return nil, err
+	}
+	signOpts := &storage.SignedURLOptions{
+		Scheme:  storage.SigningSchemeV4,
+		Method:  opts.Method,
+		Expires: time.Now().Add(opts.Expires),
+	}
+	headers := map[string]string{}
+	if opts.Method == http.MethodPut {
+		if opts.ContentType != "" {
+			signOpts.ContentType = opts.ContentType
+			headers["Content-Type"] = opts.ContentType
+		}
+		if opts.ContentLength > 0 {
+			lengthRange := fmt.Sprintf("%d,%d", opts.ContentLength, opts.ContentLength)
+			signOpts.Headers = []string{"x-goog-content-length-range:" + lengthRange}
+			headers["x-goog-content-length-range"] = lengthRange
+		}
+	}
+	url, err := s.bucket.SignedURL(key, signOpts)
+	if err != nil {
+		return nil, err
+	}
+	return &port.PresignedURL{URL: url, Headers: headers}, nil
+}
+
+// objectKey 以前の録音で保存していた gs://bucket/key 形式も受け付ける
+func (s *GCSStorage) objectKey(key string) (string, error) {
+	key = strings.TrimPrefix(key, "gs://"+s.name+"/")
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+func (s *GCSStorage) uri(key string) string {
+	return "gs://" + s.name + "/" + key
+}
+
+func (s *GCSStorage) objectInfo(attrs *storage.ObjectAttrs) *port.ObjectInfo {
+	return &port.ObjectInfo{
+		Key:         attrs.Name,
+		Size:        attrs.Size,
+		ContentType: attrs.ContentType,
+		ETag:        attrs.Etag,
+		URI:         s.uri(attrs.Name),
+		UpdatedAt:   attrs.Updated,
+	}
+}
+
+func mapGCSError(err error) error {
+	if errors.Is(err, storage.ErrObjectNotExist) {
+		return port.ErrObjectNotFound
+	}
+	return err
+}
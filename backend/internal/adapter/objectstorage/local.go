@@ -0,0 +1,346 @@
+package objectstorage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// LocalPresignPath ローカルストレージの署名付きURLを受け付けるパス
+const LocalPresignPath = "/api/storage/"
+
+// localUploadTimeout 署名付きURLでのアップロード1回にかける時間の上限
+const localUploadTimeout = time.Hour
+
+// localMeta オブジェクトと一緒に保存するメタデータ
+type localMeta struct {
+	ContentType string `json:"content_type"`
+	ETag        string `json:"etag"`
+}
+
+// LocalStorage ローカルファイルシステムに保存するオブジェクトストレージ（開発環境・オンプレミス向け）
+// 署名付きURLはこのサーバーのLocalPresignPathを指し、ServeHTTPで検証して読み書きする
+type LocalStorage struct {
+	objects string // オブジェクトの保存先
+	meta    string // メタデータの保存先
+	baseURL string // 署名付きURLのベースURL（APIサーバーの公開URL）
+	secret  []byte
+	now     func() time.Time
+}
+
+// NewLocalStorage 新しいローカルストレージを作成
+func NewLocalStorage(dir, baseURL string, secret []byte) (*LocalStorage, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	s := &LocalStorage{
+		objects: filepath.Join(abs, "objects"),
+		meta:    filepath.Join(abs, "meta"),
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  secret,
+		now:     time.Now,
+	}
+	for _, d := range []string{s.objects, s.meta} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create storage directory: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// Put オブジェクトを保存（一時ファイルに書き込んでから置き換える）
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*port.ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	file := s.objectPath(key)
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(file), ".tmp-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := md5.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), &contextReader{ctx: ctx, r: body})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 && written != size {
+		return nil, fmt.Errorf("%w: got %d bytes, want %d", ErrSizeMismatch, written, size)
+	}
+
+	meta := localMeta{ContentType: contentType, ETag: hex.EncodeToString(hash.Sum(nil))}
+	if err := s.writeMeta(key, meta); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(tmp.Name(), file); err != nil {
+		return nil, err
+	}
+	return s.Stat(ctx, key)
+}
+
+// Get オブジェクトを取得
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, *port.ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(s.objectPath(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, port.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := s.Stat(ctx, key)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, info, nil
+}
+
+// Stat オブジェクトの情報を取得
+func (s *LocalStorage) Stat(ctx context.Context, key string) (*port.ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	file := s.objectPath(key)
+	stat, err := os.Stat(file)
+	if errors.Is(err, fs.ErrNotExist) || (err == nil && stat.IsDir()) {
+		return nil, port.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var meta localMeta
+	if data, err := os.ReadFile(s.metaPath(key)); err == nil {
+		json.Unmarshal(data, &meta)
+	}
+	return &port.ObjectInfo{
+		Key:         key,
+		Size:        stat.Size(),
+		ContentType: meta.ContentType,
+		ETag:        meta.ETag,
+		URI:         (&url.URL{Scheme: "file", Path: filepath.ToSlash(file)}).String(),
+		UpdatedAt:   stat.ModTime(),
+	}, nil
+}
+
+// Delete オブジェクトを削除
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	if err := os.Remove(s.objectPath(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(s.metaPath(key)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// List プレフィックスに一致するオブジェクトを取得
+func (s *LocalStorage) List(ctx context.Context, prefix string) ([]*port.ObjectInfo, error) {
+	// プレフィックスのディレクトリ部分から探索する
+	start := s.objects
+	if dir := path.Dir(prefix); dir != "." && validateKey(dir) == nil {
+		start = s.objectPath(dir)
+	}
+
+	var objects []*port.ObjectInfo
+	err := filepath.WalkDir(start, func(file string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.objects, file)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := s.Stat(ctx, key)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, info)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// PresignURL このサーバーで検証する署名付きURLを発行
+func (s *LocalStorage) PresignURL(ctx context.Context, key string, opts port.PresignOptions) (*port.PresignedURL, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	if opts.Method != http.MethodGet && opts.Method != http.MethodPut {
+		return nil, fmt.Errorf("unsupported presign method: %s", opts.Method)
+	}
+
+	headers := map[string]string{}
+	query := url.Values{}
+	query.Set("method", opts.Method)
+	query.Set("expires", strconv.FormatInt(s.now().Add(opts.Expires).Unix(), 10))
+	if opts.ContentType != "" {
+		query.Set("content_type", opts.ContentType)
+		headers["Content-Type"] = opts.ContentType
+	}
+	if opts.ContentLength > 0 {
+		query.Set("content_length", strconv.FormatInt(opts.ContentLength, 10))
+	}
+	query.Set("signature", s.sign(key, query))
+
+	escaped := (&url.URL{Path: key}).EscapedPath()
+	return &port.PresignedURL{
+		URL:     s.baseURL + LocalPresignPath + escaped + "?" + query.Encode(),
+		Headers: headers,
+	}, nil
+}
+
+// ServeHTTP 署名付きURLでの取得・アップロードを処理
+func (s *LocalStorage) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, LocalPresignPath)
+	query := r.URL.Query()
+	method := query.Get("method")
+	if r.Method == http.MethodHead && method == http.MethodGet {
+		method = http.MethodHead
+	}
+	if validateKey(key) != nil || method != r.Method || !s.verify(key, query) {
+		http.Error(w, "Invalid signature", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		f, err := os.Open(s.objectPath(key))
+		if err != nil {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		defer f.Close()
+		stat, err := f.Stat()
+		if err != nil || stat.IsDir() {
+			http.Error(w, "Not found", http.StatusNotFound)
+			return
+		}
+		if info, err := s.Stat(r.Context(), key); err == nil && info.ContentType != "" {
+			w.Header().Set("Content-Type", info.ContentType)
+		}
+		http.ServeContent(w, r, path.Base(key), stat.ModTime(), f)
+
+	case http.MethodPut:
+		contentType := r.Header.Get("Content-Type")
+		if want := query.Get("content_type"); want != "" && contentType != want {
+			http.Error(w, "Content-Type does not match the signed URL", http.StatusForbidden)
+			return
+		}
+		size := r.ContentLength
+		if want := query.Get("content_length"); want != "" && strconv.FormatInt(size, 10) != want {
+			http.Error(w, "Content-Length does not match the signed URL", http.StatusForbidden)
+			return
+		}
+		// 録音の直接アップロードはサーバー全体の読み込みのタイムアウトより時間がかかる
+		if err := http.NewResponseController(w).SetReadDeadline(time.Now().Add(localUploadTimeout)); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			http.Error(w, "Failed to accept upload", http.StatusInternalServerError)
+			return
+		}
+		info, err := s.Put(r.Context(), key, r.Body, size, contentType)
+		if err != nil {
+			http.Error(w, "Failed to store object", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("ETag", `"`+info.ETag+`"`)
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// sign 署名付きURLの署名（キーとクエリパラメータのHMAC）
+func (s *LocalStorage) sign(key string, query url.Values) string {
+	mac := hmac.New(sha256.New, s.secret)
+	for _, part := range []string{key, query.Get("method"), query.Get("expires"), query.Get("content_type"), query.Get("content_length")} {
+		mac.Write([]byte(part))
+		mac.Write([]byte{0})
+	}
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verify 署名と有効期限を検証
+func (s *LocalStorage) verify(key string, query url.Values) bool {
+	expires, err := strconv.ParseInt(query.Get("expires"), 10, 64)
+	if err != nil || s.now().Unix() > expires {
+		return false
+	}
+	return hmac.Equal([]byte(s.sign(key, query)), []byte(query.Get("signature")))
+}
+
+func (s *LocalStorage) objectPath(key string) string {
+	return filepath.Join(s.objects, filepath.FromSlash(key))
+}
+
+func (s *LocalStorage) metaPath(key string) string {
+	return filepath.Join(s.meta, filepath.FromSlash(key)+".json")
+}
+
+func (s *LocalStorage) writeMeta(key string, meta localMeta) error {
+	file := s.metaPath(key)
+	if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0o644)
+}
+
+// contextReader キャンセルされたら読み込みを中断するReader
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
@@ -0,0 +1,222 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+func TestLocalStorage_Objects(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewLocalStorage(dir, "http://api.test", []byte("secret"))
+	if err != nil {
+		t.Fatalf("NewLocalStorage() unexpected error = %v", err)
+	}
+	ctx := context.Background()
+
+	// サイズが不明な本文（-1）はそのまま保存する
+	puts := []struct {
+		key  string
+		body string
+		size int64
+	}{
+		{key: "recordings/1/user-1.webm", body: "audio of user-1", size: 15},
+		{key: "recordings/1/user-2.webm", body: "audio of user-2", size: -1},
+		{key: "recordings/2/user-1.webm", body: "other room", size: -1},
+	}
+	for _, put := range puts {
+		info, err := storage.Put(ctx, put.key, strings.NewReader(put.body), put.size, "audio/webm")
+		if err != nil {
+			t.Fatalf("Put(%q) unexpected error = %v", put.key, err)
+		}
+		if info.Key != put.key || info.Size != int64(len(put.body)) || info.ContentType != "audio/webm" || info.ETag == "" {
+			t.Errorf("Put(%q) = %+v", put.key, info)
+		}
+	}
+
+	body, info, err := storage.Get(ctx, "recordings/1/user-2.webm")
+	if err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "audio of user-2" || info.ContentType != "audio/webm" {
+		t.Errorf("Get() = %q, %+v", data, info)
+	}
+
+	objects, err := storage.List(ctx, "recordings/1/")
+	if err != nil {
+		t.Fatalf("List() unexpected error = %v", err)
+	}
+	if len(objects) != 2 || objects[0].Key != "recordings/1/user-1.webm" || objects[1].Key != "recordings/1/user-2.webm" {
+		t.Errorf("List() = %+v, want the two objects of room 1", objects)
+	}
+
+	if err := storage.Delete(ctx, "recordings/1/user-1.webm"); err != nil {
+		t.Fatalf("Delete() unexpected error = %v", err)
+	}
+	if _, err := storage.Stat(ctx, "recordings/1/user-1.webm"); !errors.Is(err, port.ErrObjectNotFound) {
+		t.Errorf("Stat() after delete error = %v, want ErrObjectNotFound", err)
+	}
+	if err := storage.Delete(ctx, "recordings/1/user-1.webm"); err != nil {
+		t.Errorf("Delete() of a missing object error = %v, want nil", err)
+	}
+}
+
+func TestLocalStorage_PutRejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		size    int64
+		wantErr error
+	}{
+		{name: "parent traversal", key: "../escape.webm", size: 4, wantErr: ErrInvalidKey},
+		{name: "nested traversal", key: "recordings/../../escape.webm", size: 4, wantErr: ErrInvalidKey},
+		{name: "absolute path", key: "/tmp/escape.webm", size: 4, wantErr: ErrInvalidKey},
+		{name: "shorter body", key: "recordings/1/short.webm", size: 10, wantErr: ErrSizeMismatch},
+		{name: "longer body", key: "recordings/1/long.webm", size: 2, wantErr: ErrSizeMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			dir := t.TempDir()
+			storage, _ := NewLocalStorage(filepath.Join(dir, "storage"), "http://api.test", []byte("secret"))
+			ctx := context.Background()
+
+			// Act
+			_, err := storage.Put(ctx, tt.key, strings.NewReader("data"), tt.size, "audio/webm")
+
+			// Assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Put() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "escape.webm")); !errors.Is(err, os.ErrNotExist) {
+				t.Error("Put() wrote outside the storage directory")
+			}
+			if objects, _ := storage.List(ctx, ""); len(objects) != 0 {
+				t.Errorf("List() = %+v, want nothing stored", objects)
+			}
+		})
+	}
+}
+
+func TestLocalStorage_ServeHTTP(t *testing.T) {
+	const key = "recordings/1/direct.webm"
+	data := "0123456789"
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string     // 署名付きURLのパスを差し替える場合のパス
+		query      url.Values // 署名付きURLのクエリに上書きする値
+		later      time.Duration
+		header     string
+		body       string
+		wantStatus int
+	}{
+		{name: "valid upload", method: http.MethodPut, header: "audio/webm", body: data, wantStatus: http.StatusOK},
+		{name: "wrong method", method: http.MethodPost, header: "audio/webm", body: data, wantStatus: http.StatusForbidden},
+		{name: "download with upload url", method: http.MethodGet, wantStatus: http.StatusForbidden},
+		{name: "expired url", method: http.MethodPut, later: 16 * time.Minute, header: "audio/webm", body: data, wantStatus: http.StatusForbidden},
+		{name: "tampered content_type", method: http.MethodPut, query: url.Values{"content_type": {"video/mp4"}}, header: "video/mp4", body: data, wantStatus: http.StatusForbidden},
+		{name: "tampered content_length", method: http.MethodPut, query: url.Values{"content_length": {"11"}}, header: "audio/webm", body: data + "!", wantStatus: http.StatusForbidden},
+		{name: "tampered key", method: http.MethodPut, path: LocalPresignPath + "recordings/2/direct.webm", header: "audio/webm", body: data, wantStatus: http.StatusForbidden},
+		{name: "other content type", method: http.MethodPut, header: "video/mp4", body: data, wantStatus: http.StatusForbidden},
+		{name: "other size", method: http.MethodPut, header: "audio/webm", body: data[:5], wantStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			storage, _ := NewLocalStorage(t.TempDir(), "http://api.test", []byte("secret"))
+			now := time.Now()
+			storage.now = func() time.Time { return now }
+			ctx := context.Background()
+
+			presigned, err := storage.PresignURL(ctx, key, port.PresignOptions{
+				Method:        http.MethodPut,
+				Expires:       15 * time.Minute,
+				ContentType:   "audio/webm",
+				ContentLength: int64(len(data)),
+			})
+			if err != nil {
+				t.Fatalf("PresignURL() unexpected error = %v", err)
+			}
+			target, _ := url.Parse(presigned.URL)
+			query := target.Query()
+			for name, values := range tt.query {
+				query[name] = values
+			}
+			target.RawQuery = query.Encode()
+			if tt.path != "" {
+				target.Path = tt.path
+			}
+			storage.now = func() time.Time { return now.Add(tt.later) }
+
+			// Act
+			req := httptest.NewRequest(tt.method, target.String(), strings.NewReader(tt.body))
+			if tt.header != "" {
+				req.Header.Set("Content-Type", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			storage.ServeHTTP(rec, req)
+
+			// Assert
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			info, err := storage.Stat(ctx, key)
+			if tt.wantStatus != http.StatusOK {
+				if !errors.Is(err, port.ErrObjectNotFound) {
+					t.Errorf("Stat() = %+v, %v, want nothing stored", info, err)
+				}
+				return
+			}
+			if err != nil || info.Size != int64(len(data)) || rec.Header().Get("ETag") != `"`+info.ETag+`"` {
+				t.Errorf("Stat() = %+v, %v, ETag header %q", info, err, rec.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestLocalStorage_ServeHTTPDownload(t *testing.T) {
+	storage, _ := NewLocalStorage(t.TempDir(), "http://api.test", []byte("secret"))
+	ctx := context.Background()
+	key := "recordings/1/user 1.webm"
+	if _, err := storage.Put(ctx, key, strings.NewReader("audio"), 5, "audio/webm"); err != nil {
+		t.Fatalf("Put() unexpected error = %v", err)
+	}
+
+	presigned, err := storage.PresignURL(ctx, key, port.PresignOptions{Method: http.MethodGet, Expires: time.Minute})
+	if err != nil {
+		t.Fatalf("PresignURL() unexpected error = %v", err)
+	}
+	for _, method := range []string{http.MethodGet, http.MethodHead} {
+		rec := httptest.NewRecorder()
+		storage.ServeHTTP(rec, httptest.NewRequest(method, presigned.URL, nil))
+		if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "audio/webm" {
+			t.Errorf("%s status = %d, Content-Type = %q", method, rec.Code, rec.Header().Get("Content-Type"))
+		}
+		if method == http.MethodGet && rec.Body.String() != "audio" {
+			t.Errorf("GET body = %q, want audio", rec.Body.String())
+		}
+	}
+
+	// ダウンロード用のURLではアップロードできない
+	rec := httptest.NewRecorder()
+	storage.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, presigned.URL, strings.NewReader("overwrite")))
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("PUT with download url status = %d, want 403", rec.Code)
+	}
+}
@@ -0,0 +1,27 @@
+// Package objectstorage port.ObjectStorageの実装（ローカル・S3互換・GCS）
+package objectstorage
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrInvalidKey オブジェクトキーが不正（空、絶対パス、".."を含むなど）
+	ErrInvalidKey = errors.New("invalid object key")
+	// ErrSizeMismatch 書き込んだサイズが指定されたサイズと一致しない
+	ErrSizeMismatch = errors.New("object size mismatch")
+)
+
+// validateKey バックエンドに関係なく同じキーだけを受け付ける
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package objectstorage
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key     string
+		wantErr bool
+	}{
+		{key: "recordings/1/user-1.webm"},
+		{key: "recordings/1/user 2.webm"},
+		{key: "", wantErr: true},
+		{key: "/etc/passwd", wantErr: true},
+		{key: "../escape", wantErr: true},
+		{key: "recordings/../../escape", wantErr: true},
+		{key: "recordings/./user-1.webm", wantErr: true},
+		{key: "recordings//user-1.webm", wantErr: true},
+		{key: "recordings/", wantErr: true},
+		{key: `recordings\..\escape`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			err := validateKey(tt.key)
+			if tt.wantErr && !errors.Is(err, ErrInvalidKey) {
+				t.Errorf("validateKey(%q) error = %v, want ErrInvalidKey", tt.key, err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validateKey(%q) unexpected error = %v", tt.key, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package objectstorage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/s3"
+)
+
+// S3Storage S3互換ストレージ（AWS S3・MinIOなど）に保存するオブジェクトストレージ
+type S3Storage struct {
+	client *s3.Client
+}
+
+// NewS3Storage 新しいS3Storageを作成
+func NewS3Storage(client *s3.Client) *S3Storage {
+	return &S3Storage{client: client}
+}
+
+// Put オブジェクトを保存
+// サイズが不明な場合は一時ファイルに書き出してからアップロードする（S3はContent-Lengthが必須）
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*port.ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	if size < 0 {
+		spool, err := os.CreateTemp("", "upload-*")
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(spool.Name())
+		defer spool.Close()
+
+		if size, err = io.Copy(spool, body); err != nil {
+			return nil, err
+		}
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		body = spool
+	}
+
+	object, err := s.client.PutObject(ctx, key, body, size, contentType)
+	if err != nil {
+		return nil, err
+	}
+	return s.objectInfo(object), nil
+}
+
+// Get オブジェクトを取得
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, *port.ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, nil, err
+	}
+	body, object, err := s.client.GetObject(ctx, key)
+	if err != nil {
+		return nil, nil, mapS3Error(err)
+	}
+	return body, s.objectInfo(object), nil
+}
+
+// Stat オブジェクトの情報を取得
+func (s *S3Storage) Stat(ctx context.Context, key string) (*port.ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	object, err := s.client.HeadObject(ctx, key)
+	if err != nil {
+		return nil, mapS3Error(err)
+	}
+	return s.objectInfo(object), nil
+}
+
+// Delete オブジェクトを削除
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	return s.client.DeleteObject(ctx, key)
+}
+
+// List プレフィックスに一致するオブジェクトを取得
+func (s *S3Storage) List(ctx context.Context, prefix string) ([]*port.ObjectInfo, error) {
+	objects, err := s.client.ListObjects(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*port.ObjectInfo, 0, len(objects))
+	for _, object := range objects {
+		infos = append(infos, s.objectInfo(object))
+	}
+	return infos, nil
+}
+
+// PresignURL 署名付きURLを発行
+// Content-Type・Content-Lengthを指定した場合は署名に含め、異なる値でのアップロードを拒否させる
+// Content-Lengthはクライアントが本文から設定するため、送信するヘッダーには含めない
+func (s *S3Storage) PresignURL(ctx context.Context, key string, opts port.PresignOptions) (*port.PresignedURL, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+	headers := http.Header{}
+	send := map[string]string{}
+	if opts.ContentType != "" {
+		headers.Set("Content-Type", opts.ContentType)
+		send["Content-Type"] = opts.ContentType
+	}
+	if opts.ContentLength > 0 {
+		headers.Set("Content-Length", strconv.FormatInt(opts.ContentLength, 10))
+	}
+	return &port.PresignedURL{
+		URL:     s.client.PresignURL(opts.Method, key, headers, opts.Expires),
+		Headers: send,
+	}, nil
+}
+
+func (s *S3Storage) objectInfo(object *s3.Object) *port.ObjectInfo {
+	return &port.ObjectInfo{
+		Key:         object.Key,
+		Size:        object.Size,
+		ContentType: object.ContentType,
+		ETag:        object.ETag,
+		URI:         "s3://" + s.client.Bucket() + "/" + object.Key,
+		UpdatedAt:   object.LastModified,
+	}
+}
+
+func mapS3Error(err error) error {
+	if errors.Is(err, s3.ErrNotFound) {
+		return port.ErrObjectNotFound
+	}
+	return err
+}
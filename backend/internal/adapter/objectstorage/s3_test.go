@@ -0,0 +1,142 @@
+package objectstorage
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/s3"
+	"Go-Next-WebRTC/pkg/s3/s3test"
+)
+
+func TestS3Storage_Objects(t *testing.T) {
+	server := s3test.NewServer("recordings")
+	defer server.Close()
+	client, err := s3.NewClient(server.Config("recordings"))
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error = %v", err)
+	}
+	storage := NewS3Storage(client)
+	ctx := context.Background()
+
+	// サイズが不明な本文は一時ファイルに書き出してからアップロードされる
+	unsized := io.MultiReader(strings.NewReader("audio of "), strings.NewReader("user-2"))
+	puts := []struct {
+		key  string
+		body io.Reader
+		size int64
+	}{
+		{key: "recordings/1/user-1.webm", body: strings.NewReader("audio of user-1"), size: 15},
+		{key: "recordings/1/user-2.webm", body: unsized, size: -1},
+		{key: "recordings/2/user-1.webm", body: strings.NewReader("other room"), size: 10},
+	}
+	for _, put := range puts {
+		info, err := storage.Put(ctx, put.key, put.body, put.size, "audio/webm")
+		if err != nil {
+			t.Fatalf("Put(%q) unexpected error = %v", put.key, err)
+		}
+		if info.Key != put.key || info.URI != "s3://recordings/"+put.key || info.ETag == "" {
+			t.Errorf("Put(%q) = %+v", put.key, info)
+		}
+	}
+
+	body, info, err := storage.Get(ctx, "recordings/1/user-2.webm")
+	if err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "audio of user-2" || info.Size != 15 || info.ContentType != "audio/webm" {
+		t.Errorf("Get() = %q, %+v", data, info)
+	}
+
+	if info, err := storage.Stat(ctx, "recordings/1/user-1.webm"); err != nil || info.Size != 15 {
+		t.Errorf("Stat() = %+v, %v, want 15 bytes", info, err)
+	}
+
+	objects, err := storage.List(ctx, "recordings/1/")
+	if err != nil {
+		t.Fatalf("List() unexpected error = %v", err)
+	}
+	if len(objects) != 2 || objects[0].Key != "recordings/1/user-1.webm" || objects[1].Key != "recordings/1/user-2.webm" {
+		t.Errorf("List() = %+v, want the two objects of room 1", objects)
+	}
+
+	if err := storage.Delete(ctx, "recordings/1/user-1.webm"); err != nil {
+		t.Fatalf("Delete() unexpected error = %v", err)
+	}
+	if _, err := storage.Stat(ctx, "recordings/1/user-1.webm"); !errors.Is(err, port.ErrObjectNotFound) {
+		t.Errorf("Stat() after delete error = %v, want ErrObjectNotFound", err)
+	}
+	if _, _, err := storage.Get(ctx, "recordings/1/user-1.webm"); !errors.Is(err, port.ErrObjectNotFound) {
+		t.Errorf("Get() after delete error = %v, want ErrObjectNotFound", err)
+	}
+
+	if _, err := storage.Put(ctx, "../escape", strings.NewReader("x"), 1, "text/plain"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Put() with traversal key error = %v, want ErrInvalidKey", err)
+	}
+}
+
+func TestS3Storage_PresignURL(t *testing.T) {
+	server := s3test.NewServer("recordings")
+	defer server.Close()
+	client, _ := s3.NewClient(server.Config("recordings"))
+	storage := NewS3Storage(client)
+	ctx := context.Background()
+
+	key := "recordings/1/direct.webm"
+	data := []byte("0123456789")
+	upload, err := storage.PresignURL(ctx, key, port.PresignOptions{
+		Method:        http.MethodPut,
+		Expires:       15 * time.Minute,
+		ContentType:   "audio/webm",
+		ContentLength: int64(len(data)),
+	})
+	if err != nil {
+		t.Fatalf("PresignURL() unexpected error = %v", err)
+	}
+	// Content-Lengthは本文から設定されるため送信するヘッダーに含めない
+	if len(upload.Headers) != 1 || upload.Headers["Content-Type"] != "audio/webm" {
+		t.Errorf("PresignURL() headers = %v, want only Content-Type", upload.Headers)
+	}
+
+	put := func(contentType string, body []byte) int {
+		req, _ := http.NewRequest(http.MethodPut, upload.URL, bytes.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("upload failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if status := put("video/mp4", data); status != http.StatusForbidden {
+		t.Errorf("upload with another content type status = %d, want 403", status)
+	}
+	if status := put("audio/webm", data[:5]); status != http.StatusForbidden {
+		t.Errorf("upload with another size status = %d, want 403", status)
+	}
+	if status := put(upload.Headers["Content-Type"], data); status != http.StatusOK {
+		t.Fatalf("upload status = %d, want 200", status)
+	}
+
+	download, err := storage.PresignURL(ctx, key, port.PresignOptions{Method: http.MethodGet, Expires: time.Minute})
+	if err != nil {
+		t.Fatalf("PresignURL() unexpected error = %v", err)
+	}
+	resp, err := http.Get(download.URL)
+	if err != nil {
+		t.Fatalf("download failed: %v", err)
+	}
+	got, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !bytes.Equal(got, data) {
+		t.Errorf("download = %d %q, want 200 %q", resp.StatusCode, got, data)
+	}
+}
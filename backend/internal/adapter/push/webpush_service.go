@@ -0,0 +1,53 @@
+package push
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/webpush"
+)
+
+// WebPushService Web Push プロトコルでプッシュサービスへ送信する
+type WebPushService struct {
+	client *webpush.Client
+}
+
+// NewWebPushService 新しいWebPushServiceを作成
+func NewWebPushService(client *webpush.Client) port.PushService {
+	return &WebPushService{client: client}
+}
+
+// Send 購読先へ通知を送信
+func (s *WebPushService) Send(ctx context.Context, subscription *entity.PushSubscription, payload []byte, options entity.PushOptions) error {
+	resp, err := s.client.Send(ctx, &webpush.Message{
+		Endpoint: subscription.Endpoint,
+		P256dh:   subscription.P256dh,
+		Auth:     subscription.Auth,
+		Payload:  payload,
+		TTL:      options.TTL,
+		Urgency:  webpush.Urgency(options.Urgency),
+		Topic:    options.Topic,
+	})
+	if errors.Is(err, webpush.ErrInvalidKey) {
+		// 鍵が壊れている購読には今後も送れない
+		return entity.ErrPushSubscriptionGone
+	}
+	if err != nil {
+		return err
+	}
+	if resp.Gone() {
+		return entity.ErrPushSubscriptionGone
+	}
+	if !resp.OK() {
+		return fmt.Errorf("push service responded %d: %s", resp.StatusCode, resp.Body)
+	}
+	return nil
+}
+
+// PublicKey VAPIDの公開鍵
+func (s *WebPushService) PublicKey() string {
+	return s.client.PublicKey()
+}
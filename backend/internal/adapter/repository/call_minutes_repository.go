@@ -3,7 +3,6 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"errors"
 
 	"Go-Next-WebRTC/internal/domain/entity"
 	"Go-Next-WebRTC/internal/domain/port"
@@ -22,15 +21,23 @@ func NewMySQLCallMinutesRepository(db *database.MySQL) port.CallMinutesRepositor
 // Create 議事録を作成
 func (r *MySQLCallMinutesRepository) Create(ctx context.Context, minutes *entity.CallMinutes) error {
 	query := `
-		INSERT INTO call_minutes (room_id, title, summary, full_transcript, participants_list, email_sent)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO call_minutes (room_id, title, summary, full_transcript, participants_list, poll_results, notes, languages, run_id, email_sent)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
+	languages, err := encodeStringList(minutes.Languages)
+	if err != nil {
+		return err
+	}
 	result, err := r.db.ExecContext(ctx, query,
 		minutes.RoomID,
 		minutes.Title,
 		minutes.Summary,
 		minutes.FullTranscript,
 		minutes.ParticipantsList,
+		minutes.PollResults,
+		minutes.Notes,
+		languages,
+		minutes.RunID,
 		minutes.EmailSent,
 	)
 	if err != nil {
@@ -50,13 +57,21 @@ func (r *MySQLCallMinutesRepository) Create(ctx context.Context, minutes *entity
 func (r *MySQLCallMinutesRepository) Update(ctx context.Context, minutes *entity.CallMinutes) error {
 	query := `
 		UPDATE call_minutes
-		SET summary = ?, full_transcript = ?, participants_list = ?, email_sent = ?, email_sent_at = ?, updated_at = CURRENT_TIMESTAMP
+		SET summary = ?, full_transcript = ?, participants_list = ?, poll_results = ?, notes = ?, languages = ?, run_id = ?, email_sent = ?, email_sent_at = ?, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	languages, err := encodeStringList(minutes.Languages)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query,
 		minutes.Summary,
 		minutes.FullTranscript,
 		minutes.ParticipantsList,
+		minutes.PollResults,
+		minutes.Notes,
+		languages,
+		minutes.RunID,
 		minutes.EmailSent,
 		minutes.EmailSentAt,
 		minutes.ID,
@@ -67,26 +82,13 @@ func (r *MySQLCallMinutesRepository) Update(ctx context.Context, minutes *entity
 // FindByRoomID ルームの議事録を取得
 func (r *MySQLCallMinutesRepository) FindByRoomID(ctx context.Context, roomID int64) (*entity.CallMinutes, error) {
 	query := `
-		SELECT id, room_id, title, summary, full_transcript, participants_list, email_sent, email_sent_at, created_at, updated_at
+		SELECT id, room_id, title, summary, full_transcript, participants_list, poll_results, notes, languages, run_id, email_sent, email_sent_at, created_at, updated_at
 		FROM call_minutes
 		WHERE room_id = ?
 	`
-	m := &entity.CallMinutes{}
-	err := r.db.QueryRowContext(ctx, query, roomID).Scan(
-		&m.ID,
-		&m.RoomID,
-		&m.Title,
-		&m.Summary,
-		&m.FullTranscript,
-		&m.ParticipantsList,
-		&m.EmailSent,
-		&m.EmailSentAt,
-		&m.CreatedAt,
-		&m.UpdatedAt,
-	)
-
+	m, err := scanCallMinutes(r.db.QueryRowContext(ctx, query, roomID))
 	if err == sql.ErrNoRows {
-		return nil, errors.New("minutes not found")
+		return nil, entity.ErrMinutesNotFound
 	}
 	if err != nil {
 		return nil, err
@@ -98,7 +100,7 @@ func (r *MySQLCallMinutesRepository) FindByRoomID(ctx context.Context, roomID in
 // FindByUserID ユーザーの議事録一覧を取得（参加した通話）
 func (r *MySQLCallMinutesRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.CallMinutes, error) {
 	query := `
-		SELECT DISTINCT m.id, m.room_id, m.title, m.summary, m.full_transcript, m.participants_list, m.email_sent, m.email_sent_at, m.created_at, m.updated_at
+		SELECT DISTINCT m.id, m.room_id, m.title, m.summary, m.full_transcript, m.participants_list, m.poll_results, m.notes, m.languages, m.run_id, m.email_sent, m.email_sent_at, m.created_at, m.updated_at
 		FROM call_minutes m
 		INNER JOIN call_participants p ON m.room_id = p.room_id
 		WHERE p.user_id = ?
@@ -112,19 +114,7 @@ func (r *MySQLCallMinutesRepository) FindByUserID(ctx context.Context, userID in
 
 	var minutesList []*entity.CallMinutes
 	for rows.Next() {
-		m := &entity.CallMinutes{}
-		err := rows.Scan(
-			&m.ID,
-			&m.RoomID,
-			&m.Title,
-			&m.Summary,
-			&m.FullTranscript,
-			&m.ParticipantsList,
-			&m.EmailSent,
-			&m.EmailSentAt,
-			&m.CreatedAt,
-			&m.UpdatedAt,
-		)
+		m, err := scanCallMinutes(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -133,3 +123,37 @@ func (r *MySQLCallMinutesRepository) FindByUserID(ctx context.Context, userID in
 
 	return minutesList, rows.Err()
 }
+
+// FindRoomIDsWithMinutes 議事録が存在するルームIDを取得
+func (r *MySQLCallMinutesRepository) FindRoomIDsWithMinutes(ctx context.Context, roomIDs []int64) (map[int64]bool, error) {
+	return findExistingRoomIDs(ctx, r.db, "call_minutes", roomIDs)
+}
+
+// scanCallMinutes SELECTのカラム順で議事録をスキャン（話された言語はJSONから変換）
+func scanCallMinutes(s rowScanner) (*entity.CallMinutes, error) {
+	m := &entity.CallMinutes{}
+	var languages sql.NullString
+	err := s.Scan(
+		&m.ID,
+		&m.RoomID,
+		&m.Title,
+		&m.Summary,
+		&m.FullTranscript,
+		&m.ParticipantsList,
+		&m.PollResults,
+		&m.Notes,
+		&languages,
+		&m.RunID,
+		&m.EmailSent,
+		&m.EmailSentAt,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if m.Languages, err = decodeStringList(languages); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
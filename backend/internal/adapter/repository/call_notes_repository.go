@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+type MySQLCallNotesRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLCallNotesRepository 新しいCallNotesリポジトリを作成
+func NewMySQLCallNotesRepository(db *database.MySQL) port.CallNotesRepository {
+	return &MySQLCallNotesRepository{db: db}
+}
+
+// Save 共有ノートのスナップショットを保存
+func (r *MySQLCallNotesRepository) Save(ctx context.Context, notes *entity.CallNotes) error {
+	query := `
+		INSERT INTO call_notes (room_id, content, state, version, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE content = VALUES(content), state = VALUES(state), version = VALUES(version), updated_at = VALUES(updated_at)
+	`
+	_, err := r.db.ExecContext(ctx, query, notes.RoomID, notes.Content, notes.State, notes.Version, notes.UpdatedAt)
+	return err
+}
+
+// FindByRoomID ルームの共有ノートを取得
+func (r *MySQLCallNotesRepository) FindByRoomID(ctx context.Context, roomID int64) (*entity.CallNotes, error) {
+	query := `
+		SELECT room_id, content, state, version, updated_at
+		FROM call_notes
+		WHERE room_id = ?
+	`
+	n := &entity.CallNotes{}
+	err := r.db.QueryRowContext(ctx, query, roomID).Scan(&n.RoomID, &n.Content, &n.State, &n.Version, &n.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrCallNotesNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return n, nil
+}
@@ -10,6 +10,9 @@ import (
 	"Go-Next-WebRTC/pkg/database"
 )
 
+// callParticipantColumns call_participantsのSELECT対象カラム
+const callParticipantColumns = `id, room_id, user_id, guest_id, guest_name, role, joined_at, left_at, is_active, created_at, updated_at`
+
 type MySQLCallParticipantRepository struct {
 	db *database.MySQL
 }
@@ -22,12 +25,25 @@ func NewMySQLCallParticipantRepository(db *database.MySQL) port.CallParticipantR
 // Create 参加者を作成
 func (r *MySQLCallParticipantRepository) Create(ctx context.Context, participant *entity.CallParticipant) error {
 	query := `
-		INSERT INTO call_participants (room_id, user_id, is_active)
-		VALUES (?, ?, ?)
+		INSERT INTO call_participants (room_id, user_id, guest_id, guest_name, role, is_active)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
+	if participant.Role == "" {
+		participant.Role = entity.RoleParticipant
+	}
+
+	// ゲストはusersに存在しないためuser_idはNULLで保存
+	var userID *int64
+	if !participant.IsGuest() {
+		userID = &participant.UserID
+	}
+
 	result, err := r.db.ExecContext(ctx, query,
 		participant.RoomID,
-		participant.UserID,
+		userID,
+		participant.GuestID,
+		participant.GuestName,
+		participant.Role,
 		participant.IsActive,
 	)
 	if err != nil {
@@ -47,10 +63,11 @@ func (r *MySQLCallParticipantRepository) Create(ctx context.Context, participant
 func (r *MySQLCallParticipantRepository) Update(ctx context.Context, participant *entity.CallParticipant) error {
 	query := `
 		UPDATE call_participants
-		SET left_at = ?, is_active = ?, updated_at = CURRENT_TIMESTAMP
+		SET role = ?, left_at = ?, is_active = ?, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?
 	`
 	_, err := r.db.ExecContext(ctx, query,
+		participant.Role,
 		participant.LeftAt,
 		participant.IsActive,
 		participant.ID,
@@ -61,48 +78,131 @@ func (r *MySQLCallParticipantRepository) Update(ctx context.Context, participant
 // FindByRoomID ルームの参加者一覧を取得
 func (r *MySQLCallParticipantRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallParticipant, error) {
 	query := `
-		SELECT id, room_id, user_id, joined_at, left_at, is_active, created_at, updated_at
+		SELECT ` + callParticipantColumns + `
 		FROM call_participants
 		WHERE room_id = ?
 		ORDER BY joined_at ASC
 	`
-	rows, err := r.db.QueryContext(ctx, query, roomID)
+	return r.findMany(ctx, query, roomID)
+}
+
+// FindActiveByRoomID ルームのアクティブな参加者を取得
+func (r *MySQLCallParticipantRepository) FindActiveByRoomID(ctx context.Context, roomID int64) ([]*entity.CallParticipant, error) {
+	query := `
+		SELECT ` + callParticipantColumns + `
+		FROM call_participants
+		WHERE room_id = ? AND is_active = TRUE
+		ORDER BY joined_at ASC
+	`
+	return r.findMany(ctx, query, roomID)
+}
+
+// FindByRoomIDAndUserID 特定ユーザーの参加記録を取得
+func (r *MySQLCallParticipantRepository) FindByRoomIDAndUserID(ctx context.Context, roomID int64, userID int64) (*entity.CallParticipant, error) {
+	query := `
+		SELECT ` + callParticipantColumns + `
+		FROM call_participants
+		WHERE room_id = ? AND user_id = ? AND is_active = TRUE
+		ORDER BY joined_at DESC
+		LIMIT 1
+	`
+	return r.findOne(ctx, query, roomID, userID)
+}
+
+// FindByRoomIDAndGuestID 特定ゲストの参加記録を取得
+func (r *MySQLCallParticipantRepository) FindByRoomIDAndGuestID(ctx context.Context, roomID int64, guestID string) (*entity.CallParticipant, error) {
+	query := `
+		SELECT ` + callParticipantColumns + `
+		FROM call_participants
+		WHERE room_id = ? AND guest_id = ? AND is_active = TRUE
+		ORDER BY joined_at DESC
+		LIMIT 1
+	`
+	return r.findOne(ctx, query, roomID, guestID)
+}
+
+// FindByID IDで参加記録を取得
+func (r *MySQLCallParticipantRepository) FindByID(ctx context.Context, id int64) (*entity.CallParticipant, error) {
+	query := `
+		SELECT ` + callParticipantColumns + `
+		FROM call_participants
+		WHERE id = ?
+	`
+	return r.findOne(ctx, query, id)
+}
+
+// FindLatestByRoomIDAndUserID 特定ユーザーの最新の参加記録を取得（退出済みも含む）
+func (r *MySQLCallParticipantRepository) FindLatestByRoomIDAndUserID(ctx context.Context, roomID int64, userID int64) (*entity.CallParticipant, error) {
+	query := `
+		SELECT ` + callParticipantColumns + `
+		FROM call_participants
+		WHERE room_id = ? AND user_id = ?
+		ORDER BY joined_at DESC, id DESC
+		LIMIT 1
+	`
+	return r.findOne(ctx, query, roomID, userID)
+}
+
+// FindByRoomIDs 複数ルームの参加記録を一括取得
+func (r *MySQLCallParticipantRepository) FindByRoomIDs(ctx context.Context, roomIDs []int64) ([]*entity.CallParticipant, error) {
+	if len(roomIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inClause(roomIDs)
+	query := `
+		SELECT ` + callParticipantColumns + `
+		FROM call_participants
+		WHERE room_id IN (` + placeholders + `)
+		ORDER BY room_id ASC, joined_at ASC, id ASC
+	`
+	return r.findMany(ctx, query, args...)
+}
+
+// FindContactUserIDs 同じ通話に参加したことのあるユーザーID一覧を最近一緒になった順に取得
+func (r *MySQLCallParticipantRepository) FindContactUserIDs(ctx context.Context, userID int64, limit int) ([]int64, error) {
+	query := `
+		SELECT other.user_id
+		FROM call_participants me
+		JOIN call_participants other
+			ON other.room_id = me.room_id AND other.user_id IS NOT NULL AND other.user_id <> me.user_id
+		WHERE me.user_id = ?
+		GROUP BY other.user_id
+		ORDER BY MAX(other.joined_at) DESC
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID, limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var participants []*entity.CallParticipant
+	var userIDs []int64
 	for rows.Next() {
-		p := &entity.CallParticipant{}
-		err := rows.Scan(
-			&p.ID,
-			&p.RoomID,
-			&p.UserID,
-			&p.JoinedAt,
-			&p.LeftAt,
-			&p.IsActive,
-			&p.CreatedAt,
-			&p.UpdatedAt,
-		)
-		if err != nil {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
-		participants = append(participants, p)
+		userIDs = append(userIDs, id)
 	}
+	return userIDs, rows.Err()
+}
 
-	return participants, rows.Err()
+// findOne 1件の参加記録を取得
+func (r *MySQLCallParticipantRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.CallParticipant, error) {
+	p, err := scanCallParticipant(r.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("participant not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
 }
 
-// FindActiveByRoomID ルームのアクティブな参加者を取得
-func (r *MySQLCallParticipantRepository) FindActiveByRoomID(ctx context.Context, roomID int64) ([]*entity.CallParticipant, error) {
-	query := `
-		SELECT id, room_id, user_id, joined_at, left_at, is_active, created_at, updated_at
-		FROM call_participants
-		WHERE room_id = ? AND is_active = TRUE
-		ORDER BY joined_at ASC
-	`
-	rows, err := r.db.QueryContext(ctx, query, roomID)
+// findMany 複数の参加記録を取得
+func (r *MySQLCallParticipantRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entity.CallParticipant, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -110,17 +210,7 @@ func (r *MySQLCallParticipantRepository) FindActiveByRoomID(ctx context.Context,
 
 	var participants []*entity.CallParticipant
 	for rows.Next() {
-		p := &entity.CallParticipant{}
-		err := rows.Scan(
-			&p.ID,
-			&p.RoomID,
-			&p.UserID,
-			&p.JoinedAt,
-			&p.LeftAt,
-			&p.IsActive,
-			&p.CreatedAt,
-			&p.UpdatedAt,
-		)
+		p, err := scanCallParticipant(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -130,33 +220,26 @@ func (r *MySQLCallParticipantRepository) FindActiveByRoomID(ctx context.Context,
 	return participants, rows.Err()
 }
 
-// FindByRoomIDAndUserID 特定ユーザーの参加記録を取得
-func (r *MySQLCallParticipantRepository) FindByRoomIDAndUserID(ctx context.Context, roomID int64, userID int64) (*entity.CallParticipant, error) {
-	query := `
-		SELECT id, room_id, user_id, joined_at, left_at, is_active, created_at, updated_at
-		FROM call_participants
-		WHERE room_id = ? AND user_id = ? AND is_active = TRUE
-		ORDER BY joined_at DESC
-		LIMIT 1
-	`
+// scanCallParticipant callParticipantColumnsの順で参加記録をスキャン
+func scanCallParticipant(s rowScanner) (*entity.CallParticipant, error) {
 	p := &entity.CallParticipant{}
-	err := r.db.QueryRowContext(ctx, query, roomID, userID).Scan(
+	var userID sql.NullInt64
+	err := s.Scan(
 		&p.ID,
 		&p.RoomID,
-		&p.UserID,
+		&userID,
+		&p.GuestID,
+		&p.GuestName,
+		&p.Role,
 		&p.JoinedAt,
 		&p.LeftAt,
 		&p.IsActive,
 		&p.CreatedAt,
 		&p.UpdatedAt,
 	)
-
-	if err == sql.ErrNoRows {
-		return nil, errors.New("participant not found")
-	}
 	if err != nil {
 		return nil, err
 	}
-
+	p.UserID = userID.Int64
 	return p, nil
 }
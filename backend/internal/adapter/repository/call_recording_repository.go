@@ -22,8 +22,8 @@ func NewMySQLCallRecordingRepository(db *database.MySQL) port.CallRecordingRepos
 // Create 録音を作成
 func (r *MySQLCallRecordingRepository) Create(ctx context.Context, recording *entity.CallRecording) error {
 	query := `
-		INSERT INTO call_recordings (room_id, user_id, file_path, file_size, duration_seconds, format)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO call_recordings (room_id, user_id, file_path, file_size, duration_seconds, format, started_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`
 	result, err := r.db.ExecContext(ctx, query,
 		recording.RoomID,
@@ -32,6 +32,7 @@ func (r *MySQLCallRecordingRepository) Create(ctx context.Context, recording *en
 		recording.FileSize,
 		recording.DurationSeconds,
 		recording.Format,
+		recording.StartedAt,
 	)
 	if err != nil {
 		return err
@@ -49,7 +50,7 @@ func (r *MySQLCallRecordingRepository) Create(ctx context.Context, recording *en
 // FindByRoomID ルームの録音一覧を取得
 func (r *MySQLCallRecordingRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallRecording, error) {
 	query := `
-		SELECT id, room_id, user_id, file_path, file_size, duration_seconds, format, uploaded_at, created_at, updated_at
+		SELECT id, room_id, user_id, file_path, file_size, duration_seconds, format, started_at, uploaded_at, created_at, updated_at
 		FROM call_recordings
 		WHERE room_id = ?
 		ORDER BY uploaded_at ASC
@@ -71,6 +72,7 @@ func (r *MySQLCallRecordingRepository) FindByRoomID(ctx context.Context, roomID
 			&rec.FileSize,
 			&rec.DurationSeconds,
 			&rec.Format,
+			&rec.StartedAt,
 			&rec.UploadedAt,
 			&rec.CreatedAt,
 			&rec.UpdatedAt,
@@ -87,7 +89,7 @@ func (r *MySQLCallRecordingRepository) FindByRoomID(ctx context.Context, roomID
 // FindByID 録音を取得
 func (r *MySQLCallRecordingRepository) FindByID(ctx context.Context, id int64) (*entity.CallRecording, error) {
 	query := `
-		SELECT id, room_id, user_id, file_path, file_size, duration_seconds, format, uploaded_at, created_at, updated_at
+		SELECT id, room_id, user_id, file_path, file_size, duration_seconds, format, started_at, uploaded_at, created_at, updated_at
 		FROM call_recordings
 		WHERE id = ?
 	`
@@ -100,6 +102,7 @@ func (r *MySQLCallRecordingRepository) FindByID(ctx context.Context, id int64) (
 		&rec.FileSize,
 		&rec.DurationSeconds,
 		&rec.Format,
+		&rec.StartedAt,
 		&rec.UploadedAt,
 		&rec.CreatedAt,
 		&rec.UpdatedAt,
@@ -114,3 +117,41 @@ func (r *MySQLCallRecordingRepository) FindByID(ctx context.Context, id int64) (
 
 	return rec, nil
 }
+
+// FindByRoomIDAndFilePath ファイルの場所でルームの録音を取得
+func (r *MySQLCallRecordingRepository) FindByRoomIDAndFilePath(ctx context.Context, roomID int64, filePath string) (*entity.CallRecording, error) {
+	query := `
+		SELECT id, room_id, user_id, file_path, file_size, duration_seconds, format, started_at, uploaded_at, created_at, updated_at
+		FROM call_recordings
+		WHERE room_id = ? AND file_path = ?
+		ORDER BY id ASC
+		LIMIT 1
+	`
+	rec := &entity.CallRecording{}
+	err := r.db.QueryRowContext(ctx, query, roomID, filePath).Scan(
+		&rec.ID,
+		&rec.RoomID,
+		&rec.UserID,
+		&rec.FilePath,
+		&rec.FileSize,
+		&rec.DurationSeconds,
+		&rec.Format,
+		&rec.StartedAt,
+		&rec.UploadedAt,
+		&rec.CreatedAt,
+		&rec.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrRecordingNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rec, nil
+}
+
+// FindRoomIDsWithRecordings 録音が存在するルームIDを取得
+func (r *MySQLCallRecordingRepository) FindRoomIDsWithRecordings(ctx context.Context, roomIDs []int64) (map[int64]bool, error) {
+	return findExistingRoomIDs(ctx, r.db, "call_recordings", roomIDs)
+}
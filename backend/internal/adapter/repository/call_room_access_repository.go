@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+type MySQLCallRoomAccessRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLCallRoomAccessRepository 新しいCallRoomAccessリポジトリを作成
+func NewMySQLCallRoomAccessRepository(db *database.MySQL) port.CallRoomAccessRepository {
+	return &MySQLCallRoomAccessRepository{db: db}
+}
+
+// ReplaceRules 許可リストを置き換え
+func (r *MySQLCallRoomAccessRepository) ReplaceRules(ctx context.Context, roomID int64, rules []*entity.CallRoomAccessRule) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM call_room_access_rules WHERE room_id = ?`, roomID); err != nil {
+		return err
+	}
+
+	if len(rules) > 0 {
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO call_room_access_rules (room_id, user_id, email_domain)
+			VALUES (?, ?, ?)
+		`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, rule := range rules {
+			result, err := stmt.ExecContext(ctx, roomID, rule.UserID, rule.EmailDomain)
+			if err != nil {
+				if isDuplicateError(err) {
+					continue
+				}
+				return err
+			}
+			if id, err := result.LastInsertId(); err == nil {
+				rule.ID = id
+			}
+			rule.RoomID = roomID
+		}
+	}
+
+	return tx.Commit()
+}
+
+// AddRule 許可リストに1件追加
+func (r *MySQLCallRoomAccessRepository) AddRule(ctx context.Context, rule *entity.CallRoomAccessRule) error {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO call_room_access_rules (room_id, user_id, email_domain)
+		VALUES (?, ?, ?)
+	`, rule.RoomID, rule.UserID, rule.EmailDomain)
+	if err != nil {
+		if isDuplicateError(err) {
+			return nil
+		}
+		return err
+	}
+	if id, err := result.LastInsertId(); err == nil {
+		rule.ID = id
+	}
+	return nil
+}
+
+// FindByRoomID ルームの許可リストを取得
+func (r *MySQLCallRoomAccessRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallRoomAccessRule, error) {
+	return r.FindByRoomIDs(ctx, []int64{roomID})
+}
+
+// FindByRoomIDs 複数ルームの許可リストを一括取得
+func (r *MySQLCallRoomAccessRepository) FindByRoomIDs(ctx context.Context, roomIDs []int64) ([]*entity.CallRoomAccessRule, error) {
+	if len(roomIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inClause(roomIDs)
+	query := `
+		SELECT id, room_id, user_id, email_domain, created_at
+		FROM call_room_access_rules
+		WHERE room_id IN (` + placeholders + `)
+		ORDER BY room_id ASC, id ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rules []*entity.CallRoomAccessRule
+	for rows.Next() {
+		rule := &entity.CallRoomAccessRule{}
+		if err := rows.Scan(&rule.ID, &rule.RoomID, &rule.UserID, &rule.EmailDomain, &rule.CreatedAt); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, rows.Err()
+}
@@ -4,12 +4,19 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"strings"
+	"time"
 
 	"Go-Next-WebRTC/internal/domain/entity"
 	"Go-Next-WebRTC/internal/domain/port"
 	"Go-Next-WebRTC/pkg/database"
 )
 
+// callRoomColumns call_roomsのSELECT対象カラム
+const callRoomColumns = `id, room_id, name, created_by, status, started_at, ended_at, max_participants,
+		visibility, passcode_hash, series_id, occurrence_start, scheduled_at, personal_room_id, session_number,
+		transcription_language, transcription_alternative_languages, created_at, updated_at`
+
 type MySQLCallRoomRepository struct {
 	db *database.MySQL
 }
@@ -22,15 +29,36 @@ func NewMySQLCallRoomRepository(db *database.MySQL) port.CallRoomRepository {
 // Create 通話ルームを作成
 func (r *MySQLCallRoomRepository) Create(ctx context.Context, room *entity.CallRoom) error {
 	query := `
-		INSERT INTO call_rooms (room_id, name, created_by, status, max_participants)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO call_rooms (room_id, name, created_by, status, max_participants, visibility, passcode_hash,
+			series_id, occurrence_start, scheduled_at, personal_room_id, session_number,
+			transcription_language, transcription_alternative_languages)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
+	if room.Visibility == "" {
+		room.Visibility = entity.RoomVisibilityPublic
+	}
+	if room.TranscriptionLanguage == "" {
+		room.TranscriptionLanguage = entity.DefaultTranscriptionLanguage
+	}
+	alternatives, err := encodeStringList(room.TranscriptionAlternativeLanguages)
+	if err != nil {
+		return err
+	}
 	result, err := r.db.ExecContext(ctx, query,
 		room.RoomID,
 		room.Name,
 		room.CreatedBy,
 		room.Status,
 		room.MaxParticipants,
+		room.Visibility,
+		room.PasscodeHash,
+		room.SeriesID,
+		room.OccurrenceStart,
+		room.ScheduledAt,
+		room.PersonalRoomID,
+		room.SessionNumber,
+		room.TranscriptionLanguage,
+		alternatives,
 	)
 	if err != nil {
 		return err
@@ -48,69 +76,40 @@ func (r *MySQLCallRoomRepository) Create(ctx context.Context, room *entity.CallR
 // FindByRoomID room_idで通話ルームを取得
 func (r *MySQLCallRoomRepository) FindByRoomID(ctx context.Context, roomID string) (*entity.CallRoom, error) {
 	query := `
-		SELECT id, room_id, name, created_by, status, started_at, ended_at, max_participants, created_at, updated_at
+		SELECT ` + callRoomColumns + `
 		FROM call_rooms
 		WHERE room_id = ?
 	`
-	room := &entity.CallRoom{}
-	err := r.db.QueryRowContext(ctx, query, roomID).Scan(
-		&room.ID,
-		&room.RoomID,
-		&room.Name,
-		&room.CreatedBy,
-		&room.Status,
-		&room.StartedAt,
-		&room.EndedAt,
-		&room.MaxParticipants,
-		&room.CreatedAt,
-		&room.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return nil, errors.New("room not found")
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	return room, nil
+	return r.findOne(ctx, query, roomID)
 }
 
 // FindByID IDで通話ルームを取得
 func (r *MySQLCallRoomRepository) FindByID(ctx context.Context, id int64) (*entity.CallRoom, error) {
 	query := `
-		SELECT id, room_id, name, created_by, status, started_at, ended_at, max_participants, created_at, updated_at
+		SELECT ` + callRoomColumns + `
 		FROM call_rooms
 		WHERE id = ?
 	`
-	room := &entity.CallRoom{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&room.ID,
-		&room.RoomID,
-		&room.Name,
-		&room.CreatedBy,
-		&room.Status,
-		&room.StartedAt,
-		&room.EndedAt,
-		&room.MaxParticipants,
-		&room.CreatedAt,
-		&room.UpdatedAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return nil, errors.New("room not found")
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	return room, nil
+	return r.findOne(ctx, query, id)
 }
 
 // FindActiveRooms アクティブな通話ルーム一覧を取得
 func (r *MySQLCallRoomRepository) FindActiveRooms(ctx context.Context) ([]*entity.CallRoom, error) {
 	query := `
-		SELECT id, room_id, name, created_by, status, started_at, ended_at, max_participants, created_at, updated_at
+		SELECT ` + callRoomColumns + `
+		FROM call_rooms
+		WHERE status IN ('waiting', 'active')
+		ORDER BY created_at DESC
+	`
+	return r.findMany(ctx, query)
+}
+
+// FindLobbyRooms アクティブな通話ルーム一覧を参加中の人数付きで取得
+// 参加人数はルームごとに問い合わせず、1回のクエリで集計する
+func (r *MySQLCallRoomRepository) FindLobbyRooms(ctx context.Context) ([]*entity.LobbyRoom, error) {
+	query := `
+		SELECT ` + callRoomColumns + `,
+			(SELECT COUNT(*) FROM call_participants p WHERE p.room_id = call_rooms.id AND p.is_active = TRUE)
 		FROM call_rooms
 		WHERE status IN ('waiting', 'active')
 		ORDER BY created_at DESC
@@ -121,25 +120,18 @@ func (r *MySQLCallRoomRepository) FindActiveRooms(ctx context.Context) ([]*entit
 	}
 	defer rows.Close()
 
-	var rooms []*entity.CallRoom
+	var rooms []*entity.LobbyRoom
 	for rows.Next() {
-		room := &entity.CallRoom{}
-		err := rows.Scan(
-			&room.ID,
-			&room.RoomID,
-			&room.Name,
-			&room.CreatedBy,
-			&room.Status,
-			&room.StartedAt,
-			&room.EndedAt,
-			&room.MaxParticipants,
-			&room.CreatedAt,
-			&room.UpdatedAt,
-		)
+		lobbyRoom := &entity.LobbyRoom{Room: &entity.CallRoom{}}
+		var alternatives sql.NullString
+		err := rows.Scan(append(callRoomFields(lobbyRoom.Room, &alternatives), &lobbyRoom.ParticipantCount)...)
 		if err != nil {
 			return nil, err
 		}
-		rooms = append(rooms, room)
+		if lobbyRoom.Room.TranscriptionAlternativeLanguages, err = decodeStringList(alternatives); err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, lobbyRoom)
 	}
 
 	return rooms, rows.Err()
@@ -149,13 +141,27 @@ func (r *MySQLCallRoomRepository) FindActiveRooms(ctx context.Context) ([]*entit
 func (r *MySQLCallRoomRepository) Update(ctx context.Context, room *entity.CallRoom) error {
 	query := `
 		UPDATE call_rooms
-		SET status = ?, started_at = ?, ended_at = ?, updated_at = CURRENT_TIMESTAMP
+		SET name = ?, status = ?, started_at = ?, ended_at = ?, scheduled_at = ?, visibility = ?, passcode_hash = ?,
+			transcription_language = ?, transcription_alternative_languages = ?, updated_at = CURRENT_TIMESTAMP
 		WHERE id = ?
 	`
-	_, err := r.db.ExecContext(ctx, query,
+	if room.TranscriptionLanguage == "" {
+		room.TranscriptionLanguage = entity.DefaultTranscriptionLanguage
+	}
+	alternatives, err := encodeStringList(room.TranscriptionAlternativeLanguages)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query,
+		room.Name,
 		room.Status,
 		room.StartedAt,
 		room.EndedAt,
+		room.ScheduledAt,
+		room.Visibility,
+		room.PasscodeHash,
+		room.TranscriptionLanguage,
+		alternatives,
 		room.ID,
 	)
 	return err
@@ -164,12 +170,116 @@ func (r *MySQLCallRoomRepository) Update(ctx context.Context, room *entity.CallR
 // FindByCreatedBy ユーザーが作成した通話ルーム一覧を取得
 func (r *MySQLCallRoomRepository) FindByCreatedBy(ctx context.Context, userID int64) ([]*entity.CallRoom, error) {
 	query := `
-		SELECT id, room_id, name, created_by, status, started_at, ended_at, max_participants, created_at, updated_at
+		SELECT ` + callRoomColumns + `
 		FROM call_rooms
 		WHERE created_by = ?
 		ORDER BY created_at DESC
 	`
-	rows, err := r.db.QueryContext(ctx, query, userID)
+	return r.findMany(ctx, query, userID)
+}
+
+// FindHistory 通話履歴を検索
+// 開始日時（未開始の場合は作成日時）の新しい順
+func (r *MySQLCallRoomRepository) FindHistory(ctx context.Context, filter *entity.CallHistoryFilter) ([]*entity.CallRoom, int, error) {
+	var conds []string
+	var args []interface{}
+
+	switch filter.Scope {
+	case entity.CallHistoryScopeCreated:
+		conds = append(conds, "created_by = ?")
+		args = append(args, filter.UserID)
+	case entity.CallHistoryScopeJoined:
+		conds = append(conds, "id IN (SELECT room_id FROM call_participants WHERE user_id = ?)")
+		args = append(args, filter.UserID)
+	default:
+		conds = append(conds, "(created_by = ? OR id IN (SELECT room_id FROM call_participants WHERE user_id = ?))")
+		args = append(args, filter.UserID, filter.UserID)
+	}
+	if filter.Status != "" {
+		conds = append(conds, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.From != nil {
+		conds = append(conds, "COALESCE(started_at, created_at) >= ?")
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		conds = append(conds, "COALESCE(started_at, created_at) < ?")
+		args = append(args, *filter.To)
+	}
+	if filter.Query != "" {
+		conds = append(conds, "name LIKE ?")
+		args = append(args, "%"+escapeLike(filter.Query)+"%")
+	}
+	where := strings.Join(conds, " AND ")
+
+	var total int
+	if err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM call_rooms WHERE `+where, args...).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + callRoomColumns + `
+		FROM call_rooms
+		WHERE ` + where + `
+		ORDER BY COALESCE(started_at, created_at) DESC, id DESC
+		LIMIT ? OFFSET ?
+	`
+	rooms, err := r.findMany(ctx, query, append(args, filter.Limit, filter.Offset)...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return rooms, total, nil
+}
+
+// FindBySeriesOccurrence シリーズの開催回に対応する通話ルームを取得
+func (r *MySQLCallRoomRepository) FindBySeriesOccurrence(ctx context.Context, seriesID int64, occurrenceStart time.Time) (*entity.CallRoom, error) {
+	query := `
+		SELECT ` + callRoomColumns + `
+		FROM call_rooms
+		WHERE series_id = ? AND occurrence_start = ?
+	`
+	return r.findOne(ctx, query, seriesID, occurrenceStart.UTC())
+}
+
+// FindBySeriesID シリーズから生成された通話ルーム一覧を取得
+func (r *MySQLCallRoomRepository) FindBySeriesID(ctx context.Context, seriesID int64) ([]*entity.CallRoom, error) {
+	query := `
+		SELECT ` + callRoomColumns + `
+		FROM call_rooms
+		WHERE series_id = ?
+		ORDER BY occurrence_start ASC
+	`
+	return r.findMany(ctx, query, seriesID)
+}
+
+// FindByPersonalRoomID 個人ルームのセッション一覧を新しい順に取得
+func (r *MySQLCallRoomRepository) FindByPersonalRoomID(ctx context.Context, personalRoomID int64, limit int) ([]*entity.CallRoom, error) {
+	query := `
+		SELECT ` + callRoomColumns + `
+		FROM call_rooms
+		WHERE personal_room_id = ?
+		ORDER BY session_number DESC
+		LIMIT ?
+	`
+	return r.findMany(ctx, query, personalRoomID, limit)
+}
+
+// findOne 1件の通話ルームを取得
+func (r *MySQLCallRoomRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.CallRoom, error) {
+	room, err := scanCallRoom(r.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, errors.New("room not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// findMany 複数の通話ルームを取得
+func (r *MySQLCallRoomRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entity.CallRoom, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -177,19 +287,7 @@ func (r *MySQLCallRoomRepository) FindByCreatedBy(ctx context.Context, userID in
 
 	var rooms []*entity.CallRoom
 	for rows.Next() {
-		room := &entity.CallRoom{}
-		err := rows.Scan(
-			&room.ID,
-			&room.RoomID,
-			&room.Name,
-			&room.CreatedBy,
-			&room.Status,
-			&room.StartedAt,
-			&room.EndedAt,
-			&room.MaxParticipants,
-			&room.CreatedAt,
-			&room.UpdatedAt,
-		)
+		room, err := scanCallRoom(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -198,3 +296,48 @@ func (r *MySQLCallRoomRepository) FindByCreatedBy(ctx context.Context, userID in
 
 	return rooms, rows.Err()
 }
+
+// rowScanner *sql.Row と *sql.Rows の共通インターフェース
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCallRoom callRoomColumnsの順で通話ルームをスキャン
+func scanCallRoom(s rowScanner) (*entity.CallRoom, error) {
+	room := &entity.CallRoom{}
+	var alternatives sql.NullString
+	if err := s.Scan(callRoomFields(room, &alternatives)...); err != nil {
+		return nil, err
+	}
+	var err error
+	if room.TranscriptionAlternativeLanguages, err = decodeStringList(alternatives); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// callRoomFields callRoomColumnsの順のスキャン先
+// 候補の言語はJSONのためalternativesに読み込み、呼び出し側で変換する
+func callRoomFields(room *entity.CallRoom, alternatives *sql.NullString) []interface{} {
+	return []interface{}{
+		&room.ID,
+		&room.RoomID,
+		&room.Name,
+		&room.CreatedBy,
+		&room.Status,
+		&room.StartedAt,
+		&room.EndedAt,
+		&room.MaxParticipants,
+		&room.Visibility,
+		&room.PasscodeHash,
+		&room.SeriesID,
+		&room.OccurrenceStart,
+		&room.ScheduledAt,
+		&room.PersonalRoomID,
+		&room.SessionNumber,
+		&room.TranscriptionLanguage,
+		alternatives,
+		&room.CreatedAt,
+		&room.UpdatedAt,
+	}
+}
@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// callSeriesColumns call_seriesのSELECT対象カラム
+const callSeriesColumns = `id, series_id, name, created_by, rrule, start_at, duration_minutes, timezone,
+		max_participants, created_at, updated_at`
+
+type MySQLCallSeriesRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLCallSeriesRepository 新しいCallSeriesリポジトリを作成
+func NewMySQLCallSeriesRepository(db *database.MySQL) port.CallSeriesRepository {
+	return &MySQLCallSeriesRepository{db: db}
+}
+
+// Create シリーズを作成
+func (r *MySQLCallSeriesRepository) Create(ctx context.Context, series *entity.CallSeries) error {
+	query := `
+		INSERT INTO call_series (series_id, name, created_by, rrule, start_at, duration_minutes, timezone, max_participants)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		series.SeriesID,
+		series.Name,
+		series.CreatedBy,
+		series.RRule,
+		series.StartAt.UTC(),
+		series.DurationMinutes,
+		series.Timezone,
+		series.MaxParticipants,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	series.ID = id
+	return nil
+}
+
+// Update シリーズを更新
+func (r *MySQLCallSeriesRepository) Update(ctx context.Context, series *entity.CallSeries) error {
+	query := `
+		UPDATE call_series
+		SET name = ?, rrule = ?, start_at = ?, duration_minutes = ?, timezone = ?, max_participants = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		series.Name,
+		series.RRule,
+		series.StartAt.UTC(),
+		series.DurationMinutes,
+		series.Timezone,
+		series.MaxParticipants,
+		series.ID,
+	)
+	return err
+}
+
+// Split 元のシリーズを更新し、from以降を引き継ぐ新しいシリーズを1トランザクションで作成
+func (r *MySQLCallSeriesRepository) Split(ctx context.Context, series *entity.CallSeries, next *entity.CallSeries, from time.Time, moveExceptions bool) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE call_series
+		SET name = ?, rrule = ?, start_at = ?, duration_minutes = ?, timezone = ?, max_participants = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`,
+		series.Name,
+		series.RRule,
+		series.StartAt.UTC(),
+		series.DurationMinutes,
+		series.Timezone,
+		series.MaxParticipants,
+		series.ID,
+	)
+	if err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO call_series (series_id, name, created_by, rrule, start_at, duration_minutes, timezone, max_participants)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		next.SeriesID,
+		next.Name,
+		next.CreatedBy,
+		next.RRule,
+		next.StartAt.UTC(),
+		next.DurationMinutes,
+		next.Timezone,
+		next.MaxParticipants,
+	)
+	if err != nil {
+		return err
+	}
+	nextID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if moveExceptions {
+		_, err = tx.ExecContext(ctx,
+			`UPDATE call_series_exceptions SET series_id = ? WHERE series_id = ? AND original_start >= ?`,
+			nextID, series.ID, from.UTC())
+	} else {
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM call_series_exceptions WHERE series_id = ? AND original_start >= ?`,
+			series.ID, from.UTC())
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	next.ID = nextID
+	return nil
+}
+
+// FindBySeriesID series_idでシリーズを取得
+func (r *MySQLCallSeriesRepository) FindBySeriesID(ctx context.Context, seriesID string) (*entity.CallSeries, error) {
+	query := `
+		SELECT ` + callSeriesColumns + `
+		FROM call_series
+		WHERE series_id = ?
+	`
+	return r.findOne(ctx, query, seriesID)
+}
+
+// FindByID IDでシリーズを取得
+func (r *MySQLCallSeriesRepository) FindByID(ctx context.Context, id int64) (*entity.CallSeries, error) {
+	query := `
+		SELECT ` + callSeriesColumns + `
+		FROM call_series
+		WHERE id = ?
+	`
+	return r.findOne(ctx, query, id)
+}
+
+// FindByCreatedBy ユーザーが作成したシリーズ一覧を取得
+func (r *MySQLCallSeriesRepository) FindByCreatedBy(ctx context.Context, userID int64) ([]*entity.CallSeries, error) {
+	query := `
+		SELECT ` + callSeriesColumns + `
+		FROM call_series
+		WHERE created_by = ?
+		ORDER BY start_at ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var list []*entity.CallSeries
+	for rows.Next() {
+		s, err := scanCallSeries(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+	}
+
+	return list, rows.Err()
+}
+
+// SaveException 例外を作成または更新
+func (r *MySQLCallSeriesRepository) SaveException(ctx context.Context, exception *entity.CallSeriesException) error {
+	query := `
+		INSERT INTO call_series_exceptions (series_id, original_start, cancelled, start_at, duration_minutes, name)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			id = LAST_INSERT_ID(id),
+			cancelled = VALUES(cancelled),
+			start_at = VALUES(start_at),
+			duration_minutes = VALUES(duration_minutes),
+			name = VALUES(name),
+			updated_at = CURRENT_TIMESTAMP
+	`
+	var startAt *time.Time
+	if exception.StartAt != nil {
+		utc := exception.StartAt.UTC()
+		startAt = &utc
+	}
+
+	result, err := r.db.ExecContext(ctx, query,
+		exception.SeriesID,
+		exception.OriginalStart.UTC(),
+		exception.Cancelled,
+		startAt,
+		exception.DurationMinutes,
+		exception.Name,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	exception.ID = id
+	return nil
+}
+
+// FindExceptions シリーズの例外一覧を取得
+func (r *MySQLCallSeriesRepository) FindExceptions(ctx context.Context, seriesID int64) ([]*entity.CallSeriesException, error) {
+	query := `
+		SELECT id, series_id, original_start, cancelled, start_at, duration_minutes, name, created_at, updated_at
+		FROM call_series_exceptions
+		WHERE series_id = ?
+		ORDER BY original_start ASC
+	`
+	rows, err := r.db.QueryContext(ctx, query, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var exceptions []*entity.CallSeriesException
+	for rows.Next() {
+		e := &entity.CallSeriesException{}
+		err := rows.Scan(
+			&e.ID,
+			&e.SeriesID,
+			&e.OriginalStart,
+			&e.Cancelled,
+			&e.StartAt,
+			&e.DurationMinutes,
+			&e.Name,
+			&e.CreatedAt,
+			&e.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		exceptions = append(exceptions, e)
+	}
+
+	return exceptions, rows.Err()
+}
+
+// DeleteExceptionsFrom 指定日時以降の例外を削除
+func (r *MySQLCallSeriesRepository) DeleteExceptionsFrom(ctx context.Context, seriesID int64, from time.Time) error {
+	query := `DELETE FROM call_series_exceptions WHERE series_id = ? AND original_start >= ?`
+	_, err := r.db.ExecContext(ctx, query, seriesID, from.UTC())
+	return err
+}
+
+// findOne 1件のシリーズを取得
+func (r *MySQLCallSeriesRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.CallSeries, error) {
+	s, err := scanCallSeries(r.db.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrSeriesNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// scanCallSeries callSeriesColumnsの順でシリーズをスキャン
+func scanCallSeries(s rowScanner) (*entity.CallSeries, error) {
+	series := &entity.CallSeries{}
+	err := s.Scan(
+		&series.ID,
+		&series.SeriesID,
+		&series.Name,
+		&series.CreatedBy,
+		&series.RRule,
+		&series.StartAt,
+		&series.DurationMinutes,
+		&series.Timezone,
+		&series.MaxParticipants,
+		&series.CreatedAt,
+		&series.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return series, nil
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 
 	"Go-Next-WebRTC/internal/domain/entity"
 	"Go-Next-WebRTC/internal/domain/port"
@@ -20,17 +21,22 @@ func NewMySQLCallTranscriptionRepository(db *database.MySQL) port.CallTranscript
 // Create 文字起こしを作成
 func (r *MySQLCallTranscriptionRepository) Create(ctx context.Context, transcription *entity.CallTranscription) error {
 	query := `
-		INSERT INTO call_transcriptions (room_id, recording_id, speaker_tag, text, confidence, start_time, end_time, language)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO call_transcriptions (room_id, run_id, recording_id, user_id, speaker_tag, text, confidence, start_time, end_time,
+			started_at, ended_at, language)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 	result, err := r.db.ExecContext(ctx, query,
 		transcription.RoomID,
+		transcription.RunID,
 		transcription.RecordingID,
+		transcription.UserID,
 		transcription.SpeakerTag,
 		transcription.Text,
 		transcription.Confidence,
 		transcription.StartTime,
 		transcription.EndTime,
+		transcription.StartedAt,
+		transcription.EndedAt,
 		transcription.Language,
 	)
 	if err != nil {
@@ -58,9 +64,18 @@ func (r *MySQLCallTranscriptionRepository) CreateBatch(ctx context.Context, tran
 	}
 	defer tx.Rollback()
 
+	if err := insertTranscriptions(ctx, tx, transcriptions); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertTranscriptions トランザクション内で文字起こしを作成
+func insertTranscriptions(ctx context.Context, tx *sql.Tx, transcriptions []*entity.CallTranscription) error {
 	stmt, err := tx.PrepareContext(ctx, `
-		INSERT INTO call_transcriptions (room_id, recording_id, speaker_tag, text, confidence, start_time, end_time, language)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO call_transcriptions (room_id, run_id, recording_id, user_id, speaker_tag, text, confidence, start_time, end_time,
+			started_at, ended_at, language)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return err
@@ -70,31 +85,53 @@ func (r *MySQLCallTranscriptionRepository) CreateBatch(ctx context.Context, tran
 	for _, t := range transcriptions {
 		_, err := stmt.ExecContext(ctx,
 			t.RoomID,
+			t.RunID,
 			t.RecordingID,
+			t.UserID,
 			t.SpeakerTag,
 			t.Text,
 			t.Confidence,
 			t.StartTime,
 			t.EndTime,
+			t.StartedAt,
+			t.EndedAt,
 			t.Language,
 		)
 		if err != nil {
 			return err
 		}
 	}
-
-	return tx.Commit()
+	return nil
 }
 
-// FindByRoomID ルームの文字起こし一覧を取得
+// FindByRoomID ルームの現在の実行の文字起こし一覧を取得
 func (r *MySQLCallTranscriptionRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallTranscription, error) {
 	query := `
-		SELECT id, room_id, recording_id, speaker_tag, text, confidence, start_time, end_time, language, created_at, updated_at
+		SELECT t.id, t.room_id, t.run_id, t.recording_id, t.user_id, t.speaker_tag, t.text, t.confidence, t.start_time, t.end_time,
+			t.started_at, t.ended_at, t.language, t.created_at, t.updated_at
+		FROM call_transcriptions t
+		INNER JOIN call_transcription_runs run ON t.run_id = run.id
+		WHERE run.room_id = ? AND run.is_current = TRUE
+		ORDER BY t.started_at IS NULL, t.started_at ASC, t.start_time ASC, t.id ASC
+	`
+	return r.findTranscriptions(ctx, query, roomID)
+}
+
+// FindByRunID 実行の文字起こし一覧を取得
+func (r *MySQLCallTranscriptionRepository) FindByRunID(ctx context.Context, runID int64) ([]*entity.CallTranscription, error) {
+	query := `
+		SELECT id, room_id, run_id, recording_id, user_id, speaker_tag, text, confidence, start_time, end_time,
+			started_at, ended_at, language, created_at, updated_at
 		FROM call_transcriptions
-		WHERE room_id = ?
-		ORDER BY start_time ASC, id ASC
+		WHERE run_id = ?
+		ORDER BY started_at IS NULL, started_at ASC, start_time ASC, id ASC
 	`
-	rows, err := r.db.QueryContext(ctx, query, roomID)
+	return r.findTranscriptions(ctx, query, runID)
+}
+
+// findTranscriptions 文字起こし一覧を取得（SELECTのカラム順でスキャン）
+func (r *MySQLCallTranscriptionRepository) findTranscriptions(ctx context.Context, query string, args ...interface{}) ([]*entity.CallTranscription, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -106,12 +143,16 @@ func (r *MySQLCallTranscriptionRepository) FindByRoomID(ctx context.Context, roo
 		err := rows.Scan(
 			&t.ID,
 			&t.RoomID,
+			&t.RunID,
 			&t.RecordingID,
+			&t.UserID,
 			&t.SpeakerTag,
 			&t.Text,
 			&t.Confidence,
 			&t.StartTime,
 			&t.EndTime,
+			&t.StartedAt,
+			&t.EndedAt,
 			&t.Language,
 			&t.CreatedAt,
 			&t.UpdatedAt,
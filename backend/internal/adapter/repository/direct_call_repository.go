@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// directCallColumns direct_callsのSELECT対象カラム
+const directCallColumns = `id, call_id, caller_id, callee_id, room_id, status, ring_expires_at, answered_at, created_at, updated_at`
+
+type MySQLDirectCallRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLDirectCallRepository 新しいDirectCallリポジトリを作成
+func NewMySQLDirectCallRepository(db *database.MySQL) port.DirectCallRepository {
+	return &MySQLDirectCallRepository{db: db}
+}
+
+// Create 1対1通話を作成
+func (r *MySQLDirectCallRepository) Create(ctx context.Context, call *entity.DirectCall) error {
+	query := `
+		INSERT INTO direct_calls (call_id, caller_id, callee_id, room_id, status, ring_expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		call.CallID,
+		call.CallerID,
+		call.CalleeID,
+		call.RoomID,
+		call.Status,
+		call.RingExpiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	call.ID = id
+	return nil
+}
+
+// UpdateFromRinging 着信中の1対1通話の状態を更新
+// 応答とタイムアウトが同時に起きても一方だけが反映されるよう、着信中の場合のみ更新する
+func (r *MySQLDirectCallRepository) UpdateFromRinging(ctx context.Context, call *entity.DirectCall) (bool, error) {
+	query := `
+		UPDATE direct_calls
+		SET status = ?, answered_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = ?
+	`
+	result, err := r.db.ExecContext(ctx, query, call.Status, call.AnsweredAt, call.ID, entity.DirectCallStatusRinging)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// FindByCallID call_idで1対1通話を取得
+func (r *MySQLDirectCallRepository) FindByCallID(ctx context.Context, callID string) (*entity.DirectCall, error) {
+	query := `
+		SELECT ` + directCallColumns + `
+		FROM direct_calls
+		WHERE call_id = ?
+	`
+	call, err := scanDirectCall(r.db.QueryRowContext(ctx, query, callID))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrDirectCallNotFound
+	}
+	return call, err
+}
+
+// FindExpiredRinging 応答期限を過ぎた着信中の通話一覧を取得
+func (r *MySQLDirectCallRepository) FindExpiredRinging(ctx context.Context, now time.Time, limit int) ([]*entity.DirectCall, error) {
+	query := `
+		SELECT ` + directCallColumns + `
+		FROM direct_calls
+		WHERE status = ? AND ring_expires_at <= ?
+		ORDER BY ring_expires_at
+		LIMIT ?
+	`
+	return r.findMany(ctx, query, entity.DirectCallStatusRinging, now, limit)
+}
+
+// FindMissedByCallee ユーザーの不在着信一覧を新しい順に取得
+func (r *MySQLDirectCallRepository) FindMissedByCallee(ctx context.Context, calleeID int64, limit int) ([]*entity.DirectCall, error) {
+	query := `
+		SELECT ` + directCallColumns + `
+		FROM direct_calls
+		WHERE callee_id = ? AND status = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`
+	return r.findMany(ctx, query, calleeID, entity.DirectCallStatusMissed, limit)
+}
+
+// findMany 複数の1対1通話を取得
+func (r *MySQLDirectCallRepository) findMany(ctx context.Context, query string, args ...interface{}) ([]*entity.DirectCall, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var calls []*entity.DirectCall
+	for rows.Next() {
+		call, err := scanDirectCall(rows)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, call)
+	}
+	return calls, rows.Err()
+}
+
+// scanDirectCall directCallColumnsの順で1対1通話をスキャン
+func scanDirectCall(s rowScanner) (*entity.DirectCall, error) {
+	call := &entity.DirectCall{}
+	err := s.Scan(
+		&call.ID,
+		&call.CallID,
+		&call.CallerID,
+		&call.CalleeID,
+		&call.RoomID,
+		&call.Status,
+		&call.RingExpiresAt,
+		&call.AnsweredAt,
+		&call.CreatedAt,
+		&call.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return call, nil
+}
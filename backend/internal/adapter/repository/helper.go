@@ -1,8 +1,12 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"encoding/json"
 	"strings"
+
+	"Go-Next-WebRTC/pkg/database"
 )
 
 // isDuplicateError MySQLの重複エラーかチェック
@@ -20,4 +24,92 @@ func isDuplicateError(err error) bool {
 // isNotFoundError レコードが見つからないエラーかチェック
 func isNotFoundError(err error) bool {
 	return err == sql.ErrNoRows
-}
\ No newline at end of file
+}
+
+// inClause IN句のプレースホルダーと引数を作成
+func inClause(ids []int64) (string, []interface{}) {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","), args
+}
+
+// escapeLike LIKE検索用にワイルドカード文字をエスケープ
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// findExistingRoomIDs 指定テーブルにレコードが存在するルームIDを取得
+func findExistingRoomIDs(ctx context.Context, db *database.MySQL, table string, roomIDs []int64) (map[int64]bool, error) {
+	exists := make(map[int64]bool)
+	if len(roomIDs) == 0 {
+		return exists, nil
+	}
+
+	placeholders, args := inClause(roomIDs)
+	rows, err := db.QueryContext(ctx, `SELECT DISTINCT room_id FROM `+table+` WHERE room_id IN (`+placeholders+`)`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var roomID int64
+		if err := rows.Scan(&roomID); err != nil {
+			return nil, err
+		}
+		exists[roomID] = true
+	}
+	return exists, rows.Err()
+}
+
+// encodeInt64List 数値の一覧をJSONのカラムの値に変換（空の場合はNULL）
+func encodeInt64List(values []int64) (*string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	encoded := string(data)
+	return &encoded, nil
+}
+
+// decodeInt64List JSONのカラムの値を数値の一覧に変換（NULLの場合はnil）
+func decodeInt64List(value sql.NullString) ([]int64, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+	var values []int64
+	if err := json.Unmarshal([]byte(value.String), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// encodeStringList 文字列の一覧をJSONのカラムの値に変換（空の場合はNULL）
+func encodeStringList(values []string) (*string, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	encoded := string(data)
+	return &encoded, nil
+}
+
+// decodeStringList JSONのカラムの値を文字列の一覧に変換（NULLの場合はnil）
+func decodeStringList(value sql.NullString) ([]string, error) {
+	if !value.Valid || value.String == "" {
+		return nil, nil
+	}
+	var values []string
+	if err := json.Unmarshal([]byte(value.String), &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
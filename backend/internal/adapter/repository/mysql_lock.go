@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// MySQLLock MySQLのGET_LOCKを使った排他ロック
+type MySQLLock struct {
+	db *database.MySQL
+}
+
+// NewMySQLLock 新しいMySQLロックを作成
+func NewMySQLLock(db *database.MySQL) port.DistributedLock {
+	return &MySQLLock{db: db}
+}
+
+// TryLock ロックの取得を試みる
+// GET_LOCKは接続単位のため、解放まで専用の接続を保持する
+func (l *MySQLLock) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, `SELECT GET_LOCK(?, 0)`, name).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		conn.Close()
+		return nil, false, nil
+	}
+
+	release := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if _, err := conn.ExecContext(ctx, `DO RELEASE_LOCK(?)`, name); err != nil {
+			slog.Warn("Failed to release lock", slog.String("name", name), slog.String("error", err.Error()))
+		}
+		conn.Close()
+	}
+	return release, true, nil
+}
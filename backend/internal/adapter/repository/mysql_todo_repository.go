@@ -21,7 +21,7 @@ func NewMySQLTodoRepository(db *database.MySQL) port.TodoRepository {
 
 // FindAllByUserID は指定されたユーザーの全てのTodoを取得する
 func (r *MySQLTodoRepository) FindAllByUserID(ctx context.Context, userID int64) ([]*entity.Todo, error) {
-	query := `SELECT id, user_id, title, description, completed, created_at, updated_at
+	query := `SELECT id, user_id, assignee_id, title, description, completed, created_at, updated_at
 			  FROM todos
 			  WHERE user_id = ?
 			  ORDER BY created_at DESC`
@@ -35,9 +35,11 @@ func (r *MySQLTodoRepository) FindAllByUserID(ctx context.Context, userID int64)
 	var todos []*entity.Todo
 	for rows.Next() {
 		todo := &entity.Todo{}
+		var assigneeID sql.NullInt64
 		err := rows.Scan(
 			&todo.ID,
 			&todo.UserID,
+			&assigneeID,
 			&todo.Title,
 			&todo.Description,
 			&todo.Completed,
@@ -47,6 +49,9 @@ func (r *MySQLTodoRepository) FindAllByUserID(ctx context.Context, userID int64)
 		if err != nil {
 			return nil, err
 		}
+		if assigneeID.Valid {
+			todo.AssigneeID = &assigneeID.Int64
+		}
 		todos = append(todos, todo)
 	}
 
@@ -60,15 +65,17 @@ func (r *MySQLTodoRepository) FindAllByUserID(ctx context.Context, userID int64)
 // FindByIDAndUserID は指定されたIDとユーザーIDのTodoを取得する
 func (r *MySQLTodoRepository) FindByIDAndUserID(ctx context.Context, id int, userID int64) (*entity.Todo, error) {
 	query := `
-		SELECT id, user_id, title, description, completed, created_at, updated_at
+		SELECT id, user_id, assignee_id, title, description, completed, created_at, updated_at
 		FROM todos
 		WHERE id = ? AND user_id = ?
 	`
 
 	todo := &entity.Todo{}
+	var assigneeID sql.NullInt64
 	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(
 		&todo.ID,
 		&todo.UserID,
+		&assigneeID,
 		&todo.Title,
 		&todo.Description,
 		&todo.Completed,
@@ -82,6 +89,9 @@ func (r *MySQLTodoRepository) FindByIDAndUserID(ctx context.Context, id int, use
 		}
 		return nil, err
 	}
+	if assigneeID.Valid {
+		todo.AssigneeID = &assigneeID.Int64
+	}
 
 	return todo, nil
 }
@@ -99,10 +109,10 @@ func (r *MySQLTodoRepository) Save(ctx context.Context, todo *entity.Todo) error
 // create は新しいTodoを作成する
 func (r *MySQLTodoRepository) create(ctx context.Context, todo *entity.Todo) error {
 	query := `
-		INSERT INTO todos (user_id, title, description, completed)
-		VALUES (?, ?, ?, ?)
+		INSERT INTO todos (user_id, assignee_id, title, description, completed)
+		VALUES (?, ?, ?, ?, ?)
 	`
-	result, err := r.db.ExecContext(ctx, query, todo.UserID, todo.Title, todo.Description, todo.Completed)
+	result, err := r.db.ExecContext(ctx, query, todo.UserID, todo.AssigneeID, todo.Title, todo.Description, todo.Completed)
 	if err != nil {
 		return err
 	}
@@ -123,11 +133,12 @@ func (r *MySQLTodoRepository) create(ctx context.Context, todo *entity.Todo) err
 func (r *MySQLTodoRepository) update(ctx context.Context, todo *entity.Todo) error {
 	query := `
 		UPDATE todos
-		SET title = ?, description = ?, completed = ?
+		SET assignee_id = ?, title = ?, description = ?, completed = ?
 		WHERE id = ? AND user_id = ?
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
+		todo.AssigneeID,
 		todo.Title,
 		todo.Description,
 		todo.Completed,
@@ -99,6 +99,41 @@ func (r *MySQLUserRepository) FindByID(ctx context.Context, id int64) (*entity.U
 	return user, nil
 }
 
+// FindByIDs 複数IDによるユーザー一括検索（退会済みユーザーも含む）
+func (r *MySQLUserRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inClause(ids)
+	query := `
+		SELECT id, email, name, COALESCE(avatar_url, ''), is_active
+		FROM users
+		WHERE id IN (` + placeholders + `)
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*entity.User
+	for rows.Next() {
+		user := &entity.User{}
+		if err := rows.Scan(&user.ID, &user.Email, &user.Name, &user.AvatarURL, &user.IsActive); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return users, nil
+}
+
 // FindByEmail メールアドレスによるユーザー検索
 func (r *MySQLUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	user := &entity.User{}
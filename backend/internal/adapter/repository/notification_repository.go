@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// notificationColumns notificationsのSELECT対象カラム
+const notificationColumns = `id, user_id, type, title, body, data, read_at, created_at`
+
+type MySQLNotificationRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLNotificationRepository 新しいNotificationリポジトリを作成
+func NewMySQLNotificationRepository(db *database.MySQL) port.NotificationRepository {
+	return &MySQLNotificationRepository{db: db}
+}
+
+// Create 通知を作成
+func (r *MySQLNotificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	var data interface{}
+	if len(notification.Data) > 0 {
+		encoded, err := json.Marshal(notification.Data)
+		if err != nil {
+			return err
+		}
+		data = string(encoded)
+	}
+
+	query := `
+		INSERT INTO notifications (user_id, type, title, body, data, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		notification.UserID,
+		notification.Type,
+		notification.Title,
+		notification.Body,
+		data,
+		notification.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	notification.ID = id
+	return nil
+}
+
+// FindByID 通知を取得
+func (r *MySQLNotificationRepository) FindByID(ctx context.Context, id int64) (*entity.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE id = ?
+	`
+	notification, err := scanNotification(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrNotificationNotFound
+	}
+	return notification, err
+}
+
+// FindByUserID ユーザーの通知一覧を新しい順に取得
+func (r *MySQLNotificationRepository) FindByUserID(ctx context.Context, filter *entity.NotificationFilter) ([]*entity.Notification, error) {
+	query := `
+		SELECT ` + notificationColumns + `
+		FROM notifications
+		WHERE user_id = ?
+	`
+	args := []interface{}{filter.UserID}
+	if filter.UnreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+	if filter.BeforeID > 0 {
+		query += ` AND id < ?`
+		args = append(args, filter.BeforeID)
+	}
+	query += ` ORDER BY id DESC LIMIT ?`
+	args = append(args, filter.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var notifications []*entity.Notification
+	for rows.Next() {
+		notification, err := scanNotification(rows)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications, rows.Err()
+}
+
+// CountUnread ユーザーの未読件数を取得
+func (r *MySQLNotificationRepository) CountUnread(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM notifications WHERE user_id = ? AND read_at IS NULL`, userID).Scan(&count)
+	return count, err
+}
+
+// MarkRead 通知を既読にする
+func (r *MySQLNotificationRepository) MarkRead(ctx context.Context, id int64, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE notifications SET read_at = ? WHERE id = ? AND read_at IS NULL`, at, id)
+	return err
+}
+
+// MarkAllRead ユーザーの未読の通知をすべて既読にする
+func (r *MySQLNotificationRepository) MarkAllRead(ctx context.Context, userID int64, at time.Time) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `UPDATE notifications SET read_at = ? WHERE user_id = ? AND read_at IS NULL`, at, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// scanNotification notificationColumnsの順で通知をスキャン
+func scanNotification(s rowScanner) (*entity.Notification, error) {
+	notification := &entity.Notification{}
+	var data sql.NullString
+	err := s.Scan(
+		&notification.ID,
+		&notification.UserID,
+		&notification.Type,
+		&notification.Title,
+		&notification.Body,
+		&data,
+		&notification.ReadAt,
+		&notification.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if data.Valid && data.String != "" {
+		if err := json.Unmarshal([]byte(data.String), &notification.Data); err != nil {
+			return nil, err
+		}
+	}
+	return notification, nil
+}
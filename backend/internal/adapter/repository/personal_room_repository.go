@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// personalRoomColumns personal_roomsのSELECT対象カラム
+const personalRoomColumns = `id, user_id, slug, name, max_participants, visibility, passcode_hash,
+		allowed_user_ids, allowed_email_domains, created_at, updated_at`
+
+type MySQLPersonalRoomRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLPersonalRoomRepository 新しいPersonalRoomリポジトリを作成
+func NewMySQLPersonalRoomRepository(db *database.MySQL) port.PersonalRoomRepository {
+	return &MySQLPersonalRoomRepository{db: db}
+}
+
+// Create 個人ルームを作成
+func (r *MySQLPersonalRoomRepository) Create(ctx context.Context, room *entity.PersonalRoom) error {
+	if room.Visibility == "" {
+		room.Visibility = entity.RoomVisibilityPublic
+	}
+	userIDs, domains, err := encodePersonalRoomAccess(room)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO personal_rooms (user_id, slug, name, max_participants, visibility, passcode_hash,
+			allowed_user_ids, allowed_email_domains)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		room.UserID,
+		room.Slug,
+		room.Name,
+		room.MaxParticipants,
+		room.Visibility,
+		room.PasscodeHash,
+		userIDs,
+		domains,
+	)
+	if err != nil {
+		if isDuplicateError(err) {
+			return entity.ErrSlugTaken
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	room.ID = id
+	return nil
+}
+
+// Update 個人ルームを更新
+func (r *MySQLPersonalRoomRepository) Update(ctx context.Context, room *entity.PersonalRoom) error {
+	userIDs, domains, err := encodePersonalRoomAccess(room)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE personal_rooms
+		SET slug = ?, name = ?, max_participants = ?, visibility = ?, passcode_hash = ?,
+			allowed_user_ids = ?, allowed_email_domains = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		room.Slug,
+		room.Name,
+		room.MaxParticipants,
+		room.Visibility,
+		room.PasscodeHash,
+		userIDs,
+		domains,
+		room.ID,
+	)
+	if isDuplicateError(err) {
+		return entity.ErrSlugTaken
+	}
+	return err
+}
+
+// FindByUserID ユーザーの個人ルームを取得
+func (r *MySQLPersonalRoomRepository) FindByUserID(ctx context.Context, userID int64) (*entity.PersonalRoom, error) {
+	query := `
+		SELECT ` + personalRoomColumns + `
+		FROM personal_rooms
+		WHERE user_id = ?
+	`
+	return r.findOne(ctx, query, userID)
+}
+
+// FindBySlug スラッグで個人ルームを取得
+func (r *MySQLPersonalRoomRepository) FindBySlug(ctx context.Context, slug string) (*entity.PersonalRoom, error) {
+	query := `
+		SELECT ` + personalRoomColumns + `
+		FROM personal_rooms
+		WHERE slug = ?
+	`
+	return r.findOne(ctx, query, slug)
+}
+
+// findOne 1件の個人ルームを取得
+func (r *MySQLPersonalRoomRepository) findOne(ctx context.Context, query string, args ...interface{}) (*entity.PersonalRoom, error) {
+	room := &entity.PersonalRoom{}
+	var userIDs, domains sql.NullString
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(
+		&room.ID,
+		&room.UserID,
+		&room.Slug,
+		&room.Name,
+		&room.MaxParticipants,
+		&room.Visibility,
+		&room.PasscodeHash,
+		&userIDs,
+		&domains,
+		&room.CreatedAt,
+		&room.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrPersonalRoomNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if room.AllowedUserIDs, err = decodeInt64List(userIDs); err != nil {
+		return nil, err
+	}
+	if room.AllowedEmailDomains, err = decodeStringList(domains); err != nil {
+		return nil, err
+	}
+	return room, nil
+}
+
+// encodePersonalRoomAccess 許可リストをJSONのカラムの値に変換
+func encodePersonalRoomAccess(room *entity.PersonalRoom) (*string, *string, error) {
+	userIDs, err := encodeInt64List(room.AllowedUserIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	domains, err := encodeStringList(room.AllowedEmailDomains)
+	if err != nil {
+		return nil, nil, err
+	}
+	return userIDs, domains, nil
+}
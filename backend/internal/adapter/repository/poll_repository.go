@@ -0,0 +1,303 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// pollColumns pollsのSELECT対象カラム
+const pollColumns = `id, room_id, question, multiple_choice, anonymous, created_by, status, closed_at, created_at, updated_at`
+
+type MySQLPollRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLPollRepository 新しいPollリポジトリを作成
+func NewMySQLPollRepository(db *database.MySQL) port.PollRepository {
+	return &MySQLPollRepository{db: db}
+}
+
+// Create 投票を選択肢と合わせて作成
+func (r *MySQLPollRepository) Create(ctx context.Context, poll *entity.Poll) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var createdBy interface{}
+	if poll.CreatedBy != 0 {
+		createdBy = poll.CreatedBy
+	}
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO polls (room_id, question, multiple_choice, anonymous, created_by, status)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, poll.RoomID, poll.Question, poll.MultipleChoice, poll.Anonymous, createdBy, poll.Status)
+	if err != nil {
+		return err
+	}
+	pollID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO poll_options (poll_id, position, text)
+		VALUES (?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, option := range poll.Options {
+		result, err := stmt.ExecContext(ctx, pollID, option.Position, option.Text)
+		if err != nil {
+			return err
+		}
+		if option.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		option.PollID = pollID
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	poll.ID = pollID
+	return nil
+}
+
+// FindByID 投票を選択肢と合わせて取得
+func (r *MySQLPollRepository) FindByID(ctx context.Context, id int64) (*entity.Poll, error) {
+	query := `
+		SELECT ` + pollColumns + `
+		FROM polls
+		WHERE id = ?
+	`
+	poll, err := scanPoll(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrPollNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadOptions(ctx, []*entity.Poll{poll}); err != nil {
+		return nil, err
+	}
+	return poll, nil
+}
+
+// FindByRoomID ルームの投票一覧を作成順に取得
+func (r *MySQLPollRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.Poll, error) {
+	query := `
+		SELECT ` + pollColumns + `
+		FROM polls
+		WHERE room_id = ?
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var polls []*entity.Poll
+	for rows.Next() {
+		poll, err := scanPoll(rows)
+		if err != nil {
+			return nil, err
+		}
+		polls = append(polls, poll)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadOptions(ctx, polls); err != nil {
+		return nil, err
+	}
+	return polls, nil
+}
+
+// loadOptions 投票の選択肢を一括取得して設定
+func (r *MySQLPollRepository) loadOptions(ctx context.Context, polls []*entity.Poll) error {
+	if len(polls) == 0 {
+		return nil
+	}
+	byID := make(map[int64]*entity.Poll, len(polls))
+	ids := make([]int64, len(polls))
+	for i, poll := range polls {
+		byID[poll.ID] = poll
+		ids[i] = poll.ID
+	}
+
+	placeholders, args := inClause(ids)
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, poll_id, position, text, vote_count
+		FROM poll_options
+		WHERE poll_id IN (`+placeholders+`)
+		ORDER BY poll_id, position
+	`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		option := &entity.PollOption{}
+		if err := rows.Scan(&option.ID, &option.PollID, &option.Position, &option.Text, &option.VoteCount); err != nil {
+			return err
+		}
+		if poll := byID[option.PollID]; poll != nil {
+			poll.Options = append(poll.Options, option)
+		}
+	}
+	return rows.Err()
+}
+
+// ReplaceVotes 投票者の投票を置き換え
+// 締め切りと同時に投票された場合に備えて、投票の行をロックしてから状態を確認する
+func (r *MySQLPollRepository) ReplaceVotes(ctx context.Context, pollID int64, voterKey string, votes []*entity.PollVote) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status entity.PollStatus
+	err = tx.QueryRowContext(ctx, `SELECT status FROM polls WHERE id = ? FOR UPDATE`, pollID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return entity.ErrPollNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != entity.PollStatusOpen {
+		return entity.ErrPollClosed
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM poll_votes WHERE poll_id = ? AND voter_key = ?`, pollID, voterKey); err != nil {
+		return err
+	}
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO poll_votes (poll_id, option_id, voter_key, user_id, voter_name)
+		VALUES (?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, vote := range votes {
+		var userID interface{}
+		if vote.UserID != 0 {
+			userID = vote.UserID
+		}
+		result, err := stmt.ExecContext(ctx, pollID, vote.OptionID, voterKey, userID, vote.VoterName)
+		if err != nil {
+			return err
+		}
+		if vote.ID, err = result.LastInsertId(); err != nil {
+			return err
+		}
+		vote.PollID = pollID
+		vote.VoterKey = voterKey
+	}
+
+	return tx.Commit()
+}
+
+// FindVotes 投票の一覧を投票順に取得
+func (r *MySQLPollRepository) FindVotes(ctx context.Context, pollID int64) ([]*entity.PollVote, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, poll_id, option_id, voter_key, user_id, voter_name, created_at
+		FROM poll_votes
+		WHERE poll_id = ?
+		ORDER BY id
+	`, pollID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var votes []*entity.PollVote
+	for rows.Next() {
+		vote := &entity.PollVote{}
+		var userID sql.NullInt64
+		if err := rows.Scan(&vote.ID, &vote.PollID, &vote.OptionID, &vote.VoterKey, &userID, &vote.VoterName, &vote.CreatedAt); err != nil {
+			return nil, err
+		}
+		vote.UserID = userID.Int64
+		votes = append(votes, vote)
+	}
+	return votes, rows.Err()
+}
+
+// Close 投票を締め切り、選択肢ごとの最終得票数を集計して保存
+// 投票の行をロックすることで、締め切りと同時の投票が集計から漏れないようにする
+func (r *MySQLPollRepository) Close(ctx context.Context, pollID int64, closedAt time.Time) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var status entity.PollStatus
+	err = tx.QueryRowContext(ctx, `SELECT status FROM polls WHERE id = ? FOR UPDATE`, pollID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return entity.ErrPollNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if status != entity.PollStatusOpen {
+		return entity.ErrPollClosed
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE polls
+		SET status = ?, closed_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, entity.PollStatusClosed, closedAt, pollID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE poll_options o
+		SET vote_count = (SELECT COUNT(*) FROM poll_votes v WHERE v.option_id = o.id)
+		WHERE o.poll_id = ?
+	`, pollID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// scanPoll pollColumnsの順で投票をスキャン（選択肢は含まない）
+func scanPoll(s rowScanner) (*entity.Poll, error) {
+	poll := &entity.Poll{}
+	var createdBy sql.NullInt64
+	err := s.Scan(
+		&poll.ID,
+		&poll.RoomID,
+		&poll.Question,
+		&poll.MultipleChoice,
+		&poll.Anonymous,
+		&createdBy,
+		&poll.Status,
+		&poll.ClosedAt,
+		&poll.CreatedAt,
+		&poll.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	poll.CreatedBy = createdBy.Int64
+	return poll, nil
+}
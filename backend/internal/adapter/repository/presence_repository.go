@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+type MySQLPresenceRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLPresenceRepository 新しいPresenceリポジトリを作成
+func NewMySQLPresenceRepository(db *database.MySQL) port.PresenceRepository {
+	return &MySQLPresenceRepository{db: db}
+}
+
+// FindByUserIDs 在席状態を複数取得
+func (r *MySQLPresenceRepository) FindByUserIDs(ctx context.Context, userIDs []int64) ([]*entity.UserPresence, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders, args := inClause(userIDs)
+	query := `
+		SELECT user_id, status, last_seen_at, updated_at
+		FROM user_presences
+		WHERE user_id IN (` + placeholders + `)
+	`
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var presences []*entity.UserPresence
+	for rows.Next() {
+		p := &entity.UserPresence{}
+		if err := rows.Scan(&p.UserID, &p.Status, &p.LastSeenAt, &p.UpdatedAt); err != nil {
+			return nil, err
+		}
+		presences = append(presences, p)
+	}
+	return presences, rows.Err()
+}
+
+// SaveStatus ユーザーが設定した状態を保存
+func (r *MySQLPresenceRepository) SaveStatus(ctx context.Context, userID int64, status entity.PresenceStatus) error {
+	query := `
+		INSERT INTO user_presences (user_id, status)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE status = VALUES(status)
+	`
+	_, err := r.db.ExecContext(ctx, query, userID, status)
+	return err
+}
+
+// UpdateLastSeen 最終接続日時を保存
+func (r *MySQLPresenceRepository) UpdateLastSeen(ctx context.Context, userID int64, at time.Time) error {
+	query := `
+		INSERT INTO user_presences (user_id, last_seen_at)
+		VALUES (?, ?)
+		ON DUPLICATE KEY UPDATE last_seen_at = VALUES(last_seen_at)
+	`
+	_, err := r.db.ExecContext(ctx, query, userID, at)
+	return err
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// pushSubscriptionColumns push_subscriptionsのSELECT対象カラム
+const pushSubscriptionColumns = `id, user_id, endpoint, p256dh, auth, user_agent, created_at, updated_at`
+
+type MySQLPushSubscriptionRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLPushSubscriptionRepository 新しいPushSubscriptionリポジトリを作成
+func NewMySQLPushSubscriptionRepository(db *database.MySQL) port.PushSubscriptionRepository {
+	return &MySQLPushSubscriptionRepository{db: db}
+}
+
+// endpointHash エンドポイントの一意制約に使うハッシュ（URLが長くインデックスに収まらないため）
+func endpointHash(endpoint string) string {
+	sum := sha256.Sum256([]byte(endpoint))
+	return hex.EncodeToString(sum[:])
+}
+
+// Save 購読を保存
+// ブラウザは同じエンドポイントで鍵を更新することがあり、端末で別のユーザーがログインし直すこともあるため上書きする
+func (r *MySQLPushSubscriptionRepository) Save(ctx context.Context, subscription *entity.PushSubscription) error {
+	query := `
+		INSERT INTO push_subscriptions (user_id, endpoint, endpoint_hash, p256dh, auth, user_agent)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			id = LAST_INSERT_ID(id),
+			user_id = VALUES(user_id),
+			p256dh = VALUES(p256dh),
+			auth = VALUES(auth),
+			user_agent = VALUES(user_agent),
+			updated_at = CURRENT_TIMESTAMP
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		subscription.UserID,
+		subscription.Endpoint,
+		endpointHash(subscription.Endpoint),
+		subscription.P256dh,
+		subscription.Auth,
+		subscription.UserAgent,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	subscription.ID = id
+	return nil
+}
+
+// FindByUserID ユーザーの購読一覧を取得
+func (r *MySQLPushSubscriptionRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.PushSubscription, error) {
+	query := `
+		SELECT ` + pushSubscriptionColumns + `
+		FROM push_subscriptions
+		WHERE user_id = ?
+		ORDER BY id
+	`
+	rows, err := r.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subscriptions []*entity.PushSubscription
+	for rows.Next() {
+		subscription := &entity.PushSubscription{}
+		err := rows.Scan(
+			&subscription.ID,
+			&subscription.UserID,
+			&subscription.Endpoint,
+			&subscription.P256dh,
+			&subscription.Auth,
+			&subscription.UserAgent,
+			&subscription.CreatedAt,
+			&subscription.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, rows.Err()
+}
+
+// DeleteByEndpoint ユーザーの購読をエンドポイントで削除
+func (r *MySQLPushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, userID int64, endpoint string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM push_subscriptions WHERE user_id = ? AND endpoint_hash = ?`, userID, endpointHash(endpoint))
+	return err
+}
+
+// Delete 購読を削除
+func (r *MySQLPushSubscriptionRepository) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM push_subscriptions WHERE id = ?`, id)
+	return err
+}
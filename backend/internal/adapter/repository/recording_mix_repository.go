@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// recordingMixColumns recording_mixesのSELECT対象カラム
+const recordingMixColumns = `id, room_id, requested_by, status, attempts, tracks, recording_started_at, file_path,
+		file_size, format, lease_owner, lease_expires_at, last_error, finished_at, created_at, updated_at`
+
+// recordingMixTrack tracksカラムに保存する録音
+type recordingMixTrack struct {
+	RecordingID    int64   `json:"recording_id"`
+	UserID         int64   `json:"user_id"`
+	OffsetMs       int64   `json:"offset_ms"`
+	NormalizedPath *string `json:"normalized_path,omitempty"`
+}
+
+type MySQLRecordingMixRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLRecordingMixRepository 新しい録音のミックスリポジトリを作成
+func NewMySQLRecordingMixRepository(db *database.MySQL) port.RecordingMixRepository {
+	return &MySQLRecordingMixRepository{db: db}
+}
+
+// Create ミックスを作成
+func (r *MySQLRecordingMixRepository) Create(ctx context.Context, mix *entity.RecordingMix) error {
+	tracks, err := encodeRecordingMixTracks(mix.Tracks)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO recording_mixes (room_id, requested_by, status, tracks, format)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		mix.RoomID,
+		mix.RequestedBy,
+		mix.Status,
+		tracks,
+		mix.Format,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	mix.ID = id
+	return nil
+}
+
+// Update ミックスを更新
+func (r *MySQLRecordingMixRepository) Update(ctx context.Context, mix *entity.RecordingMix) error {
+	tracks, err := encodeRecordingMixTracks(mix.Tracks)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE recording_mixes
+		SET status = ?, attempts = ?, tracks = ?, recording_started_at = ?, file_path = ?, file_size = ?, format = ?,
+			lease_owner = ?, lease_expires_at = ?, last_error = ?, finished_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		mix.Status,
+		mix.Attempts,
+		tracks,
+		mix.RecordingStartedAt,
+		mix.FilePath,
+		mix.FileSize,
+		mix.Format,
+		mix.LeaseOwner,
+		mix.LeaseExpiresAt,
+		mix.LastError,
+		mix.FinishedAt,
+		mix.ID,
+	)
+	return err
+}
+
+// FindByID IDでミックスを取得
+func (r *MySQLRecordingMixRepository) FindByID(ctx context.Context, id int64) (*entity.RecordingMix, error) {
+	query := `
+		SELECT ` + recordingMixColumns + `
+		FROM recording_mixes
+		WHERE id = ?
+	`
+	mix, err := scanRecordingMix(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrRecordingMixNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mix, nil
+}
+
+// FindLatestByRoomID ルームの最新のミックスを取得
+func (r *MySQLRecordingMixRepository) FindLatestByRoomID(ctx context.Context, roomID int64) (*entity.RecordingMix, error) {
+	query := `
+		SELECT ` + recordingMixColumns + `
+		FROM recording_mixes
+		WHERE room_id = ?
+		ORDER BY id DESC
+		LIMIT 1
+	`
+	mix, err := scanRecordingMix(r.db.QueryRowContext(ctx, query, roomID))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrRecordingMixNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mix, nil
+}
+
+// Claim 実行できるミックスを1件確保
+// 候補を取得してから条件付きUPDATEで確保するため、複数のワーカー・レプリカが同時に呼び出しても同じミックスは1つだけが確保する
+func (r *MySQLRecordingMixRepository) Claim(ctx context.Context, owner string, now time.Time, leaseUntil time.Time) (*entity.RecordingMix, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id
+		FROM recording_mixes
+		WHERE status = 'queued' OR (status = 'running' AND lease_expires_at < ?)
+		ORDER BY id ASC
+		LIMIT ?
+	`, now, claimCandidates)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		result, err := r.db.ExecContext(ctx, `
+			UPDATE recording_mixes
+			SET status = 'running', attempts = attempts + 1, lease_owner = ?, lease_expires_at = ?,
+				updated_at = CURRENT_TIMESTAMP
+			WHERE id = ? AND (status = 'queued' OR (status = 'running' AND lease_expires_at < ?))
+		`, owner, leaseUntil, id, now)
+		if err != nil {
+			return nil, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if affected == 1 {
+			return r.FindByID(ctx, id)
+		}
+	}
+	return nil, entity.ErrRecordingMixNotFound
+}
+
+func encodeRecordingMixTracks(tracks []entity.RecordingMixTrack) (string, error) {
+	stored := make([]recordingMixTrack, 0, len(tracks))
+	for _, track := range tracks {
+		stored = append(stored, recordingMixTrack{
+			RecordingID:    track.RecordingID,
+			UserID:         track.UserID,
+			OffsetMs:       track.Offset.Milliseconds(),
+			NormalizedPath: track.NormalizedPath,
+		})
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// scanRecordingMix recordingMixColumnsの順でミックスをスキャン
+func scanRecordingMix(s rowScanner) (*entity.RecordingMix, error) {
+	mix := &entity.RecordingMix{}
+	var tracks string
+	err := s.Scan(
+		&mix.ID,
+		&mix.RoomID,
+		&mix.RequestedBy,
+		&mix.Status,
+		&mix.Attempts,
+		&tracks,
+		&mix.RecordingStartedAt,
+		&mix.FilePath,
+		&mix.FileSize,
+		&mix.Format,
+		&mix.LeaseOwner,
+		&mix.LeaseExpiresAt,
+		&mix.LastError,
+		&mix.FinishedAt,
+		&mix.CreatedAt,
+		&mix.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []recordingMixTrack
+	if err := json.Unmarshal([]byte(tracks), &stored); err != nil {
+		return nil, err
+	}
+	for _, track := range stored {
+		mix.Tracks = append(mix.Tracks, entity.RecordingMixTrack{
+			RecordingID:    track.RecordingID,
+			UserID:         track.UserID,
+			Offset:         time.Duration(track.OffsetMs) * time.Millisecond,
+			NormalizedPath: track.NormalizedPath,
+		})
+	}
+	return mix, nil
+}
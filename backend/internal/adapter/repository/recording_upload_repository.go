@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// recordingUploadColumns recording_uploadsのSELECT対象カラム
+const recordingUploadColumns = `id, room_id, user_id, upload_length, upload_offset, chunks, content_type,
+		duration_seconds, started_at, recording_id, expires_at, created_at, updated_at`
+
+// recordingUploadChunk chunksカラムに保存するチャンク
+type recordingUploadChunk struct {
+	Key    string `json:"key"`
+	Offset int64  `json:"offset"`
+	Size   int64  `json:"size"`
+}
+
+type MySQLRecordingUploadRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLRecordingUploadRepository 新しい録音アップロードリポジトリを作成
+func NewMySQLRecordingUploadRepository(db *database.MySQL) port.RecordingUploadRepository {
+	return &MySQLRecordingUploadRepository{db: db}
+}
+
+// Create アップロードを作成
+func (r *MySQLRecordingUploadRepository) Create(ctx context.Context, upload *entity.RecordingUpload) error {
+	chunks, err := encodeRecordingUploadChunks(upload.Chunks)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO recording_uploads (id, room_id, user_id, upload_length, upload_offset, chunks, content_type,
+			duration_seconds, started_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		upload.ID,
+		upload.RoomID,
+		upload.UserID,
+		upload.Length,
+		upload.Offset,
+		chunks,
+		upload.ContentType,
+		upload.DurationSeconds,
+		upload.StartedAt,
+		upload.ExpiresAt,
+	)
+	return err
+}
+
+// FindByID IDでアップロードを取得
+func (r *MySQLRecordingUploadRepository) FindByID(ctx context.Context, id string) (*entity.RecordingUpload, error) {
+	query := `
+		SELECT ` + recordingUploadColumns + `
+		FROM recording_uploads
+		WHERE id = ?
+	`
+	upload, err := scanRecordingUpload(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return upload, nil
+}
+
+// UpdateProgress 受信済みのサイズ・チャンク・期限を更新（受信済みのサイズが変わっていない場合のみ）
+func (r *MySQLRecordingUploadRepository) UpdateProgress(ctx context.Context, upload *entity.RecordingUpload, expectedOffset int64) (bool, error) {
+	chunks, err := encodeRecordingUploadChunks(upload.Chunks)
+	if err != nil {
+		return false, err
+	}
+
+	query := `
+		UPDATE recording_uploads
+		SET upload_offset = ?, chunks = ?, expires_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND upload_offset = ? AND recording_id IS NULL
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		upload.Offset,
+		chunks,
+		upload.ExpiresAt,
+		upload.ID,
+		expectedOffset,
+	)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// Complete 作成した録音を記録して完了にする
+func (r *MySQLRecordingUploadRepository) Complete(ctx context.Context, id string, recordingID int64) error {
+	query := `
+		UPDATE recording_uploads
+		SET recording_id = ?, chunks = '[]', updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, recordingID, id)
+	return err
+}
+
+// Delete アップロードを削除
+func (r *MySQLRecordingUploadRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM recording_uploads WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// FindExpired 期限を過ぎたアップロードを取得
+func (r *MySQLRecordingUploadRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.RecordingUpload, error) {
+	query := `
+		SELECT ` + recordingUploadColumns + `
+		FROM recording_uploads
+		WHERE expires_at <= ?
+		ORDER BY expires_at
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var uploads []*entity.RecordingUpload
+	for rows.Next() {
+		upload, err := scanRecordingUpload(rows)
+		if err != nil {
+			return nil, err
+		}
+		uploads = append(uploads, upload)
+	}
+	return uploads, rows.Err()
+}
+
+func encodeRecordingUploadChunks(chunks []entity.RecordingUploadChunk) (string, error) {
+	stored := make([]recordingUploadChunk, 0, len(chunks))
+	for _, chunk := range chunks {
+		stored = append(stored, recordingUploadChunk{
+			Key:    chunk.Key,
+			Offset: chunk.Offset,
+			Size:   chunk.Size,
+		})
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// scanRecordingUpload recordingUploadColumnsの順でアップロードをスキャン
+func scanRecordingUpload(s rowScanner) (*entity.RecordingUpload, error) {
+	upload := &entity.RecordingUpload{}
+	var chunks string
+	err := s.Scan(
+		&upload.ID,
+		&upload.RoomID,
+		&upload.UserID,
+		&upload.Length,
+		&upload.Offset,
+		&chunks,
+		&upload.ContentType,
+		&upload.DurationSeconds,
+		&upload.StartedAt,
+		&upload.RecordingID,
+		&upload.ExpiresAt,
+		&upload.CreatedAt,
+		&upload.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []recordingUploadChunk
+	if err := json.Unmarshal([]byte(chunks), &stored); err != nil {
+		return nil, err
+	}
+	for _, chunk := range stored {
+		upload.Chunks = append(upload.Chunks, entity.RecordingUploadChunk{
+			Key:    chunk.Key,
+			Offset: chunk.Offset,
+			Size:   chunk.Size,
+		})
+	}
+	return upload, nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// recordingUploadSlotColumns recording_upload_slotsのSELECT対象カラム
+const recordingUploadSlotColumns = `id, room_id, user_id, object_key, content_type, size, duration_seconds,
+		started_at, finalizing_at, recording_id, expires_at, created_at`
+
+type MySQLRecordingUploadSlotRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLRecordingUploadSlotRepository 新しい直接アップロードの枠リポジトリを作成
+func NewMySQLRecordingUploadSlotRepository(db *database.MySQL) port.RecordingUploadSlotRepository {
+	return &MySQLRecordingUploadSlotRepository{db: db}
+}
+
+// Create 枠を作成
+func (r *MySQLRecordingUploadSlotRepository) Create(ctx context.Context, slot *entity.RecordingUploadSlot) error {
+	query := `
+		INSERT INTO recording_upload_slots (id, room_id, user_id, object_key, content_type, size, duration_seconds,
+			started_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		slot.ID,
+		slot.RoomID,
+		slot.UserID,
+		slot.ObjectKey,
+		slot.ContentType,
+		slot.Size,
+		slot.DurationSeconds,
+		slot.StartedAt,
+		slot.ExpiresAt,
+	)
+	return err
+}
+
+// FindByID IDで枠を取得
+func (r *MySQLRecordingUploadSlotRepository) FindByID(ctx context.Context, id string) (*entity.RecordingUploadSlot, error) {
+	query := `
+		SELECT ` + recordingUploadSlotColumns + `
+		FROM recording_upload_slots
+		WHERE id = ?
+	`
+	slot, err := scanRecordingUploadSlot(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrUploadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
+
+// StartFinalizing 完了の処理を始める
+func (r *MySQLRecordingUploadSlotRepository) StartFinalizing(ctx context.Context, id string, now time.Time, staleBefore time.Time) (bool, error) {
+	query := `
+		UPDATE recording_upload_slots
+		SET finalizing_at = ?
+		WHERE id = ? AND recording_id IS NULL AND (finalizing_at IS NULL OR finalizing_at < ?)
+	`
+	result, err := r.db.ExecContext(ctx, query, now, id, staleBefore)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// CancelFinalizing 完了の処理をやめる
+func (r *MySQLRecordingUploadSlotRepository) CancelFinalizing(ctx context.Context, id string) error {
+	query := `UPDATE recording_upload_slots SET finalizing_at = NULL WHERE id = ? AND recording_id IS NULL`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// Complete 作成した録音を記録して完了にする
+func (r *MySQLRecordingUploadSlotRepository) Complete(ctx context.Context, id string, recordingID int64) error {
+	query := `UPDATE recording_upload_slots SET recording_id = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, recordingID, id)
+	return err
+}
+
+// Delete 枠を削除
+func (r *MySQLRecordingUploadSlotRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM recording_upload_slots WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
+
+// FindExpired 期限を過ぎた枠を取得
+func (r *MySQLRecordingUploadSlotRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.RecordingUploadSlot, error) {
+	query := `
+		SELECT ` + recordingUploadSlotColumns + `
+		FROM recording_upload_slots
+		WHERE expires_at <= ?
+		ORDER BY expires_at
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var slots []*entity.RecordingUploadSlot
+	for rows.Next() {
+		slot, err := scanRecordingUploadSlot(rows)
+		if err != nil {
+			return nil, err
+		}
+		slots = append(slots, slot)
+	}
+	return slots, rows.Err()
+}
+
+// scanRecordingUploadSlot recordingUploadSlotColumnsの順で枠をスキャン
+func scanRecordingUploadSlot(s rowScanner) (*entity.RecordingUploadSlot, error) {
+	slot := &entity.RecordingUploadSlot{}
+	err := s.Scan(
+		&slot.ID,
+		&slot.RoomID,
+		&slot.UserID,
+		&slot.ObjectKey,
+		&slot.ContentType,
+		&slot.Size,
+		&slot.DurationSeconds,
+		&slot.StartedAt,
+		&slot.FinalizingAt,
+		&slot.RecordingID,
+		&slot.ExpiresAt,
+		&slot.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return slot, nil
+}
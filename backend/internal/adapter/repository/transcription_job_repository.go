@@ -0,0 +1,310 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// transcriptionJobColumns transcription_jobsのSELECT対象カラム
+const transcriptionJobColumns = `id, room_id, requested_by, status, attempts, items, next_attempt_at, lease_owner,
+		lease_expires_at, last_error, minutes_id, started_at, finished_at, created_at, updated_at`
+
+// claimCandidates 1回の確保で競合を考慮して試す件数
+const claimCandidates = 5
+
+// transcriptionJobItem itemsカラムに保存する録音ごとの進捗
+type transcriptionJobItem struct {
+	RecordingID int64   `json:"recording_id"`
+	Status      string  `json:"status"`
+	Segments    int     `json:"segments"`
+	Error       *string `json:"error,omitempty"`
+}
+
+type MySQLTranscriptionJobRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLTranscriptionJobRepository 新しい文字起こしジョブリポジトリを作成
+func NewMySQLTranscriptionJobRepository(db *database.MySQL) port.TranscriptionJobRepository {
+	return &MySQLTranscriptionJobRepository{db: db}
+}
+
+// Create ジョブを作成
+func (r *MySQLTranscriptionJobRepository) Create(ctx context.Context, job *entity.TranscriptionJob) error {
+	items, err := encodeTranscriptionJobItems(job.Items)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO transcription_jobs (room_id, requested_by, status, attempts, items, next_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		job.RoomID,
+		job.RequestedBy,
+		job.Status,
+		job.Attempts,
+		items,
+		job.NextAttemptAt,
+	)
+	if err != nil {
+		// 実行待ち・実行中のジョブはルームごとに1つ（active_room_idの一意制約）
+		if isDuplicateError(err) {
+			return entity.ErrTranscriptionJobActive
+		}
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	job.ID = id
+	return nil
+}
+
+// Update ジョブを更新
+func (r *MySQLTranscriptionJobRepository) Update(ctx context.Context, job *entity.TranscriptionJob) error {
+	items, err := encodeTranscriptionJobItems(job.Items)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE transcription_jobs
+		SET status = ?, attempts = ?, items = ?, next_attempt_at = ?, lease_owner = ?, lease_expires_at = ?,
+			last_error = ?, minutes_id = ?, started_at = ?, finished_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		job.Status,
+		job.Attempts,
+		items,
+		job.NextAttemptAt,
+		job.LeaseOwner,
+		job.LeaseExpiresAt,
+		job.LastError,
+		job.MinutesID,
+		job.StartedAt,
+		job.FinishedAt,
+		job.ID,
+	)
+	return err
+}
+
+// FindByID IDでジョブを取得
+func (r *MySQLTranscriptionJobRepository) FindByID(ctx context.Context, id int64) (*entity.TranscriptionJob, error) {
+	query := `
+		SELECT ` + transcriptionJobColumns + `
+		FROM transcription_jobs
+		WHERE id = ?
+	`
+	job, err := scanTranscriptionJob(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrTranscriptionJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// FindActiveByRoomID ルームの実行待ち・実行中のジョブを取得
+func (r *MySQLTranscriptionJobRepository) FindActiveByRoomID(ctx context.Context, roomID int64) (*entity.TranscriptionJob, error) {
+	query := `
+		SELECT ` + transcriptionJobColumns + `
+		FROM transcription_jobs
+		WHERE room_id = ? AND status IN ('queued', 'running')
+		ORDER BY id DESC
+		LIMIT 1
+	`
+	job, err := scanTranscriptionJob(r.db.QueryRowContext(ctx, query, roomID))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrTranscriptionJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// FindByRoomID ルームのジョブ一覧を取得（新しい順）
+func (r *MySQLTranscriptionJobRepository) FindByRoomID(ctx context.Context, roomID int64, limit int) ([]*entity.TranscriptionJob, error) {
+	query := `
+		SELECT ` + transcriptionJobColumns + `
+		FROM transcription_jobs
+		WHERE room_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`
+	rows, err := r.db.QueryContext(ctx, query, roomID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*entity.TranscriptionJob
+	for rows.Next() {
+		job, err := scanTranscriptionJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+// Claim 実行できるジョブを1件確保
+// 候補を取得してから条件付きUPDATEで確保するため、複数のワーカー・レプリカが同時に呼び出しても同じジョブは1つだけが確保する
+func (r *MySQLTranscriptionJobRepository) Claim(ctx context.Context, owner string, now time.Time, leaseUntil time.Time) (*entity.TranscriptionJob, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id
+		FROM transcription_jobs
+		WHERE (status = 'queued' AND next_attempt_at <= ?)
+			OR (status = 'running' AND lease_expires_at < ?)
+		ORDER BY id ASC
+		LIMIT ?
+	`, now, now, claimCandidates)
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, id := range ids {
+		result, err := r.db.ExecContext(ctx, `
+			UPDATE transcription_jobs
+			SET status = 'running', attempts = attempts + 1, lease_owner = ?, lease_expires_at = ?,
+				next_attempt_at = NULL, started_at = COALESCE(started_at, ?), updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?
+				AND ((status = 'queued' AND next_attempt_at <= ?) OR (status = 'running' AND lease_expires_at < ?))
+		`, owner, leaseUntil, now, id, now, now)
+		if err != nil {
+			return nil, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if affected == 1 {
+			return r.FindByID(ctx, id)
+		}
+	}
+	return nil, entity.ErrTranscriptionJobNotFound
+}
+
+// UpdateItems 実行中のジョブの進捗を更新
+// 進捗以外の列は書き換えないため、延長したリースを古い値で上書きしない
+func (r *MySQLTranscriptionJobRepository) UpdateItems(ctx context.Context, id int64, owner string, items []entity.TranscriptionJobItem) (bool, error) {
+	encoded, err := encodeTranscriptionJobItems(items)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE transcription_jobs
+		SET items = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND status = 'running' AND lease_owner = ?
+	`, encoded, id, owner)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// RenewLease リースを延長
+func (r *MySQLTranscriptionJobRepository) RenewLease(ctx context.Context, id int64, owner string, leaseUntil time.Time) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE transcription_jobs
+		SET lease_expires_at = ?
+		WHERE id = ? AND status = 'running' AND lease_owner = ?
+	`, leaseUntil, id, owner)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func encodeTranscriptionJobItems(items []entity.TranscriptionJobItem) (string, error) {
+	stored := make([]transcriptionJobItem, 0, len(items))
+	for _, item := range items {
+		stored = append(stored, transcriptionJobItem{
+			RecordingID: item.RecordingID,
+			Status:      string(item.Status),
+			Segments:    item.Segments,
+			Error:       item.Error,
+		})
+	}
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// scanTranscriptionJob transcriptionJobColumnsの順でジョブをスキャン
+func scanTranscriptionJob(s rowScanner) (*entity.TranscriptionJob, error) {
+	job := &entity.TranscriptionJob{}
+	var items string
+	err := s.Scan(
+		&job.ID,
+		&job.RoomID,
+		&job.RequestedBy,
+		&job.Status,
+		&job.Attempts,
+		&items,
+		&job.NextAttemptAt,
+		&job.LeaseOwner,
+		&job.LeaseExpiresAt,
+		&job.LastError,
+		&job.MinutesID,
+		&job.StartedAt,
+		&job.FinishedAt,
+		&job.CreatedAt,
+		&job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var stored []transcriptionJobItem
+	if err := json.Unmarshal([]byte(items), &stored); err != nil {
+		return nil, err
+	}
+	for _, item := range stored {
+		job.Items = append(job.Items, entity.TranscriptionJobItem{
+			RecordingID: item.RecordingID,
+			Status:      entity.TranscriptionItemStatus(item.Status),
+			Segments:    item.Segments,
+			Error:       item.Error,
+		})
+	}
+	return job, nil
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// transcriptionRunColumns call_transcription_runsのSELECT対象カラム
+const transcriptionRunColumns = `id, room_id, job_id, engine, language, alternative_languages, status, is_current,
+		segments, recordings, error, started_at, finished_at, created_at, updated_at`
+
+type MySQLTranscriptionRunRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLTranscriptionRunRepository 新しい文字起こしの実行リポジトリを作成
+func NewMySQLTranscriptionRunRepository(db *database.MySQL) port.TranscriptionRunRepository {
+	return &MySQLTranscriptionRunRepository{db: db}
+}
+
+// Create 実行を作成（現在の実行にはしない）
+func (r *MySQLTranscriptionRunRepository) Create(ctx context.Context, run *entity.TranscriptionRun) error {
+	alternatives, err := encodeStringList(run.AlternativeLanguages)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO call_transcription_runs (room_id, job_id, engine, language, alternative_languages, status,
+			segments, recordings, error, started_at, finished_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		run.RoomID,
+		run.JobID,
+		run.Engine,
+		run.Language,
+		alternatives,
+		run.Status,
+		run.Segments,
+		run.Recordings,
+		run.Error,
+		run.StartedAt,
+		run.FinishedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	run.ID = id
+	run.IsCurrent = false
+	return nil
+}
+
+// Update 実行を更新
+func (r *MySQLTranscriptionRunRepository) Update(ctx context.Context, run *entity.TranscriptionRun) error {
+	query := `
+		UPDATE call_transcription_runs
+		SET status = ?, segments = ?, recordings = ?, error = ?, finished_at = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		run.Status,
+		run.Segments,
+		run.Recordings,
+		run.Error,
+		run.FinishedAt,
+		run.ID,
+	)
+	return err
+}
+
+// FindByID IDで実行を取得
+func (r *MySQLTranscriptionRunRepository) FindByID(ctx context.Context, id int64) (*entity.TranscriptionRun, error) {
+	query := `
+		SELECT ` + transcriptionRunColumns + `
+		FROM call_transcription_runs
+		WHERE id = ?
+	`
+	run, err := scanTranscriptionRun(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrTranscriptionRunNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// FindByRoomID ルームの実行一覧を取得（新しい順）
+func (r *MySQLTranscriptionRunRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.TranscriptionRun, error) {
+	query := `
+		SELECT ` + transcriptionRunColumns + `
+		FROM call_transcription_runs
+		WHERE room_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+	rows, err := r.db.QueryContext(ctx, query, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []*entity.TranscriptionRun
+	for rows.Next() {
+		run, err := scanTranscriptionRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+// FindCurrentByRoomID ルームの現在の実行を取得
+func (r *MySQLTranscriptionRunRepository) FindCurrentByRoomID(ctx context.Context, roomID int64) (*entity.TranscriptionRun, error) {
+	query := `
+		SELECT ` + transcriptionRunColumns + `
+		FROM call_transcription_runs
+		WHERE room_id = ? AND is_current = TRUE
+	`
+	run, err := scanTranscriptionRun(r.db.QueryRowContext(ctx, query, roomID))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrTranscriptionRunNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// SetCurrent ルームの現在の実行を切り替える
+// 一意制約（ルームごとに1つ）に反しないよう、前の現在の実行を解除してから設定する
+func (r *MySQLTranscriptionRunRepository) SetCurrent(ctx context.Context, roomID int64, runID int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE call_transcription_runs
+		SET is_current = FALSE, updated_at = CURRENT_TIMESTAMP
+		WHERE room_id = ? AND is_current = TRUE AND id <> ?
+	`, roomID, runID); err != nil {
+		return err
+	}
+	result, err := tx.ExecContext(ctx, `
+		UPDATE call_transcription_runs
+		SET is_current = TRUE, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND room_id = ?
+	`, runID, roomID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	// 既に現在の実行の場合も変更なしで0件になるため、存在するかを確認する
+	if affected == 0 {
+		var exists bool
+		err := tx.QueryRowContext(ctx, `
+			SELECT EXISTS(SELECT 1 FROM call_transcription_runs WHERE id = ? AND room_id = ?)
+		`, runID, roomID).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return entity.ErrTranscriptionRunNotFound
+		}
+	}
+	return tx.Commit()
+}
+
+// scanTranscriptionRun transcriptionRunColumnsの順で実行をスキャン（候補の言語はJSONから変換）
+func scanTranscriptionRun(s rowScanner) (*entity.TranscriptionRun, error) {
+	run := &entity.TranscriptionRun{}
+	var alternatives sql.NullString
+	err := s.Scan(
+		&run.ID,
+		&run.RoomID,
+		&run.JobID,
+		&run.Engine,
+		&run.Language,
+		&alternatives,
+		&run.Status,
+		&run.IsCurrent,
+		&run.Segments,
+		&run.Recordings,
+		&run.Error,
+		&run.StartedAt,
+		&run.FinishedAt,
+		&run.CreatedAt,
+		&run.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if run.AlternativeLanguages, err = decodeStringList(alternatives); err != nil {
+		return nil, err
+	}
+	return run, nil
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// userDeviceColumns user_devicesのSELECT対象カラム
+const userDeviceColumns = `id, user_id, device_hash, user_agent, ip_address, first_seen_at, last_seen_at`
+
+type MySQLUserDeviceRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLUserDeviceRepository 新しいUserDeviceリポジトリを作成
+func NewMySQLUserDeviceRepository(db *database.MySQL) port.UserDeviceRepository {
+	return &MySQLUserDeviceRepository{db: db}
+}
+
+// FindByHash ユーザーIDとハッシュで端末を取得
+func (r *MySQLUserDeviceRepository) FindByHash(ctx context.Context, userID int64, hash string) (*entity.UserDevice, error) {
+	query := `
+		SELECT ` + userDeviceColumns + `
+		FROM user_devices
+		WHERE user_id = ? AND device_hash = ?
+	`
+	device := &entity.UserDevice{}
+	err := r.db.QueryRowContext(ctx, query, userID, hash).Scan(
+		&device.ID,
+		&device.UserID,
+		&device.DeviceHash,
+		&device.UserAgent,
+		&device.IPAddress,
+		&device.FirstSeenAt,
+		&device.LastSeenAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrUserDeviceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return device, nil
+}
+
+// CountByUserID ユーザーの端末数を取得
+func (r *MySQLUserDeviceRepository) CountByUserID(ctx context.Context, userID int64) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM user_devices WHERE user_id = ?`, userID).Scan(&count)
+	return count, err
+}
+
+// Create 端末を作成
+// 同じ端末から同時にログインした場合は最終ログイン日時のみ更新する
+func (r *MySQLUserDeviceRepository) Create(ctx context.Context, device *entity.UserDevice) error {
+	query := `
+		INSERT INTO user_devices (user_id, device_hash, user_agent, ip_address, first_seen_at, last_seen_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE id = LAST_INSERT_ID(id), ip_address = VALUES(ip_address), last_seen_at = VALUES(last_seen_at)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		device.UserID,
+		device.DeviceHash,
+		device.UserAgent,
+		device.IPAddress,
+		device.FirstSeenAt,
+		device.LastSeenAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	device.ID = id
+	return nil
+}
+
+// UpdateLastSeen 最終ログイン日時とIPアドレスを更新
+func (r *MySQLUserDeviceRepository) UpdateLastSeen(ctx context.Context, device *entity.UserDevice) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE user_devices SET ip_address = ?, last_seen_at = ? WHERE id = ?`,
+		device.IPAddress, device.LastSeenAt, device.ID)
+	return err
+}
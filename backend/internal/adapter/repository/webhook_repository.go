@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/database"
+)
+
+// webhookColumns webhooksのSELECT対象カラム
+const webhookColumns = `id, user_id, url, secret, events, is_active, created_at, updated_at`
+
+// webhookDeliveryColumns webhook_deliveriesのSELECT対象カラム
+const webhookDeliveryColumns = `id, webhook_id, event_id, event, payload, status, attempts, next_attempt_at,
+		last_status_code, last_error, delivered_at, replay_of, created_at, updated_at`
+
+type MySQLWebhookRepository struct {
+	db *database.MySQL
+}
+
+// NewMySQLWebhookRepository 新しいWebhookリポジトリを作成
+func NewMySQLWebhookRepository(db *database.MySQL) port.WebhookRepository {
+	return &MySQLWebhookRepository{db: db}
+}
+
+// Create Webhookを作成
+func (r *MySQLWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO webhooks (user_id, url, secret, events, is_active)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		webhook.UserID,
+		webhook.URL,
+		webhook.Secret,
+		string(events),
+		webhook.IsActive,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	webhook.ID = id
+	return nil
+}
+
+// Update Webhookを更新
+func (r *MySQLWebhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	events, err := json.Marshal(webhook.Events)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE webhooks
+		SET url = ?, secret = ?, events = ?, is_active = ?, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		webhook.URL,
+		webhook.Secret,
+		string(events),
+		webhook.IsActive,
+		webhook.ID,
+	)
+	return err
+}
+
+// Delete Webhookを削除（配信記録は外部キーで削除される）
+func (r *MySQLWebhookRepository) Delete(ctx context.Context, id int64) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM webhooks WHERE id = ?`, id)
+	return err
+}
+
+// FindByID IDでWebhookを取得
+func (r *MySQLWebhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE id = ?
+	`
+	webhook, err := scanWebhook(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// FindByUserID ユーザーのWebhook一覧を取得
+func (r *MySQLWebhookRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE user_id = ?
+		ORDER BY created_at ASC, id ASC
+	`
+	return r.findWebhooks(ctx, query, userID)
+}
+
+// FindActiveByUserID ユーザーの有効なWebhook一覧を取得
+func (r *MySQLWebhookRepository) FindActiveByUserID(ctx context.Context, userID int64) ([]*entity.Webhook, error) {
+	query := `
+		SELECT ` + webhookColumns + `
+		FROM webhooks
+		WHERE user_id = ? AND is_active = TRUE
+		ORDER BY id ASC
+	`
+	return r.findWebhooks(ctx, query, userID)
+}
+
+// CreateDelivery 配信記録を作成
+func (r *MySQLWebhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_id, event, payload, status, attempts, next_attempt_at, replay_of)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		delivery.WebhookID,
+		delivery.EventID,
+		delivery.Event,
+		string(delivery.Payload),
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.ReplayOf,
+	)
+	if err != nil {
+		return err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	delivery.ID = id
+	return nil
+}
+
+// UpdateDelivery 配信記録の送信結果を更新
+func (r *MySQLWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_status_code = ?, last_error = ?, delivered_at = ?,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastStatusCode,
+		delivery.LastError,
+		delivery.DeliveredAt,
+		delivery.ID,
+	)
+	return err
+}
+
+// FindDeliveryByID IDで配信記録を取得
+func (r *MySQLWebhookRepository) FindDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE id = ?
+	`
+	delivery, err := scanWebhookDelivery(r.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// FindDeliveriesByWebhookID Webhookの配信記録一覧を取得（新しい順）
+func (r *MySQLWebhookRepository) FindDeliveriesByWebhookID(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE webhook_id = ?
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`
+	return r.findDeliveries(ctx, query, webhookID, limit)
+}
+
+// FindDueDeliveries 送信予定時刻を過ぎた配信待ちの記録を取得（古い順）
+func (r *MySQLWebhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	query := `
+		SELECT ` + webhookDeliveryColumns + `
+		FROM webhook_deliveries
+		WHERE status = 'pending' AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC, id ASC
+		LIMIT ?
+	`
+	return r.findDeliveries(ctx, query, now, limit)
+}
+
+// findWebhooks 複数のWebhookを取得
+func (r *MySQLWebhookRepository) findWebhooks(ctx context.Context, query string, args ...interface{}) ([]*entity.Webhook, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []*entity.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, rows.Err()
+}
+
+// findDeliveries 複数の配信記録を取得
+func (r *MySQLWebhookRepository) findDeliveries(ctx context.Context, query string, args ...interface{}) ([]*entity.WebhookDelivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []*entity.WebhookDelivery
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// scanWebhook webhookColumnsの順でWebhookをスキャン
+func scanWebhook(s rowScanner) (*entity.Webhook, error) {
+	webhook := &entity.Webhook{}
+	var events string
+	err := s.Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.URL,
+		&webhook.Secret,
+		&events,
+		&webhook.IsActive,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(events), &webhook.Events); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// scanWebhookDelivery webhookDeliveryColumnsの順で配信記録をスキャン
+func scanWebhookDelivery(s rowScanner) (*entity.WebhookDelivery, error) {
+	delivery := &entity.WebhookDelivery{}
+	var payload string
+	err := s.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.Event,
+		&payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastStatusCode,
+		&delivery.LastError,
+		&delivery.DeliveredAt,
+		&delivery.ReplayOf,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Payload = []byte(payload)
+	return delivery, nil
+}
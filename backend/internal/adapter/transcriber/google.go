@@ -0,0 +1,64 @@
+package transcriber
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/transcription"
+)
+
+// GoogleTranscriber Google Cloud Speech-to-Textで文字起こしする
+type GoogleTranscriber struct {
+	client *transcription.SpeechToTextClient
+}
+
+// NewGoogleTranscriber 新しいGoogleTranscriberを作成
+func NewGoogleTranscriber(client *transcription.SpeechToTextClient) port.Transcriber {
+	return &GoogleTranscriber{client: client}
+}
+
+// Name エンジン名
+func (t *GoogleTranscriber) Name() string {
+	return "google"
+}
+
+// SupportsLanguages クライアントは言語コードを1つしか受け付けず、話された言語を判定できないため、
+// 自動判定や候補の言語は扱えない
+func (t *GoogleTranscriber) SupportsLanguages(opts port.TranscribeOptions) error {
+	if opts.Language == "" {
+		return fmt.Errorf("%w: Speech-to-Text cannot detect the spoken language, set a transcription language", port.ErrLanguageNotSupported)
+	}
+	if len(opts.AlternativeLanguages) > 0 {
+		return fmt.Errorf("%w: Speech-to-Text cannot choose between alternative languages %v, remove them or use whisper", port.ErrLanguageNotSupported, opts.AlternativeLanguages)
+	}
+	return nil
+}
+
+// Transcribe GCS上の音声を文字起こし（他のストレージの音声・扱えない言語の条件はエラーにする）
+func (t *GoogleTranscriber) Transcribe(ctx context.Context, audio port.TranscriptionAudio, opts port.TranscribeOptions) ([]port.TranscriptSegment, error) {
+	if !strings.HasPrefix(audio.URI, "gs://") {
+		return nil, fmt.Errorf("%w: Speech-to-Text requires a gs:// URI, got %q", port.ErrAudioNotSupported, audio.URI)
+	}
+	if err := t.SupportsLanguages(opts); err != nil {
+		return nil, err
+	}
+
+	results, err := t.client.TranscribeFromGCS(ctx, audio.URI, opts.Language, opts.Diarization)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]port.TranscriptSegment, 0, len(results))
+	for _, r := range results {
+		segments = append(segments, port.TranscriptSegment{
+			Text:       r.Text,
+			SpeakerTag: r.SpeakerTag,
+			Confidence: r.Confidence,
+			StartTime:  r.StartTime,
+			EndTime:    r.EndTime,
+			Language:   opts.Language,
+		})
+	}
+	return segments, nil
+}
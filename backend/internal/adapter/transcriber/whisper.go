@@ -0,0 +1,81 @@
+package transcriber
+
+import (
+	"context"
+	"fmt"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/whisper"
+)
+
+// WhisperTranscriber whisper.cppをローカルで実行して文字起こしする（外部サービスに接続しない）
+type WhisperTranscriber struct {
+	client *whisper.Client
+}
+
+// NewWhisperTranscriber 新しいWhisperTranscriberを作成
+func NewWhisperTranscriber(client *whisper.Client) port.Transcriber {
+	return &WhisperTranscriber{client: client}
+}
+
+// Name エンジン名
+func (t *WhisperTranscriber) Name() string {
+	return "whisper"
+}
+
+// SupportsLanguages 自動判定と候補の言語のどちらも扱える
+func (t *WhisperTranscriber) SupportsLanguages(opts port.TranscribeOptions) error {
+	return nil
+}
+
+// Transcribe ストレージから音声を読み込んで文字起こし
+// whisper.cppは候補の言語を指定できないため、候補がある場合は自動判定して判定結果を候補の言語コードに合わせる
+func (t *WhisperTranscriber) Transcribe(ctx context.Context, audio port.TranscriptionAudio, opts port.TranscribeOptions) ([]port.TranscriptSegment, error) {
+	if audio.Open == nil {
+		return nil, fmt.Errorf("%w: audio data is not readable", port.ErrAudioNotSupported)
+	}
+	body, err := audio.Open(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audio: %w", err)
+	}
+	defer body.Close()
+
+	language := opts.Language
+	if len(opts.AlternativeLanguages) > 0 {
+		language = ""
+	}
+	results, err := t.client.Transcribe(ctx, body, whisper.Options{
+		Language:    language,
+		Diarization: opts.Diarization,
+	})
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]port.TranscriptSegment, 0, len(results))
+	for _, r := range results {
+		segments = append(segments, port.TranscriptSegment{
+			Text:       r.Text,
+			SpeakerTag: r.Speaker,
+			Confidence: r.Confidence,
+			StartTime:  r.Start,
+			EndTime:    r.End,
+			Language:   candidateLanguage(r.Language, opts),
+		})
+	}
+	return segments, nil
+}
+
+// candidateLanguage whisperが判定した言語（ja、en など）を指定された候補の言語コード（ja-JP など）にする
+// 候補にない言語はそのまま返す
+func candidateLanguage(detected string, opts port.TranscribeOptions) string {
+	if detected == "" {
+		return ""
+	}
+	for _, candidate := range append([]string{opts.Language}, opts.AlternativeLanguages...) {
+		if candidate != "" && entity.SameLanguage(candidate, detected) {
+			return candidate
+		}
+	}
+	return detected
+}
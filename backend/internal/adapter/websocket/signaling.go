@@ -1,12 +1,17 @@
 package websocket
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/websocket"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/pkg/crdt"
 )
 
 // Upgrader WebSocket接続をアップグレードする設定
@@ -34,6 +39,11 @@ type Client struct {
 	UserID int64
 	Conn   *websocket.Conn
 	Send   chan []byte
+
+	// ロールは通話中に変更されるためロックで保護する
+	mu      sync.RWMutex
+	role    entity.ParticipantRole
+	sharing bool
 }
 
 // Room 通話ルーム
@@ -49,9 +59,24 @@ type SignalingServer struct {
 	register   chan *Client
 	unregister chan *Client
 	broadcast  chan *BroadcastMessage
+	notes      NotesSync
 	mu         sync.RWMutex
 }
 
+// NotesSync 共有ノートの同期先（サーバー上のCRDT文書）
+type NotesSync interface {
+	// 共有ノートの要素列を取得
+	Sync(ctx context.Context, roomID string) ([]crdt.Element, error)
+	// 参加者の操作を適用し、新たに適用された操作を返す
+	Apply(ctx context.Context, roomID string, site string, ops []crdt.Op) ([]crdt.Op, error)
+}
+
+// notesData 共有ノートのメッセージの内容
+type notesData struct {
+	Elements []crdt.Element `json:"elements,omitempty"`
+	Ops      []crdt.Op      `json:"ops,omitempty"`
+}
+
 // BroadcastMessage ブロードキャストメッセージ
 type BroadcastMessage struct {
 	RoomID  string
@@ -60,12 +85,13 @@ type BroadcastMessage struct {
 }
 
 // NewSignalingServer 新しいシグナリングサーバーを作成
-func NewSignalingServer() *SignalingServer {
+func NewSignalingServer(notes NotesSync) *SignalingServer {
 	return &SignalingServer{
 		rooms:      make(map[string]*Room),
 		register:   make(chan *Client),
 		unregister: make(chan *Client),
 		broadcast:  make(chan *BroadcastMessage),
+		notes:      notes,
 	}
 }
 
@@ -194,7 +220,7 @@ func (s *SignalingServer) broadcastToRoom(message *BroadcastMessage) {
 }
 
 // HandleWebSocket WebSocket接続を処理
-func (s *SignalingServer) HandleWebSocket(w http.ResponseWriter, r *http.Request, roomID string, clientID string, userID int64) {
+func (s *SignalingServer) HandleWebSocket(w http.ResponseWriter, r *http.Request, roomID string, clientID string, userID int64, role entity.ParticipantRole) {
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		slog.Error("WebSocket upgrade failed", slog.String("error", err.Error()))
@@ -207,6 +233,7 @@ func (s *SignalingServer) HandleWebSocket(w http.ResponseWriter, r *http.Request
 		UserID: userID,
 		Conn:   conn,
 		Send:   make(chan []byte, 256),
+		role:   role,
 	}
 
 	s.register <- client
@@ -263,6 +290,15 @@ func (s *SignalingServer) handleMessage(client *Client, msg *Message) {
 	case "offer", "answer", "ice-candidate":
 		// P2Pシグナリングメッセージを転送
 		s.forwardMessage(client, msg)
+	case "screen-share-start", "screen-share-stop":
+		// 画面共有はロールの権限を確認してからルーム全体に通知
+		s.handleScreenShare(client, msg)
+	case "notes-sync":
+		// 共有ノートの現在の内容を要求したクライアントに返す
+		s.handleNotesSync(client)
+	case "notes-op":
+		// 共有ノートの編集を適用してルーム全体に通知
+		s.handleNotesOp(client, msg)
 	case "leave":
 		// 退出処理
 		s.unregister <- client
@@ -313,3 +349,213 @@ func (s *SignalingServer) forwardMessage(client *Client, msg *Message) {
 		slog.Warn("Failed to send message to target client", slog.String("client_id", msg.To))
 	}
 }
+
+// handleScreenShare 画面共有の開始・停止を処理
+func (s *SignalingServer) handleScreenShare(client *Client, msg *Message) {
+	starting := msg.Type == "screen-share-start"
+
+	client.mu.Lock()
+	if starting && !client.role.Can(entity.PermissionScreenShare) {
+		client.mu.Unlock()
+		s.sendError(client, msg.Type, "permission denied")
+		return
+	}
+	client.sharing = starting
+	client.mu.Unlock()
+
+	s.broadcastMessage(client.RoomID, msg, client.ID)
+}
+
+// handleNotesSync 共有ノートの要素列を返す
+// クライアントはこの応答を受け取るまでに届いたnotes-opを保留し、復元した文書に適用し直す
+func (s *SignalingServer) handleNotesSync(client *Client) {
+	if s.notes == nil {
+		s.sendError(client, "notes-sync", "notes not available")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	elements, err := s.notes.Sync(ctx, client.RoomID)
+	if err != nil {
+		slog.Warn("Failed to sync notes", slog.String("room_id", client.RoomID), slog.String("error", err.Error()))
+		s.sendError(client, "notes-sync", err.Error())
+		return
+	}
+	if elements == nil {
+		elements = []crdt.Element{}
+	}
+	data, _ := json.Marshal(notesData{Elements: elements})
+	s.sendToClient(client, &Message{Type: "notes-sync", Data: data})
+}
+
+// handleNotesOp 共有ノートの編集を適用し、適用できた操作を他の参加者に通知
+func (s *SignalingServer) handleNotesOp(client *Client, msg *Message) {
+	if s.notes == nil {
+		s.sendError(client, msg.Type, "notes not available")
+		return
+	}
+
+	client.mu.RLock()
+	canEdit := client.role.Can(entity.PermissionEditNotes)
+	client.mu.RUnlock()
+	if !canEdit {
+		s.sendError(client, msg.Type, "permission denied")
+		return
+	}
+
+	var data notesData
+	if err := json.Unmarshal(msg.Data, &data); err != nil || len(data.Ops) == 0 {
+		s.sendError(client, msg.Type, "invalid notes operation")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	applied, err := s.notes.Apply(ctx, client.RoomID, client.ID, data.Ops)
+	if len(applied) > 0 {
+		encoded, _ := json.Marshal(notesData{Ops: applied})
+		s.broadcastMessage(client.RoomID, &Message{Type: "notes-op", From: client.ID, Data: encoded}, client.ID)
+	}
+	if err != nil {
+		// 適用できなかった操作はクライアントの文書と食い違うため、notes-syncで同期し直してもらう
+		slog.Warn("Failed to apply notes operation", slog.String("client_id", client.ID), slog.String("error", err.Error()))
+		s.sendError(client, msg.Type, err.Error())
+	}
+}
+
+// SetClientRole 接続中クライアントのロールを更新し、ルーム全体に通知
+// 画面共有の権限を失った場合は共有を停止させる
+func (s *SignalingServer) SetClientRole(roomID string, clientID string, role entity.ParticipantRole) {
+	if client := s.findClient(roomID, clientID); client != nil {
+		client.mu.Lock()
+		client.role = role
+		stopSharing := client.sharing && !role.Can(entity.PermissionScreenShare)
+		if stopSharing {
+			client.sharing = false
+		}
+		client.mu.Unlock()
+
+		if stopSharing {
+			s.broadcastMessage(roomID, &Message{Type: "screen-share-stop", From: clientID}, "")
+		}
+	}
+
+	data, _ := json.Marshal(map[string]string{"client_id": clientID, "role": string(role)})
+	s.broadcastMessage(roomID, &Message{Type: "role-changed", Data: data}, "")
+}
+
+// KickClient クライアントに退出を通知して切断
+func (s *SignalingServer) KickClient(roomID string, clientID string) {
+	data, _ := json.Marshal(map[string]string{"client_id": clientID})
+	msgBytes, _ := json.Marshal(&Message{Type: "kicked", Data: data})
+
+	if room := s.getRoom(roomID); room != nil {
+		// ロック中に送信することで登録解除済み（Sendが閉じられた）クライアントへの送信を防ぐ
+		room.mu.RLock()
+		client := room.Clients[clientID]
+		if client != nil {
+			select {
+			case client.Send <- msgBytes:
+			default:
+			}
+		}
+		room.mu.RUnlock()
+
+		if client != nil {
+			s.unregister <- client
+		}
+	}
+
+	// 他の参加者にも通知
+	s.broadcast <- &BroadcastMessage{
+		RoomID:  roomID,
+		Message: msgBytes,
+		Exclude: clientID,
+	}
+}
+
+// BroadcastEvent サーバー側で発生したイベント（投票の開始・集計結果など）をルーム全体に通知
+func (s *SignalingServer) BroadcastEvent(roomID string, msgType string, data interface{}) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		slog.Error("Failed to marshal event data", slog.String("type", msgType), slog.String("error", err.Error()))
+		return
+	}
+	s.broadcastMessage(roomID, &Message{Type: msgType, Data: encoded}, "")
+}
+
+// broadcastMessage メッセージをルーム内にブロードキャスト
+func (s *SignalingServer) broadcastMessage(roomID string, msg *Message, exclude string) {
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("Failed to marshal message", slog.String("error", err.Error()))
+		return
+	}
+	s.broadcast <- &BroadcastMessage{
+		RoomID:  roomID,
+		Message: msgBytes,
+		Exclude: exclude,
+	}
+}
+
+// sendError クライアントにエラーを通知
+func (s *SignalingServer) sendError(client *Client, msgType string, reason string) {
+	data, _ := json.Marshal(map[string]string{"type": msgType, "error": reason})
+	s.sendToClient(client, &Message{Type: "error", Data: data})
+}
+
+// sendToClient 登録中のクライアントにメッセージを送信
+func (s *SignalingServer) sendToClient(client *Client, msg *Message) {
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		slog.Error("Failed to marshal message", slog.String("error", err.Error()))
+		return
+	}
+
+	room := s.getRoom(client.RoomID)
+	if room == nil {
+		return
+	}
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+	if _, ok := room.Clients[client.ID]; !ok {
+		return
+	}
+	select {
+	case client.Send <- msgBytes:
+	default:
+		slog.Warn("Failed to send message to client", slog.String("client_id", client.ID))
+	}
+}
+
+// HasConnections ルームに接続中のクライアントがいるか
+func (s *SignalingServer) HasConnections(roomID string) bool {
+	room := s.getRoom(roomID)
+	if room == nil {
+		return false
+	}
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+	return len(room.Clients) > 0
+}
+
+// getRoom ルームを取得
+func (s *SignalingServer) getRoom(roomID string) *Room {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rooms[roomID]
+}
+
+// findClient ルーム内のクライアントを取得
+func (s *SignalingServer) findClient(roomID string, clientID string) *Client {
+	room := s.getRoom(roomID)
+	if room == nil {
+		return nil
+	}
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+	return room.Clients[clientID]
+}
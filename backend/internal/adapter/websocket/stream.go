@@ -0,0 +1,69 @@
+package websocket
+
+import (
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// streamWriteWait 1メッセージの送信にかけられる時間
+	streamWriteWait = 10 * time.Second
+	// streamPingInterval プロキシに切断されないよう送るPingの間隔
+	streamPingInterval = 30 * time.Second
+)
+
+// HandleStream サーバーから一方向にメッセージを配信するWebSocket接続を処理
+// messagesが閉じられるか、クライアントが切断するまでJSONで送信し続ける。
+// 終了時にはonCloseを呼び、messagesが閉じられるまで読み捨てる
+func HandleStream(w http.ResponseWriter, r *http.Request, messages <-chan interface{}, onClose func()) {
+	defer func() {
+		onClose()
+		for range messages {
+		}
+	}()
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		slog.Error("WebSocket upgrade failed", slog.String("error", err.Error()))
+		return
+	}
+	defer conn.Close()
+
+	// クライアントからの受信は切断の検知にのみ使う
+	disconnected := make(chan struct{})
+	go func() {
+		defer close(disconnected)
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(streamPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg, ok := <-messages:
+			conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+			if err := conn.WriteJSON(msg); err != nil {
+				slog.Error("WebSocket write error", slog.String("error", err.Error()))
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait)); err != nil {
+				return
+			}
+		case <-disconnected:
+			return
+		}
+	}
+}
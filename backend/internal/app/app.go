@@ -1,27 +1,38 @@
 package app
 
 import (
+	"context"
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
 
 	"Go-Next-WebRTC/internal/adapter/http/types"
+	"Go-Next-WebRTC/internal/application/usecase"
 	"Go-Next-WebRTC/internal/config"
 	"Go-Next-WebRTC/internal/domain/port"
 	"Go-Next-WebRTC/internal/infrastructure/router"
 	"Go-Next-WebRTC/pkg/database"
 	"Go-Next-WebRTC/pkg/logger"
-	"Go-Next-WebRTC/pkg/storage"
 	"Go-Next-WebRTC/pkg/transcription"
 )
 
 // Dependencies アプリケーションの依存関係
 type Dependencies struct {
-	DB           *database.MySQL
-	GCSClient    *storage.GCSClient
-	SpeechClient *transcription.SpeechToTextClient
-	Handlers     *types.Handlers
-	AuthRepo     port.AuthRepository
+	DB                *database.MySQL
+	Storage           port.ObjectStorage
+	SpeechClient      *transcription.SpeechToTextClient
+	Handlers          *types.Handlers
+	AuthRepo          port.AuthRepository
+	RoomReaper        usecase.RoomReaperUsecase
+	Webhooks          usecase.WebhookUsecase
+	Lobby             usecase.LobbyUsecase
+	DirectCalls       usecase.DirectCallUsecase
+	Notes             usecase.NotesUsecase
+	TranscriptionJobs usecase.TranscriptionJobUsecase
+	RecordingUploads  usecase.RecordingUploadUsecase
+	DirectUploads     usecase.DirectUploadUsecase
+	RecordingMixes    usecase.RecordingMixUsecase
 }
 
 // Close リソースのクリーンアップ
@@ -29,8 +40,8 @@ func (d *Dependencies) Close() {
 	if d.DB != nil {
 		d.DB.Close()
 	}
-	if d.GCSClient != nil {
-		d.GCSClient.Close()
+	if closer, ok := d.Storage.(io.Closer); ok {
+		closer.Close()
 	}
 	if d.SpeechClient != nil {
 		d.SpeechClient.Close()
@@ -59,12 +70,12 @@ func Run() error {
 	r := router.NewRouter(deps.Handlers, deps.AuthRepo)
 
 	// 5. サーバーの起動
-	return startServer(cfg, r, deps.AuthRepo)
+	return startServer(cfg, r, deps)
 }
 
 
 // startServer HTTPサーバーの起動とグレースフルシャットダウン
-func startServer(cfg *config.Config, handler http.Handler, authRepo port.AuthRepository) error {
+func startServer(cfg *config.Config, handler http.Handler, deps *Dependencies) error {
 	// サーバーインスタンスの作成
 	server := NewServer(cfg, handler)
 
@@ -72,13 +83,45 @@ func startServer(cfg *config.Config, handler http.Handler, authRepo port.AuthRep
 	server.Start()
 
 	// 定期的なクリーンアップタスク
-	go StartCleanupTasks(authRepo)
+	go StartCleanupTasks(deps.AuthRepo, deps.RoomReaper, cfg.RoomReaperInterval)
+
+	// Webhookの配信
+	go StartWebhookDelivery(deps.Webhooks, cfg.WebhookDeliveryInterval)
+
+	// ロビーの参加人数のずれを補正
+	go StartLobbyResync(deps.Lobby, cfg.LobbyResyncInterval)
+
+	// 応答のない着信を不在着信にする
+	go StartDirectCallExpiry(deps.DirectCalls, cfg.DirectCallExpiryInterval)
+
+	// 共有ノートのスナップショットを保存
+	go StartNotesSnapshots(deps.Notes, cfg.NotesSnapshotInterval)
+
+	// 期限切れの録音アップロード・直接アップロードの枠を削除
+	go StartRecordingUploadCleanup(deps.RecordingUploads, deps.DirectUploads, cfg.RecordingUploadCleanup)
+
+	// 文字起こしジョブの実行
+	transcriptionCtx, stopTranscription := context.WithCancel(context.Background())
+	transcriptionWorkers := StartTranscriptionWorkers(transcriptionCtx, deps.TranscriptionJobs,
+		cfg.TranscriptionWorkers, cfg.TranscriptionPollInterval, cfg.TranscriptionJobTimeout)
+
+	// 録音のミックスの実行
+	mixWorkers := StartRecordingMixWorkers(transcriptionCtx, deps.RecordingMixes, cfg.RecordingMixWorkers, cfg.RecordingMixPollInterval)
 
 	// シャットダウンシグナルを待機
 	server.WaitForShutdown()
 
 	// グレースフルシャットダウン
-	return server.Shutdown()
+	err := server.Shutdown()
+
+	// 編集中の共有ノートを失わないよう最後に保存
+	saveNotesSnapshots(deps.Notes)
+
+	// 実行中の文字起こしジョブ・ミックスは中断して実行待ちに戻す（他のレプリカか次回の起動で再開する）
+	stopTranscription()
+	transcriptionWorkers.Wait()
+	mixWorkers.Wait()
+	return err
 }
 
 // initLogger 構造化ログの初期化
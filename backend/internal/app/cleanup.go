@@ -5,16 +5,31 @@ import (
 	"log/slog"
 	"time"
 
+	"Go-Next-WebRTC/internal/application/usecase"
 	"Go-Next-WebRTC/internal/domain/port"
 )
 
 // StartCleanupTasks 定期的なクリーンアップタスクを開始
-func StartCleanupTasks(authRepo port.AuthRepository) {
+// reaperIntervalが0以下の場合、放置されたルームの終了は行わない
+func StartCleanupTasks(authRepo port.AuthRepository, roomReaper usecase.RoomReaperUsecase, reaperInterval time.Duration) {
 	ticker := time.NewTicker(1 * time.Hour)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		cleanupExpiredTokens(authRepo)
+	// 無効時はnilチャネルとして扱い、selectで選ばれないようにする
+	var reaperC <-chan time.Time
+	if roomReaper != nil && reaperInterval > 0 {
+		reaperTicker := time.NewTicker(reaperInterval)
+		defer reaperTicker.Stop()
+		reaperC = reaperTicker.C
+	}
+
+	for {
+		select {
+		case <-ticker.C:
+			cleanupExpiredTokens(authRepo)
+		case <-reaperC:
+			reapStaleRooms(roomReaper)
+		}
 	}
 }
 
@@ -29,3 +44,18 @@ func cleanupExpiredTokens(authRepo port.AuthRepository) {
 		slog.Info("Cleaned up expired refresh tokens")
 	}
 }
+
+// reapStaleRooms 放置されたルームの終了（文字起こしを含むため長めのタイムアウト）
+func reapStaleRooms(roomReaper usecase.RoomReaperUsecase) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	ended, err := roomReaper.ReapStaleRooms(ctx, time.Now())
+	if err != nil {
+		slog.Error("Failed to reap stale rooms", slog.String("error", err.Error()))
+		return
+	}
+	if ended > 0 {
+		slog.Info("Reaped stale rooms", slog.Int("ended", ended))
+	}
+}
@@ -7,16 +7,25 @@ import (
 	"Go-Next-WebRTC/internal/adapter/http/handler"
 	"Go-Next-WebRTC/internal/adapter/http/middleware"
 	"Go-Next-WebRTC/internal/adapter/http/types"
+	"Go-Next-WebRTC/internal/adapter/mixer"
+	"Go-Next-WebRTC/internal/adapter/objectstorage"
+	"Go-Next-WebRTC/internal/adapter/push"
 	"Go-Next-WebRTC/internal/adapter/repository"
+	"Go-Next-WebRTC/internal/adapter/transcriber"
 	"Go-Next-WebRTC/internal/adapter/websocket"
 	"Go-Next-WebRTC/internal/application/usecase"
 	"Go-Next-WebRTC/internal/config"
 	"Go-Next-WebRTC/internal/domain/port"
 	"Go-Next-WebRTC/pkg/database"
 	"Go-Next-WebRTC/pkg/email"
+	"Go-Next-WebRTC/pkg/ffmpeg"
+	"Go-Next-WebRTC/pkg/invite"
 	jwtpkg "Go-Next-WebRTC/pkg/jwt"
-	"Go-Next-WebRTC/pkg/storage"
+	"Go-Next-WebRTC/pkg/s3"
 	"Go-Next-WebRTC/pkg/transcription"
+	"Go-Next-WebRTC/pkg/webhook"
+	"Go-Next-WebRTC/pkg/webpush"
+	"Go-Next-WebRTC/pkg/whisper"
 )
 
 // initializeDependencies 依存関係の初期化
@@ -24,40 +33,62 @@ func initializeDependencies(cfg *config.Config) (*Dependencies, error) {
 	ctx := context.Background()
 
 	// インフラストラクチャ層の初期化
-	db, gcsClient, speechClient, err := initializeInfrastructure(ctx, cfg)
+	db, objectStorage, speechClient, err := initializeInfrastructure(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	// サービス層の初期化
 	jwtService := jwtpkg.NewService([]byte(cfg.JWTSecret))
-	authMiddleware := middleware.NewAuth(jwtService)
 	emailClient := initializeEmailClient(cfg)
+	speechTranscriber := initializeTranscriber(cfg, speechClient)
+	pushService := initializePushService(cfg)
+	audioMixer := initializeMixer(cfg)
 
 	// リポジトリ層の初期化
 	repos := initializeRepositories(db)
 
 	// ユースケース層の初期化
-	usecases := initializeUsecases(cfg, repos, gcsClient, speechClient, emailClient)
+	usecases := initializeUsecases(cfg, repos, objectStorage, speechTranscriber, audioMixer, emailClient, pushService)
+
+	// 認証ミドルウェア（ゲストトークンの検証は招待ユースケースに委譲）
+	authMiddleware := middleware.NewAuth(jwtService, usecases.Invite)
 
 	// WebSocketシグナリングサーバー
-	signalingServer := websocket.NewSignalingServer()
+	signalingServer := websocket.NewSignalingServer(usecases.Notes)
 	go signalingServer.Run()
 
+	// ルーム終了ユースケースはシグナリングサーバーの接続状態を参照する
+	usecases.Reaper = initializeReaper(cfg, repos, usecases, signalingServer)
+
 	// ハンドラー層の初期化
 	handlers := initializeHandlers(usecases, signalingServer, authMiddleware, jwtService)
 
+	// ローカルストレージの署名付きURLはこのサーバーで受け付ける
+	if local, ok := objectStorage.(*objectstorage.LocalStorage); ok {
+		handlers.StorageHandler = local
+	}
+
 	return &Dependencies{
-		DB:           db,
-		GCSClient:    gcsClient,
-		SpeechClient: speechClient,
-		Handlers:     handlers,
-		AuthRepo:     repos.Auth,
+		DB:                db,
+		Storage:           objectStorage,
+		SpeechClient:      speechClient,
+		Handlers:          handlers,
+		AuthRepo:          repos.Auth,
+		RoomReaper:        usecases.Reaper,
+		Webhooks:          usecases.Webhook,
+		Lobby:             usecases.Lobby,
+		DirectCalls:       usecases.DirectCall,
+		Notes:             usecases.Notes,
+		TranscriptionJobs: usecases.TranscriptionJob,
+		RecordingUploads:  usecases.RecordingUpload,
+		DirectUploads:     usecases.DirectUpload,
+		RecordingMixes:    usecases.RecordingMix,
 	}, nil
 }
 
 // initializeInfrastructure インフラストラクチャ層の初期化
-func initializeInfrastructure(ctx context.Context, cfg *config.Config) (*database.MySQL, *storage.GCSClient, *transcription.SpeechToTextClient, error) {
+func initializeInfrastructure(ctx context.Context, cfg *config.Config) (*database.MySQL, port.ObjectStorage, *transcription.SpeechToTextClient, error) {
 	// データベース接続
 	db, err := database.NewMySQL(cfg.DBDSN)
 	if err != nil {
@@ -65,23 +96,18 @@ func initializeInfrastructure(ctx context.Context, cfg *config.Config) (*databas
 		return nil, nil, nil, err
 	}
 
-	// GCS Client (オプショナル - 開発環境では不要)
-	var gcsClient *storage.GCSClient
-	if cfg.GCSBucketName != "" && cfg.GoogleApplicationCredentials != "" {
-		gcsClient, err = storage.NewGCSClient(ctx, cfg.GCSBucketName, cfg.GoogleApplicationCredentials)
-		if err != nil {
-			slog.Warn("Failed to create GCS client (optional)", slog.String("error", err.Error()))
-			gcsClient = nil
-		} else {
-			slog.Info("GCS client initialized successfully")
-		}
-	} else {
-		slog.Info("GCS client not configured (skipping)")
+	// オブジェクトストレージ
+	objectStorage, err := initializeObjectStorage(ctx, cfg)
+	if err != nil {
+		slog.Error("Failed to initialize object storage", slog.String("backend", cfg.StorageBackend), slog.String("error", err.Error()))
+		db.Close()
+		return nil, nil, nil, err
 	}
+	slog.Info("Object storage initialized", slog.String("backend", cfg.StorageBackend))
 
-	// Speech-to-Text Client (オプショナル - 開発環境では不要)
+	// Speech-to-Text Client (TRANSCRIPTION_PROVIDER=google の場合のみ)
 	var speechClient *transcription.SpeechToTextClient
-	if cfg.GoogleApplicationCredentials != "" {
+	if cfg.TranscriptionProvider == "google" {
 		speechClient, err = transcription.NewSpeechToTextClient(ctx, cfg.GoogleApplicationCredentials)
 		if err != nil {
 			slog.Warn("Failed to create speech client (optional)", slog.String("error", err.Error()))
@@ -93,7 +119,76 @@ func initializeInfrastructure(ctx context.Context, cfg *config.Config) (*databas
 		slog.Info("Speech-to-Text client not configured (skipping)")
 	}
 
-	return db, gcsClient, speechClient, nil
+	return db, objectStorage, speechClient, nil
+}
+
+// initializeObjectStorage 設定されたバックエンドのオブジェクトストレージを作成
+func initializeObjectStorage(ctx context.Context, cfg *config.Config) (port.ObjectStorage, error) {
+	switch cfg.StorageBackend {
+	case "s3":
+		client, err := s3.NewClient(s3.Config{
+			Endpoint:        cfg.S3Endpoint,
+			Region:          cfg.S3Region,
+			Bucket:          cfg.S3Bucket,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+			PathStyle:       cfg.S3UsePathStyle,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return objectstorage.NewS3Storage(client), nil
+	case "gcs":
+		return objectstorage.NewGCSStorage(ctx, cfg.GCSBucketName, cfg.GoogleApplicationCredentials)
+	default:
+		return objectstorage.NewLocalStorage(cfg.StorageLocalDir, cfg.StoragePublicURL, []byte(cfg.StorageSigningSecret))
+	}
+}
+
+// initializeTranscriber 設定された文字起こしエンジンの初期化（未設定の場合はnil）
+func initializeTranscriber(cfg *config.Config, speechClient *transcription.SpeechToTextClient) port.Transcriber {
+	switch cfg.TranscriptionProvider {
+	case "google":
+		if speechClient == nil {
+			return nil
+		}
+		return transcriber.NewGoogleTranscriber(speechClient)
+	case "whisper":
+		client, err := whisper.NewClient(whisper.Config{
+			Binary:      cfg.WhisperBinary,
+			Model:       cfg.WhisperModel,
+			FFmpeg:      cfg.FFmpegBinary,
+			Threads:     cfg.WhisperThreads,
+			TinyDiarize: cfg.WhisperTinyDiarize,
+		})
+		if err != nil {
+			slog.Warn("Failed to initialize whisper (optional)", slog.String("error", err.Error()))
+			return nil
+		}
+		slog.Info("Whisper transcriber initialized", slog.String("model", cfg.WhisperModel))
+		return transcriber.NewWhisperTranscriber(client)
+	default:
+		slog.Info("Transcriber not configured (skipping)")
+		return nil
+	}
+}
+
+// initializeMixer 録音をミックスするffmpegの初期化（無効・ffmpegが見つからない場合はnil）
+func initializeMixer(cfg *config.Config) port.AudioMixer {
+	if !cfg.RecordingMixEnabled {
+		slog.Info("Recording mix disabled (skipping)")
+		return nil
+	}
+	client, err := ffmpeg.NewClient(ffmpeg.Config{
+		Binary:   cfg.FFmpegBinary,
+		Bitrate:  cfg.RecordingMixBitrate,
+		Loudness: float64(cfg.RecordingMixLoudness),
+	})
+	if err != nil {
+		slog.Warn("Failed to initialize ffmpeg for recording mix (optional)", slog.String("error", err.Error()))
+		return nil
+	}
+	return mixer.NewFFmpegMixer(client)
 }
 
 // initializeEmailClient メールクライアントの初期化
@@ -116,6 +211,23 @@ func initializeEmailClient(cfg *config.Config) *email.SMTPClient {
 	return client
 }
 
+// initializePushService Web Pushの送信サービスの初期化
+func initializePushService(cfg *config.Config) port.PushService {
+	// VAPIDの鍵がない場合はnilを返す（購読・送信を行わない）
+	if cfg.VAPIDPrivateKey == "" {
+		slog.Info("Web Push not configured (skipping)")
+		return nil
+	}
+
+	keys, err := webpush.ParseVAPIDKeys(cfg.VAPIDPrivateKey)
+	if err != nil {
+		slog.Warn("Invalid VAPID_PRIVATE_KEY, Web Push disabled", slog.String("error", err.Error()))
+		return nil
+	}
+	slog.Info("Web Push initialized successfully")
+	return push.NewWebPushService(webpush.NewClient(keys, cfg.VAPIDSubject, cfg.PushTimeout, false))
+}
+
 // repositories リポジトリの集約（内部実装）
 type repositories struct {
 	Todo              port.TodoRepository
@@ -126,6 +238,23 @@ type repositories struct {
 	CallRecording     port.CallRecordingRepository
 	CallTranscription port.CallTranscriptionRepository
 	CallMinutes       port.CallMinutesRepository
+	CallSeries        port.CallSeriesRepository
+	CallRoomAccess    port.CallRoomAccessRepository
+	Webhook           port.WebhookRepository
+	PersonalRoom      port.PersonalRoomRepository
+	DirectCall        port.DirectCallRepository
+	Presence          port.PresenceRepository
+	PushSubscription  port.PushSubscriptionRepository
+	Poll              port.PollRepository
+	CallNotes         port.CallNotesRepository
+	Notification      port.NotificationRepository
+	UserDevice        port.UserDeviceRepository
+	TranscriptionJob  port.TranscriptionJobRepository
+	TranscriptionRun  port.TranscriptionRunRepository
+	RecordingUpload   port.RecordingUploadRepository
+	RecordingSlot     port.RecordingUploadSlotRepository
+	RecordingMix      port.RecordingMixRepository
+	Lock              port.DistributedLock
 }
 
 // initializeRepositories リポジトリ層の初期化
@@ -139,46 +268,215 @@ func initializeRepositories(db *database.MySQL) *repositories {
 		CallRecording:     repository.NewMySQLCallRecordingRepository(db),
 		CallTranscription: repository.NewMySQLCallTranscriptionRepository(db),
 		CallMinutes:       repository.NewMySQLCallMinutesRepository(db),
+		CallSeries:        repository.NewMySQLCallSeriesRepository(db),
+		CallRoomAccess:    repository.NewMySQLCallRoomAccessRepository(db),
+		Webhook:           repository.NewMySQLWebhookRepository(db),
+		PersonalRoom:      repository.NewMySQLPersonalRoomRepository(db),
+		DirectCall:        repository.NewMySQLDirectCallRepository(db),
+		Presence:          repository.NewMySQLPresenceRepository(db),
+		PushSubscription:  repository.NewMySQLPushSubscriptionRepository(db),
+		Poll:              repository.NewMySQLPollRepository(db),
+		CallNotes:         repository.NewMySQLCallNotesRepository(db),
+		Notification:      repository.NewMySQLNotificationRepository(db),
+		UserDevice:        repository.NewMySQLUserDeviceRepository(db),
+		TranscriptionJob:  repository.NewMySQLTranscriptionJobRepository(db),
+		TranscriptionRun:  repository.NewMySQLTranscriptionRunRepository(db),
+		RecordingUpload:   repository.NewMySQLRecordingUploadRepository(db),
+		RecordingSlot:     repository.NewMySQLRecordingUploadSlotRepository(db),
+		RecordingMix:      repository.NewMySQLRecordingMixRepository(db),
+		Lock:              repository.NewMySQLLock(db),
 	}
 }
 
 // usecases ユースケースの集約（内部実装）
 type usecases struct {
-	Todo      usecase.TodoUsecase
-	Auth      usecase.AuthUseCase
-	Call      usecase.CallUsecase
-	Recording usecase.RecordingUsecase
+	Todo             usecase.TodoUsecase
+	Auth             usecase.AuthUseCase
+	Call             usecase.CallUsecase
+	Recording        usecase.RecordingUsecase
+	Series           usecase.SeriesUsecase
+	Invite           usecase.InviteUsecase
+	History          usecase.CallHistoryUsecase
+	Reaper           usecase.RoomReaperUsecase
+	Events           usecase.EventPublisher
+	Webhook          usecase.WebhookUsecase
+	Lobby            usecase.LobbyUsecase
+	Personal         usecase.PersonalRoomUsecase
+	Presence         usecase.PresenceUsecase
+	DirectCall       usecase.DirectCallUsecase
+	Notification     usecase.NotificationUsecase
+	Push             usecase.PushUsecase
+	Poll             usecase.PollUsecase
+	Notes            usecase.NotesUsecase
+	TranscriptionJob usecase.TranscriptionJobUsecase
+	RecordingUpload  usecase.RecordingUploadUsecase
+	DirectUpload     usecase.DirectUploadUsecase
+	RecordingMix     usecase.RecordingMixUsecase
 }
 
 // initializeUsecases ユースケース層の初期化
 func initializeUsecases(
 	cfg *config.Config,
 	repos *repositories,
-	gcsClient *storage.GCSClient,
-	speechClient *transcription.SpeechToTextClient,
+	objectStorage port.ObjectStorage,
+	speechTranscriber port.Transcriber,
+	audioMixer port.AudioMixer,
 	emailClient *email.SMTPClient,
+	pushService port.PushService,
 ) *usecases {
 	authConfig := usecase.NewAuthConfig(cfg.JWTSecret)
 
+	// 通話・議事録のイベントはWebhook・ロビー・在席状態・通知・投票・共有ノートへ配信する
+	webhooks := usecase.NewWebhookUsecase(
+		repos.Webhook,
+		webhook.NewClient(cfg.WebhookTimeout, cfg.WebhookAllowPrivateNetworks),
+		repos.Lock,
+		usecase.WebhookConfig{
+			MaxAttempts:          cfg.WebhookMaxAttempts,
+			RetryBase:            cfg.WebhookRetryBase,
+			RetryMax:             cfg.WebhookRetryMax,
+			BatchSize:            50,
+			AllowPrivateNetworks: cfg.WebhookAllowPrivateNetworks,
+		},
+	)
+	lobby := usecase.NewLobbyUsecase(repos.CallRoom, repos.CallRoomAccess, repos.User)
+	userEvents := usecase.NewUserEventHub()
+	presence := usecase.NewPresenceUsecase(repos.Presence, repos.CallParticipant, userEvents)
+	pushes := usecase.NewPushUsecase(repos.PushSubscription, pushService, userEvents)
+	notifications := usecase.NewNotificationUsecase(repos.Notification, repos.CallRoom, repos.CallParticipant, userEvents, pushes)
+	polls := usecase.NewPollUsecase(repos.Poll, repos.CallParticipant, repos.CallRoom, repos.User, repos.CallMinutes)
+	notes := usecase.NewNotesUsecase(repos.CallNotes, repos.CallRoom, repos.CallMinutes)
+	events := usecase.NewEventPublishers(webhooks, lobby, presence, notifications, polls, notes)
+
+	recording := usecase.NewRecordingUsecase(
+		repos.CallRecording,
+		repos.CallTranscription,
+		repos.TranscriptionRun,
+		repos.CallMinutes,
+		repos.CallNotes,
+		repos.CallParticipant,
+		repos.CallRoom,
+		repos.User,
+		objectStorage,
+		speechTranscriber,
+		emailClient,
+		events,
+		cfg.FrontendURL,
+	)
+
+	call := usecase.NewCallUsecase(repos.CallRoom, repos.CallParticipant, repos.CallRoomAccess, repos.User, speechTranscriber, events)
+
 	return &usecases{
-		Todo: usecase.NewTodoUsecase(repos.Todo),
-		Auth: usecase.NewAuthUseCase(repos.User, repos.Auth, authConfig),
-		Call: usecase.NewCallUsecase(repos.CallRoom, repos.CallParticipant),
-		Recording: usecase.NewRecordingUsecase(
+		Todo:      usecase.NewTodoUsecase(repos.Todo, repos.User, notifications),
+		Auth:      usecase.NewAuthUseCase(repos.User, repos.Auth, repos.UserDevice, notifications, authConfig),
+		Call:      call,
+		Recording: recording,
+		Series:    usecase.NewSeriesUsecase(repos.CallSeries, repos.CallRoom, repos.CallParticipant),
+		Invite: usecase.NewInviteUsecase(
+			repos.CallRoom,
+			repos.CallParticipant,
+			repos.CallRoomAccess,
+			repos.User,
+			presence,
+			notifications,
+			invite.NewSigner([]byte(cfg.InviteSecret)),
+			cfg.FrontendURL,
+		),
+		History: usecase.NewCallHistoryUsecase(
+			repos.CallRoom,
+			repos.CallParticipant,
 			repos.CallRecording,
-			repos.CallTranscription,
 			repos.CallMinutes,
-			repos.CallParticipant,
+			repos.User,
+		),
+		Events:   events,
+		Webhook:  webhooks,
+		Lobby:    lobby,
+		Personal: usecase.NewPersonalRoomUsecase(repos.PersonalRoom, repos.CallRoom, repos.User, call),
+		Presence: presence,
+		DirectCall: usecase.NewDirectCallUsecase(
+			repos.DirectCall,
 			repos.CallRoom,
 			repos.User,
-			gcsClient,
-			speechClient,
-			emailClient,
-			cfg.FrontendURL,
+			call,
+			userEvents,
+			presence,
+			notifications,
+			pushes,
+			repos.Lock,
+			usecase.DirectCallConfig{RingTimeout: cfg.DirectCallRingTimeout},
+		),
+		Notification: notifications,
+		Push:         pushes,
+		Poll:         polls,
+		Notes:        notes,
+		TranscriptionJob: usecase.NewTranscriptionJobUsecase(
+			repos.TranscriptionJob,
+			repos.CallRoom,
+			repos.CallRecording,
+			recording,
+			userEvents,
+			usecase.TranscriptionJobConfig{
+				MaxAttempts: cfg.TranscriptionMaxAttempts,
+				RetryBase:   cfg.TranscriptionRetryBase,
+				RetryMax:    cfg.TranscriptionRetryMax,
+				Lease:       cfg.TranscriptionLease,
+			},
+		),
+		RecordingUpload: usecase.NewRecordingUploadUsecase(
+			repos.RecordingUpload,
+			objectStorage,
+			recording,
+			usecase.RecordingUploadConfig{
+				MaxSize: cfg.RecordingUploadMaxSize,
+				Expiry:  cfg.RecordingUploadExpiry,
+			},
+		),
+		DirectUpload: usecase.NewDirectUploadUsecase(
+			repos.RecordingSlot,
+			repos.CallRecording,
+			objectStorage,
+			recording,
+			usecase.DirectUploadConfig{
+				MaxSize:   cfg.RecordingUploadMaxSize,
+				URLExpiry: cfg.RecordingUploadURLExpiry,
+			},
+		),
+		RecordingMix: usecase.NewRecordingMixUsecase(
+			repos.RecordingMix,
+			repos.CallRoom,
+			repos.CallRecording,
+			objectStorage,
+			audioMixer,
+			userEvents,
+			usecase.RecordingMixConfig{
+				Timeout:   cfg.RecordingMixTimeout,
+				URLExpiry: cfg.RecordingUploadURLExpiry,
+			},
 		),
 	}
 }
 
+// initializeReaper ルーム終了ユースケースの初期化（シグナリングサーバーの作成後に行う）
+func initializeReaper(cfg *config.Config, repos *repositories, usecases *usecases, connections port.RoomConnections) usecase.RoomReaperUsecase {
+	return usecase.NewRoomReaperUsecase(
+		repos.CallRoom,
+		repos.CallParticipant,
+		repos.CallRecording,
+		repos.CallMinutes,
+		usecases.TranscriptionJob,
+		connections,
+		repos.Lock,
+		usecases.Events,
+		usecase.RoomReaperConfig{
+			WaitingTTL:      cfg.RoomWaitingTTL,
+			IdleTTL:         cfg.RoomIdleTTL,
+			StaleTTL:        cfg.RoomStaleTTL,
+			TranscribeOnEnd: cfg.RoomReaperTranscribe,
+		},
+	)
+}
+
 // initializeHandlers ハンドラー層の初期化
 func initializeHandlers(
 	usecases *usecases,
@@ -187,9 +485,26 @@ func initializeHandlers(
 	jwtService *jwtpkg.Service,
 ) *types.Handlers {
 	return &types.Handlers{
-		TodoHandler:    handler.NewTodoHandler(usecases.Todo),
-		AuthHandler:    handler.NewAuthHandler(usecases.Auth),
-		CallHandler:    handler.NewCallHandler(usecases.Call, usecases.Recording, signalingServer, jwtService),
-		AuthMiddleware: authMiddleware,
+		TodoHandler:            handler.NewTodoHandler(usecases.Todo),
+		AuthHandler:            handler.NewAuthHandler(usecases.Auth),
+		CallHandler:            handler.NewCallHandler(usecases.Call, usecases.Recording, usecases.Invite, signalingServer, jwtService),
+		SeriesHandler:          handler.NewSeriesHandler(usecases.Series),
+		InviteHandler:          handler.NewInviteHandler(usecases.Invite),
+		HistoryHandler:         handler.NewCallHistoryHandler(usecases.History),
+		WebhookHandler:         handler.NewWebhookHandler(usecases.Webhook),
+		LobbyHandler:           handler.NewLobbyHandler(usecases.Lobby, usecases.Invite, jwtService),
+		PersonalRoomHandler:    handler.NewPersonalRoomHandler(usecases.Personal),
+		DirectCallHandler:      handler.NewDirectCallHandler(usecases.DirectCall),
+		UserEventHandler:       handler.NewUserEventHandler(usecases.Presence, jwtService),
+		PresenceHandler:        handler.NewPresenceHandler(usecases.Presence),
+		NotificationHandler:    handler.NewNotificationHandler(usecases.Notification),
+		PushHandler:            handler.NewPushHandler(usecases.Push),
+		PollHandler:            handler.NewPollHandler(usecases.Call, usecases.Poll, signalingServer),
+		NotesHandler:           handler.NewNotesHandler(usecases.Call, usecases.Notes),
+		TranscriptionHandler:   handler.NewTranscriptionHandler(usecases.Call, usecases.TranscriptionJob, usecases.Recording),
+		RecordingUploadHandler: handler.NewRecordingUploadHandler(usecases.Call, usecases.RecordingUpload),
+		DirectUploadHandler:    handler.NewDirectUploadHandler(usecases.Call, usecases.DirectUpload),
+		RecordingMixHandler:    handler.NewRecordingMixHandler(usecases.Call, usecases.RecordingMix),
+		AuthMiddleware:         authMiddleware,
 	}
 }
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase"
+)
+
+// StartDirectCallExpiry 応答期限を過ぎた着信を定期的に不在着信にする
+// 着信の鳴動時間に合わせて短い間隔で実行するため、クリーンアップとは別に実行する
+func StartDirectCallExpiry(directCalls usecase.DirectCallUsecase, interval time.Duration) {
+	if directCalls == nil || interval <= 0 {
+		slog.Info("Direct call expiry disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		expireDirectCalls(directCalls)
+	}
+}
+
+// expireDirectCalls 応答期限を過ぎた着信を不在着信にする
+func expireDirectCalls(directCalls usecase.DirectCallUsecase) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	expired, err := directCalls.ExpireUnanswered(ctx, time.Now())
+	if err != nil {
+		slog.Error("Failed to expire direct calls", slog.String("error", err.Error()))
+		return
+	}
+	if expired > 0 {
+		slog.Info("Direct calls marked as missed", slog.Int("count", expired))
+	}
+}
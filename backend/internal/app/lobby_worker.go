@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase"
+)
+
+// StartLobbyResync ロビーの状態を定期的にDBと照合
+// 他のレプリカで起きた変更やイベントの取りこぼしによる参加人数のずれを補正する
+func StartLobbyResync(lobby usecase.LobbyUsecase, interval time.Duration) {
+	if lobby == nil || interval <= 0 {
+		slog.Info("Lobby resync disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		resyncLobby(lobby)
+	}
+}
+
+// resyncLobby ロビーの状態をDBから読み直す
+func resyncLobby(lobby usecase.LobbyUsecase) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if err := lobby.Resync(ctx); err != nil {
+		slog.Error("Failed to resync lobby", slog.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase"
+)
+
+// StartRecordingMixWorkers 録音のミックスを実行するワーカーを起動
+// ミックスはDBで確保するため、複数のレプリカで起動しても同じミックスを重複して実行しない。
+// 1回の実行の上限はユースケースの設定に従う。ctxをキャンセルすると実行中のミックスは実行待ちに戻る
+func StartRecordingMixWorkers(ctx context.Context, mixes usecase.RecordingMixUsecase, workers int, interval time.Duration) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	if mixes == nil || workers <= 0 || interval <= 0 {
+		slog.Info("Recording mix workers disabled")
+		return &wg
+	}
+
+	hostname, _ := os.Hostname()
+	for i := 0; i < workers; i++ {
+		workerID := fmt.Sprintf("%s-%d-mix-%d", hostname, os.Getpid(), i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runRecordingMixWorker(ctx, mixes, workerID, interval)
+		}()
+	}
+	slog.Info("Recording mix workers started", slog.Int("workers", workers))
+	return &wg
+}
+
+// runRecordingMixWorker ミックスがなくなるまで実行し、次の確認時刻か新しいミックスの依頼まで待つ
+func runRecordingMixWorker(ctx context.Context, mixes usecase.RecordingMixUsecase, workerID string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil && runRecordingMix(ctx, mixes, workerID) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-mixes.Wake():
+		}
+	}
+}
+
+// runRecordingMix ミックスを1件実行（ミックスがなかった場合・エラーの場合はfalse）
+func runRecordingMix(ctx context.Context, mixes usecase.RecordingMixUsecase, workerID string) bool {
+	ran, err := mixes.RunNext(ctx, workerID)
+	if err != nil {
+		slog.Error("Failed to run recording mix",
+			slog.String("worker", workerID),
+			slog.String("error", err.Error()))
+		return false
+	}
+	return ran
+}
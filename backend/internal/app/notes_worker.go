@@ -0,0 +1,39 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase"
+)
+
+// StartNotesSnapshots 編集中の共有ノートのスナップショットを定期的に保存
+// サーバーが異常終了しても、最後に保存した時点から編集を再開できる
+func StartNotesSnapshots(notes usecase.NotesUsecase, interval time.Duration) {
+	if notes == nil || interval <= 0 {
+		slog.Info("Notes snapshots disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		saveNotesSnapshots(notes)
+	}
+}
+
+// saveNotesSnapshots 変更のあった共有ノートを保存
+func saveNotesSnapshots(notes usecase.NotesUsecase) {
+	if notes == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	if saved := notes.SaveSnapshots(ctx); saved > 0 {
+		slog.Debug("Notes snapshots saved", slog.Int("count", saved))
+	}
+}
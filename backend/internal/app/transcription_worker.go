@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase"
+)
+
+// StartTranscriptionWorkers 文字起こしジョブを実行するワーカーを起動
+// ジョブはDBで確保するため、複数のレプリカで起動しても同じジョブを重複して実行しない。
+// ctxをキャンセルすると実行中のジョブは中断して実行待ちに戻る（戻り値で終了を待てる）
+func StartTranscriptionWorkers(ctx context.Context, jobs usecase.TranscriptionJobUsecase, workers int, interval time.Duration, timeout time.Duration) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	if jobs == nil || workers <= 0 || interval <= 0 {
+		slog.Info("Transcription workers disabled")
+		return &wg
+	}
+
+	hostname, _ := os.Hostname()
+	for i := 0; i < workers; i++ {
+		workerID := fmt.Sprintf("%s-%d-%d", hostname, os.Getpid(), i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			runTranscriptionWorker(ctx, jobs, workerID, interval, timeout)
+		}()
+	}
+	slog.Info("Transcription workers started", slog.Int("workers", workers))
+	return &wg
+}
+
+// runTranscriptionWorker ジョブがなくなるまで実行し、次の確認時刻か新しいジョブの追加まで待つ
+func runTranscriptionWorker(ctx context.Context, jobs usecase.TranscriptionJobUsecase, workerID string, interval time.Duration, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for ctx.Err() == nil && runTranscriptionJob(ctx, jobs, workerID, timeout) {
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-jobs.Wake():
+		}
+	}
+}
+
+// runTranscriptionJob ジョブを1件実行（ジョブがなかった場合・エラーの場合はfalse）
+func runTranscriptionJob(ctx context.Context, jobs usecase.TranscriptionJobUsecase, workerID string, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ran, err := jobs.RunNext(ctx, workerID)
+	if err != nil {
+		slog.Error("Failed to run transcription job",
+			slog.String("worker", workerID),
+			slog.String("error", err.Error()))
+		return false
+	}
+	return ran
+}
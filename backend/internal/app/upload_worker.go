@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase"
+)
+
+// StartRecordingUploadCleanup 期限を過ぎた録音アップロード（チャンク）と直接アップロードの枠を定期的に削除
+func StartRecordingUploadCleanup(uploads usecase.RecordingUploadUsecase, direct usecase.DirectUploadUsecase, interval time.Duration) {
+	if uploads == nil || direct == nil || interval <= 0 {
+		slog.Info("Recording upload cleanup disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		cleanupExpiredUploads(uploads)
+		cleanupExpiredSlots(direct)
+	}
+}
+
+// cleanupExpiredUploads 期限切れのアップロードを削除
+func cleanupExpiredUploads(uploads usecase.RecordingUploadUsecase) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	deleted, err := uploads.CleanupExpired(ctx, time.Now())
+	if err != nil {
+		slog.Error("Failed to clean up expired uploads", slog.String("error", err.Error()))
+		return
+	}
+	if deleted > 0 {
+		slog.Info("Cleaned up expired uploads", slog.Int("deleted", deleted))
+	}
+}
+
+// cleanupExpiredSlots 期限切れの直接アップロードの枠と、完了していないオブジェクトを削除
+func cleanupExpiredSlots(direct usecase.DirectUploadUsecase) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	deleted, err := direct.CleanupExpired(ctx, time.Now())
+	if err != nil {
+		slog.Error("Failed to clean up expired upload slots", slog.String("error", err.Error()))
+		return
+	}
+	if deleted > 0 {
+		slog.Info("Cleaned up expired upload slots", slog.Int("deleted", deleted))
+	}
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase"
+)
+
+// StartWebhookDelivery 配信待ちのWebhookを定期的に送信
+// 文字起こし等の長い処理に配信が遅れないよう、クリーンアップとは別に実行する
+func StartWebhookDelivery(webhooks usecase.WebhookUsecase, interval time.Duration) {
+	if webhooks == nil || interval <= 0 {
+		slog.Info("Webhook delivery disabled")
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deliverWebhooks(webhooks)
+	}
+}
+
+// deliverWebhooks 配信予定時刻を過ぎたWebhookを送信
+func deliverWebhooks(webhooks usecase.WebhookUsecase) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	sent, err := webhooks.DeliverPending(ctx, time.Now())
+	if err != nil {
+		slog.Error("Failed to deliver webhooks", slog.String("error", err.Error()))
+		return
+	}
+	if sent > 0 {
+		slog.Debug("Delivered webhooks", slog.Int("sent", sent))
+	}
+}
@@ -18,7 +18,7 @@ import (
 type AuthUseCase interface {
 	// 認証関連
 	Register(ctx context.Context, email, password, name string) (*entity.AuthTokens, error)
-	Login(ctx context.Context, email, password string) (*entity.AuthTokens, error)
+	Login(ctx context.Context, email, password string, device *entity.LoginDevice) (*entity.AuthTokens, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*entity.AuthTokens, error)
 	Logout(ctx context.Context, userID int64) error
 	
@@ -55,16 +55,26 @@ func NewAuthConfig(jwtSecret string) *AuthConfig {
 }
 
 type authUseCase struct {
-	userRepo port.UserRepository
-	authRepo port.AuthRepository
-	config   *AuthConfig
+	userRepo      port.UserRepository
+	authRepo      port.AuthRepository
+	deviceRepo    port.UserDeviceRepository
+	notifications NotificationUsecase
+	config        *AuthConfig
 }
 
-func NewAuthUseCase(userRepo port.UserRepository, authRepo port.AuthRepository, config *AuthConfig) AuthUseCase {
+func NewAuthUseCase(
+	userRepo port.UserRepository,
+	authRepo port.AuthRepository,
+	deviceRepo port.UserDeviceRepository,
+	notifications NotificationUsecase,
+	config *AuthConfig,
+) AuthUseCase {
 	return &authUseCase{
-		userRepo: userRepo,
-		authRepo: authRepo,
-		config:   config,
+		userRepo:      userRepo,
+		authRepo:      authRepo,
+		deviceRepo:    deviceRepo,
+		notifications: notifications,
+		config:        config,
 	}
 }
 
@@ -123,7 +133,8 @@ func (u *authUseCase) Register(ctx context.Context, email, password, name string
 }
 
 // Login ユーザーログイン
-func (u *authUseCase) Login(ctx context.Context, email, password string) (*entity.AuthTokens, error) {
+// deviceにはログイン元の端末情報を渡す（nilの場合は端末の記録・通知を行わない）
+func (u *authUseCase) Login(ctx context.Context, email, password string, device *entity.LoginDevice) (*entity.AuthTokens, error) {
 	// 入力検証
 	email = strings.ToLower(strings.TrimSpace(email))
 	if email == "" || password == "" {
@@ -145,6 +156,9 @@ func (u *authUseCase) Login(ctx context.Context, email, password string) (*entit
 		return nil, entity.ErrInvalidCredentials
 	}
 
+	// 初めての端末からのログインを通知
+	u.checkLoginDevice(ctx, user, device)
+
 	// 古いセッションをクリーンアップ
 	u.authRepo.DeleteExpiredRefreshTokens(ctx)
 
@@ -152,6 +166,64 @@ func (u *authUseCase) Login(ctx context.Context, email, password string) (*entit
 	return u.generateTokens(ctx, user)
 }
 
+// checkLoginDevice ログイン元の端末を記録し、初めての端末なら通知する
+// 最初に記録される端末（登録後の初回ログイン）は通知しない
+func (u *authUseCase) checkLoginDevice(ctx context.Context, user *entity.User, device *entity.LoginDevice) {
+	if device == nil || strings.TrimSpace(device.UserAgent) == "" {
+		return
+	}
+	now := time.Now()
+
+	known, err := u.deviceRepo.FindByHash(ctx, user.ID, device.Hash())
+	if err == nil {
+		known.IPAddress = device.IPAddress
+		known.LastSeenAt = now
+		if err := u.deviceRepo.UpdateLastSeen(ctx, known); err != nil {
+			fmt.Printf("failed to update login device: %v\n", err)
+		}
+		return
+	}
+	if !errors.Is(err, entity.ErrUserDeviceNotFound) {
+		fmt.Printf("failed to find login device: %v\n", err)
+		return
+	}
+
+	count, err := u.deviceRepo.CountByUserID(ctx, user.ID)
+	if err != nil {
+		fmt.Printf("failed to count login devices: %v\n", err)
+		return
+	}
+	err = u.deviceRepo.Create(ctx, &entity.UserDevice{
+		UserID:      user.ID,
+		DeviceHash:  device.Hash(),
+		UserAgent:   device.UserAgent,
+		IPAddress:   device.IPAddress,
+		FirstSeenAt: now,
+		LastSeenAt:  now,
+	})
+	if err != nil {
+		fmt.Printf("failed to save login device: %v\n", err)
+		return
+	}
+	if count == 0 {
+		return
+	}
+
+	err = u.notifications.Notify(ctx, &entity.Notification{
+		UserID: user.ID,
+		Type:   entity.NotificationNewDeviceLogin,
+		Title:  "New sign-in to your account",
+		Body:   device.UserAgent,
+		Data: map[string]interface{}{
+			"user_agent": device.UserAgent,
+			"ip_address": device.IPAddress,
+		},
+	})
+	if err != nil {
+		fmt.Printf("failed to notify new device login: %v\n", err)
+	}
+}
+
 // RefreshToken アクセストークンの更新
 func (u *authUseCase) RefreshToken(ctx context.Context, refreshToken string) (*entity.AuthTokens, error) {
 	// リフレッシュトークンをデータベースから取得
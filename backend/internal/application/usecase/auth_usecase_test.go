@@ -9,6 +9,16 @@ import (
 	"Go-Next-WebRTC/internal/domain/entity"
 )
 
+// newAuthTestUseCase 端末の記録・通知にモックを使う認証ユースケースを作成
+func newAuthTestUseCase(userRepo *testutil.MockUserRepository, authRepo *testutil.MockAuthRepository, config *AuthConfig) AuthUseCase {
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	hub := NewUserEventHub()
+	push, _, _ := newPushTestUsecase(hub)
+	notifications := NewNotificationUsecase(testutil.NewMockNotificationRepository(), roomRepo, participantRepo, hub, push)
+	return NewAuthUseCase(userRepo, authRepo, testutil.NewMockUserDeviceRepository(), notifications, config)
+}
+
 func TestAuthUseCase_Register(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -92,7 +102,7 @@ func TestAuthUseCase_Register(t *testing.T) {
 			userRepo := testutil.NewMockUserRepository()
 			authRepo := testutil.NewMockAuthRepository()
 			config := NewAuthConfig("test-secret-key-must-be-32-chars-long")
-			usecase := NewAuthUseCase(userRepo, authRepo, config)
+			usecase := newAuthTestUseCase(userRepo, authRepo, config)
 			ctx := context.Background()
 
 			// Act
@@ -134,7 +144,7 @@ func TestAuthUseCase_Register_DuplicateEmail(t *testing.T) {
 	userRepo := testutil.NewMockUserRepository()
 	authRepo := testutil.NewMockAuthRepository()
 	config := NewAuthConfig("test-secret-key-must-be-32-chars-long")
-	usecase := NewAuthUseCase(userRepo, authRepo, config)
+	usecase := newAuthTestUseCase(userRepo, authRepo, config)
 	ctx := context.Background()
 
 	email := "duplicate@example.com"
@@ -164,7 +174,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 	userRepo := testutil.NewMockUserRepository()
 	authRepo := testutil.NewMockAuthRepository()
 	config := NewAuthConfig("test-secret-key-must-be-32-chars-long")
-	usecase := NewAuthUseCase(userRepo, authRepo, config)
+	usecase := newAuthTestUseCase(userRepo, authRepo, config)
 	ctx := context.Background()
 
 	email := "test@example.com"
@@ -218,7 +228,7 @@ func TestAuthUseCase_Login(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Act
-			tokens, err := usecase.Login(ctx, tt.email, tt.password)
+			tokens, err := usecase.Login(ctx, tt.email, tt.password, nil)
 
 			// Assert
 			if tt.wantErr {
@@ -245,12 +255,69 @@ func TestAuthUseCase_Login(t *testing.T) {
 	}
 }
 
+func TestAuthUseCase_LoginFromNewDevice(t *testing.T) {
+	userRepo := testutil.NewMockUserRepository()
+	deviceRepo := testutil.NewMockUserDeviceRepository()
+	notificationRepo := testutil.NewMockNotificationRepository()
+	hub := NewUserEventHub()
+	push, _, _ := newPushTestUsecase(hub)
+	notifications := NewNotificationUsecase(notificationRepo, testutil.NewMockCallRoomRepository(), testutil.NewMockCallParticipantRepository(), hub, push)
+	usecase := NewAuthUseCase(userRepo, testutil.NewMockAuthRepository(), deviceRepo, notifications, NewAuthConfig("test-secret-key-must-be-32-chars-long"))
+	ctx := context.Background()
+
+	email, password := "test@example.com", "ValidPass123!"
+	registered, err := usecase.Register(ctx, email, password, "Test User")
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	userID := registered.User.ID
+	laptop := &entity.LoginDevice{UserAgent: "Mozilla/5.0 (Macintosh)", IPAddress: "192.0.2.1"}
+	phone := &entity.LoginDevice{UserAgent: "Mozilla/5.0 (iPhone)", IPAddress: "198.51.100.7"}
+
+	// 最初の端末は記録のみ、同じ端末からの再ログインも通知しない
+	for _, device := range []*entity.LoginDevice{laptop, laptop, nil} {
+		if _, err := usecase.Login(ctx, email, password, device); err != nil {
+			t.Fatalf("Login() unexpected error = %v", err)
+		}
+	}
+	if count, _ := deviceRepo.CountByUserID(ctx, userID); count != 1 {
+		t.Errorf("devices = %d, want 1", count)
+	}
+	if saved := notificationRepo.ByType(userID, entity.NotificationNewDeviceLogin); len(saved) != 0 {
+		t.Fatalf("notifications = %d, want none for known device", len(saved))
+	}
+
+	// 失敗したログインでは端末を記録しない
+	if _, err := usecase.Login(ctx, email, "WrongPass123!", phone); err == nil {
+		t.Fatal("Login() with wrong password error = nil")
+	}
+
+	sub := hub.Subscribe(userID)
+	if _, err := usecase.Login(ctx, email, password, phone); err != nil {
+		t.Fatalf("Login() unexpected error = %v", err)
+	}
+	saved := notificationRepo.ByType(userID, entity.NotificationNewDeviceLogin)
+	if len(saved) != 1 || saved[0].Data["ip_address"] != "198.51.100.7" {
+		t.Fatalf("notifications = %+v, want one new-device-login from 198.51.100.7", saved)
+	}
+	if event := receiveUserEvent(t, sub); event.Type != entity.UserEventNotification {
+		t.Errorf("event = %s, want notification", event.Type)
+	}
+
+	if _, err := usecase.Login(ctx, email, password, phone); err != nil {
+		t.Fatalf("Login() unexpected error = %v", err)
+	}
+	if saved := notificationRepo.ByType(userID, entity.NotificationNewDeviceLogin); len(saved) != 1 {
+		t.Errorf("notifications = %d after second login from phone, want 1", len(saved))
+	}
+}
+
 func TestAuthUseCase_RefreshToken(t *testing.T) {
 	// Arrange
 	userRepo := testutil.NewMockUserRepository()
 	authRepo := testutil.NewMockAuthRepository()
 	config := NewAuthConfig("test-secret-key-must-be-32-chars-long")
-	usecase := NewAuthUseCase(userRepo, authRepo, config)
+	usecase := newAuthTestUseCase(userRepo, authRepo, config)
 	ctx := context.Background()
 
 	// ユーザー登録とログイン
@@ -321,7 +388,7 @@ func TestAuthUseCase_Logout(t *testing.T) {
 	userRepo := testutil.NewMockUserRepository()
 	authRepo := testutil.NewMockAuthRepository()
 	config := NewAuthConfig("test-secret-key-must-be-32-chars-long")
-	usecase := NewAuthUseCase(userRepo, authRepo, config)
+	usecase := newAuthTestUseCase(userRepo, authRepo, config)
 	ctx := context.Background()
 
 	// ユーザー登録
@@ -349,7 +416,7 @@ func TestAuthUseCase_GetUserByID(t *testing.T) {
 	userRepo := testutil.NewMockUserRepository()
 	authRepo := testutil.NewMockAuthRepository()
 	config := NewAuthConfig("test-secret-key-must-be-32-chars-long")
-	usecase := NewAuthUseCase(userRepo, authRepo, config)
+	usecase := newAuthTestUseCase(userRepo, authRepo, config)
 	ctx := context.Background()
 
 	// ユーザー登録
@@ -390,7 +457,7 @@ func TestAuthUseCase_ChangePassword(t *testing.T) {
 	userRepo := testutil.NewMockUserRepository()
 	authRepo := testutil.NewMockAuthRepository()
 	config := NewAuthConfig("test-secret-key-must-be-32-chars-long")
-	usecase := NewAuthUseCase(userRepo, authRepo, config)
+	usecase := newAuthTestUseCase(userRepo, authRepo, config)
 	ctx := context.Background()
 
 	oldPassword := "OldPass123!"
@@ -443,7 +510,7 @@ func TestAuthUseCase_ChangePassword(t *testing.T) {
 				}
 
 				// 新しいパスワードでログインできることを確認
-				_, err = usecase.Login(ctx, "test@example.com", tt.newPassword)
+				_, err = usecase.Login(ctx, "test@example.com", tt.newPassword, nil)
 				if err != nil {
 					t.Errorf("Login with new password failed: %v", err)
 				}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// 通話履歴の取得件数
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+// CallHistoryUsecase 通話履歴ユースケースのインターフェース
+type CallHistoryUsecase interface {
+	// 通話履歴を取得
+	GetHistory(ctx context.Context, filter *entity.CallHistoryFilter) (*entity.CallHistoryPage, error)
+}
+
+type callHistoryUsecase struct {
+	roomRepo        port.CallRoomRepository
+	participantRepo port.CallParticipantRepository
+	recordingRepo   port.CallRecordingRepository
+	minutesRepo     port.CallMinutesRepository
+	userRepo        port.UserRepository
+}
+
+// NewCallHistoryUsecase 新しい通話履歴ユースケースを作成
+func NewCallHistoryUsecase(
+	roomRepo port.CallRoomRepository,
+	participantRepo port.CallParticipantRepository,
+	recordingRepo port.CallRecordingRepository,
+	minutesRepo port.CallMinutesRepository,
+	userRepo port.UserRepository,
+) CallHistoryUsecase {
+	return &callHistoryUsecase{
+		roomRepo:        roomRepo,
+		participantRepo: participantRepo,
+		recordingRepo:   recordingRepo,
+		minutesRepo:     minutesRepo,
+		userRepo:        userRepo,
+	}
+}
+
+// GetHistory 通話履歴を取得
+// 参加者・録音・議事録はページ内のルームについてまとめて取得する
+func (u *callHistoryUsecase) GetHistory(ctx context.Context, filter *entity.CallHistoryFilter) (*entity.CallHistoryPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultHistoryLimit
+	}
+	if filter.Limit > maxHistoryLimit {
+		filter.Limit = maxHistoryLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	rooms, total, err := u.roomRepo.FindHistory(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &entity.CallHistoryPage{
+		Entries: make([]*entity.CallHistoryEntry, len(rooms)),
+		Total:   total,
+	}
+	if len(rooms) == 0 {
+		return page, nil
+	}
+
+	roomIDs := make([]int64, len(rooms))
+	for i, room := range rooms {
+		roomIDs[i] = room.ID
+	}
+
+	participants, err := u.participantRepo.FindByRoomIDs(ctx, roomIDs)
+	if err != nil {
+		return nil, err
+	}
+	withRecordings, err := u.recordingRepo.FindRoomIDsWithRecordings(ctx, roomIDs)
+	if err != nil {
+		return nil, err
+	}
+	withMinutes, err := u.minutesRepo.FindRoomIDsWithMinutes(ctx, roomIDs)
+	if err != nil {
+		return nil, err
+	}
+	names, err := u.userNames(ctx, participants)
+	if err != nil {
+		return nil, err
+	}
+
+	byRoom := make(map[int64][]*entity.CallParticipant)
+	for _, p := range participants {
+		byRoom[p.RoomID] = append(byRoom[p.RoomID], p)
+	}
+
+	for i, room := range rooms {
+		page.Entries[i] = &entity.CallHistoryEntry{
+			Room:          room,
+			Participants:  groupParticipations(byRoom[room.ID], names),
+			HasRecordings: withRecordings[room.ID],
+			HasMinutes:    withMinutes[room.ID],
+		}
+	}
+
+	return page, nil
+}
+
+// userNames 参加ユーザーの表示名を一括取得
+func (u *callHistoryUsecase) userNames(ctx context.Context, participants []*entity.CallParticipant) (map[int64]string, error) {
+	seen := make(map[int64]bool)
+	var userIDs []int64
+	for _, p := range participants {
+		if p.IsGuest() || seen[p.UserID] {
+			continue
+		}
+		seen[p.UserID] = true
+		userIDs = append(userIDs, p.UserID)
+	}
+
+	users, err := u.userRepo.FindByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int64]string, len(users))
+	for _, user := range users {
+		names[user.ID] = user.Name
+	}
+	return names, nil
+}
+
+// groupParticipations 参加記録をユーザー・ゲストごとの参加区間にまとめる（初回参加順）
+func groupParticipations(participations []*entity.CallParticipant, names map[int64]string) []*entity.CallHistoryParticipant {
+	result := make([]*entity.CallHistoryParticipant, 0)
+	index := make(map[string]*entity.CallHistoryParticipant)
+
+	for _, p := range participations {
+		key := "user-" + strconv.FormatInt(p.UserID, 10)
+		if p.IsGuest() {
+			key = "guest-" + *p.GuestID
+		}
+
+		hp, ok := index[key]
+		if !ok {
+			hp = &entity.CallHistoryParticipant{UserID: p.UserID, GuestID: p.GuestID}
+			switch {
+			case p.IsGuest() && p.GuestName != nil:
+				hp.Name = *p.GuestName
+			case names[p.UserID] != "":
+				hp.Name = names[p.UserID]
+			default:
+				hp.Name = "User " + strconv.FormatInt(p.UserID, 10)
+			}
+			index[key] = hp
+			result = append(result, hp)
+		}
+
+		hp.Intervals = append(hp.Intervals, entity.ParticipationInterval{
+			JoinedAt: p.JoinedAt,
+			LeftAt:   p.LeftAt,
+		})
+	}
+
+	return result
+}
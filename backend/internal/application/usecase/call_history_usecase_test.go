@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// setupHistoryTest 通話履歴のテストデータを作成
+// room-a: ユーザー1が作成・終了済み（ユーザー2が2回参加、ゲスト1名、録音あり）
+// room-b: ユーザー2が作成・通話中（ユーザー1が参加、議事録あり）
+// room-c: ユーザー3のみ
+func setupHistoryTest(t *testing.T) CallHistoryUsecase {
+	t.Helper()
+	ctx := context.Background()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	recordingRepo := testutil.NewMockCallRecordingRepository()
+	minutesRepo := testutil.NewMockCallMinutesRepository()
+	userRepo := testutil.NewMockUserRepository()
+	roomRepo.Participants = participantRepo
+
+	userRepo.Users["taro@example.com"] = &entity.User{ID: 1, Name: "Taro"}
+	userRepo.Users["hanako@example.com"] = &entity.User{ID: 2, Name: "Hanako"}
+
+	base := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	at := func(min int) *time.Time {
+		t := base.Add(time.Duration(min) * time.Minute)
+		return &t
+	}
+
+	rooms := []*entity.CallRoom{
+		{RoomID: "room-a", Name: "Weekly sync", CreatedBy: 1, Status: entity.CallRoomStatusEnded, StartedAt: at(0), EndedAt: at(30)},
+		{RoomID: "room-b", Name: "Design review", CreatedBy: 2, Status: entity.CallRoomStatusActive, StartedAt: at(60)},
+		{RoomID: "room-c", Name: "Other", CreatedBy: 3, Status: entity.CallRoomStatusEnded, StartedAt: at(120), EndedAt: at(150)},
+	}
+	for _, room := range rooms {
+		roomRepo.Create(ctx, room)
+	}
+
+	guestID := "g-1"
+	guestName := "Guest Jiro"
+	for _, p := range []*entity.CallParticipant{
+		{RoomID: 1, UserID: 1, JoinedAt: *at(0), LeftAt: at(30)},
+		{RoomID: 1, UserID: 2, JoinedAt: *at(5), LeftAt: at(10)},
+		{RoomID: 1, GuestID: &guestID, GuestName: &guestName, JoinedAt: *at(6), LeftAt: at(20)},
+		{RoomID: 1, UserID: 2, JoinedAt: *at(15), LeftAt: at(30)},
+		{RoomID: 2, UserID: 2, JoinedAt: *at(60), IsActive: true},
+		{RoomID: 2, UserID: 1, JoinedAt: *at(61), IsActive: true},
+		{RoomID: 3, UserID: 3, JoinedAt: *at(120), LeftAt: at(150)},
+	} {
+		participantRepo.Create(ctx, p)
+	}
+
+	recordingRepo.Create(ctx, &entity.CallRecording{RoomID: 1, UserID: 1})
+	minutesRepo.Create(ctx, &entity.CallMinutes{RoomID: 2, Title: "Design review"})
+
+	return NewCallHistoryUsecase(roomRepo, participantRepo, recordingRepo, minutesRepo, userRepo)
+}
+
+func TestCallHistoryUsecase_GetHistory(t *testing.T) {
+	uc := setupHistoryTest(t)
+	ctx := context.Background()
+
+	page, err := uc.GetHistory(ctx, &entity.CallHistoryFilter{UserID: 1})
+	if err != nil {
+		t.Fatalf("GetHistory() unexpected error = %v", err)
+	}
+	if page.Total != 2 || len(page.Entries) != 2 {
+		t.Fatalf("GetHistory() total = %d, entries = %d, want 2", page.Total, len(page.Entries))
+	}
+
+	// 開始日時の新しい順
+	b, a := page.Entries[0], page.Entries[1]
+	if b.Room.RoomID != "room-b" || a.Room.RoomID != "room-a" {
+		t.Fatalf("GetHistory() order = %s, %s", b.Room.RoomID, a.Room.RoomID)
+	}
+	if !a.HasRecordings || a.HasMinutes || b.HasRecordings || !b.HasMinutes {
+		t.Errorf("GetHistory() flags a=(%v,%v) b=(%v,%v)", a.HasRecordings, a.HasMinutes, b.HasRecordings, b.HasMinutes)
+	}
+	if d := a.Room.Duration(time.Now()); d != 30*time.Minute {
+		t.Errorf("Duration() = %v, want 30m", d)
+	}
+
+	// 再参加は同じ参加者の区間としてまとめる
+	if len(a.Participants) != 3 {
+		t.Fatalf("room-a participants = %d, want 3", len(a.Participants))
+	}
+	hanako := a.Participants[1]
+	if hanako.Name != "Hanako" || len(hanako.Intervals) != 2 {
+		t.Errorf("room-a participant = %+v", hanako)
+	}
+	if d := hanako.TotalDuration(time.Now()); d != 20*time.Minute {
+		t.Errorf("TotalDuration() = %v, want 20m", d)
+	}
+	guest := a.Participants[2]
+	if guest.Name != "Guest Jiro" || guest.GuestID == nil {
+		t.Errorf("room-a guest = %+v", guest)
+	}
+	if a.Participants[0].Name != "Taro" {
+		t.Errorf("room-a creator name = %q", a.Participants[0].Name)
+	}
+}
+
+func TestCallHistoryUsecase_GetHistory_Filters(t *testing.T) {
+	from := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	to := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		filter        entity.CallHistoryFilter
+		expectedRooms []string
+		expectedTotal int
+		expectedErr   error
+	}{
+		{name: "created only", filter: entity.CallHistoryFilter{UserID: 1, Scope: entity.CallHistoryScopeCreated}, expectedRooms: []string{"room-a"}, expectedTotal: 1},
+		{name: "joined only", filter: entity.CallHistoryFilter{UserID: 2, Scope: entity.CallHistoryScopeJoined}, expectedRooms: []string{"room-b", "room-a"}, expectedTotal: 2},
+		{name: "status", filter: entity.CallHistoryFilter{UserID: 1, Status: entity.CallRoomStatusEnded}, expectedRooms: []string{"room-a"}, expectedTotal: 1},
+		{name: "from", filter: entity.CallHistoryFilter{UserID: 1, From: &from}, expectedRooms: []string{"room-b"}, expectedTotal: 1},
+		{name: "name query", filter: entity.CallHistoryFilter{UserID: 1, Query: "Design"}, expectedRooms: []string{"room-b"}, expectedTotal: 1},
+		{name: "pagination", filter: entity.CallHistoryFilter{UserID: 1, Limit: 1, Offset: 1}, expectedRooms: []string{"room-a"}, expectedTotal: 2},
+		{name: "offset past end", filter: entity.CallHistoryFilter{UserID: 1, Offset: 10}, expectedRooms: []string{}, expectedTotal: 2},
+		{name: "no calls", filter: entity.CallHistoryFilter{UserID: 9}, expectedRooms: []string{}, expectedTotal: 0},
+		{name: "invalid scope", filter: entity.CallHistoryFilter{UserID: 1, Scope: "mine"}, expectedErr: entity.ErrInvalidHistoryScope},
+		{name: "invalid status", filter: entity.CallHistoryFilter{UserID: 1, Status: "done"}, expectedErr: entity.ErrInvalidHistoryStatus},
+		{name: "invalid range", filter: entity.CallHistoryFilter{UserID: 1, From: &from, To: &to}, expectedErr: entity.ErrInvalidHistoryRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := setupHistoryTest(t)
+			filter := tt.filter
+
+			page, err := uc.GetHistory(context.Background(), &filter)
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("GetHistory() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetHistory() unexpected error = %v", err)
+			}
+			if page.Total != tt.expectedTotal {
+				t.Errorf("GetHistory() total = %d, want %d", page.Total, tt.expectedTotal)
+			}
+			got := make([]string, len(page.Entries))
+			for i, e := range page.Entries {
+				got[i] = e.Room.RoomID
+			}
+			if len(got) != len(tt.expectedRooms) {
+				t.Fatalf("GetHistory() rooms = %v, want %v", got, tt.expectedRooms)
+			}
+			for i := range got {
+				if got[i] != tt.expectedRooms[i] {
+					t.Errorf("GetHistory() rooms = %v, want %v", got, tt.expectedRooms)
+					break
+				}
+			}
+		})
+	}
+}
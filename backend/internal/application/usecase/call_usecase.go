@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"Go-Next-WebRTC/internal/domain/entity"
@@ -11,41 +12,98 @@ import (
 
 // CallUsecase 通話ユースケースのインターフェース
 type CallUsecase interface {
-	// 通話ルーム作成
-	CreateRoom(ctx context.Context, room *entity.CallRoom) error
+	// 通話ルーム作成（settingsはnil可）
+	CreateRoom(ctx context.Context, room *entity.CallRoom, settings *entity.RoomAccessSettings) error
 	// 通話ルーム取得
 	GetRoomByRoomID(ctx context.Context, roomID string) (*entity.CallRoom, error)
 	// アクティブなルーム一覧取得
 	GetActiveRooms(ctx context.Context) ([]*entity.CallRoom, error)
+	// 主体に表示可能なアクティブルーム一覧取得
+	GetVisibleRooms(ctx context.Context, principal *entity.RoomPrincipal) ([]*entity.LobbyRoom, error)
+	// ルームへのアクセス可否を判定
+	CheckRoomAccess(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, passcode string) error
+	// ルームのアクセス設定を更新
+	UpdateRoomAccess(ctx context.Context, room *entity.CallRoom, settings *entity.RoomAccessSettings) error
+	// ルームの許可リスト取得
+	GetRoomAccessRules(ctx context.Context, roomID int64) ([]*entity.CallRoomAccessRule, error)
+	// ルームの文字起こしの言語設定を更新
+	UpdateTranscriptionSettings(ctx context.Context, room *entity.CallRoom, settings *entity.TranscriptionLanguageSettings) error
 	// 通話ルームに参加
 	JoinRoom(ctx context.Context, participant *entity.CallParticipant) error
 	// 通話ルームから退出
 	LeaveRoom(ctx context.Context, roomID int64, userID int64) error
+	// ゲストとして通話ルームから退出
+	LeaveRoomAsGuest(ctx context.Context, roomID int64, guestID string) error
 	// アクティブな参加者取得
 	GetActiveParticipants(ctx context.Context, roomID int64) ([]*entity.CallParticipant, error)
 	// ルームステータス更新
 	UpdateRoomStatus(ctx context.Context, room *entity.CallRoom) error
+	// 通話を開始（待機中のルームをアクティブにする）
+	StartRoom(ctx context.Context, room *entity.CallRoom) error
+	// 通話を終了（参加中の参加者も退出済みにする）
+	EndRoom(ctx context.Context, room *entity.CallRoom) error
+	// ルーム内での主体のロール取得
+	GetRole(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal) (entity.ParticipantRole, error)
+	// 主体が権限を持っているか判定
+	Authorize(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, perm entity.Permission) error
+	// 参加者のロールを変更（変更された参加記録を返す）
+	ChangeRole(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, participantID int64, role entity.ParticipantRole) ([]*entity.CallParticipant, error)
+	// 参加者を退出させる
+	KickParticipant(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, participantID int64) (*entity.CallParticipant, error)
 }
 
 type callUsecase struct {
 	roomRepo        port.CallRoomRepository
 	participantRepo port.CallParticipantRepository
+	accessRepo      port.CallRoomAccessRepository
+	userRepo        port.UserRepository
+	transcriber     port.Transcriber
+	events          EventPublisher
 }
 
 // NewCallUsecase 新しい通話ユースケースを作成
 func NewCallUsecase(
 	roomRepo port.CallRoomRepository,
 	participantRepo port.CallParticipantRepository,
+	accessRepo port.CallRoomAccessRepository,
+	userRepo port.UserRepository,
+	transcriber port.Transcriber,
+	events EventPublisher,
 ) CallUsecase {
 	return &callUsecase{
 		roomRepo:        roomRepo,
 		participantRepo: participantRepo,
+		accessRepo:      accessRepo,
+		userRepo:        userRepo,
+		transcriber:     transcriber,
+		events:          events,
 	}
 }
 
 // CreateRoom 通話ルームを作成
-func (u *callUsecase) CreateRoom(ctx context.Context, room *entity.CallRoom) error {
-	return u.roomRepo.Create(ctx, room)
+func (u *callUsecase) CreateRoom(ctx context.Context, room *entity.CallRoom, settings *entity.RoomAccessSettings) error {
+	if settings == nil {
+		settings = &entity.RoomAccessSettings{}
+	}
+
+	// ルーム作成前に設定を検証する
+	rules, err := applyAccessSettings(room, settings)
+	if err != nil {
+		return err
+	}
+
+	if err := u.roomRepo.Create(ctx, room); err != nil {
+		return err
+	}
+
+	if len(rules) > 0 {
+		if err := u.accessRepo.ReplaceRules(ctx, room.ID, rules); err != nil {
+			return err
+		}
+	}
+
+	publishRoomEvent(ctx, u.events, entity.WebhookEventRoomCreated, room)
+	return nil
 }
 
 // GetRoomByRoomID 通話ルームを取得
@@ -58,25 +116,224 @@ func (u *callUsecase) GetActiveRooms(ctx context.Context) ([]*entity.CallRoom, e
 	return u.roomRepo.FindActiveRooms(ctx)
 }
 
+// GetVisibleRooms 主体に表示可能なアクティブルーム一覧を参加人数付きで取得
+func (u *callUsecase) GetVisibleRooms(ctx context.Context, principal *entity.RoomPrincipal) ([]*entity.LobbyRoom, error) {
+	rooms, err := u.roomRepo.FindLobbyRooms(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rulesByRoom, err := findRulesByRoom(ctx, u.accessRepo, rooms)
+	if err != nil {
+		return nil, err
+	}
+
+	principal = withVerifiedEmail(ctx, u.userRepo, principal)
+	visible := make([]*entity.LobbyRoom, 0, len(rooms))
+	for _, lobbyRoom := range rooms {
+		if lobbyRoom.Room.IsVisibleTo(rulesByRoom[lobbyRoom.Room.ID], principal) {
+			visible = append(visible, lobbyRoom)
+		}
+	}
+
+	return visible, nil
+}
+
+// withVerifiedEmail メールアドレスが検証済みかをユーザー情報から設定した主体を返す
+// トークンのメールアドレスは検証済みとは限らないため、ユーザーを取得できない場合は未検証として扱う
+func withVerifiedEmail(ctx context.Context, userRepo port.UserRepository, principal *entity.RoomPrincipal) *entity.RoomPrincipal {
+	verified := *principal
+	verified.EmailVerified = false
+	if principal.Guest != nil || principal.UserID == 0 {
+		return &verified
+	}
+	user, err := userRepo.FindByID(ctx, principal.UserID)
+	if err == nil && user != nil && user.IsEmailVerified() && strings.EqualFold(user.Email, principal.Email) {
+		verified.EmailVerified = true
+	}
+	return &verified
+}
+
+// findRulesByRoom 許可リストをまとめて取得してルームごとに振り分ける
+func findRulesByRoom(ctx context.Context, accessRepo port.CallRoomAccessRepository, rooms []*entity.LobbyRoom) (map[int64][]*entity.CallRoomAccessRule, error) {
+	roomIDs := make([]int64, len(rooms))
+	for i, lobbyRoom := range rooms {
+		roomIDs[i] = lobbyRoom.Room.ID
+	}
+
+	allRules, err := accessRepo.FindByRoomIDs(ctx, roomIDs)
+	if err != nil {
+		return nil, err
+	}
+	rulesByRoom := make(map[int64][]*entity.CallRoomAccessRule)
+	for _, rule := range allRules {
+		rulesByRoom[rule.RoomID] = append(rulesByRoom[rule.RoomID], rule)
+	}
+	return rulesByRoom, nil
+}
+
+// CheckRoomAccess ルームへのアクセス可否を判定
+// 作成者・参加中のユーザー・招待されたゲストはパスコード不要
+func (u *callUsecase) CheckRoomAccess(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, passcode string) error {
+	if principal.Guest != nil {
+		if principal.Guest.RoomID == room.RoomID {
+			return nil
+		}
+		return entity.ErrRoomAccessDenied
+	}
+
+	if room.CreatedBy == principal.UserID {
+		return nil
+	}
+
+	// 既に参加中（参加時に検証済み）
+	if p, err := u.participantRepo.FindByRoomIDAndUserID(ctx, room.ID, principal.UserID); err == nil && p != nil {
+		return nil
+	}
+
+	rules, err := u.accessRepo.FindByRoomID(ctx, room.ID)
+	if err != nil {
+		return err
+	}
+	principal = withVerifiedEmail(ctx, u.userRepo, principal)
+	if !room.Admits(rules, principal.UserID, principal.VerifiedEmail()) {
+		return entity.ErrRoomAccessDenied
+	}
+
+	if room.HasPasscode() {
+		if passcode == "" {
+			return entity.ErrPasscodeRequired
+		}
+		if !room.CheckPasscode(passcode) {
+			return entity.ErrInvalidPasscode
+		}
+	}
+
+	return nil
+}
+
+// UpdateRoomAccess ルームのアクセス設定を更新（許可リストは置き換え）
+func (u *callUsecase) UpdateRoomAccess(ctx context.Context, room *entity.CallRoom, settings *entity.RoomAccessSettings) error {
+	rules, err := applyAccessSettings(room, settings)
+	if err != nil {
+		return err
+	}
+
+	if err := u.roomRepo.Update(ctx, room); err != nil {
+		return err
+	}
+
+	if err := u.accessRepo.ReplaceRules(ctx, room.ID, rules); err != nil {
+		return err
+	}
+
+	publishRoomEvent(ctx, u.events, entity.WebhookEventRoomUpdated, room)
+	return nil
+}
+
+// GetRoomAccessRules ルームの許可リストを取得
+func (u *callUsecase) GetRoomAccessRules(ctx context.Context, roomID int64) ([]*entity.CallRoomAccessRule, error) {
+	return u.accessRepo.FindByRoomID(ctx, roomID)
+}
+
+// UpdateTranscriptionSettings ルームの文字起こしの言語設定を更新（以降の文字起こしに使う）
+// 設定された文字起こしエンジンが扱えない言語の条件は受け付けない
+func (u *callUsecase) UpdateTranscriptionSettings(ctx context.Context, room *entity.CallRoom, settings *entity.TranscriptionLanguageSettings) error {
+	updated := *room
+	if err := settings.Apply(&updated); err != nil {
+		return err
+	}
+	if u.transcriber != nil {
+		if err := u.transcriber.SupportsLanguages(transcribeOptions(&updated)); err != nil {
+			return err
+		}
+	}
+	*room = updated
+
+	if err := u.roomRepo.Update(ctx, room); err != nil {
+		return err
+	}
+
+	publishRoomEvent(ctx, u.events, entity.WebhookEventRoomUpdated, room)
+	return nil
+}
+
+// applyAccessSettings 公開範囲とパスコードをルームに反映し、許可リストを返す
+func applyAccessSettings(room *entity.CallRoom, settings *entity.RoomAccessSettings) ([]*entity.CallRoomAccessRule, error) {
+	if settings.Visibility != "" {
+		if !settings.Visibility.IsValid() {
+			return nil, entity.ErrInvalidVisibility
+		}
+		room.Visibility = settings.Visibility
+	}
+	if room.Visibility == "" {
+		room.Visibility = entity.RoomVisibilityPublic
+	}
+
+	rules, err := settings.Rules(room.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if settings.Passcode != nil {
+		if err := room.SetPasscode(*settings.Passcode); err != nil {
+			return nil, err
+		}
+	}
+
+	return rules, nil
+}
+
 // JoinRoom 通話ルームに参加
+// ロールは前回の参加時のものを引き継ぎ、初参加の作成者はホストになる
 func (u *callUsecase) JoinRoom(ctx context.Context, participant *entity.CallParticipant) error {
 	// 既に参加しているか確認
-	existing, err := u.participantRepo.FindByRoomIDAndUserID(ctx, participant.RoomID, participant.UserID)
+	existing, err := u.findParticipant(ctx, participant)
 	if err == nil && existing != nil {
+		participant.ID = existing.ID
+		participant.Role = existing.Role
+
 		// 既に参加している場合は再参加として処理
 		if !existing.IsActive {
 			now := time.Now()
 			existing.IsActive = true
 			existing.JoinedAt = now
 			existing.LeftAt = nil
-			return u.participantRepo.Update(ctx, existing)
+			if err := u.participantRepo.Update(ctx, existing); err != nil {
+				return err
+			}
+			u.publishParticipantEvent(ctx, entity.WebhookEventParticipantJoined, existing)
 		}
 		// 既にアクティブな場合は成功を返す（冪等性）
 		return nil
 	}
 
 	// 新規参加
-	return u.participantRepo.Create(ctx, participant)
+	if participant.Role == "" {
+		participant.Role = u.initialRole(ctx, participant)
+	}
+	if participant.JoinedAt.IsZero() {
+		participant.JoinedAt = time.Now()
+	}
+	if err := u.participantRepo.Create(ctx, participant); err != nil {
+		return err
+	}
+	u.publishParticipantEvent(ctx, entity.WebhookEventParticipantJoined, participant)
+	return nil
+}
+
+// initialRole 新規参加時のロールを決定
+func (u *callUsecase) initialRole(ctx context.Context, participant *entity.CallParticipant) entity.ParticipantRole {
+	if participant.IsGuest() {
+		return entity.RoleParticipant
+	}
+	if last, err := u.participantRepo.FindLatestByRoomIDAndUserID(ctx, participant.RoomID, participant.UserID); err == nil && last != nil {
+		return last.Role
+	}
+	if room, err := u.roomRepo.FindByID(ctx, participant.RoomID); err == nil && room.CreatedBy == participant.UserID {
+		return entity.RoleHost
+	}
+	return entity.RoleParticipant
 }
 
 // LeaveRoom 通話ルームから退出
@@ -86,6 +343,21 @@ func (u *callUsecase) LeaveRoom(ctx context.Context, roomID int64, userID int64)
 		return err
 	}
 
+	return u.leave(ctx, participant)
+}
+
+// LeaveRoomAsGuest ゲストとして通話ルームから退出
+func (u *callUsecase) LeaveRoomAsGuest(ctx context.Context, roomID int64, guestID string) error {
+	participant, err := u.participantRepo.FindByRoomIDAndGuestID(ctx, roomID, guestID)
+	if err != nil {
+		return err
+	}
+
+	return u.leave(ctx, participant)
+}
+
+// leave 参加記録を退出済みにする
+func (u *callUsecase) leave(ctx context.Context, participant *entity.CallParticipant) error {
 	if participant == nil {
 		return errors.New("participant not found")
 	}
@@ -94,7 +366,28 @@ func (u *callUsecase) LeaveRoom(ctx context.Context, roomID int64, userID int64)
 	participant.IsActive = false
 	participant.LeftAt = &now
 
-	return u.participantRepo.Update(ctx, participant)
+	if err := u.participantRepo.Update(ctx, participant); err != nil {
+		return err
+	}
+	u.publishParticipantEvent(ctx, entity.WebhookEventParticipantLeft, participant)
+	return nil
+}
+
+// publishParticipantEvent 参加者のイベントを通知（ルームが見つからない場合は通知しない）
+func (u *callUsecase) publishParticipantEvent(ctx context.Context, event entity.WebhookEvent, participant *entity.CallParticipant) {
+	room, err := u.roomRepo.FindByID(ctx, participant.RoomID)
+	if err != nil {
+		return
+	}
+	publishParticipantEvent(ctx, u.events, event, room, participant)
+}
+
+// findParticipant ユーザーまたはゲストの参加記録を取得
+func (u *callUsecase) findParticipant(ctx context.Context, participant *entity.CallParticipant) (*entity.CallParticipant, error) {
+	if participant.IsGuest() {
+		return u.participantRepo.FindByRoomIDAndGuestID(ctx, participant.RoomID, *participant.GuestID)
+	}
+	return u.participantRepo.FindByRoomIDAndUserID(ctx, participant.RoomID, participant.UserID)
 }
 
 // GetActiveParticipants アクティブな参加者を取得
@@ -106,3 +399,167 @@ func (u *callUsecase) GetActiveParticipants(ctx context.Context, roomID int64) (
 func (u *callUsecase) UpdateRoomStatus(ctx context.Context, room *entity.CallRoom) error {
 	return u.roomRepo.Update(ctx, room)
 }
+
+// StartRoom 通話を開始
+func (u *callUsecase) StartRoom(ctx context.Context, room *entity.CallRoom) error {
+	now := time.Now()
+	room.Status = entity.CallRoomStatusActive
+	room.StartedAt = &now
+	if err := u.roomRepo.Update(ctx, room); err != nil {
+		return err
+	}
+
+	publishRoomEvent(ctx, u.events, entity.WebhookEventRoomStarted, room)
+	return nil
+}
+
+// EndRoom 通話を終了
+func (u *callUsecase) EndRoom(ctx context.Context, room *entity.CallRoom) error {
+	participants, err := u.participantRepo.FindActiveByRoomID(ctx, room.ID)
+	if err != nil {
+		return err
+	}
+	return endRoom(ctx, u.roomRepo, u.participantRepo, u.events, room, participants, time.Now())
+}
+
+// endRoom ルームを終了状態にし、参加中の参加区間をendedAtで閉じる
+func endRoom(ctx context.Context, roomRepo port.CallRoomRepository, participantRepo port.CallParticipantRepository, events EventPublisher, room *entity.CallRoom, participants []*entity.CallParticipant, endedAt time.Time) error {
+	var left []*entity.CallParticipant
+	for _, p := range participants {
+		if !p.IsActive {
+			continue
+		}
+		leftAt := endedAt
+		if leftAt.Before(p.JoinedAt) {
+			leftAt = p.JoinedAt
+		}
+		p.IsActive = false
+		p.LeftAt = &leftAt
+		if err := participantRepo.Update(ctx, p); err != nil {
+			return err
+		}
+		left = append(left, p)
+	}
+
+	room.Status = entity.CallRoomStatusEnded
+	room.EndedAt = &endedAt
+	if err := roomRepo.Update(ctx, room); err != nil {
+		return err
+	}
+
+	for _, p := range left {
+		publishParticipantEvent(ctx, events, entity.WebhookEventParticipantLeft, room, p)
+	}
+	publishRoomEvent(ctx, events, entity.WebhookEventRoomEnded, room)
+	return nil
+}
+
+// GetRole ルーム内での主体のロールを取得
+// 退出済みのユーザーは最後の参加時のロール、未参加の作成者はホストとして扱う
+func (u *callUsecase) GetRole(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal) (entity.ParticipantRole, error) {
+	if principal.Guest != nil {
+		if principal.Guest.RoomID != room.RoomID {
+			return "", entity.ErrNotRoomMember
+		}
+		p, err := u.participantRepo.FindByRoomIDAndGuestID(ctx, room.ID, principal.Guest.GuestID)
+		if err != nil {
+			return "", entity.ErrNotRoomMember
+		}
+		return p.Role, nil
+	}
+
+	if p, err := u.participantRepo.FindLatestByRoomIDAndUserID(ctx, room.ID, principal.UserID); err == nil && p != nil {
+		return p.Role, nil
+	}
+	if room.CreatedBy == principal.UserID {
+		return entity.RoleHost, nil
+	}
+	return "", entity.ErrNotRoomMember
+}
+
+// Authorize 主体が権限を持っているか判定
+func (u *callUsecase) Authorize(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, perm entity.Permission) error {
+	role, err := u.GetRole(ctx, room, principal)
+	if err != nil {
+		return err
+	}
+	if !role.Can(perm) {
+		return entity.ErrPermissionDenied
+	}
+	return nil
+}
+
+// ChangeRole 参加者のロールを変更
+// ホストを移譲した場合、元のホストは共同ホストになる
+func (u *callUsecase) ChangeRole(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, participantID int64, role entity.ParticipantRole) ([]*entity.CallParticipant, error) {
+	if !role.IsValid() {
+		return nil, entity.ErrInvalidRole
+	}
+
+	actor, target, err := u.findActorAndTarget(ctx, room, principal, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Role.CanAssign(target.Role, role) {
+		return nil, entity.ErrPermissionDenied
+	}
+	if target.Role == role {
+		return []*entity.CallParticipant{target}, nil
+	}
+
+	target.Role = role
+	if err := u.participantRepo.Update(ctx, target); err != nil {
+		return nil, err
+	}
+	changed := []*entity.CallParticipant{target}
+
+	if role == entity.RoleHost {
+		actor.Role = entity.RoleCoHost
+		if err := u.participantRepo.Update(ctx, actor); err != nil {
+			return nil, err
+		}
+		changed = append(changed, actor)
+	}
+
+	return changed, nil
+}
+
+// KickParticipant 参加者を退出させる（自分より上位のロールは不可）
+func (u *callUsecase) KickParticipant(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, participantID int64) (*entity.CallParticipant, error) {
+	actor, target, err := u.findActorAndTarget(ctx, room, principal, participantID)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.Role.Can(entity.PermissionKick) || target.Role.Outranks(actor.Role) {
+		return nil, entity.ErrPermissionDenied
+	}
+
+	if err := u.leave(ctx, target); err != nil {
+		return nil, err
+	}
+	return target, nil
+}
+
+// findActorAndTarget 操作する参加者と対象の参加者を取得（どちらも参加中である必要がある）
+func (u *callUsecase) findActorAndTarget(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, participantID int64) (*entity.CallParticipant, *entity.CallParticipant, error) {
+	var actor *entity.CallParticipant
+	var err error
+	if principal.Guest != nil {
+		actor, err = u.participantRepo.FindByRoomIDAndGuestID(ctx, room.ID, principal.Guest.GuestID)
+	} else {
+		actor, err = u.participantRepo.FindByRoomIDAndUserID(ctx, room.ID, principal.UserID)
+	}
+	if err != nil || actor == nil {
+		return nil, nil, entity.ErrNotRoomMember
+	}
+
+	target, err := u.participantRepo.FindByID(ctx, participantID)
+	if err != nil || target == nil || target.RoomID != room.ID || !target.IsActive {
+		return nil, nil, entity.ErrParticipantNotFound
+	}
+	if target.ID == actor.ID {
+		return nil, nil, entity.ErrCannotTargetSelf
+	}
+
+	return actor, target, nil
+}
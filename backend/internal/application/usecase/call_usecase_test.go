@@ -0,0 +1,631 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// newCallTestUsecase テスト用の通話ユースケースを作成
+func newCallTestUsecase(users ...*entity.User) (CallUsecase, *testutil.MockCallRoomRepository, *testutil.MockCallParticipantRepository) {
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	userRepo := testutil.NewMockUserRepository()
+	for _, user := range users {
+		userRepo.Users[user.Email] = user
+	}
+	uc := NewCallUsecase(roomRepo, participantRepo, testutil.NewMockCallRoomAccessRepository(), userRepo, nil, testutil.NewMockEventPublisher())
+	return uc, roomRepo, participantRepo
+}
+
+// testUser テスト用のユーザーを作成（verifiedの場合はメールアドレスを検証済みにする）
+func testUser(id int64, email string, verified bool) *entity.User {
+	user := &entity.User{ID: id, Email: email}
+	if verified {
+		user.VerifyEmail()
+	}
+	return user
+}
+
+func strPtr(s string) *string {
+	return &s
+}
+
+func TestCallUsecase_CreateRoom_AccessSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		settings    *entity.RoomAccessSettings
+		expectedErr error
+	}{
+		{name: "default settings", settings: nil},
+		{name: "private with passcode", settings: &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityPrivate, Passcode: strPtr("1234")}},
+		{name: "invalid visibility", settings: &entity.RoomAccessSettings{Visibility: "secret"}, expectedErr: entity.ErrInvalidVisibility},
+		{name: "short passcode", settings: &entity.RoomAccessSettings{Passcode: strPtr("12")}, expectedErr: entity.ErrPasscodeLength},
+		{name: "invalid domain", settings: &entity.RoomAccessSettings{AllowedEmailDomains: []string{"localhost"}}, expectedErr: entity.ErrInvalidDomain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, roomRepo, _ := newCallTestUsecase()
+			room := &entity.CallRoom{RoomID: "room-1", Name: "Room", CreatedBy: 1, Status: entity.CallRoomStatusWaiting}
+
+			err := uc.CreateRoom(context.Background(), room, tt.settings)
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("CreateRoom() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				if len(roomRepo.Rooms) != 0 {
+					t.Error("CreateRoom() should not create a room with invalid settings")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateRoom() unexpected error = %v", err)
+			}
+			if room.Visibility == "" {
+				t.Error("CreateRoom() visibility should default to public")
+			}
+			if room.PasscodeHash != nil && *room.PasscodeHash == "1234" {
+				t.Error("CreateRoom() passcode must be stored hashed")
+			}
+		})
+	}
+}
+
+func TestCallUsecase_CheckRoomAccess(t *testing.T) {
+	const creator, member, outsider int64 = 1, 2, 3
+
+	tests := []struct {
+		name        string
+		settings    *entity.RoomAccessSettings
+		principal   *entity.RoomPrincipal
+		passcode    string
+		joined      bool
+		verified    bool
+		expectedErr error
+	}{
+		{name: "public room", settings: &entity.RoomAccessSettings{}, principal: &entity.RoomPrincipal{UserID: outsider}},
+		{name: "private room via link", settings: &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityPrivate}, principal: &entity.RoomPrincipal{UserID: outsider}},
+		{
+			name:        "invite only outsider",
+			settings:    &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityInviteOnly, AllowedUserIDs: []int64{member}},
+			principal:   &entity.RoomPrincipal{UserID: outsider},
+			expectedErr: entity.ErrRoomAccessDenied,
+		},
+		{
+			name:      "invite only allowed user",
+			settings:  &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityInviteOnly, AllowedUserIDs: []int64{member}},
+			principal: &entity.RoomPrincipal{UserID: member},
+		},
+		{
+			name:      "invite only allowed domain",
+			settings:  &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityInviteOnly, AllowedEmailDomains: []string{"@Example.com"}},
+			principal: &entity.RoomPrincipal{UserID: outsider, Email: "taro@example.COM"},
+			verified:  true,
+		},
+		{
+			name:        "invite only allowed domain unverified",
+			settings:    &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityInviteOnly, AllowedEmailDomains: []string{"example.com"}},
+			principal:   &entity.RoomPrincipal{UserID: outsider, Email: "taro@example.com", EmailVerified: true},
+			expectedErr: entity.ErrRoomAccessDenied,
+		},
+		{
+			name:        "allow list restricts public room",
+			settings:    &entity.RoomAccessSettings{AllowedEmailDomains: []string{"example.com"}},
+			principal:   &entity.RoomPrincipal{UserID: outsider, Email: "taro@example.org"},
+			verified:    true,
+			expectedErr: entity.ErrRoomAccessDenied,
+		},
+		{
+			name:      "invite only creator",
+			settings:  &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityInviteOnly, Passcode: strPtr("secret")},
+			principal: &entity.RoomPrincipal{UserID: creator},
+		},
+		{
+			name:      "invited guest",
+			settings:  &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityInviteOnly, Passcode: strPtr("secret")},
+			principal: &entity.RoomPrincipal{Guest: &entity.GuestPrincipal{GuestID: "g1", RoomID: "room-1"}},
+		},
+		{
+			name:        "guest for another room",
+			settings:    &entity.RoomAccessSettings{},
+			principal:   &entity.RoomPrincipal{Guest: &entity.GuestPrincipal{GuestID: "g1", RoomID: "room-2"}},
+			expectedErr: entity.ErrRoomAccessDenied,
+		},
+		{
+			name:        "passcode required",
+			settings:    &entity.RoomAccessSettings{Passcode: strPtr("secret")},
+			principal:   &entity.RoomPrincipal{UserID: outsider},
+			expectedErr: entity.ErrPasscodeRequired,
+		},
+		{
+			name:        "wrong passcode",
+			settings:    &entity.RoomAccessSettings{Passcode: strPtr("secret")},
+			principal:   &entity.RoomPrincipal{UserID: outsider},
+			passcode:    "guess",
+			expectedErr: entity.ErrInvalidPasscode,
+		},
+		{
+			name:      "correct passcode",
+			settings:  &entity.RoomAccessSettings{Passcode: strPtr("secret")},
+			principal: &entity.RoomPrincipal{UserID: outsider},
+			passcode:  "secret",
+		},
+		{
+			name:      "already joined skips passcode",
+			settings:  &entity.RoomAccessSettings{Passcode: strPtr("secret")},
+			principal: &entity.RoomPrincipal{UserID: outsider},
+			joined:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var users []*entity.User
+			if tt.principal.Guest == nil {
+				users = append(users, testUser(tt.principal.UserID, tt.principal.Email, tt.verified))
+			}
+			uc, _, participantRepo := newCallTestUsecase(users...)
+			ctx := context.Background()
+			room := &entity.CallRoom{RoomID: "room-1", Name: "Room", CreatedBy: creator, Status: entity.CallRoomStatusActive}
+			if err := uc.CreateRoom(ctx, room, tt.settings); err != nil {
+				t.Fatalf("CreateRoom() unexpected error = %v", err)
+			}
+			if tt.joined {
+				participantRepo.Create(ctx, &entity.CallParticipant{RoomID: room.ID, UserID: tt.principal.UserID, IsActive: true})
+			}
+
+			err := uc.CheckRoomAccess(ctx, room, tt.principal, tt.passcode)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("CheckRoomAccess() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestCallUsecase_GetVisibleRooms(t *testing.T) {
+	uc, _, _ := newCallTestUsecase(
+		testUser(2, "member@example.org", true),
+		testUser(3, "user@example.com", true),
+		testUser(4, "unverified@example.com", false),
+	)
+	ctx := context.Background()
+
+	rooms := []struct {
+		roomID   string
+		settings *entity.RoomAccessSettings
+	}{
+		{"public", &entity.RoomAccessSettings{}},
+		{"public-restricted", &entity.RoomAccessSettings{AllowedEmailDomains: []string{"example.com"}}},
+		{"private", &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityPrivate}},
+		{"private-member", &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityPrivate, AllowedUserIDs: []int64{2}}},
+		{"invite-only", &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityInviteOnly}},
+	}
+	for _, r := range rooms {
+		room := &entity.CallRoom{RoomID: r.roomID, Name: r.roomID, CreatedBy: 1, Status: entity.CallRoomStatusActive}
+		if err := uc.CreateRoom(ctx, room, r.settings); err != nil {
+			t.Fatalf("CreateRoom() unexpected error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		principal *entity.RoomPrincipal
+		expected  []string
+	}{
+		{name: "creator sees all", principal: &entity.RoomPrincipal{UserID: 1}, expected: []string{"public", "public-restricted", "private", "private-member", "invite-only"}},
+		{name: "member", principal: &entity.RoomPrincipal{UserID: 2, Email: "member@example.org"}, expected: []string{"public", "private-member"}},
+		{name: "domain user", principal: &entity.RoomPrincipal{UserID: 3, Email: "user@example.com"}, expected: []string{"public", "public-restricted"}},
+		{name: "unverified domain user", principal: &entity.RoomPrincipal{UserID: 4, Email: "unverified@example.com"}, expected: []string{"public"}},
+		{name: "guest", principal: &entity.RoomPrincipal{Guest: &entity.GuestPrincipal{RoomID: "invite-only"}}, expected: []string{"invite-only"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			visible, err := uc.GetVisibleRooms(ctx, tt.principal)
+			if err != nil {
+				t.Fatalf("GetVisibleRooms() unexpected error = %v", err)
+			}
+			got := make([]string, len(visible))
+			for i, room := range visible {
+				got[i] = room.Room.RoomID
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("GetVisibleRooms() = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("GetVisibleRooms() = %v, want %v", got, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestCallUsecase_GuestJoinAndLeave(t *testing.T) {
+	uc, _, participantRepo := newCallTestUsecase()
+	ctx := context.Background()
+
+	guestID := "guest-1"
+	name := "Guest Taro"
+	guest := &entity.CallParticipant{RoomID: 1, GuestID: &guestID, GuestName: &name, IsActive: true}
+	if err := uc.JoinRoom(ctx, guest); err != nil {
+		t.Fatalf("JoinRoom() unexpected error = %v", err)
+	}
+	// 同じゲストの再参加は冪等
+	again := &entity.CallParticipant{RoomID: 1, GuestID: &guestID, GuestName: &name, IsActive: true}
+	if err := uc.JoinRoom(ctx, again); err != nil {
+		t.Fatalf("JoinRoom() unexpected error = %v", err)
+	}
+	// ユーザーID 0 のユーザーとゲストは区別される
+	if _, err := participantRepo.FindByRoomIDAndUserID(ctx, 1, 0); err == nil {
+		t.Error("guest should not be found by user ID")
+	}
+
+	active, _ := uc.GetActiveParticipants(ctx, 1)
+	if len(active) != 1 || !active[0].IsGuest() {
+		t.Fatalf("GetActiveParticipants() = %d participants, want 1 guest", len(active))
+	}
+
+	if err := uc.LeaveRoomAsGuest(ctx, 1, guestID); err != nil {
+		t.Fatalf("LeaveRoomAsGuest() unexpected error = %v", err)
+	}
+	active, _ = uc.GetActiveParticipants(ctx, 1)
+	if len(active) != 0 {
+		t.Errorf("GetActiveParticipants() after leave = %d, want 0", len(active))
+	}
+}
+
+// setupRoleTest ホスト(1)・共同ホスト(2)・参加者(3)が参加中のルームを作成
+func setupRoleTest(t *testing.T) (CallUsecase, *entity.CallRoom, map[int64]*entity.CallParticipant) {
+	t.Helper()
+	uc, roomRepo, participantRepo := newCallTestUsecase()
+	ctx := context.Background()
+
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Room", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+	if err := roomRepo.Create(ctx, room); err != nil {
+		t.Fatalf("failed to create room: %v", err)
+	}
+
+	byUser := make(map[int64]*entity.CallParticipant)
+	for _, userID := range []int64{1, 2, 3} {
+		p := &entity.CallParticipant{RoomID: room.ID, UserID: userID, IsActive: true}
+		if err := uc.JoinRoom(ctx, p); err != nil {
+			t.Fatalf("JoinRoom() unexpected error = %v", err)
+		}
+		byUser[userID] = p
+	}
+	byUser[2].Role = entity.RoleCoHost
+	participantRepo.Update(ctx, byUser[2])
+
+	return uc, room, byUser
+}
+
+func TestCallUsecase_JoinRoom_Role(t *testing.T) {
+	uc, room, byUser := setupRoleTest(t)
+	ctx := context.Background()
+
+	if byUser[1].Role != entity.RoleHost {
+		t.Errorf("creator role = %q, want host", byUser[1].Role)
+	}
+	if byUser[3].Role != entity.RoleParticipant {
+		t.Errorf("participant role = %q, want participant", byUser[3].Role)
+	}
+
+	// 再参加時は前回のロールを引き継ぐ
+	if err := uc.LeaveRoom(ctx, room.ID, 2); err != nil {
+		t.Fatalf("LeaveRoom() unexpected error = %v", err)
+	}
+	rejoined := &entity.CallParticipant{RoomID: room.ID, UserID: 2, IsActive: true}
+	if err := uc.JoinRoom(ctx, rejoined); err != nil {
+		t.Fatalf("JoinRoom() unexpected error = %v", err)
+	}
+	if rejoined.Role != entity.RoleCoHost {
+		t.Errorf("rejoined role = %q, want co_host", rejoined.Role)
+	}
+}
+
+func TestCallUsecase_Authorize(t *testing.T) {
+	uc, room, _ := setupRoleTest(t)
+
+	tests := []struct {
+		name        string
+		principal   *entity.RoomPrincipal
+		perm        entity.Permission
+		expectedErr error
+	}{
+		{name: "host ends call", principal: &entity.RoomPrincipal{UserID: 1}, perm: entity.PermissionEndCall},
+		{name: "co-host kicks", principal: &entity.RoomPrincipal{UserID: 2}, perm: entity.PermissionKick},
+		{name: "co-host cannot manage room", principal: &entity.RoomPrincipal{UserID: 2}, perm: entity.PermissionManageRoom, expectedErr: entity.ErrPermissionDenied},
+		{name: "participant records", principal: &entity.RoomPrincipal{UserID: 3}, perm: entity.PermissionRecord},
+		{name: "participant cannot transcribe", principal: &entity.RoomPrincipal{UserID: 3}, perm: entity.PermissionTranscribe, expectedErr: entity.ErrPermissionDenied},
+		{name: "participant cannot share screen", principal: &entity.RoomPrincipal{UserID: 3}, perm: entity.PermissionScreenShare, expectedErr: entity.ErrPermissionDenied},
+		{name: "non member", principal: &entity.RoomPrincipal{UserID: 9}, perm: entity.PermissionReadMinutes, expectedErr: entity.ErrNotRoomMember},
+		{name: "guest of another room", principal: &entity.RoomPrincipal{Guest: &entity.GuestPrincipal{GuestID: "g1", RoomID: "room-2"}}, perm: entity.PermissionReadMinutes, expectedErr: entity.ErrNotRoomMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := uc.Authorize(context.Background(), room, tt.principal, tt.perm)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Authorize() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestCallUsecase_ChangeRole(t *testing.T) {
+	tests := []struct {
+		name        string
+		actor       int64
+		target      int64
+		role        entity.ParticipantRole
+		expectedErr error
+	}{
+		{name: "host promotes to presenter", actor: 1, target: 3, role: entity.RolePresenter},
+		{name: "host demotes co-host", actor: 1, target: 2, role: entity.RoleViewer},
+		{name: "co-host promotes to presenter", actor: 2, target: 3, role: entity.RolePresenter},
+		{name: "co-host cannot promote to co-host", actor: 2, target: 3, role: entity.RoleCoHost, expectedErr: entity.ErrPermissionDenied},
+		{name: "co-host cannot demote host", actor: 2, target: 1, role: entity.RoleViewer, expectedErr: entity.ErrPermissionDenied},
+		{name: "participant cannot change roles", actor: 3, target: 2, role: entity.RoleViewer, expectedErr: entity.ErrPermissionDenied},
+		{name: "invalid role", actor: 1, target: 3, role: "owner", expectedErr: entity.ErrInvalidRole},
+		{name: "own role", actor: 1, target: 1, role: entity.RoleViewer, expectedErr: entity.ErrCannotTargetSelf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, room, byUser := setupRoleTest(t)
+
+			changed, err := uc.ChangeRole(context.Background(), room, &entity.RoomPrincipal{UserID: tt.actor}, byUser[tt.target].ID, tt.role)
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("ChangeRole() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ChangeRole() unexpected error = %v", err)
+			}
+			if len(changed) != 1 || changed[0].Role != tt.role {
+				t.Errorf("ChangeRole() changed = %+v", changed)
+			}
+		})
+	}
+
+	t.Run("host transfer", func(t *testing.T) {
+		uc, room, byUser := setupRoleTest(t)
+		ctx := context.Background()
+
+		changed, err := uc.ChangeRole(ctx, room, &entity.RoomPrincipal{UserID: 1}, byUser[3].ID, entity.RoleHost)
+		if err != nil {
+			t.Fatalf("ChangeRole() unexpected error = %v", err)
+		}
+		if len(changed) != 2 || byUser[3].Role != entity.RoleHost || byUser[1].Role != entity.RoleCoHost {
+			t.Errorf("ChangeRole() host = %q, previous host = %q", byUser[3].Role, byUser[1].Role)
+		}
+
+		// 作成者でもホストを移譲した後はルーム管理権限を持たない
+		if err := uc.Authorize(ctx, room, &entity.RoomPrincipal{UserID: 1}, entity.PermissionManageRoom); !errors.Is(err, entity.ErrPermissionDenied) {
+			t.Errorf("Authorize() error = %v, want ErrPermissionDenied", err)
+		}
+	})
+}
+
+func TestCallUsecase_KickParticipant(t *testing.T) {
+	tests := []struct {
+		name        string
+		actor       int64
+		target      int64
+		expectedErr error
+	}{
+		{name: "host kicks participant", actor: 1, target: 3},
+		{name: "co-host kicks participant", actor: 2, target: 3},
+		{name: "co-host cannot kick host", actor: 2, target: 1, expectedErr: entity.ErrPermissionDenied},
+		{name: "participant cannot kick", actor: 3, target: 2, expectedErr: entity.ErrPermissionDenied},
+		{name: "non member", actor: 9, target: 3, expectedErr: entity.ErrNotRoomMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, room, byUser := setupRoleTest(t)
+			ctx := context.Background()
+
+			kicked, err := uc.KickParticipant(ctx, room, &entity.RoomPrincipal{UserID: tt.actor}, byUser[tt.target].ID)
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("KickParticipant() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("KickParticipant() unexpected error = %v", err)
+			}
+			if kicked.IsActive || kicked.LeftAt == nil {
+				t.Errorf("KickParticipant() participant still active: %+v", kicked)
+			}
+
+			// 退出済みの参加者は再度対象にできない
+			if _, err := uc.KickParticipant(ctx, room, &entity.RoomPrincipal{UserID: tt.actor}, kicked.ID); !errors.Is(err, entity.ErrParticipantNotFound) {
+				t.Errorf("KickParticipant() error = %v, want ErrParticipantNotFound", err)
+			}
+		})
+	}
+}
+
+func TestCallUsecase_LifecycleEvents(t *testing.T) {
+	ctx := context.Background()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	events := testutil.NewMockEventPublisher()
+	uc := NewCallUsecase(roomRepo, participantRepo, testutil.NewMockCallRoomAccessRepository(), testutil.NewMockUserRepository(), nil, events)
+
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Room", CreatedBy: 1, Status: entity.CallRoomStatusWaiting}
+	if err := uc.CreateRoom(ctx, room, nil); err != nil {
+		t.Fatalf("CreateRoom() unexpected error = %v", err)
+	}
+
+	host := &entity.CallParticipant{RoomID: room.ID, UserID: 1, IsActive: true}
+	if err := uc.JoinRoom(ctx, host); err != nil {
+		t.Fatalf("JoinRoom() unexpected error = %v", err)
+	}
+	if err := uc.StartRoom(ctx, room); err != nil {
+		t.Fatalf("StartRoom() unexpected error = %v", err)
+	}
+	guest := &entity.CallParticipant{RoomID: room.ID, GuestID: strPtr("guest-1"), GuestName: strPtr("Guest"), IsActive: true}
+	if err := uc.JoinRoom(ctx, guest); err != nil {
+		t.Fatalf("JoinRoom() unexpected error = %v", err)
+	}
+	// 参加中の再参加はイベントを発行しない
+	if err := uc.JoinRoom(ctx, &entity.CallParticipant{RoomID: room.ID, UserID: 1, IsActive: true}); err != nil {
+		t.Fatalf("JoinRoom() unexpected error = %v", err)
+	}
+	if err := uc.LeaveRoomAsGuest(ctx, room.ID, "guest-1"); err != nil {
+		t.Fatalf("LeaveRoomAsGuest() unexpected error = %v", err)
+	}
+	if err := uc.EndRoom(ctx, room); err != nil {
+		t.Fatalf("EndRoom() unexpected error = %v", err)
+	}
+
+	expected := []entity.WebhookEvent{
+		entity.WebhookEventRoomCreated,
+		entity.WebhookEventParticipantJoined,
+		entity.WebhookEventRoomStarted,
+		entity.WebhookEventParticipantJoined,
+		entity.WebhookEventParticipantLeft,
+		entity.WebhookEventParticipantLeft, // 終了時に参加中だったホスト
+		entity.WebhookEventRoomEnded,
+	}
+	names := events.Names()
+	if len(names) != len(expected) {
+		t.Fatalf("published events = %v, want %v", names, expected)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Errorf("published events = %v, want %v", names, expected)
+			break
+		}
+	}
+
+	for _, e := range events.Events {
+		if e.OwnerID != room.CreatedBy {
+			t.Errorf("event %s published to owner %d, want %d", e.Event, e.OwnerID, room.CreatedBy)
+		}
+	}
+	if data, ok := events.Events[3].Data.(participantEventData); !ok || data.GuestID == nil || *data.GuestID != "guest-1" || data.UserID != nil {
+		t.Errorf("guest joined data = %+v", events.Events[3].Data)
+	}
+	if data, ok := events.Events[6].Data.(roomEventData); !ok || data.Status != string(entity.CallRoomStatusEnded) || data.EndedAt == nil {
+		t.Errorf("room ended data = %+v", events.Events[6].Data)
+	}
+}
+
+// singleLanguageTranscriber 言語を1つしか指定できない文字起こしエンジン
+type singleLanguageTranscriber struct {
+	fakeTranscriber
+}
+
+func (f *singleLanguageTranscriber) SupportsLanguages(opts port.TranscribeOptions) error {
+	if opts.Language == "" || len(opts.AlternativeLanguages) > 0 {
+		return port.ErrLanguageNotSupported
+	}
+	return nil
+}
+
+func TestCallUsecase_UpdateTranscriptionSettings(t *testing.T) {
+	tests := []struct {
+		name                 string
+		transcriber          port.Transcriber
+		settings             *entity.TranscriptionLanguageSettings
+		expectedLanguage     string
+		expectedAlternatives []string
+		expectedErr          error
+	}{
+		{name: "default language", settings: &entity.TranscriptionLanguageSettings{}, expectedLanguage: "ja-JP", expectedAlternatives: []string{}},
+		{
+			name:                 "normalized codes without duplicates",
+			settings:             &entity.TranscriptionLanguageSettings{Language: "en-us", AlternativeLanguages: []string{"ja-jp", "en-US", "zh-hans-cn"}},
+			expectedLanguage:     "en-US",
+			expectedAlternatives: []string{"ja-JP", "zh-Hans-CN"},
+		},
+		{
+			name:                 "auto detection",
+			settings:             &entity.TranscriptionLanguageSettings{Language: "AUTO", AlternativeLanguages: []string{"ja-JP", "en-US"}},
+			expectedLanguage:     entity.TranscriptionLanguageAuto,
+			expectedAlternatives: []string{"ja-JP", "en-US"},
+		},
+		{name: "invalid language", settings: &entity.TranscriptionLanguageSettings{Language: "japanese"}, expectedErr: entity.ErrInvalidTranscriptionLanguage},
+		{name: "auto as alternative", settings: &entity.TranscriptionLanguageSettings{AlternativeLanguages: []string{"auto"}}, expectedErr: entity.ErrInvalidTranscriptionLanguage},
+		{
+			name:        "too many alternatives",
+			settings:    &entity.TranscriptionLanguageSettings{AlternativeLanguages: []string{"en-US", "ko-KR", "zh-CN", "fr-FR"}},
+			expectedErr: entity.ErrTooManyAlternativeLanguages,
+		},
+		{
+			name:                 "single language engine",
+			transcriber:          &singleLanguageTranscriber{},
+			settings:             &entity.TranscriptionLanguageSettings{Language: "en-US"},
+			expectedLanguage:     "en-US",
+			expectedAlternatives: []string{},
+		},
+		{
+			name:        "auto detection not supported by engine",
+			transcriber: &singleLanguageTranscriber{},
+			settings:    &entity.TranscriptionLanguageSettings{Language: "auto"},
+			expectedErr: port.ErrLanguageNotSupported,
+		},
+		{
+			name:        "alternatives not supported by engine",
+			transcriber: &singleLanguageTranscriber{},
+			settings:    &entity.TranscriptionLanguageSettings{Language: "ja-JP", AlternativeLanguages: []string{"en-US"}},
+			expectedErr: port.ErrLanguageNotSupported,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			uc := NewCallUsecase(roomRepo, testutil.NewMockCallParticipantRepository(), testutil.NewMockCallRoomAccessRepository(), testutil.NewMockUserRepository(), tt.transcriber, testutil.NewMockEventPublisher())
+			room := &entity.CallRoom{RoomID: "room-1", Name: "Room", CreatedBy: 1, Status: entity.CallRoomStatusWaiting}
+			if err := uc.CreateRoom(ctx, room, nil); err != nil {
+				t.Fatalf("CreateRoom() unexpected error = %v", err)
+			}
+
+			err := uc.UpdateTranscriptionSettings(ctx, room, tt.settings)
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("UpdateTranscriptionSettings() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				if room.TranscriptionLanguage != "" || room.TranscriptionAlternativeLanguages != nil {
+					t.Error("UpdateTranscriptionSettings() should not change the room with invalid settings")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateTranscriptionSettings() unexpected error = %v", err)
+			}
+			saved := roomRepo.Rooms[room.ID]
+			if saved.TranscriptionLanguage != tt.expectedLanguage {
+				t.Errorf("TranscriptionLanguage = %q, want %q", saved.TranscriptionLanguage, tt.expectedLanguage)
+			}
+			if len(saved.TranscriptionAlternativeLanguages) != len(tt.expectedAlternatives) {
+				t.Fatalf("TranscriptionAlternativeLanguages = %v, want %v", saved.TranscriptionAlternativeLanguages, tt.expectedAlternatives)
+			}
+			for i, l := range tt.expectedAlternatives {
+				if saved.TranscriptionAlternativeLanguages[i] != l {
+					t.Errorf("TranscriptionAlternativeLanguages = %v, want %v", saved.TranscriptionAlternativeLanguages, tt.expectedAlternatives)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,412 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// directCallExpiryLockName 複数レプリカで同時に実行しないためのロック名
+const directCallExpiryLockName = "direct_call_expiry"
+
+// 不在着信一覧の取得件数
+const (
+	defaultMissedCallLimit = 20
+	maxMissedCallLimit     = 100
+)
+
+// directCallExpiryBatchSize 1回の処理で不在着信にする通話の上限
+const directCallExpiryBatchSize = 100
+
+// DirectCallConfig 1対1通話の設定
+type DirectCallConfig struct {
+	// 着信を鳴らし続ける時間（過ぎたら不在着信）
+	RingTimeout time.Duration
+}
+
+// DirectCallUsecase 1対1通話ユースケースのインターフェース
+type DirectCallUsecase interface {
+	// 相手を指定して発信（非公開の通話ルームを作成し、相手の全端末へ着信を通知）
+	StartCall(ctx context.Context, callerID, calleeID int64) (*entity.DirectCall, *entity.CallRoom, error)
+	// 着信に応答（参加する通話ルームを返す）
+	Accept(ctx context.Context, userID int64, callID string) (*entity.DirectCall, *entity.CallRoom, error)
+	// 着信を拒否
+	Decline(ctx context.Context, userID int64, callID string) (*entity.DirectCall, error)
+	// 発信を取り消し（相手には不在着信として残る）
+	Cancel(ctx context.Context, userID int64, callID string) (*entity.DirectCall, error)
+	// 応答期限を過ぎた着信を不在着信にし、処理した件数を返す
+	ExpireUnanswered(ctx context.Context, now time.Time) (int, error)
+	// 自分宛ての不在着信一覧（新しい順）
+	GetMissedCalls(ctx context.Context, userID int64, limit int) ([]*entity.MissedCall, error)
+}
+
+// directCallEventData 1対1通話のイベントのデータ
+type directCallEventData struct {
+	CallID     string    `json:"call_id"`
+	RoomID     string    `json:"room_id"`
+	CallerID   int64     `json:"caller_id"`
+	CallerName string    `json:"caller_name,omitempty"`
+	CalleeID   int64     `json:"callee_id"`
+	Status     string    `json:"status"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+type directCallUsecase struct {
+	directCallRepo port.DirectCallRepository
+	roomRepo       port.CallRoomRepository
+	userRepo       port.UserRepository
+	callUsecase    CallUsecase
+	hub            UserEventHub
+	presence       PresenceUsecase
+	notifications  NotificationUsecase
+	push           PushUsecase
+	lock           port.DistributedLock
+	config         DirectCallConfig
+}
+
+// NewDirectCallUsecase 新しい1対1通話ユースケースを作成
+func NewDirectCallUsecase(
+	directCallRepo port.DirectCallRepository,
+	roomRepo port.CallRoomRepository,
+	userRepo port.UserRepository,
+	callUsecase CallUsecase,
+	hub UserEventHub,
+	presence PresenceUsecase,
+	notifications NotificationUsecase,
+	push PushUsecase,
+	lock port.DistributedLock,
+	config DirectCallConfig,
+) DirectCallUsecase {
+	return &directCallUsecase{
+		directCallRepo: directCallRepo,
+		roomRepo:       roomRepo,
+		userRepo:       userRepo,
+		callUsecase:    callUsecase,
+		hub:            hub,
+		presence:       presence,
+		notifications:  notifications,
+		push:           push,
+		lock:           lock,
+		config:         config,
+	}
+}
+
+// StartCall 相手を指定して発信
+// 相手が取り込み中の場合は発信しない
+func (u *directCallUsecase) StartCall(ctx context.Context, callerID, calleeID int64) (*entity.DirectCall, *entity.CallRoom, error) {
+	if callerID == calleeID {
+		return nil, nil, entity.ErrCannotCallSelf
+	}
+	caller, err := u.userRepo.FindByID(ctx, callerID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get caller: %w", err)
+	}
+	callee, err := u.userRepo.FindByID(ctx, calleeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	dnd, err := u.presence.IsDoNotDisturb(ctx, calleeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if dnd {
+		return nil, nil, entity.ErrUserDoNotDisturb
+	}
+
+	// 2人だけが入れる非公開のルーム
+	room := &entity.CallRoom{
+		RoomID:          uuid.New().String(),
+		Name:            caller.Name + " & " + callee.Name,
+		CreatedBy:       callerID,
+		Status:          entity.CallRoomStatusWaiting,
+		MaxParticipants: 2,
+	}
+	settings := &entity.RoomAccessSettings{
+		Visibility:     entity.RoomVisibilityPrivate,
+		AllowedUserIDs: []int64{calleeID},
+	}
+	if err := u.callUsecase.CreateRoom(ctx, room, settings); err != nil {
+		return nil, nil, fmt.Errorf("failed to create room: %w", err)
+	}
+
+	now := time.Now()
+	call := &entity.DirectCall{
+		CallID:        uuid.New().String(),
+		CallerID:      callerID,
+		CalleeID:      calleeID,
+		RoomID:        room.ID,
+		Status:        entity.DirectCallStatusRinging,
+		RingExpiresAt: now.Add(u.config.RingTimeout),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := u.directCallRepo.Create(ctx, call); err != nil {
+		return nil, nil, fmt.Errorf("failed to create direct call: %w", err)
+	}
+
+	data := toDirectCallEventData(call, room, caller.Name)
+	u.hub.Send(calleeID, entity.UserEvent{Type: entity.UserEventIncomingCall, Data: data})
+
+	// アプリを開いていない場合はWeb Pushで着信を知らせる（鳴らしている間だけ有効）
+	u.push.NotifyOffline(ctx, calleeID, &entity.PushMessage{
+		Type:  string(entity.UserEventIncomingCall),
+		Title: caller.Name + " is calling",
+		Data: map[string]interface{}{
+			"call_id":     data.CallID,
+			"room_id":     data.RoomID,
+			"caller_id":   data.CallerID,
+			"caller_name": data.CallerName,
+			"expires_at":  data.ExpiresAt,
+		},
+		Options: entity.PushOptions{TTL: u.config.RingTimeout, Urgency: entity.PushUrgencyHigh},
+	})
+	return call, room, nil
+}
+
+// Accept 着信に応答
+func (u *directCallUsecase) Accept(ctx context.Context, userID int64, callID string) (*entity.DirectCall, *entity.CallRoom, error) {
+	call, room, err := u.findForCallee(ctx, userID, callID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	if !call.IsRinging(now) {
+		return nil, nil, entity.ErrDirectCallNotRinging
+	}
+	call.Status = entity.DirectCallStatusAccepted
+	call.AnsweredAt = &now
+	if err := u.transition(ctx, call); err != nil {
+		return nil, nil, err
+	}
+
+	// 着信者の他の端末は着信表示を止める
+	u.notifyBoth(call, room, entity.UserEventCallAccepted)
+	return call, room, nil
+}
+
+// Decline 着信を拒否
+func (u *directCallUsecase) Decline(ctx context.Context, userID int64, callID string) (*entity.DirectCall, error) {
+	call, room, err := u.findForCallee(ctx, userID, callID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	call.Status = entity.DirectCallStatusDeclined
+	call.AnsweredAt = &now
+	if err := u.transition(ctx, call); err != nil {
+		return nil, err
+	}
+
+	u.endRoom(ctx, room)
+	u.notifyBoth(call, room, entity.UserEventCallDeclined)
+	return call, nil
+}
+
+// Cancel 発信を取り消し
+func (u *directCallUsecase) Cancel(ctx context.Context, userID int64, callID string) (*entity.DirectCall, error) {
+	call, err := u.directCallRepo.FindByCallID(ctx, callID)
+	if err != nil {
+		return nil, err
+	}
+	if call.CallerID != userID {
+		return nil, entity.ErrDirectCallNotFound
+	}
+	room, err := u.roomRepo.FindByID(ctx, call.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.miss(ctx, call, room); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// ExpireUnanswered 応答期限を過ぎた着信を不在着信にする
+// 他のレプリカが実行中の場合は何もしない
+func (u *directCallUsecase) ExpireUnanswered(ctx context.Context, now time.Time) (int, error) {
+	release, acquired, err := u.lock.TryLock(ctx, directCallExpiryLockName)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		return 0, nil
+	}
+	defer release()
+
+	calls, err := u.directCallRepo.FindExpiredRinging(ctx, now, directCallExpiryBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, call := range calls {
+		room, err := u.roomRepo.FindByID(ctx, call.RoomID)
+		if err != nil {
+			slog.Error("Failed to get direct call room",
+				slog.String("call_id", call.CallID),
+				slog.String("error", err.Error()))
+			continue
+		}
+		if err := u.miss(ctx, call, room); err != nil {
+			if !errors.Is(err, entity.ErrDirectCallNotRinging) {
+				slog.Error("Failed to expire direct call",
+					slog.String("call_id", call.CallID),
+					slog.String("error", err.Error()))
+			}
+			continue
+		}
+		expired++
+	}
+	return expired, nil
+}
+
+// GetMissedCalls 自分宛ての不在着信一覧を取得
+func (u *directCallUsecase) GetMissedCalls(ctx context.Context, userID int64, limit int) ([]*entity.MissedCall, error) {
+	if limit <= 0 {
+		limit = defaultMissedCallLimit
+	}
+	if limit > maxMissedCallLimit {
+		limit = maxMissedCallLimit
+	}
+
+	calls, err := u.directCallRepo.FindMissedByCallee(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(calls) == 0 {
+		return []*entity.MissedCall{}, nil
+	}
+
+	// 発信者名をまとめて取得
+	seen := make(map[int64]bool)
+	var callerIDs []int64
+	for _, call := range calls {
+		if !seen[call.CallerID] {
+			seen[call.CallerID] = true
+			callerIDs = append(callerIDs, call.CallerID)
+		}
+	}
+	users, err := u.userRepo.FindByIDs(ctx, callerIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(users))
+	for _, user := range users {
+		names[user.ID] = user.Name
+	}
+
+	missed := make([]*entity.MissedCall, len(calls))
+	for i, call := range calls {
+		missed[i] = &entity.MissedCall{Call: call, CallerName: names[call.CallerID]}
+	}
+	return missed, nil
+}
+
+// findForCallee 着信者宛ての通話と通話ルームを取得（他人の通話は見つからない扱い）
+func (u *directCallUsecase) findForCallee(ctx context.Context, userID int64, callID string) (*entity.DirectCall, *entity.CallRoom, error) {
+	call, err := u.directCallRepo.FindByCallID(ctx, callID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if call.CalleeID != userID {
+		return nil, nil, entity.ErrDirectCallNotFound
+	}
+	room, err := u.roomRepo.FindByID(ctx, call.RoomID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return call, room, nil
+}
+
+// miss 応答されなかった通話を不在着信にしてルームを終了
+func (u *directCallUsecase) miss(ctx context.Context, call *entity.DirectCall, room *entity.CallRoom) error {
+	call.Status = entity.DirectCallStatusMissed
+	if err := u.transition(ctx, call); err != nil {
+		return err
+	}
+
+	u.endRoom(ctx, room)
+	u.notifyBoth(call, room, entity.UserEventCallMissed)
+	u.notifyMissed(ctx, call, room)
+	return nil
+}
+
+// notifyMissed 着信者へ不在着信を通知（失敗しても不在着信一覧には残る）
+func (u *directCallUsecase) notifyMissed(ctx context.Context, call *entity.DirectCall, room *entity.CallRoom) {
+	callerName := ""
+	if caller, err := u.userRepo.FindByID(ctx, call.CallerID); err == nil {
+		callerName = caller.Name
+	}
+
+	err := u.notifications.Notify(ctx, &entity.Notification{
+		UserID: call.CalleeID,
+		Type:   entity.NotificationMissedCall,
+		Title:  "Missed call",
+		Body:   callerName,
+		Data: map[string]interface{}{
+			"call_id":     call.CallID,
+			"room_id":     room.RoomID,
+			"caller_id":   call.CallerID,
+			"caller_name": callerName,
+		},
+	})
+	if err != nil {
+		slog.Error("Failed to notify missed call",
+			slog.String("call_id", call.CallID),
+			slog.String("error", err.Error()))
+	}
+}
+
+// transition 着信中の通話の状態を更新（応答・終了済みならErrDirectCallNotRinging）
+func (u *directCallUsecase) transition(ctx context.Context, call *entity.DirectCall) error {
+	updated, err := u.directCallRepo.UpdateFromRinging(ctx, call)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return entity.ErrDirectCallNotRinging
+	}
+	return nil
+}
+
+// endRoom 使われなかった通話ルームを終了（失敗しても放置ルームの終了処理で回収される）
+func (u *directCallUsecase) endRoom(ctx context.Context, room *entity.CallRoom) {
+	if room.Status == entity.CallRoomStatusEnded {
+		return
+	}
+	if err := u.callUsecase.EndRoom(ctx, room); err != nil {
+		slog.Error("Failed to end direct call room",
+			slog.String("room_id", room.RoomID),
+			slog.String("error", err.Error()))
+	}
+}
+
+// notifyBoth 発信者と着信者の全端末へ通話の状態を配信
+func (u *directCallUsecase) notifyBoth(call *entity.DirectCall, room *entity.CallRoom, eventType entity.UserEventType) {
+	event := entity.UserEvent{Type: eventType, Data: toDirectCallEventData(call, room, "")}
+	u.hub.Send(call.CallerID, event)
+	u.hub.Send(call.CalleeID, event)
+}
+
+// toDirectCallEventData 1対1通話をイベントのデータに変換
+func toDirectCallEventData(call *entity.DirectCall, room *entity.CallRoom, callerName string) directCallEventData {
+	return directCallEventData{
+		CallID:     call.CallID,
+		RoomID:     room.RoomID,
+		CallerID:   call.CallerID,
+		CallerName: callerName,
+		CalleeID:   call.CalleeID,
+		Status:     string(call.Status),
+		ExpiresAt:  call.RingExpiresAt,
+	}
+}
@@ -0,0 +1,276 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// newDirectCallTestUsecase テスト用の1対1通話ユースケースを作成（ユーザー1: Tanaka, 2: Suzuki, 3: Sato）
+func newDirectCallTestUsecase() (DirectCallUsecase, UserEventHub, *testutil.MockCallRoomRepository, PresenceUsecase) {
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	userRepo := testutil.NewMockUserRepository()
+	for _, user := range []*entity.User{
+		{ID: 1, Email: "tanaka@example.com", Name: "Tanaka"},
+		{ID: 2, Email: "suzuki@example.com", Name: "Suzuki"},
+		{ID: 3, Email: "sato@example.com", Name: "Sato"},
+	} {
+		userRepo.Users[user.Email] = user
+	}
+	hub := NewUserEventHub()
+	presence := NewPresenceUsecase(testutil.NewMockPresenceRepository(), participantRepo, hub)
+	push, _, _ := newPushTestUsecase(hub)
+	call := NewCallUsecase(roomRepo, participantRepo, testutil.NewMockCallRoomAccessRepository(), testutil.NewMockUserRepository(), nil, testutil.NewMockEventPublisher())
+	uc := NewDirectCallUsecase(
+		testutil.NewMockDirectCallRepository(),
+		roomRepo,
+		userRepo,
+		call,
+		hub,
+		presence,
+		NewNotificationUsecase(testutil.NewMockNotificationRepository(), roomRepo, participantRepo, hub, push),
+		push,
+		testutil.NewMockDistributedLock(),
+		DirectCallConfig{RingTimeout: 30 * time.Second},
+	)
+	return uc, hub, roomRepo, presence
+}
+
+// receiveUserEvent 溜まっているイベントを1件取り出す
+func receiveUserEvent(t *testing.T, sub *UserEventSubscription) entity.UserEvent {
+	t.Helper()
+	select {
+	case event, ok := <-sub.Events:
+		if !ok {
+			t.Fatal("subscription closed unexpectedly")
+		}
+		return event
+	default:
+		t.Fatal("expected user event, got none")
+	}
+	return entity.UserEvent{}
+}
+
+func TestDirectCallUsecase_StartCall(t *testing.T) {
+	uc, hub, _, _ := newDirectCallTestUsecase()
+	ctx := context.Background()
+
+	// 着信は相手の全端末へ届く
+	phone, desktop := hub.Subscribe(2), hub.Subscribe(2)
+	if hub.ConnectionCount(2) != 2 {
+		t.Fatalf("ConnectionCount() = %d, want 2", hub.ConnectionCount(2))
+	}
+
+	call, room, err := uc.StartCall(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("StartCall() unexpected error = %v", err)
+	}
+	if call.Status != entity.DirectCallStatusRinging || room.Visibility != entity.RoomVisibilityPrivate || room.CreatedBy != 1 {
+		t.Fatalf("StartCall() = %+v, room %+v, want ringing call in private room", call, room)
+	}
+	for _, sub := range []*UserEventSubscription{phone, desktop} {
+		event := receiveUserEvent(t, sub)
+		data, ok := event.Data.(directCallEventData)
+		if event.Type != entity.UserEventIncomingCall || !ok || data.CallID != call.CallID || data.CallerName != "Tanaka" {
+			t.Errorf("event = %s %+v, want incoming-call from Tanaka", event.Type, event.Data)
+		}
+	}
+
+	hub.Unsubscribe(phone)
+	if hub.ConnectionCount(2) != 1 {
+		t.Errorf("ConnectionCount() after unsubscribe = %d, want 1", hub.ConnectionCount(2))
+	}
+
+	if _, _, err := uc.StartCall(ctx, 1, 1); !errors.Is(err, entity.ErrCannotCallSelf) {
+		t.Errorf("StartCall(self) error = %v, want ErrCannotCallSelf", err)
+	}
+	if _, _, err := uc.StartCall(ctx, 1, 99); !errors.Is(err, entity.ErrUserNotFound) {
+		t.Errorf("StartCall(unknown) error = %v, want ErrUserNotFound", err)
+	}
+}
+
+func TestDirectCallUsecase_Answer(t *testing.T) {
+	tests := []struct {
+		name          string
+		answer        func(uc DirectCallUsecase, callID string) error
+		expectedEvent entity.UserEventType
+		expectedRoom  entity.CallRoomStatus
+	}{
+		{
+			name: "accept",
+			answer: func(uc DirectCallUsecase, callID string) error {
+				_, _, err := uc.Accept(context.Background(), 2, callID)
+				return err
+			},
+			expectedEvent: entity.UserEventCallAccepted,
+			expectedRoom:  entity.CallRoomStatusWaiting,
+		},
+		{
+			name: "decline",
+			answer: func(uc DirectCallUsecase, callID string) error {
+				_, err := uc.Decline(context.Background(), 2, callID)
+				return err
+			},
+			expectedEvent: entity.UserEventCallDeclined,
+			expectedRoom:  entity.CallRoomStatusEnded,
+		},
+		{
+			name: "caller cancels",
+			answer: func(uc DirectCallUsecase, callID string) error {
+				_, err := uc.Cancel(context.Background(), 1, callID)
+				return err
+			},
+			expectedEvent: entity.UserEventCallMissed,
+			expectedRoom:  entity.CallRoomStatusEnded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, hub, _, _ := newDirectCallTestUsecase()
+			caller, callee := hub.Subscribe(1), hub.Subscribe(2)
+
+			call, room, err := uc.StartCall(context.Background(), 1, 2)
+			if err != nil {
+				t.Fatalf("StartCall() unexpected error = %v", err)
+			}
+			receiveUserEvent(t, callee)
+
+			if err := tt.answer(uc, call.CallID); err != nil {
+				t.Fatalf("answer unexpected error = %v", err)
+			}
+			for _, sub := range []*UserEventSubscription{caller, callee} {
+				if event := receiveUserEvent(t, sub); event.Type != tt.expectedEvent {
+					t.Errorf("event for user %d = %s, want %s", sub.UserID, event.Type, tt.expectedEvent)
+				}
+			}
+			if room.Status != tt.expectedRoom {
+				t.Errorf("room status = %s, want %s", room.Status, tt.expectedRoom)
+			}
+
+			// 応答・終了した通話には再度応答できない
+			if err := tt.answer(uc, call.CallID); !errors.Is(err, entity.ErrDirectCallNotRinging) {
+				t.Errorf("second answer error = %v, want ErrDirectCallNotRinging", err)
+			}
+		})
+	}
+}
+
+func TestDirectCallUsecase_OnlyParticipantsCanAnswer(t *testing.T) {
+	uc, _, _, _ := newDirectCallTestUsecase()
+	ctx := context.Background()
+
+	call, _, err := uc.StartCall(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("StartCall() unexpected error = %v", err)
+	}
+	if _, _, err := uc.Accept(ctx, 3, call.CallID); !errors.Is(err, entity.ErrDirectCallNotFound) {
+		t.Errorf("Accept(other user) error = %v, want ErrDirectCallNotFound", err)
+	}
+	if _, err := uc.Decline(ctx, 1, call.CallID); !errors.Is(err, entity.ErrDirectCallNotFound) {
+		t.Errorf("Decline(caller) error = %v, want ErrDirectCallNotFound", err)
+	}
+	if _, err := uc.Cancel(ctx, 2, call.CallID); !errors.Is(err, entity.ErrDirectCallNotFound) {
+		t.Errorf("Cancel(callee) error = %v, want ErrDirectCallNotFound", err)
+	}
+}
+
+func TestDirectCallUsecase_ExpireUnanswered(t *testing.T) {
+	uc, hub, roomRepo, _ := newDirectCallTestUsecase()
+	ctx := context.Background()
+
+	first, firstRoom, err := uc.StartCall(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("StartCall() unexpected error = %v", err)
+	}
+	second, _, err := uc.StartCall(ctx, 3, 2)
+	if err != nil {
+		t.Fatalf("StartCall() unexpected error = %v", err)
+	}
+	answered, _, err := uc.StartCall(ctx, 3, 1)
+	if err != nil {
+		t.Fatalf("StartCall() unexpected error = %v", err)
+	}
+	if _, _, err := uc.Accept(ctx, 1, answered.CallID); err != nil {
+		t.Fatalf("Accept() unexpected error = %v", err)
+	}
+
+	// 期限前は何もしない
+	if n, err := uc.ExpireUnanswered(ctx, time.Now()); err != nil || n != 0 {
+		t.Fatalf("ExpireUnanswered() before timeout = %d, %v, want 0", n, err)
+	}
+
+	callee := hub.Subscribe(2)
+	n, err := uc.ExpireUnanswered(ctx, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("ExpireUnanswered() unexpected error = %v", err)
+	}
+	if n != 2 {
+		t.Errorf("ExpireUnanswered() = %d, want 2", n)
+	}
+	// 着信の終了に続いて不在着信の通知が届く
+	for i := 0; i < 2; i++ {
+		if event := receiveUserEvent(t, callee); event.Type != entity.UserEventCallMissed {
+			t.Errorf("event = %s, want call-missed", event.Type)
+		}
+		event := receiveUserEvent(t, callee)
+		if data, ok := event.Data.(notificationEventData); event.Type != entity.UserEventNotification || !ok || data.Type != string(entity.NotificationMissedCall) {
+			t.Errorf("event = %s %+v, want missed-call notification", event.Type, event.Data)
+		}
+	}
+	if room, _ := roomRepo.FindByID(ctx, firstRoom.ID); room.Status != entity.CallRoomStatusEnded {
+		t.Errorf("room status = %s, want ended", room.Status)
+	}
+
+	// 期限切れの着信には応答できない
+	if _, _, err := uc.Accept(ctx, 2, first.CallID); !errors.Is(err, entity.ErrDirectCallNotRinging) {
+		t.Errorf("Accept(expired) error = %v, want ErrDirectCallNotRinging", err)
+	}
+
+	missed, err := uc.GetMissedCalls(ctx, 2, 0)
+	if err != nil {
+		t.Fatalf("GetMissedCalls() unexpected error = %v", err)
+	}
+	if len(missed) != 2 || missed[0].Call.CallID != second.CallID || missed[0].CallerName != "Sato" || missed[1].CallerName != "Tanaka" {
+		t.Errorf("GetMissedCalls() = %d calls, want [Sato, Tanaka]", len(missed))
+	}
+
+	// 応答した通話は不在着信に含まれない
+	missed, err = uc.GetMissedCalls(ctx, 1, 0)
+	if err != nil {
+		t.Fatalf("GetMissedCalls() unexpected error = %v", err)
+	}
+	if len(missed) != 0 {
+		t.Errorf("GetMissedCalls() for answered = %d calls, want 0", len(missed))
+	}
+}
+
+func TestDirectCallUsecase_DoNotDisturb(t *testing.T) {
+	uc, hub, _, presence := newDirectCallTestUsecase()
+	ctx := context.Background()
+
+	callee := hub.Subscribe(2)
+	if _, err := presence.SetStatus(ctx, 2, entity.PresenceDoNotDisturb); err != nil {
+		t.Fatalf("SetStatus() unexpected error = %v", err)
+	}
+	if _, _, err := uc.StartCall(ctx, 1, 2); !errors.Is(err, entity.ErrUserDoNotDisturb) {
+		t.Fatalf("StartCall(dnd) error = %v, want ErrUserDoNotDisturb", err)
+	}
+	select {
+	case event := <-callee.Events:
+		t.Errorf("unexpected event %s while dnd", event.Type)
+	default:
+	}
+
+	if _, err := presence.SetStatus(ctx, 2, entity.PresenceAway); err != nil {
+		t.Fatalf("SetStatus() unexpected error = %v", err)
+	}
+	if _, _, err := uc.StartCall(ctx, 1, 2); err != nil {
+		t.Errorf("StartCall(away) unexpected error = %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+const (
+	// directUploadFinalizeGrace 署名付きURLの期限後、完了の通知を受け付ける時間（期限直前に始めたアップロードのため）
+	directUploadFinalizeGrace = time.Hour
+	// directUploadFinalizeStale 完了の処理が中断されたとみなすまでの時間
+	directUploadFinalizeStale = 5 * time.Minute
+)
+
+// DirectUploadUsecase ストレージへ直接アップロードする録音のユースケースのインターフェース
+// 録音の本文はこのサーバーを経由せず、署名付きURLでストレージへ送信する
+type DirectUploadUsecase interface {
+	// アップロードの枠を作成し、recordings/{room}/ 以下への署名付きURLを発行
+	CreateSlot(ctx context.Context, roomID int64, userID int64, size int64, contentType string, duration *int, startedAt *time.Time) (*DirectUpload, error)
+	// アップロードされたオブジェクトの存在とサイズを確認して録音を登録（完了済みの場合は登録した録音を返す）
+	Finalize(ctx context.Context, roomID int64, slotID string, userID int64) (*entity.CallRecording, error)
+	// 期限を過ぎた枠と、完了していないオブジェクトを削除（削除した件数を返す）
+	CleanupExpired(ctx context.Context, now time.Time) (int, error)
+}
+
+// DirectUpload 発行した署名付きURL
+type DirectUpload struct {
+	Slot      *entity.RecordingUploadSlot
+	URL       string
+	Method    string
+	Headers   map[string]string // アップロード時に送るヘッダー（署名に含まれるため変更できない）
+	ExpiresAt time.Time         // 署名付きURLの期限
+}
+
+// DirectUploadConfig 直接アップロードの設定
+type DirectUploadConfig struct {
+	MaxSize   int64         // 1つの録音の最大サイズ
+	URLExpiry time.Duration // 署名付きURLの有効期間
+}
+
+type directUploadUsecase struct {
+	slotRepo      port.RecordingUploadSlotRepository
+	recordingRepo port.CallRecordingRepository
+	storage       port.ObjectStorage
+	recording     RecordingUsecase
+	config        DirectUploadConfig
+}
+
+// NewDirectUploadUsecase 新しい直接アップロードユースケースを作成
+func NewDirectUploadUsecase(
+	slotRepo port.RecordingUploadSlotRepository,
+	recordingRepo port.CallRecordingRepository,
+	storage port.ObjectStorage,
+	recording RecordingUsecase,
+	config DirectUploadConfig,
+) DirectUploadUsecase {
+	if config.MaxSize <= 0 {
+		config.MaxSize = 1 << 30
+	}
+	if config.URLExpiry <= 0 {
+		config.URLExpiry = time.Hour
+	}
+	return &directUploadUsecase{
+		slotRepo:      slotRepo,
+		recordingRepo: recordingRepo,
+		storage:       storage,
+		recording:     recording,
+		config:        config,
+	}
+}
+
+// CreateSlot アップロードの枠を作成
+func (u *directUploadUsecase) CreateSlot(ctx context.Context, roomID int64, userID int64, size int64, contentType string, duration *int, startedAt *time.Time) (*DirectUpload, error) {
+	if size <= 0 {
+		return nil, entity.ErrInvalidUploadLength
+	}
+	if size > u.config.MaxSize {
+		return nil, entity.ErrUploadTooLarge
+	}
+	format, ok := entity.RecordingFormat(contentType)
+	if !ok {
+		return nil, entity.ErrUnsupportedAudioType
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate slot id: %w", err)
+	}
+
+	now := time.Now()
+	slot := &entity.RecordingUploadSlot{
+		ID:              id,
+		RoomID:          roomID,
+		UserID:          userID,
+		ObjectKey:       fmt.Sprintf("recordings/%d/user-%d-%d-%s.%s", roomID, userID, now.Unix(), id[:8], format),
+		ContentType:     contentType,
+		Size:            size,
+		DurationSeconds: duration,
+		StartedAt:       startedAt,
+		ExpiresAt:       now.Add(u.config.URLExpiry + directUploadFinalizeGrace),
+	}
+
+	presigned, err := u.storage.PresignURL(ctx, slot.ObjectKey, port.PresignOptions{
+		Method:        http.MethodPut,
+		Expires:       u.config.URLExpiry,
+		ContentType:   contentType,
+		ContentLength: size,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to presign upload url: %w", err)
+	}
+
+	if err := u.slotRepo.Create(ctx, slot); err != nil {
+		return nil, fmt.Errorf("failed to create upload slot: %w", err)
+	}
+
+	return &DirectUpload{
+		Slot:      slot,
+		URL:       presigned.URL,
+		Method:    http.MethodPut,
+		Headers:   presigned.Headers,
+		ExpiresAt: now.Add(u.config.URLExpiry),
+	}, nil
+}
+
+// Finalize アップロードを確認して録音を登録
+func (u *directUploadUsecase) Finalize(ctx context.Context, roomID int64, slotID string, userID int64) (*entity.CallRecording, error) {
+	slot, err := u.slotRepo.FindByID(ctx, slotID)
+	if err != nil {
+		return nil, err
+	}
+	// 他のユーザー・ルームの枠は存在を明かさない
+	if slot.UserID != userID || slot.RoomID != roomID {
+		return nil, entity.ErrUploadNotFound
+	}
+	if slot.IsComplete() {
+		return u.recordingRepo.FindByID(ctx, *slot.RecordingID)
+	}
+	now := time.Now()
+	if slot.IsExpired(now) {
+		return nil, entity.ErrUploadExpired
+	}
+
+	// 同時に完了を通知されても録音を重複して登録しない
+	started, err := u.slotRepo.StartFinalizing(ctx, slot.ID, now, now.Add(-directUploadFinalizeStale))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start finalizing: %w", err)
+	}
+	if !started {
+		return nil, entity.ErrUploadFinalizing
+	}
+
+	recording, err := u.register(ctx, slot)
+	if err != nil {
+		if cancelErr := u.slotRepo.CancelFinalizing(ctx, slot.ID); cancelErr != nil {
+			slog.Error("Failed to cancel finalizing upload slot",
+				slog.String("slot_id", slot.ID),
+				slog.String("error", cancelErr.Error()))
+		}
+		return nil, err
+	}
+
+	if err := u.slotRepo.Complete(ctx, slot.ID, recording.ID); err != nil {
+		return nil, fmt.Errorf("failed to complete upload slot: %w", err)
+	}
+
+	slog.Info("Direct recording upload finalized",
+		slog.String("slot_id", slot.ID),
+		slog.Int64("recording_id", recording.ID),
+		slog.Int64("size", slot.Size))
+	return recording, nil
+}
+
+// register アップロードされたオブジェクトを確認して録音として登録
+// 前回の完了処理が録音の登録後に中断していた場合は、登録済みの録音を返す
+func (u *directUploadUsecase) register(ctx context.Context, slot *entity.RecordingUploadSlot) (*entity.CallRecording, error) {
+	existing, err := u.recordingRepo.FindByRoomIDAndFilePath(ctx, slot.RoomID, slot.ObjectKey)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, entity.ErrRecordingNotFound) {
+		return nil, fmt.Errorf("failed to find registered recording: %w", err)
+	}
+
+	object, err := u.storage.Stat(ctx, slot.ObjectKey)
+	if errors.Is(err, port.ErrObjectNotFound) {
+		return nil, entity.ErrUploadNotReceived
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat uploaded object: %w", err)
+	}
+
+	// 署名でサイズを制限していても、ストレージ上のサイズをここで確認する
+	// 異なる場合は削除し、同じ枠で再送できるようにする
+	if object.Size != slot.Size {
+		u.deleteObject(ctx, slot.ObjectKey)
+		return nil, entity.ErrUploadSizeMismatch
+	}
+	object.ContentType = slot.ContentType
+
+	return u.recording.RegisterRecording(ctx, slot.RoomID, slot.UserID, object, slot.DurationSeconds, slot.StartedAt)
+}
+
+// CleanupExpired 期限を過ぎた枠を削除
+func (u *directUploadUsecase) CleanupExpired(ctx context.Context, now time.Time) (int, error) {
+	slots, err := u.slotRepo.FindExpired(ctx, now, expiredUploadBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, slot := range slots {
+		if err := u.slotRepo.Delete(ctx, slot.ID); err != nil {
+			slog.Error("Failed to delete expired upload slot",
+				slog.String("slot_id", slot.ID),
+				slog.String("error", err.Error()))
+			continue
+		}
+		// 完了済みのオブジェクトと、録音の登録後に完了の記録に失敗したオブジェクトは録音として残す
+		if !slot.IsComplete() && !u.isRegistered(ctx, slot) {
+			u.deleteObject(ctx, slot.ObjectKey)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
+
+// isRegistered 枠のオブジェクトが録音として登録済みか判定（確認できない場合は登録済みとみなして残す）
+func (u *directUploadUsecase) isRegistered(ctx context.Context, slot *entity.RecordingUploadSlot) bool {
+	_, err := u.recordingRepo.FindByRoomIDAndFilePath(ctx, slot.RoomID, slot.ObjectKey)
+	if errors.Is(err, entity.ErrRecordingNotFound) {
+		return false
+	}
+	if err != nil {
+		slog.Error("Failed to check registered recording",
+			slog.String("slot_id", slot.ID),
+			slog.String("error", err.Error()))
+	}
+	return true
+}
+
+func (u *directUploadUsecase) deleteObject(ctx context.Context, key string) {
+	if err := u.storage.Delete(ctx, key); err != nil {
+		slog.Error("Failed to delete upload object",
+			slog.String("key", key),
+			slog.String("error", err.Error()))
+	}
+}
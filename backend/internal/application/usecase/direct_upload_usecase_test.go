@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// directUploadTestConfig テスト用の直接アップロード設定（最大1KB、URLは15分有効）
+var directUploadTestConfig = DirectUploadConfig{
+	MaxSize:   1024,
+	URLExpiry: 15 * time.Minute,
+}
+
+// putUploadedObject クライアントが署名付きURLでアップロードしたことを再現する
+func putUploadedObject(t *testing.T, storage *testutil.MockObjectStorage, upload *DirectUpload, body string) {
+	t.Helper()
+	if _, err := storage.Put(context.Background(), upload.Slot.ObjectKey, strings.NewReader(body), -1, upload.Headers["Content-Type"]); err != nil {
+		t.Fatalf("Put error: %v", err)
+	}
+}
+
+func TestDirectUploadUsecase_CreateSlot(t *testing.T) {
+	tests := []struct {
+		name        string
+		size        int64
+		contentType string
+		wantExt     string
+		expectedErr error
+	}{
+		{name: "ogg", size: 5, contentType: "audio/ogg", wantExt: ".ogg"},
+		{name: "over max size", size: 2048, contentType: "audio/webm", expectedErr: entity.ErrUploadTooLarge},
+		{name: "not audio", size: 10, contentType: "application/zip", expectedErr: entity.ErrUnsupportedAudioType},
+		{name: "negative size", size: -1, contentType: "audio/webm", expectedErr: entity.ErrInvalidUploadLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			slotRepo := testutil.NewMockRecordingUploadSlotRepository()
+			recordingRepo := testutil.NewMockCallRecordingRepository()
+			usecase := NewDirectUploadUsecase(slotRepo, recordingRepo, testutil.NewMockObjectStorage(), &uploadRecordingUsecase{recordings: recordingRepo}, directUploadTestConfig)
+			ctx := context.Background()
+
+			// Act
+			upload, err := usecase.CreateSlot(ctx, 1, 10, tt.size, tt.contentType, nil, nil)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CreateSlot() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr != nil {
+				if len(slotRepo.Slots) != 0 {
+					t.Error("CreateSlot() should not save a rejected slot")
+				}
+				return
+			}
+			if upload.Method != http.MethodPut || !strings.HasSuffix(upload.URL, upload.Slot.ObjectKey) {
+				t.Errorf("presigned = %s %s, want PUT to %s", upload.Method, upload.URL, upload.Slot.ObjectKey)
+			}
+			if !strings.HasPrefix(upload.Slot.ObjectKey, "recordings/1/user-10-") || !strings.HasSuffix(upload.Slot.ObjectKey, tt.wantExt) {
+				t.Errorf("ObjectKey = %s, want recordings/1/user-10-*%s", upload.Slot.ObjectKey, tt.wantExt)
+			}
+		})
+	}
+}
+
+func TestDirectUploadUsecase_Finalize(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string // アップロードした内容（空ならアップロードしていない）
+		roomID        int64
+		userID        int64
+		finalizing    time.Duration // 他のリクエストが完了の処理を始めてからの時間（0なら処理中でない）
+		preRegistered bool          // 録音の登録後、枠の完了を記録する前に中断した
+		expectedErr   error
+	}{
+		{name: "uploaded", body: "audio", roomID: 1, userID: 10},
+		{name: "not uploaded yet", roomID: 1, userID: 10, expectedErr: entity.ErrUploadNotReceived},
+		{name: "other user", body: "audio", roomID: 1, userID: 11, expectedErr: entity.ErrUploadNotFound},
+		{name: "other room", body: "audio", roomID: 2, userID: 10, expectedErr: entity.ErrUploadNotFound},
+		{name: "size mismatch", body: "too long", roomID: 1, userID: 10, expectedErr: entity.ErrUploadSizeMismatch},
+		{name: "finalizing in another request", body: "audio", roomID: 1, userID: 10, finalizing: time.Second, expectedErr: entity.ErrUploadFinalizing},
+		{name: "stale finalizing", body: "audio", roomID: 1, userID: 10, finalizing: time.Hour},
+		{name: "recording registered before interruption", body: "audio", roomID: 1, userID: 10, preRegistered: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			slotRepo := testutil.NewMockRecordingUploadSlotRepository()
+			recordingRepo := testutil.NewMockCallRecordingRepository()
+			storage := testutil.NewMockObjectStorage()
+			usecase := NewDirectUploadUsecase(slotRepo, recordingRepo, storage, &uploadRecordingUsecase{recordings: recordingRepo}, directUploadTestConfig)
+			ctx := context.Background()
+
+			upload, _ := usecase.CreateSlot(ctx, 1, 10, 5, "audio/webm", nil, nil)
+			if tt.body != "" {
+				putUploadedObject(t, storage, upload, tt.body)
+			}
+			if tt.finalizing > 0 {
+				since := time.Now().Add(-tt.finalizing)
+				slotRepo.Slots[upload.Slot.ID].FinalizingAt = &since
+			}
+			var registered *entity.CallRecording
+			if tt.preRegistered {
+				registered = &entity.CallRecording{RoomID: 1, UserID: 10, FilePath: upload.Slot.ObjectKey, FileSize: 5}
+				recordingRepo.Create(ctx, registered)
+			}
+
+			// Act
+			recording, err := usecase.Finalize(ctx, tt.roomID, upload.Slot.ID, tt.userID)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Finalize() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr == entity.ErrUploadSizeMismatch {
+				if _, ok := storage.Objects[upload.Slot.ObjectKey]; ok {
+					t.Error("object with wrong size was not deleted")
+				}
+			}
+			if tt.expectedErr != nil {
+				if len(recordingRepo.Recordings) != 0 {
+					t.Errorf("recordings = %d, want none", len(recordingRepo.Recordings))
+				}
+				return
+			}
+			if recording.FilePath != upload.Slot.ObjectKey || recording.FileSize != 5 {
+				t.Errorf("recording = %s (%d bytes), want %s (5 bytes)", recording.FilePath, recording.FileSize, upload.Slot.ObjectKey)
+			}
+			if registered != nil && recording.ID != registered.ID {
+				t.Errorf("Finalize returned recording %d, want %d", recording.ID, registered.ID)
+			}
+			if len(recordingRepo.Recordings) != 1 {
+				t.Errorf("recordings = %d, want 1", len(recordingRepo.Recordings))
+			}
+			slot, _ := slotRepo.FindByID(ctx, upload.Slot.ID)
+			if !slot.IsComplete() || *slot.RecordingID != recording.ID {
+				t.Error("slot was not completed with the recording")
+			}
+		})
+	}
+}
+
+func TestDirectUploadUsecase_FinalizeRetry(t *testing.T) {
+	tests := []struct {
+		name  string
+		first string // 1回目にアップロードした内容
+	}{
+		{name: "finalize notification resent", first: "audio"},
+		{name: "re-upload after size mismatch", first: "too long"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			recordingRepo := testutil.NewMockCallRecordingRepository()
+			storage := testutil.NewMockObjectStorage()
+			recording := &uploadRecordingUsecase{recordings: recordingRepo}
+			usecase := NewDirectUploadUsecase(testutil.NewMockRecordingUploadSlotRepository(), recordingRepo, storage, recording, directUploadTestConfig)
+			ctx := context.Background()
+			upload, _ := usecase.CreateSlot(ctx, 1, 10, 5, "audio/webm", nil, nil)
+			putUploadedObject(t, storage, upload, tt.first)
+			first, _ := usecase.Finalize(ctx, 1, upload.Slot.ID, 10)
+
+			// Act（同じ枠で再送する）
+			putUploadedObject(t, storage, upload, "audio")
+			again, err := usecase.Finalize(ctx, 1, upload.Slot.ID, 10)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Finalize() again unexpected error = %v", err)
+			}
+			if first != nil && again.ID != first.ID {
+				t.Errorf("Finalize() again = %d, want the same recording %d", again.ID, first.ID)
+			}
+			if len(recording.registered) != 1 {
+				t.Errorf("registered = %d, want 1", len(recording.registered))
+			}
+		})
+	}
+}
+
+func TestDirectUploadUsecase_CleanupExpired(t *testing.T) {
+	// Arrange
+	recordingRepo := testutil.NewMockCallRecordingRepository()
+	storage := testutil.NewMockObjectStorage()
+	usecase := NewDirectUploadUsecase(testutil.NewMockRecordingUploadSlotRepository(), recordingRepo, storage, &uploadRecordingUsecase{recordings: recordingRepo}, directUploadTestConfig)
+	ctx := context.Background()
+
+	abandoned, _ := usecase.CreateSlot(ctx, 1, 10, 5, "audio/webm", nil, nil)
+	putUploadedObject(t, storage, abandoned, "audio")
+
+	finalized, _ := usecase.CreateSlot(ctx, 1, 10, 5, "audio/webm", nil, nil)
+	putUploadedObject(t, storage, finalized, "audio")
+	if _, err := usecase.Finalize(ctx, 1, finalized.Slot.ID, 10); err != nil {
+		t.Fatalf("Finalize error: %v", err)
+	}
+
+	// 録音を登録した後、枠の完了の記録に失敗した場合を再現する
+	interrupted, _ := usecase.CreateSlot(ctx, 1, 10, 5, "audio/webm", nil, nil)
+	putUploadedObject(t, storage, interrupted, "audio")
+	if err := recordingRepo.Create(ctx, &entity.CallRecording{RoomID: 1, UserID: 10, FilePath: interrupted.Slot.ObjectKey, FileSize: 5}); err != nil {
+		t.Fatalf("Create error: %v", err)
+	}
+
+	// Act（アップロードしたまま完了を通知しなかった枠は期限後に削除する）
+	deleted, err := usecase.CleanupExpired(ctx, time.Now().Add(3*time.Hour))
+
+	// Assert
+	if err != nil || deleted != 3 {
+		t.Fatalf("CleanupExpired = %d, %v, want 3", deleted, err)
+	}
+	if _, ok := storage.Objects[abandoned.Slot.ObjectKey]; ok {
+		t.Error("object of unfinalized slot was not deleted")
+	}
+	if _, ok := storage.Objects[finalized.Slot.ObjectKey]; !ok {
+		t.Error("recording of finalized slot was deleted")
+	}
+	if _, ok := storage.Objects[interrupted.Slot.ObjectKey]; !ok {
+		t.Error("object referenced by a recording was deleted")
+	}
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// EventPublisher 通話・議事録のイベント通知先
+type EventPublisher interface {
+	// ownerIDのユーザーが所有するルーム等のイベントを通知（失敗してもエラーは返さない）
+	Publish(ctx context.Context, ownerID int64, event entity.WebhookEvent, data interface{})
+}
+
+// eventPublishers 複数の通知先へ同じイベントを配る
+type eventPublishers []EventPublisher
+
+// NewEventPublishers 複数の通知先をまとめたEventPublisherを作成
+func NewEventPublishers(publishers ...EventPublisher) EventPublisher {
+	return eventPublishers(publishers)
+}
+
+// Publish 全ての通知先へイベントを通知
+func (p eventPublishers) Publish(ctx context.Context, ownerID int64, event entity.WebhookEvent, data interface{}) {
+	for _, publisher := range p {
+		publisher.Publish(ctx, ownerID, event, data)
+	}
+}
@@ -0,0 +1,248 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/invite"
+)
+
+// 招待リンクとゲストトークンの有効期限
+const (
+	defaultInviteTTL  = 24 * time.Hour
+	maxInviteTTL      = 7 * 24 * time.Hour
+	guestSessionTTL   = 12 * time.Hour
+	maxDisplayNameLen = 50
+)
+
+// InviteUsecase 招待リンク・ゲスト参加ユースケースのインターフェース
+type InviteUsecase interface {
+	// 招待リンク作成
+	CreateInvite(ctx context.Context, roomID string, userID int64, ttl time.Duration) (*entity.CallInvite, error)
+	// 招待を受諾してゲストトークンを発行
+	AcceptInvite(ctx context.Context, token string, displayName string) (*entity.GuestSession, error)
+	// ゲストトークン検証
+	ValidateGuestToken(token string) (*entity.GuestPrincipal, error)
+	// 登録ユーザーを招待（相手へ通知し、参加を制限しているルームにも参加できるようにする）
+	InviteUser(ctx context.Context, roomID string, inviterID, inviteeID int64) error
+}
+
+type inviteUsecase struct {
+	roomRepo        port.CallRoomRepository
+	participantRepo port.CallParticipantRepository
+	accessRepo      port.CallRoomAccessRepository
+	userRepo        port.UserRepository
+	presence        PresenceUsecase
+	notifications   NotificationUsecase
+	signer          *invite.Signer
+	frontendURL     string
+}
+
+// NewInviteUsecase 新しい招待ユースケースを作成
+func NewInviteUsecase(
+	roomRepo port.CallRoomRepository,
+	participantRepo port.CallParticipantRepository,
+	accessRepo port.CallRoomAccessRepository,
+	userRepo port.UserRepository,
+	presence PresenceUsecase,
+	notifications NotificationUsecase,
+	signer *invite.Signer,
+	frontendURL string,
+) InviteUsecase {
+	return &inviteUsecase{
+		roomRepo:        roomRepo,
+		participantRepo: participantRepo,
+		accessRepo:      accessRepo,
+		userRepo:        userRepo,
+		presence:        presence,
+		notifications:   notifications,
+		signer:          signer,
+		frontendURL:     frontendURL,
+	}
+}
+
+// CreateInvite 招待リンクを作成
+// ルームの作成者または現在の参加者のみが発行できる
+func (u *inviteUsecase) CreateInvite(ctx context.Context, roomID string, userID int64, ttl time.Duration) (*entity.CallInvite, error) {
+	room, err := u.findRoomForInviter(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if ttl <= 0 {
+		ttl = defaultInviteTTL
+	}
+	if ttl > maxInviteTTL {
+		ttl = maxInviteTTL
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	token, err := u.signer.Sign(invite.Claims{
+		Kind:      invite.KindInvite,
+		RoomID:    room.RoomID,
+		IssuedBy:  userID,
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.CallInvite{
+		RoomID:    room.RoomID,
+		Token:     token,
+		URL:       u.frontendURL + "/calls/" + room.RoomID + "?invite=" + url.QueryEscape(token),
+		CreatedBy: userID,
+		ExpiresAt: time.Unix(expiresAt.Unix(), 0),
+	}, nil
+}
+
+// AcceptInvite 招待を受諾してゲストトークンを発行
+// 参加記録は発行したトークンでJoinRoomした時点で作成される
+func (u *inviteUsecase) AcceptInvite(ctx context.Context, token string, displayName string) (*entity.GuestSession, error) {
+	claims, err := u.signer.Verify(token, invite.KindInvite)
+	if err != nil {
+		if errors.Is(err, invite.ErrExpiredToken) {
+			return nil, entity.ErrInviteExpired
+		}
+		return nil, entity.ErrInvalidInvite
+	}
+
+	displayName = strings.TrimSpace(displayName)
+	if displayName == "" || utf8.RuneCountInString(displayName) > maxDisplayNameLen {
+		return nil, entity.ErrInvalidDisplayName
+	}
+
+	room, err := u.roomRepo.FindByRoomID(ctx, claims.RoomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.Status == entity.CallRoomStatusEnded {
+		return nil, entity.ErrRoomEnded
+	}
+
+	guest := &entity.GuestPrincipal{
+		GuestID:     uuid.New().String(),
+		RoomID:      room.RoomID,
+		DisplayName: displayName,
+		ExpiresAt:   time.Unix(time.Now().Add(guestSessionTTL).Unix(), 0),
+	}
+
+	accessToken, err := u.signer.Sign(invite.Claims{
+		Kind:        invite.KindGuest,
+		RoomID:      guest.RoomID,
+		GuestID:     guest.GuestID,
+		DisplayName: guest.DisplayName,
+		IssuedBy:    claims.IssuedBy,
+		ExpiresAt:   guest.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.GuestSession{
+		Guest:       guest,
+		AccessToken: accessToken,
+	}, nil
+}
+
+// ValidateGuestToken ゲストトークンを検証
+func (u *inviteUsecase) ValidateGuestToken(token string) (*entity.GuestPrincipal, error) {
+	claims, err := u.signer.Verify(token, invite.KindGuest)
+	if err != nil {
+		if errors.Is(err, invite.ErrExpiredToken) {
+			return nil, entity.ErrTokenExpired
+		}
+		return nil, entity.ErrInvalidToken
+	}
+	if claims.GuestID == "" {
+		return nil, entity.ErrInvalidToken
+	}
+
+	return &entity.GuestPrincipal{
+		GuestID:     claims.GuestID,
+		RoomID:      claims.RoomID,
+		DisplayName: claims.DisplayName,
+		ExpiresAt:   claims.ExpiresAtTime(),
+	}, nil
+}
+
+// InviteUser 登録ユーザーを通話ルームに招待
+// 取り込み中のユーザーには通知しない
+func (u *inviteUsecase) InviteUser(ctx context.Context, roomID string, inviterID, inviteeID int64) error {
+	if inviterID == inviteeID {
+		return entity.ErrCannotInviteSelf
+	}
+	room, err := u.findRoomForInviter(ctx, roomID, inviterID)
+	if err != nil {
+		return err
+	}
+	inviter, err := u.userRepo.FindByID(ctx, inviterID)
+	if err != nil {
+		return fmt.Errorf("failed to get inviter: %w", err)
+	}
+	if _, err := u.userRepo.FindByID(ctx, inviteeID); err != nil {
+		return err
+	}
+
+	dnd, err := u.presence.IsDoNotDisturb(ctx, inviteeID)
+	if err != nil {
+		return err
+	}
+	if dnd {
+		return entity.ErrUserDoNotDisturb
+	}
+
+	// 参加を許可リストで制限しているルームには参加できるよう招待した相手を追加する
+	// 制限していないルームに追加すると、他のユーザーが参加できなくなるため追加しない
+	rules, err := u.accessRepo.FindByRoomID(ctx, room.ID)
+	if err != nil {
+		return err
+	}
+	if room.RestrictsAdmission(rules) {
+		if err := u.accessRepo.AddRule(ctx, &entity.CallRoomAccessRule{RoomID: room.ID, UserID: &inviteeID}); err != nil {
+			return err
+		}
+	}
+
+	return u.notifications.Notify(ctx, &entity.Notification{
+		UserID: inviteeID,
+		Type:   entity.NotificationCallInvite,
+		Title:  inviter.Name + " invited you to a call",
+		Body:   room.Name,
+		Data: map[string]interface{}{
+			"room_id":      room.RoomID,
+			"room_name":    room.Name,
+			"inviter_id":   inviterID,
+			"inviter_name": inviter.Name,
+			"url":          u.frontendURL + "/calls/" + room.RoomID,
+		},
+	})
+}
+
+// findRoomForInviter 招待できるルームを取得
+// ルームの作成者または現在の参加者のみが招待できる
+func (u *inviteUsecase) findRoomForInviter(ctx context.Context, roomID string, userID int64) (*entity.CallRoom, error) {
+	room, err := u.roomRepo.FindByRoomID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.Status == entity.CallRoomStatusEnded {
+		return nil, entity.ErrRoomEnded
+	}
+
+	if room.CreatedBy != userID {
+		if _, err := u.participantRepo.FindByRoomIDAndUserID(ctx, room.ID, userID); err != nil {
+			return nil, entity.ErrNotRoomMember
+		}
+	}
+	return room, nil
+}
@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/pkg/invite"
+)
+
+// setupInviteTest テスト用のルームと招待ユースケースを作成
+func setupInviteTest(t *testing.T) (InviteUsecase, *testutil.MockCallRoomRepository, *testutil.MockCallParticipantRepository) {
+	t.Helper()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Weekly", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+	if err := roomRepo.Create(context.Background(), room); err != nil {
+		t.Fatalf("failed to create room: %v", err)
+	}
+
+	hub := NewUserEventHub()
+	push, _, _ := newPushTestUsecase(hub)
+	uc := NewInviteUsecase(
+		roomRepo,
+		participantRepo,
+		testutil.NewMockCallRoomAccessRepository(),
+		testutil.NewMockUserRepository(),
+		NewPresenceUsecase(testutil.NewMockPresenceRepository(), participantRepo, hub),
+		NewNotificationUsecase(testutil.NewMockNotificationRepository(), roomRepo, participantRepo, hub, push),
+		invite.NewSigner([]byte("test-secret")),
+		"http://localhost:3000",
+	)
+	return uc, roomRepo, participantRepo
+}
+
+func TestInviteUsecase_CreateInvite(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      int64
+		setup       func(rooms *testutil.MockCallRoomRepository, participants *testutil.MockCallParticipantRepository)
+		ttl         time.Duration
+		expectedTTL time.Duration
+		expectedErr error
+	}{
+		{
+			name:        "room creator with default ttl",
+			userID:      1,
+			expectedTTL: defaultInviteTTL,
+		},
+		{
+			name:   "active participant",
+			userID: 2,
+			setup: func(rooms *testutil.MockCallRoomRepository, participants *testutil.MockCallParticipantRepository) {
+				participants.Create(context.Background(), &entity.CallParticipant{RoomID: 1, UserID: 2, IsActive: true})
+			},
+			ttl:         time.Hour,
+			expectedTTL: time.Hour,
+		},
+		{
+			name:        "ttl is capped",
+			userID:      1,
+			ttl:         30 * 24 * time.Hour,
+			expectedTTL: maxInviteTTL,
+		},
+		{
+			name:        "non member",
+			userID:      3,
+			expectedErr: entity.ErrNotRoomMember,
+		},
+		{
+			name:   "ended room",
+			userID: 1,
+			setup: func(rooms *testutil.MockCallRoomRepository, participants *testutil.MockCallParticipantRepository) {
+				rooms.Rooms[1].Status = entity.CallRoomStatusEnded
+			},
+			expectedErr: entity.ErrRoomEnded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, rooms, participants := setupInviteTest(t)
+			if tt.setup != nil {
+				tt.setup(rooms, participants)
+			}
+
+			inv, err := uc.CreateInvite(context.Background(), "room-1", tt.userID, tt.ttl)
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("CreateInvite() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateInvite() unexpected error = %v", err)
+			}
+			if !strings.HasPrefix(inv.URL, "http://localhost:3000/calls/room-1?invite=") {
+				t.Errorf("CreateInvite() URL = %q", inv.URL)
+			}
+			if d := time.Until(inv.ExpiresAt); d > tt.expectedTTL || d < tt.expectedTTL-time.Minute {
+				t.Errorf("CreateInvite() expires in %v, want about %v", d, tt.expectedTTL)
+			}
+		})
+	}
+}
+
+func TestInviteUsecase_AcceptInvite(t *testing.T) {
+	uc, rooms, _ := setupInviteTest(t)
+	ctx := context.Background()
+
+	inv, err := uc.CreateInvite(ctx, "room-1", 1, time.Hour)
+	if err != nil {
+		t.Fatalf("CreateInvite() unexpected error = %v", err)
+	}
+
+	t.Run("valid invite", func(t *testing.T) {
+		session, err := uc.AcceptInvite(ctx, inv.Token, "  Guest Taro ")
+		if err != nil {
+			t.Fatalf("AcceptInvite() unexpected error = %v", err)
+		}
+		if session.Guest.RoomID != "room-1" || session.Guest.DisplayName != "Guest Taro" || session.Guest.GuestID == "" {
+			t.Errorf("AcceptInvite() guest = %+v", session.Guest)
+		}
+
+		guest, err := uc.ValidateGuestToken(session.AccessToken)
+		if err != nil {
+			t.Fatalf("ValidateGuestToken() unexpected error = %v", err)
+		}
+		if guest.GuestID != session.Guest.GuestID {
+			t.Errorf("ValidateGuestToken() guest ID = %q, want %q", guest.GuestID, session.Guest.GuestID)
+		}
+	})
+
+	t.Run("invite token cannot be used as guest token", func(t *testing.T) {
+		if _, err := uc.ValidateGuestToken(inv.Token); !errors.Is(err, entity.ErrInvalidToken) {
+			t.Errorf("ValidateGuestToken() error = %v, want ErrInvalidToken", err)
+		}
+	})
+
+	t.Run("invalid display name", func(t *testing.T) {
+		for _, name := range []string{"", "   ", strings.Repeat("あ", maxDisplayNameLen+1)} {
+			if _, err := uc.AcceptInvite(ctx, inv.Token, name); !errors.Is(err, entity.ErrInvalidDisplayName) {
+				t.Errorf("AcceptInvite(%q) error = %v, want ErrInvalidDisplayName", name, err)
+			}
+		}
+	})
+
+	t.Run("tampered token", func(t *testing.T) {
+		if _, err := uc.AcceptInvite(ctx, inv.Token+"x", "Guest"); !errors.Is(err, entity.ErrInvalidInvite) {
+			t.Errorf("AcceptInvite() error = %v, want ErrInvalidInvite", err)
+		}
+	})
+
+	t.Run("ended room", func(t *testing.T) {
+		rooms.Rooms[1].Status = entity.CallRoomStatusEnded
+		defer func() { rooms.Rooms[1].Status = entity.CallRoomStatusActive }()
+
+		if _, err := uc.AcceptInvite(ctx, inv.Token, "Guest"); !errors.Is(err, entity.ErrRoomEnded) {
+			t.Errorf("AcceptInvite() error = %v, want ErrRoomEnded", err)
+		}
+	})
+}
+
+func TestInviteUsecase_InviteUser(t *testing.T) {
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	accessRepo := testutil.NewMockCallRoomAccessRepository()
+	userRepo := testutil.NewMockUserRepository()
+	for _, user := range []*entity.User{
+		{ID: 1, Email: "tanaka@example.com", Name: "Tanaka"},
+		{ID: 2, Email: "suzuki@example.com", Name: "Suzuki"},
+		{ID: 3, Email: "sato@example.com", Name: "Sato"},
+	} {
+		userRepo.Users[user.Email] = user
+	}
+	hub := NewUserEventHub()
+	push, _, _ := newPushTestUsecase(hub)
+	presence := NewPresenceUsecase(testutil.NewMockPresenceRepository(), participantRepo, hub)
+	notificationRepo := testutil.NewMockNotificationRepository()
+	notifications := NewNotificationUsecase(notificationRepo, roomRepo, participantRepo, hub, push)
+	uc := NewInviteUsecase(roomRepo, participantRepo, accessRepo, userRepo, presence, notifications, invite.NewSigner([]byte("test-secret")), "http://localhost:3000")
+	ctx := context.Background()
+
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Weekly", CreatedBy: 1, Status: entity.CallRoomStatusActive, Visibility: entity.RoomVisibilityInviteOnly}
+	if err := roomRepo.Create(ctx, room); err != nil {
+		t.Fatalf("failed to create room: %v", err)
+	}
+	openRoom := &entity.CallRoom{RoomID: "room-2", Name: "Lounge", CreatedBy: 1, Status: entity.CallRoomStatusActive, Visibility: entity.RoomVisibilityPublic}
+	if err := roomRepo.Create(ctx, openRoom); err != nil {
+		t.Fatalf("failed to create room: %v", err)
+	}
+
+	invitee := hub.Subscribe(2)
+	if err := uc.InviteUser(ctx, "room-1", 1, 2); err != nil {
+		t.Fatalf("InviteUser() unexpected error = %v", err)
+	}
+	select {
+	case event := <-invitee.Events:
+		data, ok := event.Data.(notificationEventData)
+		if event.Type != entity.UserEventNotification || !ok || data.Type != string(entity.NotificationCallInvite) || data.Data["inviter_name"] != "Tanaka" {
+			t.Errorf("event = %s %+v, want call-invite notification from Tanaka", event.Type, event.Data)
+		}
+	default:
+		t.Fatal("expected notification event, got none")
+	}
+	if saved := notificationRepo.ByType(2, entity.NotificationCallInvite); len(saved) != 1 || saved[0].Data["room_id"] != "room-1" {
+		t.Errorf("saved call-invite notifications = %d, want 1 for room-1", len(saved))
+	}
+
+	// 招待制のルームでも参加できるよう許可リストに追加される（重複はしない）
+	if err := uc.InviteUser(ctx, "room-1", 1, 2); err != nil {
+		t.Fatalf("InviteUser() unexpected error = %v", err)
+	}
+	rules, _ := accessRepo.FindByRoomID(ctx, room.ID)
+	if len(rules) != 1 || !room.IsVisibleTo(rules, &entity.RoomPrincipal{UserID: 2}) {
+		t.Errorf("access rules = %d, want invitee allowed once", len(rules))
+	}
+
+	// 制限していないルームでは許可リストを作らず、他のユーザーも参加できるままにする
+	if err := uc.InviteUser(ctx, "room-2", 1, 2); err != nil {
+		t.Fatalf("InviteUser() unexpected error = %v", err)
+	}
+	openRules, _ := accessRepo.FindByRoomID(ctx, openRoom.ID)
+	if len(openRules) != 0 || !openRoom.Admits(openRules, 3, "sato@example.com") {
+		t.Errorf("access rules = %d, want public room to stay open", len(openRules))
+	}
+
+	tests := []struct {
+		name        string
+		inviterID   int64
+		inviteeID   int64
+		setup       func()
+		expectedErr error
+	}{
+		{name: "self", inviterID: 1, inviteeID: 1, expectedErr: entity.ErrCannotInviteSelf},
+		{name: "not a member", inviterID: 3, inviteeID: 2, expectedErr: entity.ErrNotRoomMember},
+		{name: "unknown user", inviterID: 1, inviteeID: 99, expectedErr: entity.ErrUserNotFound},
+		{
+			name:      "do not disturb",
+			inviterID: 1,
+			inviteeID: 3,
+			setup: func() {
+				presence.SetStatus(ctx, 3, entity.PresenceDoNotDisturb)
+			},
+			expectedErr: entity.ErrUserDoNotDisturb,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+			if err := uc.InviteUser(ctx, "room-1", tt.inviterID, tt.inviteeID); !errors.Is(err, tt.expectedErr) {
+				t.Errorf("InviteUser() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"sort"
+	"sync"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// lobbySubscriberBuffer 購読者ごとに溜められるイベント数（超えたら購読を打ち切る）
+const lobbySubscriberBuffer = 64
+
+// LobbyUsecase ロビー（アクティブなルーム一覧）のリアルタイム配信
+// 通話のイベントを受け取り、参加人数をメモリ上で管理して購読者へ配信する
+type LobbyUsecase interface {
+	EventPublisher
+	// 主体に表示可能なルーム一覧を返し、以降の変更を購読する
+	Subscribe(ctx context.Context, principal *entity.RoomPrincipal) ([]*entity.LobbyRoom, *LobbySubscription, error)
+	// 購読を解除する
+	Unsubscribe(sub *LobbySubscription)
+	// DBの状態を読み直し、メモリ上の状態とのずれを購読者へ配信する
+	Resync(ctx context.Context) error
+}
+
+// LobbySubscription ロビーの購読
+type LobbySubscription struct {
+	// 配信されるイベント（購読解除、または配信が追いつかない場合に閉じられる）
+	Events <-chan entity.LobbyEvent
+
+	principal *entity.RoomPrincipal
+	events    chan entity.LobbyEvent
+}
+
+// lobbyRoomState メモリ上で管理するルームの状態
+type lobbyRoomState struct {
+	room  *entity.CallRoom
+	rules []*entity.CallRoomAccessRule
+	count int
+}
+
+// visibleTo 主体のロビーに表示されるか（nilは一覧にないルーム）
+func (s *lobbyRoomState) visibleTo(principal *entity.RoomPrincipal) bool {
+	return s != nil && s.room.IsVisibleTo(s.rules, principal)
+}
+
+// lobbyRoom 配信用のルーム情報
+func (s *lobbyRoomState) lobbyRoom() entity.LobbyRoom {
+	return entity.LobbyRoom{Room: s.room, ParticipantCount: s.count}
+}
+
+type lobbyUsecase struct {
+	roomRepo   port.CallRoomRepository
+	accessRepo port.CallRoomAccessRepository
+	userRepo   port.UserRepository
+
+	mu sync.Mutex
+	// 初回の購読時にDBから読み込むまではイベントを無視する
+	loaded      bool
+	rooms       map[string]*lobbyRoomState
+	subscribers map[*LobbySubscription]struct{}
+}
+
+// NewLobbyUsecase 新しいロビーユースケースを作成
+func NewLobbyUsecase(roomRepo port.CallRoomRepository, accessRepo port.CallRoomAccessRepository, userRepo port.UserRepository) LobbyUsecase {
+	return &lobbyUsecase{
+		roomRepo:    roomRepo,
+		accessRepo:  accessRepo,
+		userRepo:    userRepo,
+		rooms:       make(map[string]*lobbyRoomState),
+		subscribers: make(map[*LobbySubscription]struct{}),
+	}
+}
+
+// Publish 通話のイベントをロビーの状態に反映して配信
+func (u *lobbyUsecase) Publish(ctx context.Context, ownerID int64, event entity.WebhookEvent, data interface{}) {
+	switch d := data.(type) {
+	case participantEventData:
+		delta := 1
+		if event == entity.WebhookEventParticipantLeft {
+			delta = -1
+		}
+		u.adjustCount(d.RoomID, delta)
+	case roomEventData:
+		if event == entity.WebhookEventRoomEnded {
+			u.mu.Lock()
+			u.apply(d.RoomID, nil, entity.LobbyEventRoomEnded)
+			u.mu.Unlock()
+			return
+		}
+		u.reloadRoom(ctx, d.RoomID)
+	}
+}
+
+// adjustCount 参加人数を増減して配信
+func (u *lobbyUsecase) adjustCount(roomID string, delta int) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	current, ok := u.rooms[roomID]
+	if !ok {
+		// 一覧に載っていないルーム（未読み込み・終了済み）は次の再同期で補正する
+		return
+	}
+	count := current.count + delta
+	if count < 0 {
+		count = 0
+	}
+	u.apply(roomID, &lobbyRoomState{room: current.room, rules: current.rules, count: count}, entity.LobbyEventParticipantCount)
+}
+
+// reloadRoom ルームと許可リストを読み直して配信
+func (u *lobbyUsecase) reloadRoom(ctx context.Context, roomID string) {
+	u.mu.Lock()
+	loaded := u.loaded
+	u.mu.Unlock()
+	if !loaded {
+		return
+	}
+
+	room, err := u.roomRepo.FindByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Error("Failed to load room for lobby", slog.String("room_id", roomID), slog.String("error", err.Error()))
+		return
+	}
+	rules, err := u.accessRepo.FindByRoomID(ctx, room.ID)
+	if err != nil {
+		slog.Error("Failed to load access rules for lobby", slog.String("room_id", roomID), slog.String("error", err.Error()))
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if room.Status == entity.CallRoomStatusEnded {
+		u.apply(roomID, nil, entity.LobbyEventRoomEnded)
+		return
+	}
+	count := 0
+	if current, ok := u.rooms[roomID]; ok {
+		count = current.count
+	}
+	u.apply(roomID, &lobbyRoomState{room: copyRoom(room), rules: rules, count: count}, entity.LobbyEventRoomUpdated)
+}
+
+// Subscribe 主体に表示可能なルーム一覧を返し、以降の変更を購読する
+func (u *lobbyUsecase) Subscribe(ctx context.Context, principal *entity.RoomPrincipal) ([]*entity.LobbyRoom, *LobbySubscription, error) {
+	u.mu.Lock()
+	loaded := u.loaded
+	u.mu.Unlock()
+	if !loaded {
+		if err := u.Resync(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	principal = withVerifiedEmail(ctx, u.userRepo, principal)
+	events := make(chan entity.LobbyEvent, lobbySubscriberBuffer)
+	sub := &LobbySubscription{Events: events, principal: principal, events: events}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	rooms := make([]*entity.LobbyRoom, 0, len(u.rooms))
+	for _, state := range u.rooms {
+		if state.visibleTo(principal) {
+			lobbyRoom := state.lobbyRoom()
+			rooms = append(rooms, &lobbyRoom)
+		}
+	}
+	// REST一覧と同じく作成日時の新しい順
+	sort.Slice(rooms, func(i, j int) bool {
+		if !rooms[i].Room.CreatedAt.Equal(rooms[j].Room.CreatedAt) {
+			return rooms[i].Room.CreatedAt.After(rooms[j].Room.CreatedAt)
+		}
+		return rooms[i].Room.ID > rooms[j].Room.ID
+	})
+
+	u.subscribers[sub] = struct{}{}
+	return rooms, sub, nil
+}
+
+// Unsubscribe 購読を解除する
+func (u *lobbyUsecase) Unsubscribe(sub *LobbySubscription) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.drop(sub)
+}
+
+// Resync DBの状態を読み直し、メモリ上の状態とのずれを購読者へ配信する
+func (u *lobbyUsecase) Resync(ctx context.Context) error {
+	rooms, err := u.roomRepo.FindLobbyRooms(ctx)
+	if err != nil {
+		return err
+	}
+	rulesByRoom, err := findRulesByRoom(ctx, u.accessRepo, rooms)
+	if err != nil {
+		return err
+	}
+
+	next := make(map[string]*lobbyRoomState, len(rooms))
+	for _, lobbyRoom := range rooms {
+		next[lobbyRoom.Room.RoomID] = &lobbyRoomState{
+			room:  copyRoom(lobbyRoom.Room),
+			rules: rulesByRoom[lobbyRoom.Room.ID],
+			count: lobbyRoom.ParticipantCount,
+		}
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for roomID := range u.rooms {
+		if _, ok := next[roomID]; !ok {
+			u.apply(roomID, nil, entity.LobbyEventRoomEnded)
+		}
+	}
+	for roomID, state := range next {
+		current, ok := u.rooms[roomID]
+		switch {
+		case !ok:
+			u.apply(roomID, state, entity.LobbyEventRoomCreated)
+		case !reflect.DeepEqual(current.room, state.room) || !reflect.DeepEqual(current.rules, state.rules):
+			u.apply(roomID, state, entity.LobbyEventRoomUpdated)
+		case current.count != state.count:
+			u.apply(roomID, state, entity.LobbyEventParticipantCount)
+		}
+	}
+	u.loaded = true
+	return nil
+}
+
+// apply ルームの状態を置き換えて購読者へ配信（nextがnilなら一覧から外す）
+// 表示可否が変わった購読者にはroom-created/room-endedとして配信する。呼び出し側でロックを取ること
+func (u *lobbyUsecase) apply(roomID string, next *lobbyRoomState, changed entity.LobbyEventType) {
+	prev := u.rooms[roomID]
+	if prev == nil && next == nil {
+		return
+	}
+	if next == nil {
+		delete(u.rooms, roomID)
+	} else {
+		u.rooms[roomID] = next
+	}
+
+	for sub := range u.subscribers {
+		before, after := prev.visibleTo(sub.principal), next.visibleTo(sub.principal)
+		var event entity.LobbyEvent
+		switch {
+		case !before && after:
+			event = entity.LobbyEvent{Type: entity.LobbyEventRoomCreated, Room: next.lobbyRoom()}
+		case before && !after:
+			event = entity.LobbyEvent{Type: entity.LobbyEventRoomEnded, Room: prev.lobbyRoom()}
+		case before && after:
+			event = entity.LobbyEvent{Type: changed, Room: next.lobbyRoom()}
+		default:
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+			// 受信が追いつかない購読者は打ち切り、再接続時のスナップショットで追いつかせる
+			slog.Warn("Dropping slow lobby subscriber")
+			u.drop(sub)
+		}
+	}
+}
+
+// copyRoom 呼び出し側での変更が配信前の状態に混ざらないようルームを複製
+func copyRoom(room *entity.CallRoom) *entity.CallRoom {
+	copied := *room
+	return &copied
+}
+
+// drop 購読者を外してチャネルを閉じる。呼び出し側でロックを取ること
+func (u *lobbyUsecase) drop(sub *LobbySubscription) {
+	if _, ok := u.subscribers[sub]; !ok {
+		return
+	}
+	delete(u.subscribers, sub)
+	close(sub.events)
+}
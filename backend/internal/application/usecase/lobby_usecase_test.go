@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// newLobbyTestUsecase ロビーへイベントを配信する通話ユースケースを作成
+func newLobbyTestUsecase() (LobbyUsecase, CallUsecase, *testutil.MockCallRoomRepository, *testutil.MockCallParticipantRepository) {
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	roomRepo.Participants = participantRepo
+	accessRepo := testutil.NewMockCallRoomAccessRepository()
+	userRepo := testutil.NewMockUserRepository()
+	lobby := NewLobbyUsecase(roomRepo, accessRepo, userRepo)
+	call := NewCallUsecase(roomRepo, participantRepo, accessRepo, userRepo, nil, NewEventPublishers(testutil.NewMockEventPublisher(), lobby))
+	return lobby, call, roomRepo, participantRepo
+}
+
+// receiveLobbyEvent 溜まっているイベントを1件取り出す
+func receiveLobbyEvent(t *testing.T, sub *LobbySubscription) entity.LobbyEvent {
+	t.Helper()
+	select {
+	case event, ok := <-sub.Events:
+		if !ok {
+			t.Fatal("subscription closed unexpectedly")
+		}
+		return event
+	default:
+		t.Fatal("expected lobby event, got none")
+	}
+	return entity.LobbyEvent{}
+}
+
+// assertNoLobbyEvent イベントが溜まっていないことを確認
+func assertNoLobbyEvent(t *testing.T, sub *LobbySubscription) {
+	t.Helper()
+	select {
+	case event := <-sub.Events:
+		t.Fatalf("unexpected lobby event %s for %s", event.Type, event.Room.Room.RoomID)
+	default:
+	}
+}
+
+func TestLobbyUsecase_SubscribeSnapshot(t *testing.T) {
+	lobby, call, _, _ := newLobbyTestUsecase()
+	ctx := context.Background()
+
+	public := &entity.CallRoom{RoomID: "public", Name: "Public", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+	private := &entity.CallRoom{RoomID: "private", Name: "Private", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+	if err := call.CreateRoom(ctx, public, nil); err != nil {
+		t.Fatalf("CreateRoom() unexpected error = %v", err)
+	}
+	if err := call.CreateRoom(ctx, private, &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityPrivate}); err != nil {
+		t.Fatalf("CreateRoom() unexpected error = %v", err)
+	}
+	for _, userID := range []int64{1, 2} {
+		if err := call.JoinRoom(ctx, &entity.CallParticipant{RoomID: public.ID, UserID: userID, IsActive: true}); err != nil {
+			t.Fatalf("JoinRoom() unexpected error = %v", err)
+		}
+	}
+
+	// 参加人数はDBから1回で集計される
+	rooms, _, err := lobby.Subscribe(ctx, &entity.RoomPrincipal{UserID: 2})
+	if err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+	if len(rooms) != 1 || rooms[0].Room.RoomID != "public" || rooms[0].ParticipantCount != 2 {
+		t.Fatalf("Subscribe() snapshot = %+v, want public with 2 participants", rooms)
+	}
+
+	rooms, _, err = lobby.Subscribe(ctx, &entity.RoomPrincipal{UserID: 1})
+	if err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+	if len(rooms) != 2 {
+		t.Fatalf("Subscribe() creator snapshot has %d rooms, want 2", len(rooms))
+	}
+}
+
+func TestLobbyUsecase_Events(t *testing.T) {
+	lobby, call, _, _ := newLobbyTestUsecase()
+	ctx := context.Background()
+
+	_, creator, err := lobby.Subscribe(ctx, &entity.RoomPrincipal{UserID: 1})
+	if err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+	_, other, err := lobby.Subscribe(ctx, &entity.RoomPrincipal{UserID: 2})
+	if err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+	_, guest, err := lobby.Subscribe(ctx, &entity.RoomPrincipal{Guest: &entity.GuestPrincipal{RoomID: "another-room"}})
+	if err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Room", CreatedBy: 1, Status: entity.CallRoomStatusWaiting}
+	if err := call.CreateRoom(ctx, room, nil); err != nil {
+		t.Fatalf("CreateRoom() unexpected error = %v", err)
+	}
+	for _, sub := range []*LobbySubscription{creator, other} {
+		if event := receiveLobbyEvent(t, sub); event.Type != entity.LobbyEventRoomCreated || event.Room.Room.RoomID != "room-1" {
+			t.Errorf("event = %s %s, want room-created room-1", event.Type, event.Room.Room.RoomID)
+		}
+	}
+
+	if err := call.JoinRoom(ctx, &entity.CallParticipant{RoomID: room.ID, UserID: 2, IsActive: true}); err != nil {
+		t.Fatalf("JoinRoom() unexpected error = %v", err)
+	}
+	if event := receiveLobbyEvent(t, other); event.Type != entity.LobbyEventParticipantCount || event.Room.ParticipantCount != 1 {
+		t.Errorf("event = %s count %d, want participant-count 1", event.Type, event.Room.ParticipantCount)
+	}
+	receiveLobbyEvent(t, creator)
+
+	if err := call.StartRoom(ctx, room); err != nil {
+		t.Fatalf("StartRoom() unexpected error = %v", err)
+	}
+	if event := receiveLobbyEvent(t, other); event.Type != entity.LobbyEventRoomUpdated || event.Room.ParticipantCount != 1 {
+		t.Errorf("event = %s count %d, want room-updated keeping count 1", event.Type, event.Room.ParticipantCount)
+	}
+	receiveLobbyEvent(t, creator)
+
+	// 非公開になったルームは作成者以外の一覧から外れる
+	if err := call.UpdateRoomAccess(ctx, room, &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityPrivate}); err != nil {
+		t.Fatalf("UpdateRoomAccess() unexpected error = %v", err)
+	}
+	if event := receiveLobbyEvent(t, creator); event.Type != entity.LobbyEventRoomUpdated {
+		t.Errorf("creator event = %s, want room-updated", event.Type)
+	}
+	if event := receiveLobbyEvent(t, other); event.Type != entity.LobbyEventRoomEnded {
+		t.Errorf("other event = %s, want room-ended", event.Type)
+	}
+
+	// 一覧に表示されていない購読者には配信しない
+	if err := call.LeaveRoom(ctx, room.ID, 2); err != nil {
+		t.Fatalf("LeaveRoom() unexpected error = %v", err)
+	}
+	if event := receiveLobbyEvent(t, creator); event.Type != entity.LobbyEventParticipantCount || event.Room.ParticipantCount != 0 {
+		t.Errorf("creator event = %s count %d, want participant-count 0", event.Type, event.Room.ParticipantCount)
+	}
+	assertNoLobbyEvent(t, other)
+
+	if err := call.EndRoom(ctx, room); err != nil {
+		t.Fatalf("EndRoom() unexpected error = %v", err)
+	}
+	if event := receiveLobbyEvent(t, creator); event.Type != entity.LobbyEventRoomEnded {
+		t.Errorf("creator event = %s, want room-ended", event.Type)
+	}
+	assertNoLobbyEvent(t, creator)
+	assertNoLobbyEvent(t, other)
+	assertNoLobbyEvent(t, guest)
+}
+
+func TestLobbyUsecase_Resync(t *testing.T) {
+	lobby, call, roomRepo, participantRepo := newLobbyTestUsecase()
+	ctx := context.Background()
+
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Room", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+	if err := call.CreateRoom(ctx, room, nil); err != nil {
+		t.Fatalf("CreateRoom() unexpected error = %v", err)
+	}
+	_, sub, err := lobby.Subscribe(ctx, &entity.RoomPrincipal{UserID: 2})
+	if err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+
+	// イベントを経由しない変更（他のレプリカでの参加など）
+	participantRepo.Create(ctx, &entity.CallParticipant{RoomID: room.ID, UserID: 3, IsActive: true})
+	other := &entity.CallRoom{RoomID: "room-2", Name: "Other", CreatedBy: 3, Status: entity.CallRoomStatusWaiting, Visibility: entity.RoomVisibilityPublic}
+	roomRepo.Create(ctx, other)
+
+	if err := lobby.Resync(ctx); err != nil {
+		t.Fatalf("Resync() unexpected error = %v", err)
+	}
+	got := map[string]entity.LobbyEvent{}
+	for i := 0; i < 2; i++ {
+		event := receiveLobbyEvent(t, sub)
+		got[event.Room.Room.RoomID] = event
+	}
+	if event := got["room-1"]; event.Type != entity.LobbyEventParticipantCount || event.Room.ParticipantCount != 1 {
+		t.Errorf("room-1 event = %s count %d, want participant-count 1", event.Type, event.Room.ParticipantCount)
+	}
+	if event := got["room-2"]; event.Type != entity.LobbyEventRoomCreated {
+		t.Errorf("room-2 event = %s, want room-created", event.Type)
+	}
+
+	// 変化がなければ何も配信しない
+	if err := lobby.Resync(ctx); err != nil {
+		t.Fatalf("Resync() unexpected error = %v", err)
+	}
+	assertNoLobbyEvent(t, sub)
+
+	other.Status = entity.CallRoomStatusEnded
+	if err := lobby.Resync(ctx); err != nil {
+		t.Fatalf("Resync() unexpected error = %v", err)
+	}
+	if event := receiveLobbyEvent(t, sub); event.Type != entity.LobbyEventRoomEnded || event.Room.Room.RoomID != "room-2" {
+		t.Errorf("event = %s %s, want room-ended room-2", event.Type, event.Room.Room.RoomID)
+	}
+}
+
+func TestLobbyUsecase_SlowSubscriber(t *testing.T) {
+	lobby, call, _, _ := newLobbyTestUsecase()
+	ctx := context.Background()
+
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Room", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+	if err := call.CreateRoom(ctx, room, nil); err != nil {
+		t.Fatalf("CreateRoom() unexpected error = %v", err)
+	}
+	_, sub, err := lobby.Subscribe(ctx, &entity.RoomPrincipal{UserID: 2})
+	if err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+
+	// 受信しないまま溢れたら購読が閉じられる
+	for i := 0; i <= lobbySubscriberBuffer; i++ {
+		lobby.Publish(ctx, 1, entity.WebhookEventParticipantJoined, participantEventData{RoomID: "room-1"})
+	}
+	received := 0
+	for range sub.Events {
+		received++
+	}
+	if received != lobbySubscriberBuffer {
+		t.Errorf("received %d events before close, want %d", received, lobbySubscriberBuffer)
+	}
+
+	// 閉じられた購読の解除は何もしない
+	lobby.Unsubscribe(sub)
+}
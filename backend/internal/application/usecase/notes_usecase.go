@@ -0,0 +1,296 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/crdt"
+)
+
+// 1回の同期で適用できる操作数の上限
+const maxNotesOpsPerApply = 10000
+
+// 編集のない共有ノートをメモリから解放するまでの時間
+const notesIdleTimeout = 10 * time.Minute
+
+// NotesUsecase 通話ルームの共有ノートユースケースのインターフェース
+// 通話中のノートはサーバーのメモリ上のCRDT文書を正とし、定期的にスナップショットを保存する
+// 通話の終了時に最終版を保存し、議事録に記録する
+type NotesUsecase interface {
+	EventPublisher
+	// 共有ノートの要素列（削除済みを含む）を取得（編集を始める前の同期に使う）
+	Sync(ctx context.Context, roomID string) ([]crdt.Element, error)
+	// 参加者の操作を順に適用し、新たに適用された操作を返す
+	// siteは参加者の識別子で、挿入する要素のIDのサイトと一致する必要がある
+	Apply(ctx context.Context, roomID string, site string, ops []crdt.Op) ([]crdt.Op, error)
+	// 共有ノートの現在の内容を取得（まだ作成されていない場合は空のノート）
+	GetNotes(ctx context.Context, room *entity.CallRoom) (*entity.CallNotes, error)
+	// 変更のあった共有ノートのスナップショットを保存し、保存した数を返す
+	SaveSnapshots(ctx context.Context) int
+}
+
+// liveNotes 編集中の共有ノート
+type liveNotes struct {
+	mu      sync.Mutex
+	roomID  int64
+	doc     *crdt.Document
+	version int64
+	dirty   bool      // 最後のスナップショットの後に変更がある
+	closed  bool      // 通話の終了などでメモリから解放され、編集を受け付けない
+	touched time.Time // 最後に同期・編集された日時
+}
+
+type notesUsecase struct {
+	notesRepo   port.CallNotesRepository
+	roomRepo    port.CallRoomRepository
+	minutesRepo port.CallMinutesRepository
+
+	mu   sync.Mutex
+	docs map[string]*liveNotes // 公開ルームIDごと
+}
+
+// NewNotesUsecase 新しい共有ノートユースケースを作成
+func NewNotesUsecase(
+	notesRepo port.CallNotesRepository,
+	roomRepo port.CallRoomRepository,
+	minutesRepo port.CallMinutesRepository,
+) NotesUsecase {
+	return &notesUsecase{
+		notesRepo:   notesRepo,
+		roomRepo:    roomRepo,
+		minutesRepo: minutesRepo,
+		docs:        make(map[string]*liveNotes),
+	}
+}
+
+// Publish 通話の終了時に共有ノートの最終版を保存し、議事録が作成済みであれば記録する
+func (u *notesUsecase) Publish(ctx context.Context, ownerID int64, event entity.WebhookEvent, data interface{}) {
+	d, ok := data.(roomEventData)
+	if !ok || event != entity.WebhookEventRoomEnded {
+		return
+	}
+
+	u.mu.Lock()
+	notes := u.docs[d.RoomID]
+	delete(u.docs, d.RoomID)
+	u.mu.Unlock()
+	if notes == nil {
+		return
+	}
+
+	notes.mu.Lock()
+	notes.closed = true
+	var err error
+	if notes.dirty {
+		err = u.save(ctx, notes)
+	}
+	content := notes.doc.Text()
+	notes.mu.Unlock()
+	if err != nil {
+		slog.Error("Failed to save final notes", slog.String("room_id", d.RoomID), slog.String("error", err.Error()))
+	}
+
+	// 通話中に議事録を作成していた場合は最終版で更新
+	minutes, err := u.minutesRepo.FindByRoomID(ctx, notes.roomID)
+	if err != nil {
+		return
+	}
+	if content == "" {
+		minutes.Notes = nil
+	} else {
+		minutes.Notes = &content
+	}
+	if err := u.minutesRepo.Update(ctx, minutes); err != nil {
+		slog.Error("Failed to update minutes notes", slog.Int64("room_id", notes.roomID), slog.String("error", err.Error()))
+	}
+}
+
+// Sync 共有ノートの要素列を取得
+func (u *notesUsecase) Sync(ctx context.Context, roomID string) ([]crdt.Element, error) {
+	notes, err := u.acquire(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer notes.mu.Unlock()
+
+	notes.touched = time.Now()
+	return notes.doc.Elements(), nil
+}
+
+// Apply 参加者の操作を適用
+// 途中で不正な操作があった場合は、それまでに適用した操作とエラーを返す
+func (u *notesUsecase) Apply(ctx context.Context, roomID string, site string, ops []crdt.Op) ([]crdt.Op, error) {
+	if len(ops) > maxNotesOpsPerApply {
+		return nil, crdt.ErrInvalidOp
+	}
+	notes, err := u.acquire(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer notes.mu.Unlock()
+
+	applied := make([]crdt.Op, 0, len(ops))
+	for _, op := range ops {
+		// 他の参加者になりすました挿入は受け付けない
+		if op.Type == crdt.OpInsert && op.ID.Site != site {
+			err = crdt.ErrInvalidOp
+			break
+		}
+		ok, applyErr := notes.doc.Apply(op)
+		if applyErr != nil {
+			err = applyErr
+			break
+		}
+		if ok {
+			applied = append(applied, op)
+		}
+	}
+	if len(applied) > 0 {
+		notes.dirty = true
+	}
+	notes.touched = time.Now()
+	return applied, err
+}
+
+// GetNotes 共有ノートの現在の内容を取得
+func (u *notesUsecase) GetNotes(ctx context.Context, room *entity.CallRoom) (*entity.CallNotes, error) {
+	u.mu.Lock()
+	live := u.docs[room.RoomID]
+	u.mu.Unlock()
+	if live != nil {
+		live.mu.Lock()
+		defer live.mu.Unlock()
+		return &entity.CallNotes{
+			RoomID:    live.roomID,
+			Content:   live.doc.Text(),
+			Version:   live.version,
+			UpdatedAt: live.touched,
+		}, nil
+	}
+
+	notes, err := u.notesRepo.FindByRoomID(ctx, room.ID)
+	if errors.Is(err, entity.ErrCallNotesNotFound) {
+		return &entity.CallNotes{RoomID: room.ID}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+	notes.State = nil
+	return notes, nil
+}
+
+// SaveSnapshots 変更のあった共有ノートを保存し、しばらく編集のないノートをメモリから解放
+func (u *notesUsecase) SaveSnapshots(ctx context.Context) int {
+	u.mu.Lock()
+	rooms := make(map[string]*liveNotes, len(u.docs))
+	for roomID, notes := range u.docs {
+		rooms[roomID] = notes
+	}
+	u.mu.Unlock()
+
+	saved := 0
+	now := time.Now()
+	for roomID, notes := range rooms {
+		notes.mu.Lock()
+		if notes.dirty {
+			if err := u.save(ctx, notes); err != nil {
+				slog.Error("Failed to save notes snapshot", slog.String("room_id", roomID), slog.String("error", err.Error()))
+			} else {
+				saved++
+			}
+		}
+		notes.mu.Unlock()
+
+		u.mu.Lock()
+		notes.mu.Lock()
+		if u.docs[roomID] == notes && !notes.dirty && now.Sub(notes.touched) > notesIdleTimeout {
+			delete(u.docs, roomID)
+			notes.closed = true
+		}
+		notes.mu.Unlock()
+		u.mu.Unlock()
+	}
+	return saved
+}
+
+// acquire 編集中の共有ノートをロックして取得（呼び出し側でnotes.muを解放する）
+func (u *notesUsecase) acquire(ctx context.Context, roomID string) (*liveNotes, error) {
+	for {
+		notes, err := u.open(ctx, roomID)
+		if err != nil {
+			return nil, err
+		}
+		notes.mu.Lock()
+		if !notes.closed {
+			return notes, nil
+		}
+		// 取得した直後に解放された場合は開き直す（通話が終了していればopenがエラーを返す）
+		notes.mu.Unlock()
+	}
+}
+
+// open 編集中の共有ノートを取得（メモリにない場合は保存済みのスナップショットから復元）
+func (u *notesUsecase) open(ctx context.Context, roomID string) (*liveNotes, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if notes, ok := u.docs[roomID]; ok {
+		return notes, nil
+	}
+
+	room, err := u.roomRepo.FindByRoomID(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.Status == entity.CallRoomStatusEnded {
+		return nil, entity.ErrRoomEnded
+	}
+
+	notes := &liveNotes{roomID: room.ID, doc: crdt.New(), touched: time.Now()}
+	saved, err := u.notesRepo.FindByRoomID(ctx, room.ID)
+	switch {
+	case err == nil:
+		var elements []crdt.Element
+		if err := json.Unmarshal(saved.State, &elements); err != nil {
+			return nil, fmt.Errorf("failed to decode notes: %w", err)
+		}
+		doc, err := crdt.Restore(elements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to restore notes: %w", err)
+		}
+		notes.doc = doc
+		notes.version = saved.Version
+	case !errors.Is(err, entity.ErrCallNotesNotFound):
+		return nil, fmt.Errorf("failed to get notes: %w", err)
+	}
+
+	u.docs[roomID] = notes
+	return notes, nil
+}
+
+// save スナップショットを保存（notes.muを保持して呼び出す）
+func (u *notesUsecase) save(ctx context.Context, notes *liveNotes) error {
+	state, err := json.Marshal(notes.doc.Elements())
+	if err != nil {
+		return err
+	}
+	snapshot := &entity.CallNotes{
+		RoomID:    notes.roomID,
+		Content:   notes.doc.Text(),
+		State:     state,
+		Version:   notes.version + 1,
+		UpdatedAt: time.Now(),
+	}
+	if err := u.notesRepo.Save(ctx, snapshot); err != nil {
+		return err
+	}
+	notes.version = snapshot.Version
+	notes.dirty = false
+	return nil
+}
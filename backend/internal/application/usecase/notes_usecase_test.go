@@ -0,0 +1,238 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/pkg/crdt"
+)
+
+// applyText クライアントと同じ手順で挿入の操作を作って適用
+func applyText(t *testing.T, usecase NotesUsecase, roomID string, client *crdt.Document, site string, pos int, text string) {
+	t.Helper()
+	ops, err := client.InsertText(site, pos, text)
+	if err != nil {
+		t.Fatalf("InsertText() unexpected error = %v", err)
+	}
+	if _, err := usecase.Apply(context.Background(), roomID, site, ops); err != nil {
+		t.Fatalf("Apply() unexpected error = %v", err)
+	}
+}
+
+func TestNotesUsecase_Apply(t *testing.T) {
+	tests := []struct {
+		name        string
+		roomID      string
+		site        string
+		ops         func(client *crdt.Document) []crdt.Op
+		wantApplied int
+		wantContent string
+		wantErr     bool
+		expectedErr error
+	}{
+		{
+			name: "insert from own site",
+			site: "user-2",
+			ops: func(client *crdt.Document) []crdt.Op {
+				ops, _ := client.InsertText("user-2", 2, ": 予算")
+				return ops
+			},
+			wantApplied: 4,
+			wantContent: "議題: 予算",
+		},
+		{
+			name: "already applied",
+			site: "user-1",
+			ops: func(client *crdt.Document) []crdt.Op {
+				return []crdt.Op{{Type: crdt.OpInsert, ID: client.Elements()[0].ID, Char: "議"}}
+			},
+			wantContent: "議題",
+		},
+		{
+			name: "insert impersonating another site",
+			site: "guest-1",
+			ops: func(client *crdt.Document) []crdt.Op {
+				ops, _ := client.InsertText("user-1", 0, "x")
+				return ops
+			},
+			wantContent: "議題",
+			expectedErr: crdt.ErrInvalidOp,
+		},
+		{
+			name: "unknown reference",
+			site: "user-2",
+			ops: func(client *crdt.Document) []crdt.Op {
+				return []crdt.Op{{Type: crdt.OpInsert, ID: crdt.ID{Clock: 99, Site: "user-2"}, After: crdt.ID{Clock: 98, Site: "user-3"}, Char: "x"}}
+			},
+			wantContent: "議題",
+			expectedErr: crdt.ErrUnknownElement,
+		},
+		{
+			name:        "missing room",
+			roomID:      "missing",
+			site:        "user-1",
+			ops:         func(client *crdt.Document) []crdt.Op { return nil },
+			wantContent: "議題",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			usecase := NewNotesUsecase(testutil.NewMockCallNotesRepository(), roomRepo, testutil.NewMockCallMinutesRepository())
+			room := &entity.CallRoom{RoomID: "room-1", Name: "Weekly", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+			roomRepo.Create(ctx, room)
+			client := crdt.New()
+			applyText(t, usecase, room.RoomID, client, "user-1", 0, "議題")
+			roomID := room.RoomID
+			if tt.roomID != "" {
+				roomID = tt.roomID
+			}
+
+			// Act
+			applied, err := usecase.Apply(ctx, roomID, tt.site, tt.ops(client))
+
+			// Assert
+			if tt.wantErr && err == nil {
+				t.Error("Apply() error = nil, wantErr true")
+			}
+			if !tt.wantErr && !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Apply() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if len(applied) != tt.wantApplied {
+				t.Errorf("Apply() applied %d ops, want %d", len(applied), tt.wantApplied)
+			}
+			notes, err := usecase.GetNotes(ctx, room)
+			if err != nil {
+				t.Fatalf("GetNotes() unexpected error = %v", err)
+			}
+			if notes.Content != tt.wantContent {
+				t.Errorf("Content = %q, want %q", notes.Content, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestNotesUsecase_SaveSnapshots(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	notesRepo := testutil.NewMockCallNotesRepository()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	minutesRepo := testutil.NewMockCallMinutesRepository()
+	usecase := NewNotesUsecase(notesRepo, roomRepo, minutesRepo)
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Weekly", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+	roomRepo.Create(ctx, room)
+	applyText(t, usecase, room.RoomID, crdt.New(), "user-1", 0, "Action items")
+
+	// Act
+	saved := usecase.SaveSnapshots(ctx)
+	unchanged := usecase.SaveSnapshots(ctx)
+
+	// Assert
+	if saved != 1 {
+		t.Fatalf("SaveSnapshots() = %d, want 1", saved)
+	}
+	// 変更がなければ保存しない
+	if unchanged != 0 {
+		t.Errorf("SaveSnapshots() without changes = %d, want 0", unchanged)
+	}
+	stored := notesRepo.Notes[room.ID]
+	if stored == nil || stored.Content != "Action items" || stored.Version != 1 {
+		t.Fatalf("stored notes = %+v, want version 1 with content", stored)
+	}
+}
+
+func TestNotesUsecase_RestoreFromSnapshot(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	notesRepo := testutil.NewMockCallNotesRepository()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	minutesRepo := testutil.NewMockCallMinutesRepository()
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Weekly", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+	roomRepo.Create(ctx, room)
+	before := NewNotesUsecase(notesRepo, roomRepo, minutesRepo)
+	applyText(t, before, room.RoomID, crdt.New(), "user-1", 0, "Action items")
+	before.SaveSnapshots(ctx)
+
+	// Act（別のサーバー・再起動後でもスナップショットから編集を再開できる）
+	usecase := NewNotesUsecase(notesRepo, roomRepo, minutesRepo)
+	elements, err := usecase.Sync(ctx, room.RoomID)
+	if err != nil {
+		t.Fatalf("Sync() unexpected error = %v", err)
+	}
+	resumed, err := crdt.Restore(elements)
+	if err != nil {
+		t.Fatalf("Restore() unexpected error = %v", err)
+	}
+	applyText(t, usecase, room.RoomID, resumed, "user-2", 0, "- ")
+	usecase.SaveSnapshots(ctx)
+
+	// Assert
+	if stored := notesRepo.Notes[room.ID]; stored.Content != "- Action items" || stored.Version != 2 {
+		t.Errorf("stored notes = %q (version %d), want %q (version 2)", stored.Content, stored.Version, "- Action items")
+	}
+}
+
+func TestNotesUsecase_RoomEnded(t *testing.T) {
+	tests := []struct {
+		name       string
+		hasMinutes bool
+	}{
+		{name: "without minutes"},
+		{name: "minutes created during the call", hasMinutes: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			notesRepo := testutil.NewMockCallNotesRepository()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			minutesRepo := testutil.NewMockCallMinutesRepository()
+			usecase := NewNotesUsecase(notesRepo, roomRepo, minutesRepo)
+			room := &entity.CallRoom{RoomID: "room-1", Name: "Weekly", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+			roomRepo.Create(ctx, room)
+			client := crdt.New()
+			applyText(t, usecase, room.RoomID, client, "user-1", 0, "Decided: ship on Friday")
+			if tt.hasMinutes {
+				minutesRepo.Create(ctx, &entity.CallMinutes{RoomID: room.ID, Title: "Weekly"})
+			}
+
+			// Act
+			room.Status = entity.CallRoomStatusEnded
+			usecase.Publish(ctx, room.CreatedBy, entity.WebhookEventRoomEnded, roomEventData{RoomID: room.RoomID})
+
+			// Assert
+			stored := notesRepo.Notes[room.ID]
+			if stored == nil || stored.Content != "Decided: ship on Friday" {
+				t.Fatalf("final notes were not saved: %+v", stored)
+			}
+			// 通話中に議事録を作成していた場合も最終版が記録される
+			if tt.hasMinutes {
+				minutes := minutesRepo.Minutes[room.ID]
+				if minutes.Notes == nil || *minutes.Notes != "Decided: ship on Friday" {
+					t.Errorf("minutes.Notes = %v, want the final notes", minutes.Notes)
+				}
+			}
+
+			// 終了後は編集できず、保存済みの内容を参照できる
+			ops, _ := client.InsertText("user-1", 0, "x")
+			if _, err := usecase.Apply(ctx, room.RoomID, "user-1", ops); !errors.Is(err, entity.ErrRoomEnded) {
+				t.Errorf("Apply() after end error = %v, want ErrRoomEnded", err)
+			}
+			if _, err := usecase.Sync(ctx, room.RoomID); !errors.Is(err, entity.ErrRoomEnded) {
+				t.Errorf("Sync() after end error = %v, want ErrRoomEnded", err)
+			}
+			notes, err := usecase.GetNotes(ctx, room)
+			if err != nil || notes.Content != "Decided: ship on Friday" {
+				t.Errorf("GetNotes() = %+v, %v, want the final notes", notes, err)
+			}
+		})
+	}
+}
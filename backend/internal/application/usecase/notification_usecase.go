@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// 通知一覧の取得件数
+const (
+	defaultNotificationLimit = 20
+	maxNotificationLimit     = 100
+)
+
+// pushNotificationOptions アプリを開いていないユーザーへWeb Pushでも届ける通知の種類と配信方法
+var pushNotificationOptions = map[entity.NotificationType]entity.PushOptions{
+	entity.NotificationMinutesReady: {TTL: 24 * time.Hour, Urgency: entity.PushUrgencyNormal},
+}
+
+// NotificationUsecase 通知ユースケースのインターフェース
+// 通知を保存してユーザーの全端末へ配信する。議事録の作成完了はイベントから通知を作る
+type NotificationUsecase interface {
+	EventPublisher
+	// 通知を保存し、接続中の端末へ配信
+	Notify(ctx context.Context, notification *entity.Notification) error
+	// 通知一覧と未読件数を取得（新しい順）
+	List(ctx context.Context, filter *entity.NotificationFilter) ([]*entity.Notification, int, error)
+	// 通知を既読にする
+	MarkRead(ctx context.Context, userID, notificationID int64) error
+	// 未読の通知をすべて既読にする
+	MarkAllRead(ctx context.Context, userID int64) error
+}
+
+// notificationEventData notification イベントのデータ
+type notificationEventData struct {
+	ID        int64                  `json:"id"`
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title"`
+	Body      string                 `json:"body"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// notificationsReadEventData notifications-read イベントのデータ
+type notificationsReadEventData struct {
+	ID  int64 `json:"id,omitempty"`  // 既読にした通知（allの場合は0）
+	All bool  `json:"all,omitempty"` // すべて既読にした
+}
+
+type notificationUsecase struct {
+	notificationRepo port.NotificationRepository
+	roomRepo         port.CallRoomRepository
+	participantRepo  port.CallParticipantRepository
+	hub              UserEventHub
+	push             PushUsecase
+}
+
+// NewNotificationUsecase 新しい通知ユースケースを作成
+func NewNotificationUsecase(
+	notificationRepo port.NotificationRepository,
+	roomRepo port.CallRoomRepository,
+	participantRepo port.CallParticipantRepository,
+	hub UserEventHub,
+	push PushUsecase,
+) NotificationUsecase {
+	return &notificationUsecase{
+		notificationRepo: notificationRepo,
+		roomRepo:         roomRepo,
+		participantRepo:  participantRepo,
+		hub:              hub,
+		push:             push,
+	}
+}
+
+// Publish 議事録の作成完了を、ルームの作成者と参加したユーザーへ通知
+// 文字起こしの再実行で作り直した議事録は通知しない
+func (u *notificationUsecase) Publish(ctx context.Context, ownerID int64, event entity.WebhookEvent, data interface{}) {
+	d, ok := data.(minutesEventData)
+	if !ok || event != entity.WebhookEventMinutesReady || d.Regenerated {
+		return
+	}
+
+	room, err := u.roomRepo.FindByRoomID(ctx, d.RoomID)
+	if err != nil {
+		slog.Error("Failed to load room for notification", slog.String("room_id", d.RoomID), slog.String("error", err.Error()))
+		return
+	}
+	participants, err := u.participantRepo.FindByRoomID(ctx, room.ID)
+	if err != nil {
+		slog.Error("Failed to load participants for notification", slog.String("room_id", d.RoomID), slog.String("error", err.Error()))
+		return
+	}
+
+	// 作成者を先頭に、参加したユーザーへ1件ずつ（ゲストと再入室の重複は除く）
+	userIDs := []int64{ownerID}
+	seen := map[int64]bool{ownerID: true}
+	for _, participant := range participants {
+		if participant.IsGuest() || seen[participant.UserID] {
+			continue
+		}
+		seen[participant.UserID] = true
+		userIDs = append(userIDs, participant.UserID)
+	}
+
+	for _, userID := range userIDs {
+		err := u.Notify(ctx, &entity.Notification{
+			UserID: userID,
+			Type:   entity.NotificationMinutesReady,
+			Title:  "Minutes are ready",
+			Body:   d.Title,
+			Data: map[string]interface{}{
+				"room_id":    d.RoomID,
+				"minutes_id": d.MinutesID,
+			},
+		})
+		if err != nil {
+			slog.Error("Failed to notify minutes ready",
+				slog.Int64("user_id", userID),
+				slog.String("room_id", d.RoomID),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+// Notify 通知を保存し、接続中の端末へ配信（種類によってはアプリを開いていない端末へWeb Pushでも送る）
+func (u *notificationUsecase) Notify(ctx context.Context, notification *entity.Notification) error {
+	if notification.CreatedAt.IsZero() {
+		notification.CreatedAt = time.Now()
+	}
+	if err := u.notificationRepo.Create(ctx, notification); err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	u.hub.Send(notification.UserID, entity.UserEvent{
+		Type: entity.UserEventNotification,
+		Data: notificationEventData{
+			ID:        notification.ID,
+			Type:      string(notification.Type),
+			Title:     notification.Title,
+			Body:      notification.Body,
+			Data:      notification.Data,
+			CreatedAt: notification.CreatedAt,
+		},
+	})
+
+	if options, ok := pushNotificationOptions[notification.Type]; ok {
+		data := map[string]interface{}{"notification_id": notification.ID}
+		for key, value := range notification.Data {
+			data[key] = value
+		}
+		u.push.NotifyOffline(ctx, notification.UserID, &entity.PushMessage{
+			Type:    string(notification.Type),
+			Title:   notification.Title,
+			Body:    notification.Body,
+			Data:    data,
+			Options: options,
+		})
+	}
+	return nil
+}
+
+// List 通知一覧と未読件数を取得
+func (u *notificationUsecase) List(ctx context.Context, filter *entity.NotificationFilter) ([]*entity.Notification, int, error) {
+	if filter.Limit <= 0 {
+		filter.Limit = defaultNotificationLimit
+	}
+	if filter.Limit > maxNotificationLimit {
+		filter.Limit = maxNotificationLimit
+	}
+
+	notifications, err := u.notificationRepo.FindByUserID(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	unread, err := u.notificationRepo.CountUnread(ctx, filter.UserID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return notifications, unread, nil
+}
+
+// MarkRead 通知を既読にする（他のユーザーの通知は見つからない扱い）
+func (u *notificationUsecase) MarkRead(ctx context.Context, userID, notificationID int64) error {
+	notification, err := u.notificationRepo.FindByID(ctx, notificationID)
+	if err != nil {
+		return err
+	}
+	if notification.UserID != userID {
+		return entity.ErrNotificationNotFound
+	}
+	if notification.IsRead() {
+		return nil
+	}
+
+	if err := u.notificationRepo.MarkRead(ctx, notificationID, time.Now()); err != nil {
+		return err
+	}
+	u.hub.Send(userID, entity.UserEvent{
+		Type: entity.UserEventNotificationsRead,
+		Data: notificationsReadEventData{ID: notificationID},
+	})
+	return nil
+}
+
+// MarkAllRead 未読の通知をすべて既読にする
+func (u *notificationUsecase) MarkAllRead(ctx context.Context, userID int64) error {
+	count, err := u.notificationRepo.MarkAllRead(ctx, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		u.hub.Send(userID, entity.UserEvent{
+			Type: entity.UserEventNotificationsRead,
+			Data: notificationsReadEventData{All: true},
+		})
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// newNotificationTestUsecase テスト用の通知ユースケースを作成
+func newNotificationTestUsecase() (NotificationUsecase, UserEventHub, *testutil.MockNotificationRepository, *testutil.MockCallRoomRepository, *testutil.MockCallParticipantRepository) {
+	notificationRepo := testutil.NewMockNotificationRepository()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	hub := NewUserEventHub()
+	push, _, _ := newPushTestUsecase(hub)
+	return NewNotificationUsecase(notificationRepo, roomRepo, participantRepo, hub, push), hub, notificationRepo, roomRepo, participantRepo
+}
+
+func TestNotificationUsecase_NotifyAndRead(t *testing.T) {
+	uc, hub, _, _, _ := newNotificationTestUsecase()
+	ctx := context.Background()
+
+	phone, desktop := hub.Subscribe(1), hub.Subscribe(1)
+	for i := 0; i < 3; i++ {
+		if err := uc.Notify(ctx, &entity.Notification{UserID: 1, Type: entity.NotificationMissedCall, Title: "Missed call"}); err != nil {
+			t.Fatalf("Notify() unexpected error = %v", err)
+		}
+	}
+	if err := uc.Notify(ctx, &entity.Notification{UserID: 2, Type: entity.NotificationMissedCall, Title: "Missed call"}); err != nil {
+		t.Fatalf("Notify() unexpected error = %v", err)
+	}
+
+	// 接続中の全端末へ配信される
+	for _, sub := range []*UserEventSubscription{phone, desktop} {
+		for i := 0; i < 3; i++ {
+			if event := receiveUserEvent(t, sub); event.Type != entity.UserEventNotification {
+				t.Fatalf("event = %s, want notification", event.Type)
+			}
+		}
+		assertNoUserEvent(t, sub)
+	}
+
+	// 新しい順にページングできる
+	page, unread, err := uc.List(ctx, &entity.NotificationFilter{UserID: 1, Limit: 2})
+	if err != nil {
+		t.Fatalf("List() unexpected error = %v", err)
+	}
+	if len(page) != 2 || unread != 3 || page[0].ID < page[1].ID {
+		t.Fatalf("List() = %d notifications, %d unread, want newest 2 of 3 unread", len(page), unread)
+	}
+	rest, _, err := uc.List(ctx, &entity.NotificationFilter{UserID: 1, BeforeID: page[1].ID})
+	if err != nil {
+		t.Fatalf("List() unexpected error = %v", err)
+	}
+	if len(rest) != 1 || rest[0].ID >= page[1].ID {
+		t.Fatalf("List(before) = %d notifications, want the oldest one", len(rest))
+	}
+
+	// 他のユーザーの通知は既読にできない
+	if err := uc.MarkRead(ctx, 2, page[0].ID); !errors.Is(err, entity.ErrNotificationNotFound) {
+		t.Errorf("MarkRead(other user) error = %v, want ErrNotificationNotFound", err)
+	}
+	if err := uc.MarkRead(ctx, 1, page[0].ID); err != nil {
+		t.Fatalf("MarkRead() unexpected error = %v", err)
+	}
+	if event := receiveUserEvent(t, desktop); event.Type != entity.UserEventNotificationsRead {
+		t.Errorf("event = %s, want notifications-read", event.Type)
+	}
+	// 既読の通知を再度既読にしても配信しない
+	if err := uc.MarkRead(ctx, 1, page[0].ID); err != nil {
+		t.Fatalf("MarkRead() unexpected error = %v", err)
+	}
+	assertNoUserEvent(t, desktop)
+
+	unreadOnly, unread, err := uc.List(ctx, &entity.NotificationFilter{UserID: 1, UnreadOnly: true})
+	if err != nil {
+		t.Fatalf("List() unexpected error = %v", err)
+	}
+	if len(unreadOnly) != 2 || unread != 2 {
+		t.Errorf("List(unread) = %d notifications, %d unread, want 2", len(unreadOnly), unread)
+	}
+
+	if err := uc.MarkAllRead(ctx, 1); err != nil {
+		t.Fatalf("MarkAllRead() unexpected error = %v", err)
+	}
+	if event := receiveUserEvent(t, desktop); event.Type != entity.UserEventNotificationsRead {
+		t.Errorf("event = %s, want notifications-read", event.Type)
+	}
+	if _, unread, _ := uc.List(ctx, &entity.NotificationFilter{UserID: 1}); unread != 0 {
+		t.Errorf("unread after MarkAllRead() = %d, want 0", unread)
+	}
+	if _, unread, _ := uc.List(ctx, &entity.NotificationFilter{UserID: 2}); unread != 1 {
+		t.Errorf("other user's unread after MarkAllRead() = %d, want 1", unread)
+	}
+}
+
+func TestNotificationUsecase_MinutesReady(t *testing.T) {
+	uc, _, notificationRepo, roomRepo, participantRepo := newNotificationTestUsecase()
+	ctx := context.Background()
+
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Weekly", CreatedBy: 1, Status: entity.CallRoomStatusEnded}
+	roomRepo.Create(ctx, room)
+	guestID := "guest-1"
+	for _, participant := range []*entity.CallParticipant{
+		{RoomID: room.ID, UserID: 1},
+		{RoomID: room.ID, UserID: 2},
+		{RoomID: room.ID, UserID: 2}, // 再入室
+		{RoomID: room.ID, GuestID: &guestID},
+	} {
+		participantRepo.Create(ctx, participant)
+	}
+
+	uc.Publish(ctx, 1, entity.WebhookEventMinutesReady, minutesEventData{RoomID: "room-1", MinutesID: 10, Title: "Weekly minutes"})
+	// 議事録以外のイベントと、再実行で作り直した議事録は通知しない
+	uc.Publish(ctx, 1, entity.WebhookEventRoomEnded, roomEventData{RoomID: "room-1"})
+	uc.Publish(ctx, 1, entity.WebhookEventMinutesReady, minutesEventData{RoomID: "room-1", MinutesID: 10, Title: "Weekly minutes", Regenerated: true})
+
+	for _, userID := range []int64{1, 2} {
+		saved := notificationRepo.ByType(userID, entity.NotificationMinutesReady)
+		if len(saved) != 1 || saved[0].Body != "Weekly minutes" || saved[0].Data["room_id"] != "room-1" {
+			t.Errorf("user %d minutes-ready notifications = %+v, want one for room-1", userID, saved)
+		}
+	}
+	if len(notificationRepo.Notifications) != 2 {
+		t.Errorf("notifications = %d, want 2", len(notificationRepo.Notifications))
+	}
+}
@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// セッション一覧の取得件数
+const (
+	defaultSessionLimit = 20
+	maxSessionLimit     = 100
+)
+
+// maxSlugSuffix スラッグが重複した場合に試す連番の上限（超えたらユーザーIDを使う）
+const maxSlugSuffix = 9
+
+// PersonalRoomUsecase 個人ルームユースケースのインターフェース
+type PersonalRoomUsecase interface {
+	// 自分の個人ルーム取得（未作成の場合はメールアドレスからスラッグを決めて作成）
+	GetMyRoom(ctx context.Context, userID int64) (*entity.PersonalRoom, error)
+	// 自分の個人ルームの設定を更新（アクセス設定は進行中と以降のセッションに適用）
+	UpdateMyRoom(ctx context.Context, userID int64, update *PersonalRoomUpdate) (*entity.PersonalRoom, error)
+	// スラッグで個人ルームと進行中のセッションを取得（セッションがない場合はnil）
+	GetBySlug(ctx context.Context, slug string) (*entity.PersonalRoom, *entity.CallRoom, error)
+	// 個人ルームのセッションを開く（進行中のセッションがなければ新しく作成）
+	OpenSession(ctx context.Context, slug string) (*entity.PersonalRoom, *entity.CallRoom, error)
+	// 自分の個人ルームのセッション一覧（新しい順）
+	GetSessions(ctx context.Context, userID int64, limit int) ([]*entity.CallRoom, error)
+}
+
+// PersonalRoomUpdate 個人ルームの更新内容（nilの項目は変更しない）
+type PersonalRoomUpdate struct {
+	Slug            *string
+	Name            *string
+	MaxParticipants *int
+	// セッションのアクセス設定（nilは変更なし、許可リストは置き換え）
+	Access *entity.RoomAccessSettings
+}
+
+type personalRoomUsecase struct {
+	personalRoomRepo port.PersonalRoomRepository
+	roomRepo         port.CallRoomRepository
+	userRepo         port.UserRepository
+	callUsecase      CallUsecase
+}
+
+// NewPersonalRoomUsecase 新しい個人ルームユースケースを作成
+func NewPersonalRoomUsecase(
+	personalRoomRepo port.PersonalRoomRepository,
+	roomRepo port.CallRoomRepository,
+	userRepo port.UserRepository,
+	callUsecase CallUsecase,
+) PersonalRoomUsecase {
+	return &personalRoomUsecase{
+		personalRoomRepo: personalRoomRepo,
+		roomRepo:         roomRepo,
+		userRepo:         userRepo,
+		callUsecase:      callUsecase,
+	}
+}
+
+// GetMyRoom 自分の個人ルームを取得（未作成の場合は作成）
+func (u *personalRoomUsecase) GetMyRoom(ctx context.Context, userID int64) (*entity.PersonalRoom, error) {
+	room, err := u.personalRoomRepo.FindByUserID(ctx, userID)
+	if err == nil {
+		return room, nil
+	}
+	if !errors.Is(err, entity.ErrPersonalRoomNotFound) {
+		return nil, err
+	}
+
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// 重複したら連番を付け、それでも空かなければユーザーIDで作る
+	base := entity.SuggestSlug(user.Email, userID)
+	candidates := []string{base}
+	for i := 2; i <= maxSlugSuffix; i++ {
+		candidates = append(candidates, base+"-"+strconv.Itoa(i))
+	}
+	candidates = append(candidates, "room-"+strconv.FormatInt(userID, 10))
+
+	for _, slug := range candidates {
+		room = &entity.PersonalRoom{
+			UserID:          userID,
+			Slug:            slug,
+			Name:            user.Name + "'s room",
+			MaxParticipants: 10,
+			Visibility:      entity.RoomVisibilityPublic,
+		}
+		err := u.personalRoomRepo.Create(ctx, room)
+		if err == nil {
+			return room, nil
+		}
+		if !errors.Is(err, entity.ErrSlugTaken) {
+			return nil, fmt.Errorf("failed to create personal room: %w", err)
+		}
+		// 同時リクエストで既に作成されている場合はそれを使う
+		if existing, findErr := u.personalRoomRepo.FindByUserID(ctx, userID); findErr == nil {
+			return existing, nil
+		}
+	}
+	return nil, entity.ErrSlugTaken
+}
+
+// UpdateMyRoom 自分の個人ルームの設定を更新
+func (u *personalRoomUsecase) UpdateMyRoom(ctx context.Context, userID int64, update *PersonalRoomUpdate) (*entity.PersonalRoom, error) {
+	room, err := u.GetMyRoom(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.Slug != nil {
+		if err := entity.ValidateSlug(*update.Slug); err != nil {
+			return nil, err
+		}
+		room.Slug = *update.Slug
+	}
+	if update.Name != nil {
+		if *update.Name == "" {
+			return nil, entity.ErrNameRequired
+		}
+		room.Name = *update.Name
+	}
+	if update.MaxParticipants != nil && *update.MaxParticipants > 0 {
+		room.MaxParticipants = *update.MaxParticipants
+	}
+	if update.Access != nil {
+		if err := room.SetAccess(update.Access); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := u.personalRoomRepo.Update(ctx, room); err != nil {
+		return nil, err
+	}
+
+	// 進行中のセッションにも同じアクセス設定を適用する
+	if update.Access != nil {
+		latest, err := u.latestSession(ctx, room)
+		if err != nil {
+			return nil, err
+		}
+		if latest != nil && latest.Status != entity.CallRoomStatusEnded {
+			if err := u.callUsecase.UpdateRoomAccess(ctx, latest, update.Access); err != nil {
+				return nil, fmt.Errorf("failed to update session access: %w", err)
+			}
+		}
+	}
+	return room, nil
+}
+
+// GetBySlug スラッグで個人ルームと進行中のセッションを取得
+func (u *personalRoomUsecase) GetBySlug(ctx context.Context, slug string) (*entity.PersonalRoom, *entity.CallRoom, error) {
+	room, err := u.personalRoomRepo.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	latest, err := u.latestSession(ctx, room)
+	if err != nil {
+		return nil, nil, err
+	}
+	if latest == nil || latest.Status == entity.CallRoomStatusEnded {
+		return room, nil, nil
+	}
+	return room, latest, nil
+}
+
+// OpenSession 個人ルームのセッションを開く
+// 進行中のセッションがあればそれを返し、なければ次の番号のセッション（通話ルーム）を個人ルームのアクセス設定で作成する
+func (u *personalRoomUsecase) OpenSession(ctx context.Context, slug string) (*entity.PersonalRoom, *entity.CallRoom, error) {
+	room, err := u.personalRoomRepo.FindBySlug(ctx, slug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	latest, err := u.latestSession(ctx, room)
+	if err != nil {
+		return nil, nil, err
+	}
+	if latest != nil && latest.Status != entity.CallRoomStatusEnded {
+		return room, latest, nil
+	}
+
+	number := 1
+	if latest != nil && latest.SessionNumber != nil {
+		number = *latest.SessionNumber + 1
+	}
+	personalRoomID := room.ID
+	session := &entity.CallRoom{
+		RoomID:          uuid.New().String(),
+		Name:            room.Name,
+		CreatedBy:       room.UserID,
+		Status:          entity.CallRoomStatusWaiting,
+		MaxParticipants: room.MaxParticipants,
+		PersonalRoomID:  &personalRoomID,
+		SessionNumber:   &number,
+	}
+
+	if err := u.callUsecase.CreateRoom(ctx, session, room.ApplyAccessTo(session)); err != nil {
+		// 同時に開かれて同じ番号のセッションが作成済みの場合はそれを使う
+		existing, findErr := u.latestSession(ctx, room)
+		if findErr != nil || existing == nil || existing.Status == entity.CallRoomStatusEnded {
+			return nil, nil, fmt.Errorf("failed to create session: %w", err)
+		}
+		return room, existing, nil
+	}
+	return room, session, nil
+}
+
+// GetSessions 自分の個人ルームのセッション一覧を取得
+func (u *personalRoomUsecase) GetSessions(ctx context.Context, userID int64, limit int) ([]*entity.CallRoom, error) {
+	room, err := u.GetMyRoom(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultSessionLimit
+	}
+	if limit > maxSessionLimit {
+		limit = maxSessionLimit
+	}
+	return u.roomRepo.FindByPersonalRoomID(ctx, room.ID, limit)
+}
+
+// latestSession 個人ルームの最新のセッションを取得（まだない場合はnil）
+func (u *personalRoomUsecase) latestSession(ctx context.Context, room *entity.PersonalRoom) (*entity.CallRoom, error) {
+	sessions, err := u.roomRepo.FindByPersonalRoomID(ctx, room.ID, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return sessions[0], nil
+}
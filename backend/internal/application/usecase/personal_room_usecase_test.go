@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// newPersonalRoomTestUsecase テスト用の個人ルームユースケースを作成
+func newPersonalRoomTestUsecase(users ...*entity.User) (PersonalRoomUsecase, CallUsecase, *testutil.MockPersonalRoomRepository) {
+	personalRoomRepo := testutil.NewMockPersonalRoomRepository()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	userRepo := testutil.NewMockUserRepository()
+	for _, user := range users {
+		userRepo.Users[user.Email] = user
+	}
+	call := NewCallUsecase(roomRepo, participantRepo, testutil.NewMockCallRoomAccessRepository(), userRepo, nil, testutil.NewMockEventPublisher())
+	return NewPersonalRoomUsecase(personalRoomRepo, roomRepo, userRepo, call), call, personalRoomRepo
+}
+
+func TestPersonalRoomUsecase_GetMyRoom(t *testing.T) {
+	tests := []struct {
+		name     string
+		email    string
+		taken    []string
+		expected string
+	}{
+		{name: "from email", email: "Tanaka@example.com", expected: "tanaka"},
+		{name: "symbols become hyphens", email: "taro.tanaka+work@example.com", expected: "taro-tanaka-work"},
+		{name: "taken adds suffix", email: "tanaka@example.com", taken: []string{"tanaka", "tanaka-2"}, expected: "tanaka-3"},
+		{name: "too short uses user id", email: "t@example.com", expected: "room-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _, repo := newPersonalRoomTestUsecase(&entity.User{ID: 1, Email: tt.email, Name: "Tanaka"})
+			ctx := context.Background()
+			for i, slug := range tt.taken {
+				repo.Create(ctx, &entity.PersonalRoom{UserID: int64(100 + i), Slug: slug, Name: slug})
+			}
+
+			room, err := uc.GetMyRoom(ctx, 1)
+			if err != nil {
+				t.Fatalf("GetMyRoom() unexpected error = %v", err)
+			}
+			if room.Slug != tt.expected {
+				t.Errorf("GetMyRoom() slug = %s, want %s", room.Slug, tt.expected)
+			}
+
+			// 2回目以降は同じ個人ルームを返す
+			again, err := uc.GetMyRoom(ctx, 1)
+			if err != nil {
+				t.Fatalf("GetMyRoom() unexpected error = %v", err)
+			}
+			if again.ID != room.ID {
+				t.Errorf("GetMyRoom() returned room %d, want %d", again.ID, room.ID)
+			}
+		})
+	}
+}
+
+func TestPersonalRoomUsecase_UpdateMyRoom(t *testing.T) {
+	tests := []struct {
+		name        string
+		slug        string
+		expectedErr error
+	}{
+		{name: "valid", slug: "team-tanaka"},
+		{name: "uppercase", slug: "Tanaka", expectedErr: entity.ErrInvalidSlug},
+		{name: "too short", slug: "ab", expectedErr: entity.ErrInvalidSlug},
+		{name: "trailing hyphen", slug: "tanaka-", expectedErr: entity.ErrInvalidSlug},
+		{name: "double hyphen", slug: "ta--naka", expectedErr: entity.ErrInvalidSlug},
+		{name: "taken", slug: "suzuki", expectedErr: entity.ErrSlugTaken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, _, _ := newPersonalRoomTestUsecase(
+				&entity.User{ID: 1, Email: "tanaka@example.com", Name: "Tanaka"},
+				&entity.User{ID: 2, Email: "suzuki@example.com", Name: "Suzuki"},
+			)
+			ctx := context.Background()
+			if _, err := uc.GetMyRoom(ctx, 2); err != nil {
+				t.Fatalf("GetMyRoom() unexpected error = %v", err)
+			}
+
+			slug := tt.slug
+			room, err := uc.UpdateMyRoom(ctx, 1, &PersonalRoomUpdate{Slug: &slug})
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("UpdateMyRoom() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr == nil && room.Slug != tt.slug {
+				t.Errorf("UpdateMyRoom() slug = %s, want %s", room.Slug, tt.slug)
+			}
+		})
+	}
+}
+
+func TestPersonalRoomUsecase_OpenSession(t *testing.T) {
+	uc, call, _ := newPersonalRoomTestUsecase(&entity.User{ID: 1, Email: "tanaka@example.com", Name: "Tanaka"})
+	ctx := context.Background()
+
+	if _, err := uc.GetMyRoom(ctx, 1); err != nil {
+		t.Fatalf("GetMyRoom() unexpected error = %v", err)
+	}
+	if _, current, err := uc.GetBySlug(ctx, "tanaka"); err != nil || current != nil {
+		t.Fatalf("GetBySlug() before opening = %v, %v, want no session", current, err)
+	}
+
+	_, first, err := uc.OpenSession(ctx, "tanaka")
+	if err != nil {
+		t.Fatalf("OpenSession() unexpected error = %v", err)
+	}
+	if first.CreatedBy != 1 || first.SessionNumber == nil || *first.SessionNumber != 1 {
+		t.Fatalf("OpenSession() = %+v, want session 1 owned by user 1", first)
+	}
+
+	// 進行中のセッションは全員で共有する
+	_, again, err := uc.OpenSession(ctx, "tanaka")
+	if err != nil {
+		t.Fatalf("OpenSession() unexpected error = %v", err)
+	}
+	if again.RoomID != first.RoomID {
+		t.Errorf("OpenSession() while open = %s, want %s", again.RoomID, first.RoomID)
+	}
+
+	// 終了後は新しいセッションになる（録音・議事録は通話ルームごとに分かれる）
+	if err := call.EndRoom(ctx, first); err != nil {
+		t.Fatalf("EndRoom() unexpected error = %v", err)
+	}
+	_, second, err := uc.OpenSession(ctx, "tanaka")
+	if err != nil {
+		t.Fatalf("OpenSession() unexpected error = %v", err)
+	}
+	if second.RoomID == first.RoomID || *second.SessionNumber != 2 {
+		t.Errorf("OpenSession() after end = %s (#%d), want new session #2", second.RoomID, *second.SessionNumber)
+	}
+
+	sessions, err := uc.GetSessions(ctx, 1, 0)
+	if err != nil {
+		t.Fatalf("GetSessions() unexpected error = %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].RoomID != second.RoomID || sessions[1].RoomID != first.RoomID {
+		t.Errorf("GetSessions() = %d sessions, want [second, first]", len(sessions))
+	}
+
+	if _, _, err := uc.OpenSession(ctx, "unknown"); !errors.Is(err, entity.ErrPersonalRoomNotFound) {
+		t.Errorf("OpenSession(unknown) error = %v, want ErrPersonalRoomNotFound", err)
+	}
+}
+
+func TestPersonalRoomUsecase_SessionAccess(t *testing.T) {
+	uc, call, _ := newPersonalRoomTestUsecase(&entity.User{ID: 1, Email: "tanaka@example.com", Name: "Tanaka"})
+	ctx := context.Background()
+
+	passcode := "secret"
+	room, err := uc.UpdateMyRoom(ctx, 1, &PersonalRoomUpdate{Access: &entity.RoomAccessSettings{
+		Visibility:     entity.RoomVisibilityInviteOnly,
+		Passcode:       &passcode,
+		AllowedUserIDs: []int64{2},
+	}})
+	if err != nil {
+		t.Fatalf("UpdateMyRoom() unexpected error = %v", err)
+	}
+	if room.Visibility != entity.RoomVisibilityInviteOnly || !room.HasPasscode() {
+		t.Fatalf("UpdateMyRoom() access = %s, passcode %v", room.Visibility, room.HasPasscode())
+	}
+
+	// 開いたセッションに個人ルームのアクセス設定が適用される
+	_, session, err := uc.OpenSession(ctx, "tanaka")
+	if err != nil {
+		t.Fatalf("OpenSession() unexpected error = %v", err)
+	}
+	if session.Visibility != entity.RoomVisibilityInviteOnly || !session.CheckPasscode("secret") {
+		t.Errorf("OpenSession() visibility = %s, passcode set %v", session.Visibility, session.HasPasscode())
+	}
+	if err := call.CheckRoomAccess(ctx, session, &entity.RoomPrincipal{UserID: 3}, "secret"); !errors.Is(err, entity.ErrRoomAccessDenied) {
+		t.Errorf("CheckRoomAccess(outsider) error = %v, want ErrRoomAccessDenied", err)
+	}
+	if err := call.CheckRoomAccess(ctx, session, &entity.RoomPrincipal{UserID: 2}, "secret"); err != nil {
+		t.Errorf("CheckRoomAccess(allowed) unexpected error = %v", err)
+	}
+
+	// 進行中のセッションにも変更が反映される
+	if _, err := uc.UpdateMyRoom(ctx, 1, &PersonalRoomUpdate{Access: &entity.RoomAccessSettings{Visibility: entity.RoomVisibilityPublic}}); err != nil {
+		t.Fatalf("UpdateMyRoom() unexpected error = %v", err)
+	}
+	if err := call.CheckRoomAccess(ctx, session, &entity.RoomPrincipal{UserID: 3}, "secret"); err != nil {
+		t.Errorf("CheckRoomAccess() after opening up error = %v", err)
+	}
+
+	// 不正な設定は保存しない
+	short := "abc"
+	if _, err := uc.UpdateMyRoom(ctx, 1, &PersonalRoomUpdate{Access: &entity.RoomAccessSettings{Passcode: &short}}); !errors.Is(err, entity.ErrPasscodeLength) {
+		t.Errorf("UpdateMyRoom(short passcode) error = %v, want ErrPasscodeLength", err)
+	}
+	if room, _ := uc.GetMyRoom(ctx, 1); room.Visibility != entity.RoomVisibilityPublic || !room.HasPasscode() {
+		t.Errorf("GetMyRoom() after invalid update = %s, passcode %v", room.Visibility, room.HasPasscode())
+	}
+}
@@ -0,0 +1,346 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// PollUsecase 通話中の投票ユースケースのインターフェース
+// 投票はサーバーで集計し、締め切った結果はルームの議事録に追記する
+type PollUsecase interface {
+	EventPublisher
+	// 投票を作成（ホスト・共同ホストのみ）
+	CreatePoll(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, poll *entity.Poll) (*entity.PollResults, error)
+	// ルームの投票一覧と集計結果を取得（自分の投票を含む）
+	ListPolls(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal) ([]*entity.PollResults, error)
+	// 投票する（締め切りまでは投票し直せる）
+	Vote(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, pollID int64, optionIDs []int64) (*entity.PollResults, error)
+	// 投票を締め切る（ホスト・共同ホストのみ）
+	ClosePoll(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, pollID int64) (*entity.PollResults, error)
+}
+
+type pollUsecase struct {
+	pollRepo        port.PollRepository
+	participantRepo port.CallParticipantRepository
+	roomRepo        port.CallRoomRepository
+	userRepo        port.UserRepository
+	minutesRepo     port.CallMinutesRepository
+}
+
+// NewPollUsecase 新しい投票ユースケースを作成
+func NewPollUsecase(
+	pollRepo port.PollRepository,
+	participantRepo port.CallParticipantRepository,
+	roomRepo port.CallRoomRepository,
+	userRepo port.UserRepository,
+	minutesRepo port.CallMinutesRepository,
+) PollUsecase {
+	return &pollUsecase{
+		pollRepo:        pollRepo,
+		participantRepo: participantRepo,
+		roomRepo:        roomRepo,
+		userRepo:        userRepo,
+		minutesRepo:     minutesRepo,
+	}
+}
+
+// Publish 通話の終了時に受付中の投票を締め切り、議事録の作成時に投票結果を追記
+func (u *pollUsecase) Publish(ctx context.Context, ownerID int64, event entity.WebhookEvent, data interface{}) {
+	var roomID string
+	switch d := data.(type) {
+	case roomEventData:
+		if event != entity.WebhookEventRoomEnded {
+			return
+		}
+		roomID = d.RoomID
+	case minutesEventData:
+		roomID = d.RoomID
+	default:
+		return
+	}
+
+	room, err := u.roomRepo.FindByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Warn("Failed to get room for polls", slog.String("room_id", roomID), slog.String("error", err.Error()))
+		return
+	}
+
+	if event == entity.WebhookEventRoomEnded {
+		polls, err := u.pollRepo.FindByRoomID(ctx, room.ID)
+		if err != nil {
+			slog.Error("Failed to get polls", slog.Int64("room_id", room.ID), slog.String("error", err.Error()))
+			return
+		}
+		for _, poll := range polls {
+			if !poll.IsOpen() {
+				continue
+			}
+			if err := u.pollRepo.Close(ctx, poll.ID, time.Now()); err != nil && !errors.Is(err, entity.ErrPollClosed) {
+				slog.Error("Failed to close poll", slog.Int64("poll_id", poll.ID), slog.String("error", err.Error()))
+			}
+		}
+	}
+	u.syncMinutes(ctx, room.ID)
+}
+
+// CreatePoll 投票を作成
+func (u *pollUsecase) CreatePoll(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, poll *entity.Poll) (*entity.PollResults, error) {
+	if room.Status == entity.CallRoomStatusEnded {
+		return nil, entity.ErrRoomEnded
+	}
+	participant, err := u.findParticipant(ctx, room, principal)
+	if err != nil {
+		return nil, err
+	}
+	if !participant.Role.Can(entity.PermissionRunPolls) {
+		return nil, entity.ErrPermissionDenied
+	}
+	if err := poll.Validate(); err != nil {
+		return nil, err
+	}
+
+	poll.RoomID = room.ID
+	poll.CreatedBy = participant.UserID
+	poll.Status = entity.PollStatusOpen
+	if err := u.pollRepo.Create(ctx, poll); err != nil {
+		return nil, fmt.Errorf("failed to create poll: %w", err)
+	}
+	return poll.Tally(nil), nil
+}
+
+// ListPolls ルームの投票一覧と集計結果を取得
+// 通話の参加者と、通話後のルーム作成者が取得できる
+func (u *pollUsecase) ListPolls(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal) ([]*entity.PollResults, error) {
+	voterKey := ""
+	if participant, err := u.findParticipant(ctx, room, principal); err == nil {
+		voterKey = entity.PollVoterKey(participant)
+	} else if principal.Guest != nil || room.CreatedBy != principal.UserID {
+		return nil, err
+	}
+
+	polls, err := u.pollRepo.FindByRoomID(ctx, room.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get polls: %w", err)
+	}
+
+	list := make([]*entity.PollResults, 0, len(polls))
+	for _, poll := range polls {
+		results, err := u.results(ctx, poll, voterKey)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, results)
+	}
+	return list, nil
+}
+
+// Vote 投票する
+func (u *pollUsecase) Vote(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, pollID int64, optionIDs []int64) (*entity.PollResults, error) {
+	participant, err := u.findParticipant(ctx, room, principal)
+	if err != nil {
+		return nil, err
+	}
+	poll, err := u.findPoll(ctx, room, pollID)
+	if err != nil {
+		return nil, err
+	}
+	if !poll.IsOpen() {
+		return nil, entity.ErrPollClosed
+	}
+	choices, err := poll.ValidateChoices(optionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	voterKey := entity.PollVoterKey(participant)
+	voterName := u.voterName(ctx, participant)
+	votes := make([]*entity.PollVote, len(choices))
+	for i, optionID := range choices {
+		votes[i] = &entity.PollVote{
+			PollID:    poll.ID,
+			OptionID:  optionID,
+			VoterKey:  voterKey,
+			UserID:    participant.UserID,
+			VoterName: voterName,
+		}
+	}
+	if err := u.pollRepo.ReplaceVotes(ctx, poll.ID, voterKey, votes); err != nil {
+		return nil, err
+	}
+
+	return u.results(ctx, poll, voterKey)
+}
+
+// ClosePoll 投票を締め切り、結果を議事録に追記
+func (u *pollUsecase) ClosePoll(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal, pollID int64) (*entity.PollResults, error) {
+	participant, err := u.findParticipant(ctx, room, principal)
+	if err != nil {
+		return nil, err
+	}
+	if !participant.Role.Can(entity.PermissionRunPolls) {
+		return nil, entity.ErrPermissionDenied
+	}
+	poll, err := u.findPoll(ctx, room, pollID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.pollRepo.Close(ctx, poll.ID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// 最終得票数を反映した状態で集計し直す
+	if poll, err = u.pollRepo.FindByID(ctx, poll.ID); err != nil {
+		return nil, fmt.Errorf("failed to get poll: %w", err)
+	}
+	results, err := u.results(ctx, poll, entity.PollVoterKey(participant))
+	if err != nil {
+		return nil, err
+	}
+
+	u.syncMinutes(ctx, room.ID)
+	return results, nil
+}
+
+// findParticipant 主体の参加中の参加記録を取得
+func (u *pollUsecase) findParticipant(ctx context.Context, room *entity.CallRoom, principal *entity.RoomPrincipal) (*entity.CallParticipant, error) {
+	var participant *entity.CallParticipant
+	var err error
+	if principal.Guest != nil {
+		if principal.Guest.RoomID != room.RoomID {
+			return nil, entity.ErrNotRoomMember
+		}
+		participant, err = u.participantRepo.FindByRoomIDAndGuestID(ctx, room.ID, principal.Guest.GuestID)
+	} else {
+		participant, err = u.participantRepo.FindByRoomIDAndUserID(ctx, room.ID, principal.UserID)
+	}
+	if err != nil || participant == nil {
+		return nil, entity.ErrNotRoomMember
+	}
+	return participant, nil
+}
+
+// findPoll ルームの投票を取得
+func (u *pollUsecase) findPoll(ctx context.Context, room *entity.CallRoom, pollID int64) (*entity.Poll, error) {
+	poll, err := u.pollRepo.FindByID(ctx, pollID)
+	if err != nil {
+		return nil, err
+	}
+	if poll.RoomID != room.ID {
+		return nil, entity.ErrPollNotFound
+	}
+	return poll, nil
+}
+
+// voterName 投票者の表示名
+func (u *pollUsecase) voterName(ctx context.Context, participant *entity.CallParticipant) string {
+	if participant.IsGuest() {
+		if participant.GuestName != nil {
+			return *participant.GuestName
+		}
+		return ""
+	}
+	user, err := u.userRepo.FindByID(ctx, participant.UserID)
+	if err != nil {
+		return ""
+	}
+	return user.Name
+}
+
+// results 投票を集計し、voterKeyの参加者が選んだ選択肢を設定
+func (u *pollUsecase) results(ctx context.Context, poll *entity.Poll, voterKey string) (*entity.PollResults, error) {
+	votes, err := u.pollRepo.FindVotes(ctx, poll.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poll votes: %w", err)
+	}
+	results := poll.Tally(votes)
+	for _, vote := range votes {
+		if voterKey != "" && vote.VoterKey == voterKey {
+			results.MyChoices = append(results.MyChoices, vote.OptionID)
+		}
+	}
+	return results, nil
+}
+
+// syncMinutes 締め切った投票の結果をルームの議事録に反映（議事録がまだない場合は作成時に反映する）
+func (u *pollUsecase) syncMinutes(ctx context.Context, roomID int64) {
+	minutes, err := u.minutesRepo.FindByRoomID(ctx, roomID)
+	if err != nil {
+		return
+	}
+	polls, err := u.pollRepo.FindByRoomID(ctx, roomID)
+	if err != nil {
+		slog.Error("Failed to get polls", slog.Int64("room_id", roomID), slog.String("error", err.Error()))
+		return
+	}
+
+	closed := make([]*entity.PollResults, 0, len(polls))
+	for _, poll := range polls {
+		if poll.IsOpen() {
+			continue
+		}
+		results, err := u.results(ctx, poll, "")
+		if err != nil {
+			slog.Error("Failed to tally poll", slog.Int64("poll_id", poll.ID), slog.String("error", err.Error()))
+			return
+		}
+		closed = append(closed, results)
+	}
+	if len(closed) == 0 {
+		return
+	}
+
+	text := formatPollResults(closed)
+	if minutes.PollResults != nil && *minutes.PollResults == text {
+		return
+	}
+	minutes.PollResults = &text
+	if err := u.minutesRepo.Update(ctx, minutes); err != nil {
+		slog.Error("Failed to add poll results to minutes", slog.Int64("room_id", roomID), slog.String("error", err.Error()))
+	}
+}
+
+// formatPollResults 投票結果を議事録用に整形
+func formatPollResults(polls []*entity.PollResults) string {
+	var sb strings.Builder
+
+	for i, poll := range polls {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+
+		var kinds []string
+		if poll.MultipleChoice {
+			kinds = append(kinds, "複数選択")
+		}
+		if poll.Anonymous {
+			kinds = append(kinds, "匿名")
+		}
+		sb.WriteString(fmt.Sprintf("Q%d. %s", i+1, poll.Question))
+		if len(kinds) > 0 {
+			sb.WriteString("（" + strings.Join(kinds, "・") + "）")
+		}
+		sb.WriteString("\n")
+
+		for _, option := range poll.Options {
+			percent := 0
+			if poll.TotalVoters > 0 {
+				percent = option.Votes * 100 / poll.TotalVoters
+			}
+			sb.WriteString(fmt.Sprintf("- %s: %d票（%d%%）", option.Text, option.Votes, percent))
+			if len(option.Voters) > 0 {
+				sb.WriteString(" " + strings.Join(option.Voters, ", "))
+			}
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("回答者: %d人\n", poll.TotalVoters))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,301 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// seedPollRoom 投票のテスト用ルームを作成（ホスト: ユーザー1、参加者: ユーザー2、ゲスト: guest-1）
+func seedPollRoom(ctx context.Context, roomRepo *testutil.MockCallRoomRepository, participantRepo *testutil.MockCallParticipantRepository, userRepo *testutil.MockUserRepository) *entity.CallRoom {
+	for _, user := range []*entity.User{
+		{ID: 1, Email: "tanaka@example.com", Name: "Tanaka"},
+		{ID: 2, Email: "suzuki@example.com", Name: "Suzuki"},
+	} {
+		userRepo.Users[user.Email] = user
+	}
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Weekly", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+	roomRepo.Create(ctx, room)
+	participantRepo.Create(ctx, &entity.CallParticipant{RoomID: room.ID, UserID: 1, Role: entity.RoleHost, IsActive: true})
+	participantRepo.Create(ctx, &entity.CallParticipant{RoomID: room.ID, UserID: 2, IsActive: true})
+	participantRepo.Create(ctx, &entity.CallParticipant{RoomID: room.ID, GuestID: strPtr("1"), GuestName: strPtr("Guest"), IsActive: true})
+	return room
+}
+
+// newTestPoll 選択肢を持つ投票を作成
+func newTestPoll(multiple, anonymous bool, options ...string) *entity.Poll {
+	poll := &entity.Poll{Question: "Next meeting?", MultipleChoice: multiple, Anonymous: anonymous}
+	for _, text := range options {
+		poll.Options = append(poll.Options, &entity.PollOption{Text: text})
+	}
+	return poll
+}
+
+var (
+	pollHost   = &entity.RoomPrincipal{UserID: 1}
+	pollMember = &entity.RoomPrincipal{UserID: 2}
+	pollGuest  = &entity.RoomPrincipal{Guest: &entity.GuestPrincipal{GuestID: "1", RoomID: "room-1", DisplayName: "Guest"}}
+)
+
+func TestPollUsecase_CreatePoll(t *testing.T) {
+	tests := []struct {
+		name        string
+		principal   *entity.RoomPrincipal
+		question    string
+		options     []string
+		expectedErr error
+	}{
+		{name: "host", principal: pollHost, question: " Lunch? ", options: []string{"Sushi", "Ramen"}},
+		{name: "participant", principal: pollMember, question: "Lunch?", options: []string{"Sushi", "Ramen"}, expectedErr: entity.ErrPermissionDenied},
+		{name: "not a member", principal: &entity.RoomPrincipal{UserID: 9}, question: "Lunch?", options: []string{"Sushi", "Ramen"}, expectedErr: entity.ErrNotRoomMember},
+		{name: "one option", principal: pollHost, question: "Lunch?", options: []string{"Sushi"}, expectedErr: entity.ErrInvalidPoll},
+		{name: "duplicate options", principal: pollHost, question: "Lunch?", options: []string{"Sushi", " Sushi"}, expectedErr: entity.ErrInvalidPoll},
+		{name: "empty question", principal: pollHost, question: " ", options: []string{"Sushi", "Ramen"}, expectedErr: entity.ErrInvalidPoll},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			participantRepo := testutil.NewMockCallParticipantRepository()
+			userRepo := testutil.NewMockUserRepository()
+			pollRepo := testutil.NewMockPollRepository()
+			usecase := NewPollUsecase(pollRepo, participantRepo, roomRepo, userRepo, testutil.NewMockCallMinutesRepository())
+			room := seedPollRoom(ctx, roomRepo, participantRepo, userRepo)
+			poll := newTestPoll(false, false, tt.options...)
+			poll.Question = tt.question
+
+			// Act
+			results, err := usecase.CreatePoll(ctx, room, tt.principal, poll)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CreatePoll() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr != nil {
+				if len(pollRepo.Polls) != 0 {
+					t.Error("CreatePoll() should not save a rejected poll")
+				}
+				return
+			}
+			if results.Question != "Lunch?" || results.Status != entity.PollStatusOpen || len(results.Options) != 2 {
+				t.Errorf("CreatePoll() = %+v, want an open poll with 2 options", results)
+			}
+		})
+	}
+}
+
+func TestPollUsecase_Vote(t *testing.T) {
+	tests := []struct {
+		name        string
+		multiple    bool
+		otherRoom   bool
+		choices     []int // 選択肢の番号（-1は存在しない選択肢）
+		expectedMy  int
+		expectedErr error
+	}{
+		{name: "single choice", choices: []int{0}, expectedMy: 1},
+		{name: "duplicated choice", choices: []int{0, 0}, expectedMy: 1},
+		{name: "no choice", expectedErr: entity.ErrInvalidPollVote},
+		{name: "two choices", choices: []int{0, 1}, expectedErr: entity.ErrInvalidPollVote},
+		{name: "two choices in multiple choice poll", multiple: true, choices: []int{0, 2}, expectedMy: 2},
+		{name: "unknown option", choices: []int{-1}, expectedErr: entity.ErrInvalidPollVote},
+		{name: "poll of another room", otherRoom: true, choices: []int{0}, expectedErr: entity.ErrNotRoomMember},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			participantRepo := testutil.NewMockCallParticipantRepository()
+			userRepo := testutil.NewMockUserRepository()
+			usecase := NewPollUsecase(testutil.NewMockPollRepository(), participantRepo, roomRepo, userRepo, testutil.NewMockCallMinutesRepository())
+			room := seedPollRoom(ctx, roomRepo, participantRepo, userRepo)
+			poll, err := usecase.CreatePoll(ctx, room, pollHost, newTestPoll(tt.multiple, false, "Mon", "Tue", "Wed"))
+			if err != nil {
+				t.Fatalf("CreatePoll() unexpected error = %v", err)
+			}
+			var optionIDs []int64
+			for _, i := range tt.choices {
+				if i < 0 {
+					optionIDs = append(optionIDs, 999)
+					continue
+				}
+				optionIDs = append(optionIDs, poll.Options[i].OptionID)
+			}
+			voteRoom := room
+			if tt.otherRoom {
+				voteRoom = &entity.CallRoom{ID: room.ID + 1, RoomID: "room-2"}
+			}
+
+			// Act
+			results, err := usecase.Vote(ctx, voteRoom, pollMember, poll.PollID, optionIDs)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Vote() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+			if results.TotalVoters != 1 || len(results.MyChoices) != tt.expectedMy {
+				t.Errorf("Vote() = %d voters, MyChoices %v, want 1 voter with %d choices", results.TotalVoters, results.MyChoices, tt.expectedMy)
+			}
+		})
+	}
+}
+
+func TestPollUsecase_VoteResults(t *testing.T) {
+	tests := []struct {
+		name           string
+		anonymous      bool
+		expectedVoters [][]string
+	}{
+		{name: "named poll", expectedVoters: [][]string{{"Guest"}, {"Suzuki"}}},
+		{name: "anonymous poll hides voters", anonymous: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			participantRepo := testutil.NewMockCallParticipantRepository()
+			userRepo := testutil.NewMockUserRepository()
+			usecase := NewPollUsecase(testutil.NewMockPollRepository(), participantRepo, roomRepo, userRepo, testutil.NewMockCallMinutesRepository())
+			room := seedPollRoom(ctx, roomRepo, participantRepo, userRepo)
+			poll, _ := usecase.CreatePoll(ctx, room, pollHost, newTestPoll(false, tt.anonymous, "Mon", "Tue"))
+			mon, tue := poll.Options[0].OptionID, poll.Options[1].OptionID
+
+			// Act（投票し直すと前の投票は置き換わる）
+			usecase.Vote(ctx, room, pollGuest, poll.PollID, []int64{mon})
+			usecase.Vote(ctx, room, pollMember, poll.PollID, []int64{mon})
+			results, err := usecase.Vote(ctx, room, pollMember, poll.PollID, []int64{tue})
+
+			// Assert
+			if err != nil {
+				t.Fatalf("Vote() unexpected error = %v", err)
+			}
+			if results.TotalVoters != 2 || results.Options[0].Votes != 1 || results.Options[1].Votes != 1 {
+				t.Errorf("results = %d voters, votes %d/%d, want 2 voters with 1/1", results.TotalVoters, results.Options[0].Votes, results.Options[1].Votes)
+			}
+			for i, option := range results.Options {
+				var want []string
+				if tt.expectedVoters != nil {
+					want = tt.expectedVoters[i]
+				}
+				if strings.Join(option.Voters, ",") != strings.Join(want, ",") || (want == nil) != (option.Voters == nil) {
+					t.Errorf("option %d voters = %v, want %v", i, option.Voters, want)
+				}
+			}
+			if len(results.MyChoices) != 1 || results.MyChoices[0] != tue {
+				t.Errorf("MyChoices = %v, want [%d]", results.MyChoices, tue)
+			}
+
+			list, err := usecase.ListPolls(ctx, room, pollGuest)
+			if err != nil || len(list) != 1 {
+				t.Fatalf("ListPolls() = %d, %v, want 1 poll", len(list), err)
+			}
+			if len(list[0].MyChoices) != 1 || list[0].MyChoices[0] != mon {
+				t.Errorf("guest MyChoices = %v, want [%d]", list[0].MyChoices, mon)
+			}
+		})
+	}
+}
+
+func TestPollUsecase_ClosePoll(t *testing.T) {
+	tests := []struct {
+		name          string
+		principal     *entity.RoomPrincipal
+		alreadyClosed bool
+		expectedErr   error
+	}{
+		{name: "host", principal: pollHost},
+		{name: "participant", principal: pollMember, expectedErr: entity.ErrPermissionDenied},
+		{name: "already closed", principal: pollHost, alreadyClosed: true, expectedErr: entity.ErrPollClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			participantRepo := testutil.NewMockCallParticipantRepository()
+			userRepo := testutil.NewMockUserRepository()
+			pollRepo := testutil.NewMockPollRepository()
+			usecase := NewPollUsecase(pollRepo, participantRepo, roomRepo, userRepo, testutil.NewMockCallMinutesRepository())
+			room := seedPollRoom(ctx, roomRepo, participantRepo, userRepo)
+			poll, _ := usecase.CreatePoll(ctx, room, pollHost, newTestPoll(false, false, "Yes", "No"))
+			usecase.Vote(ctx, room, pollMember, poll.PollID, []int64{poll.Options[0].OptionID})
+			usecase.Vote(ctx, room, pollGuest, poll.PollID, []int64{poll.Options[0].OptionID})
+			if tt.alreadyClosed {
+				usecase.ClosePoll(ctx, room, pollHost, poll.PollID)
+			}
+
+			// Act
+			results, err := usecase.ClosePoll(ctx, room, tt.principal, poll.PollID)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("ClosePoll() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+			if results.Status != entity.PollStatusClosed || results.ClosedAt == nil || results.Options[0].Votes != 2 {
+				t.Errorf("ClosePoll() = %+v, want closed with 2 votes for Yes", results)
+			}
+			if pollRepo.Polls[poll.PollID].Options[0].VoteCount != 2 {
+				t.Error("final vote counts should be stored")
+			}
+			if _, err := usecase.Vote(ctx, room, pollHost, poll.PollID, []int64{poll.Options[1].OptionID}); !errors.Is(err, entity.ErrPollClosed) {
+				t.Errorf("Vote() after close error = %v, want ErrPollClosed", err)
+			}
+		})
+	}
+}
+
+func TestPollUsecase_Publish(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	userRepo := testutil.NewMockUserRepository()
+	pollRepo := testutil.NewMockPollRepository()
+	minutesRepo := testutil.NewMockCallMinutesRepository()
+	usecase := NewPollUsecase(pollRepo, participantRepo, roomRepo, userRepo, minutesRepo)
+	room := seedPollRoom(ctx, roomRepo, participantRepo, userRepo)
+
+	first, _ := usecase.CreatePoll(ctx, room, pollHost, newTestPoll(false, false, "Yes", "No"))
+	second, _ := usecase.CreatePoll(ctx, room, pollHost, newTestPoll(false, true, "Sushi", "Ramen"))
+	usecase.Vote(ctx, room, pollMember, first.PollID, []int64{first.Options[0].OptionID})
+	usecase.Vote(ctx, room, pollGuest, first.PollID, []int64{first.Options[0].OptionID})
+	usecase.Vote(ctx, room, pollMember, second.PollID, []int64{second.Options[1].OptionID})
+	usecase.ClosePoll(ctx, room, pollHost, first.PollID)
+
+	// Act（通話の終了で残りの投票も締め切り、議事録の作成時に結果を追記する）
+	room.Status = entity.CallRoomStatusEnded
+	usecase.Publish(ctx, 1, entity.WebhookEventRoomEnded, roomEventData{RoomID: "room-1"})
+	minutesRepo.Create(ctx, &entity.CallMinutes{RoomID: room.ID, Title: "Weekly"})
+	usecase.Publish(ctx, 1, entity.WebhookEventMinutesReady, minutesEventData{RoomID: "room-1", MinutesID: 1})
+
+	// Assert
+	if pollRepo.Polls[second.PollID].IsOpen() {
+		t.Fatal("open polls should be closed when the call ends")
+	}
+	minutes := minutesRepo.Minutes[room.ID]
+	if minutes.PollResults == nil {
+		t.Fatal("poll results should be added to the minutes")
+	}
+	for _, want := range []string{"Q1. Next meeting?", "- Yes: 2票（100%） Suzuki, Guest", "Q2. Next meeting?（匿名）", "- Ramen: 1票（100%）\n"} {
+		if !strings.Contains(*minutes.PollResults, want) {
+			t.Errorf("minutes poll results = %q, want to contain %q", *minutes.PollResults, want)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// maxPresenceContacts 在席状態を配信する連絡先の上限
+const maxPresenceContacts = 200
+
+// PresenceUsecase ユーザーの在席状態ユースケースのインターフェース
+// ユーザー単位の接続（端末ごと）と通話の参加状況から在席状態を決め、連絡先へ配信する。
+// 連絡先は同じ通話に参加したことのあるユーザー
+type PresenceUsecase interface {
+	EventPublisher
+	// 端末の接続を登録して連絡先の在席状態を返し、以降のイベントを購読する
+	Connect(ctx context.Context, userID int64) ([]*entity.Presence, *UserEventSubscription, error)
+	// 端末の接続を解除する（最後の端末なら最終接続日時を記録してオフラインにする）
+	Disconnect(ctx context.Context, sub *UserEventSubscription)
+	// 自分の状態を設定（online/away/dnd）
+	SetStatus(ctx context.Context, userID int64, status entity.PresenceStatus) (*entity.Presence, error)
+	// ユーザーの在席状態を取得
+	GetPresence(ctx context.Context, userID int64) (*entity.Presence, error)
+	// 連絡先の在席状態一覧を取得
+	GetContacts(ctx context.Context, userID int64) ([]*entity.Presence, error)
+	// 取り込み中に設定しているか（着信・招待の前に確認する）
+	IsDoNotDisturb(ctx context.Context, userID int64) (bool, error)
+}
+
+// presenceEventData presence イベントのデータ
+type presenceEventData struct {
+	UserID     int64      `json:"user_id"`
+	Status     string     `json:"status"`
+	LastSeenAt *time.Time `json:"last_seen_at,omitempty"`
+}
+
+type presenceUsecase struct {
+	presenceRepo    port.PresenceRepository
+	participantRepo port.CallParticipantRepository
+	hub             UserEventHub
+
+	mu sync.Mutex
+	// 接続中のユーザーが設定した状態
+	settings map[int64]*entity.UserPresence
+	// ユーザーが参加中のルーム
+	rooms map[int64]map[string]struct{}
+	// 在席状態を見ているユーザー（対象ユーザー → 見ているユーザーの接続数）
+	watchers map[int64]map[int64]int
+	// 接続ごとの連絡先（接続時に読み込む）
+	contacts map[*UserEventSubscription][]int64
+	// 最後に配信した状態
+	last map[int64]entity.PresenceStatus
+}
+
+// NewPresenceUsecase 新しい在席状態ユースケースを作成
+func NewPresenceUsecase(
+	presenceRepo port.PresenceRepository,
+	participantRepo port.CallParticipantRepository,
+	hub UserEventHub,
+) PresenceUsecase {
+	return &presenceUsecase{
+		presenceRepo:    presenceRepo,
+		participantRepo: participantRepo,
+		hub:             hub,
+		settings:        make(map[int64]*entity.UserPresence),
+		rooms:           make(map[int64]map[string]struct{}),
+		watchers:        make(map[int64]map[int64]int),
+		contacts:        make(map[*UserEventSubscription][]int64),
+		last:            make(map[int64]entity.PresenceStatus),
+	}
+}
+
+// Publish 通話の入退室を通話中の状態に反映
+func (u *presenceUsecase) Publish(ctx context.Context, ownerID int64, event entity.WebhookEvent, data interface{}) {
+	d, ok := data.(participantEventData)
+	if !ok || d.UserID == nil {
+		return
+	}
+	userID := *d.UserID
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	switch event {
+	case entity.WebhookEventParticipantJoined:
+		if u.rooms[userID] == nil {
+			u.rooms[userID] = make(map[string]struct{})
+		}
+		u.rooms[userID][d.RoomID] = struct{}{}
+	case entity.WebhookEventParticipantLeft:
+		delete(u.rooms[userID], d.RoomID)
+		if len(u.rooms[userID]) == 0 {
+			delete(u.rooms, userID)
+		}
+	default:
+		return
+	}
+	// 接続していないユーザーはオフラインのまま
+	if u.hub.ConnectionCount(userID) > 0 {
+		u.notify(userID)
+	}
+}
+
+// Connect 端末の接続を登録して連絡先の在席状態を返す
+func (u *presenceUsecase) Connect(ctx context.Context, userID int64) ([]*entity.Presence, *UserEventSubscription, error) {
+	contactIDs, err := u.participantRepo.FindContactUserIDs(ctx, userID, maxPresenceContacts)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := u.presenceRepo.UpdateLastSeen(ctx, userID, time.Now()); err != nil {
+		return nil, nil, err
+	}
+	settings, err := u.findSettings(ctx, append([]int64{userID}, contactIDs...))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sub := u.hub.Subscribe(userID)
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if setting, ok := settings[userID]; ok {
+		u.settings[userID] = setting
+	}
+	for _, id := range contactIDs {
+		if u.watchers[id] == nil {
+			u.watchers[id] = make(map[int64]int)
+		}
+		u.watchers[id][userID]++
+	}
+	u.contacts[sub] = contactIDs
+	u.notify(userID)
+
+	snapshot := make([]*entity.Presence, len(contactIDs))
+	for i, id := range contactIDs {
+		snapshot[i] = u.resolve(id, settings[id])
+	}
+	return snapshot, sub, nil
+}
+
+// Disconnect 端末の接続を解除する
+func (u *presenceUsecase) Disconnect(ctx context.Context, sub *UserEventSubscription) {
+	u.hub.Unsubscribe(sub)
+	userID := sub.UserID
+
+	// 最後の端末が切断されたらオフライン
+	now := time.Now()
+	offline := u.hub.ConnectionCount(userID) == 0
+	if offline {
+		if err := u.presenceRepo.UpdateLastSeen(ctx, userID, now); err != nil {
+			slog.Error("Failed to update last seen", slog.Int64("user_id", userID), slog.String("error", err.Error()))
+		}
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	for _, id := range u.contacts[sub] {
+		u.watchers[id][userID]--
+		if u.watchers[id][userID] <= 0 {
+			delete(u.watchers[id], userID)
+		}
+		if len(u.watchers[id]) == 0 {
+			delete(u.watchers, id)
+		}
+	}
+	delete(u.contacts, sub)
+
+	// 切断中に別の端末が接続した場合はオンラインのまま
+	if !offline || u.hub.ConnectionCount(userID) > 0 {
+		return
+	}
+	if setting, ok := u.settings[userID]; ok {
+		setting.LastSeenAt = &now
+	} else {
+		u.settings[userID] = &entity.UserPresence{UserID: userID, Status: entity.PresenceOnline, LastSeenAt: &now}
+	}
+	u.notify(userID)
+	delete(u.settings, userID)
+	delete(u.last, userID)
+}
+
+// SetStatus 自分の状態を設定
+func (u *presenceUsecase) SetStatus(ctx context.Context, userID int64, status entity.PresenceStatus) (*entity.Presence, error) {
+	if !status.IsSettable() {
+		return nil, entity.ErrInvalidPresenceStatus
+	}
+	if err := u.presenceRepo.SaveStatus(ctx, userID, status); err != nil {
+		return nil, err
+	}
+	settings, err := u.findSettings(ctx, []int64{userID})
+	if err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if _, ok := u.settings[userID]; ok {
+		u.settings[userID] = settings[userID]
+		u.notify(userID)
+	}
+	return u.resolve(userID, settings[userID]), nil
+}
+
+// GetPresence ユーザーの在席状態を取得
+func (u *presenceUsecase) GetPresence(ctx context.Context, userID int64) (*entity.Presence, error) {
+	presences, err := u.presences(ctx, []int64{userID})
+	if err != nil {
+		return nil, err
+	}
+	return presences[0], nil
+}
+
+// GetContacts 連絡先の在席状態一覧を取得
+func (u *presenceUsecase) GetContacts(ctx context.Context, userID int64) ([]*entity.Presence, error) {
+	contactIDs, err := u.participantRepo.FindContactUserIDs(ctx, userID, maxPresenceContacts)
+	if err != nil {
+		return nil, err
+	}
+	return u.presences(ctx, contactIDs)
+}
+
+// IsDoNotDisturb 取り込み中に設定しているか
+// 他のレプリカで設定された場合も反映されるようDBから読む
+func (u *presenceUsecase) IsDoNotDisturb(ctx context.Context, userID int64) (bool, error) {
+	settings, err := u.findSettings(ctx, []int64{userID})
+	if err != nil {
+		return false, err
+	}
+	return settings[userID].IsDoNotDisturb(), nil
+}
+
+// presences 複数ユーザーの在席状態を取得
+func (u *presenceUsecase) presences(ctx context.Context, userIDs []int64) ([]*entity.Presence, error) {
+	settings, err := u.findSettings(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	presences := make([]*entity.Presence, len(userIDs))
+	for i, id := range userIDs {
+		presences[i] = u.resolve(id, settings[id])
+	}
+	return presences, nil
+}
+
+// findSettings 保存されている状態をユーザーIDごとに取得
+func (u *presenceUsecase) findSettings(ctx context.Context, userIDs []int64) (map[int64]*entity.UserPresence, error) {
+	list, err := u.presenceRepo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[int64]*entity.UserPresence, len(list))
+	for _, p := range list {
+		settings[p.UserID] = p
+	}
+	return settings, nil
+}
+
+// resolve このレプリカの接続・通話状況から在席状態を決める。呼び出し側でロックを取ること
+func (u *presenceUsecase) resolve(userID int64, setting *entity.UserPresence) *entity.Presence {
+	return entity.ResolvePresence(userID, setting, u.hub.ConnectionCount(userID) > 0, len(u.rooms[userID]) > 0)
+}
+
+// notify 在席状態が変わっていれば見ているユーザーへ配信。呼び出し側でロックを取ること
+func (u *presenceUsecase) notify(userID int64) {
+	presence := u.resolve(userID, u.settings[userID])
+	if last, ok := u.last[userID]; ok && last == presence.Status {
+		return
+	}
+	u.last[userID] = presence.Status
+
+	event := entity.UserEvent{
+		Type: entity.UserEventPresence,
+		Data: presenceEventData{
+			UserID:     presence.UserID,
+			Status:     string(presence.Status),
+			LastSeenAt: presence.LastSeenAt,
+		},
+	}
+	for watcherID := range u.watchers[userID] {
+		u.hub.Send(watcherID, event)
+	}
+}
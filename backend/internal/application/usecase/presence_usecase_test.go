@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// newPresenceTestUsecase ユーザー1と2が同じ通話に参加したことのある状態で在席状態ユースケースを作成
+func newPresenceTestUsecase(t *testing.T) (PresenceUsecase, CallUsecase, *entity.CallRoom, *testutil.MockPresenceRepository) {
+	t.Helper()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	presenceRepo := testutil.NewMockPresenceRepository()
+	presence := NewPresenceUsecase(presenceRepo, participantRepo, NewUserEventHub())
+	call := NewCallUsecase(roomRepo, participantRepo, testutil.NewMockCallRoomAccessRepository(), testutil.NewMockUserRepository(), nil, NewEventPublishers(testutil.NewMockEventPublisher(), presence))
+
+	ctx := context.Background()
+	past := &entity.CallRoom{RoomID: "past", Name: "Past", CreatedBy: 1, Status: entity.CallRoomStatusEnded}
+	roomRepo.Create(ctx, past)
+	for _, userID := range []int64{1, 2} {
+		participantRepo.Create(ctx, &entity.CallParticipant{RoomID: past.ID, UserID: userID})
+	}
+
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Room", CreatedBy: 2, Status: entity.CallRoomStatusActive}
+	if err := call.CreateRoom(ctx, room, nil); err != nil {
+		t.Fatalf("CreateRoom() unexpected error = %v", err)
+	}
+	return presence, call, room, presenceRepo
+}
+
+// receivePresence 溜まっているpresenceイベントを1件取り出す
+func receivePresence(t *testing.T, sub *UserEventSubscription) presenceEventData {
+	t.Helper()
+	event := receiveUserEvent(t, sub)
+	data, ok := event.Data.(presenceEventData)
+	if event.Type != entity.UserEventPresence || !ok {
+		t.Fatalf("event = %s, want presence", event.Type)
+	}
+	return data
+}
+
+// assertNoUserEvent イベントが溜まっていないことを確認
+func assertNoUserEvent(t *testing.T, sub *UserEventSubscription) {
+	t.Helper()
+	select {
+	case event := <-sub.Events:
+		t.Fatalf("unexpected user event %s", event.Type)
+	default:
+	}
+}
+
+func TestPresenceUsecase_ConnectAndDisconnect(t *testing.T) {
+	presence, _, _, presenceRepo := newPresenceTestUsecase(t)
+	ctx := context.Background()
+
+	snapshot, watcher, err := presence.Connect(ctx, 1)
+	if err != nil {
+		t.Fatalf("Connect() unexpected error = %v", err)
+	}
+	if len(snapshot) != 1 || snapshot[0].UserID != 2 || snapshot[0].Status != entity.PresenceOffline {
+		t.Fatalf("Connect() snapshot = %+v, want contact 2 offline", snapshot)
+	}
+
+	// 複数端末で接続してもオンラインの配信は1回
+	_, phone, err := presence.Connect(ctx, 2)
+	if err != nil {
+		t.Fatalf("Connect() unexpected error = %v", err)
+	}
+	if data := receivePresence(t, watcher); data.UserID != 2 || data.Status != string(entity.PresenceOnline) {
+		t.Errorf("presence = %+v, want user 2 online", data)
+	}
+	_, desktop, err := presence.Connect(ctx, 2)
+	if err != nil {
+		t.Fatalf("Connect() unexpected error = %v", err)
+	}
+	assertNoUserEvent(t, watcher)
+
+	// 最後の端末が切断されたらオフラインになり、最終接続日時が記録される
+	presence.Disconnect(ctx, phone)
+	assertNoUserEvent(t, watcher)
+	presence.Disconnect(ctx, desktop)
+	data := receivePresence(t, watcher)
+	if data.Status != string(entity.PresenceOffline) || data.LastSeenAt == nil {
+		t.Errorf("presence = %+v, want offline with last seen", data)
+	}
+	if presenceRepo.Presences[2].LastSeenAt == nil {
+		t.Error("last seen was not saved")
+	}
+
+	// 連絡先でないユーザーの状態は配信しない
+	_, stranger, err := presence.Connect(ctx, 3)
+	if err != nil {
+		t.Fatalf("Connect() unexpected error = %v", err)
+	}
+	assertNoUserEvent(t, watcher)
+	presence.Disconnect(ctx, stranger)
+}
+
+func TestPresenceUsecase_Status(t *testing.T) {
+	presence, call, room, _ := newPresenceTestUsecase(t)
+	ctx := context.Background()
+
+	_, watcher, err := presence.Connect(ctx, 1)
+	if err != nil {
+		t.Fatalf("Connect() unexpected error = %v", err)
+	}
+	if _, _, err := presence.Connect(ctx, 2); err != nil {
+		t.Fatalf("Connect() unexpected error = %v", err)
+	}
+	receivePresence(t, watcher)
+
+	// 通話への参加・退出で通話中になる
+	if err := call.JoinRoom(ctx, &entity.CallParticipant{RoomID: room.ID, UserID: 2, IsActive: true}); err != nil {
+		t.Fatalf("JoinRoom() unexpected error = %v", err)
+	}
+	if data := receivePresence(t, watcher); data.Status != string(entity.PresenceInCall) {
+		t.Errorf("presence after join = %s, want in-call", data.Status)
+	}
+
+	// 取り込み中は通話中より優先する
+	p, err := presence.SetStatus(ctx, 2, entity.PresenceDoNotDisturb)
+	if err != nil {
+		t.Fatalf("SetStatus() unexpected error = %v", err)
+	}
+	if p.Status != entity.PresenceDoNotDisturb {
+		t.Errorf("SetStatus() = %s, want dnd", p.Status)
+	}
+	if data := receivePresence(t, watcher); data.Status != string(entity.PresenceDoNotDisturb) {
+		t.Errorf("presence after dnd = %s, want dnd", data.Status)
+	}
+	if dnd, err := presence.IsDoNotDisturb(ctx, 2); err != nil || !dnd {
+		t.Errorf("IsDoNotDisturb() = %v, %v, want true", dnd, err)
+	}
+
+	if _, err := presence.SetStatus(ctx, 2, entity.PresenceAway); err != nil {
+		t.Fatalf("SetStatus() unexpected error = %v", err)
+	}
+	if data := receivePresence(t, watcher); data.Status != string(entity.PresenceInCall) {
+		t.Errorf("presence after away while in call = %s, want in-call", data.Status)
+	}
+	if err := call.LeaveRoom(ctx, room.ID, 2); err != nil {
+		t.Fatalf("LeaveRoom() unexpected error = %v", err)
+	}
+	if data := receivePresence(t, watcher); data.Status != string(entity.PresenceAway) {
+		t.Errorf("presence after leave = %s, want away", data.Status)
+	}
+
+	contacts, err := presence.GetContacts(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetContacts() unexpected error = %v", err)
+	}
+	if len(contacts) != 1 || contacts[0].Status != entity.PresenceAway {
+		t.Errorf("GetContacts() = %+v, want user 2 away", contacts)
+	}
+
+	for _, status := range []entity.PresenceStatus{entity.PresenceOffline, entity.PresenceInCall, "busy"} {
+		if _, err := presence.SetStatus(ctx, 2, status); !errors.Is(err, entity.ErrInvalidPresenceStatus) {
+			t.Errorf("SetStatus(%s) error = %v, want ErrInvalidPresenceStatus", status, err)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// PushUsecase Web Pushユースケースのインターフェース
+// アプリを開いていない（ユーザー単位の接続がない）ユーザーへ、端末ごとの購読を使って通知を届ける
+type PushUsecase interface {
+	// ブラウザの購読登録に使うVAPIDの公開鍵（未設定の場合はErrPushNotConfigured）
+	PublicKey() (string, error)
+	// 端末の購読を登録
+	Subscribe(ctx context.Context, subscription *entity.PushSubscription) error
+	// 端末の購読を解除
+	Unsubscribe(ctx context.Context, userID int64, endpoint string) error
+	// 接続中の端末がないユーザーへプッシュ通知を送り、送信できた購読数を返す
+	NotifyOffline(ctx context.Context, userID int64, message *entity.PushMessage) int
+}
+
+// pushPayload Service Workerへ届ける本文
+type pushPayload struct {
+	Type  string                 `json:"type"`
+	Title string                 `json:"title"`
+	Body  string                 `json:"body,omitempty"`
+	Data  map[string]interface{} `json:"data,omitempty"`
+}
+
+type pushUsecase struct {
+	subscriptionRepo port.PushSubscriptionRepository
+	service          port.PushService
+	hub              UserEventHub
+}
+
+// NewPushUsecase 新しいWeb Pushユースケースを作成
+// VAPIDの鍵が設定されていない場合はserviceにnilを渡す（購読・送信は行わない）
+func NewPushUsecase(subscriptionRepo port.PushSubscriptionRepository, service port.PushService, hub UserEventHub) PushUsecase {
+	return &pushUsecase{
+		subscriptionRepo: subscriptionRepo,
+		service:          service,
+		hub:              hub,
+	}
+}
+
+// PublicKey VAPIDの公開鍵を取得
+func (u *pushUsecase) PublicKey() (string, error) {
+	if u.service == nil {
+		return "", entity.ErrPushNotConfigured
+	}
+	return u.service.PublicKey(), nil
+}
+
+// Subscribe 端末の購読を登録
+func (u *pushUsecase) Subscribe(ctx context.Context, subscription *entity.PushSubscription) error {
+	if u.service == nil {
+		return entity.ErrPushNotConfigured
+	}
+	if err := subscription.Validate(); err != nil {
+		return err
+	}
+	return u.subscriptionRepo.Save(ctx, subscription)
+}
+
+// Unsubscribe 端末の購読を解除
+func (u *pushUsecase) Unsubscribe(ctx context.Context, userID int64, endpoint string) error {
+	return u.subscriptionRepo.DeleteByEndpoint(ctx, userID, endpoint)
+}
+
+// NotifyOffline 接続中の端末がないユーザーへプッシュ通知を送る
+// 接続の有無はこのレプリカで見ているため、他のレプリカに接続中のユーザーにも届くことがある
+// （その場合はService Worker側で、開いているウィンドウがあれば表示しない）
+func (u *pushUsecase) NotifyOffline(ctx context.Context, userID int64, message *entity.PushMessage) int {
+	if u.service == nil || u.hub.ConnectionCount(userID) > 0 {
+		return 0
+	}
+
+	subscriptions, err := u.subscriptionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		slog.Error("Failed to load push subscriptions", slog.Int64("user_id", userID), slog.String("error", err.Error()))
+		return 0
+	}
+	if len(subscriptions) == 0 {
+		return 0
+	}
+
+	payload, err := json.Marshal(pushPayload{
+		Type:  message.Type,
+		Title: message.Title,
+		Body:  message.Body,
+		Data:  message.Data,
+	})
+	if err != nil {
+		slog.Error("Failed to encode push payload", slog.String("error", err.Error()))
+		return 0
+	}
+
+	sent := 0
+	for _, subscription := range subscriptions {
+		err := u.service.Send(ctx, subscription, payload, message.Options)
+		switch {
+		case err == nil:
+			sent++
+		case errors.Is(err, entity.ErrPushSubscriptionGone):
+			// ブラウザ側で解除・失効した購読は削除する
+			if err := u.subscriptionRepo.Delete(ctx, subscription.ID); err != nil {
+				slog.Error("Failed to delete expired push subscription", slog.Int64("subscription_id", subscription.ID), slog.String("error", err.Error()))
+			}
+		default:
+			slog.Warn("Failed to send push notification",
+				slog.Int64("user_id", userID),
+				slog.Int64("subscription_id", subscription.ID),
+				slog.String("error", err.Error()))
+		}
+	}
+	return sent
+}
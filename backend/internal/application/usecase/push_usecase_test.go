@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// newPushTestUsecase モックのプッシュサービスへ送るWeb Pushユースケースを作成
+func newPushTestUsecase(hub UserEventHub) (PushUsecase, *testutil.MockPushSubscriptionRepository, *testutil.MockPushService) {
+	repo := testutil.NewMockPushSubscriptionRepository()
+	service := testutil.NewMockPushService()
+	return NewPushUsecase(repo, service, hub), repo, service
+}
+
+// testPushSubscription テスト用の購読
+func testPushSubscription(userID int64, endpoint string) *entity.PushSubscription {
+	return &entity.PushSubscription{UserID: userID, Endpoint: endpoint, P256dh: "p256dh", Auth: "auth"}
+}
+
+func TestPushUsecase_Subscribe(t *testing.T) {
+	push, repo, _ := newPushTestUsecase(NewUserEventHub())
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		subscription *entity.PushSubscription
+		expectedErr  error
+	}{
+		{name: "valid", subscription: testPushSubscription(1, "https://push.example.com/a")},
+		{name: "http endpoint", subscription: testPushSubscription(1, "http://push.example.com/a"), expectedErr: entity.ErrInvalidPushSubscription},
+		{name: "internal endpoint", subscription: testPushSubscription(1, "https://169.254.169.254/latest"), expectedErr: entity.ErrInvalidPushSubscription},
+		{name: "localhost endpoint", subscription: testPushSubscription(1, "https://localhost:8443/push"), expectedErr: entity.ErrInvalidPushSubscription},
+		{name: "missing keys", subscription: &entity.PushSubscription{UserID: 1, Endpoint: "https://push.example.com/a"}, expectedErr: entity.ErrInvalidPushSubscription},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := push.Subscribe(ctx, tt.subscription); !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Subscribe() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+
+	// 同じ端末で別のユーザーがログインし直した場合は付け替える
+	if err := push.Subscribe(ctx, testPushSubscription(2, "https://push.example.com/a")); err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+	if subs, _ := repo.FindByUserID(ctx, 1); len(subs) != 0 {
+		t.Errorf("user 1 subscriptions = %d, want 0", len(subs))
+	}
+
+	if err := push.Unsubscribe(ctx, 2, "https://push.example.com/a"); err != nil {
+		t.Fatalf("Unsubscribe() unexpected error = %v", err)
+	}
+	if len(repo.Subscriptions) != 0 {
+		t.Errorf("subscriptions = %d after Unsubscribe(), want 0", len(repo.Subscriptions))
+	}
+}
+
+func TestPushUsecase_NotifyOffline(t *testing.T) {
+	hub := NewUserEventHub()
+	push, repo, service := newPushTestUsecase(hub)
+	ctx := context.Background()
+
+	for _, endpoint := range []string{"https://push.example.com/phone", "https://push.example.com/old"} {
+		if err := push.Subscribe(ctx, testPushSubscription(1, endpoint)); err != nil {
+			t.Fatalf("Subscribe() unexpected error = %v", err)
+		}
+	}
+	service.GoneEndpoints["https://push.example.com/old"] = true
+	message := &entity.PushMessage{Type: "minutes-ready", Title: "Minutes are ready", Options: entity.PushOptions{TTL: time.Hour}}
+
+	// アプリを開いている間は送らない
+	sub := hub.Subscribe(1)
+	if sent := push.NotifyOffline(ctx, 1, message); sent != 0 || len(service.Sent) != 0 {
+		t.Fatalf("NotifyOffline() while connected = %d, want 0", sent)
+	}
+	hub.Unsubscribe(sub)
+
+	if sent := push.NotifyOffline(ctx, 1, message); sent != 1 {
+		t.Fatalf("NotifyOffline() = %d, want 1", sent)
+	}
+	var payload pushPayload
+	if err := json.Unmarshal(service.Sent[0].Payload, &payload); err != nil || payload.Type != "minutes-ready" || payload.Title != "Minutes are ready" {
+		t.Errorf("payload = %s, want minutes-ready", service.Sent[0].Payload)
+	}
+	if service.Sent[0].Options.TTL != time.Hour {
+		t.Errorf("TTL = %s, want 1h", service.Sent[0].Options.TTL)
+	}
+
+	// 解除済みの購読は削除される
+	if subs, _ := repo.FindByUserID(ctx, 1); len(subs) != 1 || subs[0].Endpoint != "https://push.example.com/phone" {
+		t.Errorf("subscriptions = %d, want only the phone", len(subs))
+	}
+}
+
+func TestPushUsecase_NotConfigured(t *testing.T) {
+	push := NewPushUsecase(testutil.NewMockPushSubscriptionRepository(), nil, NewUserEventHub())
+	ctx := context.Background()
+
+	if _, err := push.PublicKey(); !errors.Is(err, entity.ErrPushNotConfigured) {
+		t.Errorf("PublicKey() error = %v, want ErrPushNotConfigured", err)
+	}
+	if err := push.Subscribe(ctx, testPushSubscription(1, "https://push.example.com/a")); !errors.Is(err, entity.ErrPushNotConfigured) {
+		t.Errorf("Subscribe() error = %v, want ErrPushNotConfigured", err)
+	}
+	if sent := push.NotifyOffline(ctx, 1, &entity.PushMessage{Type: "incoming-call"}); sent != 0 {
+		t.Errorf("NotifyOffline() = %d, want 0", sent)
+	}
+}
+
+func TestPushUsecase_Producers(t *testing.T) {
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	userRepo := testutil.NewMockUserRepository()
+	for _, user := range []*entity.User{
+		{ID: 1, Email: "tanaka@example.com", Name: "Tanaka"},
+		{ID: 2, Email: "suzuki@example.com", Name: "Suzuki"},
+	} {
+		userRepo.Users[user.Email] = user
+	}
+	hub := NewUserEventHub()
+	push, _, service := newPushTestUsecase(hub)
+	notifications := NewNotificationUsecase(testutil.NewMockNotificationRepository(), roomRepo, participantRepo, hub, push)
+	directCalls := NewDirectCallUsecase(
+		testutil.NewMockDirectCallRepository(),
+		roomRepo,
+		userRepo,
+		NewCallUsecase(roomRepo, participantRepo, testutil.NewMockCallRoomAccessRepository(), testutil.NewMockUserRepository(), nil, testutil.NewMockEventPublisher()),
+		hub,
+		NewPresenceUsecase(testutil.NewMockPresenceRepository(), participantRepo, hub),
+		notifications,
+		push,
+		testutil.NewMockDistributedLock(),
+		DirectCallConfig{RingTimeout: 30 * time.Second},
+	)
+	ctx := context.Background()
+	if err := push.Subscribe(ctx, testPushSubscription(2, "https://push.example.com/suzuki")); err != nil {
+		t.Fatalf("Subscribe() unexpected error = %v", err)
+	}
+
+	// 着信は鳴らしている間だけ、すぐに届ける
+	call, _, err := directCalls.StartCall(ctx, 1, 2)
+	if err != nil {
+		t.Fatalf("StartCall() unexpected error = %v", err)
+	}
+	if len(service.Sent) != 1 {
+		t.Fatalf("pushes after StartCall() = %d, want 1", len(service.Sent))
+	}
+	var payload pushPayload
+	json.Unmarshal(service.Sent[0].Payload, &payload)
+	if payload.Type != "incoming-call" || payload.Data["call_id"] != call.CallID || payload.Data["caller_name"] != "Tanaka" {
+		t.Errorf("payload = %s, want incoming-call from Tanaka", service.Sent[0].Payload)
+	}
+	if options := service.Sent[0].Options; options.Urgency != entity.PushUrgencyHigh || options.TTL != 30*time.Second {
+		t.Errorf("options = %+v, want high urgency for the ring timeout", options)
+	}
+
+	// 議事録の作成完了は送るが、招待はアプリ内の通知のみ
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Weekly", CreatedBy: 2, Status: entity.CallRoomStatusEnded}
+	roomRepo.Create(ctx, room)
+	notifications.Publish(ctx, 2, entity.WebhookEventMinutesReady, minutesEventData{RoomID: "room-1", MinutesID: 5, Title: "Weekly"})
+	notifications.Notify(ctx, &entity.Notification{UserID: 2, Type: entity.NotificationCallInvite, Title: "Invite"})
+	if len(service.Sent) != 2 {
+		t.Fatalf("pushes = %d, want 2", len(service.Sent))
+	}
+	json.Unmarshal(service.Sent[1].Payload, &payload)
+	if payload.Type != "minutes-ready" || payload.Data["room_id"] != "room-1" || payload.Data["notification_id"] == nil {
+		t.Errorf("payload = %s, want minutes-ready for room-1", service.Sent[1].Payload)
+	}
+}
@@ -0,0 +1,399 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// RecordingMixUsecase 参加者ごとの録音を1つにまとめるミックスのユースケースのインターフェース
+// ミックスはワーカーが非同期に実行する
+type RecordingMixUsecase interface {
+	// ミックスを依頼（実行待ち・実行中のミックスがある場合はそのミックスを返す）
+	Enqueue(ctx context.Context, roomID int64, requestedBy *int64) (*entity.RecordingMix, error)
+	// ルームの最新のミックスを取得
+	GetLatest(ctx context.Context, roomID int64) (*entity.RecordingMix, error)
+	// 保存したミックス・正規化したコピーをダウンロードする署名付きURLを発行
+	DownloadURL(ctx context.Context, key string) (string, error)
+	// 実行できるミックスを1件確保して実行（ミックスがなかった場合はfalse）
+	RunNext(ctx context.Context, workerID string) (bool, error)
+	// ミックスが追加されたことをワーカーへ知らせるチャネル
+	Wake() <-chan struct{}
+}
+
+// RecordingMixConfig ミックスの実行設定
+type RecordingMixConfig struct {
+	Timeout     time.Duration // 1回の実行の上限（リースの期間にもなる）
+	MaxAttempts int           // ワーカーが停止して引き継がれた場合も含めた最大実行回数
+	URLExpiry   time.Duration // ダウンロードURLの有効期間
+}
+
+type recordingMixUsecase struct {
+	mixRepo       port.RecordingMixRepository
+	roomRepo      port.CallRoomRepository
+	recordingRepo port.CallRecordingRepository
+	storage       port.ObjectStorage
+	mixer         port.AudioMixer
+	hub           UserEventHub
+	config        RecordingMixConfig
+	wake          chan struct{}
+}
+
+// NewRecordingMixUsecase 新しい録音のミックスユースケースを作成（mixerがnilの場合はミックスできない）
+func NewRecordingMixUsecase(
+	mixRepo port.RecordingMixRepository,
+	roomRepo port.CallRoomRepository,
+	recordingRepo port.CallRecordingRepository,
+	storage port.ObjectStorage,
+	mixer port.AudioMixer,
+	hub UserEventHub,
+	config RecordingMixConfig,
+) RecordingMixUsecase {
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Minute
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 3
+	}
+	if config.URLExpiry <= 0 {
+		config.URLExpiry = time.Hour
+	}
+	return &recordingMixUsecase{
+		mixRepo:       mixRepo,
+		roomRepo:      roomRepo,
+		recordingRepo: recordingRepo,
+		storage:       storage,
+		mixer:         mixer,
+		hub:           hub,
+		config:        config,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// recordingMixEventData recording-mix イベントのデータ
+type recordingMixEventData struct {
+	MixID  int64   `json:"mix_id"`
+	RoomID string  `json:"room_id"`
+	Status string  `json:"status"`
+	Error  *string `json:"error,omitempty"`
+}
+
+// Enqueue ミックスを依頼
+func (u *recordingMixUsecase) Enqueue(ctx context.Context, roomID int64, requestedBy *int64) (*entity.RecordingMix, error) {
+	if u.mixer == nil {
+		return nil, entity.ErrAudioMixerNotConfigured
+	}
+
+	// 同じルームのミックスを重複して実行しない
+	latest, err := u.mixRepo.FindLatestByRoomID(ctx, roomID)
+	if err == nil && latest.IsActive() {
+		return latest, nil
+	}
+	if err != nil && !errors.Is(err, entity.ErrRecordingMixNotFound) {
+		return nil, fmt.Errorf("failed to find latest mix: %w", err)
+	}
+
+	room, err := u.roomRepo.FindByID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+
+	recordings, err := u.recordingRepo.FindByRoomID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recordings: %w", err)
+	}
+	if len(recordings) == 0 {
+		return nil, entity.ErrNoRecordings
+	}
+
+	mix := &entity.RecordingMix{
+		RoomID:      roomID,
+		RequestedBy: requestedBy,
+		Status:      entity.RecordingMixQueued,
+		Format:      u.format(),
+	}
+	if err := u.mixRepo.Create(ctx, mix); err != nil {
+		return nil, fmt.Errorf("failed to create recording mix: %w", err)
+	}
+
+	slog.Info("Recording mix queued",
+		slog.Int64("mix_id", mix.ID),
+		slog.Int64("room_id", roomID),
+		slog.Int("recordings_count", len(recordings)),
+	)
+	u.publish(room, mix)
+
+	// 待機中のワーカーを起こす（既に通知済みなら何もしない）
+	select {
+	case u.wake <- struct{}{}:
+	default:
+	}
+	return mix, nil
+}
+
+// GetLatest ルームの最新のミックスを取得
+func (u *recordingMixUsecase) GetLatest(ctx context.Context, roomID int64) (*entity.RecordingMix, error) {
+	return u.mixRepo.FindLatestByRoomID(ctx, roomID)
+}
+
+// DownloadURL ダウンロードする署名付きURLを発行
+func (u *recordingMixUsecase) DownloadURL(ctx context.Context, key string) (string, error) {
+	presigned, err := u.storage.PresignURL(ctx, key, port.PresignOptions{
+		Method:  http.MethodGet,
+		Expires: u.config.URLExpiry,
+	})
+	if err != nil {
+		return "", err
+	}
+	return presigned.URL, nil
+}
+
+// Wake ミックスが追加されたことをワーカーへ知らせるチャネル
+func (u *recordingMixUsecase) Wake() <-chan struct{} {
+	return u.wake
+}
+
+// RunNext 実行できるミックスを1件確保して実行
+// リースは実行の上限と同じ期間で、期限までに終わらなかったミックスは他のワーカーが引き継ぐ
+func (u *recordingMixUsecase) RunNext(ctx context.Context, workerID string) (bool, error) {
+	now := time.Now()
+	mix, err := u.mixRepo.Claim(ctx, workerID, now, now.Add(u.config.Timeout))
+	if errors.Is(err, entity.ErrRecordingMixNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim recording mix: %w", err)
+	}
+
+	slog.Info("Recording mix started",
+		slog.Int64("mix_id", mix.ID),
+		slog.Int64("room_id", mix.RoomID),
+		slog.Int("attempt", mix.Attempts),
+		slog.String("worker", workerID),
+	)
+
+	runCtx, cancel := context.WithTimeout(ctx, u.config.Timeout)
+	defer cancel()
+	return true, u.run(ctx, runCtx, mix)
+}
+
+// run 確保したミックスを実行
+// 参加者ごとに音量を正規化したコピーを保存してから、全員の録音を開始時刻に合わせてミックスする
+func (u *recordingMixUsecase) run(ctx context.Context, runCtx context.Context, mix *entity.RecordingMix) error {
+	room, err := u.roomRepo.FindByID(ctx, mix.RoomID)
+	if err != nil {
+		return u.fail(ctx, nil, mix, fmt.Errorf("failed to get room: %w", err))
+	}
+
+	// 引き継ぎを繰り返すミックス（実行中にワーカーが落ちるなど）は打ち切る
+	if mix.Attempts > u.config.MaxAttempts {
+		return u.fail(ctx, room, mix, fmt.Errorf("gave up after %d attempts", mix.Attempts-1))
+	}
+
+	// 前回の実行が途中で止まっていた場合の出力は作り直す
+	prefix := fmt.Sprintf("recordings/%d/mixes/%d/", mix.RoomID, mix.ID)
+	u.deleteOutputs(ctx, prefix)
+
+	recordings, err := u.recordingRepo.FindByRoomID(runCtx, mix.RoomID)
+	if err != nil {
+		return u.fail(ctx, room, mix, fmt.Errorf("failed to get recordings: %w", err))
+	}
+	recordings = u.storedRecordings(runCtx, recordings)
+	if len(recordings) == 0 {
+		return u.fail(ctx, room, mix, entity.ErrNoRecordings)
+	}
+
+	origin, tracks := u.alignRecordings(recordings)
+	mix.RecordingStartedAt = &origin
+	mix.Tracks = make([]entity.RecordingMixTrack, len(recordings))
+
+	for i, rec := range recordings {
+		key := fmt.Sprintf("%strack-%d.%s", prefix, rec.ID, u.format())
+		body, err := u.mixer.Normalize(runCtx, port.AudioTrack{Open: tracks[i].Open})
+		if err == nil {
+			_, err = u.store(runCtx, key, body)
+		}
+		if err != nil {
+			return u.abort(ctx, room, mix, prefix, fmt.Errorf("failed to normalize recording %d: %w", rec.ID, err))
+		}
+		mix.Tracks[i] = entity.RecordingMixTrack{
+			RecordingID:    rec.ID,
+			UserID:         rec.UserID,
+			Offset:         tracks[i].Offset,
+			NormalizedPath: &key,
+		}
+	}
+
+	key := prefix + "mix." + u.format()
+	body, err := u.mixer.Mix(runCtx, tracks)
+	if err != nil {
+		return u.abort(ctx, room, mix, prefix, fmt.Errorf("failed to mix recordings: %w", err))
+	}
+	object, err := u.store(runCtx, key, body)
+	if err != nil {
+		return u.abort(ctx, room, mix, prefix, fmt.Errorf("failed to mix recordings: %w", err))
+	}
+
+	now := time.Now()
+	mix.Status = entity.RecordingMixSucceeded
+	mix.FilePath = &object.Key
+	mix.FileSize = &object.Size
+	mix.LastError = nil
+	mix.FinishedAt = &now
+	clearMixLease(mix)
+	if err := u.mixRepo.Update(ctx, mix); err != nil {
+		return fmt.Errorf("failed to update recording mix: %w", err)
+	}
+	u.publish(room, mix)
+
+	slog.Info("Recording mix succeeded",
+		slog.Int64("mix_id", mix.ID),
+		slog.Int64("room_id", mix.RoomID),
+		slog.Int("tracks", len(mix.Tracks)),
+		slog.Int64("size", object.Size),
+	)
+	return nil
+}
+
+// storedRecordings ストレージに存在する録音のみを返す（削除された録音はミックスに含めない）
+func (u *recordingMixUsecase) storedRecordings(ctx context.Context, recordings []*entity.CallRecording) []*entity.CallRecording {
+	stored := make([]*entity.CallRecording, 0, len(recordings))
+	for _, rec := range recordings {
+		if _, err := u.storage.Stat(ctx, rec.FilePath); err != nil {
+			slog.Warn("Skipping recording that cannot be mixed",
+				slog.Int64("recording_id", rec.ID),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		stored = append(stored, rec)
+	}
+	return stored
+}
+
+// alignRecordings 最も早く録音を開始した時刻を先頭とし、各録音を開始位置に合わせてミキサーに渡す形にする
+func (u *recordingMixUsecase) alignRecordings(recordings []*entity.CallRecording) (time.Time, []port.AudioTrack) {
+	origin := recordings[0].StartTime()
+	for _, rec := range recordings[1:] {
+		if start := rec.StartTime(); start.Before(origin) {
+			origin = start
+		}
+	}
+
+	tracks := make([]port.AudioTrack, len(recordings))
+	for i, rec := range recordings {
+		key := rec.FilePath
+		tracks[i] = port.AudioTrack{
+			Offset: rec.StartTime().Sub(origin),
+			Open: func(ctx context.Context) (io.ReadCloser, error) {
+				body, _, err := u.storage.Get(ctx, key)
+				return body, err
+			},
+		}
+	}
+	return origin, tracks
+}
+
+// store ミキサーの出力をストレージへ保存
+func (u *recordingMixUsecase) store(ctx context.Context, key string, body io.ReadCloser) (*port.ObjectInfo, error) {
+	defer body.Close()
+	return u.storage.Put(ctx, key, body, -1, port.AudioMixerContentType)
+}
+
+// abort 途中まで保存した出力を削除して失敗にする
+// シャットダウンで中断した場合は実行回数に数えずに実行待ちに戻す
+func (u *recordingMixUsecase) abort(ctx context.Context, room *entity.CallRoom, mix *entity.RecordingMix, prefix string, cause error) error {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return u.release(mix)
+	}
+	u.deleteOutputs(ctx, prefix)
+	return u.fail(ctx, room, mix, cause)
+}
+
+// fail ミックスを失敗にする
+// 音声の変換の失敗は再実行しても変わらないことが多いため、再試行せずに依頼し直してもらう
+func (u *recordingMixUsecase) fail(ctx context.Context, room *entity.CallRoom, mix *entity.RecordingMix, cause error) error {
+	msg := cause.Error()
+	now := time.Now()
+	mix.Status = entity.RecordingMixFailed
+	mix.LastError = &msg
+	mix.FinishedAt = &now
+	clearMixLease(mix)
+
+	slog.Error("Recording mix failed",
+		slog.Int64("mix_id", mix.ID),
+		slog.Int64("room_id", mix.RoomID),
+		slog.String("error", msg),
+	)
+	if err := u.mixRepo.Update(ctx, mix); err != nil {
+		return fmt.Errorf("failed to update recording mix: %w", err)
+	}
+	if room != nil {
+		u.publish(room, mix)
+	}
+	return nil
+}
+
+// release 中断したミックスを実行待ちに戻す
+func (u *recordingMixUsecase) release(mix *entity.RecordingMix) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	mix.Status = entity.RecordingMixQueued
+	mix.Attempts--
+	clearMixLease(mix)
+	if err := u.mixRepo.Update(ctx, mix); err != nil {
+		return fmt.Errorf("failed to release recording mix: %w", err)
+	}
+	slog.Info("Recording mix released", slog.Int64("mix_id", mix.ID))
+	return nil
+}
+
+// deleteOutputs ミックスの出力を削除
+func (u *recordingMixUsecase) deleteOutputs(ctx context.Context, prefix string) {
+	objects, err := u.storage.List(ctx, prefix)
+	if err != nil {
+		slog.Warn("Failed to list recording mix outputs", slog.String("prefix", prefix), slog.String("error", err.Error()))
+		return
+	}
+	for _, object := range objects {
+		if err := u.storage.Delete(ctx, object.Key); err != nil {
+			slog.Warn("Failed to delete recording mix output", slog.String("key", object.Key), slog.String("error", err.Error()))
+		}
+	}
+}
+
+// publish ミックスの状態を依頼したユーザーの全端末へ配信
+func (u *recordingMixUsecase) publish(room *entity.CallRoom, mix *entity.RecordingMix) {
+	if mix.RequestedBy == nil {
+		return
+	}
+	u.hub.Send(*mix.RequestedBy, entity.UserEvent{
+		Type: entity.UserEventRecordingMix,
+		Data: recordingMixEventData{
+			MixID:  mix.ID,
+			RoomID: room.RoomID,
+			Status: string(mix.Status),
+			Error:  mix.LastError,
+		},
+	})
+}
+
+// format ミキサーの出力のフォーマット（拡張子）
+func (u *recordingMixUsecase) format() string {
+	format, _ := entity.RecordingFormat(port.AudioMixerContentType)
+	return format
+}
+
+// clearMixLease ワーカーのリースを解放
+func clearMixLease(mix *entity.RecordingMix) {
+	mix.LeaseOwner = nil
+	mix.LeaseExpiresAt = nil
+}
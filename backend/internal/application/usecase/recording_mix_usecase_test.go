@@ -0,0 +1,305 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// fakeAudioMixer 音声を読み込んで開始位置とともに書き出すミキサー
+type fakeAudioMixer struct {
+	err error // 指定した場合はミックスの出力の最後で返す
+}
+
+func (m *fakeAudioMixer) Mix(ctx context.Context, tracks []port.AudioTrack) (io.ReadCloser, error) {
+	parts := make([]string, 0, len(tracks))
+	for _, track := range tracks {
+		data, err := readTrack(ctx, track)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, fmt.Sprintf("%s@%dms", data, track.Offset.Milliseconds()))
+	}
+	var body io.Reader = strings.NewReader(strings.Join(parts, "+"))
+	if m.err != nil {
+		body = io.MultiReader(body, &errReader{err: m.err})
+	}
+	return io.NopCloser(body), nil
+}
+
+func (m *fakeAudioMixer) Normalize(ctx context.Context, track port.AudioTrack) (io.ReadCloser, error) {
+	data, err := readTrack(ctx, track)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(strings.NewReader("normalized " + data)), nil
+}
+
+func readTrack(ctx context.Context, track port.AudioTrack) (string, error) {
+	body, err := track.Open(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	return string(data), err
+}
+
+type errReader struct{ err error }
+
+func (r *errReader) Read([]byte) (int, error) { return 0, r.err }
+
+// recordingMixTestConfig テスト用のミックス設定（2回まで実行）
+var recordingMixTestConfig = RecordingMixConfig{
+	Timeout:     time.Minute,
+	MaxAttempts: 2,
+}
+
+// mixTestStart テストで最初に録音を始めた時刻
+var mixTestStart = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+// addMixRecording 録音開始からdelay後に録音を始めた参加者の録音を追加
+func addMixRecording(storage *testutil.MockObjectStorage, recordingRepo *testutil.MockCallRecordingRepository, roomID, userID int64, delay time.Duration, data string) *entity.CallRecording {
+	startedAt := mixTestStart.Add(delay)
+	rec := &entity.CallRecording{
+		RoomID:    roomID,
+		UserID:    userID,
+		FilePath:  fmt.Sprintf("recordings/%d/user-%d.webm", roomID, userID),
+		StartedAt: &startedAt,
+	}
+	storage.Put(context.Background(), rec.FilePath, strings.NewReader(data), -1, "audio/webm")
+	recordingRepo.Create(context.Background(), rec)
+	return rec
+}
+
+func TestRecordingMixUsecase_Enqueue(t *testing.T) {
+	tests := []struct {
+		name        string
+		recordings  int
+		noMixer     bool
+		expectedErr error
+	}{
+		{name: "with recordings", recordings: 2},
+		{name: "no recordings", expectedErr: entity.ErrNoRecordings},
+		{name: "mixer not configured", recordings: 1, noMixer: true, expectedErr: entity.ErrAudioMixerNotConfigured},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			recordingRepo := testutil.NewMockCallRecordingRepository()
+			storage := testutil.NewMockObjectStorage()
+			var mixer port.AudioMixer = &fakeAudioMixer{}
+			if tt.noMixer {
+				mixer = nil
+			}
+			usecase := NewRecordingMixUsecase(testutil.NewMockRecordingMixRepository(), roomRepo, recordingRepo, storage, mixer, NewUserEventHub(), recordingMixTestConfig)
+			room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+			roomRepo.Create(ctx, room)
+			for i := 0; i < tt.recordings; i++ {
+				addMixRecording(storage, recordingRepo, room.ID, int64(i+1), 0, "audio")
+			}
+
+			// Act
+			mix, err := usecase.Enqueue(ctx, room.ID, &room.CreatedBy)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Enqueue() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+			if mix.Status != entity.RecordingMixQueued {
+				t.Errorf("Enqueue() status = %s, want queued", mix.Status)
+			}
+			// 実行待ちの間に依頼し直しても同じミックスを返す
+			if again, _ := usecase.Enqueue(ctx, room.ID, nil); again.ID != mix.ID {
+				t.Errorf("Enqueue() while queued = mix %d, want %d", again.ID, mix.ID)
+			}
+		})
+	}
+}
+
+func TestRecordingMixUsecase_RunNext(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	recordingRepo := testutil.NewMockCallRecordingRepository()
+	storage := testutil.NewMockObjectStorage()
+	hub := NewUserEventHub()
+	usecase := NewRecordingMixUsecase(testutil.NewMockRecordingMixRepository(), roomRepo, recordingRepo, storage, &fakeAudioMixer{}, hub, recordingMixTestConfig)
+	room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+	roomRepo.Create(ctx, room)
+	bob := addMixRecording(storage, recordingRepo, room.ID, 2, 1500*time.Millisecond, "bob")
+	alice := addMixRecording(storage, recordingRepo, room.ID, 1, 0, "alice")
+	mix, _ := usecase.Enqueue(ctx, room.ID, &room.CreatedBy)
+	sub := hub.Subscribe(1)
+
+	// Act
+	ran, err := usecase.RunNext(ctx, "worker-1")
+
+	// Assert
+	if err != nil || !ran {
+		t.Fatalf("RunNext() = %v, %v, want true", ran, err)
+	}
+	done, _ := usecase.GetLatest(ctx, room.ID)
+	if done.Status != entity.RecordingMixSucceeded || done.FilePath == nil {
+		t.Fatalf("mix = %+v, want succeeded", done)
+	}
+	if !done.RecordingStartedAt.Equal(mixTestStart) {
+		t.Errorf("RecordingStartedAt = %v, want %v", done.RecordingStartedAt, mixTestStart)
+	}
+	if !strings.HasPrefix(*done.FilePath, fmt.Sprintf("recordings/%d/mixes/%d/", room.ID, mix.ID)) || !strings.HasSuffix(*done.FilePath, ".ogg") {
+		t.Errorf("FilePath = %s", *done.FilePath)
+	}
+
+	// 参加者ごとに音量を正規化したコピー
+	if len(done.Tracks) != 2 {
+		t.Fatalf("Tracks = %d, want 2", len(done.Tracks))
+	}
+	for _, track := range done.Tracks {
+		want := map[int64]string{bob.ID: "normalized bob", alice.ID: "normalized alice"}[track.RecordingID]
+		if got := string(storage.Objects[*track.NormalizedPath]); got != want {
+			t.Errorf("normalized copy of recording %d = %q, want %q", track.RecordingID, got, want)
+		}
+	}
+	if done.Tracks[0].Offset != 1500*time.Millisecond || done.Tracks[1].Offset != 0 {
+		t.Errorf("offsets = %v, %v, want 1.5s and 0", done.Tracks[0].Offset, done.Tracks[1].Offset)
+	}
+
+	var last recordingMixEventData
+	for len(sub.Events) > 0 {
+		last = (<-sub.Events).Data.(recordingMixEventData)
+	}
+	if last.Status != string(entity.RecordingMixSucceeded) || last.RoomID != "room-abc" {
+		t.Errorf("last event = %+v, want succeeded", last)
+	}
+
+	if ran, _ := usecase.RunNext(ctx, "worker-1"); ran {
+		t.Error("RunNext() with no queued mix = true")
+	}
+}
+
+func TestRecordingMixUsecase_Offsets(t *testing.T) {
+	tests := []struct {
+		name       string
+		estimateA  bool // aの録音開始時刻が送られず、アップロード時刻と録音時間から推定する
+		delayA     time.Duration
+		delayB     time.Duration
+		wantOutput string
+	}{
+		{name: "recorded start times", delayA: 0, delayB: 1500 * time.Millisecond, wantOutput: "a@0ms+b@1500ms"},
+		{name: "estimated start time", estimateA: true, wantOutput: "a@30000ms+b@0ms"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			recordingRepo := testutil.NewMockCallRecordingRepository()
+			storage := testutil.NewMockObjectStorage()
+			usecase := NewRecordingMixUsecase(testutil.NewMockRecordingMixRepository(), roomRepo, recordingRepo, storage, &fakeAudioMixer{}, NewUserEventHub(), recordingMixTestConfig)
+			room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+			roomRepo.Create(ctx, room)
+			a := addMixRecording(storage, recordingRepo, room.ID, 1, tt.delayA, "a")
+			if tt.estimateA {
+				duration := 60
+				a.StartedAt = nil
+				a.DurationSeconds = &duration
+				a.UploadedAt = mixTestStart.Add(90 * time.Second)
+			}
+			addMixRecording(storage, recordingRepo, room.ID, 2, tt.delayB, "b")
+			usecase.Enqueue(ctx, room.ID, nil)
+
+			// Act
+			usecase.RunNext(ctx, "worker-1")
+
+			// Assert（録音を開始した時刻の差だけずらしてミックスする）
+			done, _ := usecase.GetLatest(ctx, room.ID)
+			if done.FilePath == nil {
+				t.Fatalf("mix = %+v, want an output", done)
+			}
+			if got := string(storage.Objects[*done.FilePath]); got != tt.wantOutput {
+				t.Errorf("mix = %q, want %q", got, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestRecordingMixUsecase_Failed(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	recordingRepo := testutil.NewMockCallRecordingRepository()
+	storage := testutil.NewMockObjectStorage()
+	mixer := &fakeAudioMixer{err: errors.New("Invalid data found when processing input")}
+	usecase := NewRecordingMixUsecase(testutil.NewMockRecordingMixRepository(), roomRepo, recordingRepo, storage, mixer, NewUserEventHub(), recordingMixTestConfig)
+	room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+	roomRepo.Create(ctx, room)
+	addMixRecording(storage, recordingRepo, room.ID, 1, 0, "alice")
+	mix, _ := usecase.Enqueue(ctx, room.ID, nil)
+
+	// Act
+	_, err := usecase.RunNext(ctx, "worker-1")
+
+	// Assert
+	if err != nil {
+		t.Fatalf("RunNext() unexpected error = %v", err)
+	}
+	failed, _ := usecase.GetLatest(ctx, room.ID)
+	if failed.Status != entity.RecordingMixFailed || failed.LastError == nil || !strings.Contains(*failed.LastError, "Invalid data") {
+		t.Errorf("mix = %+v, want failed with the mixer error", failed)
+	}
+	// 途中まで保存した出力は残さない
+	if keys := storage.Keys(fmt.Sprintf("recordings/%d/mixes/%d/", room.ID, mix.ID)); len(keys) != 0 {
+		t.Errorf("outputs left = %v, want none", keys)
+	}
+	// 失敗した後は依頼し直せる
+	if retry, err := usecase.Enqueue(ctx, room.ID, nil); err != nil || retry.ID == mix.ID {
+		t.Errorf("Enqueue() after failure = %v, %v, want a new mix", retry, err)
+	}
+}
+
+func TestRecordingMixUsecase_TakeOver(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	mixRepo := testutil.NewMockRecordingMixRepository()
+	recordingRepo := testutil.NewMockCallRecordingRepository()
+	storage := testutil.NewMockObjectStorage()
+	usecase := NewRecordingMixUsecase(mixRepo, roomRepo, recordingRepo, storage, &fakeAudioMixer{}, NewUserEventHub(), recordingMixTestConfig)
+	room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+	roomRepo.Create(ctx, room)
+	addMixRecording(storage, recordingRepo, room.ID, 1, 0, "alice")
+	mix, _ := usecase.Enqueue(ctx, room.ID, nil)
+
+	// 実行中のワーカーが止まり、リースが切れるたびに他のワーカーが引き継ぐ
+	mixRepo.Claim(ctx, "crashed-1", time.Now(), time.Now().Add(time.Minute))
+	mixRepo.Expire(mix.ID)
+	mixRepo.Claim(ctx, "crashed-2", time.Now(), time.Now().Add(time.Minute))
+	mixRepo.Expire(mix.ID)
+
+	// Act
+	ran, _ := usecase.RunNext(ctx, "worker-1")
+
+	// Assert（最大実行回数を超えたら打ち切る）
+	if !ran {
+		t.Fatal("RunNext() = false, want to take over the expired mix")
+	}
+	failed, _ := usecase.GetLatest(ctx, room.ID)
+	if failed.Status != entity.RecordingMixFailed {
+		t.Errorf("Status = %s, want failed after too many attempts", failed.Status)
+	}
+}
@@ -0,0 +1,384 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// expiredUploadBatch 1回のクリーンアップで処理する期限切れアップロードの件数
+const expiredUploadBatch = 100
+
+// RecordingUploadUsecase 再開可能な録音アップロード（tus）ユースケースのインターフェース
+type RecordingUploadUsecase interface {
+	// アップロードを作成
+	Create(ctx context.Context, roomID int64, userID int64, length int64, contentType string, duration *int, startedAt *time.Time) (*entity.RecordingUpload, error)
+	// アップロードを取得（他のユーザーのアップロードはErrUploadNotFound）
+	Get(ctx context.Context, id string, userID int64) (*entity.RecordingUpload, error)
+	// offsetからのチャンクを保存し、全体を受信したら録音を作成
+	// 全体を受信済みで録音の作成に失敗していた場合、空のチャンクで作成をやり直す
+	WriteChunk(ctx context.Context, id string, userID int64, offset int64, body io.Reader, checksum *UploadChecksum) (*entity.RecordingUpload, error)
+	// アップロードを中止して保存済みのチャンクを削除
+	Terminate(ctx context.Context, id string, userID int64) error
+	// 期限を過ぎたアップロードを削除（削除した件数を返す）
+	CleanupExpired(ctx context.Context, now time.Time) (int, error)
+	// アップロードできる最大サイズ
+	MaxSize() int64
+}
+
+// UploadChecksum チャンクのチェックサム（Upload-Checksumヘッダー）
+type UploadChecksum struct {
+	Hash hash.Hash
+	Sum  []byte
+}
+
+// RecordingUploadConfig 録音アップロードの設定
+type RecordingUploadConfig struct {
+	MaxSize int64         // 1つの録音の最大サイズ
+	Expiry  time.Duration // 最後にチャンクを受信してから未完了のアップロードを削除するまでの期間
+}
+
+type recordingUploadUsecase struct {
+	uploadRepo port.RecordingUploadRepository
+	storage    port.ObjectStorage
+	recording  RecordingUsecase
+	config     RecordingUploadConfig
+}
+
+// NewRecordingUploadUsecase 新しい録音アップロードユースケースを作成
+func NewRecordingUploadUsecase(
+	uploadRepo port.RecordingUploadRepository,
+	storage port.ObjectStorage,
+	recording RecordingUsecase,
+	config RecordingUploadConfig,
+) RecordingUploadUsecase {
+	if config.MaxSize <= 0 {
+		config.MaxSize = 1 << 30
+	}
+	if config.Expiry <= 0 {
+		config.Expiry = 24 * time.Hour
+	}
+	return &recordingUploadUsecase{
+		uploadRepo: uploadRepo,
+		storage:    storage,
+		recording:  recording,
+		config:     config,
+	}
+}
+
+// MaxSize アップロードできる最大サイズ
+func (u *recordingUploadUsecase) MaxSize() int64 {
+	return u.config.MaxSize
+}
+
+// Create アップロードを作成
+func (u *recordingUploadUsecase) Create(ctx context.Context, roomID int64, userID int64, length int64, contentType string, duration *int, startedAt *time.Time) (*entity.RecordingUpload, error) {
+	if length <= 0 {
+		return nil, entity.ErrInvalidUploadLength
+	}
+	if length > u.config.MaxSize {
+		return nil, entity.ErrUploadTooLarge
+	}
+	if _, ok := entity.RecordingFormat(contentType); !ok {
+		return nil, entity.ErrUnsupportedAudioType
+	}
+
+	id, err := newUploadID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate upload id: %w", err)
+	}
+
+	upload := &entity.RecordingUpload{
+		ID:              id,
+		RoomID:          roomID,
+		UserID:          userID,
+		Length:          length,
+		ContentType:     contentType,
+		DurationSeconds: duration,
+		StartedAt:       startedAt,
+		ExpiresAt:       time.Now().Add(u.config.Expiry),
+	}
+	if err := u.uploadRepo.Create(ctx, upload); err != nil {
+		return nil, fmt.Errorf("failed to create upload: %w", err)
+	}
+	return upload, nil
+}
+
+// Get アップロードを取得
+func (u *recordingUploadUsecase) Get(ctx context.Context, id string, userID int64) (*entity.RecordingUpload, error) {
+	upload, err := u.uploadRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// 他のユーザーにはアップロードの存在を明かさない
+	if upload.UserID != userID {
+		return nil, entity.ErrUploadNotFound
+	}
+	if upload.IsExpired(time.Now()) {
+		return nil, entity.ErrUploadExpired
+	}
+	return upload, nil
+}
+
+// WriteChunk チャンクを保存
+func (u *recordingUploadUsecase) WriteChunk(ctx context.Context, id string, userID int64, offset int64, body io.Reader, checksum *UploadChecksum) (*entity.RecordingUpload, error) {
+	upload, err := u.Get(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if offset != upload.Offset {
+		return nil, entity.ErrUploadOffsetMismatch
+	}
+	if upload.IsComplete() {
+		return upload, nil
+	}
+
+	// 残りのサイズより1バイト多く読み、超えていれば拒否する
+	remaining := upload.Length - upload.Offset
+	var hashed io.Reader = io.LimitReader(body, remaining+1)
+	if checksum != nil {
+		hashed = io.TeeReader(hashed, checksum.Hash)
+	}
+
+	if remaining > 0 {
+		chunk, stored, err := u.storeChunk(ctx, upload, hashed)
+		if err != nil {
+			return nil, err
+		}
+		if stored {
+			if err := u.verifyChunk(ctx, chunk, remaining, checksum); err != nil {
+				return nil, err
+			}
+
+			previous := upload.Offset
+			upload.Chunks = append(upload.Chunks, *chunk)
+			upload.Offset += chunk.Size
+			upload.ExpiresAt = time.Now().Add(u.config.Expiry)
+
+			updated, err := u.uploadRepo.UpdateProgress(ctx, upload, previous)
+			if err != nil {
+				u.deleteObject(ctx, chunk.Key)
+				return nil, fmt.Errorf("failed to update upload: %w", err)
+			}
+			// 同じオフセットへのチャンクが同時に届いた場合は後から保存した方を破棄する
+			if !updated {
+				u.deleteObject(ctx, chunk.Key)
+				return nil, entity.ErrUploadOffsetMismatch
+			}
+		}
+	} else if n, _ := io.ReadFull(hashed, make([]byte, 1)); n > 0 {
+		return nil, entity.ErrUploadTooLarge
+	}
+
+	if upload.Offset == upload.Length {
+		if err := u.complete(ctx, upload); err != nil {
+			return nil, err
+		}
+	}
+	return upload, nil
+}
+
+// storeChunk チャンクをストレージに保存（本文が空の場合は保存せずfalse）
+func (u *recordingUploadUsecase) storeChunk(ctx context.Context, upload *entity.RecordingUpload, body io.Reader) (*entity.RecordingUploadChunk, bool, error) {
+	// 空のPATCHでオブジェクトを作らないよう、先頭の1バイトを読んでから保存する
+	head := make([]byte, 1)
+	n, err := io.ReadFull(body, head)
+	if n == 0 {
+		if err != nil && err != io.EOF {
+			return nil, false, err
+		}
+		return nil, false, nil
+	}
+
+	suffix, err := newUploadID()
+	if err != nil {
+		return nil, false, err
+	}
+	key := fmt.Sprintf("%s%015d-%s", uploadChunkPrefix(upload.ID), upload.Offset, suffix[:8])
+	object, err := u.storage.Put(ctx, key, io.MultiReader(bytes.NewReader(head), body), -1, "application/octet-stream")
+	if err != nil {
+		// 途中で切断されたチャンクは保存しない（クライアントは受信済みのオフセットから再送する）
+		u.deleteObject(ctx, key)
+		return nil, false, fmt.Errorf("failed to store chunk: %w", err)
+	}
+	return &entity.RecordingUploadChunk{Key: object.Key, Offset: upload.Offset, Size: object.Size}, true, nil
+}
+
+// verifyChunk 保存したチャンクのサイズとチェックサムを検証（不正な場合はチャンクを削除）
+func (u *recordingUploadUsecase) verifyChunk(ctx context.Context, chunk *entity.RecordingUploadChunk, remaining int64, checksum *UploadChecksum) error {
+	if chunk.Size > remaining {
+		u.deleteObject(ctx, chunk.Key)
+		return entity.ErrUploadTooLarge
+	}
+	if checksum != nil && !bytes.Equal(checksum.Hash.Sum(nil), checksum.Sum) {
+		u.deleteObject(ctx, chunk.Key)
+		return entity.ErrUploadChecksumMismatch
+	}
+	return nil
+}
+
+// complete チャンクを1つの録音にまとめて登録
+func (u *recordingUploadUsecase) complete(ctx context.Context, upload *entity.RecordingUpload) error {
+	format, ok := entity.RecordingFormat(upload.ContentType)
+	if !ok {
+		return entity.ErrUnsupportedAudioType
+	}
+
+	key := fmt.Sprintf("recordings/%d/user-%d-%d.%s", upload.RoomID, upload.UserID, time.Now().Unix(), format)
+	reader := &chunkReader{ctx: ctx, storage: u.storage, chunks: upload.Chunks}
+	defer reader.Close()
+
+	object, err := u.storage.Put(ctx, key, reader, upload.Length, upload.ContentType)
+	if err != nil {
+		return fmt.Errorf("failed to assemble recording: %w", err)
+	}
+
+	recording, err := u.recording.RegisterRecording(ctx, upload.RoomID, upload.UserID, object, upload.DurationSeconds, upload.StartedAt)
+	if err != nil {
+		u.deleteObject(ctx, object.Key)
+		return err
+	}
+
+	if err := u.uploadRepo.Complete(ctx, upload.ID, recording.ID); err != nil {
+		return fmt.Errorf("failed to complete upload: %w", err)
+	}
+	upload.RecordingID = &recording.ID
+	upload.Chunks = nil
+
+	u.deleteChunks(ctx, upload.ID)
+
+	slog.Info("Recording upload completed",
+		slog.String("upload_id", upload.ID),
+		slog.Int64("recording_id", recording.ID),
+		slog.Int64("size", object.Size))
+	return nil
+}
+
+// Terminate アップロードを中止
+func (u *recordingUploadUsecase) Terminate(ctx context.Context, id string, userID int64) error {
+	upload, err := u.uploadRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if upload.UserID != userID {
+		return entity.ErrUploadNotFound
+	}
+
+	// 完了済みの場合、作成した録音は残す
+	if err := u.uploadRepo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete upload: %w", err)
+	}
+	u.deleteChunks(ctx, id)
+	return nil
+}
+
+// CleanupExpired 期限を過ぎたアップロードを削除
+func (u *recordingUploadUsecase) CleanupExpired(ctx context.Context, now time.Time) (int, error) {
+	uploads, err := u.uploadRepo.FindExpired(ctx, now, expiredUploadBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	for _, upload := range uploads {
+		if err := u.uploadRepo.Delete(ctx, upload.ID); err != nil {
+			slog.Error("Failed to delete expired upload",
+				slog.String("upload_id", upload.ID),
+				slog.String("error", err.Error()))
+			continue
+		}
+		u.deleteChunks(ctx, upload.ID)
+		deleted++
+	}
+	return deleted, nil
+}
+
+// deleteChunks アップロードのチャンクをすべて削除
+// DBに記録する前に停止した場合のチャンクも残さないよう、プレフィックスで検索する
+func (u *recordingUploadUsecase) deleteChunks(ctx context.Context, id string) {
+	objects, err := u.storage.List(ctx, uploadChunkPrefix(id))
+	if err != nil {
+		slog.Error("Failed to list upload chunks",
+			slog.String("upload_id", id),
+			slog.String("error", err.Error()))
+		return
+	}
+	for _, object := range objects {
+		u.deleteObject(ctx, object.Key)
+	}
+}
+
+func (u *recordingUploadUsecase) deleteObject(ctx context.Context, key string) {
+	if err := u.storage.Delete(ctx, key); err != nil {
+		slog.Error("Failed to delete upload object",
+			slog.String("key", key),
+			slog.String("error", err.Error()))
+	}
+}
+
+// uploadChunkPrefix アップロードのチャンクを保存するキーのプレフィックス
+func uploadChunkPrefix(id string) string {
+	return "uploads/" + id + "/"
+}
+
+// newUploadID ランダムなアップロードIDを生成
+func newUploadID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// chunkReader 保存済みのチャンクを順に読み込むReader（必要になったチャンクから開く）
+type chunkReader struct {
+	ctx     context.Context
+	storage port.ObjectStorage
+	chunks  []entity.RecordingUploadChunk
+	current io.ReadCloser
+}
+
+func (c *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if c.current == nil {
+			if len(c.chunks) == 0 {
+				return 0, io.EOF
+			}
+			body, _, err := c.storage.Get(c.ctx, c.chunks[0].Key)
+			if err != nil {
+				return 0, fmt.Errorf("failed to open chunk %s: %w", c.chunks[0].Key, err)
+			}
+			c.current = body
+			c.chunks = c.chunks[1:]
+		}
+
+		n, err := c.current.Read(p)
+		if err == io.EOF {
+			c.current.Close()
+			c.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (c *chunkReader) Close() error {
+	if c.current == nil {
+		return nil
+	}
+	err := c.current.Close()
+	c.current = nil
+	return err
+}
@@ -0,0 +1,293 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// uploadRecordingUsecase 登録された録音を記録するRecordingUsecase
+type uploadRecordingUsecase struct {
+	fakeRecordingUsecase
+	registered []*port.ObjectInfo
+	err        error
+	recordings *testutil.MockCallRecordingRepository // 指定した場合は録音を保存する
+}
+
+func (f *uploadRecordingUsecase) RegisterRecording(ctx context.Context, roomID int64, userID int64, object *port.ObjectInfo, duration *int, startedAt *time.Time) (*entity.CallRecording, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.registered = append(f.registered, object)
+	recording := &entity.CallRecording{ID: int64(len(f.registered)), RoomID: roomID, UserID: userID, FilePath: object.Key, FileSize: object.Size, StartedAt: startedAt}
+	if f.recordings != nil {
+		f.recordings.Create(ctx, recording)
+	}
+	return recording, nil
+}
+
+// recordingUploadTestConfig テスト用のアップロード設定（最大1KB、1時間で期限切れ）
+var recordingUploadTestConfig = RecordingUploadConfig{
+	MaxSize: 1024,
+	Expiry:  time.Hour,
+}
+
+func TestRecordingUploadUsecase_Create(t *testing.T) {
+	tests := []struct {
+		name        string
+		length      int64
+		contentType string
+		expectedErr error
+	}{
+		{name: "webm with codecs", length: 10, contentType: "audio/webm;codecs=opus"},
+		{name: "over max size", length: 2048, contentType: "audio/webm", expectedErr: entity.ErrUploadTooLarge},
+		{name: "not audio", length: 10, contentType: "text/plain", expectedErr: entity.ErrUnsupportedAudioType},
+		{name: "zero length", length: 0, contentType: "audio/webm", expectedErr: entity.ErrInvalidUploadLength},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			uploadRepo := testutil.NewMockRecordingUploadRepository()
+			usecase := NewRecordingUploadUsecase(uploadRepo, testutil.NewMockObjectStorage(), &uploadRecordingUsecase{}, recordingUploadTestConfig)
+			ctx := context.Background()
+
+			// Act
+			upload, err := usecase.Create(ctx, 1, 10, tt.length, tt.contentType, nil, nil)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Create() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr != nil {
+				if len(uploadRepo.Uploads) != 0 {
+					t.Error("Create() should not save a rejected upload")
+				}
+				return
+			}
+			if upload.Offset != 0 || upload.Length != tt.length || upload.IsComplete() {
+				t.Errorf("Create() = %+v, want an empty upload of %d bytes", upload, tt.length)
+			}
+		})
+	}
+}
+
+func TestRecordingUploadUsecase_WriteChunk(t *testing.T) {
+	goodSum := sha256.Sum256([]byte("12"))
+
+	tests := []struct {
+		name        string
+		written     string // 事前に書き込んだチャンク
+		userID      int64
+		offset      int64
+		chunk       string
+		checksum    []byte
+		wantOffset  int64
+		expectedErr error
+	}{
+		{name: "first chunk", userID: 10, chunk: "12", wantOffset: 2},
+		{name: "resume from offset", written: "12", userID: 10, offset: 2, chunk: "34", wantOffset: 4},
+		{name: "stale offset", written: "12", userID: 10, offset: 0, chunk: "12", wantOffset: 2, expectedErr: entity.ErrUploadOffsetMismatch},
+		{name: "other user", userID: 11, chunk: "12", expectedErr: entity.ErrUploadNotFound},
+		{name: "over declared length", userID: 10, chunk: "1234567", expectedErr: entity.ErrUploadTooLarge},
+		{name: "matching checksum", userID: 10, chunk: "12", checksum: goodSum[:], wantOffset: 2},
+		{name: "checksum mismatch", userID: 10, chunk: "12", checksum: bytes.Repeat([]byte{0}, sha256.Size), expectedErr: entity.ErrUploadChecksumMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			storage := testutil.NewMockObjectStorage()
+			recording := &uploadRecordingUsecase{}
+			usecase := NewRecordingUploadUsecase(testutil.NewMockRecordingUploadRepository(), storage, recording, recordingUploadTestConfig)
+			ctx := context.Background()
+			upload, _ := usecase.Create(ctx, 1, 10, 6, "audio/ogg", nil, nil)
+			if tt.written != "" {
+				usecase.WriteChunk(ctx, upload.ID, 10, 0, strings.NewReader(tt.written), nil)
+			}
+			var checksum *UploadChecksum
+			if tt.checksum != nil {
+				checksum = &UploadChecksum{Hash: sha256.New(), Sum: tt.checksum}
+			}
+
+			// Act
+			_, err := usecase.WriteChunk(ctx, upload.ID, tt.userID, tt.offset, strings.NewReader(tt.chunk), checksum)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("WriteChunk() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			current, err := usecase.Get(ctx, upload.ID, 10)
+			if err != nil {
+				t.Fatalf("Get() unexpected error = %v", err)
+			}
+			if current.Offset != tt.wantOffset {
+				t.Errorf("Offset = %d, want %d", current.Offset, tt.wantOffset)
+			}
+			// 拒否したチャンクは保存しない
+			if keys := storage.Keys("uploads/"); tt.wantOffset == 0 && len(keys) != 0 {
+				t.Errorf("chunks left = %v, want none", keys)
+			}
+			if current.IsComplete() || len(recording.registered) != 0 {
+				t.Error("incomplete upload should not register a recording")
+			}
+		})
+	}
+}
+
+func TestRecordingUploadUsecase_Complete(t *testing.T) {
+	// Arrange
+	storage := testutil.NewMockObjectStorage()
+	recording := &uploadRecordingUsecase{}
+	usecase := NewRecordingUploadUsecase(testutil.NewMockRecordingUploadRepository(), storage, recording, recordingUploadTestConfig)
+	ctx := context.Background()
+	upload, err := usecase.Create(ctx, 1, 10, 10, "audio/webm;codecs=opus", nil, nil)
+	if err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+	if _, err := usecase.WriteChunk(ctx, upload.ID, 10, 0, strings.NewReader("hello"), nil); err != nil {
+		t.Fatalf("WriteChunk() unexpected error = %v", err)
+	}
+
+	// Act
+	done, err := usecase.WriteChunk(ctx, upload.ID, 10, 5, strings.NewReader("world"), nil)
+
+	// Assert
+	if err != nil {
+		t.Fatalf("WriteChunk() unexpected error = %v", err)
+	}
+	if !done.IsComplete() || *done.RecordingID != 1 {
+		t.Fatalf("RecordingID = %v, want 1", done.RecordingID)
+	}
+	if len(recording.registered) != 1 {
+		t.Fatalf("registered = %d, want 1", len(recording.registered))
+	}
+	object := recording.registered[0]
+	if !strings.HasPrefix(object.Key, "recordings/1/user-10-") || !strings.HasSuffix(object.Key, ".webm") {
+		t.Errorf("Key = %s, want recordings/1/user-10-*.webm", object.Key)
+	}
+	if got := string(storage.Objects[object.Key]); got != "helloworld" {
+		t.Errorf("recording = %q, want helloworld", got)
+	}
+	if keys := storage.Keys("uploads/"); len(keys) != 0 {
+		t.Errorf("chunks left = %v, want none", keys)
+	}
+
+	// 完了後に届いた空のチャンクは完了済みのアップロードを返す
+	again, err := usecase.WriteChunk(ctx, upload.ID, 10, 10, strings.NewReader(""), nil)
+	if err != nil || !again.IsComplete() || len(recording.registered) != 1 {
+		t.Errorf("WriteChunk after completion = %v, %v, want completed upload without a new recording", again, err)
+	}
+}
+
+func TestRecordingUploadUsecase_RetryCompletion(t *testing.T) {
+	// Arrange
+	storage := testutil.NewMockObjectStorage()
+	recording := &uploadRecordingUsecase{err: errors.New("db down")}
+	usecase := NewRecordingUploadUsecase(testutil.NewMockRecordingUploadRepository(), storage, recording, recordingUploadTestConfig)
+	ctx := context.Background()
+	upload, _ := usecase.Create(ctx, 1, 10, 3, "audio/webm", nil, nil)
+	if _, err := usecase.WriteChunk(ctx, upload.ID, 10, 0, strings.NewReader("abc"), nil); err == nil {
+		t.Fatal("WriteChunk error = nil, want registration error")
+	}
+	if keys := storage.Keys("recordings/"); len(keys) != 0 {
+		t.Errorf("recordings = %v, want none after failed registration", keys)
+	}
+
+	// Act（全体を受信済みなので、空のチャンクで録音の作成をやり直せる）
+	recording.err = nil
+	done, err := usecase.WriteChunk(ctx, upload.ID, 10, 3, strings.NewReader(""), nil)
+
+	// Assert
+	if err != nil || !done.IsComplete() {
+		t.Fatalf("retry completion = %v, %v, want completed", done, err)
+	}
+	if got := string(storage.Objects[recording.registered[0].Key]); got != "abc" {
+		t.Errorf("recording = %q, want abc", got)
+	}
+}
+
+func TestRecordingUploadUsecase_Terminate(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      int64
+		expectedErr error
+	}{
+		{name: "owner", userID: 10},
+		{name: "other user", userID: 11, expectedErr: entity.ErrUploadNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			storage := testutil.NewMockObjectStorage()
+			usecase := NewRecordingUploadUsecase(testutil.NewMockRecordingUploadRepository(), storage, &uploadRecordingUsecase{}, recordingUploadTestConfig)
+			ctx := context.Background()
+			upload, _ := usecase.Create(ctx, 1, 10, 10, "audio/webm", nil, nil)
+			usecase.WriteChunk(ctx, upload.ID, 10, 0, strings.NewReader("abc"), nil)
+
+			// Act
+			err := usecase.Terminate(ctx, upload.ID, tt.userID)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Terminate() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			_, err = usecase.Get(ctx, upload.ID, 10)
+			if tt.expectedErr != nil {
+				if err != nil {
+					t.Errorf("Get() after rejected terminate error = %v, want the upload kept", err)
+				}
+				return
+			}
+			if !errors.Is(err, entity.ErrUploadNotFound) {
+				t.Errorf("Get() after terminate error = %v, want ErrUploadNotFound", err)
+			}
+			if keys := storage.Keys("uploads/"); len(keys) != 0 {
+				t.Errorf("chunks left = %v, want none", keys)
+			}
+		})
+	}
+}
+
+func TestRecordingUploadUsecase_CleanupExpired(t *testing.T) {
+	// Arrange
+	uploadRepo := testutil.NewMockRecordingUploadRepository()
+	storage := testutil.NewMockObjectStorage()
+	usecase := NewRecordingUploadUsecase(uploadRepo, storage, &uploadRecordingUsecase{}, recordingUploadTestConfig)
+	ctx := context.Background()
+	abandoned, _ := usecase.Create(ctx, 1, 10, 10, "audio/webm", nil, nil)
+	usecase.WriteChunk(ctx, abandoned.ID, 10, 0, strings.NewReader("abc"), nil)
+	active, _ := usecase.Create(ctx, 1, 10, 10, "audio/webm", nil, nil)
+
+	later := time.Now().Add(2 * time.Hour)
+	uploadRepo.Uploads[active.ID].ExpiresAt = later.Add(time.Hour)
+	uploadRepo.Uploads[abandoned.ID].ExpiresAt = time.Now().Add(-time.Second)
+
+	// 期限を過ぎたアップロードにはチャンクを書き込めない
+	if _, err := usecase.WriteChunk(ctx, abandoned.ID, 10, 3, strings.NewReader("def"), nil); !errors.Is(err, entity.ErrUploadExpired) {
+		t.Errorf("WriteChunk after expiry error = %v, want ErrUploadExpired", err)
+	}
+
+	// Act
+	deleted, err := usecase.CleanupExpired(ctx, later)
+
+	// Assert
+	if err != nil || deleted != 1 {
+		t.Fatalf("CleanupExpired = %d, %v, want 1", deleted, err)
+	}
+	if _, ok := uploadRepo.Uploads[active.ID]; !ok {
+		t.Error("active upload was deleted")
+	}
+	if keys := storage.Keys("uploads/"); len(keys) != 0 {
+		t.Errorf("chunks left = %v, want none", keys)
+	}
+}
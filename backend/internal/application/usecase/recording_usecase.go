@@ -2,9 +2,12 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -12,30 +15,51 @@ import (
 	"Go-Next-WebRTC/internal/domain/entity"
 	"Go-Next-WebRTC/internal/domain/port"
 	"Go-Next-WebRTC/pkg/email"
-	"Go-Next-WebRTC/pkg/storage"
-	"Go-Next-WebRTC/pkg/transcription"
 )
 
 // RecordingUsecase 録音・文字起こしユースケースのインターフェース
 type RecordingUsecase interface {
-	// 録音アップロード
-	UploadRecording(ctx context.Context, roomID int64, userID int64, file io.Reader, fileSize int64, duration *int) (*entity.CallRecording, error)
+	// 録音アップロード（startedAtは録音を開始した時刻。不明な場合はnil）
+	UploadRecording(ctx context.Context, roomID int64, userID int64, file io.Reader, fileSize int64, duration *int, startedAt *time.Time) (*entity.CallRecording, error)
+	// 保存済みのオブジェクトを録音として登録（再開可能なアップロードの完了時に呼び出す）
+	RegisterRecording(ctx context.Context, roomID int64, userID int64, object *port.ObjectInfo, duration *int, startedAt *time.Time) (*entity.CallRecording, error)
 	// 文字起こしと議事録作成
 	TranscribeAndCreateMinutes(ctx context.Context, roomID int64) error
+	// 文字起こしと議事録作成（録音ごとの進捗を通知する。文字起こしジョブから呼び出す）
+	TranscribeRoom(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error)
 	// 議事録取得
 	GetMinutes(ctx context.Context, roomID int64) (*entity.CallMinutes, error)
+	// ルームの文字起こしの実行一覧取得（新しい順）
+	GetTranscriptionRuns(ctx context.Context, roomID int64) ([]*entity.TranscriptionRun, error)
+	// 文字起こしの実行とその区間を取得（実行どうしの比較に使う）
+	GetTranscriptionRun(ctx context.Context, roomID int64, runID int64) (*entity.TranscriptionRun, []*entity.CallTranscription, error)
+	// 現在の実行を切り替えて議事録を作り直す（成功した実行のみ）
+	SetCurrentTranscriptionRun(ctx context.Context, roomID int64, runID int64) (*entity.CallMinutes, error)
+}
+
+// TranscribeRoomOptions 文字起こしの実行条件
+type TranscribeRoomOptions struct {
+	// 一部の録音の文字起こしに失敗しても議事録を作成する（falseの場合はエラーを返す）
+	AllowPartial bool
+	// 文字起こしジョブから実行する場合のジョブID（実行に記録する）
+	JobID *int64
+	// 録音ごとの進捗の通知先
+	OnProgress func(recordingID int64, status entity.TranscriptionItemStatus, segments int, err error)
 }
 
 type recordingUsecase struct {
 	recordingRepo      port.CallRecordingRepository
 	transcriptionRepo  port.CallTranscriptionRepository
+	runRepo            port.TranscriptionRunRepository
 	minutesRepo        port.CallMinutesRepository
+	notesRepo          port.CallNotesRepository
 	participantRepo    port.CallParticipantRepository
 	roomRepo           port.CallRoomRepository
 	userRepo           port.UserRepository
-	gcsClient          *storage.GCSClient
-	speechClient       *transcription.SpeechToTextClient
+	storage            port.ObjectStorage
+	transcriber        port.Transcriber
 	emailClient        *email.SMTPClient
+	events             EventPublisher
 	frontendURL        string
 }
 
@@ -43,188 +67,442 @@ type recordingUsecase struct {
 func NewRecordingUsecase(
 	recordingRepo port.CallRecordingRepository,
 	transcriptionRepo port.CallTranscriptionRepository,
+	runRepo port.TranscriptionRunRepository,
 	minutesRepo port.CallMinutesRepository,
+	notesRepo port.CallNotesRepository,
 	participantRepo port.CallParticipantRepository,
 	roomRepo port.CallRoomRepository,
 	userRepo port.UserRepository,
-	gcsClient *storage.GCSClient,
-	speechClient *transcription.SpeechToTextClient,
+	storage port.ObjectStorage,
+	transcriber port.Transcriber,
 	emailClient *email.SMTPClient,
+	events EventPublisher,
 	frontendURL string,
 ) RecordingUsecase {
 	return &recordingUsecase{
 		recordingRepo:     recordingRepo,
 		transcriptionRepo: transcriptionRepo,
+		runRepo:           runRepo,
 		minutesRepo:       minutesRepo,
+		notesRepo:         notesRepo,
 		participantRepo:   participantRepo,
 		roomRepo:          roomRepo,
 		userRepo:          userRepo,
-		gcsClient:         gcsClient,
-		speechClient:      speechClient,
+		storage:           storage,
+		transcriber:       transcriber,
 		emailClient:       emailClient,
+		events:            events,
 		frontendURL:       frontendURL,
 	}
 }
 
 // UploadRecording 録音をアップロード
-func (u *recordingUsecase) UploadRecording(ctx context.Context, roomID int64, userID int64, file io.Reader, fileSize int64, duration *int) (*entity.CallRecording, error) {
-	// GCS未設定の場合はエラー
-	if u.gcsClient == nil {
-		return nil, fmt.Errorf("GCS client not configured - recording upload is not available")
+func (u *recordingUsecase) UploadRecording(ctx context.Context, roomID int64, userID int64, file io.Reader, fileSize int64, duration *int, startedAt *time.Time) (*entity.CallRecording, error) {
+	// オブジェクトストレージにアップロード（FilePathにはバックエンドに依存しないキーを保存する）
+	objectKey := fmt.Sprintf("recordings/%d/user-%d-%d.webm", roomID, userID, time.Now().Unix())
+	object, err := u.storage.Put(ctx, objectKey, file, fileSize, "audio/webm")
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload recording: %w", err)
 	}
 
-	// GCSにアップロード
-	objectName := fmt.Sprintf("recordings/%d/user-%d-%d.webm", roomID, userID, time.Now().Unix())
-	filePath, err := u.gcsClient.UploadFile(ctx, objectName, file, "audio/webm")
-	if err != nil {
-		return nil, fmt.Errorf("failed to upload to GCS: %w", err)
+	return u.RegisterRecording(ctx, roomID, userID, object, duration, startedAt)
+}
+
+// RegisterRecording 保存済みのオブジェクトを録音として登録
+func (u *recordingUsecase) RegisterRecording(ctx context.Context, roomID int64, userID int64, object *port.ObjectInfo, duration *int, startedAt *time.Time) (*entity.CallRecording, error) {
+	format, ok := entity.RecordingFormat(object.ContentType)
+	if !ok {
+		return nil, entity.ErrUnsupportedAudioType
 	}
 
 	// DB に保存
 	recording := &entity.CallRecording{
 		RoomID:          roomID,
 		UserID:          userID,
-		FilePath:        filePath,
-		FileSize:        fileSize,
+		FilePath:        object.Key,
+		FileSize:        object.Size,
 		DurationSeconds: duration,
-		Format:          "webm",
+		Format:          format,
+		StartedAt:       startedAt,
 	}
 
 	if err := u.recordingRepo.Create(ctx, recording); err != nil {
 		return nil, fmt.Errorf("failed to create recording: %w", err)
 	}
 
+	if room, err := u.roomRepo.FindByID(ctx, roomID); err == nil {
+		u.events.Publish(ctx, room.CreatedBy, entity.WebhookEventRecordingUploaded, recordingEventData{
+			RoomID:          room.RoomID,
+			RecordingID:     recording.ID,
+			UserID:          recording.UserID,
+			FileSize:        recording.FileSize,
+			DurationSeconds: recording.DurationSeconds,
+			Format:          recording.Format,
+		})
+	}
+
 	return recording, nil
 }
 
 // TranscribeAndCreateMinutes 文字起こしと議事録作成
+// 一部の録音の文字起こしに失敗しても、残りの録音から議事録を作成する
 func (u *recordingUsecase) TranscribeAndCreateMinutes(ctx context.Context, roomID int64) error {
-	// Speech-to-Text未設定の場合はエラー
-	if u.speechClient == nil {
-		return fmt.Errorf("Speech-to-Text client not configured - transcription is not available")
+	_, err := u.TranscribeRoom(ctx, roomID, TranscribeRoomOptions{AllowPartial: true})
+	return err
+}
+
+// TranscribeRoom ルームの録音を文字起こしして議事録を作成
+// 実行ごとに条件と区間を残し、成功した実行を現在の実行にして議事録を作り直す（前回までの実行は残す）
+func (u *recordingUsecase) TranscribeRoom(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error) {
+	// 文字起こしエンジン未設定の場合はエラー
+	if u.transcriber == nil {
+		return nil, entity.ErrTranscriberNotConfigured
 	}
 
 	// ルーム情報を取得
 	room, err := u.roomRepo.FindByID(ctx, roomID)
 	if err != nil {
-		return fmt.Errorf("failed to get room: %w", err)
+		return nil, fmt.Errorf("failed to get room: %w", err)
 	}
 
 	// 録音ファイル一覧を取得
 	recordings, err := u.recordingRepo.FindByRoomID(ctx, roomID)
 	if err != nil {
-		return fmt.Errorf("failed to get recordings: %w", err)
+		return nil, fmt.Errorf("failed to get recordings: %w", err)
 	}
 
 	if len(recordings) == 0 {
-		return fmt.Errorf("no recordings found")
+		return nil, entity.ErrNoRecordings
+	}
+
+	// 実行を記録（エンジンと言語設定は実行時点のものを残す）
+	run := &entity.TranscriptionRun{
+		RoomID:               roomID,
+		JobID:                opts.JobID,
+		Engine:               u.transcriber.Name(),
+		Language:             room.TranscriptionLanguageOrDefault(),
+		AlternativeLanguages: room.TranscriptionAlternativeLanguages,
+		Status:               entity.TranscriptionRunRunning,
+		StartedAt:            time.Now(),
+	}
+	if err := u.runRepo.Create(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to create transcription run: %w", err)
 	}
 
 	slog.Info("Starting transcription",
 		slog.Int64("room_id", roomID),
+		slog.Int64("run_id", run.ID),
 		slog.Int("recordings_count", len(recordings)),
 	)
 
-	// 全ての録音を文字起こし
+	transcriptions, err := u.transcribeRun(ctx, room, run, recordings, opts)
+	if err != nil {
+		u.failRun(run, err)
+		return nil, err
+	}
+
+	// 議事録を作り直す
+	minutes, err := u.writeMinutes(ctx, room, run.ID, transcriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Transcription and minutes creation completed", slog.Int64("room_id", roomID), slog.Int64("run_id", run.ID))
+
+	return minutes, nil
+}
+
+// transcribeRun ルームの録音を文字起こしして実行の区間として保存し、実行を成功にして現在の実行にする
+func (u *recordingUsecase) transcribeRun(ctx context.Context, room *entity.CallRoom, run *entity.TranscriptionRun, recordings []*entity.CallRecording, opts TranscribeRoomOptions) ([]*entity.CallTranscription, error) {
+	progress := opts.OnProgress
+	if progress == nil {
+		progress = func(int64, entity.TranscriptionItemStatus, int, error) {}
+	}
+
+	// 全ての録音をルームの言語設定で文字起こし
+	transcribeOpts := transcribeOptions(room)
 	allTranscriptions := make([]*entity.CallTranscription, 0)
+	transcribed := 0
+	var failed error
 	for _, rec := range recordings {
-		// GCS URIから文字起こし
-		results, err := u.speechClient.TranscribeFromGCS(ctx, rec.FilePath, "ja-JP", true)
+		progress(rec.ID, entity.TranscriptionItemRunning, 0, nil)
+
+		results, err := u.transcribeRecording(ctx, rec, transcribeOpts)
+		// 再試行しても文字起こしできない録音（エンジンが扱えない・ファイルがない）は飛ばす
+		if errors.Is(err, port.ErrAudioNotSupported) || errors.Is(err, port.ErrObjectNotFound) {
+			slog.Warn("Skipping recording that cannot be transcribed",
+				slog.Int64("recording_id", rec.ID),
+				slog.String("error", err.Error()),
+			)
+			progress(rec.ID, entity.TranscriptionItemSkipped, 0, err)
+			continue
+		}
 		if err != nil {
 			slog.Error("Failed to transcribe recording",
 				slog.Int64("recording_id", rec.ID),
 				slog.String("error", err.Error()),
 			)
+			progress(rec.ID, entity.TranscriptionItemFailed, 0, err)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if failed == nil {
+				failed = fmt.Errorf("failed to transcribe recording %d: %w", rec.ID, err)
+			}
 			continue
 		}
 
-		// 結果をEntityに変換
-		for _, r := range results {
+		// 結果をEntityに変換（区間の時刻は録音の開始時刻を基準に実時間へ直す）
+		origin := rec.StartTime()
+		for i := range results {
+			r := results[i]
+			startedAt := origin.Add(secondsToDuration(r.StartTime))
+			endedAt := origin.Add(secondsToDuration(r.EndTime))
 			t := &entity.CallTranscription{
-				RoomID:      roomID,
+				RoomID:      room.ID,
+				RunID:       &run.ID,
 				RecordingID: &rec.ID,
+				UserID:      &rec.UserID,
 				SpeakerTag:  &r.SpeakerTag,
 				Text:        r.Text,
 				Confidence:  &r.Confidence,
 				StartTime:   &r.StartTime,
 				EndTime:     &r.EndTime,
-				Language:    "ja-JP",
+				StartedAt:   &startedAt,
+				EndedAt:     &endedAt,
+				Language:    segmentLanguage(r, transcribeOpts),
 			}
 			allTranscriptions = append(allTranscriptions, t)
 		}
+		transcribed++
+		progress(rec.ID, entity.TranscriptionItemDone, len(results), nil)
 	}
 
+	// 再試行する場合は、失敗した録音を含めずに議事録を作成しない
+	if failed != nil && !opts.AllowPartial {
+		return nil, failed
+	}
 	if len(allTranscriptions) == 0 {
-		return fmt.Errorf("transcription produced no results")
+		if failed != nil {
+			return nil, failed
+		}
+		return nil, fmt.Errorf("transcription produced no results")
 	}
 
-	// 文字起こしをDBに保存
+	// 録音ごとの区間を1つの時系列にまとめる
+	allTranscriptions = mergeTranscriptions(allTranscriptions)
+
+	// 文字起こしを実行の区間としてDBに保存（前回までの実行の区間は残す）
 	if err := u.transcriptionRepo.CreateBatch(ctx, allTranscriptions); err != nil {
-		return fmt.Errorf("failed to save transcriptions: %w", err)
+		return nil, fmt.Errorf("failed to save transcriptions: %w", err)
 	}
 
-	// 議事録を生成
-	fullTranscript := u.formatTranscript(allTranscriptions)
+	run.Succeed(len(allTranscriptions), transcribed, time.Now())
+	if err := u.runRepo.Update(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to update transcription run: %w", err)
+	}
+	if err := u.runRepo.SetCurrent(ctx, room.ID, run.ID); err != nil {
+		return nil, fmt.Errorf("failed to set current transcription run: %w", err)
+	}
+	run.IsCurrent = true
 
-	// 参加者情報を取得
-	participants, err := u.participantRepo.FindByRoomID(ctx, roomID)
-	if err != nil {
-		return fmt.Errorf("failed to get participants: %w", err)
+	return allTranscriptions, nil
+}
+
+// failRun 実行を失敗として記録（キャンセルされた場合も記録できるよう、呼び出し元のコンテキストは使わない）
+func (u *recordingUsecase) failRun(run *entity.TranscriptionRun, cause error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	run.Fail(cause, time.Now())
+	if err := u.runRepo.Update(ctx, run); err != nil {
+		slog.Error("Failed to record failed transcription run",
+			slog.Int64("run_id", run.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+// writeMinutes 現在の実行の文字起こしから議事録を作成する
+// 議事録が既にある場合は文字起こしから作る部分（文字起こし・言語・共有ノート）だけを作り直し、
+// 投票結果とメールの送信状況は残す。メールは初めて作成したときだけ送信する
+func (u *recordingUsecase) writeMinutes(ctx context.Context, room *entity.CallRoom, runID int64, transcriptions []*entity.CallTranscription) (*entity.CallMinutes, error) {
+	// 議事録を生成（話者は参加者の表示名で示し、主な言語と異なる発言には言語を付ける）
+	languages := transcriptLanguages(transcriptions)
+	mainLanguage := ""
+	if len(languages) > 0 {
+		mainLanguage = languages[0]
 	}
+	fullTranscript := u.formatTranscript(transcriptions, u.speakerNames(ctx, transcriptions), mainLanguage)
+
+	minutes, err := u.minutesRepo.FindByRoomID(ctx, room.ID)
+	if err != nil && !errors.Is(err, entity.ErrMinutesNotFound) {
+		return nil, fmt.Errorf("failed to get minutes: %w", err)
+	}
+	regenerated := err == nil
+	if regenerated {
+		minutes.FullTranscript = &fullTranscript
+		minutes.Notes = u.findNotes(ctx, room.ID)
+		minutes.Languages = languages
+		minutes.RunID = &runID
+		if err := u.minutesRepo.Update(ctx, minutes); err != nil {
+			return nil, fmt.Errorf("failed to update minutes: %w", err)
+		}
+	} else {
+		// 参加者情報を取得
+		participants, err := u.participantRepo.FindByRoomID(ctx, room.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get participants: %w", err)
+		}
+
+		// ゲストはメールアドレスを持たないため送信対象外
+		participantIDs := make([]int64, 0, len(participants))
+		for _, p := range participants {
+			if p.IsGuest() {
+				continue
+			}
+			participantIDs = append(participantIDs, p.UserID)
+		}
+
+		// 議事録を作成
+		minutes = &entity.CallMinutes{
+			RoomID:         room.ID,
+			Title:          room.Name + " - " + time.Now().Format("2006/01/02"),
+			FullTranscript: &fullTranscript,
+			Notes:          u.findNotes(ctx, room.ID),
+			Languages:      languages,
+			RunID:          &runID,
+		}
+
+		if err := u.minutesRepo.Create(ctx, minutes); err != nil {
+			return nil, fmt.Errorf("failed to create minutes: %w", err)
+		}
 
-	participantIDs := make([]int64, len(participants))
-	for i, p := range participants {
-		participantIDs[i] = p.UserID
+		// メールを送信
+		if err := u.sendMinutesEmail(ctx, room, participantIDs, formatMinutesBody(minutes)); err != nil {
+			slog.Error("Failed to send email", slog.String("error", err.Error()))
+			// メール送信エラーは処理を中断しない
+		} else {
+			// メール送信成功を記録
+			now := time.Now()
+			minutes.EmailSent = true
+			minutes.EmailSentAt = &now
+			u.minutesRepo.Update(ctx, minutes)
+		}
 	}
 
-	// 議事録を作成
-	minutes := &entity.CallMinutes{
-		RoomID:         roomID,
-		Title:          room.Name + " - " + time.Now().Format("2006/01/02"),
-		FullTranscript: &fullTranscript,
+	u.events.Publish(ctx, room.CreatedBy, entity.WebhookEventMinutesReady, minutesEventData{
+		RoomID:      room.RoomID,
+		MinutesID:   minutes.ID,
+		Title:       minutes.Title,
+		Languages:   minutes.Languages,
+		RunID:       runID,
+		Regenerated: regenerated,
+	})
+
+	return minutes, nil
+}
+
+// transcribeRecording 保存済みの録音を1件文字起こし
+func (u *recordingUsecase) transcribeRecording(ctx context.Context, rec *entity.CallRecording, opts port.TranscribeOptions) ([]port.TranscriptSegment, error) {
+	object, err := u.storage.Stat(ctx, rec.FilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recording in storage: %w", err)
 	}
+	return u.transcriber.Transcribe(ctx, u.transcriptionAudio(object), opts)
+}
 
-	if err := u.minutesRepo.Create(ctx, minutes); err != nil {
-		return fmt.Errorf("failed to create minutes: %w", err)
+// transcribeOptions ルームの言語設定から文字起こしの条件を作る（自動判定の場合は言語を指定しない）
+// 録音は参加者ごとで話者は録音した参加者に決まるため、話者を識別しない
+func transcribeOptions(room *entity.CallRoom) port.TranscribeOptions {
+	language := room.TranscriptionLanguageOrDefault()
+	if language == entity.TranscriptionLanguageAuto {
+		language = ""
 	}
+	return port.TranscribeOptions{
+		Language:             language,
+		AlternativeLanguages: room.TranscriptionAlternativeLanguages,
+		Diarization:          false,
+	}
+}
 
-	// メールを送信
-	if err := u.sendMinutesEmail(ctx, room, participantIDs, fullTranscript); err != nil {
-		slog.Error("Failed to send email", slog.String("error", err.Error()))
-		// メール送信エラーは処理を中断しない
-	} else {
-		// メール送信成功を記録
-		now := time.Now()
-		minutes.EmailSent = true
-		minutes.EmailSentAt = &now
-		u.minutesRepo.Update(ctx, minutes)
+// segmentLanguage 区間の言語（エンジンが判定しない場合は指定した言語、自動判定で不明な場合は und）
+func segmentLanguage(segment port.TranscriptSegment, opts port.TranscribeOptions) string {
+	if segment.Language != "" {
+		return segment.Language
+	}
+	if opts.Language != "" {
+		return opts.Language
 	}
+	return entity.UndeterminedLanguage
+}
 
-	slog.Info("Transcription and minutes creation completed", slog.Int64("room_id", roomID))
+// transcriptLanguages 文字起こしで話された言語を区間の多い順に返す（判定できなかった区間は数えない）
+func transcriptLanguages(transcriptions []*entity.CallTranscription) []string {
+	counts := make(map[string]int)
+	var languages []string
+	for _, t := range transcriptions {
+		if t.Language == "" || t.Language == entity.UndeterminedLanguage {
+			continue
+		}
+		if counts[t.Language] == 0 {
+			languages = append(languages, t.Language)
+		}
+		counts[t.Language]++
+	}
+	// 同じ数の言語は先に話された順
+	sort.SliceStable(languages, func(i, j int) bool {
+		return counts[languages[i]] > counts[languages[j]]
+	})
+	return languages
+}
 
-	return nil
+// transcriptionAudio 保存済みの録音を文字起こしエンジンに渡す形にする
+func (u *recordingUsecase) transcriptionAudio(object *port.ObjectInfo) port.TranscriptionAudio {
+	return port.TranscriptionAudio{
+		URI:         object.URI,
+		ContentType: object.ContentType,
+		Open: func(ctx context.Context) (io.ReadCloser, error) {
+			body, _, err := u.storage.Get(ctx, object.Key)
+			return body, err
+		},
+	}
 }
 
 // formatTranscript 文字起こし結果を整形
-func (u *recordingUsecase) formatTranscript(transcriptions []*entity.CallTranscription) string {
+// namesは話者のユーザーIDごとの表示名。話者のわからない文字起こしは識別した話者番号で示す。
+// 前の話者が話し終わる前に話し始めた発言には同時発話と付ける。
+// 主な言語（mainLanguage）と異なる言語で話した発言には、言語が変わったところに言語コードを付ける
+func (u *recordingUsecase) formatTranscript(transcriptions []*entity.CallTranscription, names map[int64]string, mainLanguage string) string {
 	var sb strings.Builder
 
-	currentSpeaker := -1
+	currentSpeaker := ""
+	currentLanguage := mainLanguage
+	var turnEnd time.Time // 現在の話者の発言が終わる時刻
 	for _, t := range transcriptions {
-		speaker := 0
-		if t.SpeakerTag != nil {
-			speaker = *t.SpeakerTag
-		}
+		speaker := speakerLabel(t, names)
 
 		// 話者が変わったら改行
-		if currentSpeaker != speaker && currentSpeaker != -1 {
+		if currentSpeaker != speaker && currentSpeaker != "" {
 			sb.WriteString("\n\n")
 		}
 
-		// 話者タグを表示
+		// 話者を表示
 		if currentSpeaker != speaker {
-			sb.WriteString(fmt.Sprintf("[話者%d] ", speaker))
+			sb.WriteString("[" + speaker + "] ")
+			if currentSpeaker != "" && t.StartedAt != nil && t.StartedAt.Before(turnEnd) {
+				sb.WriteString("（同時発話） ")
+			}
 			currentSpeaker = speaker
+			currentLanguage = mainLanguage
+			turnEnd = time.Time{}
+		}
+		if t.EndedAt != nil && t.EndedAt.After(turnEnd) {
+			turnEnd = *t.EndedAt
+		}
+		if t.Language != "" && t.Language != entity.UndeterminedLanguage && t.Language != currentLanguage {
+			sb.WriteString("（" + t.Language + "） ")
+			currentLanguage = t.Language
 		}
 
 		sb.WriteString(t.Text)
@@ -234,6 +512,100 @@ func (u *recordingUsecase) formatTranscript(transcriptions []*entity.CallTranscr
 	return sb.String()
 }
 
+// mergeTranscriptions 録音ごとの文字起こしを話し始めた時刻の順に並べる
+// 同時に話し始めた区間は先に終わった順、それも同じなら元の順（録音順）にする。
+// 実時間のない古い文字起こしは元の順のまま
+func mergeTranscriptions(transcriptions []*entity.CallTranscription) []*entity.CallTranscription {
+	merged := append([]*entity.CallTranscription(nil), transcriptions...)
+	sort.SliceStable(merged, func(i, j int) bool {
+		a, b := merged[i], merged[j]
+		if a.StartedAt == nil || b.StartedAt == nil {
+			return false
+		}
+		if !a.StartedAt.Equal(*b.StartedAt) {
+			return a.StartedAt.Before(*b.StartedAt)
+		}
+		return a.EndedAt != nil && b.EndedAt != nil && a.EndedAt.Before(*b.EndedAt)
+	})
+	return merged
+}
+
+// secondsToDuration 秒数をtime.Durationに変換（ミリ秒に丸める）
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Round(seconds*1000)) * time.Millisecond
+}
+
+// speakerLabel 文字起こしの話者の表示
+func speakerLabel(t *entity.CallTranscription, names map[int64]string) string {
+	if t.UserID != nil {
+		if name, ok := names[*t.UserID]; ok && name != "" {
+			return name
+		}
+		return fmt.Sprintf("参加者%d", *t.UserID)
+	}
+	speaker := 0
+	if t.SpeakerTag != nil {
+		speaker = *t.SpeakerTag
+	}
+	return fmt.Sprintf("話者%d", speaker)
+}
+
+// speakerNames 文字起こしの話者の表示名を一括取得（取得できない場合は空）
+func (u *recordingUsecase) speakerNames(ctx context.Context, transcriptions []*entity.CallTranscription) map[int64]string {
+	seen := make(map[int64]bool)
+	var userIDs []int64
+	for _, t := range transcriptions {
+		if t.UserID == nil || seen[*t.UserID] {
+			continue
+		}
+		seen[*t.UserID] = true
+		userIDs = append(userIDs, *t.UserID)
+	}
+
+	names := make(map[int64]string, len(userIDs))
+	if len(userIDs) == 0 {
+		return names
+	}
+	users, err := u.userRepo.FindByIDs(ctx, userIDs)
+	if err != nil {
+		slog.Warn("Failed to get speaker names", slog.String("error", err.Error()))
+		return names
+	}
+	for _, user := range users {
+		names[user.ID] = user.Name
+	}
+	return names
+}
+
+// findNotes 共有ノートの保存済みの最新版を取得（空の場合はnil）
+// 通話の終了時に最終版が保存されるため、通話後の議事録作成では最終版になる
+func (u *recordingUsecase) findNotes(ctx context.Context, roomID int64) *string {
+	notes, err := u.notesRepo.FindByRoomID(ctx, roomID)
+	if err != nil {
+		if !errors.Is(err, entity.ErrCallNotesNotFound) {
+			slog.Warn("Failed to get notes", slog.Int64("room_id", roomID), slog.String("error", err.Error()))
+		}
+		return nil
+	}
+	if strings.TrimSpace(notes.Content) == "" {
+		return nil
+	}
+	return &notes.Content
+}
+
+// formatMinutesBody メール本文に載せる議事録（共有ノートがある場合は文字起こしの前に載せる）
+// 複数の言語で話された場合は、文字起こしの前に話された言語を載せる
+func formatMinutesBody(minutes *entity.CallMinutes) string {
+	transcript := *minutes.FullTranscript
+	if len(minutes.Languages) > 1 {
+		transcript = "言語: " + strings.Join(minutes.Languages, ", ") + "\n\n" + transcript
+	}
+	if minutes.Notes == nil {
+		return transcript
+	}
+	return "■ 共有ノート\n" + *minutes.Notes + "\n\n■ 文字起こし\n" + transcript
+}
+
 // sendMinutesEmail 議事録メールを送信
 func (u *recordingUsecase) sendMinutesEmail(ctx context.Context, room *entity.CallRoom, participantIDs []int64, transcript string) error {
 	// Email未設定の場合はスキップ
@@ -279,3 +651,58 @@ func (u *recordingUsecase) sendMinutesEmail(ctx context.Context, room *entity.Ca
 func (u *recordingUsecase) GetMinutes(ctx context.Context, roomID int64) (*entity.CallMinutes, error) {
 	return u.minutesRepo.FindByRoomID(ctx, roomID)
 }
+
+// GetTranscriptionRuns ルームの文字起こしの実行一覧を取得（新しい順）
+func (u *recordingUsecase) GetTranscriptionRuns(ctx context.Context, roomID int64) ([]*entity.TranscriptionRun, error) {
+	return u.runRepo.FindByRoomID(ctx, roomID)
+}
+
+// GetTranscriptionRun 文字起こしの実行とその区間を取得（他のルームの実行はErrTranscriptionRunNotFound）
+func (u *recordingUsecase) GetTranscriptionRun(ctx context.Context, roomID int64, runID int64) (*entity.TranscriptionRun, []*entity.CallTranscription, error) {
+	run, err := u.findRun(ctx, roomID, runID)
+	if err != nil {
+		return nil, nil, err
+	}
+	transcriptions, err := u.transcriptionRepo.FindByRunID(ctx, run.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get transcriptions: %w", err)
+	}
+	return run, transcriptions, nil
+}
+
+// SetCurrentTranscriptionRun 現在の実行を切り替えて、その実行の区間から議事録を作り直す
+// 以前の実行に戻す場合も文字起こしはしない（成功していない実行はErrTranscriptionRunNotSucceeded）
+func (u *recordingUsecase) SetCurrentTranscriptionRun(ctx context.Context, roomID int64, runID int64) (*entity.CallMinutes, error) {
+	run, err := u.findRun(ctx, roomID, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != entity.TranscriptionRunSucceeded {
+		return nil, entity.ErrTranscriptionRunNotSucceeded
+	}
+	room, err := u.roomRepo.FindByID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+	transcriptions, err := u.transcriptionRepo.FindByRunID(ctx, run.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transcriptions: %w", err)
+	}
+
+	if err := u.runRepo.SetCurrent(ctx, roomID, run.ID); err != nil {
+		return nil, fmt.Errorf("failed to set current transcription run: %w", err)
+	}
+	return u.writeMinutes(ctx, room, run.ID, transcriptions)
+}
+
+// findRun ルームの文字起こしの実行を取得（他のルームの実行はErrTranscriptionRunNotFound）
+func (u *recordingUsecase) findRun(ctx context.Context, roomID int64, runID int64) (*entity.TranscriptionRun, error) {
+	run, err := u.runRepo.FindByID(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.RoomID != roomID {
+		return nil, entity.ErrTranscriptionRunNotFound
+	}
+	return run, nil
+}
@@ -0,0 +1,420 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// fakeTranscriber 音声の内容ごとに決めた区間を返す文字起こしエンジン
+type fakeTranscriber struct {
+	segments map[string][]port.TranscriptSegment // 音声の内容ごと
+	opts     []port.TranscribeOptions            // 呼び出されたときの条件
+}
+
+func (f *fakeTranscriber) Name() string {
+	return "fake"
+}
+
+func (f *fakeTranscriber) SupportsLanguages(opts port.TranscribeOptions) error {
+	return nil
+}
+
+func (f *fakeTranscriber) Transcribe(ctx context.Context, audio port.TranscriptionAudio, opts port.TranscribeOptions) ([]port.TranscriptSegment, error) {
+	f.opts = append(f.opts, opts)
+	body, err := audio.Open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return f.segments[string(data)], nil
+}
+
+type recordingTestEnv struct {
+	uc             RecordingUsecase
+	recordings     *testutil.MockCallRecordingRepository
+	transcriptions *testutil.MockCallTranscriptionRepository
+	runs           *testutil.MockTranscriptionRunRepository
+	minutes        *testutil.MockCallMinutesRepository
+	users          *testutil.MockUserRepository
+	storage        *testutil.MockObjectStorage
+	transcriber    *fakeTranscriber
+	events         *testutil.MockEventPublisher
+	room           *entity.CallRoom
+}
+
+func newRecordingTestEnv() *recordingTestEnv {
+	rooms := testutil.NewMockCallRoomRepository()
+	env := &recordingTestEnv{
+		recordings:     testutil.NewMockCallRecordingRepository(),
+		transcriptions: testutil.NewMockCallTranscriptionRepository(),
+		runs:           testutil.NewMockTranscriptionRunRepository(),
+		minutes:        testutil.NewMockCallMinutesRepository(),
+		users:          testutil.NewMockUserRepository(),
+		storage:        testutil.NewMockObjectStorage(),
+		transcriber:    &fakeTranscriber{segments: make(map[string][]port.TranscriptSegment)},
+		events:         testutil.NewMockEventPublisher(),
+		room:           &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1},
+	}
+	env.transcriptions.Runs = env.runs
+	rooms.Create(context.Background(), env.room)
+	env.uc = NewRecordingUsecase(
+		env.recordings,
+		env.transcriptions,
+		env.runs,
+		env.minutes,
+		testutil.NewMockCallNotesRepository(),
+		testutil.NewMockCallParticipantRepository(),
+		rooms,
+		env.users,
+		env.storage,
+		env.transcriber,
+		nil,
+		env.events,
+		"http://localhost:3000",
+	)
+	return env
+}
+
+// addUser 表示名がnameのユーザーを追加
+func (env *recordingTestEnv) addUser(name string) *entity.User {
+	user := &entity.User{Email: strings.ToLower(name) + "@example.com", Name: name}
+	env.users.Create(context.Background(), user)
+	return user
+}
+
+// addRecording userIDの参加者の録音と、その文字起こし結果を追加
+func (env *recordingTestEnv) addRecording(userID int64, segments ...port.TranscriptSegment) *entity.CallRecording {
+	data := fmt.Sprintf("audio of user %d", userID)
+	rec := &entity.CallRecording{
+		RoomID:   env.room.ID,
+		UserID:   userID,
+		FilePath: fmt.Sprintf("recordings/%d/user-%d.webm", env.room.ID, userID),
+	}
+	env.storage.Put(context.Background(), rec.FilePath, strings.NewReader(data), -1, "audio/webm")
+	env.recordings.Create(context.Background(), rec)
+	env.transcriber.segments[data] = segments
+	return rec
+}
+
+func TestRecordingUsecase_TranscribeRoom_Speakers(t *testing.T) {
+	env := newRecordingTestEnv()
+	ctx := context.Background()
+	alice := env.addUser("Alice")
+	bob := env.addUser("Bob")
+	env.addRecording(alice.ID,
+		port.TranscriptSegment{Text: "おはようございます", StartTime: 0, EndTime: 2},
+		port.TranscriptSegment{Text: "始めます", StartTime: 2, EndTime: 3},
+	)
+	env.addRecording(bob.ID, port.TranscriptSegment{Text: "よろしくお願いします", StartTime: 4, EndTime: 6})
+
+	minutes, err := env.uc.TranscribeRoom(ctx, env.room.ID, TranscribeRoomOptions{})
+	if err != nil {
+		t.Fatalf("TranscribeRoom() unexpected error = %v", err)
+	}
+
+	// 話者は番号ではなく録音した参加者の表示名で示す
+	want := "[Alice] おはようございます 始めます \n\n[Bob] よろしくお願いします "
+	if *minutes.FullTranscript != want {
+		t.Errorf("FullTranscript = %q, want %q", *minutes.FullTranscript, want)
+	}
+
+	// 参加者ごとの録音は話者が1人のため、話者を識別しない
+	for _, opts := range env.transcriber.opts {
+		if opts.Diarization {
+			t.Error("Transcribe() called with diarization for a single-speaker recording")
+		}
+	}
+
+	saved, _ := env.transcriptions.FindByRoomID(ctx, env.room.ID)
+	if len(saved) != 3 {
+		t.Fatalf("saved transcriptions = %d, want 3", len(saved))
+	}
+	for _, tr := range saved {
+		if tr.UserID == nil {
+			t.Fatalf("transcription %q has no speaker", tr.Text)
+		}
+	}
+	if *saved[0].UserID != alice.ID || *saved[2].UserID != bob.ID {
+		t.Errorf("speakers = %d, %d, want %d, %d", *saved[0].UserID, *saved[2].UserID, alice.ID, bob.ID)
+	}
+}
+
+func TestRecordingUsecase_TranscribeRoom_UnknownSpeaker(t *testing.T) {
+	env := newRecordingTestEnv()
+	ctx := context.Background()
+
+	// 退会などで取得できないユーザーはIDで示す
+	env.addRecording(42, port.TranscriptSegment{Text: "こんにちは"})
+
+	minutes, err := env.uc.TranscribeRoom(ctx, env.room.ID, TranscribeRoomOptions{})
+	if err != nil {
+		t.Fatalf("TranscribeRoom() unexpected error = %v", err)
+	}
+	if want := "[参加者42] こんにちは "; *minutes.FullTranscript != want {
+		t.Errorf("FullTranscript = %q, want %q", *minutes.FullTranscript, want)
+	}
+}
+
+func TestRecordingUsecase_TranscribeRoom_Timeline(t *testing.T) {
+	env := newRecordingTestEnv()
+	ctx := context.Background()
+	alice := env.addUser("Alice")
+	bob := env.addUser("Bob")
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	bobStart := start.Add(5 * time.Second)
+
+	// 区間の時刻は録音ごとの先頭からの秒数で、Bobは5秒遅れて録音を始めた
+	env.addRecording(alice.ID,
+		port.TranscriptSegment{Text: "A1", StartTime: 0, EndTime: 4},
+		port.TranscriptSegment{Text: "A2", StartTime: 6, EndTime: 9},
+		port.TranscriptSegment{Text: "A3", StartTime: 12, EndTime: 14},
+	).StartedAt = &start
+	env.addRecording(bob.ID,
+		port.TranscriptSegment{Text: "B1", StartTime: 0, EndTime: 3},
+		port.TranscriptSegment{Text: "B2", StartTime: 10, EndTime: 11},
+	).StartedAt = &bobStart
+
+	minutes, err := env.uc.TranscribeRoom(ctx, env.room.ID, TranscribeRoomOptions{})
+	if err != nil {
+		t.Fatalf("TranscribeRoom() unexpected error = %v", err)
+	}
+
+	// 録音をまたいで話し始めた順に並べ、Bobが話し終わる前に話し始めた発言は同時発話とする
+	want := "[Alice] A1 \n\n[Bob] B1 \n\n[Alice] （同時発話） A2 A3 \n\n[Bob] B2 "
+	if *minutes.FullTranscript != want {
+		t.Errorf("FullTranscript = %q, want %q", *minutes.FullTranscript, want)
+	}
+
+	// 保存する区間は実時間の順で、録音の先頭からの秒数も残す
+	saved, _ := env.transcriptions.FindByRoomID(ctx, env.room.ID)
+	var texts []string
+	for _, tr := range saved {
+		texts = append(texts, tr.Text)
+	}
+	if got := strings.Join(texts, ","); got != "A1,B1,A2,A3,B2" {
+		t.Errorf("saved order = %s, want A1,B1,A2,A3,B2", got)
+	}
+	b2 := saved[4]
+	if !b2.StartedAt.Equal(start.Add(15*time.Second)) || !b2.EndedAt.Equal(start.Add(16*time.Second)) || *b2.StartTime != 10 {
+		t.Errorf("B2 = %v-%v (%v), want 10:00:15-10:00:16 (10)", b2.StartedAt, b2.EndedAt, *b2.StartTime)
+	}
+}
+
+func TestRecordingUsecase_TranscribeRoom_Languages(t *testing.T) {
+	env := newRecordingTestEnv()
+	ctx := context.Background()
+	env.room.TranscriptionLanguage = entity.TranscriptionLanguageAuto
+	env.room.TranscriptionAlternativeLanguages = []string{"ja-JP", "en-US"}
+	alice := env.addUser("Alice")
+	bob := env.addUser("Bob")
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	bobStart := start.Add(10 * time.Second)
+
+	env.addRecording(alice.ID,
+		port.TranscriptSegment{Text: "おはようございます", StartTime: 0, EndTime: 2, Language: "ja-JP"},
+		port.TranscriptSegment{Text: "Let's start", StartTime: 2, EndTime: 3, Language: "en-US"},
+		port.TranscriptSegment{Text: "始めます", StartTime: 3, EndTime: 4, Language: "ja-JP"},
+	).StartedAt = &start
+	env.addRecording(bob.ID,
+		port.TranscriptSegment{Text: "Hello", StartTime: 0, EndTime: 1, Language: "en-US"},
+		port.TranscriptSegment{Text: "Thanks", StartTime: 1, EndTime: 2, Language: "en-US"},
+		port.TranscriptSegment{Text: "えっと", StartTime: 2, EndTime: 3},
+	).StartedAt = &bobStart
+
+	minutes, err := env.uc.TranscribeRoom(ctx, env.room.ID, TranscribeRoomOptions{})
+	if err != nil {
+		t.Fatalf("TranscribeRoom() unexpected error = %v", err)
+	}
+
+	// 自動判定では言語を指定せず、候補の言語を渡す
+	for _, opts := range env.transcriber.opts {
+		if opts.Language != "" || len(opts.AlternativeLanguages) != 2 {
+			t.Errorf("Transcribe() options = %+v, want auto detection among the alternatives", opts)
+		}
+	}
+
+	// 最も多く話された言語を主な言語とし、他の言語に切り替わったところに言語を付ける
+	if len(minutes.Languages) != 2 || minutes.Languages[0] != "en-US" || minutes.Languages[1] != "ja-JP" {
+		t.Errorf("Languages = %v, want [en-US ja-JP]", minutes.Languages)
+	}
+	want := "[Alice] （ja-JP） おはようございます （en-US） Let's start （ja-JP） 始めます \n\n[Bob] Hello Thanks えっと "
+	if *minutes.FullTranscript != want {
+		t.Errorf("FullTranscript = %q, want %q", *minutes.FullTranscript, want)
+	}
+
+	// 区間ごとに判定した言語を保存し、判定できなかった区間は und とする
+	saved, _ := env.transcriptions.FindByRoomID(ctx, env.room.ID)
+	var languages []string
+	for _, tr := range saved {
+		languages = append(languages, tr.Language)
+	}
+	if got := strings.Join(languages, ","); got != "ja-JP,en-US,ja-JP,en-US,en-US,und" {
+		t.Errorf("saved languages = %s, want ja-JP,en-US,ja-JP,en-US,en-US,und", got)
+	}
+}
+
+func TestRecordingUsecase_TranscribeRoom_RoomLanguage(t *testing.T) {
+	env := newRecordingTestEnv()
+	ctx := context.Background()
+	env.room.TranscriptionLanguage = "en-US"
+	env.addRecording(env.addUser("Alice").ID, port.TranscriptSegment{Text: "Good morning"})
+
+	minutes, err := env.uc.TranscribeRoom(ctx, env.room.ID, TranscribeRoomOptions{})
+	if err != nil {
+		t.Fatalf("TranscribeRoom() unexpected error = %v", err)
+	}
+
+	// ルームの言語で文字起こしし、エンジンが言語を返さない区間はルームの言語とする
+	if len(env.transcriber.opts) != 1 || env.transcriber.opts[0].Language != "en-US" {
+		t.Errorf("Transcribe() options = %+v, want language en-US", env.transcriber.opts)
+	}
+	if len(minutes.Languages) != 1 || minutes.Languages[0] != "en-US" {
+		t.Errorf("Languages = %v, want [en-US]", minutes.Languages)
+	}
+	if want := "[Alice] Good morning "; *minutes.FullTranscript != want {
+		t.Errorf("FullTranscript = %q, want %q", *minutes.FullTranscript, want)
+	}
+}
+
+func TestRecordingUsecase_TranscribeRoom_Rerun(t *testing.T) {
+	env := newRecordingTestEnv()
+	ctx := context.Background()
+	alice := env.addUser("Alice")
+	rec := env.addRecording(alice.ID, port.TranscriptSegment{Text: "グッドモーニング"})
+
+	first, err := env.uc.TranscribeRoom(ctx, env.room.ID, TranscribeRoomOptions{})
+	if err != nil {
+		t.Fatalf("TranscribeRoom() unexpected error = %v", err)
+	}
+	polls := "投票結果"
+	first.PollResults = &polls
+	env.minutes.Update(ctx, first)
+
+	// 言語設定を変えて再実行すると、同じ議事録を新しい実行から作り直す
+	env.room.TranscriptionLanguage = "en-US"
+	env.transcriber.segments[fmt.Sprintf("audio of user %d", rec.UserID)] = []port.TranscriptSegment{{Text: "Good morning"}}
+	jobID := int64(7)
+	second, err := env.uc.TranscribeRoom(ctx, env.room.ID, TranscribeRoomOptions{JobID: &jobID})
+	if err != nil {
+		t.Fatalf("TranscribeRoom() again unexpected error = %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("minutes ID = %d, want the existing minutes %d", second.ID, first.ID)
+	}
+	if want := "[Alice] Good morning "; *second.FullTranscript != want {
+		t.Errorf("FullTranscript = %q, want %q", *second.FullTranscript, want)
+	}
+	if second.PollResults == nil || *second.PollResults != polls {
+		t.Errorf("PollResults = %v, want the results kept", second.PollResults)
+	}
+
+	// 前回の実行は条件と区間を残したまま、新しい実行が現在の実行になる
+	runs, err := env.uc.GetTranscriptionRuns(ctx, env.room.ID)
+	if err != nil || len(runs) != 2 {
+		t.Fatalf("GetTranscriptionRuns() = %d runs, %v, want 2", len(runs), err)
+	}
+	latest, previous := runs[0], runs[1]
+	if !latest.IsCurrent || previous.IsCurrent {
+		t.Errorf("current = %v, %v, want only the latest run", latest.IsCurrent, previous.IsCurrent)
+	}
+	if latest.Language != "en-US" || previous.Language != entity.DefaultTranscriptionLanguage {
+		t.Errorf("languages = %s, %s, want en-US, %s", latest.Language, previous.Language, entity.DefaultTranscriptionLanguage)
+	}
+	if latest.Engine != "fake" || latest.Status != entity.TranscriptionRunSucceeded || latest.Segments != 1 || latest.Recordings != 1 {
+		t.Errorf("latest run = %+v, want a succeeded fake run with 1 segment", latest)
+	}
+	if latest.JobID == nil || *latest.JobID != jobID || previous.JobID != nil {
+		t.Errorf("job IDs = %v, %v, want %d and none", latest.JobID, previous.JobID, jobID)
+	}
+	if second.RunID == nil || *second.RunID != latest.ID {
+		t.Errorf("minutes run = %v, want %d", second.RunID, latest.ID)
+	}
+
+	current, _ := env.transcriptions.FindByRoomID(ctx, env.room.ID)
+	if len(current) != 1 || current[0].Text != "Good morning" {
+		t.Errorf("current transcriptions = %v, want only the latest run", current)
+	}
+	_, kept, err := env.uc.GetTranscriptionRun(ctx, env.room.ID, previous.ID)
+	if err != nil || len(kept) != 1 || kept[0].Text != "グッドモーニング" {
+		t.Errorf("GetTranscriptionRun(previous) = %v, %v, want the previous segments", kept, err)
+	}
+
+	// 作り直した議事録は通知で区別する
+	var regenerated []bool
+	for _, e := range env.events.Events {
+		if d, ok := e.Data.(minutesEventData); ok {
+			regenerated = append(regenerated, d.Regenerated)
+		}
+	}
+	if len(regenerated) != 2 || regenerated[0] || !regenerated[1] {
+		t.Errorf("minutes.ready regenerated = %v, want [false true]", regenerated)
+	}
+}
+
+func TestRecordingUsecase_SetCurrentTranscriptionRun(t *testing.T) {
+	env := newRecordingTestEnv()
+	ctx := context.Background()
+	alice := env.addUser("Alice")
+
+	// ストレージにない録音だけでは文字起こしできず、失敗した実行が残る
+	env.recordings.Create(ctx, &entity.CallRecording{RoomID: env.room.ID, UserID: alice.ID, FilePath: "recordings/missing.webm"})
+	if _, err := env.uc.TranscribeRoom(ctx, env.room.ID, TranscribeRoomOptions{}); err == nil {
+		t.Fatal("TranscribeRoom() without audio error = nil, want error")
+	}
+	rec := env.addRecording(alice.ID, port.TranscriptSegment{Text: "一回目"})
+	if _, err := env.uc.TranscribeRoom(ctx, env.room.ID, TranscribeRoomOptions{}); err != nil {
+		t.Fatalf("TranscribeRoom() unexpected error = %v", err)
+	}
+	env.transcriber.segments[fmt.Sprintf("audio of user %d", rec.UserID)] = []port.TranscriptSegment{{Text: "二回目"}}
+	if _, err := env.uc.TranscribeRoom(ctx, env.room.ID, TranscribeRoomOptions{}); err != nil {
+		t.Fatalf("TranscribeRoom() again unexpected error = %v", err)
+	}
+
+	runs, _ := env.uc.GetTranscriptionRuns(ctx, env.room.ID)
+	if len(runs) != 3 {
+		t.Fatalf("runs = %d, want 3", len(runs))
+	}
+	failed, first := runs[2], runs[1]
+	if failed.Status != entity.TranscriptionRunFailed || failed.Error == nil || failed.FinishedAt == nil {
+		t.Errorf("failed run = %+v, want failed with the error", failed)
+	}
+
+	if _, err := env.uc.SetCurrentTranscriptionRun(ctx, env.room.ID, failed.ID); !errors.Is(err, entity.ErrTranscriptionRunNotSucceeded) {
+		t.Errorf("SetCurrentTranscriptionRun(failed) error = %v, want ErrTranscriptionRunNotSucceeded", err)
+	}
+	if _, err := env.uc.SetCurrentTranscriptionRun(ctx, env.room.ID+1, first.ID); !errors.Is(err, entity.ErrTranscriptionRunNotFound) {
+		t.Errorf("SetCurrentTranscriptionRun(other room) error = %v, want ErrTranscriptionRunNotFound", err)
+	}
+
+	// 前の実行に戻すと、文字起こしし直さずにその区間から議事録を作り直す
+	calls := len(env.transcriber.opts)
+	minutes, err := env.uc.SetCurrentTranscriptionRun(ctx, env.room.ID, first.ID)
+	if err != nil {
+		t.Fatalf("SetCurrentTranscriptionRun() unexpected error = %v", err)
+	}
+	if len(env.transcriber.opts) != calls {
+		t.Error("SetCurrentTranscriptionRun() transcribed the recordings again")
+	}
+	if want := "[Alice] 一回目 "; *minutes.FullTranscript != want {
+		t.Errorf("FullTranscript = %q, want %q", *minutes.FullTranscript, want)
+	}
+	if minutes.RunID == nil || *minutes.RunID != first.ID {
+		t.Errorf("minutes run = %v, want %d", minutes.RunID, first.ID)
+	}
+	if current, _ := env.runs.FindCurrentByRoomID(ctx, env.room.ID); current == nil || current.ID != first.ID {
+		t.Errorf("current run = %v, want %d", current, first.ID)
+	}
+}
@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// roomReaperLockName 複数レプリカで同時に実行しないためのロック名
+const roomReaperLockName = "call_room_reaper"
+
+// RoomReaperConfig 放置されたルームを終了する条件（0以下の場合はその条件を無効化）
+type RoomReaperConfig struct {
+	// 誰も参加しないまま開始予定日時（未設定の場合は作成日時）から経過したら終了
+	WaitingTTL time.Duration
+	// 参加者が全員退出してから経過したら終了
+	IdleTTL time.Duration
+	// 参加中のままの参加者がいても、最後の入退室から経過したら終了（切断されて退出できなかった参加者の救済）
+	// いずれの条件でもシグナリングに接続中のクライアントがいるルームは終了しない
+	StaleTTL time.Duration
+	// 終了したルームに録音があり議事録がなければ文字起こしジョブを登録
+	TranscribeOnEnd bool
+}
+
+// RoomReaperUsecase 放置されたルームの終了ユースケースのインターフェース
+type RoomReaperUsecase interface {
+	// 放置されたルームを終了し、終了したルーム数を返す
+	ReapStaleRooms(ctx context.Context, now time.Time) (int, error)
+}
+
+type roomReaperUsecase struct {
+	roomRepo         port.CallRoomRepository
+	participantRepo  port.CallParticipantRepository
+	recordingRepo    port.CallRecordingRepository
+	minutesRepo      port.CallMinutesRepository
+	transcriptionJob TranscriptionJobUsecase
+	connections      port.RoomConnections
+	lock             port.DistributedLock
+	events           EventPublisher
+	config           RoomReaperConfig
+}
+
+// NewRoomReaperUsecase 新しいルーム終了ユースケースを作成
+func NewRoomReaperUsecase(
+	roomRepo port.CallRoomRepository,
+	participantRepo port.CallParticipantRepository,
+	recordingRepo port.CallRecordingRepository,
+	minutesRepo port.CallMinutesRepository,
+	transcriptionJob TranscriptionJobUsecase,
+	connections port.RoomConnections,
+	lock port.DistributedLock,
+	events EventPublisher,
+	config RoomReaperConfig,
+) RoomReaperUsecase {
+	return &roomReaperUsecase{
+		roomRepo:         roomRepo,
+		participantRepo:  participantRepo,
+		recordingRepo:    recordingRepo,
+		minutesRepo:      minutesRepo,
+		transcriptionJob: transcriptionJob,
+		connections:      connections,
+		lock:             lock,
+		events:           events,
+		config:           config,
+	}
+}
+
+// ReapStaleRooms 放置されたルームを終了
+// 他のレプリカが実行中の場合は何もしない
+func (u *roomReaperUsecase) ReapStaleRooms(ctx context.Context, now time.Time) (int, error) {
+	release, acquired, err := u.lock.TryLock(ctx, roomReaperLockName)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		slog.Debug("Room reaper is running on another instance")
+		return 0, nil
+	}
+	defer release()
+
+	rooms, err := u.roomRepo.FindActiveRooms(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(rooms) == 0 {
+		return 0, nil
+	}
+
+	roomIDs := make([]int64, len(rooms))
+	for i, room := range rooms {
+		roomIDs[i] = room.ID
+	}
+	participants, err := u.participantRepo.FindByRoomIDs(ctx, roomIDs)
+	if err != nil {
+		return 0, err
+	}
+	byRoom := make(map[int64][]*entity.CallParticipant)
+	for _, p := range participants {
+		byRoom[p.RoomID] = append(byRoom[p.RoomID], p)
+	}
+
+	var ended []*entity.CallRoom
+	for _, room := range rooms {
+		endedAt, reason, ok := u.staleSince(room, byRoom[room.ID], now)
+		if !ok {
+			continue
+		}
+		// 参加状態が古くてもシグナリングに接続中のクライアントがいれば通話は続いている
+		if u.connections.HasConnections(room.RoomID) {
+			slog.Debug("Skipping stale room with live connections",
+				slog.String("room_id", room.RoomID),
+				slog.String("reason", reason))
+			continue
+		}
+
+		if err := endRoom(ctx, u.roomRepo, u.participantRepo, u.events, room, byRoom[room.ID], endedAt); err != nil {
+			slog.Error("Failed to end stale room",
+				slog.String("room_id", room.RoomID),
+				slog.String("error", err.Error()))
+			continue
+		}
+
+		slog.Info("Stale room ended",
+			slog.String("room_id", room.RoomID),
+			slog.String("reason", reason),
+			slog.Time("ended_at", endedAt))
+		ended = append(ended, room)
+	}
+
+	if u.config.TranscribeOnEnd {
+		for _, room := range ended {
+			u.transcribe(ctx, room)
+		}
+	}
+
+	return len(ended), nil
+}
+
+// staleSince ルームを終了すべきか判定し、終了日時と理由を返す
+func (u *roomReaperUsecase) staleSince(room *entity.CallRoom, participants []*entity.CallParticipant, now time.Time) (time.Time, string, bool) {
+	// 誰も参加していない
+	if len(participants) == 0 {
+		since := room.CreatedAt
+		if room.ScheduledAt != nil {
+			since = *room.ScheduledAt
+		}
+		if u.config.WaitingTTL > 0 && now.Sub(since) > u.config.WaitingTTL {
+			return now, "never_joined", true
+		}
+		return time.Time{}, "", false
+	}
+
+	active := false
+	var lastActivity time.Time
+	for _, p := range participants {
+		if p.IsActive {
+			active = true
+		}
+		if p.JoinedAt.After(lastActivity) {
+			lastActivity = p.JoinedAt
+		}
+		if p.LeftAt != nil && p.LeftAt.After(lastActivity) {
+			lastActivity = *p.LeftAt
+		}
+	}
+
+	// 全員退出済み（最後の退出日時を終了日時とする）
+	if !active {
+		if u.config.IdleTTL > 0 && now.Sub(lastActivity) > u.config.IdleTTL {
+			return lastActivity, "idle", true
+		}
+		return time.Time{}, "", false
+	}
+
+	// 参加中のまま入退室がない
+	if u.config.StaleTTL > 0 && now.Sub(lastActivity) > u.config.StaleTTL {
+		return now, "abandoned", true
+	}
+	return time.Time{}, "", false
+}
+
+// transcribe 録音があり議事録がないルームの文字起こしジョブを登録
+// 文字起こしはジョブのワーカーで実行する（ロックを持ったまま時間のかかる処理をしない）
+func (u *roomReaperUsecase) transcribe(ctx context.Context, room *entity.CallRoom) {
+	recordings, err := u.recordingRepo.FindRoomIDsWithRecordings(ctx, []int64{room.ID})
+	if err != nil || !recordings[room.ID] {
+		return
+	}
+	minutes, err := u.minutesRepo.FindRoomIDsWithMinutes(ctx, []int64{room.ID})
+	if err != nil || minutes[room.ID] {
+		return
+	}
+
+	job, err := u.transcriptionJob.Enqueue(ctx, room.ID, nil)
+	if err != nil {
+		slog.Error("Failed to enqueue transcription for stale room",
+			slog.String("room_id", room.RoomID),
+			slog.String("error", err.Error()))
+		return
+	}
+	slog.Info("Stale room transcription queued",
+		slog.String("room_id", room.RoomID),
+		slog.Int64("job_id", job.ID))
+}
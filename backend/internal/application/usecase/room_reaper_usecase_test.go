@@ -0,0 +1,262 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// fakeTranscriptionJobUsecase 文字起こしの依頼を記録するTranscriptionJobUsecase
+type fakeTranscriptionJobUsecase struct {
+	enqueued []int64
+}
+
+func (f *fakeTranscriptionJobUsecase) Enqueue(ctx context.Context, roomID int64, requestedBy *int64) (*entity.TranscriptionJob, error) {
+	f.enqueued = append(f.enqueued, roomID)
+	return &entity.TranscriptionJob{ID: int64(len(f.enqueued)), RoomID: roomID, RequestedBy: requestedBy, Status: entity.TranscriptionJobQueued}, nil
+}
+
+func (f *fakeTranscriptionJobUsecase) GetJob(ctx context.Context, roomID int64, jobID int64) (*entity.TranscriptionJob, error) {
+	return nil, entity.ErrTranscriptionJobNotFound
+}
+
+func (f *fakeTranscriptionJobUsecase) GetJobs(ctx context.Context, roomID int64, limit int) ([]*entity.TranscriptionJob, error) {
+	return nil, nil
+}
+
+func (f *fakeTranscriptionJobUsecase) RunNext(ctx context.Context, workerID string) (bool, error) {
+	return false, nil
+}
+
+func (f *fakeTranscriptionJobUsecase) Wake() <-chan struct{} {
+	return nil
+}
+
+// fakeRoomConnections シグナリングに接続中のルームを返すRoomConnections
+type fakeRoomConnections map[string]bool
+
+func (f fakeRoomConnections) HasConnections(roomID string) bool {
+	return f[roomID]
+}
+
+func TestRoomReaperUsecase_ReapStaleRooms(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+	config := RoomReaperConfig{WaitingTTL: 24 * time.Hour, IdleTTL: 10 * time.Minute, StaleTTL: 6 * time.Hour}
+
+	tests := []struct {
+		name            string
+		room            entity.CallRoom
+		participants    []entity.CallParticipant
+		config          RoomReaperConfig
+		connected       bool
+		expectEnded     bool
+		expectedEndedAt *time.Time
+	}{
+		{
+			name:        "waiting room never joined",
+			room:        entity.CallRoom{Status: entity.CallRoomStatusWaiting, CreatedAt: *ago(25 * time.Hour)},
+			config:      config,
+			expectEnded: true, expectedEndedAt: &now,
+		},
+		{
+			name:   "recently created waiting room",
+			room:   entity.CallRoom{Status: entity.CallRoomStatusWaiting, CreatedAt: *ago(time.Hour)},
+			config: config,
+		},
+		{
+			name:   "scheduled room uses scheduled time",
+			room:   entity.CallRoom{Status: entity.CallRoomStatusWaiting, CreatedAt: *ago(30 * 24 * time.Hour), ScheduledAt: ago(time.Hour)},
+			config: config,
+		},
+		{
+			name:         "everyone left long ago",
+			room:         entity.CallRoom{Status: entity.CallRoomStatusActive, CreatedAt: *ago(2 * time.Hour), StartedAt: ago(2 * time.Hour)},
+			participants: []entity.CallParticipant{{UserID: 1, JoinedAt: *ago(2 * time.Hour), LeftAt: ago(time.Hour)}, {UserID: 2, JoinedAt: *ago(2 * time.Hour), LeftAt: ago(30 * time.Minute)}},
+			config:       config,
+			expectEnded:  true, expectedEndedAt: ago(30 * time.Minute),
+		},
+		{
+			name:         "everyone left recently",
+			room:         entity.CallRoom{Status: entity.CallRoomStatusActive, CreatedAt: *ago(time.Hour), StartedAt: ago(time.Hour)},
+			participants: []entity.CallParticipant{{UserID: 1, JoinedAt: *ago(time.Hour), LeftAt: ago(5 * time.Minute)}},
+			config:       config,
+		},
+		{
+			name:         "abandoned participants",
+			room:         entity.CallRoom{Status: entity.CallRoomStatusActive, CreatedAt: *ago(8 * time.Hour), StartedAt: ago(8 * time.Hour)},
+			participants: []entity.CallParticipant{{UserID: 1, JoinedAt: *ago(8 * time.Hour), IsActive: true}},
+			config:       config,
+			expectEnded:  true, expectedEndedAt: &now,
+		},
+		{
+			name:         "abandoned participants still connected",
+			room:         entity.CallRoom{Status: entity.CallRoomStatusActive, CreatedAt: *ago(8 * time.Hour), StartedAt: ago(8 * time.Hour)},
+			participants: []entity.CallParticipant{{UserID: 1, JoinedAt: *ago(8 * time.Hour), IsActive: true}},
+			config:       config,
+			connected:    true,
+		},
+		{
+			name:         "ongoing call",
+			room:         entity.CallRoom{Status: entity.CallRoomStatusActive, CreatedAt: *ago(time.Hour), StartedAt: ago(time.Hour)},
+			participants: []entity.CallParticipant{{UserID: 1, JoinedAt: *ago(time.Hour), IsActive: true}},
+			config:       config,
+		},
+		{
+			name:         "disabled threshold",
+			room:         entity.CallRoom{Status: entity.CallRoomStatusActive, CreatedAt: *ago(8 * time.Hour), StartedAt: ago(8 * time.Hour)},
+			participants: []entity.CallParticipant{{UserID: 1, JoinedAt: *ago(8 * time.Hour), IsActive: true}},
+			config:       RoomReaperConfig{IdleTTL: 10 * time.Minute},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			roomRepo := testutil.NewMockCallRoomRepository()
+			participantRepo := testutil.NewMockCallParticipantRepository()
+			connections := fakeRoomConnections{}
+			usecase := NewRoomReaperUsecase(roomRepo, participantRepo, testutil.NewMockCallRecordingRepository(), testutil.NewMockCallMinutesRepository(), &fakeTranscriptionJobUsecase{}, connections, testutil.NewMockDistributedLock(), testutil.NewMockEventPublisher(), tt.config)
+			ctx := context.Background()
+
+			room := tt.room
+			room.RoomID = "room-1"
+			connections[room.RoomID] = tt.connected
+			roomRepo.Create(ctx, &room)
+			room.CreatedAt = tt.room.CreatedAt
+			for i := range tt.participants {
+				p := tt.participants[i]
+				p.RoomID = room.ID
+				participantRepo.Create(ctx, &p)
+			}
+
+			// Act
+			ended, err := usecase.ReapStaleRooms(ctx, now)
+
+			// Assert
+			if err != nil {
+				t.Fatalf("ReapStaleRooms() unexpected error = %v", err)
+			}
+			if !tt.expectEnded {
+				if ended != 0 || room.Status == entity.CallRoomStatusEnded {
+					t.Errorf("ReapStaleRooms() ended = %d, status = %s, want untouched", ended, room.Status)
+				}
+				return
+			}
+			if ended != 1 || room.Status != entity.CallRoomStatusEnded {
+				t.Fatalf("ReapStaleRooms() ended = %d, status = %s", ended, room.Status)
+			}
+			if room.EndedAt == nil || !room.EndedAt.Equal(*tt.expectedEndedAt) {
+				t.Errorf("ReapStaleRooms() ended_at = %v, want %v", room.EndedAt, tt.expectedEndedAt)
+			}
+
+			// 参加中の参加区間は閉じられる
+			for _, p := range participantRepo.Participants {
+				if p.IsActive || p.LeftAt == nil {
+					t.Errorf("participant %d still active", p.ID)
+				}
+			}
+		})
+	}
+}
+
+func TestRoomReaperUsecase_ReapStaleRooms_Lock(t *testing.T) {
+	tests := []struct {
+		name        string
+		heldByOther bool
+		expectEnded int
+	}{
+		{name: "lock held by another instance", heldByOther: true, expectEnded: 0},
+		{name: "lock acquired", heldByOther: false, expectEnded: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			now := time.Now()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			lock := testutil.NewMockDistributedLock()
+			usecase := NewRoomReaperUsecase(roomRepo, testutil.NewMockCallParticipantRepository(), testutil.NewMockCallRecordingRepository(), testutil.NewMockCallMinutesRepository(), &fakeTranscriptionJobUsecase{}, fakeRoomConnections{}, lock, testutil.NewMockEventPublisher(), RoomReaperConfig{WaitingTTL: time.Hour})
+			ctx := context.Background()
+
+			room := &entity.CallRoom{RoomID: "room-1", Status: entity.CallRoomStatusWaiting}
+			roomRepo.Create(ctx, room)
+			room.CreatedAt = now.Add(-2 * time.Hour)
+			lock.Held[roomReaperLockName] = tt.heldByOther
+
+			// Act
+			ended, err := usecase.ReapStaleRooms(ctx, now)
+
+			// Assert
+			if err != nil || ended != tt.expectEnded {
+				t.Fatalf("ReapStaleRooms() ended = %d, err = %v, want %d", ended, err, tt.expectEnded)
+			}
+			if tt.heldByOther {
+				if room.Status != entity.CallRoomStatusWaiting {
+					t.Errorf("room status = %s, want waiting while another instance holds the lock", room.Status)
+				}
+				return
+			}
+			if lock.Held[roomReaperLockName] {
+				t.Error("ReapStaleRooms() did not release the lock")
+			}
+		})
+	}
+}
+
+func TestRoomReaperUsecase_ReapStaleRooms_Transcribe(t *testing.T) {
+	tests := []struct {
+		name            string
+		transcribeOnEnd bool
+		hasRecording    bool
+		hasMinutes      bool
+		expectEnqueued  bool
+	}{
+		{name: "recording without minutes", transcribeOnEnd: true, hasRecording: true, expectEnqueued: true},
+		{name: "minutes already created", transcribeOnEnd: true, hasRecording: true, hasMinutes: true},
+		{name: "no recording", transcribeOnEnd: true},
+		{name: "transcription on end disabled", hasRecording: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			now := time.Now()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			participantRepo := testutil.NewMockCallParticipantRepository()
+			recordingRepo := testutil.NewMockCallRecordingRepository()
+			minutesRepo := testutil.NewMockCallMinutesRepository()
+			jobs := &fakeTranscriptionJobUsecase{}
+			usecase := NewRoomReaperUsecase(roomRepo, participantRepo, recordingRepo, minutesRepo, jobs, fakeRoomConnections{}, testutil.NewMockDistributedLock(), testutil.NewMockEventPublisher(), RoomReaperConfig{IdleTTL: time.Minute, TranscribeOnEnd: tt.transcribeOnEnd})
+			ctx := context.Background()
+
+			left := now.Add(-time.Hour)
+			room := &entity.CallRoom{RoomID: "room-1", Status: entity.CallRoomStatusActive}
+			roomRepo.Create(ctx, room)
+			participantRepo.Create(ctx, &entity.CallParticipant{RoomID: room.ID, UserID: 1, JoinedAt: left.Add(-time.Hour), LeftAt: &left})
+			if tt.hasRecording {
+				recordingRepo.Create(ctx, &entity.CallRecording{RoomID: room.ID})
+			}
+			if tt.hasMinutes {
+				minutesRepo.Create(ctx, &entity.CallMinutes{RoomID: room.ID})
+			}
+
+			// Act
+			ended, err := usecase.ReapStaleRooms(ctx, now)
+
+			// Assert
+			if err != nil || ended != 1 {
+				t.Fatalf("ReapStaleRooms() ended = %d, err = %v, want 1", ended, err)
+			}
+			if enqueued := len(jobs.enqueued) == 1 && jobs.enqueued[0] == room.ID; enqueued != tt.expectEnqueued {
+				t.Errorf("enqueued rooms = %v, want room %d enqueued = %v", jobs.enqueued, room.ID, tt.expectEnqueued)
+			}
+		})
+	}
+}
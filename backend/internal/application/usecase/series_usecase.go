@@ -0,0 +1,608 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/rrule"
+)
+
+// maxOccurrenceScan 開催回を展開する際に走査する最大件数
+const maxOccurrenceScan = 5000
+
+// SeriesUsecase 定例会議シリーズユースケースのインターフェース
+type SeriesUsecase interface {
+	// シリーズ作成（exDatesは中止する開催回）
+	CreateSeries(ctx context.Context, series *entity.CallSeries, exDates []time.Time) error
+	// シリーズ取得（作成者と開催回の参加者のみ閲覧可能）
+	GetSeries(ctx context.Context, seriesID string, userID int64) (*entity.CallSeries, error)
+	// ユーザーが作成したシリーズ一覧取得
+	GetSeriesByUser(ctx context.Context, userID int64) ([]*entity.CallSeries, error)
+	// 今後の開催回一覧取得（生成済みの通話ルームがあれば紐付ける）
+	ListOccurrences(ctx context.Context, series *entity.CallSeries, from time.Time, limit int) ([]*entity.CallOccurrence, error)
+	// 開催回の通話ルームを取得し、無ければ作成する
+	OpenOccurrence(ctx context.Context, series *entity.CallSeries, originalStart time.Time) (*entity.CallOccurrence, error)
+	// 開催回の編集（この回のみ・これ以降・全て）。編集後に対象となるシリーズを返す
+	EditOccurrence(ctx context.Context, series *entity.CallSeries, originalStart time.Time, scope entity.SeriesEditScope, edit *SeriesEdit) (*entity.CallSeries, error)
+}
+
+// SeriesEdit 開催回の編集内容（nilの項目は変更しない）
+type SeriesEdit struct {
+	Name            *string
+	StartAt         *time.Time
+	DurationMinutes *int
+	RRule           *string
+	Cancelled       *bool
+}
+
+// cancels 開催回を中止する編集か判定
+func (e *SeriesEdit) cancels() bool {
+	return e.Cancelled != nil && *e.Cancelled
+}
+
+type seriesUsecase struct {
+	seriesRepo      port.CallSeriesRepository
+	roomRepo        port.CallRoomRepository
+	participantRepo port.CallParticipantRepository
+}
+
+// NewSeriesUsecase 新しい定例会議シリーズユースケースを作成
+func NewSeriesUsecase(
+	seriesRepo port.CallSeriesRepository,
+	roomRepo port.CallRoomRepository,
+	participantRepo port.CallParticipantRepository,
+) SeriesUsecase {
+	return &seriesUsecase{
+		seriesRepo:      seriesRepo,
+		roomRepo:        roomRepo,
+		participantRepo: participantRepo,
+	}
+}
+
+// CreateSeries シリーズを作成
+func (u *seriesUsecase) CreateSeries(ctx context.Context, series *entity.CallSeries, exDates []time.Time) error {
+	if err := normalizeSeries(series); err != nil {
+		return err
+	}
+
+	if err := u.seriesRepo.Create(ctx, series); err != nil {
+		return fmt.Errorf("failed to create series: %w", err)
+	}
+
+	for _, exDate := range exDates {
+		exception := &entity.CallSeriesException{
+			SeriesID:      series.ID,
+			OriginalStart: exDate,
+			Cancelled:     true,
+		}
+		if err := u.seriesRepo.SaveException(ctx, exception); err != nil {
+			return fmt.Errorf("failed to save exdate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// normalizeSeries シリーズの入力を検証し、既定値と正規化したRRULEを設定する
+func normalizeSeries(series *entity.CallSeries) error {
+	if series.Name == "" {
+		return entity.ErrNameRequired
+	}
+	if series.DurationMinutes <= 0 {
+		return entity.ErrInvalidDuration
+	}
+	if series.MaxParticipants <= 0 {
+		series.MaxParticipants = 10
+	}
+	if series.Timezone == "" {
+		series.Timezone = "UTC"
+	}
+	rule, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return fmt.Errorf("%w: %v", entity.ErrInvalidRecurrence, err)
+	}
+	if _, err := series.Location(); err != nil {
+		return err
+	}
+	series.RRule = rule.String()
+	if series.SeriesID == "" {
+		series.SeriesID = uuid.New().String()
+	}
+	return nil
+}
+
+// GetSeries シリーズを取得（作成者と、いずれかの開催回に参加したユーザーのみ閲覧可能）
+func (u *seriesUsecase) GetSeries(ctx context.Context, seriesID string, userID int64) (*entity.CallSeries, error) {
+	series, err := u.seriesRepo.FindBySeriesID(ctx, seriesID)
+	if err != nil {
+		return nil, err
+	}
+	if series.CreatedBy == userID {
+		return series, nil
+	}
+
+	rooms, err := u.roomRepo.FindBySeriesID(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series rooms: %w", err)
+	}
+	roomIDs := make([]int64, len(rooms))
+	for i, room := range rooms {
+		roomIDs[i] = room.ID
+	}
+	participants, err := u.participantRepo.FindByRoomIDs(ctx, roomIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series participants: %w", err)
+	}
+	for _, p := range participants {
+		if !p.IsGuest() && p.UserID == userID {
+			return series, nil
+		}
+	}
+
+	return nil, entity.ErrSeriesAccessDenied
+}
+
+// GetSeriesByUser ユーザーが作成したシリーズ一覧を取得
+func (u *seriesUsecase) GetSeriesByUser(ctx context.Context, userID int64) ([]*entity.CallSeries, error) {
+	return u.seriesRepo.FindByCreatedBy(ctx, userID)
+}
+
+// ListOccurrences 今後の開催回一覧を取得し、生成済みの通話ルームを紐付ける
+func (u *seriesUsecase) ListOccurrences(ctx context.Context, series *entity.CallSeries, from time.Time, limit int) ([]*entity.CallOccurrence, error) {
+	exceptions, err := u.seriesRepo.FindExceptions(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exceptions: %w", err)
+	}
+
+	occurrences, err := expandOccurrences(series, exceptions, from, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rooms, err := u.roomRepo.FindBySeriesID(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series rooms: %w", err)
+	}
+	for _, occ := range occurrences {
+		for _, room := range rooms {
+			if room.OccurrenceStart != nil && room.OccurrenceStart.Equal(occ.OriginalStart) {
+				occ.Room = room
+				break
+			}
+		}
+	}
+
+	return occurrences, nil
+}
+
+// OpenOccurrence 開催回の通話ルームを取得し、無ければ作成する
+func (u *seriesUsecase) OpenOccurrence(ctx context.Context, series *entity.CallSeries, originalStart time.Time) (*entity.CallOccurrence, error) {
+	ok, err := isOccurrence(series, originalStart)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, entity.ErrOccurrenceNotFound
+	}
+
+	exceptions, err := u.seriesRepo.FindExceptions(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get exceptions: %w", err)
+	}
+	occ := newOccurrence(series, originalStart, findException(exceptions, originalStart))
+	if occ.Cancelled {
+		return nil, entity.ErrOccurrenceNotFound
+	}
+
+	if err := u.materializeRoom(ctx, series, occ); err != nil {
+		return nil, fmt.Errorf("failed to materialize room: %w", err)
+	}
+	return occ, nil
+}
+
+// EditOccurrence 開催回を編集
+func (u *seriesUsecase) EditOccurrence(ctx context.Context, series *entity.CallSeries, originalStart time.Time, scope entity.SeriesEditScope, edit *SeriesEdit) (*entity.CallSeries, error) {
+	if !scope.IsValid() {
+		return nil, entity.ErrInvalidEditScope
+	}
+	if edit.DurationMinutes != nil && *edit.DurationMinutes <= 0 {
+		return nil, entity.ErrInvalidDuration
+	}
+	if edit.Name != nil && *edit.Name == "" {
+		return nil, entity.ErrNameRequired
+	}
+
+	ok, err := isOccurrence(series, originalStart)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, entity.ErrOccurrenceNotFound
+	}
+
+	switch scope {
+	case entity.SeriesEditScopeThis:
+		return series, u.editThis(ctx, series, originalStart, edit)
+	case entity.SeriesEditScopeFollowing:
+		if originalStart.Equal(series.StartAt) {
+			return series, u.editAll(ctx, series, originalStart, edit)
+		}
+		return u.editFollowing(ctx, series, originalStart, edit)
+	default:
+		return series, u.editAll(ctx, series, originalStart, edit)
+	}
+}
+
+// editThis この回のみを編集（例外として保存）
+func (u *seriesUsecase) editThis(ctx context.Context, series *entity.CallSeries, originalStart time.Time, edit *SeriesEdit) error {
+	if edit.RRule != nil {
+		return fmt.Errorf("%w: rrule can only be changed for following or all occurrences", entity.ErrInvalidEditScope)
+	}
+
+	exceptions, err := u.seriesRepo.FindExceptions(ctx, series.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get exceptions: %w", err)
+	}
+
+	exception := findException(exceptions, originalStart)
+	if exception == nil {
+		exception = &entity.CallSeriesException{
+			SeriesID:      series.ID,
+			OriginalStart: originalStart,
+		}
+	}
+
+	if edit.Cancelled != nil {
+		exception.Cancelled = *edit.Cancelled
+	}
+	if edit.Name != nil {
+		exception.Name = edit.Name
+	}
+	if edit.StartAt != nil {
+		exception.StartAt = edit.StartAt
+	}
+	if edit.DurationMinutes != nil {
+		exception.DurationMinutes = edit.DurationMinutes
+	}
+
+	if err := u.seriesRepo.SaveException(ctx, exception); err != nil {
+		return fmt.Errorf("failed to save exception: %w", err)
+	}
+
+	return u.syncRoom(ctx, newOccurrence(series, originalStart, exception))
+}
+
+// editAll シリーズ全体を編集
+func (u *seriesUsecase) editAll(ctx context.Context, series *entity.CallSeries, originalStart time.Time, edit *SeriesEdit) error {
+	if edit.cancels() {
+		return fmt.Errorf("%w: use scope \"this\" to cancel an occurrence", entity.ErrInvalidEditScope)
+	}
+
+	recurrenceChanged := false
+	if edit.Name != nil {
+		series.Name = *edit.Name
+	}
+	if edit.DurationMinutes != nil {
+		series.DurationMinutes = *edit.DurationMinutes
+	}
+	if edit.RRule != nil {
+		rule, err := rrule.Parse(*edit.RRule)
+		if err != nil {
+			return fmt.Errorf("%w: %v", entity.ErrInvalidRecurrence, err)
+		}
+		recurrenceChanged = rule.String() != series.RRule
+		series.RRule = rule.String()
+	}
+	if edit.StartAt != nil {
+		// 編集した回との差分だけシリーズ全体をずらす
+		delta := edit.StartAt.Sub(originalStart)
+		if delta != 0 {
+			series.StartAt = series.StartAt.Add(delta)
+			recurrenceChanged = true
+		}
+	}
+
+	if err := u.seriesRepo.Update(ctx, series); err != nil {
+		return fmt.Errorf("failed to update series: %w", err)
+	}
+
+	// 繰り返しが変わった場合、既存の例外は対応する開催回を失うため破棄する
+	var exceptions []*entity.CallSeriesException
+	if recurrenceChanged {
+		if err := u.seriesRepo.DeleteExceptionsFrom(ctx, series.ID, time.Time{}); err != nil {
+			return fmt.Errorf("failed to delete exceptions: %w", err)
+		}
+	} else {
+		var err error
+		exceptions, err = u.seriesRepo.FindExceptions(ctx, series.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get exceptions: %w", err)
+		}
+	}
+
+	rooms, err := u.roomRepo.FindBySeriesID(ctx, series.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get series rooms: %w", err)
+	}
+
+	for _, room := range rooms {
+		if room.Status != entity.CallRoomStatusWaiting || room.OccurrenceStart == nil {
+			continue
+		}
+		ok, err := isOccurrence(series, *room.OccurrenceStart)
+		if err != nil {
+			return err
+		}
+		occ := newOccurrence(series, *room.OccurrenceStart, findException(exceptions, *room.OccurrenceStart))
+		if !ok {
+			occ.Cancelled = true
+		}
+		occ.Room = room
+		if err := u.syncRoom(ctx, occ); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// editFollowing この回以降を編集（シリーズを分割して新しいシリーズを作成）
+func (u *seriesUsecase) editFollowing(ctx context.Context, series *entity.CallSeries, originalStart time.Time, edit *SeriesEdit) (*entity.CallSeries, error) {
+	if edit.cancels() {
+		return nil, fmt.Errorf("%w: use scope \"this\" to cancel an occurrence", entity.ErrInvalidEditScope)
+	}
+
+	rule, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrInvalidRecurrence, err)
+	}
+	loc, err := series.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	// 元のシリーズは対象回の直前で終了させる
+	before := len(rule.Between(series.StartAt.In(loc), series.StartAt, originalStart, 0))
+	if before == 0 {
+		return series, u.editAll(ctx, series, originalStart, edit)
+	}
+
+	oldRule := *rule
+	newRule := *rule
+	if rule.Count > 0 {
+		oldRule.Count = before
+		newRule.Count = rule.Count - before
+	} else {
+		oldRule.Until = originalStart.Add(-time.Second).UTC()
+	}
+
+	if edit.RRule != nil {
+		parsed, err := rrule.Parse(*edit.RRule)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", entity.ErrInvalidRecurrence, err)
+		}
+		newRule = *parsed
+	}
+
+	newSeries := &entity.CallSeries{
+		Name:            series.Name,
+		CreatedBy:       series.CreatedBy,
+		RRule:           newRule.String(),
+		StartAt:         originalStart,
+		DurationMinutes: series.DurationMinutes,
+		Timezone:        series.Timezone,
+		MaxParticipants: series.MaxParticipants,
+	}
+	if edit.Name != nil {
+		newSeries.Name = *edit.Name
+	}
+	if edit.DurationMinutes != nil {
+		newSeries.DurationMinutes = *edit.DurationMinutes
+	}
+	if edit.StartAt != nil {
+		newSeries.StartAt = *edit.StartAt
+	}
+	if err := normalizeSeries(newSeries); err != nil {
+		return nil, err
+	}
+
+	// 開始日時と繰り返しが変わらない場合は、以降の例外を新しいシリーズへ引き継ぐ
+	carryOver := newSeries.StartAt.Equal(originalStart) && edit.RRule == nil
+
+	shortened := *series
+	shortened.RRule = oldRule.String()
+	if err := u.seriesRepo.Split(ctx, &shortened, newSeries, originalStart, carryOver); err != nil {
+		return nil, fmt.Errorf("failed to split series: %w", err)
+	}
+	*series = shortened
+
+	// 以降の未開始ルームは新しいシリーズで再生成されるため終了させる
+	rooms, err := u.roomRepo.FindBySeriesID(ctx, series.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get series rooms: %w", err)
+	}
+	for _, room := range rooms {
+		if room.Status != entity.CallRoomStatusWaiting || room.OccurrenceStart == nil || room.OccurrenceStart.Before(originalStart) {
+			continue
+		}
+		if err := u.syncRoom(ctx, &entity.CallOccurrence{Cancelled: true, Room: room}); err != nil {
+			return nil, err
+		}
+	}
+
+	return newSeries, nil
+}
+
+// materializeRoom 開催回に対応する通話ルームを取得し、無ければ作成する
+func (u *seriesUsecase) materializeRoom(ctx context.Context, series *entity.CallSeries, occ *entity.CallOccurrence) error {
+	room, err := u.roomRepo.FindBySeriesOccurrence(ctx, series.ID, occ.OriginalStart)
+	if err == nil && room != nil {
+		occ.Room = room
+		return nil
+	}
+
+	originalStart := occ.OriginalStart.UTC()
+	scheduledAt := occ.StartAt.UTC()
+	seriesID := series.ID
+	room = &entity.CallRoom{
+		RoomID:          uuid.New().String(),
+		Name:            occ.Name,
+		CreatedBy:       series.CreatedBy,
+		Status:          entity.CallRoomStatusWaiting,
+		MaxParticipants: series.MaxParticipants,
+		SeriesID:        &seriesID,
+		OccurrenceStart: &originalStart,
+		ScheduledAt:     &scheduledAt,
+	}
+
+	if err := u.roomRepo.Create(ctx, room); err != nil {
+		// 同時リクエストで既に作成されている場合は既存のルームを使う
+		existing, findErr := u.roomRepo.FindBySeriesOccurrence(ctx, series.ID, occ.OriginalStart)
+		if findErr != nil {
+			return err
+		}
+		room = existing
+	} else {
+		slog.Info("Series occurrence room created",
+			slog.String("series_id", series.SeriesID),
+			slog.String("room_id", room.RoomID),
+			slog.Time("occurrence_start", originalStart),
+		)
+	}
+
+	occ.Room = room
+	return nil
+}
+
+// syncRoom 開催回の変更を未開始の通話ルームに反映する
+func (u *seriesUsecase) syncRoom(ctx context.Context, occ *entity.CallOccurrence) error {
+	room := occ.Room
+	if room == nil && occ.SeriesID != 0 {
+		found, err := u.roomRepo.FindBySeriesOccurrence(ctx, occ.SeriesID, occ.OriginalStart)
+		if err != nil {
+			// まだルームが生成されていない
+			return nil
+		}
+		room = found
+	}
+	if room == nil || room.Status != entity.CallRoomStatusWaiting {
+		return nil
+	}
+
+	if occ.Cancelled {
+		now := time.Now()
+		room.Status = entity.CallRoomStatusEnded
+		room.EndedAt = &now
+	} else {
+		scheduledAt := occ.StartAt.UTC()
+		room.Name = occ.Name
+		room.ScheduledAt = &scheduledAt
+	}
+
+	if err := u.roomRepo.Update(ctx, room); err != nil {
+		return fmt.Errorf("failed to update occurrence room: %w", err)
+	}
+	return nil
+}
+
+// expandOccurrences from以降に終了する開催回を最大limit件展開する（中止された回は除く）
+func expandOccurrences(series *entity.CallSeries, exceptions []*entity.CallSeriesException, from time.Time, limit int) ([]*entity.CallOccurrence, error) {
+	rule, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", entity.ErrInvalidRecurrence, err)
+	}
+	loc, err := series.Location()
+	if err != nil {
+		return nil, err
+	}
+
+	var occurrences []*entity.CallOccurrence
+	it := rule.Iterator(series.StartAt.In(loc))
+	for i := 0; i < maxOccurrenceScan && len(occurrences) < limit; i++ {
+		start, ok := it.Next()
+		if !ok {
+			break
+		}
+		occ := newOccurrence(series, start, findException(exceptions, start))
+		if occ.Cancelled || !occ.EndAt.After(from) {
+			continue
+		}
+		occurrences = append(occurrences, occ)
+	}
+
+	// 個別に時刻を変更した回があるため開始日時順に並べ直す
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return occurrences[i].StartAt.Before(occurrences[j].StartAt)
+	})
+
+	return occurrences, nil
+}
+
+// isOccurrence 指定日時がシリーズの開催回（本来の開始日時）か判定
+func isOccurrence(series *entity.CallSeries, originalStart time.Time) (bool, error) {
+	rule, err := rrule.Parse(series.RRule)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", entity.ErrInvalidRecurrence, err)
+	}
+	loc, err := series.Location()
+	if err != nil {
+		return false, err
+	}
+
+	it := rule.Iterator(series.StartAt.In(loc))
+	for i := 0; i < maxOccurrenceScan; i++ {
+		start, ok := it.Next()
+		if !ok || start.After(originalStart) {
+			return false, nil
+		}
+		if start.Equal(originalStart) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// newOccurrence 本来の開始日時と例外から開催回を組み立てる
+func newOccurrence(series *entity.CallSeries, originalStart time.Time, exception *entity.CallSeriesException) *entity.CallOccurrence {
+	occ := &entity.CallOccurrence{
+		SeriesID:      series.ID,
+		OriginalStart: originalStart.UTC(),
+		StartAt:       originalStart.UTC(),
+		EndAt:         originalStart.Add(series.Duration()).UTC(),
+		Name:          series.Name,
+	}
+	if exception == nil {
+		return occ
+	}
+
+	duration := series.Duration()
+	if exception.DurationMinutes != nil {
+		duration = time.Duration(*exception.DurationMinutes) * time.Minute
+	}
+	if exception.StartAt != nil {
+		occ.StartAt = exception.StartAt.UTC()
+	}
+	if exception.Name != nil {
+		occ.Name = *exception.Name
+	}
+	occ.EndAt = occ.StartAt.Add(duration)
+	occ.Cancelled = exception.Cancelled
+	return occ
+}
+
+// findException 本来の開始日時に対応する例外を探す
+func findException(exceptions []*entity.CallSeriesException, originalStart time.Time) *entity.CallSeriesException {
+	for _, e := range exceptions {
+		if e.OriginalStart.Equal(originalStart) {
+			return e
+		}
+	}
+	return nil
+}
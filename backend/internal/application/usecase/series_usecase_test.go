@@ -0,0 +1,372 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// newDailyStandup テスト用の毎日9時(UTC)のシリーズを作成
+func newDailyStandup(t *testing.T, uc SeriesUsecase, rule string) *entity.CallSeries {
+	t.Helper()
+	series := &entity.CallSeries{
+		Name:            "Daily Standup",
+		CreatedBy:       1,
+		RRule:           rule,
+		StartAt:         time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC),
+		DurationMinutes: 15,
+		Timezone:        "UTC",
+	}
+	if err := uc.CreateSeries(context.Background(), series, nil); err != nil {
+		t.Fatalf("CreateSeries() unexpected error = %v", err)
+	}
+	return series
+}
+
+func day(d int) time.Time {
+	return time.Date(2026, 10, d, 9, 0, 0, 0, time.UTC)
+}
+
+// openOccurrences テスト用に開催回の通話ルームを作成
+func openOccurrences(t *testing.T, uc SeriesUsecase, series *entity.CallSeries, starts ...time.Time) []*entity.CallOccurrence {
+	t.Helper()
+	occurrences := make([]*entity.CallOccurrence, len(starts))
+	for i, start := range starts {
+		occ, err := uc.OpenOccurrence(context.Background(), series, start)
+		if err != nil {
+			t.Fatalf("OpenOccurrence() unexpected error = %v", err)
+		}
+		occurrences[i] = occ
+	}
+	return occurrences
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestSeriesUsecase_CreateSeries(t *testing.T) {
+	tests := []struct {
+		name        string
+		series      *entity.CallSeries
+		expectedErr error
+	}{
+		{
+			name: "valid series",
+			series: &entity.CallSeries{
+				Name: "Standup", RRule: "FREQ=DAILY", StartAt: day(19), DurationMinutes: 15, Timezone: "Asia/Tokyo",
+			},
+		},
+		{
+			name: "invalid rrule",
+			series: &entity.CallSeries{
+				Name: "Standup", RRule: "FREQ=SOMETIMES", StartAt: day(19), DurationMinutes: 15,
+			},
+			expectedErr: entity.ErrInvalidRecurrence,
+		},
+		{
+			name: "invalid timezone",
+			series: &entity.CallSeries{
+				Name: "Standup", RRule: "FREQ=DAILY", StartAt: day(19), DurationMinutes: 15, Timezone: "Mars/Olympus",
+			},
+			expectedErr: entity.ErrInvalidTimezone,
+		},
+		{
+			name: "zero duration",
+			series: &entity.CallSeries{
+				Name: "Standup", RRule: "FREQ=DAILY", StartAt: day(19),
+			},
+			expectedErr: entity.ErrInvalidDuration,
+		},
+		{
+			name: "empty name",
+			series: &entity.CallSeries{
+				RRule: "FREQ=DAILY", StartAt: day(19), DurationMinutes: 15,
+			},
+			expectedErr: entity.ErrNameRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewSeriesUsecase(testutil.NewMockCallSeriesRepository(), testutil.NewMockCallRoomRepository(), testutil.NewMockCallParticipantRepository())
+
+			err := uc.CreateSeries(context.Background(), tt.series, nil)
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("CreateSeries() error = %v, expectedErr %v", err, tt.expectedErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateSeries() unexpected error = %v", err)
+			}
+			if tt.series.SeriesID == "" {
+				t.Error("CreateSeries() SeriesID should be generated")
+			}
+		})
+	}
+}
+
+func TestSeriesUsecase_ListOccurrences(t *testing.T) {
+	seriesRepo := testutil.NewMockCallSeriesRepository()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	uc := NewSeriesUsecase(seriesRepo, roomRepo, testutil.NewMockCallParticipantRepository())
+	ctx := context.Background()
+
+	series := &entity.CallSeries{
+		Name: "Daily Standup", CreatedBy: 1, RRule: "FREQ=DAILY", StartAt: day(19), DurationMinutes: 15,
+	}
+	if err := uc.CreateSeries(ctx, series, []time.Time{day(20)}); err != nil {
+		t.Fatalf("CreateSeries() unexpected error = %v", err)
+	}
+
+	occurrences, err := uc.ListOccurrences(ctx, series, day(19), 3)
+	if err != nil {
+		t.Fatalf("ListOccurrences() unexpected error = %v", err)
+	}
+
+	if len(occurrences) != 3 {
+		t.Fatalf("ListOccurrences() returned %d occurrences, want 3", len(occurrences))
+	}
+	// 10/20 は EXDATE のためスキップされる
+	want := []time.Time{day(19), day(21), day(22)}
+	for i, occ := range occurrences {
+		if !occ.StartAt.Equal(want[i]) {
+			t.Errorf("occurrence[%d].StartAt = %v, want %v", i, occ.StartAt, want[i])
+		}
+		if occ.Room != nil {
+			t.Errorf("occurrence[%d] should not have a room before it is opened", i)
+		}
+	}
+	// 一覧の取得ではルームを作成しない
+	if len(roomRepo.Rooms) != 0 {
+		t.Errorf("expected no rooms, got %d", len(roomRepo.Rooms))
+	}
+
+	opened := openOccurrences(t, uc, series, day(21))[0]
+	if opened.Room == nil || opened.Room.RoomID == "" {
+		t.Fatal("OpenOccurrence() should materialize a room")
+	}
+	if opened.Room.SeriesID == nil || *opened.Room.SeriesID != series.ID {
+		t.Error("OpenOccurrence() room is not linked to series")
+	}
+
+	// 2回目の呼び出しでは同じルームを再利用し、一覧にも紐付く
+	again := openOccurrences(t, uc, series, day(21))[0]
+	if again.Room.RoomID != opened.Room.RoomID {
+		t.Error("OpenOccurrence() should reuse materialized rooms")
+	}
+	listed, err := uc.ListOccurrences(ctx, series, day(19), 3)
+	if err != nil {
+		t.Fatalf("ListOccurrences() unexpected error = %v", err)
+	}
+	if listed[1].Room == nil || listed[1].Room.RoomID != opened.Room.RoomID {
+		t.Error("ListOccurrences() should attach opened rooms")
+	}
+	if len(roomRepo.Rooms) != 1 {
+		t.Errorf("expected 1 room, got %d", len(roomRepo.Rooms))
+	}
+
+	// 中止された回は開けない
+	if _, err := uc.OpenOccurrence(ctx, series, day(20)); !errors.Is(err, entity.ErrOccurrenceNotFound) {
+		t.Errorf("OpenOccurrence(cancelled) error = %v, want %v", err, entity.ErrOccurrenceNotFound)
+	}
+}
+
+func TestSeriesUsecase_EditOccurrence_This(t *testing.T) {
+	seriesRepo := testutil.NewMockCallSeriesRepository()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	uc := NewSeriesUsecase(seriesRepo, roomRepo, testutil.NewMockCallParticipantRepository())
+	ctx := context.Background()
+	series := newDailyStandup(t, uc, "FREQ=DAILY")
+	occurrences := openOccurrences(t, uc, series, day(19), day(20))
+
+	// 10/20 のみ 1時間後ろにずらし、名前を変更
+	newStart := day(20).Add(time.Hour)
+	name := "Standup (moved)"
+	_, err := uc.EditOccurrence(ctx, series, day(20), entity.SeriesEditScopeThis, &SeriesEdit{Name: &name, StartAt: &newStart})
+	if err != nil {
+		t.Fatalf("EditOccurrence() unexpected error = %v", err)
+	}
+
+	room := occurrences[1].Room
+	if room.Name != name {
+		t.Errorf("room name = %q, want %q", room.Name, name)
+	}
+	if room.ScheduledAt == nil || !room.ScheduledAt.Equal(newStart) {
+		t.Errorf("room scheduled_at = %v, want %v", room.ScheduledAt, newStart)
+	}
+
+	// 10/19 を中止するとルームは終了になり一覧から消える
+	if _, err := uc.EditOccurrence(ctx, series, day(19), entity.SeriesEditScopeThis, &SeriesEdit{Cancelled: boolPtr(true)}); err != nil {
+		t.Fatalf("EditOccurrence() unexpected error = %v", err)
+	}
+	if occurrences[0].Room.Status != entity.CallRoomStatusEnded {
+		t.Errorf("cancelled occurrence room status = %s, want ended", occurrences[0].Room.Status)
+	}
+
+	// 中止を指定しない編集では中止状態を維持する
+	renamed := "Standup (cancelled)"
+	if _, err := uc.EditOccurrence(ctx, series, day(19), entity.SeriesEditScopeThis, &SeriesEdit{Name: &renamed}); err != nil {
+		t.Fatalf("EditOccurrence() unexpected error = %v", err)
+	}
+	exceptions, err := seriesRepo.FindExceptions(ctx, series.ID)
+	if err != nil {
+		t.Fatalf("FindExceptions() unexpected error = %v", err)
+	}
+	if len(exceptions) == 0 || !exceptions[0].Cancelled {
+		t.Error("EditOccurrence() without cancelled should keep the occurrence cancelled")
+	}
+
+	listed, err := uc.ListOccurrences(ctx, series, day(19), 2)
+	if err != nil {
+		t.Fatalf("ListOccurrences() unexpected error = %v", err)
+	}
+	if !listed[0].StartAt.Equal(newStart) || listed[0].Name != name {
+		t.Errorf("first occurrence = %v %q, want moved occurrence", listed[0].StartAt, listed[0].Name)
+	}
+}
+
+func TestSeriesUsecase_EditOccurrence_Following(t *testing.T) {
+	seriesRepo := testutil.NewMockCallSeriesRepository()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	uc := NewSeriesUsecase(seriesRepo, roomRepo, testutil.NewMockCallParticipantRepository())
+	ctx := context.Background()
+	series := newDailyStandup(t, uc, "FREQ=DAILY;COUNT=10")
+
+	openOccurrences(t, uc, series, day(19), day(20), day(21), day(22), day(23))
+
+	name := "Standup v2"
+	newSeries, err := uc.EditOccurrence(ctx, series, day(22), entity.SeriesEditScopeFollowing, &SeriesEdit{Name: &name})
+	if err != nil {
+		t.Fatalf("EditOccurrence() unexpected error = %v", err)
+	}
+
+	if newSeries.ID == series.ID {
+		t.Fatal("EditOccurrence(following) should create a new series")
+	}
+	if !strings.Contains(series.RRule, "COUNT=3") {
+		t.Errorf("original series rrule = %q, want COUNT=3", series.RRule)
+	}
+	if !strings.Contains(newSeries.RRule, "COUNT=7") {
+		t.Errorf("new series rrule = %q, want COUNT=7", newSeries.RRule)
+	}
+	if newSeries.Name != name || !newSeries.StartAt.Equal(day(22)) {
+		t.Errorf("new series = %q at %v", newSeries.Name, newSeries.StartAt)
+	}
+
+	// 分割点以降の未開始ルームは終了している
+	for _, room := range roomRepo.Rooms {
+		if room.SeriesID == nil || *room.SeriesID != series.ID {
+			continue
+		}
+		ended := room.Status == entity.CallRoomStatusEnded
+		if room.OccurrenceStart.Before(day(22)) == ended {
+			t.Errorf("room for %v status = %s", room.OccurrenceStart, room.Status)
+		}
+	}
+
+	oldOccurrences, err := uc.ListOccurrences(ctx, series, day(19), 10)
+	if err != nil {
+		t.Fatalf("ListOccurrences() unexpected error = %v", err)
+	}
+	if len(oldOccurrences) != 3 {
+		t.Errorf("original series has %d occurrences, want 3", len(oldOccurrences))
+	}
+}
+
+func TestSeriesUsecase_EditOccurrence_All(t *testing.T) {
+	seriesRepo := testutil.NewMockCallSeriesRepository()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	uc := NewSeriesUsecase(seriesRepo, roomRepo, testutil.NewMockCallParticipantRepository())
+	ctx := context.Background()
+	series := newDailyStandup(t, uc, "FREQ=DAILY")
+	occurrences := openOccurrences(t, uc, series, day(19), day(20))
+
+	name := "Team Sync"
+	if _, err := uc.EditOccurrence(ctx, series, day(20), entity.SeriesEditScopeAll, &SeriesEdit{Name: &name}); err != nil {
+		t.Fatalf("EditOccurrence() unexpected error = %v", err)
+	}
+
+	if series.Name != name {
+		t.Errorf("series name = %q, want %q", series.Name, name)
+	}
+	for _, occ := range occurrences {
+		if occ.Room.Name != name {
+			t.Errorf("room name = %q, want %q", occ.Room.Name, name)
+		}
+	}
+}
+
+func TestSeriesUsecase_EditOccurrence_Errors(t *testing.T) {
+	uc := NewSeriesUsecase(testutil.NewMockCallSeriesRepository(), testutil.NewMockCallRoomRepository(), testutil.NewMockCallParticipantRepository())
+	ctx := context.Background()
+	series := newDailyStandup(t, uc, "FREQ=WEEKLY")
+
+	rule := "FREQ=DAILY"
+	tests := []struct {
+		name          string
+		originalStart time.Time
+		scope         entity.SeriesEditScope
+		edit          *SeriesEdit
+		expectedErr   error
+	}{
+		{name: "invalid scope", originalStart: day(19), scope: "some", edit: &SeriesEdit{}, expectedErr: entity.ErrInvalidEditScope},
+		{name: "not an occurrence", originalStart: day(20), scope: entity.SeriesEditScopeThis, edit: &SeriesEdit{}, expectedErr: entity.ErrOccurrenceNotFound},
+		{name: "rrule on single occurrence", originalStart: day(26), scope: entity.SeriesEditScopeThis, edit: &SeriesEdit{RRule: &rule}, expectedErr: entity.ErrInvalidEditScope},
+		{name: "cancel all", originalStart: day(26), scope: entity.SeriesEditScopeAll, edit: &SeriesEdit{Cancelled: boolPtr(true)}, expectedErr: entity.ErrInvalidEditScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.EditOccurrence(ctx, series, tt.originalStart, tt.scope, tt.edit)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("EditOccurrence() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSeriesUsecase_GetSeries(t *testing.T) {
+	seriesRepo := testutil.NewMockCallSeriesRepository()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	participantRepo := testutil.NewMockCallParticipantRepository()
+	uc := NewSeriesUsecase(seriesRepo, roomRepo, participantRepo)
+	ctx := context.Background()
+	series := newDailyStandup(t, uc, "FREQ=DAILY")
+
+	occ := openOccurrences(t, uc, series, day(19))[0]
+	// 退出済みでも参加したことがあれば閲覧できる
+	if err := participantRepo.Create(ctx, &entity.CallParticipant{RoomID: occ.Room.ID, UserID: 2, IsActive: false}); err != nil {
+		t.Fatalf("Create() unexpected error = %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		userID      int64
+		expectedErr error
+	}{
+		{name: "creator", userID: 1},
+		{name: "past participant", userID: 2},
+		{name: "stranger", userID: 3, expectedErr: entity.ErrSeriesAccessDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.GetSeries(ctx, series.SeriesID, tt.userID)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("GetSeries() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr == nil && got.ID != series.ID {
+				t.Errorf("GetSeries() = %d, want %d", got.ID, series.ID)
+			}
+		})
+	}
+}
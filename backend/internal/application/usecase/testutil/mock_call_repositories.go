@@ -0,0 +1,914 @@
+package testutil
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// MockCallRoomRepository モック通話ルームリポジトリ
+type MockCallRoomRepository struct {
+	Rooms      map[int64]*entity.CallRoom
+	NextID     int64
+	CreateFunc func(ctx context.Context, room *entity.CallRoom) error
+	// FindHistoryで参加した通話の判定、FindLobbyRoomsの参加人数に使う参加記録
+	// （nilの場合は作成者のみで判定し、参加人数は0）
+	Participants *MockCallParticipantRepository
+}
+
+func NewMockCallRoomRepository() *MockCallRoomRepository {
+	return &MockCallRoomRepository{
+		Rooms:  make(map[int64]*entity.CallRoom),
+		NextID: 1,
+	}
+}
+
+func (m *MockCallRoomRepository) Create(ctx context.Context, room *entity.CallRoom) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, room)
+	}
+	room.ID = m.NextID
+	m.NextID++
+	room.CreatedAt = time.Now()
+	room.UpdatedAt = time.Now()
+	m.Rooms[room.ID] = room
+	return nil
+}
+
+func (m *MockCallRoomRepository) FindByRoomID(ctx context.Context, roomID string) (*entity.CallRoom, error) {
+	for _, room := range m.Rooms {
+		if room.RoomID == roomID {
+			return room, nil
+		}
+	}
+	return nil, errors.New("room not found")
+}
+
+func (m *MockCallRoomRepository) FindByID(ctx context.Context, id int64) (*entity.CallRoom, error) {
+	room, ok := m.Rooms[id]
+	if !ok {
+		return nil, errors.New("room not found")
+	}
+	return room, nil
+}
+
+func (m *MockCallRoomRepository) FindActiveRooms(ctx context.Context) ([]*entity.CallRoom, error) {
+	var rooms []*entity.CallRoom
+	for _, room := range m.sorted() {
+		if room.Status != entity.CallRoomStatusEnded {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms, nil
+}
+
+func (m *MockCallRoomRepository) FindLobbyRooms(ctx context.Context) ([]*entity.LobbyRoom, error) {
+	rooms, _ := m.FindActiveRooms(ctx)
+	lobbyRooms := make([]*entity.LobbyRoom, 0, len(rooms))
+	for _, room := range rooms {
+		count := 0
+		if m.Participants != nil {
+			for _, p := range m.Participants.Participants {
+				if p.RoomID == room.ID && p.IsActive {
+					count++
+				}
+			}
+		}
+		lobbyRooms = append(lobbyRooms, &entity.LobbyRoom{Room: room, ParticipantCount: count})
+	}
+	return lobbyRooms, nil
+}
+
+func (m *MockCallRoomRepository) Update(ctx context.Context, room *entity.CallRoom) error {
+	if _, ok := m.Rooms[room.ID]; !ok {
+		return errors.New("room not found")
+	}
+	room.UpdatedAt = time.Now()
+	m.Rooms[room.ID] = room
+	return nil
+}
+
+func (m *MockCallRoomRepository) FindByCreatedBy(ctx context.Context, userID int64) ([]*entity.CallRoom, error) {
+	var rooms []*entity.CallRoom
+	for _, room := range m.sorted() {
+		if room.CreatedBy == userID {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms, nil
+}
+
+func (m *MockCallRoomRepository) FindBySeriesOccurrence(ctx context.Context, seriesID int64, occurrenceStart time.Time) (*entity.CallRoom, error) {
+	for _, room := range m.Rooms {
+		if room.SeriesID != nil && *room.SeriesID == seriesID &&
+			room.OccurrenceStart != nil && room.OccurrenceStart.Equal(occurrenceStart) {
+			return room, nil
+		}
+	}
+	return nil, errors.New("room not found")
+}
+
+func (m *MockCallRoomRepository) FindBySeriesID(ctx context.Context, seriesID int64) ([]*entity.CallRoom, error) {
+	var rooms []*entity.CallRoom
+	for _, room := range m.sorted() {
+		if room.SeriesID != nil && *room.SeriesID == seriesID {
+			rooms = append(rooms, room)
+		}
+	}
+	return rooms, nil
+}
+
+func (m *MockCallRoomRepository) FindByPersonalRoomID(ctx context.Context, personalRoomID int64, limit int) ([]*entity.CallRoom, error) {
+	var rooms []*entity.CallRoom
+	for _, room := range m.sorted() {
+		if room.PersonalRoomID != nil && *room.PersonalRoomID == personalRoomID {
+			rooms = append(rooms, room)
+		}
+	}
+	sort.Slice(rooms, func(i, j int) bool { return *rooms[i].SessionNumber > *rooms[j].SessionNumber })
+	if len(rooms) > limit {
+		rooms = rooms[:limit]
+	}
+	return rooms, nil
+}
+
+func (m *MockCallRoomRepository) FindHistory(ctx context.Context, filter *entity.CallHistoryFilter) ([]*entity.CallRoom, int, error) {
+	var matched []*entity.CallRoom
+	for _, room := range m.sorted() {
+		created := room.CreatedBy == filter.UserID
+		joined := false
+		if m.Participants != nil {
+			for _, p := range m.Participants.Participants {
+				if p.RoomID == room.ID && !p.IsGuest() && p.UserID == filter.UserID {
+					joined = true
+				}
+			}
+		}
+		switch filter.Scope {
+		case entity.CallHistoryScopeCreated:
+			if !created {
+				continue
+			}
+		case entity.CallHistoryScopeJoined:
+			if !joined {
+				continue
+			}
+		default:
+			if !created && !joined {
+				continue
+			}
+		}
+
+		at := historyTime(room)
+		if filter.Status != "" && room.Status != filter.Status ||
+			filter.From != nil && at.Before(*filter.From) ||
+			filter.To != nil && !at.Before(*filter.To) ||
+			filter.Query != "" && !strings.Contains(room.Name, filter.Query) {
+			continue
+		}
+		matched = append(matched, room)
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		ti, tj := historyTime(matched[i]), historyTime(matched[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return matched[i].ID > matched[j].ID
+	})
+
+	total := len(matched)
+	if filter.Offset >= total {
+		return []*entity.CallRoom{}, total, nil
+	}
+	end := filter.Offset + filter.Limit
+	if end > total {
+		end = total
+	}
+	return matched[filter.Offset:end], total, nil
+}
+
+// historyTime 通話履歴の並び順に使う日時（未開始の場合は作成日時）
+func historyTime(room *entity.CallRoom) time.Time {
+	if room.StartedAt != nil {
+		return *room.StartedAt
+	}
+	return room.CreatedAt
+}
+
+// sorted ID順のルーム一覧
+func (m *MockCallRoomRepository) sorted() []*entity.CallRoom {
+	rooms := make([]*entity.CallRoom, 0, len(m.Rooms))
+	for _, room := range m.Rooms {
+		rooms = append(rooms, room)
+	}
+	sort.Slice(rooms, func(i, j int) bool { return rooms[i].ID < rooms[j].ID })
+	return rooms
+}
+
+// MockCallSeriesRepository モック定例会議シリーズリポジトリ
+type MockCallSeriesRepository struct {
+	Series     map[int64]*entity.CallSeries
+	Exceptions map[int64]*entity.CallSeriesException
+	NextID     int64
+}
+
+func NewMockCallSeriesRepository() *MockCallSeriesRepository {
+	return &MockCallSeriesRepository{
+		Series:     make(map[int64]*entity.CallSeries),
+		Exceptions: make(map[int64]*entity.CallSeriesException),
+		NextID:     1,
+	}
+}
+
+func (m *MockCallSeriesRepository) Create(ctx context.Context, series *entity.CallSeries) error {
+	series.ID = m.NextID
+	m.NextID++
+	m.Series[series.ID] = series
+	return nil
+}
+
+func (m *MockCallSeriesRepository) Update(ctx context.Context, series *entity.CallSeries) error {
+	if _, ok := m.Series[series.ID]; !ok {
+		return entity.ErrSeriesNotFound
+	}
+	m.Series[series.ID] = series
+	return nil
+}
+
+func (m *MockCallSeriesRepository) Split(ctx context.Context, series *entity.CallSeries, next *entity.CallSeries, from time.Time, moveExceptions bool) error {
+	if _, ok := m.Series[series.ID]; !ok {
+		return entity.ErrSeriesNotFound
+	}
+	m.Series[series.ID] = series
+	if err := m.Create(ctx, next); err != nil {
+		return err
+	}
+	for id, e := range m.Exceptions {
+		if e.SeriesID != series.ID || e.OriginalStart.Before(from) {
+			continue
+		}
+		if moveExceptions {
+			e.SeriesID = next.ID
+		} else {
+			delete(m.Exceptions, id)
+		}
+	}
+	return nil
+}
+
+func (m *MockCallSeriesRepository) FindBySeriesID(ctx context.Context, seriesID string) (*entity.CallSeries, error) {
+	for _, s := range m.Series {
+		if s.SeriesID == seriesID {
+			return s, nil
+		}
+	}
+	return nil, entity.ErrSeriesNotFound
+}
+
+func (m *MockCallSeriesRepository) FindByID(ctx context.Context, id int64) (*entity.CallSeries, error) {
+	s, ok := m.Series[id]
+	if !ok {
+		return nil, entity.ErrSeriesNotFound
+	}
+	return s, nil
+}
+
+func (m *MockCallSeriesRepository) FindByCreatedBy(ctx context.Context, userID int64) ([]*entity.CallSeries, error) {
+	var list []*entity.CallSeries
+	for _, s := range m.Series {
+		if s.CreatedBy == userID {
+			list = append(list, s)
+		}
+	}
+	return list, nil
+}
+
+func (m *MockCallSeriesRepository) SaveException(ctx context.Context, exception *entity.CallSeriesException) error {
+	for _, e := range m.Exceptions {
+		if e.SeriesID == exception.SeriesID && e.OriginalStart.Equal(exception.OriginalStart) {
+			exception.ID = e.ID
+		}
+	}
+	if exception.ID == 0 {
+		exception.ID = m.NextID
+		m.NextID++
+	}
+	m.Exceptions[exception.ID] = exception
+	return nil
+}
+
+func (m *MockCallSeriesRepository) FindExceptions(ctx context.Context, seriesID int64) ([]*entity.CallSeriesException, error) {
+	var list []*entity.CallSeriesException
+	for _, e := range m.Exceptions {
+		if e.SeriesID == seriesID {
+			list = append(list, e)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].OriginalStart.Before(list[j].OriginalStart) })
+	return list, nil
+}
+
+func (m *MockCallSeriesRepository) DeleteExceptionsFrom(ctx context.Context, seriesID int64, from time.Time) error {
+	for id, e := range m.Exceptions {
+		if e.SeriesID == seriesID && !e.OriginalStart.Before(from) {
+			delete(m.Exceptions, id)
+		}
+	}
+	return nil
+}
+
+// MockCallParticipantRepository モック通話参加者リポジトリ
+type MockCallParticipantRepository struct {
+	Participants map[int64]*entity.CallParticipant
+	NextID       int64
+}
+
+func NewMockCallParticipantRepository() *MockCallParticipantRepository {
+	return &MockCallParticipantRepository{
+		Participants: make(map[int64]*entity.CallParticipant),
+		NextID:       1,
+	}
+}
+
+func (m *MockCallParticipantRepository) Create(ctx context.Context, participant *entity.CallParticipant) error {
+	participant.ID = m.NextID
+	m.NextID++
+	if participant.Role == "" {
+		participant.Role = entity.RoleParticipant
+	}
+	if participant.JoinedAt.IsZero() {
+		participant.JoinedAt = time.Now()
+	}
+	participant.CreatedAt = time.Now()
+	participant.UpdatedAt = time.Now()
+	m.Participants[participant.ID] = participant
+	return nil
+}
+
+func (m *MockCallParticipantRepository) Update(ctx context.Context, participant *entity.CallParticipant) error {
+	if _, ok := m.Participants[participant.ID]; !ok {
+		return errors.New("participant not found")
+	}
+	participant.UpdatedAt = time.Now()
+	m.Participants[participant.ID] = participant
+	return nil
+}
+
+func (m *MockCallParticipantRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallParticipant, error) {
+	var list []*entity.CallParticipant
+	for _, p := range m.sorted() {
+		if p.RoomID == roomID {
+			list = append(list, p)
+		}
+	}
+	return list, nil
+}
+
+func (m *MockCallParticipantRepository) FindActiveByRoomID(ctx context.Context, roomID int64) ([]*entity.CallParticipant, error) {
+	var list []*entity.CallParticipant
+	for _, p := range m.sorted() {
+		if p.RoomID == roomID && p.IsActive {
+			list = append(list, p)
+		}
+	}
+	return list, nil
+}
+
+func (m *MockCallParticipantRepository) FindByRoomIDAndUserID(ctx context.Context, roomID int64, userID int64) (*entity.CallParticipant, error) {
+	for _, p := range m.sorted() {
+		if p.RoomID == roomID && !p.IsGuest() && p.UserID == userID && p.IsActive {
+			return p, nil
+		}
+	}
+	return nil, errors.New("participant not found")
+}
+
+func (m *MockCallParticipantRepository) FindByRoomIDAndGuestID(ctx context.Context, roomID int64, guestID string) (*entity.CallParticipant, error) {
+	for _, p := range m.sorted() {
+		if p.RoomID == roomID && p.IsGuest() && *p.GuestID == guestID && p.IsActive {
+			return p, nil
+		}
+	}
+	return nil, errors.New("participant not found")
+}
+
+func (m *MockCallParticipantRepository) FindByID(ctx context.Context, id int64) (*entity.CallParticipant, error) {
+	p, ok := m.Participants[id]
+	if !ok {
+		return nil, errors.New("participant not found")
+	}
+	return p, nil
+}
+
+func (m *MockCallParticipantRepository) FindLatestByRoomIDAndUserID(ctx context.Context, roomID int64, userID int64) (*entity.CallParticipant, error) {
+	var latest *entity.CallParticipant
+	for _, p := range m.sorted() {
+		if p.RoomID == roomID && !p.IsGuest() && p.UserID == userID {
+			latest = p
+		}
+	}
+	if latest == nil {
+		return nil, errors.New("participant not found")
+	}
+	return latest, nil
+}
+
+func (m *MockCallParticipantRepository) FindByRoomIDs(ctx context.Context, roomIDs []int64) ([]*entity.CallParticipant, error) {
+	var participants []*entity.CallParticipant
+	for _, roomID := range roomIDs {
+		for _, p := range m.sorted() {
+			if p.RoomID == roomID {
+				participants = append(participants, p)
+			}
+		}
+	}
+	return participants, nil
+}
+
+// sorted ID順の参加記録一覧
+func (m *MockCallParticipantRepository) FindContactUserIDs(ctx context.Context, userID int64, limit int) ([]int64, error) {
+	rooms := make(map[int64]bool)
+	for _, p := range m.Participants {
+		if p.UserID == userID {
+			rooms[p.RoomID] = true
+		}
+	}
+	// 最近一緒になった順
+	list := m.sorted()
+	seen := make(map[int64]bool)
+	var userIDs []int64
+	for i := len(list) - 1; i >= 0; i-- {
+		p := list[i]
+		if rooms[p.RoomID] && p.UserID != 0 && p.UserID != userID && !seen[p.UserID] {
+			seen[p.UserID] = true
+			userIDs = append(userIDs, p.UserID)
+		}
+	}
+	if len(userIDs) > limit {
+		userIDs = userIDs[:limit]
+	}
+	return userIDs, nil
+}
+
+func (m *MockCallParticipantRepository) sorted() []*entity.CallParticipant {
+	list := make([]*entity.CallParticipant, 0, len(m.Participants))
+	for _, p := range m.Participants {
+		list = append(list, p)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// MockCallRoomAccessRepository モック許可リストリポジトリ
+type MockCallRoomAccessRepository struct {
+	Rules  map[int64][]*entity.CallRoomAccessRule
+	NextID int64
+}
+
+func NewMockCallRoomAccessRepository() *MockCallRoomAccessRepository {
+	return &MockCallRoomAccessRepository{
+		Rules:  make(map[int64][]*entity.CallRoomAccessRule),
+		NextID: 1,
+	}
+}
+
+func (m *MockCallRoomAccessRepository) ReplaceRules(ctx context.Context, roomID int64, rules []*entity.CallRoomAccessRule) error {
+	for _, rule := range rules {
+		rule.ID = m.NextID
+		m.NextID++
+		rule.RoomID = roomID
+	}
+	m.Rules[roomID] = rules
+	return nil
+}
+
+func (m *MockCallRoomAccessRepository) AddRule(ctx context.Context, rule *entity.CallRoomAccessRule) error {
+	for _, existing := range m.Rules[rule.RoomID] {
+		if rule.UserID != nil && existing.UserID != nil && *existing.UserID == *rule.UserID {
+			return nil
+		}
+		if rule.EmailDomain != nil && existing.EmailDomain != nil && *existing.EmailDomain == *rule.EmailDomain {
+			return nil
+		}
+	}
+	rule.ID = m.NextID
+	m.NextID++
+	m.Rules[rule.RoomID] = append(m.Rules[rule.RoomID], rule)
+	return nil
+}
+
+func (m *MockCallRoomAccessRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallRoomAccessRule, error) {
+	return m.Rules[roomID], nil
+}
+
+func (m *MockCallRoomAccessRepository) FindByRoomIDs(ctx context.Context, roomIDs []int64) ([]*entity.CallRoomAccessRule, error) {
+	var rules []*entity.CallRoomAccessRule
+	for _, id := range roomIDs {
+		rules = append(rules, m.Rules[id]...)
+	}
+	return rules, nil
+}
+
+// MockCallRecordingRepository モック録音リポジトリ
+type MockCallRecordingRepository struct {
+	Recordings map[int64]*entity.CallRecording
+	NextID     int64
+}
+
+func NewMockCallRecordingRepository() *MockCallRecordingRepository {
+	return &MockCallRecordingRepository{
+		Recordings: make(map[int64]*entity.CallRecording),
+		NextID:     1,
+	}
+}
+
+func (m *MockCallRecordingRepository) Create(ctx context.Context, recording *entity.CallRecording) error {
+	recording.ID = m.NextID
+	m.NextID++
+	recording.UploadedAt = time.Now()
+	m.Recordings[recording.ID] = recording
+	return nil
+}
+
+func (m *MockCallRecordingRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallRecording, error) {
+	var recordings []*entity.CallRecording
+	for _, r := range m.sorted() {
+		if r.RoomID == roomID {
+			recordings = append(recordings, r)
+		}
+	}
+	return recordings, nil
+}
+
+func (m *MockCallRecordingRepository) FindByID(ctx context.Context, id int64) (*entity.CallRecording, error) {
+	r, ok := m.Recordings[id]
+	if !ok {
+		return nil, errors.New("recording not found")
+	}
+	return r, nil
+}
+
+func (m *MockCallRecordingRepository) FindByRoomIDAndFilePath(ctx context.Context, roomID int64, filePath string) (*entity.CallRecording, error) {
+	for _, r := range m.sorted() {
+		if r.RoomID == roomID && r.FilePath == filePath {
+			return r, nil
+		}
+	}
+	return nil, entity.ErrRecordingNotFound
+}
+
+func (m *MockCallRecordingRepository) FindRoomIDsWithRecordings(ctx context.Context, roomIDs []int64) (map[int64]bool, error) {
+	exists := make(map[int64]bool)
+	for _, roomID := range roomIDs {
+		for _, r := range m.Recordings {
+			if r.RoomID == roomID {
+				exists[roomID] = true
+			}
+		}
+	}
+	return exists, nil
+}
+
+// sorted ID順の録音一覧
+func (m *MockCallRecordingRepository) sorted() []*entity.CallRecording {
+	recordings := make([]*entity.CallRecording, 0, len(m.Recordings))
+	for _, r := range m.Recordings {
+		recordings = append(recordings, r)
+	}
+	sort.Slice(recordings, func(i, j int) bool { return recordings[i].ID < recordings[j].ID })
+	return recordings
+}
+
+// MockCallTranscriptionRepository モック文字起こしリポジトリ
+type MockCallTranscriptionRepository struct {
+	Transcriptions map[int64][]*entity.CallTranscription // room_idごと（保存順）
+	NextID         int64
+	// 現在の実行を調べる実行リポジトリ（nilの場合はFindByRoomIDがルームの全ての文字起こしを返す）
+	Runs *MockTranscriptionRunRepository
+}
+
+func NewMockCallTranscriptionRepository() *MockCallTranscriptionRepository {
+	return &MockCallTranscriptionRepository{
+		Transcriptions: make(map[int64][]*entity.CallTranscription),
+		NextID:         1,
+	}
+}
+
+func (m *MockCallTranscriptionRepository) Create(ctx context.Context, transcription *entity.CallTranscription) error {
+	transcription.ID = m.NextID
+	m.NextID++
+	m.Transcriptions[transcription.RoomID] = append(m.Transcriptions[transcription.RoomID], transcription)
+	return nil
+}
+
+func (m *MockCallTranscriptionRepository) CreateBatch(ctx context.Context, transcriptions []*entity.CallTranscription) error {
+	for _, t := range transcriptions {
+		m.Create(ctx, t)
+	}
+	return nil
+}
+
+func (m *MockCallTranscriptionRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallTranscription, error) {
+	if m.Runs == nil {
+		return m.Transcriptions[roomID], nil
+	}
+	currentID := m.Runs.CurrentRunID(roomID)
+	var transcriptions []*entity.CallTranscription
+	for _, t := range m.Transcriptions[roomID] {
+		if t.RunID != nil && *t.RunID == currentID {
+			transcriptions = append(transcriptions, t)
+		}
+	}
+	return transcriptions, nil
+}
+
+func (m *MockCallTranscriptionRepository) FindByRunID(ctx context.Context, runID int64) ([]*entity.CallTranscription, error) {
+	var transcriptions []*entity.CallTranscription
+	for _, list := range m.Transcriptions {
+		for _, t := range list {
+			if t.RunID != nil && *t.RunID == runID {
+				transcriptions = append(transcriptions, t)
+			}
+		}
+	}
+	return transcriptions, nil
+}
+
+// MockCallMinutesRepository モック議事録リポジトリ
+type MockCallMinutesRepository struct {
+	Minutes map[int64]*entity.CallMinutes // room_idごと
+	NextID  int64
+}
+
+func NewMockCallMinutesRepository() *MockCallMinutesRepository {
+	return &MockCallMinutesRepository{
+		Minutes: make(map[int64]*entity.CallMinutes),
+		NextID:  1,
+	}
+}
+
+func (m *MockCallMinutesRepository) Create(ctx context.Context, minutes *entity.CallMinutes) error {
+	minutes.ID = m.NextID
+	m.NextID++
+	minutes.CreatedAt = time.Now()
+	m.Minutes[minutes.RoomID] = minutes
+	return nil
+}
+
+func (m *MockCallMinutesRepository) Update(ctx context.Context, minutes *entity.CallMinutes) error {
+	m.Minutes[minutes.RoomID] = minutes
+	return nil
+}
+
+func (m *MockCallMinutesRepository) FindByRoomID(ctx context.Context, roomID int64) (*entity.CallMinutes, error) {
+	minutes, ok := m.Minutes[roomID]
+	if !ok {
+		return nil, entity.ErrMinutesNotFound
+	}
+	return minutes, nil
+}
+
+func (m *MockCallMinutesRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.CallMinutes, error) {
+	return nil, nil
+}
+
+func (m *MockCallMinutesRepository) FindRoomIDsWithMinutes(ctx context.Context, roomIDs []int64) (map[int64]bool, error) {
+	exists := make(map[int64]bool)
+	for _, roomID := range roomIDs {
+		if _, ok := m.Minutes[roomID]; ok {
+			exists[roomID] = true
+		}
+	}
+	return exists, nil
+}
+
+// MockCallNotesRepository モック共有ノートリポジトリ
+type MockCallNotesRepository struct {
+	mu    sync.Mutex
+	Notes map[int64]*entity.CallNotes // room_idごと
+	Saves int                         // Saveが呼ばれた回数
+}
+
+func NewMockCallNotesRepository() *MockCallNotesRepository {
+	return &MockCallNotesRepository{Notes: make(map[int64]*entity.CallNotes)}
+}
+
+func (m *MockCallNotesRepository) Save(ctx context.Context, notes *entity.CallNotes) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := *notes
+	m.Notes[notes.RoomID] = &copied
+	m.Saves++
+	return nil
+}
+
+func (m *MockCallNotesRepository) FindByRoomID(ctx context.Context, roomID int64) (*entity.CallNotes, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	notes, ok := m.Notes[roomID]
+	if !ok {
+		return nil, entity.ErrCallNotesNotFound
+	}
+	copied := *notes
+	return &copied, nil
+}
+
+// MockDistributedLock モック排他ロック
+type MockDistributedLock struct {
+	Held map[string]bool
+}
+
+func NewMockDistributedLock() *MockDistributedLock {
+	return &MockDistributedLock{Held: make(map[string]bool)}
+}
+
+func (m *MockDistributedLock) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	if m.Held[name] {
+		return nil, false, nil
+	}
+	m.Held[name] = true
+	return func() { delete(m.Held, name) }, true, nil
+}
+
+// MockPersonalRoomRepository モック個人ルームリポジトリ
+type MockPersonalRoomRepository struct {
+	Rooms  map[int64]*entity.PersonalRoom
+	NextID int64
+}
+
+func NewMockPersonalRoomRepository() *MockPersonalRoomRepository {
+	return &MockPersonalRoomRepository{
+		Rooms:  make(map[int64]*entity.PersonalRoom),
+		NextID: 1,
+	}
+}
+
+func (m *MockPersonalRoomRepository) Create(ctx context.Context, room *entity.PersonalRoom) error {
+	for _, existing := range m.Rooms {
+		if existing.Slug == room.Slug || existing.UserID == room.UserID {
+			return entity.ErrSlugTaken
+		}
+	}
+	room.ID = m.NextID
+	m.NextID++
+	room.CreatedAt = time.Now()
+	room.UpdatedAt = time.Now()
+	m.Rooms[room.ID] = room
+	return nil
+}
+
+func (m *MockPersonalRoomRepository) Update(ctx context.Context, room *entity.PersonalRoom) error {
+	for _, existing := range m.Rooms {
+		if existing.ID != room.ID && existing.Slug == room.Slug {
+			return entity.ErrSlugTaken
+		}
+	}
+	if _, ok := m.Rooms[room.ID]; !ok {
+		return entity.ErrPersonalRoomNotFound
+	}
+	room.UpdatedAt = time.Now()
+	m.Rooms[room.ID] = room
+	return nil
+}
+
+func (m *MockPersonalRoomRepository) FindByUserID(ctx context.Context, userID int64) (*entity.PersonalRoom, error) {
+	for _, room := range m.Rooms {
+		if room.UserID == userID {
+			return room, nil
+		}
+	}
+	return nil, entity.ErrPersonalRoomNotFound
+}
+
+func (m *MockPersonalRoomRepository) FindBySlug(ctx context.Context, slug string) (*entity.PersonalRoom, error) {
+	for _, room := range m.Rooms {
+		if room.Slug == slug {
+			return room, nil
+		}
+	}
+	return nil, entity.ErrPersonalRoomNotFound
+}
+
+// MockDirectCallRepository モック1対1通話リポジトリ
+type MockDirectCallRepository struct {
+	Calls  map[string]*entity.DirectCall
+	NextID int64
+}
+
+func NewMockDirectCallRepository() *MockDirectCallRepository {
+	return &MockDirectCallRepository{
+		Calls:  make(map[string]*entity.DirectCall),
+		NextID: 1,
+	}
+}
+
+func (m *MockDirectCallRepository) Create(ctx context.Context, call *entity.DirectCall) error {
+	call.ID = m.NextID
+	m.NextID++
+	stored := *call
+	m.Calls[call.CallID] = &stored
+	return nil
+}
+
+func (m *MockDirectCallRepository) UpdateFromRinging(ctx context.Context, call *entity.DirectCall) (bool, error) {
+	stored, ok := m.Calls[call.CallID]
+	if !ok || stored.Status != entity.DirectCallStatusRinging {
+		return false, nil
+	}
+	stored.Status = call.Status
+	stored.AnsweredAt = call.AnsweredAt
+	return true, nil
+}
+
+func (m *MockDirectCallRepository) FindByCallID(ctx context.Context, callID string) (*entity.DirectCall, error) {
+	stored, ok := m.Calls[callID]
+	if !ok {
+		return nil, entity.ErrDirectCallNotFound
+	}
+	call := *stored
+	return &call, nil
+}
+
+func (m *MockDirectCallRepository) FindExpiredRinging(ctx context.Context, now time.Time, limit int) ([]*entity.DirectCall, error) {
+	var calls []*entity.DirectCall
+	for _, stored := range m.sorted() {
+		if stored.Status == entity.DirectCallStatusRinging && !stored.RingExpiresAt.After(now) {
+			call := *stored
+			calls = append(calls, &call)
+		}
+	}
+	if len(calls) > limit {
+		calls = calls[:limit]
+	}
+	return calls, nil
+}
+
+func (m *MockDirectCallRepository) FindMissedByCallee(ctx context.Context, calleeID int64, limit int) ([]*entity.DirectCall, error) {
+	var calls []*entity.DirectCall
+	list := m.sorted()
+	for i := len(list) - 1; i >= 0; i-- {
+		if list[i].CalleeID == calleeID && list[i].Status == entity.DirectCallStatusMissed {
+			call := *list[i]
+			calls = append(calls, &call)
+		}
+	}
+	if len(calls) > limit {
+		calls = calls[:limit]
+	}
+	return calls, nil
+}
+
+func (m *MockDirectCallRepository) sorted() []*entity.DirectCall {
+	list := make([]*entity.DirectCall, 0, len(m.Calls))
+	for _, call := range m.Calls {
+		list = append(list, call)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// MockPresenceRepository モック在席状態リポジトリ
+type MockPresenceRepository struct {
+	Presences map[int64]*entity.UserPresence
+}
+
+func NewMockPresenceRepository() *MockPresenceRepository {
+	return &MockPresenceRepository{Presences: make(map[int64]*entity.UserPresence)}
+}
+
+func (m *MockPresenceRepository) FindByUserIDs(ctx context.Context, userIDs []int64) ([]*entity.UserPresence, error) {
+	var presences []*entity.UserPresence
+	for _, id := range userIDs {
+		if p, ok := m.Presences[id]; ok {
+			copied := *p
+			presences = append(presences, &copied)
+		}
+	}
+	return presences, nil
+}
+
+func (m *MockPresenceRepository) SaveStatus(ctx context.Context, userID int64, status entity.PresenceStatus) error {
+	m.get(userID).Status = status
+	return nil
+}
+
+func (m *MockPresenceRepository) UpdateLastSeen(ctx context.Context, userID int64, at time.Time) error {
+	m.get(userID).LastSeenAt = &at
+	return nil
+}
+
+func (m *MockPresenceRepository) get(userID int64) *entity.UserPresence {
+	p, ok := m.Presences[userID]
+	if !ok {
+		p = &entity.UserPresence{UserID: userID, Status: entity.PresenceOnline}
+		m.Presences[userID] = p
+	}
+	p.UpdatedAt = time.Now()
+	return p
+}
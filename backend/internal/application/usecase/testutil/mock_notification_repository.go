@@ -0,0 +1,111 @@
+package testutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// MockNotificationRepository モック通知リポジトリ
+type MockNotificationRepository struct {
+	mu            sync.Mutex
+	Notifications map[int64]*entity.Notification
+	NextID        int64
+}
+
+func NewMockNotificationRepository() *MockNotificationRepository {
+	return &MockNotificationRepository{
+		Notifications: make(map[int64]*entity.Notification),
+		NextID:        1,
+	}
+}
+
+func (m *MockNotificationRepository) Create(ctx context.Context, notification *entity.Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	notification.ID = m.NextID
+	m.NextID++
+	copied := *notification
+	m.Notifications[notification.ID] = &copied
+	return nil
+}
+
+func (m *MockNotificationRepository) FindByID(ctx context.Context, id int64) (*entity.Notification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	notification, ok := m.Notifications[id]
+	if !ok {
+		return nil, entity.ErrNotificationNotFound
+	}
+	copied := *notification
+	return &copied, nil
+}
+
+func (m *MockNotificationRepository) FindByUserID(ctx context.Context, filter *entity.NotificationFilter) ([]*entity.Notification, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var notifications []*entity.Notification
+	for _, n := range m.Notifications {
+		if n.UserID != filter.UserID || (filter.UnreadOnly && n.IsRead()) || (filter.BeforeID > 0 && n.ID >= filter.BeforeID) {
+			continue
+		}
+		copied := *n
+		notifications = append(notifications, &copied)
+	}
+	sort.Slice(notifications, func(i, j int) bool { return notifications[i].ID > notifications[j].ID })
+	if len(notifications) > filter.Limit {
+		notifications = notifications[:filter.Limit]
+	}
+	return notifications, nil
+}
+
+func (m *MockNotificationRepository) CountUnread(ctx context.Context, userID int64) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := 0
+	for _, n := range m.Notifications {
+		if n.UserID == userID && !n.IsRead() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockNotificationRepository) MarkRead(ctx context.Context, id int64, at time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n, ok := m.Notifications[id]; ok && !n.IsRead() {
+		n.ReadAt = &at
+	}
+	return nil
+}
+
+func (m *MockNotificationRepository) MarkAllRead(ctx context.Context, userID int64, at time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var count int64
+	for _, n := range m.Notifications {
+		if n.UserID == userID && !n.IsRead() {
+			n.ReadAt = &at
+			count++
+		}
+	}
+	return count, nil
+}
+
+// ByType ユーザー宛ての指定した種類の通知一覧
+func (m *MockNotificationRepository) ByType(userID int64, notificationType entity.NotificationType) []*entity.Notification {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var notifications []*entity.Notification
+	for _, n := range m.Notifications {
+		if n.UserID == userID && n.Type == notificationType {
+			notifications = append(notifications, n)
+		}
+	}
+	sort.Slice(notifications, func(i, j int) bool { return notifications[i].ID < notifications[j].ID })
+	return notifications
+}
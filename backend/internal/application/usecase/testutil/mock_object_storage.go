@@ -0,0 +1,117 @@
+package testutil
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// MockObjectStorage メモリ上のオブジェクトストレージ
+type MockObjectStorage struct {
+	mu      sync.Mutex
+	Objects map[string][]byte
+	types   map[string]string
+}
+
+func NewMockObjectStorage() *MockObjectStorage {
+	return &MockObjectStorage{
+		Objects: make(map[string][]byte),
+		types:   make(map[string]string),
+	}
+}
+
+func (m *MockObjectStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*port.ObjectInfo, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if size >= 0 && int64(len(data)) != size {
+		return nil, fmt.Errorf("size mismatch: got %d bytes, want %d", len(data), size)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Objects[key] = data
+	m.types[key] = contentType
+	return m.info(key), nil
+}
+
+func (m *MockObjectStorage) Get(ctx context.Context, key string) (io.ReadCloser, *port.ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, ok := m.Objects[key]
+	if !ok {
+		return nil, nil, port.ErrObjectNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), m.info(key), nil
+}
+
+func (m *MockObjectStorage) Stat(ctx context.Context, key string) (*port.ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Objects[key]; !ok {
+		return nil, port.ErrObjectNotFound
+	}
+	return m.info(key), nil
+}
+
+func (m *MockObjectStorage) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.Objects, key)
+	delete(m.types, key)
+	return nil
+}
+
+func (m *MockObjectStorage) List(ctx context.Context, prefix string) ([]*port.ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var objects []*port.ObjectInfo
+	for key := range m.Objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, m.info(key))
+		}
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+func (m *MockObjectStorage) PresignURL(ctx context.Context, key string, opts port.PresignOptions) (*port.PresignedURL, error) {
+	headers := map[string]string{}
+	if opts.ContentType != "" {
+		headers["Content-Type"] = opts.ContentType
+	}
+	return &port.PresignedURL{URL: "https://storage.example.com/" + key, Headers: headers}, nil
+}
+
+// Keys プレフィックスに一致するキー一覧
+func (m *MockObjectStorage) Keys(prefix string) []string {
+	objects, _ := m.List(context.Background(), prefix)
+	keys := make([]string, len(objects))
+	for i, object := range objects {
+		keys[i] = object.Key
+	}
+	return keys
+}
+
+func (m *MockObjectStorage) info(key string) *port.ObjectInfo {
+	return &port.ObjectInfo{
+		Key:         key,
+		Size:        int64(len(m.Objects[key])),
+		ContentType: m.types[key],
+		URI:         "mem://" + key,
+		UpdatedAt:   time.Now(),
+	}
+}
@@ -0,0 +1,140 @@
+package testutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// MockPollRepository モック投票リポジトリ
+type MockPollRepository struct {
+	mu           sync.Mutex
+	Polls        map[int64]*entity.Poll
+	Votes        []*entity.PollVote
+	NextID       int64
+	NextOptionID int64
+}
+
+func NewMockPollRepository() *MockPollRepository {
+	return &MockPollRepository{
+		Polls:        make(map[int64]*entity.Poll),
+		NextID:       1,
+		NextOptionID: 1,
+	}
+}
+
+// copyPoll 呼び出し側の変更が保存内容に影響しないよう選択肢ごと複製
+func copyPoll(poll *entity.Poll) *entity.Poll {
+	copied := *poll
+	copied.Options = make([]*entity.PollOption, len(poll.Options))
+	for i, option := range poll.Options {
+		o := *option
+		copied.Options[i] = &o
+	}
+	return &copied
+}
+
+func (m *MockPollRepository) Create(ctx context.Context, poll *entity.Poll) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	poll.ID = m.NextID
+	m.NextID++
+	for _, option := range poll.Options {
+		option.ID = m.NextOptionID
+		m.NextOptionID++
+		option.PollID = poll.ID
+	}
+	poll.CreatedAt = time.Now()
+	m.Polls[poll.ID] = copyPoll(poll)
+	return nil
+}
+
+func (m *MockPollRepository) FindByID(ctx context.Context, id int64) (*entity.Poll, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	poll, ok := m.Polls[id]
+	if !ok {
+		return nil, entity.ErrPollNotFound
+	}
+	return copyPoll(poll), nil
+}
+
+func (m *MockPollRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.Poll, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var polls []*entity.Poll
+	for _, poll := range m.Polls {
+		if poll.RoomID == roomID {
+			polls = append(polls, copyPoll(poll))
+		}
+	}
+	sort.Slice(polls, func(i, j int) bool { return polls[i].ID < polls[j].ID })
+	return polls, nil
+}
+
+func (m *MockPollRepository) ReplaceVotes(ctx context.Context, pollID int64, voterKey string, votes []*entity.PollVote) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	poll, ok := m.Polls[pollID]
+	if !ok {
+		return entity.ErrPollNotFound
+	}
+	if !poll.IsOpen() {
+		return entity.ErrPollClosed
+	}
+
+	kept := m.Votes[:0]
+	for _, vote := range m.Votes {
+		if vote.PollID != pollID || vote.VoterKey != voterKey {
+			kept = append(kept, vote)
+		}
+	}
+	m.Votes = kept
+	for _, vote := range votes {
+		copied := *vote
+		copied.PollID = pollID
+		copied.VoterKey = voterKey
+		copied.CreatedAt = time.Now()
+		m.Votes = append(m.Votes, &copied)
+	}
+	return nil
+}
+
+func (m *MockPollRepository) FindVotes(ctx context.Context, pollID int64) ([]*entity.PollVote, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var votes []*entity.PollVote
+	for _, vote := range m.Votes {
+		if vote.PollID == pollID {
+			copied := *vote
+			votes = append(votes, &copied)
+		}
+	}
+	return votes, nil
+}
+
+func (m *MockPollRepository) Close(ctx context.Context, pollID int64, closedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	poll, ok := m.Polls[pollID]
+	if !ok {
+		return entity.ErrPollNotFound
+	}
+	if !poll.IsOpen() {
+		return entity.ErrPollClosed
+	}
+	poll.Status = entity.PollStatusClosed
+	poll.ClosedAt = &closedAt
+	for _, option := range poll.Options {
+		option.VoteCount = 0
+		for _, vote := range m.Votes {
+			if vote.OptionID == option.ID {
+				option.VoteCount++
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package testutil
+
+import (
+	"context"
+	"sort"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// MockPushSubscriptionRepository モックWeb Push購読リポジトリ
+type MockPushSubscriptionRepository struct {
+	Subscriptions map[int64]*entity.PushSubscription
+	NextID        int64
+}
+
+func NewMockPushSubscriptionRepository() *MockPushSubscriptionRepository {
+	return &MockPushSubscriptionRepository{
+		Subscriptions: make(map[int64]*entity.PushSubscription),
+		NextID:        1,
+	}
+}
+
+func (m *MockPushSubscriptionRepository) Save(ctx context.Context, subscription *entity.PushSubscription) error {
+	for id, existing := range m.Subscriptions {
+		if existing.Endpoint == subscription.Endpoint {
+			subscription.ID = id
+			copied := *subscription
+			m.Subscriptions[id] = &copied
+			return nil
+		}
+	}
+	subscription.ID = m.NextID
+	m.NextID++
+	copied := *subscription
+	m.Subscriptions[subscription.ID] = &copied
+	return nil
+}
+
+func (m *MockPushSubscriptionRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.PushSubscription, error) {
+	var subscriptions []*entity.PushSubscription
+	for _, subscription := range m.Subscriptions {
+		if subscription.UserID == userID {
+			copied := *subscription
+			subscriptions = append(subscriptions, &copied)
+		}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].ID < subscriptions[j].ID })
+	return subscriptions, nil
+}
+
+func (m *MockPushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, userID int64, endpoint string) error {
+	for id, subscription := range m.Subscriptions {
+		if subscription.UserID == userID && subscription.Endpoint == endpoint {
+			delete(m.Subscriptions, id)
+		}
+	}
+	return nil
+}
+
+func (m *MockPushSubscriptionRepository) Delete(ctx context.Context, id int64) error {
+	delete(m.Subscriptions, id)
+	return nil
+}
+
+// SentPush モックプッシュサービスが受け取った送信
+type SentPush struct {
+	Endpoint string
+	Payload  []byte
+	Options  entity.PushOptions
+}
+
+// MockPushService モックプッシュサービス
+type MockPushService struct {
+	Sent []SentPush
+	// 解除済みとして扱うエンドポイント
+	GoneEndpoints map[string]bool
+}
+
+func NewMockPushService() *MockPushService {
+	return &MockPushService{GoneEndpoints: make(map[string]bool)}
+}
+
+func (m *MockPushService) Send(ctx context.Context, subscription *entity.PushSubscription, payload []byte, options entity.PushOptions) error {
+	if m.GoneEndpoints[subscription.Endpoint] {
+		return entity.ErrPushSubscriptionGone
+	}
+	m.Sent = append(m.Sent, SentPush{Endpoint: subscription.Endpoint, Payload: payload, Options: options})
+	return nil
+}
+
+func (m *MockPushService) PublicKey() string {
+	return "test-public-key"
+}
@@ -0,0 +1,116 @@
+package testutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// MockRecordingMixRepository モック録音のミックスリポジトリ
+// DBと同じく保存時の内容をコピーで持つため、呼び出し側がミックスを書き換えても保存するまで反映されない
+type MockRecordingMixRepository struct {
+	mu     sync.Mutex
+	Mixes  map[int64]*entity.RecordingMix
+	NextID int64
+}
+
+func NewMockRecordingMixRepository() *MockRecordingMixRepository {
+	return &MockRecordingMixRepository{
+		Mixes:  make(map[int64]*entity.RecordingMix),
+		NextID: 1,
+	}
+}
+
+func (m *MockRecordingMixRepository) Create(ctx context.Context, mix *entity.RecordingMix) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mix.ID = m.NextID
+	m.NextID++
+	mix.CreatedAt = time.Now()
+	mix.UpdatedAt = mix.CreatedAt
+	m.Mixes[mix.ID] = copyRecordingMix(mix)
+	return nil
+}
+
+func (m *MockRecordingMixRepository) Update(ctx context.Context, mix *entity.RecordingMix) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Mixes[mix.ID]; !ok {
+		return entity.ErrRecordingMixNotFound
+	}
+	mix.UpdatedAt = time.Now()
+	m.Mixes[mix.ID] = copyRecordingMix(mix)
+	return nil
+}
+
+func (m *MockRecordingMixRepository) FindByID(ctx context.Context, id int64) (*entity.RecordingMix, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mix, ok := m.Mixes[id]
+	if !ok {
+		return nil, entity.ErrRecordingMixNotFound
+	}
+	return copyRecordingMix(mix), nil
+}
+
+func (m *MockRecordingMixRepository) FindLatestByRoomID(ctx context.Context, roomID int64) (*entity.RecordingMix, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sorted := m.sorted()
+	for i := len(sorted) - 1; i >= 0; i-- {
+		if sorted[i].RoomID == roomID {
+			return copyRecordingMix(sorted[i]), nil
+		}
+	}
+	return nil, entity.ErrRecordingMixNotFound
+}
+
+func (m *MockRecordingMixRepository) Claim(ctx context.Context, owner string, now time.Time, leaseUntil time.Time) (*entity.RecordingMix, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, mix := range m.sorted() {
+		expired := mix.Status == entity.RecordingMixRunning && mix.LeaseExpiresAt != nil && mix.LeaseExpiresAt.Before(now)
+		if mix.Status != entity.RecordingMixQueued && !expired {
+			continue
+		}
+		mix.Status = entity.RecordingMixRunning
+		mix.Attempts++
+		mix.LeaseOwner = &owner
+		mix.LeaseExpiresAt = &leaseUntil
+		return copyRecordingMix(mix), nil
+	}
+	return nil, entity.ErrRecordingMixNotFound
+}
+
+// Expire ミックスのリースを期限切れにする（ワーカーの停止を再現する）
+func (m *MockRecordingMixRepository) Expire(id int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	past := time.Now().Add(-time.Second)
+	m.Mixes[id].LeaseExpiresAt = &past
+}
+
+// sorted ID順のミックス一覧
+func (m *MockRecordingMixRepository) sorted() []*entity.RecordingMix {
+	mixes := make([]*entity.RecordingMix, 0, len(m.Mixes))
+	for _, mix := range m.Mixes {
+		mixes = append(mixes, mix)
+	}
+	sort.Slice(mixes, func(i, j int) bool { return mixes[i].ID < mixes[j].ID })
+	return mixes
+}
+
+func copyRecordingMix(mix *entity.RecordingMix) *entity.RecordingMix {
+	c := *mix
+	c.Tracks = append([]entity.RecordingMixTrack(nil), mix.Tracks...)
+	return &c
+}
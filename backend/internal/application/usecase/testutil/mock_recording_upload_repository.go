@@ -0,0 +1,93 @@
+package testutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// MockRecordingUploadRepository モック録音アップロードリポジトリ
+type MockRecordingUploadRepository struct {
+	mu      sync.Mutex
+	Uploads map[string]*entity.RecordingUpload
+}
+
+func NewMockRecordingUploadRepository() *MockRecordingUploadRepository {
+	return &MockRecordingUploadRepository{
+		Uploads: make(map[string]*entity.RecordingUpload),
+	}
+}
+
+func (m *MockRecordingUploadRepository) Create(ctx context.Context, upload *entity.RecordingUpload) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	upload.CreatedAt = time.Now()
+	upload.UpdatedAt = upload.CreatedAt
+	m.Uploads[upload.ID] = copyRecordingUpload(upload)
+	return nil
+}
+
+func (m *MockRecordingUploadRepository) FindByID(ctx context.Context, id string) (*entity.RecordingUpload, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	upload, ok := m.Uploads[id]
+	if !ok {
+		return nil, entity.ErrUploadNotFound
+	}
+	return copyRecordingUpload(upload), nil
+}
+
+func (m *MockRecordingUploadRepository) UpdateProgress(ctx context.Context, upload *entity.RecordingUpload, expectedOffset int64) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.Uploads[upload.ID]
+	if !ok || stored.Offset != expectedOffset || stored.IsComplete() {
+		return false, nil
+	}
+	upload.UpdatedAt = time.Now()
+	m.Uploads[upload.ID] = copyRecordingUpload(upload)
+	return true, nil
+}
+
+func (m *MockRecordingUploadRepository) Complete(ctx context.Context, id string, recordingID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if upload, ok := m.Uploads[id]; ok {
+		upload.RecordingID = &recordingID
+		upload.Chunks = nil
+	}
+	return nil
+}
+
+func (m *MockRecordingUploadRepository) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.Uploads, id)
+	return nil
+}
+
+func (m *MockRecordingUploadRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.RecordingUpload, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var uploads []*entity.RecordingUpload
+	for _, upload := range m.Uploads {
+		if len(uploads) < limit && !now.Before(upload.ExpiresAt) {
+			uploads = append(uploads, copyRecordingUpload(upload))
+		}
+	}
+	return uploads, nil
+}
+
+func copyRecordingUpload(upload *entity.RecordingUpload) *entity.RecordingUpload {
+	c := *upload
+	c.Chunks = append([]entity.RecordingUploadChunk(nil), upload.Chunks...)
+	return &c
+}
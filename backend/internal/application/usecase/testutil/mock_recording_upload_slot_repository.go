@@ -0,0 +1,97 @@
+package testutil
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// MockRecordingUploadSlotRepository モック直接アップロードの枠リポジトリ
+type MockRecordingUploadSlotRepository struct {
+	mu    sync.Mutex
+	Slots map[string]*entity.RecordingUploadSlot
+}
+
+func NewMockRecordingUploadSlotRepository() *MockRecordingUploadSlotRepository {
+	return &MockRecordingUploadSlotRepository{
+		Slots: make(map[string]*entity.RecordingUploadSlot),
+	}
+}
+
+func (m *MockRecordingUploadSlotRepository) Create(ctx context.Context, slot *entity.RecordingUploadSlot) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	slot.CreatedAt = time.Now()
+	copied := *slot
+	m.Slots[slot.ID] = &copied
+	return nil
+}
+
+func (m *MockRecordingUploadSlotRepository) FindByID(ctx context.Context, id string) (*entity.RecordingUploadSlot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	slot, ok := m.Slots[id]
+	if !ok {
+		return nil, entity.ErrUploadNotFound
+	}
+	copied := *slot
+	return &copied, nil
+}
+
+func (m *MockRecordingUploadSlotRepository) StartFinalizing(ctx context.Context, id string, now time.Time, staleBefore time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	slot, ok := m.Slots[id]
+	if !ok || slot.IsComplete() || (slot.FinalizingAt != nil && !slot.FinalizingAt.Before(staleBefore)) {
+		return false, nil
+	}
+	slot.FinalizingAt = &now
+	return true, nil
+}
+
+func (m *MockRecordingUploadSlotRepository) CancelFinalizing(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slot, ok := m.Slots[id]; ok && !slot.IsComplete() {
+		slot.FinalizingAt = nil
+	}
+	return nil
+}
+
+func (m *MockRecordingUploadSlotRepository) Complete(ctx context.Context, id string, recordingID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if slot, ok := m.Slots[id]; ok {
+		slot.RecordingID = &recordingID
+	}
+	return nil
+}
+
+func (m *MockRecordingUploadSlotRepository) Delete(ctx context.Context, id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.Slots, id)
+	return nil
+}
+
+func (m *MockRecordingUploadSlotRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.RecordingUploadSlot, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var slots []*entity.RecordingUploadSlot
+	for _, slot := range m.Slots {
+		if len(slots) < limit && !now.Before(slot.ExpiresAt) {
+			copied := *slot
+			slots = append(slots, &copied)
+		}
+	}
+	return slots, nil
+}
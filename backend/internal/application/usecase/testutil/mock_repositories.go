@@ -48,6 +48,18 @@ func (m *MockUserRepository) FindByID(ctx context.Context, id int64) (*entity.Us
 	return nil, entity.ErrUserNotFound
 }
 
+func (m *MockUserRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.User, error) {
+	var users []*entity.User
+	for _, id := range ids {
+		for _, user := range m.Users {
+			if user.ID == id {
+				users = append(users, user)
+			}
+		}
+	}
+	return users, nil
+}
+
 func (m *MockUserRepository) Create(ctx context.Context, user *entity.User) error {
 	if m.CreateFunc != nil {
 		return m.CreateFunc(ctx, user)
@@ -239,3 +251,52 @@ func (m *MockAuthRepository) MarkPasswordResetTokenAsUsed(ctx context.Context, t
 func (m *MockAuthRepository) DeleteExpiredPasswordResetTokens(ctx context.Context) error {
 	return nil
 }
+
+// MockUserDeviceRepository モック端末リポジトリ
+type MockUserDeviceRepository struct {
+	Devices map[int64]*entity.UserDevice
+	NextID  int64
+}
+
+func NewMockUserDeviceRepository() *MockUserDeviceRepository {
+	return &MockUserDeviceRepository{
+		Devices: make(map[int64]*entity.UserDevice),
+		NextID:  1,
+	}
+}
+
+func (m *MockUserDeviceRepository) FindByHash(ctx context.Context, userID int64, hash string) (*entity.UserDevice, error) {
+	for _, device := range m.Devices {
+		if device.UserID == userID && device.DeviceHash == hash {
+			copied := *device
+			return &copied, nil
+		}
+	}
+	return nil, entity.ErrUserDeviceNotFound
+}
+
+func (m *MockUserDeviceRepository) CountByUserID(ctx context.Context, userID int64) (int, error) {
+	count := 0
+	for _, device := range m.Devices {
+		if device.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *MockUserDeviceRepository) Create(ctx context.Context, device *entity.UserDevice) error {
+	device.ID = m.NextID
+	m.NextID++
+	copied := *device
+	m.Devices[device.ID] = &copied
+	return nil
+}
+
+func (m *MockUserDeviceRepository) UpdateLastSeen(ctx context.Context, device *entity.UserDevice) error {
+	if stored, ok := m.Devices[device.ID]; ok {
+		stored.IPAddress = device.IPAddress
+		stored.LastSeenAt = device.LastSeenAt
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package testutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// MockTranscriptionJobRepository モック文字起こしジョブリポジトリ
+// DBと同じく保存時の内容をコピーで持つため、呼び出し側がジョブを書き換えても保存するまで反映されない
+type MockTranscriptionJobRepository struct {
+	mu     sync.Mutex
+	Jobs   map[int64]*entity.TranscriptionJob
+	NextID int64
+}
+
+func NewMockTranscriptionJobRepository() *MockTranscriptionJobRepository {
+	return &MockTranscriptionJobRepository{
+		Jobs:   make(map[int64]*entity.TranscriptionJob),
+		NextID: 1,
+	}
+}
+
+func (m *MockTranscriptionJobRepository) Create(ctx context.Context, job *entity.TranscriptionJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// DBの一意制約と同じく、実行待ち・実行中のジョブはルームごとに1つ
+	if job.IsActive() {
+		for _, stored := range m.Jobs {
+			if stored.RoomID == job.RoomID && stored.IsActive() {
+				return entity.ErrTranscriptionJobActive
+			}
+		}
+	}
+	job.ID = m.NextID
+	m.NextID++
+	job.CreatedAt = time.Now()
+	job.UpdatedAt = job.CreatedAt
+	m.Jobs[job.ID] = copyTranscriptionJob(job)
+	return nil
+}
+
+func (m *MockTranscriptionJobRepository) Update(ctx context.Context, job *entity.TranscriptionJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.Jobs[job.ID]; !ok {
+		return entity.ErrTranscriptionJobNotFound
+	}
+	job.UpdatedAt = time.Now()
+	m.Jobs[job.ID] = copyTranscriptionJob(job)
+	return nil
+}
+
+func (m *MockTranscriptionJobRepository) FindByID(ctx context.Context, id int64) (*entity.TranscriptionJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.Jobs[id]
+	if !ok {
+		return nil, entity.ErrTranscriptionJobNotFound
+	}
+	return copyTranscriptionJob(job), nil
+}
+
+func (m *MockTranscriptionJobRepository) FindActiveByRoomID(ctx context.Context, roomID int64) (*entity.TranscriptionJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, job := range m.sorted() {
+		if job.RoomID == roomID && job.IsActive() {
+			return copyTranscriptionJob(job), nil
+		}
+	}
+	return nil, entity.ErrTranscriptionJobNotFound
+}
+
+func (m *MockTranscriptionJobRepository) FindByRoomID(ctx context.Context, roomID int64, limit int) ([]*entity.TranscriptionJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var jobs []*entity.TranscriptionJob
+	sorted := m.sorted()
+	for i := len(sorted) - 1; i >= 0 && len(jobs) < limit; i-- {
+		if sorted[i].RoomID == roomID {
+			jobs = append(jobs, copyTranscriptionJob(sorted[i]))
+		}
+	}
+	return jobs, nil
+}
+
+func (m *MockTranscriptionJobRepository) Claim(ctx context.Context, owner string, now time.Time, leaseUntil time.Time) (*entity.TranscriptionJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, job := range m.sorted() {
+		due := job.Status == entity.TranscriptionJobQueued && job.NextAttemptAt != nil && !job.NextAttemptAt.After(now)
+		expired := job.Status == entity.TranscriptionJobRunning && job.LeaseExpiresAt != nil && job.LeaseExpiresAt.Before(now)
+		if !due && !expired {
+			continue
+		}
+		job.Status = entity.TranscriptionJobRunning
+		job.Attempts++
+		job.LeaseOwner = &owner
+		job.LeaseExpiresAt = &leaseUntil
+		job.NextAttemptAt = nil
+		if job.StartedAt == nil {
+			job.StartedAt = &now
+		}
+		return copyTranscriptionJob(job), nil
+	}
+	return nil, entity.ErrTranscriptionJobNotFound
+}
+
+func (m *MockTranscriptionJobRepository) UpdateItems(ctx context.Context, id int64, owner string, items []entity.TranscriptionJobItem) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.Jobs[id]
+	if !ok || !ownedBy(job, owner) {
+		return false, nil
+	}
+	job.Items = append([]entity.TranscriptionJobItem(nil), items...)
+	return true, nil
+}
+
+func (m *MockTranscriptionJobRepository) RenewLease(ctx context.Context, id int64, owner string, leaseUntil time.Time) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.Jobs[id]
+	if !ok || !ownedBy(job, owner) {
+		return false, nil
+	}
+	job.LeaseExpiresAt = &leaseUntil
+	return true, nil
+}
+
+// Expire ジョブのリースを期限切れにする（ワーカーの停止を再現する）
+func (m *MockTranscriptionJobRepository) Expire(id int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	past := time.Now().Add(-time.Second)
+	m.Jobs[id].LeaseExpiresAt = &past
+}
+
+// Schedule ジョブの実行予定時刻を変更する（再試行待ちを飛ばす）
+func (m *MockTranscriptionJobRepository) Schedule(id int64, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Jobs[id].NextAttemptAt = &at
+}
+
+// sorted ID順のジョブ一覧
+func (m *MockTranscriptionJobRepository) sorted() []*entity.TranscriptionJob {
+	jobs := make([]*entity.TranscriptionJob, 0, len(m.Jobs))
+	for _, job := range m.Jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].ID < jobs[j].ID })
+	return jobs
+}
+
+func ownedBy(job *entity.TranscriptionJob, owner string) bool {
+	return job.Status == entity.TranscriptionJobRunning && job.LeaseOwner != nil && *job.LeaseOwner == owner
+}
+
+func copyTranscriptionJob(job *entity.TranscriptionJob) *entity.TranscriptionJob {
+	c := *job
+	c.Items = append([]entity.TranscriptionJobItem(nil), job.Items...)
+	return &c
+}
@@ -0,0 +1,127 @@
+package testutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// MockTranscriptionRunRepository モック文字起こしの実行リポジトリ
+// DBと同じく保存時の内容をコピーで持つため、呼び出し側が実行を書き換えても保存するまで反映されない
+type MockTranscriptionRunRepository struct {
+	mu     sync.Mutex
+	Runs   map[int64]*entity.TranscriptionRun
+	NextID int64
+}
+
+func NewMockTranscriptionRunRepository() *MockTranscriptionRunRepository {
+	return &MockTranscriptionRunRepository{
+		Runs:   make(map[int64]*entity.TranscriptionRun),
+		NextID: 1,
+	}
+}
+
+func (m *MockTranscriptionRunRepository) Create(ctx context.Context, run *entity.TranscriptionRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	run.ID = m.NextID
+	m.NextID++
+	run.IsCurrent = false
+	run.CreatedAt = time.Now()
+	run.UpdatedAt = run.CreatedAt
+	m.Runs[run.ID] = copyTranscriptionRun(run)
+	return nil
+}
+
+func (m *MockTranscriptionRunRepository) Update(ctx context.Context, run *entity.TranscriptionRun) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.Runs[run.ID]
+	if !ok {
+		return entity.ErrTranscriptionRunNotFound
+	}
+	// 現在の実行かはSetCurrentでのみ変更する
+	updated := copyTranscriptionRun(run)
+	updated.IsCurrent = stored.IsCurrent
+	updated.UpdatedAt = time.Now()
+	m.Runs[run.ID] = updated
+	return nil
+}
+
+func (m *MockTranscriptionRunRepository) FindByID(ctx context.Context, id int64) (*entity.TranscriptionRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	run, ok := m.Runs[id]
+	if !ok {
+		return nil, entity.ErrTranscriptionRunNotFound
+	}
+	return copyTranscriptionRun(run), nil
+}
+
+func (m *MockTranscriptionRunRepository) FindByRoomID(ctx context.Context, roomID int64) ([]*entity.TranscriptionRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var runs []*entity.TranscriptionRun
+	for _, run := range m.Runs {
+		if run.RoomID == roomID {
+			runs = append(runs, copyTranscriptionRun(run))
+		}
+	}
+	// 新しい順
+	sort.Slice(runs, func(i, j int) bool { return runs[i].ID > runs[j].ID })
+	return runs, nil
+}
+
+func (m *MockTranscriptionRunRepository) FindCurrentByRoomID(ctx context.Context, roomID int64) (*entity.TranscriptionRun, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, run := range m.Runs {
+		if run.RoomID == roomID && run.IsCurrent {
+			return copyTranscriptionRun(run), nil
+		}
+	}
+	return nil, entity.ErrTranscriptionRunNotFound
+}
+
+func (m *MockTranscriptionRunRepository) SetCurrent(ctx context.Context, roomID int64, runID int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	target, ok := m.Runs[runID]
+	if !ok || target.RoomID != roomID {
+		return entity.ErrTranscriptionRunNotFound
+	}
+	for _, run := range m.Runs {
+		if run.RoomID == roomID {
+			run.IsCurrent = run.ID == runID
+		}
+	}
+	return nil
+}
+
+// CurrentRunID ルームの現在の実行ID（ない場合は0）
+func (m *MockTranscriptionRunRepository) CurrentRunID(roomID int64) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, run := range m.Runs {
+		if run.RoomID == roomID && run.IsCurrent {
+			return run.ID
+		}
+	}
+	return 0
+}
+
+func copyTranscriptionRun(run *entity.TranscriptionRun) *entity.TranscriptionRun {
+	c := *run
+	c.AlternativeLanguages = append([]string(nil), run.AlternativeLanguages...)
+	return &c
+}
@@ -0,0 +1,187 @@
+package testutil
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// MockWebhookRepository モックWebhookリポジトリ
+type MockWebhookRepository struct {
+	Webhooks       map[int64]*entity.Webhook
+	Deliveries     map[int64]*entity.WebhookDelivery
+	NextID         int64
+	NextDeliveryID int64
+}
+
+func NewMockWebhookRepository() *MockWebhookRepository {
+	return &MockWebhookRepository{
+		Webhooks:       make(map[int64]*entity.Webhook),
+		Deliveries:     make(map[int64]*entity.WebhookDelivery),
+		NextID:         1,
+		NextDeliveryID: 1,
+	}
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	webhook.ID = m.NextID
+	m.NextID++
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = time.Now()
+	m.Webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, webhook *entity.Webhook) error {
+	if _, ok := m.Webhooks[webhook.ID]; !ok {
+		return entity.ErrWebhookNotFound
+	}
+	webhook.UpdatedAt = time.Now()
+	m.Webhooks[webhook.ID] = webhook
+	return nil
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id int64) error {
+	delete(m.Webhooks, id)
+	for deliveryID, d := range m.Deliveries {
+		if d.WebhookID == id {
+			delete(m.Deliveries, deliveryID)
+		}
+	}
+	return nil
+}
+
+func (m *MockWebhookRepository) FindByID(ctx context.Context, id int64) (*entity.Webhook, error) {
+	webhook, ok := m.Webhooks[id]
+	if !ok {
+		return nil, entity.ErrWebhookNotFound
+	}
+	return webhook, nil
+}
+
+func (m *MockWebhookRepository) FindByUserID(ctx context.Context, userID int64) ([]*entity.Webhook, error) {
+	var webhooks []*entity.Webhook
+	for _, webhook := range m.sortedWebhooks() {
+		if webhook.UserID == userID {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (m *MockWebhookRepository) FindActiveByUserID(ctx context.Context, userID int64) ([]*entity.Webhook, error) {
+	var webhooks []*entity.Webhook
+	for _, webhook := range m.sortedWebhooks() {
+		if webhook.UserID == userID && webhook.IsActive {
+			webhooks = append(webhooks, webhook)
+		}
+	}
+	return webhooks, nil
+}
+
+func (m *MockWebhookRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	delivery.ID = m.NextDeliveryID
+	m.NextDeliveryID++
+	delivery.CreatedAt = time.Now()
+	delivery.UpdatedAt = time.Now()
+	m.Deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (m *MockWebhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if _, ok := m.Deliveries[delivery.ID]; !ok {
+		return entity.ErrWebhookDeliveryNotFound
+	}
+	delivery.UpdatedAt = time.Now()
+	m.Deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (m *MockWebhookRepository) FindDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDelivery, error) {
+	delivery, ok := m.Deliveries[id]
+	if !ok {
+		return nil, entity.ErrWebhookDeliveryNotFound
+	}
+	return delivery, nil
+}
+
+func (m *MockWebhookRepository) FindDeliveriesByWebhookID(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+	sorted := m.sortedDeliveries()
+	for i := len(sorted) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		if sorted[i].WebhookID == webhookID {
+			deliveries = append(deliveries, sorted[i])
+		}
+	}
+	return deliveries, nil
+}
+
+func (m *MockWebhookRepository) FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+	for _, d := range m.sortedDeliveries() {
+		if len(deliveries) >= limit {
+			break
+		}
+		if d.Status == entity.WebhookDeliveryPending && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			deliveries = append(deliveries, d)
+		}
+	}
+	return deliveries, nil
+}
+
+// sortedWebhooks ID順のWebhook一覧
+func (m *MockWebhookRepository) sortedWebhooks() []*entity.Webhook {
+	list := make([]*entity.Webhook, 0, len(m.Webhooks))
+	for _, webhook := range m.Webhooks {
+		list = append(list, webhook)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// sortedDeliveries ID順の配信記録一覧
+func (m *MockWebhookRepository) sortedDeliveries() []*entity.WebhookDelivery {
+	list := make([]*entity.WebhookDelivery, 0, len(m.Deliveries))
+	for _, d := range m.Deliveries {
+		list = append(list, d)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+// PublishedEvent MockEventPublisherが受け取ったイベント
+type PublishedEvent struct {
+	OwnerID int64
+	Event   entity.WebhookEvent
+	Data    interface{}
+}
+
+// MockEventPublisher 発行されたイベントを記録するモック
+type MockEventPublisher struct {
+	mu     sync.Mutex
+	Events []PublishedEvent
+}
+
+func NewMockEventPublisher() *MockEventPublisher {
+	return &MockEventPublisher{}
+}
+
+func (m *MockEventPublisher) Publish(ctx context.Context, ownerID int64, event entity.WebhookEvent, data interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Events = append(m.Events, PublishedEvent{OwnerID: ownerID, Event: event, Data: data})
+}
+
+// Names 発行されたイベント名を順に返す
+func (m *MockEventPublisher) Names() []entity.WebhookEvent {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	names := make([]entity.WebhookEvent, len(m.Events))
+	for i, e := range m.Events {
+		names[i] = e.Event
+	}
+	return names
+}
@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"log/slog"
+
 	"Go-Next-WebRTC/internal/domain/entity"
 	"Go-Next-WebRTC/internal/domain/port"
 )
@@ -10,18 +12,21 @@ import (
 type TodoUsecase interface {
 	GetAllTodos(ctx context.Context, userID int64) ([]*entity.Todo, error)
 	GetTodoByID(ctx context.Context, id int, userID int64) (*entity.Todo, error)
-	CreateTodo(ctx context.Context, userID int64, title, description string) (*entity.Todo, error)
-	UpdateTodo(ctx context.Context, id int, userID int64, title, description *string, completed *bool) (*entity.Todo, error)
+	CreateTodo(ctx context.Context, userID int64, title, description string, assigneeID *int64) (*entity.Todo, error)
+	// assigneeIDに0を指定すると担当者の割り当てを解除する
+	UpdateTodo(ctx context.Context, id int, userID int64, title, description *string, completed *bool, assigneeID *int64) (*entity.Todo, error)
 	DeleteTodo(ctx context.Context, id int, userID int64) error
 }
 
 type todoUsecase struct {
-	repo port.TodoRepository
+	repo          port.TodoRepository
+	userRepo      port.UserRepository
+	notifications NotificationUsecase
 }
 
 // NewTodoUsecase はTodoUsecaseのコンストラクタ
-func NewTodoUsecase(repo port.TodoRepository) TodoUsecase {
-	return &todoUsecase{repo: repo}
+func NewTodoUsecase(repo port.TodoRepository, userRepo port.UserRepository, notifications NotificationUsecase) TodoUsecase {
+	return &todoUsecase{repo: repo, userRepo: userRepo, notifications: notifications}
 }
 
 // GetAllTodos は指定されたユーザーの全てのTodoを取得する
@@ -38,22 +43,30 @@ func (u *todoUsecase) GetTodoByID(ctx context.Context, id int, userID int64) (*e
 }
 
 // CreateTodo は新しいTodoを作成する
-func (u *todoUsecase) CreateTodo(ctx context.Context, userID int64, title, description string) (*entity.Todo, error) {
+func (u *todoUsecase) CreateTodo(ctx context.Context, userID int64, title, description string, assigneeID *int64) (*entity.Todo, error) {
 	todo := entity.NewTodo(userID, title, description)
 
 	if err := todo.Validate(); err != nil {
 		return nil, err
 	}
 
+	if assigneeID != nil {
+		if err := u.assign(ctx, todo, *assigneeID); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := u.repo.Save(ctx, todo); err != nil {
 		return nil, err
 	}
 
+	u.notifyAssignee(ctx, todo, nil)
+
 	return todo, nil
 }
 
 // UpdateTodo は指定されたIDとユーザーIDのTodoを更新する
-func (u *todoUsecase) UpdateTodo(ctx context.Context, id int, userID int64, title, description *string, completed *bool) (*entity.Todo, error) {
+func (u *todoUsecase) UpdateTodo(ctx context.Context, id int, userID int64, title, description *string, completed *bool, assigneeID *int64) (*entity.Todo, error) {
 	if id <= 0 {
 		return nil, entity.ErrTodoNotFound
 	}
@@ -62,6 +75,7 @@ func (u *todoUsecase) UpdateTodo(ctx context.Context, id int, userID int64, titl
 	if err != nil {
 		return nil, err
 	}
+	previousAssigneeID := todo.AssigneeID
 
 	// フィールドの更新
 	if title != nil {
@@ -82,10 +96,18 @@ func (u *todoUsecase) UpdateTodo(ctx context.Context, id int, userID int64, titl
 		}
 	}
 
+	if assigneeID != nil {
+		if err := u.assign(ctx, todo, *assigneeID); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := u.repo.Save(ctx, todo); err != nil {
 		return nil, err
 	}
 
+	u.notifyAssignee(ctx, todo, previousAssigneeID)
+
 	return todo, nil
 }
 
@@ -103,3 +125,49 @@ func (u *todoUsecase) DeleteTodo(ctx context.Context, id int, userID int64) erro
 
 	return u.repo.DeleteByIDAndUserID(ctx, id, userID)
 }
+
+// assign は担当者を設定する（0なら割り当てを解除し、それ以外は存在するユーザーのみ割り当てる）
+func (u *todoUsecase) assign(ctx context.Context, todo *entity.Todo, assigneeID int64) error {
+	if assigneeID == 0 {
+		todo.Assign(nil)
+		return nil
+	}
+	if _, err := u.userRepo.FindByID(ctx, assigneeID); err != nil {
+		return err
+	}
+	todo.Assign(&assigneeID)
+	return nil
+}
+
+// notifyAssignee 担当者が変わった場合に新しい担当者へ通知する（自分自身への割り当ては通知しない）
+func (u *todoUsecase) notifyAssignee(ctx context.Context, todo *entity.Todo, previousAssigneeID *int64) {
+	if todo.AssigneeID == nil || todo.IsAssignedTo(todo.UserID) {
+		return
+	}
+	if previousAssigneeID != nil && todo.IsAssignedTo(*previousAssigneeID) {
+		return
+	}
+
+	owner, err := u.userRepo.FindByID(ctx, todo.UserID)
+	if err != nil {
+		slog.Error("Failed to load todo owner for notification", slog.Int("todo_id", todo.ID), slog.String("error", err.Error()))
+		return
+	}
+
+	err = u.notifications.Notify(ctx, &entity.Notification{
+		UserID: *todo.AssigneeID,
+		Type:   entity.NotificationTodoAssigned,
+		Title:  owner.Name + " assigned you a todo",
+		Body:   todo.Title,
+		Data: map[string]interface{}{
+			"todo_id":       todo.ID,
+			"title":         todo.Title,
+			"description":   todo.Description,
+			"assigner_id":   owner.ID,
+			"assigner_name": owner.Name,
+		},
+	})
+	if err != nil {
+		slog.Error("Failed to notify todo assignee", slog.Int("todo_id", todo.ID), slog.String("error", err.Error()))
+	}
+}
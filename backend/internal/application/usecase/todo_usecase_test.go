@@ -53,11 +53,11 @@ func TestTodoUsecase_CreateTodo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Arrange
 			repo := testutil.NewMockTodoRepository()
-			usecase := NewTodoUsecase(repo)
+			usecase := NewTodoUsecase(repo, testutil.NewMockUserRepository(), nil)
 			ctx := context.Background()
 
 			// Act
-			todo, err := usecase.CreateTodo(ctx, tt.userID, tt.title, tt.description)
+			todo, err := usecase.CreateTodo(ctx, tt.userID, tt.title, tt.description, nil)
 
 			// Assert
 			if tt.wantErr {
@@ -97,18 +97,18 @@ func TestTodoUsecase_CreateTodo(t *testing.T) {
 func TestTodoUsecase_GetAllTodos(t *testing.T) {
 	// Arrange
 	repo := testutil.NewMockTodoRepository()
-	usecase := NewTodoUsecase(repo)
+	usecase := NewTodoUsecase(repo, testutil.NewMockUserRepository(), nil)
 	ctx := context.Background()
 
 	userID1 := int64(1)
 	userID2 := int64(2)
 
 	// ユーザー1のTodoを作成
-	todo1, _ := usecase.CreateTodo(ctx, userID1, "User1 Todo 1", "Description 1")
-	todo2, _ := usecase.CreateTodo(ctx, userID1, "User1 Todo 2", "Description 2")
+	todo1, _ := usecase.CreateTodo(ctx, userID1, "User1 Todo 1", "Description 1", nil)
+	todo2, _ := usecase.CreateTodo(ctx, userID1, "User1 Todo 2", "Description 2", nil)
 
 	// ユーザー2のTodoを作成
-	_, _ = usecase.CreateTodo(ctx, userID2, "User2 Todo 1", "Description 3")
+	_, _ = usecase.CreateTodo(ctx, userID2, "User2 Todo 1", "Description 3", nil)
 
 	t.Run("get todos for user1", func(t *testing.T) {
 		// Act
@@ -165,13 +165,13 @@ func TestTodoUsecase_GetAllTodos(t *testing.T) {
 func TestTodoUsecase_GetTodoByID(t *testing.T) {
 	// Arrange
 	repo := testutil.NewMockTodoRepository()
-	usecase := NewTodoUsecase(repo)
+	usecase := NewTodoUsecase(repo, testutil.NewMockUserRepository(), nil)
 	ctx := context.Background()
 
 	userID := int64(1)
 	otherUserID := int64(2)
 
-	todo, _ := usecase.CreateTodo(ctx, userID, "Test Todo", "Description")
+	todo, _ := usecase.CreateTodo(ctx, userID, "Test Todo", "Description", nil)
 
 	t.Run("get own todo", func(t *testing.T) {
 		// Act
@@ -223,19 +223,19 @@ func TestTodoUsecase_GetTodoByID(t *testing.T) {
 func TestTodoUsecase_UpdateTodo(t *testing.T) {
 	// Arrange
 	repo := testutil.NewMockTodoRepository()
-	usecase := NewTodoUsecase(repo)
+	usecase := NewTodoUsecase(repo, testutil.NewMockUserRepository(), nil)
 	ctx := context.Background()
 
 	userID := int64(1)
 	otherUserID := int64(2)
 
-	todo, _ := usecase.CreateTodo(ctx, userID, "Original Title", "Original Description")
+	todo, _ := usecase.CreateTodo(ctx, userID, "Original Title", "Original Description", nil)
 
 	t.Run("update title", func(t *testing.T) {
 		newTitle := "Updated Title"
 
 		// Act
-		updated, err := usecase.UpdateTodo(ctx, todo.ID, userID, &newTitle, nil, nil)
+		updated, err := usecase.UpdateTodo(ctx, todo.ID, userID, &newTitle, nil, nil, nil)
 
 		// Assert
 		if err != nil {
@@ -253,7 +253,7 @@ func TestTodoUsecase_UpdateTodo(t *testing.T) {
 		newDesc := "Updated Description"
 
 		// Act
-		updated, err := usecase.UpdateTodo(ctx, todo.ID, userID, nil, &newDesc, nil)
+		updated, err := usecase.UpdateTodo(ctx, todo.ID, userID, nil, &newDesc, nil, nil)
 
 		// Assert
 		if err != nil {
@@ -268,7 +268,7 @@ func TestTodoUsecase_UpdateTodo(t *testing.T) {
 		completed := true
 
 		// Act
-		updated, err := usecase.UpdateTodo(ctx, todo.ID, userID, nil, nil, &completed)
+		updated, err := usecase.UpdateTodo(ctx, todo.ID, userID, nil, nil, &completed, nil)
 
 		// Assert
 		if err != nil {
@@ -283,7 +283,7 @@ func TestTodoUsecase_UpdateTodo(t *testing.T) {
 		completed := false
 
 		// Act
-		updated, err := usecase.UpdateTodo(ctx, todo.ID, userID, nil, nil, &completed)
+		updated, err := usecase.UpdateTodo(ctx, todo.ID, userID, nil, nil, &completed, nil)
 
 		// Assert
 		if err != nil {
@@ -298,7 +298,7 @@ func TestTodoUsecase_UpdateTodo(t *testing.T) {
 		invalidTitle := ""
 
 		// Act
-		_, err := usecase.UpdateTodo(ctx, todo.ID, userID, &invalidTitle, nil, nil)
+		_, err := usecase.UpdateTodo(ctx, todo.ID, userID, &invalidTitle, nil, nil, nil)
 
 		// Assert
 		if err == nil {
@@ -310,7 +310,7 @@ func TestTodoUsecase_UpdateTodo(t *testing.T) {
 		newTitle := "Hacked Title"
 
 		// Act
-		_, err := usecase.UpdateTodo(ctx, todo.ID, otherUserID, &newTitle, nil, nil)
+		_, err := usecase.UpdateTodo(ctx, todo.ID, otherUserID, &newTitle, nil, nil, nil)
 
 		// Assert
 		if err == nil {
@@ -322,7 +322,7 @@ func TestTodoUsecase_UpdateTodo(t *testing.T) {
 		newTitle := "New Title"
 
 		// Act
-		_, err := usecase.UpdateTodo(ctx, 99999, userID, &newTitle, nil, nil)
+		_, err := usecase.UpdateTodo(ctx, 99999, userID, &newTitle, nil, nil, nil)
 
 		// Assert
 		if err == nil {
@@ -334,13 +334,13 @@ func TestTodoUsecase_UpdateTodo(t *testing.T) {
 func TestTodoUsecase_DeleteTodo(t *testing.T) {
 	// Arrange
 	repo := testutil.NewMockTodoRepository()
-	usecase := NewTodoUsecase(repo)
+	usecase := NewTodoUsecase(repo, testutil.NewMockUserRepository(), nil)
 	ctx := context.Background()
 
 	userID := int64(1)
 	otherUserID := int64(2)
 
-	todo, _ := usecase.CreateTodo(ctx, userID, "Todo to Delete", "Description")
+	todo, _ := usecase.CreateTodo(ctx, userID, "Todo to Delete", "Description", nil)
 
 	t.Run("delete own todo", func(t *testing.T) {
 		// Act
@@ -359,7 +359,7 @@ func TestTodoUsecase_DeleteTodo(t *testing.T) {
 	})
 
 	t.Run("try to delete other user's todo", func(t *testing.T) {
-		todo2, _ := usecase.CreateTodo(ctx, userID, "Another Todo", "Description")
+		todo2, _ := usecase.CreateTodo(ctx, userID, "Another Todo", "Description", nil)
 
 		// Act
 		err := usecase.DeleteTodo(ctx, todo2.ID, otherUserID)
@@ -396,3 +396,98 @@ func TestTodoUsecase_DeleteTodo(t *testing.T) {
 		}
 	})
 }
+
+func TestTodoUsecase_AssignTodo(t *testing.T) {
+	const ownerID, assigneeID, unknownID = int64(1), int64(2), int64(99)
+	id := func(v int64) *int64 { return &v }
+
+	tests := []struct {
+		name         string
+		createWith   *int64
+		updateWith   *int64 // nilなら更新しない
+		wantErr      error
+		wantAssignee *int64
+		wantNotified int
+	}{
+		{
+			name:         "assign on create notifies assignee",
+			createWith:   id(assigneeID),
+			wantAssignee: id(assigneeID),
+			wantNotified: 1,
+		},
+		{
+			name:         "assign on update notifies assignee",
+			updateWith:   id(assigneeID),
+			wantAssignee: id(assigneeID),
+			wantNotified: 1,
+		},
+		{
+			name:         "reassigning same user does not notify again",
+			createWith:   id(assigneeID),
+			updateWith:   id(assigneeID),
+			wantAssignee: id(assigneeID),
+			wantNotified: 1,
+		},
+		{
+			name:         "self assignment does not notify",
+			createWith:   id(ownerID),
+			wantAssignee: id(ownerID),
+		},
+		{
+			name:         "zero clears assignee",
+			createWith:   id(assigneeID),
+			updateWith:   id(0),
+			wantNotified: 1,
+		},
+		{
+			name:       "unknown assignee",
+			updateWith: id(unknownID),
+			wantErr:    entity.ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			repo := testutil.NewMockTodoRepository()
+			userRepo := testutil.NewMockUserRepository()
+			notificationRepo := testutil.NewMockNotificationRepository()
+			hub := NewUserEventHub()
+			push, _, _ := newPushTestUsecase(hub)
+			notifications := NewNotificationUsecase(notificationRepo, testutil.NewMockCallRoomRepository(), testutil.NewMockCallParticipantRepository(), hub, push)
+			usecase := NewTodoUsecase(repo, userRepo, notifications)
+			ctx := context.Background()
+
+			_ = userRepo.Create(ctx, &entity.User{Email: "owner@example.com", Name: "Owner"})
+			_ = userRepo.Create(ctx, &entity.User{Email: "assignee@example.com", Name: "Assignee"})
+
+			// Act
+			todo, err := usecase.CreateTodo(ctx, ownerID, "Write minutes", "", tt.createWith)
+			if err == nil && tt.updateWith != nil {
+				todo, err = usecase.UpdateTodo(ctx, todo.ID, ownerID, nil, nil, nil, tt.updateWith)
+			}
+
+			// Assert
+			if err != tt.wantErr {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if (todo.AssigneeID == nil) != (tt.wantAssignee == nil) ||
+				(tt.wantAssignee != nil && *todo.AssigneeID != *tt.wantAssignee) {
+				t.Errorf("AssigneeID = %v, want %v", todo.AssigneeID, tt.wantAssignee)
+			}
+			saved := notificationRepo.ByType(assigneeID, entity.NotificationTodoAssigned)
+			if len(saved) != tt.wantNotified {
+				t.Fatalf("notifications = %d, want %d", len(saved), tt.wantNotified)
+			}
+			if tt.wantNotified > 0 && (saved[0].Data["todo_id"] != todo.ID || saved[0].Data["assigner_name"] != "Owner") {
+				t.Errorf("notification data = %+v, want todo_id %d from Owner", saved[0].Data, todo.ID)
+			}
+			if saved := notificationRepo.ByType(ownerID, entity.NotificationTodoAssigned); len(saved) != 0 {
+				t.Errorf("owner notifications = %d, want none", len(saved))
+			}
+		})
+	}
+}
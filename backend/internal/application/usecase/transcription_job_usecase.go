@@ -0,0 +1,414 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// TranscriptionJobUsecase 文字起こしジョブユースケースのインターフェース
+type TranscriptionJobUsecase interface {
+	// 文字起こしを依頼（実行待ち・実行中のジョブがある場合はそのジョブを返す）
+	Enqueue(ctx context.Context, roomID int64, requestedBy *int64) (*entity.TranscriptionJob, error)
+	// ルームのジョブを取得
+	GetJob(ctx context.Context, roomID int64, jobID int64) (*entity.TranscriptionJob, error)
+	// ルームのジョブ一覧（新しい順）
+	GetJobs(ctx context.Context, roomID int64, limit int) ([]*entity.TranscriptionJob, error)
+	// 実行できるジョブを1件確保して実行（ジョブがなかった場合はfalse）
+	RunNext(ctx context.Context, workerID string) (bool, error)
+	// ジョブが追加されたことをワーカーへ知らせるチャネル
+	Wake() <-chan struct{}
+}
+
+// TranscriptionJobConfig 文字起こしジョブの実行設定
+type TranscriptionJobConfig struct {
+	MaxAttempts int           // 最大実行回数（最後の実行では失敗した録音を除いて議事録を作成する）
+	RetryBase   time.Duration // 最初の再試行までの間隔（以降は倍にしていく）
+	RetryMax    time.Duration // 再試行間隔の上限
+	Lease       time.Duration // ワーカーのリース期間（1/3ごとに延長する）
+}
+
+type transcriptionJobUsecase struct {
+	jobRepo       port.TranscriptionJobRepository
+	roomRepo      port.CallRoomRepository
+	recordingRepo port.CallRecordingRepository
+	recording     RecordingUsecase
+	hub           UserEventHub
+	config        TranscriptionJobConfig
+	wake          chan struct{}
+}
+
+// NewTranscriptionJobUsecase 新しい文字起こしジョブユースケースを作成
+func NewTranscriptionJobUsecase(
+	jobRepo port.TranscriptionJobRepository,
+	roomRepo port.CallRoomRepository,
+	recordingRepo port.CallRecordingRepository,
+	recording RecordingUsecase,
+	hub UserEventHub,
+	config TranscriptionJobConfig,
+) TranscriptionJobUsecase {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 3
+	}
+	if config.RetryBase <= 0 {
+		config.RetryBase = time.Minute
+	}
+	if config.RetryMax < config.RetryBase {
+		config.RetryMax = config.RetryBase
+	}
+	if config.Lease <= 0 {
+		config.Lease = 2 * time.Minute
+	}
+	return &transcriptionJobUsecase{
+		jobRepo:       jobRepo,
+		roomRepo:      roomRepo,
+		recordingRepo: recordingRepo,
+		recording:     recording,
+		hub:           hub,
+		config:        config,
+		wake:          make(chan struct{}, 1),
+	}
+}
+
+// transcriptionJobEventData transcription-job イベントのデータ
+type transcriptionJobEventData struct {
+	JobID     int64   `json:"job_id"`
+	RoomID    string  `json:"room_id"`
+	Status    string  `json:"status"`
+	Attempts  int     `json:"attempts"`
+	Completed int     `json:"completed"`
+	Total     int     `json:"total"`
+	MinutesID *int64  `json:"minutes_id,omitempty"`
+	Error     *string `json:"error,omitempty"`
+}
+
+// Enqueue 文字起こしを依頼
+// 同じルームのジョブを重複して実行しない（同時に依頼された場合も一意制約で1つに絞る）
+func (u *transcriptionJobUsecase) Enqueue(ctx context.Context, roomID int64, requestedBy *int64) (*entity.TranscriptionJob, error) {
+	active, err := u.jobRepo.FindActiveByRoomID(ctx, roomID)
+	if err == nil {
+		return active, nil
+	}
+	if !errors.Is(err, entity.ErrTranscriptionJobNotFound) {
+		return nil, fmt.Errorf("failed to find active job: %w", err)
+	}
+
+	room, err := u.roomRepo.FindByID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get room: %w", err)
+	}
+
+	recordings, err := u.recordingRepo.FindByRoomID(ctx, roomID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recordings: %w", err)
+	}
+	if len(recordings) == 0 {
+		return nil, entity.ErrNoRecordings
+	}
+
+	now := time.Now()
+	job := &entity.TranscriptionJob{
+		RoomID:        roomID,
+		RequestedBy:   requestedBy,
+		Status:        entity.TranscriptionJobQueued,
+		Items:         pendingItems(recordings),
+		NextAttemptAt: &now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := u.jobRepo.Create(ctx, job); err != nil {
+		// 確認してから作成するまでの間に他の依頼がジョブを作成した
+		if errors.Is(err, entity.ErrTranscriptionJobActive) {
+			active, findErr := u.jobRepo.FindActiveByRoomID(ctx, roomID)
+			if findErr != nil {
+				return nil, fmt.Errorf("failed to find active job: %w", findErr)
+			}
+			return active, nil
+		}
+		return nil, fmt.Errorf("failed to create transcription job: %w", err)
+	}
+
+	slog.Info("Transcription job queued",
+		slog.Int64("job_id", job.ID),
+		slog.Int64("room_id", roomID),
+		slog.Int("recordings_count", len(recordings)),
+	)
+	u.publish(room, job)
+
+	// 待機中のワーカーを起こす（既に通知済みなら何もしない）
+	select {
+	case u.wake <- struct{}{}:
+	default:
+	}
+	return job, nil
+}
+
+// GetJob ルームのジョブを取得
+func (u *transcriptionJobUsecase) GetJob(ctx context.Context, roomID int64, jobID int64) (*entity.TranscriptionJob, error) {
+	job, err := u.jobRepo.FindByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	// 他のルームのジョブは存在しないものとして扱う
+	if job.RoomID != roomID {
+		return nil, entity.ErrTranscriptionJobNotFound
+	}
+	return job, nil
+}
+
+// GetJobs ルームのジョブ一覧
+func (u *transcriptionJobUsecase) GetJobs(ctx context.Context, roomID int64, limit int) ([]*entity.TranscriptionJob, error) {
+	if limit <= 0 || limit > 50 {
+		limit = 20
+	}
+	return u.jobRepo.FindByRoomID(ctx, roomID, limit)
+}
+
+// Wake ジョブが追加されたことをワーカーへ知らせるチャネル
+func (u *transcriptionJobUsecase) Wake() <-chan struct{} {
+	return u.wake
+}
+
+// RunNext 実行できるジョブを1件確保して実行
+func (u *transcriptionJobUsecase) RunNext(ctx context.Context, workerID string) (bool, error) {
+	now := time.Now()
+	job, err := u.jobRepo.Claim(ctx, workerID, now, now.Add(u.config.Lease))
+	if errors.Is(err, entity.ErrTranscriptionJobNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim transcription job: %w", err)
+	}
+
+	slog.Info("Transcription job started",
+		slog.Int64("job_id", job.ID),
+		slog.Int64("room_id", job.RoomID),
+		slog.Int("attempt", job.Attempts),
+		slog.String("worker", workerID),
+	)
+	return true, u.run(ctx, job, workerID)
+}
+
+// run 確保したジョブを実行
+func (u *transcriptionJobUsecase) run(ctx context.Context, job *entity.TranscriptionJob, workerID string) error {
+	room, err := u.roomRepo.FindByID(ctx, job.RoomID)
+	if err != nil {
+		return u.finish(ctx, nil, job, err)
+	}
+
+	// 実行中はリースを延長し続け、他のワーカーに引き継がれたら中断する
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var lost atomic.Bool
+	markLost := func() {
+		lost.Store(true)
+		cancel()
+	}
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		u.keepLease(runCtx, job.ID, workerID, markLost)
+	}()
+
+	// 依頼後に追加された録音も含めて進捗をやり直す
+	if recordings, err := u.recordingRepo.FindByRoomID(ctx, job.RoomID); err == nil {
+		job.Items = pendingItems(recordings)
+	}
+	u.saveProgress(runCtx, room, job, workerID, markLost)
+
+	minutes, err := u.recording.TranscribeRoom(runCtx, job.RoomID, TranscribeRoomOptions{
+		AllowPartial: job.Attempts >= u.config.MaxAttempts,
+		JobID:        &job.ID,
+		OnProgress: func(recordingID int64, status entity.TranscriptionItemStatus, segments int, err error) {
+			job.SetItem(recordingID, status, segments, err)
+			u.saveProgress(runCtx, room, job, workerID, markLost)
+		},
+	})
+	cancel()
+	<-stopped
+
+	if lost.Load() {
+		slog.Warn("Transcription job lease lost",
+			slog.Int64("job_id", job.ID),
+			slog.String("worker", workerID),
+		)
+		return nil
+	}
+
+	// シャットダウンで中断した場合は実行回数に数えずに戻す
+	if ctx.Err() != nil {
+		return u.release(job)
+	}
+
+	if err != nil {
+		return u.finish(ctx, room, job, err)
+	}
+
+	now := time.Now()
+	job.Status = entity.TranscriptionJobSucceeded
+	job.MinutesID = &minutes.ID
+	job.LastError = nil
+	job.FinishedAt = &now
+	clearLease(job)
+	if err := u.jobRepo.Update(ctx, job); err != nil {
+		return fmt.Errorf("failed to update transcription job: %w", err)
+	}
+	u.publish(room, job)
+
+	slog.Info("Transcription job succeeded",
+		slog.Int64("job_id", job.ID),
+		slog.Int64("room_id", job.RoomID),
+		slog.Int64("minutes_id", minutes.ID),
+	)
+	return nil
+}
+
+// finish 失敗したジョブを再試行待ちに戻す、または失敗にする
+func (u *transcriptionJobUsecase) finish(ctx context.Context, room *entity.CallRoom, job *entity.TranscriptionJob, cause error) error {
+	msg := cause.Error()
+	job.LastError = &msg
+	clearLease(job)
+
+	now := time.Now()
+	if isPermanentTranscriptionError(cause) || job.Attempts >= u.config.MaxAttempts {
+		job.Status = entity.TranscriptionJobFailed
+		job.NextAttemptAt = nil
+		job.FinishedAt = &now
+		slog.Error("Transcription job failed",
+			slog.Int64("job_id", job.ID),
+			slog.Int64("room_id", job.RoomID),
+			slog.Int("attempts", job.Attempts),
+			slog.String("error", msg),
+		)
+	} else {
+		next := now.Add(backoffDelay(u.config.RetryBase, u.config.RetryMax, job.Attempts))
+		job.Status = entity.TranscriptionJobQueued
+		job.NextAttemptAt = &next
+		slog.Warn("Transcription job will be retried",
+			slog.Int64("job_id", job.ID),
+			slog.Int64("room_id", job.RoomID),
+			slog.Int("attempts", job.Attempts),
+			slog.Time("next_attempt_at", next),
+			slog.String("error", msg),
+		)
+	}
+
+	if err := u.jobRepo.Update(ctx, job); err != nil {
+		return fmt.Errorf("failed to update transcription job: %w", err)
+	}
+	if room != nil {
+		u.publish(room, job)
+	}
+	return nil
+}
+
+// release 中断したジョブを実行待ちに戻す
+func (u *transcriptionJobUsecase) release(job *entity.TranscriptionJob) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	job.Status = entity.TranscriptionJobQueued
+	job.Attempts--
+	job.NextAttemptAt = &now
+	clearLease(job)
+	if err := u.jobRepo.Update(ctx, job); err != nil {
+		return fmt.Errorf("failed to release transcription job: %w", err)
+	}
+	slog.Info("Transcription job released", slog.Int64("job_id", job.ID))
+	return nil
+}
+
+// keepLease 実行中のジョブのリースを定期的に延長（延長できなかった場合はlostを呼ぶ）
+func (u *transcriptionJobUsecase) keepLease(ctx context.Context, jobID int64, workerID string, lost func()) {
+	ticker := time.NewTicker(u.config.Lease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			ok, err := u.jobRepo.RenewLease(ctx, jobID, workerID, time.Now().Add(u.config.Lease))
+			if err != nil {
+				// 一時的なDBエラーは次の延長で回復できるため、リースが切れるまでは続ける
+				slog.Warn("Failed to renew transcription job lease",
+					slog.Int64("job_id", jobID),
+					slog.String("error", err.Error()),
+				)
+				continue
+			}
+			if !ok {
+				lost()
+				return
+			}
+		}
+	}
+}
+
+// saveProgress 進捗を保存して依頼したユーザーへ通知（他のワーカーに引き継がれていた場合はlostを呼ぶ）
+func (u *transcriptionJobUsecase) saveProgress(ctx context.Context, room *entity.CallRoom, job *entity.TranscriptionJob, workerID string, lost func()) {
+	ok, err := u.jobRepo.UpdateItems(ctx, job.ID, workerID, job.Items)
+	if err != nil {
+		slog.Warn("Failed to save transcription job progress",
+			slog.Int64("job_id", job.ID),
+			slog.String("error", err.Error()),
+		)
+	} else if !ok {
+		lost()
+		return
+	}
+	u.publish(room, job)
+}
+
+// publish ジョブの状態を依頼したユーザーの全端末へ配信
+func (u *transcriptionJobUsecase) publish(room *entity.CallRoom, job *entity.TranscriptionJob) {
+	if job.RequestedBy == nil {
+		return
+	}
+	completed, total := job.Progress()
+	u.hub.Send(*job.RequestedBy, entity.UserEvent{
+		Type: entity.UserEventTranscriptionJob,
+		Data: transcriptionJobEventData{
+			JobID:     job.ID,
+			RoomID:    room.RoomID,
+			Status:    string(job.Status),
+			Attempts:  job.Attempts,
+			Completed: completed,
+			Total:     total,
+			MinutesID: job.MinutesID,
+			Error:     job.LastError,
+		},
+	})
+}
+
+// pendingItems 録音ごとの進捗を未処理で初期化
+func pendingItems(recordings []*entity.CallRecording) []entity.TranscriptionJobItem {
+	items := make([]entity.TranscriptionJobItem, 0, len(recordings))
+	for _, rec := range recordings {
+		items = append(items, entity.TranscriptionJobItem{
+			RecordingID: rec.ID,
+			Status:      entity.TranscriptionItemPending,
+		})
+	}
+	return items
+}
+
+// clearLease ワーカーのリースを解放
+func clearLease(job *entity.TranscriptionJob) {
+	job.LeaseOwner = nil
+	job.LeaseExpiresAt = nil
+}
+
+// isPermanentTranscriptionError 再試行しても結果が変わらないエラーか
+func isPermanentTranscriptionError(err error) bool {
+	return errors.Is(err, entity.ErrNoRecordings) ||
+		errors.Is(err, entity.ErrTranscriberNotConfigured) ||
+		errors.Is(err, port.ErrLanguageNotSupported)
+}
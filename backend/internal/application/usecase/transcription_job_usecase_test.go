@@ -0,0 +1,449 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+)
+
+// fakeRecordingUsecase 文字起こしの呼び出しを記録するRecordingUsecase
+type fakeRecordingUsecase struct {
+	transcribed []int64
+}
+
+func (f *fakeRecordingUsecase) UploadRecording(ctx context.Context, roomID int64, userID int64, file io.Reader, fileSize int64, duration *int, startedAt *time.Time) (*entity.CallRecording, error) {
+	return nil, nil
+}
+
+func (f *fakeRecordingUsecase) RegisterRecording(ctx context.Context, roomID int64, userID int64, object *port.ObjectInfo, duration *int, startedAt *time.Time) (*entity.CallRecording, error) {
+	return nil, nil
+}
+
+func (f *fakeRecordingUsecase) TranscribeAndCreateMinutes(ctx context.Context, roomID int64) error {
+	f.transcribed = append(f.transcribed, roomID)
+	return nil
+}
+
+func (f *fakeRecordingUsecase) TranscribeRoom(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error) {
+	f.transcribed = append(f.transcribed, roomID)
+	return &entity.CallMinutes{RoomID: roomID}, nil
+}
+
+func (f *fakeRecordingUsecase) GetMinutes(ctx context.Context, roomID int64) (*entity.CallMinutes, error) {
+	return nil, nil
+}
+
+func (f *fakeRecordingUsecase) GetTranscriptionRuns(ctx context.Context, roomID int64) ([]*entity.TranscriptionRun, error) {
+	return nil, nil
+}
+
+func (f *fakeRecordingUsecase) GetTranscriptionRun(ctx context.Context, roomID int64, runID int64) (*entity.TranscriptionRun, []*entity.CallTranscription, error) {
+	return nil, nil, entity.ErrTranscriptionRunNotFound
+}
+
+func (f *fakeRecordingUsecase) SetCurrentTranscriptionRun(ctx context.Context, roomID int64, runID int64) (*entity.CallMinutes, error) {
+	return nil, entity.ErrTranscriptionRunNotFound
+}
+
+// jobRecordingUsecase TranscribeRoomの結果を差し替えられるRecordingUsecase
+type jobRecordingUsecase struct {
+	fakeRecordingUsecase
+	transcribe func(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error)
+}
+
+func (f *jobRecordingUsecase) TranscribeRoom(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error) {
+	return f.transcribe(ctx, roomID, opts)
+}
+
+// transcriptionJobTestConfig テスト用のジョブ設定（3回まで、1分から倍々で再試行）
+var transcriptionJobTestConfig = TranscriptionJobConfig{
+	MaxAttempts: 3,
+	RetryBase:   time.Minute,
+	RetryMax:    time.Hour,
+	Lease:       time.Minute,
+}
+
+// transcribeAll 録音ごとに進捗を通知して議事録を作成するTranscribeRoom
+func transcribeAll(recordingRepo *testutil.MockCallRecordingRepository, minutesRepo *testutil.MockCallMinutesRepository) func(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error) {
+	return func(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error) {
+		recordings, _ := recordingRepo.FindByRoomID(ctx, roomID)
+		for _, rec := range recordings {
+			opts.OnProgress(rec.ID, entity.TranscriptionItemDone, 2, nil)
+		}
+		minutes := &entity.CallMinutes{RoomID: roomID}
+		minutesRepo.Create(ctx, minutes)
+		return minutes, nil
+	}
+}
+
+func TestTranscriptionJobUsecase_Enqueue(t *testing.T) {
+	tests := []struct {
+		name        string
+		recordings  int
+		hasMinutes  bool
+		wantItems   int
+		expectedErr error
+	}{
+		{name: "recordings of two participants", recordings: 2, wantItems: 2},
+		{name: "rerun after minutes were created", recordings: 1, hasMinutes: true, wantItems: 1},
+		{name: "no recordings", expectedErr: entity.ErrNoRecordings},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			recordingRepo := testutil.NewMockCallRecordingRepository()
+			minutesRepo := testutil.NewMockCallMinutesRepository()
+			hub := NewUserEventHub()
+			recording := &jobRecordingUsecase{transcribe: transcribeAll(recordingRepo, minutesRepo)}
+			usecase := NewTranscriptionJobUsecase(testutil.NewMockTranscriptionJobRepository(), roomRepo, recordingRepo, recording, hub, transcriptionJobTestConfig)
+			room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+			roomRepo.Create(ctx, room)
+			for i := 0; i < tt.recordings; i++ {
+				recordingRepo.Create(ctx, &entity.CallRecording{RoomID: room.ID, UserID: int64(i + 1), FilePath: "recordings/x.webm"})
+			}
+			if tt.hasMinutes {
+				minutesRepo.Create(ctx, &entity.CallMinutes{RoomID: room.ID})
+			}
+			sub := hub.Subscribe(1)
+
+			// Act
+			job, err := usecase.Enqueue(ctx, room.ID, &room.CreatedBy)
+
+			// Assert
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("Enqueue() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr != nil {
+				return
+			}
+			if job.Status != entity.TranscriptionJobQueued || len(job.Items) != tt.wantItems {
+				t.Fatalf("Enqueue() = %s with %d items, want queued with %d", job.Status, len(job.Items), tt.wantItems)
+			}
+			if event := receiveUserEvent(t, sub); event.Type != entity.UserEventTranscriptionJob {
+				t.Fatalf("event = %s, want transcription-job", event.Type)
+			}
+			select {
+			case <-usecase.Wake():
+			default:
+				t.Error("Enqueue() did not wake the workers")
+			}
+
+			// 実行待ちのジョブがある間は同じジョブを返す
+			if again, err := usecase.Enqueue(ctx, room.ID, nil); err != nil || again.ID != job.ID {
+				t.Errorf("Enqueue() again = %v, %v, want the same job", again, err)
+			}
+		})
+	}
+}
+
+func TestTranscriptionJobUsecase_EnqueueConcurrent(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	jobRepo := testutil.NewMockTranscriptionJobRepository()
+	recordingRepo := testutil.NewMockCallRecordingRepository()
+	usecase := NewTranscriptionJobUsecase(jobRepo, roomRepo, recordingRepo, &jobRecordingUsecase{}, NewUserEventHub(), transcriptionJobTestConfig)
+	room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+	roomRepo.Create(ctx, room)
+	recordingRepo.Create(ctx, &entity.CallRecording{RoomID: room.ID, UserID: 1, FilePath: "recordings/x.webm"})
+
+	// Act（同時に依頼されても作成されるジョブは1つで、全員が同じジョブを受け取る）
+	const requests = 8
+	ids := make(chan int64, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			job, err := usecase.Enqueue(ctx, room.ID, nil)
+			if err != nil {
+				t.Errorf("Enqueue() unexpected error = %v", err)
+				return
+			}
+			ids <- job.ID
+		}()
+	}
+	wg.Wait()
+	close(ids)
+
+	// Assert
+	var first int64
+	for id := range ids {
+		if first == 0 {
+			first = id
+		}
+		if id != first {
+			t.Errorf("Enqueue() returned jobs %d and %d, want the same job", first, id)
+		}
+	}
+	if jobs, _ := jobRepo.FindByRoomID(ctx, room.ID, 10); len(jobs) != 1 {
+		t.Errorf("Enqueue() created %d jobs, want 1", len(jobs))
+	}
+}
+
+func TestTranscriptionJobUsecase_RunNext(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	recordingRepo := testutil.NewMockCallRecordingRepository()
+	minutesRepo := testutil.NewMockCallMinutesRepository()
+	hub := NewUserEventHub()
+	var jobID *int64
+	transcribe := transcribeAll(recordingRepo, minutesRepo)
+	recording := &jobRecordingUsecase{transcribe: func(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error) {
+		jobID = opts.JobID
+		return transcribe(ctx, roomID, opts)
+	}}
+	usecase := NewTranscriptionJobUsecase(testutil.NewMockTranscriptionJobRepository(), roomRepo, recordingRepo, recording, hub, transcriptionJobTestConfig)
+	room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+	roomRepo.Create(ctx, room)
+	recordingRepo.Create(ctx, &entity.CallRecording{RoomID: room.ID, UserID: 1, FilePath: "recordings/x.webm"})
+	recordingRepo.Create(ctx, &entity.CallRecording{RoomID: room.ID, UserID: 2, FilePath: "recordings/x.webm"})
+	job, _ := usecase.Enqueue(ctx, room.ID, &room.CreatedBy)
+	sub := hub.Subscribe(1)
+
+	// Act
+	ran, err := usecase.RunNext(ctx, "worker-1")
+
+	// Assert
+	if err != nil || !ran {
+		t.Fatalf("RunNext() = %v, %v, want a job to run", ran, err)
+	}
+	got, err := usecase.GetJob(ctx, room.ID, job.ID)
+	if err != nil {
+		t.Fatalf("GetJob() unexpected error = %v", err)
+	}
+	if got.Status != entity.TranscriptionJobSucceeded || got.MinutesID == nil || got.Attempts != 1 {
+		t.Errorf("job = %s after %d attempts, minutes %v, want succeeded with minutes", got.Status, got.Attempts, got.MinutesID)
+	}
+	if completed, total := got.Progress(); completed != 2 || total != 2 {
+		t.Errorf("Progress() = %d/%d, want 2/2", completed, total)
+	}
+	if got.LeaseOwner != nil || got.FinishedAt == nil {
+		t.Errorf("finished job still holds the lease or has no finish time")
+	}
+	// 実行にジョブを記録する
+	if jobID == nil || *jobID != job.ID {
+		t.Errorf("TranscribeRoom() job = %v, want %d", jobID, job.ID)
+	}
+
+	// 開始・録音ごとの進捗・完了を配信する
+	var last entity.UserEvent
+	for i := 0; i < 4; i++ {
+		last = receiveUserEvent(t, sub)
+	}
+	assertNoUserEvent(t, sub)
+	if data := last.Data.(transcriptionJobEventData); data.Status != "succeeded" || data.RoomID != "room-abc" || data.Completed != 2 {
+		t.Errorf("last event = %+v, want succeeded with 2 completed", data)
+	}
+
+	if ran, _ := usecase.RunNext(ctx, "worker-1"); ran {
+		t.Error("RunNext() ran a job with an empty queue")
+	}
+
+	// 他のルームのジョブは取得できない
+	if _, err := usecase.GetJob(ctx, room.ID+1, job.ID); !errors.Is(err, entity.ErrTranscriptionJobNotFound) {
+		t.Errorf("GetJob(other room) error = %v, want ErrTranscriptionJobNotFound", err)
+	}
+	if jobs, err := usecase.GetJobs(ctx, room.ID, 0); err != nil || len(jobs) != 1 {
+		t.Errorf("GetJobs() = %d jobs, %v, want 1", len(jobs), err)
+	}
+}
+
+func TestTranscriptionJobUsecase_Retry(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	jobRepo := testutil.NewMockTranscriptionJobRepository()
+	recordingRepo := testutil.NewMockCallRecordingRepository()
+	minutesRepo := testutil.NewMockCallMinutesRepository()
+	var partial []bool
+	transcribe := transcribeAll(recordingRepo, minutesRepo)
+	recording := &jobRecordingUsecase{transcribe: func(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error) {
+		partial = append(partial, opts.AllowPartial)
+		if len(partial) < 3 {
+			return nil, errors.New("speech api unavailable")
+		}
+		return transcribe(ctx, roomID, opts)
+	}}
+	usecase := NewTranscriptionJobUsecase(jobRepo, roomRepo, recordingRepo, recording, NewUserEventHub(), transcriptionJobTestConfig)
+	room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+	roomRepo.Create(ctx, room)
+	recordingRepo.Create(ctx, &entity.CallRecording{RoomID: room.ID, UserID: 1, FilePath: "recordings/x.webm"})
+	job, err := usecase.Enqueue(ctx, room.ID, nil)
+	if err != nil {
+		t.Fatalf("Enqueue() unexpected error = %v", err)
+	}
+
+	// Act & Assert
+	wantDelays := []time.Duration{time.Minute, 2 * time.Minute}
+	for i, want := range wantDelays {
+		before := time.Now()
+		if ran, err := usecase.RunNext(ctx, "worker-1"); err != nil || !ran {
+			t.Fatalf("RunNext() = %v, %v", ran, err)
+		}
+		got, _ := jobRepo.FindByID(ctx, job.ID)
+		if got.Status != entity.TranscriptionJobQueued || got.LastError == nil || got.LeaseOwner != nil {
+			t.Fatalf("attempt %d: job = %s, want queued for retry with the error", i+1, got.Status)
+		}
+		if delay := got.NextAttemptAt.Sub(before); delay < want || delay > want+time.Second {
+			t.Errorf("attempt %d: retry delay = %v, want %v", i+1, delay, want)
+		}
+
+		// 再試行の予定時刻まではワーカーが確保しない
+		if ran, _ := usecase.RunNext(ctx, "worker-1"); ran {
+			t.Fatalf("attempt %d: RunNext() ran a job before its retry time", i+1)
+		}
+		jobRepo.Schedule(job.ID, time.Now())
+	}
+
+	if ran, err := usecase.RunNext(ctx, "worker-1"); err != nil || !ran {
+		t.Fatalf("RunNext() = %v, %v", ran, err)
+	}
+	got, _ := jobRepo.FindByID(ctx, job.ID)
+	if got.Status != entity.TranscriptionJobSucceeded || got.Attempts != 3 {
+		t.Errorf("job = %s after %d attempts, want succeeded after 3", got.Status, got.Attempts)
+	}
+	// 最後の実行でのみ失敗した録音を除いて議事録を作成する
+	if len(partial) != 3 || partial[0] || partial[1] || !partial[2] {
+		t.Errorf("AllowPartial per attempt = %v, want [false false true]", partial)
+	}
+}
+
+func TestTranscriptionJobUsecase_Failures(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		runs         int
+		wantAttempts int
+	}{
+		{name: "transcriber not configured", err: entity.ErrTranscriberNotConfigured, runs: 1, wantAttempts: 1},
+		{name: "language not supported", err: fmt.Errorf("failed to transcribe recording 1: %w", port.ErrLanguageNotSupported), runs: 1, wantAttempts: 1},
+		{name: "attempts exhausted", err: errors.New("speech api unavailable"), runs: 3, wantAttempts: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			ctx := context.Background()
+			roomRepo := testutil.NewMockCallRoomRepository()
+			jobRepo := testutil.NewMockTranscriptionJobRepository()
+			recordingRepo := testutil.NewMockCallRecordingRepository()
+			recording := &jobRecordingUsecase{transcribe: func(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error) {
+				return nil, tt.err
+			}}
+			usecase := NewTranscriptionJobUsecase(jobRepo, roomRepo, recordingRepo, recording, NewUserEventHub(), transcriptionJobTestConfig)
+			room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+			roomRepo.Create(ctx, room)
+			recordingRepo.Create(ctx, &entity.CallRecording{RoomID: room.ID, UserID: 1, FilePath: "recordings/x.webm"})
+			job, _ := usecase.Enqueue(ctx, room.ID, nil)
+
+			// Act
+			for i := 0; i < tt.runs; i++ {
+				if ran, _ := usecase.RunNext(ctx, "worker-1"); !ran {
+					t.Fatalf("attempt %d: RunNext() did not run the job", i+1)
+				}
+				jobRepo.Schedule(job.ID, time.Now())
+			}
+
+			// Assert
+			got, _ := jobRepo.FindByID(ctx, job.ID)
+			if got.Status != entity.TranscriptionJobFailed || got.Attempts != tt.wantAttempts || *got.LastError != tt.err.Error() {
+				t.Errorf("job = %s after %d attempts, want failed after %d", got.Status, got.Attempts, tt.wantAttempts)
+			}
+			if ran, _ := usecase.RunNext(ctx, "worker-1"); ran {
+				t.Error("RunNext() ran a failed job")
+			}
+			// 失敗したジョブは再度依頼できる
+			if retry, err := usecase.Enqueue(ctx, room.ID, nil); err != nil || retry.ID == job.ID {
+				t.Errorf("Enqueue() after failure = %v, %v, want a new job", retry, err)
+			}
+		})
+	}
+}
+
+func TestTranscriptionJobUsecase_LeaseTakeover(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	roomRepo := testutil.NewMockCallRoomRepository()
+	jobRepo := testutil.NewMockTranscriptionJobRepository()
+	recordingRepo := testutil.NewMockCallRecordingRepository()
+	var job *entity.TranscriptionJob
+	var interrupted bool
+	// 1件目の録音の処理中にリースが切れ、別のワーカーが引き継ぐ
+	recording := &jobRecordingUsecase{transcribe: func(ctx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error) {
+		jobRepo.Expire(job.ID)
+		if _, err := jobRepo.Claim(context.Background(), "worker-2", time.Now(), time.Now().Add(time.Minute)); err != nil {
+			t.Fatalf("Claim() by another worker unexpected error = %v", err)
+		}
+		opts.OnProgress(1, entity.TranscriptionItemDone, 1, nil)
+		interrupted = ctx.Err() != nil
+		return nil, ctx.Err()
+	}}
+	usecase := NewTranscriptionJobUsecase(jobRepo, roomRepo, recordingRepo, recording, NewUserEventHub(), transcriptionJobTestConfig)
+	room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+	roomRepo.Create(ctx, room)
+	recordingRepo.Create(ctx, &entity.CallRecording{RoomID: room.ID, UserID: 1, FilePath: "recordings/x.webm"})
+	recordingRepo.Create(ctx, &entity.CallRecording{RoomID: room.ID, UserID: 2, FilePath: "recordings/x.webm"})
+	job, _ = usecase.Enqueue(ctx, room.ID, nil)
+
+	// Act
+	ran, err := usecase.RunNext(ctx, "worker-1")
+
+	// Assert
+	if err != nil || !ran {
+		t.Fatalf("RunNext() = %v, %v", ran, err)
+	}
+	if !interrupted {
+		t.Error("transcription was not cancelled after losing the lease")
+	}
+	// 引き継いだワーカーのジョブを上書きしない
+	got, _ := jobRepo.FindByID(ctx, job.ID)
+	if got.Status != entity.TranscriptionJobRunning || *got.LeaseOwner != "worker-2" || got.Attempts != 2 || got.LastError != nil {
+		t.Errorf("job = %s owned by %v after %d attempts, want running by worker-2", got.Status, *got.LeaseOwner, got.Attempts)
+	}
+	if completed, _ := got.Progress(); completed != 0 {
+		t.Errorf("Progress() = %d completed, want the stale progress discarded", completed)
+	}
+}
+
+func TestTranscriptionJobUsecase_Shutdown(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	roomRepo := testutil.NewMockCallRoomRepository()
+	jobRepo := testutil.NewMockTranscriptionJobRepository()
+	recordingRepo := testutil.NewMockCallRecordingRepository()
+	recording := &jobRecordingUsecase{transcribe: func(runCtx context.Context, roomID int64, opts TranscribeRoomOptions) (*entity.CallMinutes, error) {
+		cancel()
+		return nil, runCtx.Err()
+	}}
+	usecase := NewTranscriptionJobUsecase(jobRepo, roomRepo, recordingRepo, recording, NewUserEventHub(), transcriptionJobTestConfig)
+	room := &entity.CallRoom{RoomID: "room-abc", Name: "定例", CreatedBy: 1}
+	roomRepo.Create(context.Background(), room)
+	recordingRepo.Create(context.Background(), &entity.CallRecording{RoomID: room.ID, UserID: 1, FilePath: "recordings/x.webm"})
+	job, _ := usecase.Enqueue(context.Background(), room.ID, nil)
+
+	// Act
+	ran, err := usecase.RunNext(ctx, "worker-1")
+
+	// Assert
+	if err != nil || !ran {
+		t.Fatalf("RunNext() = %v, %v", ran, err)
+	}
+	// 中断したジョブは実行回数に数えずにすぐ再実行できる
+	got, _ := jobRepo.FindByID(context.Background(), job.ID)
+	if got.Status != entity.TranscriptionJobQueued || got.Attempts != 0 || got.LeaseOwner != nil {
+		t.Errorf("job = %s after %d attempts, want queued again with 0 attempts", got.Status, got.Attempts)
+	}
+}
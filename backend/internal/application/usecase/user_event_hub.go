@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"log/slog"
+	"sync"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// userEventSubscriberBuffer 端末ごとに溜められるイベント数（超えたら接続を打ち切る）
+const userEventSubscriberBuffer = 32
+
+// UserEventHub ユーザー単位のイベント配信
+// ユーザーが接続している全端末へ着信などのイベントを届ける
+type UserEventHub interface {
+	// ユーザーの全端末へイベントを配信
+	Send(userID int64, event entity.UserEvent)
+	// 端末の接続としてイベントを購読する
+	Subscribe(userID int64) *UserEventSubscription
+	// 購読を解除する
+	Unsubscribe(sub *UserEventSubscription)
+	// ユーザーの接続中の端末数
+	ConnectionCount(userID int64) int
+}
+
+// UserEventSubscription 1端末分の購読
+type UserEventSubscription struct {
+	// 配信されるイベント（購読解除、または配信が追いつかない場合に閉じられる）
+	Events <-chan entity.UserEvent
+	UserID int64
+
+	events chan entity.UserEvent
+}
+
+type userEventHub struct {
+	mu          sync.Mutex
+	subscribers map[int64]map[*UserEventSubscription]struct{}
+}
+
+// NewUserEventHub 新しいユーザーイベントハブを作成
+func NewUserEventHub() UserEventHub {
+	return &userEventHub{subscribers: make(map[int64]map[*UserEventSubscription]struct{})}
+}
+
+// Send ユーザーの全端末へイベントを配信
+func (h *userEventHub) Send(userID int64, event entity.UserEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers[userID] {
+		select {
+		case sub.events <- event:
+		default:
+			// 受信が追いつかない端末は打ち切り、再接続させる
+			slog.Warn("Dropping slow user event subscriber", slog.Int64("user_id", userID))
+			h.drop(sub)
+		}
+	}
+}
+
+// Subscribe 端末の接続としてイベントを購読する
+func (h *userEventHub) Subscribe(userID int64) *UserEventSubscription {
+	events := make(chan entity.UserEvent, userEventSubscriberBuffer)
+	sub := &UserEventSubscription{Events: events, UserID: userID, events: events}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = make(map[*UserEventSubscription]struct{})
+	}
+	h.subscribers[userID][sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe 購読を解除する
+func (h *userEventHub) Unsubscribe(sub *UserEventSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.drop(sub)
+}
+
+// ConnectionCount ユーザーの接続中の端末数
+func (h *userEventHub) ConnectionCount(userID int64) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subscribers[userID])
+}
+
+// drop 購読者を外してチャネルを閉じる。呼び出し側でロックを取ること
+func (h *userEventHub) drop(sub *UserEventSubscription) {
+	subs := h.subscribers[sub.UserID]
+	if _, ok := subs[sub]; !ok {
+		return
+	}
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.subscribers, sub.UserID)
+	}
+	close(sub.events)
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// roomEventData room.* イベントのデータ
+type roomEventData struct {
+	RoomID    string     `json:"room_id"`
+	Name      string     `json:"name"`
+	Status    string     `json:"status"`
+	CreatedBy int64      `json:"created_by"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	EndedAt   *time.Time `json:"ended_at,omitempty"`
+}
+
+// participantEventData participant.* イベントのデータ
+type participantEventData struct {
+	RoomID        string     `json:"room_id"`
+	ParticipantID int64      `json:"participant_id"`
+	UserID        *int64     `json:"user_id,omitempty"`
+	GuestID       *string    `json:"guest_id,omitempty"`
+	GuestName     *string    `json:"guest_name,omitempty"`
+	Role          string     `json:"role"`
+	JoinedAt      time.Time  `json:"joined_at"`
+	LeftAt        *time.Time `json:"left_at,omitempty"`
+}
+
+// recordingEventData recording.uploaded イベントのデータ
+type recordingEventData struct {
+	RoomID          string `json:"room_id"`
+	RecordingID     int64  `json:"recording_id"`
+	UserID          int64  `json:"user_id"`
+	FileSize        int64  `json:"file_size"`
+	DurationSeconds *int   `json:"duration_seconds,omitempty"`
+	Format          string `json:"format"`
+}
+
+// minutesEventData minutes.ready イベントのデータ
+type minutesEventData struct {
+	RoomID      string   `json:"room_id"`
+	MinutesID   int64    `json:"minutes_id"`
+	Title       string   `json:"title"`
+	Languages   []string `json:"languages,omitempty"` // 文字起こしで話された言語（多い順）
+	RunID       int64    `json:"run_id"`              // 議事録の作成に使った文字起こしの実行
+	Regenerated bool     `json:"regenerated"`         // 再実行・実行の切り替えで既存の議事録を作り直した
+}
+
+// publishRoomEvent ルームのイベントをルーム作成者のWebhookへ通知
+func publishRoomEvent(ctx context.Context, events EventPublisher, event entity.WebhookEvent, room *entity.CallRoom) {
+	events.Publish(ctx, room.CreatedBy, event, roomEventData{
+		RoomID:    room.RoomID,
+		Name:      room.Name,
+		Status:    string(room.Status),
+		CreatedBy: room.CreatedBy,
+		StartedAt: room.StartedAt,
+		EndedAt:   room.EndedAt,
+	})
+}
+
+// publishParticipantEvent 参加者のイベントをルーム作成者のWebhookへ通知
+func publishParticipantEvent(ctx context.Context, events EventPublisher, event entity.WebhookEvent, room *entity.CallRoom, p *entity.CallParticipant) {
+	data := participantEventData{
+		RoomID:        room.RoomID,
+		ParticipantID: p.ID,
+		Role:          string(p.Role),
+		JoinedAt:      p.JoinedAt,
+		LeftAt:        p.LeftAt,
+	}
+	if p.IsGuest() {
+		data.GuestID = p.GuestID
+		data.GuestName = p.GuestName
+	} else {
+		userID := p.UserID
+		data.UserID = &userID
+	}
+	events.Publish(ctx, room.CreatedBy, event, data)
+}
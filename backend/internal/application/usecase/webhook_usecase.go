@@ -0,0 +1,401 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/internal/domain/port"
+	"Go-Next-WebRTC/pkg/webhook"
+
+	"github.com/google/uuid"
+)
+
+// webhookDeliveryLockName 複数レプリカで同じ配信を送信しないためのロック名
+const webhookDeliveryLockName = "webhook_delivery"
+
+// 配信記録一覧の取得件数
+const (
+	defaultDeliveryLimit = 20
+	maxDeliveryLimit     = 100
+)
+
+// WebhookConfig Webhook配信の再試行設定
+type WebhookConfig struct {
+	// 送信試行回数の上限（到達したら失敗とする）
+	MaxAttempts int
+	// 初回の再試行間隔（以降は2倍ずつ増やす）
+	RetryBase time.Duration
+	// 再試行間隔の上限
+	RetryMax time.Duration
+	// 1回の配信処理で送信する件数
+	BatchSize int
+	// localhostや内部向けのアドレスへの登録を許可する（ローカル開発用）
+	AllowPrivateNetworks bool
+}
+
+// WebhookUpdate Webhookの更新内容（nilの項目は変更しない）
+type WebhookUpdate struct {
+	URL          *string
+	Events       []entity.WebhookEvent
+	IsActive     *bool
+	RotateSecret bool
+}
+
+// WebhookUsecase Webhookユースケースのインターフェース
+type WebhookUsecase interface {
+	EventPublisher
+	// Webhook登録（シークレットを生成する）
+	CreateWebhook(ctx context.Context, webhook *entity.Webhook) error
+	// ユーザーのWebhook一覧取得
+	GetWebhooks(ctx context.Context, userID int64) ([]*entity.Webhook, error)
+	// Webhook取得（登録したユーザーのみ）
+	GetWebhook(ctx context.Context, userID int64, webhookID int64) (*entity.Webhook, error)
+	// Webhook更新
+	UpdateWebhook(ctx context.Context, userID int64, webhookID int64, update *WebhookUpdate) (*entity.Webhook, error)
+	// Webhook削除
+	DeleteWebhook(ctx context.Context, userID int64, webhookID int64) error
+	// 配信記録一覧取得（新しい順）
+	GetDeliveries(ctx context.Context, userID int64, webhookID int64, limit int) ([]*entity.WebhookDelivery, error)
+	// 配信を再送（同じイベントIDとペイロードで新しい配信を予約）
+	ReplayDelivery(ctx context.Context, userID int64, webhookID int64, deliveryID int64) (*entity.WebhookDelivery, error)
+	// 送信予定時刻を過ぎた配信を送信し、送信した件数を返す
+	DeliverPending(ctx context.Context, now time.Time) (int, error)
+}
+
+type webhookUsecase struct {
+	webhookRepo port.WebhookRepository
+	client      *webhook.Client
+	lock        port.DistributedLock
+	config      WebhookConfig
+}
+
+// NewWebhookUsecase 新しいWebhookユースケースを作成
+func NewWebhookUsecase(
+	webhookRepo port.WebhookRepository,
+	client *webhook.Client,
+	lock port.DistributedLock,
+	config WebhookConfig,
+) WebhookUsecase {
+	return &webhookUsecase{
+		webhookRepo: webhookRepo,
+		client:      client,
+		lock:        lock,
+		config:      config,
+	}
+}
+
+// webhookPayload 送信する本文
+type webhookPayload struct {
+	ID        string              `json:"id"`
+	Event     entity.WebhookEvent `json:"event"`
+	CreatedAt time.Time           `json:"created_at"`
+	Data      interface{}         `json:"data"`
+}
+
+// CreateWebhook Webhookを登録
+func (u *webhookUsecase) CreateWebhook(ctx context.Context, w *entity.Webhook) error {
+	if err := u.validate(w); err != nil {
+		return err
+	}
+
+	secret, err := webhook.GenerateSecret()
+	if err != nil {
+		return err
+	}
+	w.Secret = secret
+	w.IsActive = true
+
+	return u.webhookRepo.Create(ctx, w)
+}
+
+// validate Webhookを検証（内部向けの送信先は許可している場合のみ受け付ける）
+func (u *webhookUsecase) validate(w *entity.Webhook) error {
+	err := w.Validate()
+	if errors.Is(err, entity.ErrPrivateWebhookURL) && u.config.AllowPrivateNetworks {
+		return nil
+	}
+	return err
+}
+
+// GetWebhooks ユーザーのWebhook一覧を取得
+func (u *webhookUsecase) GetWebhooks(ctx context.Context, userID int64) ([]*entity.Webhook, error) {
+	return u.webhookRepo.FindByUserID(ctx, userID)
+}
+
+// GetWebhook Webhookを取得（他のユーザーのWebhookは存在しないものとして扱う）
+func (u *webhookUsecase) GetWebhook(ctx context.Context, userID int64, webhookID int64) (*entity.Webhook, error) {
+	w, err := u.webhookRepo.FindByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if w.UserID != userID {
+		return nil, entity.ErrWebhookNotFound
+	}
+	return w, nil
+}
+
+// UpdateWebhook Webhookを更新
+func (u *webhookUsecase) UpdateWebhook(ctx context.Context, userID int64, webhookID int64, update *WebhookUpdate) (*entity.Webhook, error) {
+	w, err := u.GetWebhook(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.URL != nil {
+		w.URL = *update.URL
+	}
+	if update.Events != nil {
+		w.Events = update.Events
+	}
+	if update.IsActive != nil {
+		w.IsActive = *update.IsActive
+	}
+	if err := u.validate(w); err != nil {
+		return nil, err
+	}
+	if update.RotateSecret {
+		secret, err := webhook.GenerateSecret()
+		if err != nil {
+			return nil, err
+		}
+		w.Secret = secret
+	}
+
+	if err := u.webhookRepo.Update(ctx, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// DeleteWebhook Webhookを削除
+func (u *webhookUsecase) DeleteWebhook(ctx context.Context, userID int64, webhookID int64) error {
+	if _, err := u.GetWebhook(ctx, userID, webhookID); err != nil {
+		return err
+	}
+	return u.webhookRepo.Delete(ctx, webhookID)
+}
+
+// GetDeliveries 配信記録一覧を取得
+func (u *webhookUsecase) GetDeliveries(ctx context.Context, userID int64, webhookID int64, limit int) ([]*entity.WebhookDelivery, error) {
+	if _, err := u.GetWebhook(ctx, userID, webhookID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultDeliveryLimit
+	}
+	if limit > maxDeliveryLimit {
+		limit = maxDeliveryLimit
+	}
+	return u.webhookRepo.FindDeliveriesByWebhookID(ctx, webhookID, limit)
+}
+
+// ReplayDelivery 配信を再送
+// 受信側が重複を判定できるよう、イベントIDは元の配信と同じものを使う
+func (u *webhookUsecase) ReplayDelivery(ctx context.Context, userID int64, webhookID int64, deliveryID int64) (*entity.WebhookDelivery, error) {
+	if _, err := u.GetWebhook(ctx, userID, webhookID); err != nil {
+		return nil, err
+	}
+
+	original, err := u.webhookRepo.FindDeliveryByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if original.WebhookID != webhookID {
+		return nil, entity.ErrWebhookDeliveryNotFound
+	}
+
+	now := time.Now()
+	replay := &entity.WebhookDelivery{
+		WebhookID:     webhookID,
+		EventID:       original.EventID,
+		Event:         original.Event,
+		Payload:       original.Payload,
+		Status:        entity.WebhookDeliveryPending,
+		NextAttemptAt: &now,
+		ReplayOf:      &original.ID,
+	}
+	if err := u.webhookRepo.CreateDelivery(ctx, replay); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// Publish イベントを購読している有効なWebhookへの配信を予約
+// 送信はDeliverPendingで行うため、呼び出し元の処理を待たせない
+func (u *webhookUsecase) Publish(ctx context.Context, ownerID int64, event entity.WebhookEvent, data interface{}) {
+	webhooks, err := u.webhookRepo.FindActiveByUserID(ctx, ownerID)
+	if err != nil {
+		slog.Error("Failed to get webhooks",
+			slog.Int64("user_id", ownerID),
+			slog.String("event", string(event)),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	var targets []*entity.Webhook
+	for _, w := range webhooks {
+		if w.Subscribes(event) {
+			targets = append(targets, w)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	now := time.Now()
+	eventID := uuid.New().String()
+	payload, err := json.Marshal(webhookPayload{
+		ID:        eventID,
+		Event:     event,
+		CreatedAt: now.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		slog.Error("Failed to encode webhook payload",
+			slog.String("event", string(event)),
+			slog.String("error", err.Error()))
+		return
+	}
+
+	for _, w := range targets {
+		delivery := &entity.WebhookDelivery{
+			WebhookID:     w.ID,
+			EventID:       eventID,
+			Event:         event,
+			Payload:       payload,
+			Status:        entity.WebhookDeliveryPending,
+			NextAttemptAt: &now,
+		}
+		if err := u.webhookRepo.CreateDelivery(ctx, delivery); err != nil {
+			slog.Error("Failed to create webhook delivery",
+				slog.Int64("webhook_id", w.ID),
+				slog.String("event", string(event)),
+				slog.String("error", err.Error()))
+		}
+	}
+}
+
+// DeliverPending 送信予定時刻を過ぎた配信を送信
+// 他のレプリカが実行中の場合は何もしない
+func (u *webhookUsecase) DeliverPending(ctx context.Context, now time.Time) (int, error) {
+	release, acquired, err := u.lock.TryLock(ctx, webhookDeliveryLockName)
+	if err != nil {
+		return 0, err
+	}
+	if !acquired {
+		return 0, nil
+	}
+	defer release()
+
+	deliveries, err := u.webhookRepo.FindDueDeliveries(ctx, now, u.config.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	webhooks := make(map[int64]*entity.Webhook)
+	sent := 0
+	for _, d := range deliveries {
+		w, ok := webhooks[d.WebhookID]
+		if !ok {
+			w, err = u.webhookRepo.FindByID(ctx, d.WebhookID)
+			if err != nil {
+				slog.Error("Failed to get webhook",
+					slog.Int64("webhook_id", d.WebhookID),
+					slog.String("error", err.Error()))
+				continue
+			}
+			webhooks[d.WebhookID] = w
+		}
+
+		u.deliver(ctx, w, d, now)
+		if err := u.webhookRepo.UpdateDelivery(ctx, d); err != nil {
+			slog.Error("Failed to update webhook delivery",
+				slog.Int64("delivery_id", d.ID),
+				slog.String("error", err.Error()))
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// deliver 配信を1回送信し、結果を配信記録に反映
+func (u *webhookUsecase) deliver(ctx context.Context, w *entity.Webhook, d *entity.WebhookDelivery, now time.Time) {
+	// 無効化されたWebhookへは送信しない
+	if !w.IsActive {
+		msg := "webhook is disabled"
+		d.Status = entity.WebhookDeliveryFailed
+		d.NextAttemptAt = nil
+		d.LastError = &msg
+		return
+	}
+
+	d.Attempts++
+	resp, err := u.client.Send(ctx, &webhook.Message{
+		URL:        w.URL,
+		Secret:     w.Secret,
+		Event:      string(d.Event),
+		EventID:    d.EventID,
+		DeliveryID: d.ID,
+		Body:       d.Payload,
+	})
+
+	if resp != nil {
+		d.LastStatusCode = &resp.StatusCode
+	}
+	if err == nil && resp.OK() {
+		d.Status = entity.WebhookDeliverySucceeded
+		d.DeliveredAt = &now
+		d.NextAttemptAt = nil
+		d.LastError = nil
+		return
+	}
+
+	var msg string
+	if err != nil {
+		msg = err.Error()
+	} else {
+		msg = fmt.Sprintf("unexpected status %d: %s", resp.StatusCode, resp.Body)
+	}
+	d.LastError = &msg
+
+	if d.Attempts >= u.config.MaxAttempts {
+		d.Status = entity.WebhookDeliveryFailed
+		d.NextAttemptAt = nil
+		slog.Warn("Webhook delivery failed permanently",
+			slog.Int64("delivery_id", d.ID),
+			slog.Int64("webhook_id", w.ID),
+			slog.Int("attempts", d.Attempts),
+			slog.String("error", msg))
+		return
+	}
+
+	next := now.Add(u.retryDelay(d.Attempts))
+	d.NextAttemptAt = &next
+}
+
+// retryDelay 試行回数に応じた再試行間隔（指数バックオフ）
+func (u *webhookUsecase) retryDelay(attempts int) time.Duration {
+	return backoffDelay(u.config.RetryBase, u.config.RetryMax, attempts)
+}
+
+// backoffDelay base から試行ごとに倍にし、max で打ち止めにした待ち時間
+func backoffDelay(base, max time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
@@ -0,0 +1,435 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"Go-Next-WebRTC/internal/application/usecase/testutil"
+	"Go-Next-WebRTC/internal/domain/entity"
+	"Go-Next-WebRTC/pkg/webhook"
+)
+
+// webhookReceiver テスト用のWebhook受信サーバー
+type webhookReceiver struct {
+	server *httptest.Server
+	secret string
+
+	mu       sync.Mutex
+	requests []*receivedWebhook
+	// 応答するステータス（空になったら200）
+	statuses []int
+}
+
+// receivedWebhook 受信したリクエスト
+type receivedWebhook struct {
+	Event      string
+	EventID    string
+	DeliveryID string
+	Payload    webhookPayload
+	Data       map[string]interface{}
+}
+
+func newWebhookReceiver(t *testing.T, statuses ...int) *webhookReceiver {
+	r := &webhookReceiver{statuses: statuses}
+	r.server = httptest.NewServer(http.HandlerFunc(r.handle))
+	t.Cleanup(r.server.Close)
+	return r
+}
+
+func (r *webhookReceiver) handle(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := webhook.Verify(r.secret, req.Header.Get(webhook.HeaderSignature), body, time.Minute, time.Now()); err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var payload struct {
+		webhookPayload
+		Data map[string]interface{} `json:"data"`
+	}
+	json.Unmarshal(body, &payload)
+	r.requests = append(r.requests, &receivedWebhook{
+		Event:      req.Header.Get(webhook.HeaderEvent),
+		EventID:    req.Header.Get(webhook.HeaderEventID),
+		DeliveryID: req.Header.Get(webhook.HeaderDeliveryID),
+		Payload:    payload.webhookPayload,
+		Data:       payload.Data,
+	})
+
+	if len(r.statuses) > 0 {
+		status := r.statuses[0]
+		r.statuses = r.statuses[1:]
+		w.WriteHeader(status)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (r *webhookReceiver) received() []*receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]*receivedWebhook(nil), r.requests...)
+}
+
+// newWebhookTestUsecase テスト用のWebhookユースケースを作成（受信サーバーがループバックのため内部向けの送信先を許可する）
+func newWebhookTestUsecase() (WebhookUsecase, *testutil.MockWebhookRepository, *testutil.MockDistributedLock) {
+	return newWebhookTestUsecaseWithNetworks(true)
+}
+
+func newWebhookTestUsecaseWithNetworks(allowPrivate bool) (WebhookUsecase, *testutil.MockWebhookRepository, *testutil.MockDistributedLock) {
+	repo := testutil.NewMockWebhookRepository()
+	lock := testutil.NewMockDistributedLock()
+	uc := NewWebhookUsecase(repo, webhook.NewClient(5*time.Second, allowPrivate), lock, WebhookConfig{
+		MaxAttempts:          3,
+		RetryBase:            time.Minute,
+		RetryMax:             time.Hour,
+		BatchSize:            10,
+		AllowPrivateNetworks: allowPrivate,
+	})
+	return uc, repo, lock
+}
+
+// registerWebhook 受信サーバー向けのWebhookを登録
+func registerWebhook(t *testing.T, uc WebhookUsecase, receiver *webhookReceiver, userID int64, events ...entity.WebhookEvent) *entity.Webhook {
+	t.Helper()
+	w := &entity.Webhook{UserID: userID, URL: receiver.server.URL, Events: events}
+	if err := uc.CreateWebhook(context.Background(), w); err != nil {
+		t.Fatalf("CreateWebhook() unexpected error = %v", err)
+	}
+	receiver.secret = w.Secret
+	return w
+}
+
+func TestWebhookUsecase_CreateWebhook(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		events       []entity.WebhookEvent
+		allowPrivate bool
+		expectedErr  error
+	}{
+		{name: "valid", url: "https://example.com/hooks", events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}},
+		{name: "invalid scheme", url: "ftp://example.com/hooks", events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}, expectedErr: entity.ErrInvalidWebhookURL},
+		{name: "missing host", url: "https:///hooks", events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}, expectedErr: entity.ErrInvalidWebhookURL},
+		{name: "no events", url: "https://example.com/hooks", expectedErr: entity.ErrWebhookEventsRequired},
+		{name: "unknown event", url: "https://example.com/hooks", events: []entity.WebhookEvent{"room.deleted"}, expectedErr: entity.ErrInvalidWebhookEvent},
+		{name: "localhost", url: "http://localhost:8080/hooks", events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}, expectedErr: entity.ErrPrivateWebhookURL},
+		{name: "loopback", url: "http://127.0.0.1/hooks", events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}, expectedErr: entity.ErrPrivateWebhookURL},
+		{name: "private", url: "https://10.0.0.5/hooks", events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}, expectedErr: entity.ErrPrivateWebhookURL},
+		{name: "link local", url: "http://169.254.169.254/latest/meta-data", events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}, expectedErr: entity.ErrPrivateWebhookURL},
+		{name: "unspecified ipv6", url: "http://[::]/hooks", events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}, expectedErr: entity.ErrPrivateWebhookURL},
+		{name: "private allowed", url: "http://127.0.0.1/hooks", events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}, allowPrivate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc, repo, _ := newWebhookTestUsecaseWithNetworks(tt.allowPrivate)
+			w := &entity.Webhook{UserID: 1, URL: tt.url, Events: tt.events}
+
+			err := uc.CreateWebhook(context.Background(), w)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Fatalf("CreateWebhook() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			if tt.expectedErr != nil {
+				if len(repo.Webhooks) != 0 {
+					t.Error("CreateWebhook() stored an invalid webhook")
+				}
+				return
+			}
+			if w.Secret == "" || !w.IsActive {
+				t.Errorf("CreateWebhook() secret = %q, active = %v", w.Secret, w.IsActive)
+			}
+		})
+	}
+}
+
+func TestWebhookUsecase_PublishAndDeliver(t *testing.T) {
+	ctx := context.Background()
+	uc, repo, _ := newWebhookTestUsecase()
+	receiver := newWebhookReceiver(t)
+
+	subscribed := registerWebhook(t, uc, receiver, 1, entity.WebhookEventRoomStarted, entity.WebhookEventRoomEnded)
+	// 購読していないイベント・無効化されたWebhook・他のユーザーのWebhookには配信しない
+	other := &entity.Webhook{UserID: 1, URL: receiver.server.URL, Events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}}
+	uc.CreateWebhook(ctx, other)
+	disabled := &entity.Webhook{UserID: 1, URL: receiver.server.URL, Events: []entity.WebhookEvent{entity.WebhookEventRoomStarted}}
+	uc.CreateWebhook(ctx, disabled)
+	inactive := false
+	uc.UpdateWebhook(ctx, 1, disabled.ID, &WebhookUpdate{IsActive: &inactive})
+	anotherUser := &entity.Webhook{UserID: 2, URL: receiver.server.URL, Events: []entity.WebhookEvent{entity.WebhookEventRoomStarted}}
+	uc.CreateWebhook(ctx, anotherUser)
+
+	room := &entity.CallRoom{RoomID: "room-1", Name: "Weekly", CreatedBy: 1, Status: entity.CallRoomStatusActive}
+	publishRoomEvent(ctx, uc, entity.WebhookEventRoomStarted, room)
+
+	if len(repo.Deliveries) != 1 {
+		t.Fatalf("Publish() created %d deliveries, want 1", len(repo.Deliveries))
+	}
+
+	sent, err := uc.DeliverPending(ctx, time.Now())
+	if err != nil || sent != 1 {
+		t.Fatalf("DeliverPending() sent = %d, err = %v", sent, err)
+	}
+
+	received := receiver.received()
+	if len(received) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(received))
+	}
+	got := received[0]
+	if got.Event != string(entity.WebhookEventRoomStarted) || got.Payload.Event != entity.WebhookEventRoomStarted {
+		t.Errorf("received event = %s / %s", got.Event, got.Payload.Event)
+	}
+	if got.EventID == "" || got.EventID != got.Payload.ID {
+		t.Errorf("received event id header = %q, payload id = %q", got.EventID, got.Payload.ID)
+	}
+	if got.Data["room_id"] != "room-1" || got.Data["name"] != "Weekly" {
+		t.Errorf("received data = %v", got.Data)
+	}
+
+	deliveries, err := uc.GetDeliveries(ctx, 1, subscribed.ID, 0)
+	if err != nil || len(deliveries) != 1 {
+		t.Fatalf("GetDeliveries() = %v, err = %v", deliveries, err)
+	}
+	d := deliveries[0]
+	if d.Status != entity.WebhookDeliverySucceeded || d.Attempts != 1 || d.DeliveredAt == nil || d.NextAttemptAt != nil {
+		t.Errorf("delivery = %+v", d)
+	}
+	if d.LastStatusCode == nil || *d.LastStatusCode != http.StatusOK {
+		t.Errorf("delivery last status = %v", d.LastStatusCode)
+	}
+
+	// 送信済みの配信は再送しない
+	if sent, _ := uc.DeliverPending(ctx, time.Now()); sent != 0 {
+		t.Errorf("DeliverPending() resent %d deliveries", sent)
+	}
+}
+
+func TestWebhookUsecase_DeliverPending_Retry(t *testing.T) {
+	ctx := context.Background()
+	uc, repo, _ := newWebhookTestUsecase()
+	receiver := newWebhookReceiver(t, http.StatusInternalServerError, http.StatusBadGateway)
+	registerWebhook(t, uc, receiver, 1, entity.WebhookEventMinutesReady)
+
+	uc.Publish(ctx, 1, entity.WebhookEventMinutesReady, minutesEventData{RoomID: "room-1", MinutesID: 3})
+	d := repo.Deliveries[1]
+
+	// 1回目: 失敗して1分後に再試行
+	now := time.Now()
+	uc.DeliverPending(ctx, now)
+	if d.Status != entity.WebhookDeliveryPending || d.Attempts != 1 || d.NextAttemptAt == nil || !d.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("after 1st attempt: %+v", d)
+	}
+	if d.LastStatusCode == nil || *d.LastStatusCode != http.StatusInternalServerError || d.LastError == nil {
+		t.Errorf("after 1st attempt: status = %v, error = %v", d.LastStatusCode, d.LastError)
+	}
+
+	// 再試行時刻前は送信しない
+	if sent, _ := uc.DeliverPending(ctx, now.Add(30*time.Second)); sent != 0 {
+		t.Fatalf("DeliverPending() before retry time sent %d", sent)
+	}
+
+	// 2回目: 失敗して間隔が2倍になる
+	now = now.Add(time.Minute)
+	uc.DeliverPending(ctx, now)
+	if d.Attempts != 2 || d.NextAttemptAt == nil || !d.NextAttemptAt.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("after 2nd attempt: %+v", d)
+	}
+
+	// 3回目: 成功
+	now = now.Add(2 * time.Minute)
+	uc.DeliverPending(ctx, now)
+	if d.Status != entity.WebhookDeliverySucceeded || d.Attempts != 3 || d.LastError != nil {
+		t.Fatalf("after 3rd attempt: %+v", d)
+	}
+
+	received := receiver.received()
+	if len(received) != 3 {
+		t.Fatalf("receiver got %d requests, want 3", len(received))
+	}
+	for _, r := range received {
+		if r.EventID != received[0].EventID || r.DeliveryID != received[0].DeliveryID {
+			t.Errorf("retries should keep the event and delivery id: %+v", r)
+		}
+	}
+}
+
+func TestWebhookUsecase_DeliverPending_GivesUp(t *testing.T) {
+	ctx := context.Background()
+	uc, repo, _ := newWebhookTestUsecase()
+	receiver := newWebhookReceiver(t, 500, 500, 500, 500)
+	registerWebhook(t, uc, receiver, 1, entity.WebhookEventRoomEnded)
+
+	uc.Publish(ctx, 1, entity.WebhookEventRoomEnded, roomEventData{RoomID: "room-1"})
+	d := repo.Deliveries[1]
+
+	now := time.Now()
+	for i := 0; i < 5; i++ {
+		uc.DeliverPending(ctx, now)
+		now = now.Add(time.Hour)
+	}
+
+	if d.Status != entity.WebhookDeliveryFailed || d.Attempts != 3 || d.NextAttemptAt != nil {
+		t.Errorf("delivery = %+v, want failed after 3 attempts", d)
+	}
+	if n := len(receiver.received()); n != 3 {
+		t.Errorf("receiver got %d requests, want 3", n)
+	}
+}
+
+func TestWebhookUsecase_DeliverPending_ConnectionError(t *testing.T) {
+	ctx := context.Background()
+	uc, repo, _ := newWebhookTestUsecase()
+	receiver := newWebhookReceiver(t)
+	registerWebhook(t, uc, receiver, 1, entity.WebhookEventRoomCreated)
+	receiver.server.Close()
+
+	uc.Publish(ctx, 1, entity.WebhookEventRoomCreated, roomEventData{RoomID: "room-1"})
+	uc.DeliverPending(ctx, time.Now())
+
+	d := repo.Deliveries[1]
+	if d.Status != entity.WebhookDeliveryPending || d.Attempts != 1 || d.LastStatusCode != nil || d.LastError == nil {
+		t.Errorf("delivery = %+v", d)
+	}
+}
+
+func TestWebhookUsecase_DeliverPending_DisabledWebhook(t *testing.T) {
+	ctx := context.Background()
+	uc, repo, _ := newWebhookTestUsecase()
+	receiver := newWebhookReceiver(t)
+	w := registerWebhook(t, uc, receiver, 1, entity.WebhookEventRoomCreated)
+
+	uc.Publish(ctx, 1, entity.WebhookEventRoomCreated, roomEventData{RoomID: "room-1"})
+	inactive := false
+	uc.UpdateWebhook(ctx, 1, w.ID, &WebhookUpdate{IsActive: &inactive})
+	uc.DeliverPending(ctx, time.Now())
+
+	if d := repo.Deliveries[1]; d.Status != entity.WebhookDeliveryFailed || d.Attempts != 0 {
+		t.Errorf("delivery = %+v, want failed without attempts", d)
+	}
+	if n := len(receiver.received()); n != 0 {
+		t.Errorf("receiver got %d requests, want 0", n)
+	}
+}
+
+func TestWebhookUsecase_ReplayDelivery(t *testing.T) {
+	ctx := context.Background()
+	uc, _, _ := newWebhookTestUsecase()
+	receiver := newWebhookReceiver(t)
+	w := registerWebhook(t, uc, receiver, 1, entity.WebhookEventRecordingUploaded)
+
+	uc.Publish(ctx, 1, entity.WebhookEventRecordingUploaded, recordingEventData{RoomID: "room-1", RecordingID: 9})
+	uc.DeliverPending(ctx, time.Now())
+
+	replay, err := uc.ReplayDelivery(ctx, 1, w.ID, 1)
+	if err != nil {
+		t.Fatalf("ReplayDelivery() unexpected error = %v", err)
+	}
+	if replay.ID == 1 || replay.ReplayOf == nil || *replay.ReplayOf != 1 || replay.Status != entity.WebhookDeliveryPending {
+		t.Errorf("ReplayDelivery() = %+v", replay)
+	}
+	uc.DeliverPending(ctx, time.Now())
+
+	received := receiver.received()
+	if len(received) != 2 {
+		t.Fatalf("receiver got %d requests, want 2", len(received))
+	}
+	if received[0].EventID != received[1].EventID || received[0].DeliveryID == received[1].DeliveryID {
+		t.Errorf("replay should keep the event id with a new delivery id: %+v / %+v", received[0], received[1])
+	}
+	if received[1].Data["recording_id"] != float64(9) {
+		t.Errorf("replayed data = %v", received[1].Data)
+	}
+
+	// 他のユーザーのWebhookや別のWebhookの配信は再送できない
+	if _, err := uc.ReplayDelivery(ctx, 2, w.ID, 1); !errors.Is(err, entity.ErrWebhookNotFound) {
+		t.Errorf("ReplayDelivery() by another user error = %v", err)
+	}
+	other := registerWebhook(t, uc, receiver, 1, entity.WebhookEventRoomCreated)
+	if _, err := uc.ReplayDelivery(ctx, 1, other.ID, 1); !errors.Is(err, entity.ErrWebhookDeliveryNotFound) {
+		t.Errorf("ReplayDelivery() via another webhook error = %v", err)
+	}
+}
+
+func TestWebhookUsecase_Ownership(t *testing.T) {
+	ctx := context.Background()
+	uc, repo, _ := newWebhookTestUsecase()
+	w := &entity.Webhook{UserID: 1, URL: "https://example.com/hooks", Events: []entity.WebhookEvent{entity.WebhookEventRoomCreated}}
+	uc.CreateWebhook(ctx, w)
+
+	if _, err := uc.GetWebhook(ctx, 2, w.ID); !errors.Is(err, entity.ErrWebhookNotFound) {
+		t.Errorf("GetWebhook() by another user error = %v", err)
+	}
+	if _, err := uc.GetDeliveries(ctx, 2, w.ID, 0); !errors.Is(err, entity.ErrWebhookNotFound) {
+		t.Errorf("GetDeliveries() by another user error = %v", err)
+	}
+	if err := uc.DeleteWebhook(ctx, 2, w.ID); !errors.Is(err, entity.ErrWebhookNotFound) {
+		t.Errorf("DeleteWebhook() by another user error = %v", err)
+	}
+
+	secret := w.Secret
+	updated, err := uc.UpdateWebhook(ctx, 1, w.ID, &WebhookUpdate{RotateSecret: true})
+	if err != nil || updated.Secret == secret {
+		t.Errorf("UpdateWebhook() rotate secret = %v, err = %v", updated, err)
+	}
+	if _, err := uc.UpdateWebhook(ctx, 1, w.ID, &WebhookUpdate{Events: []entity.WebhookEvent{}}); !errors.Is(err, entity.ErrWebhookEventsRequired) {
+		t.Errorf("UpdateWebhook() without events error = %v", err)
+	}
+
+	if err := uc.DeleteWebhook(ctx, 1, w.ID); err != nil || len(repo.Webhooks) != 0 {
+		t.Errorf("DeleteWebhook() err = %v, remaining = %d", err, len(repo.Webhooks))
+	}
+}
+
+func TestWebhookUsecase_DeliverPending_Lock(t *testing.T) {
+	ctx := context.Background()
+	uc, _, lock := newWebhookTestUsecase()
+	receiver := newWebhookReceiver(t)
+	registerWebhook(t, uc, receiver, 1, entity.WebhookEventRoomCreated)
+	uc.Publish(ctx, 1, entity.WebhookEventRoomCreated, roomEventData{RoomID: "room-1"})
+
+	lock.Held[webhookDeliveryLockName] = true
+	if sent, err := uc.DeliverPending(ctx, time.Now()); err != nil || sent != 0 {
+		t.Fatalf("DeliverPending() with lock held: sent = %d, err = %v", sent, err)
+	}
+
+	delete(lock.Held, webhookDeliveryLockName)
+	if sent, err := uc.DeliverPending(ctx, time.Now()); err != nil || sent != 1 {
+		t.Fatalf("DeliverPending() sent = %d, err = %v", sent, err)
+	}
+	if lock.Held[webhookDeliveryLockName] {
+		t.Error("DeliverPending() did not release the lock")
+	}
+}
+
+func TestWebhookUsecase_RetryDelay(t *testing.T) {
+	u := &webhookUsecase{config: WebhookConfig{RetryBase: 30 * time.Second, RetryMax: 10 * time.Minute}}
+
+	tests := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{attempts: 1, expected: 30 * time.Second},
+		{attempts: 2, expected: time.Minute},
+		{attempts: 3, expected: 2 * time.Minute},
+		{attempts: 5, expected: 8 * time.Minute},
+		{attempts: 6, expected: 10 * time.Minute},
+		{attempts: 100, expected: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := u.retryDelay(tt.attempts); got != tt.expected {
+			t.Errorf("retryDelay(%d) = %s, want %s", tt.attempts, got, tt.expected)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -20,6 +22,9 @@ type Config struct {
 	// JWT
 	JWTSecret string
 
+	// Invite
+	InviteSecret string
+
 	// CORS
 	AllowedOrigins string
 
@@ -30,6 +35,54 @@ type Config struct {
 	GCSBucketName              string
 	GoogleApplicationCredentials string
 
+	// オブジェクトストレージ（local / s3 / gcs。未指定ならGCSの設定があればgcs、なければlocal）
+	StorageBackend       string
+	StorageLocalDir      string
+	StoragePublicURL     string // ローカルストレージの署名付きURLのベース（このAPIサーバーの公開URL）
+	StorageSigningSecret string
+
+	// S3互換ストレージ（AWS S3・MinIOなど）
+	S3Endpoint        string
+	S3Region          string
+	S3Bucket          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+	S3UsePathStyle    bool
+
+	// 文字起こし（google / whisper / none。未指定ならGoogleの認証情報があればgoogle、
+	// WHISPER_MODELがあればwhisper、どちらもなければnone）
+	TranscriptionProvider string
+
+	// whisper.cpp（オフラインの文字起こし）
+	WhisperBinary      string
+	WhisperModel       string
+	WhisperThreads     int
+	WhisperTinyDiarize bool
+	FFmpegBinary       string
+
+	// 文字起こしジョブ
+	TranscriptionWorkers      int
+	TranscriptionPollInterval time.Duration
+	TranscriptionMaxAttempts  int
+	TranscriptionRetryBase    time.Duration
+	TranscriptionRetryMax     time.Duration
+	TranscriptionLease        time.Duration
+	TranscriptionJobTimeout   time.Duration
+
+	// 再開可能な録音アップロード
+	RecordingUploadMaxSize   int64
+	RecordingUploadExpiry    time.Duration
+	RecordingUploadURLExpiry time.Duration // 直接アップロードの署名付きURLの有効期間
+	RecordingUploadCleanup   time.Duration // 期限切れのアップロードを削除する間隔
+
+	// 録音のミックス（ffmpegを使用）
+	RecordingMixEnabled      bool
+	RecordingMixWorkers      int
+	RecordingMixPollInterval time.Duration
+	RecordingMixTimeout      time.Duration
+	RecordingMixBitrate      string // 出力するOpusのビットレート
+	RecordingMixLoudness     int    // 正規化の目標ラウドネス（LUFS）
+
 	// SMTP
 	SMTPHost     string
 	SMTPPort     string
@@ -40,6 +93,37 @@ type Config struct {
 	// Frontend
 	FrontendURL string
 
+	// Room reaper (0で無効)
+	RoomReaperInterval   time.Duration
+	RoomWaitingTTL       time.Duration
+	RoomIdleTTL          time.Duration
+	RoomStaleTTL         time.Duration
+	RoomReaperTranscribe bool
+
+	// Webhook
+	WebhookDeliveryInterval time.Duration
+	WebhookTimeout          time.Duration
+	WebhookMaxAttempts      int
+	WebhookRetryBase        time.Duration
+	WebhookRetryMax         time.Duration
+	// localhostや内部向けのアドレスへの送信を許可する（ローカル開発用）
+	WebhookAllowPrivateNetworks bool
+
+	// ロビー
+	LobbyResyncInterval time.Duration
+
+	// 1対1通話
+	DirectCallRingTimeout    time.Duration
+	DirectCallExpiryInterval time.Duration
+
+	// 共有ノートのスナップショットを保存する間隔（0で通話終了時のみ保存）
+	NotesSnapshotInterval time.Duration
+
+	// Web Push（VAPID_PRIVATE_KEY が未設定の場合は送信しない）
+	VAPIDPrivateKey string
+	VAPIDSubject    string
+	PushTimeout     time.Duration
+
 	// Logging
 	LogLevel string
 }
@@ -56,6 +140,7 @@ func Load() (*Config, error) {
 		Env:                        getEnv("ENV", "development"),
 		DBDSN:                      getEnv("DB_DSN", "root:password@tcp(localhost:3306)/Go-Next-WebRTC?parseTime=true"),
 		JWTSecret:                  os.Getenv("JWT_SECRET"),
+		InviteSecret:               os.Getenv("INVITE_SECRET"),
 		AllowedOrigins:             getEnv("ALLOWED_ORIGINS", "http://localhost:3000"),
 		MaxRequestBodySize:         getEnv("MAX_REQUEST_BODY_SIZE", "10485760"),
 		GCSBucketName:              os.Getenv("GCS_BUCKET_NAME"),
@@ -67,6 +152,57 @@ func Load() (*Config, error) {
 		SMTPFromName:               os.Getenv("SMTP_FROM_NAME"),
 		FrontendURL:                getEnv("FRONTEND_URL", "http://localhost:3000"),
 		LogLevel:                   getEnv("LOG_LEVEL", "info"),
+		RoomReaperInterval:         getEnvDuration("ROOM_REAPER_INTERVAL", time.Minute),
+		RoomWaitingTTL:             getEnvDuration("ROOM_WAITING_TTL", 24*time.Hour),
+		RoomIdleTTL:                getEnvDuration("ROOM_IDLE_TTL", 10*time.Minute),
+		RoomStaleTTL:               getEnvDuration("ROOM_STALE_TTL", 12*time.Hour),
+		RoomReaperTranscribe:       getEnvBool("ROOM_REAPER_TRANSCRIBE", false),
+		WebhookDeliveryInterval:    getEnvDuration("WEBHOOK_DELIVERY_INTERVAL", 5*time.Second),
+		WebhookTimeout:             getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookMaxAttempts:         getEnvInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookRetryBase:           getEnvDuration("WEBHOOK_RETRY_BASE", 30*time.Second),
+		WebhookRetryMax:            getEnvDuration("WEBHOOK_RETRY_MAX", 6*time.Hour),
+		WebhookAllowPrivateNetworks: getEnvBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		LobbyResyncInterval:        getEnvDuration("LOBBY_RESYNC_INTERVAL", time.Minute),
+		DirectCallRingTimeout:      getEnvDuration("DIRECT_CALL_RING_TIMEOUT", 30*time.Second),
+		DirectCallExpiryInterval:   getEnvDuration("DIRECT_CALL_EXPIRY_INTERVAL", 5*time.Second),
+		VAPIDPrivateKey:            os.Getenv("VAPID_PRIVATE_KEY"),
+		VAPIDSubject:               getEnv("VAPID_SUBJECT", "mailto:admin@localhost"),
+		PushTimeout:                getEnvDuration("PUSH_TIMEOUT", 5*time.Second),
+		NotesSnapshotInterval:      getEnvDuration("NOTES_SNAPSHOT_INTERVAL", 30*time.Second),
+		StorageBackend:             os.Getenv("STORAGE_BACKEND"),
+		StorageLocalDir:            getEnv("STORAGE_LOCAL_DIR", "./data/storage"),
+		StoragePublicURL:           getEnv("STORAGE_PUBLIC_URL", "http://localhost:8080"),
+		StorageSigningSecret:       os.Getenv("STORAGE_SIGNING_SECRET"),
+		S3Endpoint:                 os.Getenv("S3_ENDPOINT"),
+		S3Region:                   getEnv("S3_REGION", "us-east-1"),
+		S3Bucket:                   os.Getenv("S3_BUCKET"),
+		S3AccessKeyID:              os.Getenv("S3_ACCESS_KEY_ID"),
+		S3SecretAccessKey:          os.Getenv("S3_SECRET_ACCESS_KEY"),
+		S3UsePathStyle:             getEnvBool("S3_USE_PATH_STYLE", true),
+		TranscriptionProvider:      os.Getenv("TRANSCRIPTION_PROVIDER"),
+		WhisperBinary:              getEnv("WHISPER_BINARY", "whisper-cli"),
+		WhisperModel:               os.Getenv("WHISPER_MODEL"),
+		WhisperThreads:             getEnvInt("WHISPER_THREADS", 0),
+		WhisperTinyDiarize:         getEnvBool("WHISPER_TINYDIARIZE", false),
+		FFmpegBinary:               getEnv("FFMPEG_BINARY", "ffmpeg"),
+		TranscriptionWorkers:       getEnvInt("TRANSCRIPTION_WORKERS", 2),
+		TranscriptionPollInterval:  getEnvDuration("TRANSCRIPTION_POLL_INTERVAL", 5*time.Second),
+		TranscriptionMaxAttempts:   getEnvInt("TRANSCRIPTION_MAX_ATTEMPTS", 3),
+		TranscriptionRetryBase:     getEnvDuration("TRANSCRIPTION_RETRY_BASE", time.Minute),
+		TranscriptionRetryMax:      getEnvDuration("TRANSCRIPTION_RETRY_MAX", 30*time.Minute),
+		TranscriptionLease:         getEnvDuration("TRANSCRIPTION_LEASE", 2*time.Minute),
+		TranscriptionJobTimeout:    getEnvDuration("TRANSCRIPTION_JOB_TIMEOUT", time.Hour),
+		RecordingUploadMaxSize:     int64(getEnvInt("RECORDING_UPLOAD_MAX_SIZE", 1<<30)),
+		RecordingUploadExpiry:      getEnvDuration("RECORDING_UPLOAD_EXPIRY", 24*time.Hour),
+		RecordingUploadURLExpiry:   getEnvDuration("RECORDING_UPLOAD_URL_EXPIRY", time.Hour),
+		RecordingUploadCleanup:     getEnvDuration("RECORDING_UPLOAD_CLEANUP_INTERVAL", 15*time.Minute),
+		RecordingMixEnabled:        getEnvBool("RECORDING_MIX_ENABLED", true),
+		RecordingMixWorkers:        getEnvInt("RECORDING_MIX_WORKERS", 1),
+		RecordingMixPollInterval:   getEnvDuration("RECORDING_MIX_POLL_INTERVAL", 10*time.Second),
+		RecordingMixTimeout:        getEnvDuration("RECORDING_MIX_TIMEOUT", 30*time.Minute),
+		RecordingMixBitrate:        getEnv("RECORDING_MIX_BITRATE", "64k"),
+		RecordingMixLoudness:       getEnvInt("RECORDING_MIX_LOUDNESS", -16),
 	}
 
 	// 設定の検証
@@ -74,6 +210,30 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	// 招待トークンの署名鍵は未指定ならJWT_SECRETから派生させる
+	if cfg.InviteSecret == "" {
+		cfg.InviteSecret = cfg.JWTSecret
+	}
+	if cfg.StorageSigningSecret == "" {
+		cfg.StorageSigningSecret = cfg.JWTSecret
+	}
+	if cfg.TranscriptionProvider == "" {
+		switch {
+		case cfg.GoogleApplicationCredentials != "":
+			cfg.TranscriptionProvider = "google"
+		case cfg.WhisperModel != "":
+			cfg.TranscriptionProvider = "whisper"
+		default:
+			cfg.TranscriptionProvider = "none"
+		}
+	}
+	if cfg.StorageBackend == "" {
+		cfg.StorageBackend = "local"
+		if cfg.GCSBucketName != "" && cfg.GoogleApplicationCredentials != "" {
+			cfg.StorageBackend = "gcs"
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -95,6 +255,30 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("JWT_SECRET must be at least 32 characters long")
 	}
 
+	switch c.StorageBackend {
+	case "", "local", "gcs":
+	case "s3":
+		if c.S3Endpoint == "" || c.S3Bucket == "" || c.S3AccessKeyID == "" || c.S3SecretAccessKey == "" {
+			return fmt.Errorf("S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY are required for STORAGE_BACKEND=s3")
+		}
+	default:
+		return fmt.Errorf("unknown STORAGE_BACKEND %q (expected local, s3 or gcs)", c.StorageBackend)
+	}
+
+	switch c.TranscriptionProvider {
+	case "", "none", "google":
+	case "whisper":
+		if c.WhisperModel == "" {
+			return fmt.Errorf("WHISPER_MODEL is required for TRANSCRIPTION_PROVIDER=whisper")
+		}
+	default:
+		return fmt.Errorf("unknown TRANSCRIPTION_PROVIDER %q (expected google, whisper or none)", c.TranscriptionProvider)
+	}
+	// リースの延長（期間の1/3ごと）が間に合わないほど短いと、実行中のジョブを他のワーカーが引き継いでしまう
+	if c.TranscriptionLease < 30*time.Second {
+		return fmt.Errorf("TRANSCRIPTION_LEASE must be at least 30s")
+	}
+
 	return nil
 }
 
@@ -104,3 +288,45 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getEnvDuration 環境変数をtime.Durationとして取得（"10m"などの形式）
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s: %s (using default %s)", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+// getEnvInt 環境変数をintとして取得
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid int for %s: %s (using default %d)", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
+
+// getEnvBool 環境変数をboolとして取得
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid bool for %s: %s (using default %t)", key, value, defaultValue)
+		return defaultValue
+	}
+	return b
+}
@@ -12,8 +12,33 @@ type CallRoom struct {
 	StartedAt       *time.Time
 	EndedAt         *time.Time
 	MaxParticipants int
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
+	Visibility      RoomVisibility
+	PasscodeHash    *string    // bcryptでハッシュ化したパスコード
+	SeriesID        *int64     // 定例会議シリーズから生成された場合のシリーズID
+	OccurrenceStart *time.Time // シリーズ上の本来の開始日時 (RECURRENCE-ID)
+	ScheduledAt     *time.Time // 開始予定日時
+	PersonalRoomID  *int64     // 個人ルームのセッションの場合の個人ルームID
+	SessionNumber   *int       // 個人ルーム内のセッション番号（1から連番）
+	// 文字起こしの言語（BCP-47。auto の場合は自動判定）と他に話される可能性のある言語
+	TranscriptionLanguage             string
+	TranscriptionAlternativeLanguages []string
+	CreatedAt                         time.Time
+	UpdatedAt                         time.Time
+}
+
+// Duration 通話時間（開始前は0、通話中の場合はnowまで）
+func (r *CallRoom) Duration(now time.Time) time.Duration {
+	if r.StartedAt == nil {
+		return 0
+	}
+	end := now
+	if r.EndedAt != nil {
+		end = *r.EndedAt
+	}
+	if end.Before(*r.StartedAt) {
+		return 0
+	}
+	return end.Sub(*r.StartedAt)
 }
 
 // CallRoomStatus 通話ルームの状態
@@ -29,7 +54,10 @@ const (
 type CallParticipant struct {
 	ID        int64
 	RoomID    int64
-	UserID    int64
+	UserID    int64   // ゲストの場合は0
+	GuestID   *string // 招待リンクから参加したゲストの識別子
+	GuestName *string // ゲストの表示名
+	Role      ParticipantRole
 	JoinedAt  time.Time
 	LeftAt    *time.Time
 	IsActive  bool
@@ -37,6 +65,11 @@ type CallParticipant struct {
 	UpdatedAt time.Time
 }
 
+// IsGuest アカウントを持たないゲスト参加者か
+func (p *CallParticipant) IsGuest() bool {
+	return p.GuestID != nil
+}
+
 // CallRecording 録音ファイル
 type CallRecording struct {
 	ID              int64
@@ -46,22 +79,39 @@ type CallRecording struct {
 	FileSize        int64
 	DurationSeconds *int
 	Format          string
+	StartedAt       *time.Time // 録音を開始した時刻（クライアントから送られた場合のみ）
 	UploadedAt      time.Time
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
 }
 
+// StartTime 録音を開始した時刻
+// 送られていない場合は、録音の終了直後にアップロードしたものとして録音時間から推定する
+func (r *CallRecording) StartTime() time.Time {
+	if r.StartedAt != nil {
+		return *r.StartedAt
+	}
+	if r.DurationSeconds != nil {
+		return r.UploadedAt.Add(-time.Duration(*r.DurationSeconds) * time.Second)
+	}
+	return r.UploadedAt
+}
+
 // CallTranscription 文字起こし
 type CallTranscription struct {
 	ID          int64
 	RoomID      int64
+	RunID       *int64 // 文字起こしの実行
 	RecordingID *int64
-	SpeakerTag  *int
+	UserID      *int64 // 話者（録音した参加者。録音が削除された古い文字起こしではnil）
+	SpeakerTag  *int   // 文字起こしエンジンが識別した話者番号（参加者ごとの録音では識別しない）
 	Text        string
 	Confidence  *float64
-	StartTime   *float64
-	EndTime     *float64
-	Language    string
+	StartTime   *float64   // 録音の先頭からの秒数
+	EndTime     *float64   // 録音の先頭からの秒数
+	StartedAt   *time.Time // 話し始めた時刻（録音の開始時刻 + StartTime。古い文字起こしではnil）
+	EndedAt     *time.Time // 話し終えた時刻
+	Language    string     // 話された言語（BCP-47。判定できなかった場合は und）
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
@@ -74,6 +124,10 @@ type CallMinutes struct {
 	Summary          *string
 	FullTranscript   *string
 	ParticipantsList *string
+	PollResults      *string  // 通話中に締め切った投票の結果（整形済み）
+	Notes            *string  // 通話終了時点の共有ノート
+	Languages        []string // 文字起こしで話された言語（多い順）
+	RunID            *int64   // 作成に使った文字起こしの実行（ルームの現在の実行）
 	EmailSent        bool
 	EmailSentAt      *time.Time
 	CreatedAt        time.Time
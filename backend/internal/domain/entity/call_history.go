@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// 通話履歴関連のエラー
+var (
+	ErrInvalidHistoryStatus = errors.New("invalid history status")
+	ErrInvalidHistoryScope  = errors.New("invalid history scope")
+	ErrInvalidHistoryRange  = errors.New("invalid history range")
+)
+
+// CallHistoryScope 通話履歴の対象範囲
+type CallHistoryScope string
+
+const (
+	CallHistoryScopeAll     CallHistoryScope = "all"     // 作成または参加した通話
+	CallHistoryScopeCreated CallHistoryScope = "created" // 作成した通話
+	CallHistoryScopeJoined  CallHistoryScope = "joined"  // 参加した通話
+)
+
+// IsValid 有効な対象範囲か
+func (s CallHistoryScope) IsValid() bool {
+	switch s {
+	case CallHistoryScopeAll, CallHistoryScopeCreated, CallHistoryScopeJoined:
+		return true
+	}
+	return false
+}
+
+// CallHistoryFilter 通話履歴の検索条件
+type CallHistoryFilter struct {
+	UserID int64
+	Scope  CallHistoryScope
+	Status CallRoomStatus // 空の場合は全ステータス
+	From   *time.Time     // 開始日時（未開始の場合は作成日時）の下限
+	To     *time.Time     // 開始日時（未開始の場合は作成日時）の上限（含まない）
+	Query  string         // ルーム名の部分一致
+	Limit  int
+	Offset int
+}
+
+// Validate 検索条件を検証
+func (f *CallHistoryFilter) Validate() error {
+	if f.Scope == "" {
+		f.Scope = CallHistoryScopeAll
+	}
+	if !f.Scope.IsValid() {
+		return ErrInvalidHistoryScope
+	}
+	switch f.Status {
+	case "", CallRoomStatusWaiting, CallRoomStatusActive, CallRoomStatusEnded:
+	default:
+		return ErrInvalidHistoryStatus
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return ErrInvalidHistoryRange
+	}
+	return nil
+}
+
+// CallHistoryPage 通話履歴の1ページ
+type CallHistoryPage struct {
+	Entries []*CallHistoryEntry
+	Total   int
+}
+
+// CallHistoryEntry 通話履歴の1件
+type CallHistoryEntry struct {
+	Room          *CallRoom
+	Participants  []*CallHistoryParticipant
+	HasRecordings bool
+	HasMinutes    bool
+}
+
+// CallHistoryParticipant 通話履歴の参加者（再参加を含む参加区間をまとめたもの）
+type CallHistoryParticipant struct {
+	UserID    int64
+	GuestID   *string
+	Name      string
+	Intervals []ParticipationInterval
+}
+
+// ParticipationInterval 参加区間
+type ParticipationInterval struct {
+	JoinedAt time.Time
+	LeftAt   *time.Time // 参加中の場合はnil
+}
+
+// Duration 参加時間（参加中の場合はnowまで）
+func (i ParticipationInterval) Duration(now time.Time) time.Duration {
+	end := now
+	if i.LeftAt != nil {
+		end = *i.LeftAt
+	}
+	if end.Before(i.JoinedAt) {
+		return 0
+	}
+	return end.Sub(i.JoinedAt)
+}
+
+// TotalDuration 全参加区間の合計時間
+func (p *CallHistoryParticipant) TotalDuration(now time.Time) time.Duration {
+	var total time.Duration
+	for _, interval := range p.Intervals {
+		total += interval.Duration(now)
+	}
+	return total
+}
@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrCallNotesNotFound 共有ノートが存在しない
+var ErrCallNotesNotFound = errors.New("call notes not found")
+
+// CallNotes 通話ルームごとの共有ノート
+type CallNotes struct {
+	RoomID    int64
+	Content   string // 表示されるテキスト
+	State     []byte // CRDTの要素列（削除済みを含む、JSON）。編集の再開に使う
+	Version   int64  // スナップショットを保存するたびに増える
+	UpdatedAt time.Time
+}
@@ -0,0 +1,91 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// 定例会議シリーズ関連のエラー
+var (
+	ErrSeriesNotFound     = errors.New("series not found")
+	ErrSeriesAccessDenied = errors.New("series access denied")
+	ErrInvalidRecurrence  = errors.New("invalid recurrence rule")
+	ErrInvalidTimezone    = errors.New("invalid timezone")
+	ErrInvalidDuration    = errors.New("duration must be positive")
+	ErrOccurrenceNotFound = errors.New("occurrence not found")
+	ErrInvalidEditScope   = errors.New("invalid edit scope")
+)
+
+// CallSeries 定例会議シリーズ (RFC 5545 RRULE で定義)
+type CallSeries struct {
+	ID              int64
+	SeriesID        string
+	Name            string
+	CreatedBy       int64
+	RRule           string
+	StartAt         time.Time // 初回の開始日時 (DTSTART)
+	DurationMinutes int
+	Timezone        string
+	MaxParticipants int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Location シリーズのタイムゾーンを返す
+func (s *CallSeries) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// Duration 1回あたりの会議時間を返す
+func (s *CallSeries) Duration() time.Duration {
+	return time.Duration(s.DurationMinutes) * time.Minute
+}
+
+// CallSeriesException シリーズの個別回に対する例外 (EXDATE / 個別上書き)
+type CallSeriesException struct {
+	ID              int64
+	SeriesID        int64
+	OriginalStart   time.Time // 上書き対象の本来の開始日時 (RECURRENCE-ID)
+	Cancelled       bool      // true の場合は EXDATE として扱う
+	StartAt         *time.Time
+	DurationMinutes *int
+	Name            *string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// CallOccurrence シリーズを展開した1回分の会議
+type CallOccurrence struct {
+	SeriesID      int64
+	OriginalStart time.Time
+	StartAt       time.Time
+	EndAt         time.Time
+	Name          string
+	Cancelled     bool
+	Room          *CallRoom
+}
+
+// SeriesEditScope シリーズ編集の適用範囲
+type SeriesEditScope string
+
+const (
+	SeriesEditScopeThis      SeriesEditScope = "this"
+	SeriesEditScopeFollowing SeriesEditScope = "following"
+	SeriesEditScopeAll       SeriesEditScope = "all"
+)
+
+// IsValid 適用範囲が有効かチェック
+func (s SeriesEditScope) IsValid() bool {
+	switch s {
+	case SeriesEditScopeThis, SeriesEditScopeFollowing, SeriesEditScopeAll:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// 1対1通話関連のエラー
+var (
+	ErrDirectCallNotFound   = errors.New("direct call not found")
+	ErrCannotCallSelf       = errors.New("cannot call yourself")
+	ErrDirectCallNotRinging = errors.New("direct call is no longer ringing")
+)
+
+// DirectCallStatus 1対1通話の状態
+type DirectCallStatus string
+
+const (
+	DirectCallStatusRinging  DirectCallStatus = "ringing"  // 着信中
+	DirectCallStatusAccepted DirectCallStatus = "accepted" // 応答済み
+	DirectCallStatusDeclined DirectCallStatus = "declined" // 拒否
+	DirectCallStatusMissed   DirectCallStatus = "missed"   // 不在着信（タイムアウトまたは発信者の取り消し）
+)
+
+// DirectCall ユーザーを指定した1対1通話
+// 通話自体は発信時に作成する非公開の通話ルームで行う
+type DirectCall struct {
+	ID            int64
+	CallID        string
+	CallerID      int64
+	CalleeID      int64
+	RoomID        int64
+	Status        DirectCallStatus
+	RingExpiresAt time.Time // この時刻までに応答がなければ不在着信
+	AnsweredAt    *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// IsRinging 指定時刻に着信中か
+func (c *DirectCall) IsRinging(now time.Time) bool {
+	return c.Status == DirectCallStatusRinging && now.Before(c.RingExpiresAt)
+}
+
+// MissedCall 不在着信の履歴
+type MissedCall struct {
+	Call       *DirectCall
+	Room       *CallRoom
+	CallerName string
+}
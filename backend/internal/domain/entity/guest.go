@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// ゲスト招待関連のエラー
+var (
+	ErrInvalidInvite      = errors.New("invalid invite")
+	ErrInviteExpired      = errors.New("invite expired")
+	ErrInvalidDisplayName = errors.New("display name must be 1-50 characters")
+	ErrRoomEnded          = errors.New("room has ended")
+	ErrNotRoomMember      = errors.New("not a member of this room")
+	ErrCannotInviteSelf   = errors.New("cannot invite yourself")
+)
+
+// CallInvite 通話ルームへの招待リンク
+type CallInvite struct {
+	RoomID    string
+	Token     string
+	URL       string
+	CreatedBy int64
+	ExpiresAt time.Time
+}
+
+// GuestPrincipal 招待リンクから参加したゲストの認証情報
+// 特定の1ルームに対してのみ有効
+type GuestPrincipal struct {
+	GuestID     string
+	RoomID      string
+	DisplayName string
+	ExpiresAt   time.Time
+}
+
+// GuestSession 招待の受諾結果
+type GuestSession struct {
+	Guest       *GuestPrincipal
+	AccessToken string
+}
@@ -0,0 +1,24 @@
+package entity
+
+// LobbyRoom ロビーに表示するルームと参加人数
+type LobbyRoom struct {
+	Room             *CallRoom
+	ParticipantCount int
+}
+
+// LobbyEventType ロビーへ配信するイベントの種類
+type LobbyEventType string
+
+const (
+	LobbyEventRoomCreated      LobbyEventType = "room-created"      // ルームが一覧に追加された
+	LobbyEventRoomUpdated      LobbyEventType = "room-updated"      // ルームの状態・設定が変わった
+	LobbyEventParticipantCount LobbyEventType = "participant-count" // 参加人数が変わった
+	LobbyEventRoomEnded        LobbyEventType = "room-ended"        // ルームが一覧から外れた
+)
+
+// LobbyEvent ロビーへ配信するイベント
+// room-endedの場合、Roomは一覧から外れた時点の状態
+type LobbyEvent struct {
+	Type LobbyEventType
+	Room LobbyRoom
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// 通知関連のエラー
+var (
+	ErrNotificationNotFound = errors.New("notification not found")
+)
+
+// NotificationType 通知の種類
+type NotificationType string
+
+const (
+	NotificationCallInvite     NotificationType = "call-invite"      // 通話ルームへの招待
+	NotificationMissedCall     NotificationType = "missed-call"      // 不在着信
+	NotificationMinutesReady   NotificationType = "minutes-ready"    // 議事録の作成完了
+	NotificationNewDeviceLogin NotificationType = "new-device-login" // 新しい端末からのログイン
+	NotificationTodoAssigned   NotificationType = "todo-assigned"    // Todoの担当者への割り当て
+)
+
+// Notification ユーザーへの通知
+type Notification struct {
+	ID        int64
+	UserID    int64
+	Type      NotificationType
+	Title     string
+	Body      string
+	Data      map[string]interface{} // 種類ごとの付加情報（ルームIDなど）
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+// IsRead 既読か
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// NotificationFilter 通知一覧の取得条件
+type NotificationFilter struct {
+	UserID     int64
+	UnreadOnly bool
+	BeforeID   int64 // 0より大きい場合はこのIDより古い通知のみ（ページング用）
+	Limit      int
+}
@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 個人ルーム関連のエラー
+var (
+	ErrPersonalRoomNotFound = errors.New("personal room not found")
+	ErrInvalidSlug          = errors.New("slug must be 3-32 characters of lowercase letters, digits and hyphens")
+	ErrSlugTaken            = errors.New("slug is already taken")
+)
+
+// slugPattern 個人ルームのURLに使える文字列（先頭と末尾は英数字）
+var slugPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{1,30}[a-z0-9]$`)
+
+// PersonalRoom ユーザーごとの固定の会議室（/r/{slug} で開く）
+// 開くたびにセッションとして通話ルームを作成するため、録音・議事録はセッションごとに分かれる
+type PersonalRoom struct {
+	ID              int64
+	UserID          int64
+	Slug            string
+	Name            string
+	MaxParticipants int
+	// 開いたセッションに適用するアクセス設定
+	Visibility          RoomVisibility
+	PasscodeHash        *string // bcryptでハッシュ化したパスコード
+	AllowedUserIDs      []int64
+	AllowedEmailDomains []string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// SetAccess アクセス設定を反映（許可リストは置き換え、パスコードはnilの場合は変更しない）
+// 検証に失敗した場合は何も変更しない
+func (r *PersonalRoom) SetAccess(settings *RoomAccessSettings) error {
+	visibility := r.Visibility
+	if settings.Visibility != "" {
+		if !settings.Visibility.IsValid() {
+			return ErrInvalidVisibility
+		}
+		visibility = settings.Visibility
+	}
+	rules, err := settings.Rules(r.ID)
+	if err != nil {
+		return err
+	}
+	passcodeHash := r.PasscodeHash
+	if settings.Passcode != nil {
+		if passcodeHash, err = hashPasscode(*settings.Passcode); err != nil {
+			return err
+		}
+	}
+
+	r.Visibility = visibility
+	r.PasscodeHash = passcodeHash
+	r.AllowedUserIDs = nil
+	r.AllowedEmailDomains = nil
+	for _, rule := range rules {
+		if rule.UserID != nil {
+			r.AllowedUserIDs = append(r.AllowedUserIDs, *rule.UserID)
+		}
+		if rule.EmailDomain != nil {
+			r.AllowedEmailDomains = append(r.AllowedEmailDomains, *rule.EmailDomain)
+		}
+	}
+	return nil
+}
+
+// HasPasscode セッションにパスコードを設定するか
+func (r *PersonalRoom) HasPasscode() bool {
+	return r.PasscodeHash != nil
+}
+
+// ApplyAccessTo セッションに公開範囲とパスコードを設定し、許可リストのアクセス設定を返す
+func (r *PersonalRoom) ApplyAccessTo(session *CallRoom) *RoomAccessSettings {
+	session.Visibility = r.Visibility
+	session.PasscodeHash = r.PasscodeHash
+	return &RoomAccessSettings{
+		Visibility:          r.Visibility,
+		AllowedUserIDs:      r.AllowedUserIDs,
+		AllowedEmailDomains: r.AllowedEmailDomains,
+	}
+}
+
+// ValidateSlug スラッグの形式を検証
+func ValidateSlug(slug string) error {
+	if !slugPattern.MatchString(slug) || strings.Contains(slug, "--") {
+		return ErrInvalidSlug
+	}
+	return nil
+}
+
+// SuggestSlug メールアドレス（のローカル部）からスラッグの候補を作成
+// 使える文字に揃えられない場合はユーザーIDから作る
+func SuggestSlug(email string, userID int64) string {
+	local, _, _ := strings.Cut(strings.ToLower(email), "@")
+
+	var b strings.Builder
+	hyphen := false
+	for _, c := range local {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+			hyphen = false
+		} else if !hyphen && b.Len() > 0 {
+			b.WriteByte('-')
+			hyphen = true
+		}
+	}
+	slug := strings.TrimRight(b.String(), "-")
+	if len(slug) > 28 {
+		// 重複時の連番（-2 など）を付けられるよう余裕を残す
+		slug = strings.TrimRight(slug[:28], "-")
+	}
+	if ValidateSlug(slug) != nil {
+		return "room-" + strconv.FormatInt(userID, 10)
+	}
+	return slug
+}
@@ -0,0 +1,183 @@
+package entity
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// 投票関連のエラー
+var (
+	ErrPollNotFound    = errors.New("poll not found")
+	ErrPollClosed      = errors.New("poll is closed")
+	ErrInvalidPoll     = errors.New("poll requires a question (1-200 characters) and 2-10 distinct options (1-100 characters)")
+	ErrInvalidPollVote = errors.New("vote must select options of this poll (exactly one for single-choice polls)")
+)
+
+// 投票の入力制限
+const (
+	MaxPollQuestionLength = 200
+	MaxPollOptionLength   = 100
+	MinPollOptions        = 2
+	MaxPollOptions        = 10
+)
+
+// PollStatus 投票の状態
+type PollStatus string
+
+const (
+	PollStatusOpen   PollStatus = "open"
+	PollStatusClosed PollStatus = "closed"
+)
+
+// Poll 通話中の投票
+type Poll struct {
+	ID             int64
+	RoomID         int64
+	Question       string
+	Options        []*PollOption
+	MultipleChoice bool  // 複数の選択肢に投票できる
+	Anonymous      bool  // 誰がどの選択肢に投票したかを公開しない
+	CreatedBy      int64 // 作成したユーザーID（ゲストの場合は0）
+	Status         PollStatus
+	ClosedAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// PollOption 投票の選択肢
+type PollOption struct {
+	ID        int64
+	PollID    int64
+	Position  int
+	Text      string
+	VoteCount int // 締め切り時の最終得票数（投票中は0）
+}
+
+// PollVote 参加者の投票（複数選択の場合は選択肢ごとに1件）
+type PollVote struct {
+	ID        int64
+	PollID    int64
+	OptionID  int64
+	VoterKey  string // 参加者の識別子（user-{id} / guest-{id}）。1人1回の判定に使う
+	UserID    int64  // ゲストの場合は0
+	VoterName string
+	CreatedAt time.Time
+}
+
+// PollResults 投票の集計結果
+type PollResults struct {
+	PollID         int64
+	Question       string
+	MultipleChoice bool
+	Anonymous      bool
+	Status         PollStatus
+	TotalVoters    int
+	Options        []*PollOptionResult
+	MyChoices      []int64 // 結果を取得した参加者自身が選んだ選択肢
+	ClosedAt       *time.Time
+	CreatedAt      time.Time
+}
+
+// PollOptionResult 選択肢ごとの集計結果
+type PollOptionResult struct {
+	OptionID int64
+	Text     string
+	Votes    int
+	Voters   []string // 投票者の表示名（匿名投票の場合はnil）
+}
+
+// PollVoterKey 参加記録に対応する投票者の識別子（再入室しても同じ値になる）
+func PollVoterKey(p *CallParticipant) string {
+	if p.IsGuest() {
+		return "guest-" + *p.GuestID
+	}
+	return "user-" + strconv.FormatInt(p.UserID, 10)
+}
+
+// IsOpen 投票を受け付けているか
+func (p *Poll) IsOpen() bool {
+	return p.Status == PollStatusOpen
+}
+
+// Validate 質問と選択肢を整形して検証し、選択肢に表示順を設定
+func (p *Poll) Validate() error {
+	p.Question = strings.TrimSpace(p.Question)
+	if n := utf8.RuneCountInString(p.Question); n == 0 || n > MaxPollQuestionLength {
+		return ErrInvalidPoll
+	}
+	if len(p.Options) < MinPollOptions || len(p.Options) > MaxPollOptions {
+		return ErrInvalidPoll
+	}
+
+	seen := make(map[string]bool, len(p.Options))
+	for i, option := range p.Options {
+		option.Text = strings.TrimSpace(option.Text)
+		if n := utf8.RuneCountInString(option.Text); n == 0 || n > MaxPollOptionLength || seen[option.Text] {
+			return ErrInvalidPoll
+		}
+		seen[option.Text] = true
+		option.Position = i
+	}
+	return nil
+}
+
+// ValidateChoices 投票で選んだ選択肢を検証（重複は1つにまとめる）
+func (p *Poll) ValidateChoices(optionIDs []int64) ([]int64, error) {
+	valid := make(map[int64]bool, len(p.Options))
+	for _, option := range p.Options {
+		valid[option.ID] = true
+	}
+
+	choices := make([]int64, 0, len(optionIDs))
+	seen := make(map[int64]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if !valid[id] {
+			return nil, ErrInvalidPollVote
+		}
+		if !seen[id] {
+			seen[id] = true
+			choices = append(choices, id)
+		}
+	}
+	if len(choices) == 0 || (!p.MultipleChoice && len(choices) > 1) {
+		return nil, ErrInvalidPollVote
+	}
+	return choices, nil
+}
+
+// Tally 投票を選択肢ごとに集計
+func (p *Poll) Tally(votes []*PollVote) *PollResults {
+	results := &PollResults{
+		PollID:         p.ID,
+		Question:       p.Question,
+		MultipleChoice: p.MultipleChoice,
+		Anonymous:      p.Anonymous,
+		Status:         p.Status,
+		Options:        make([]*PollOptionResult, len(p.Options)),
+		ClosedAt:       p.ClosedAt,
+		CreatedAt:      p.CreatedAt,
+	}
+	byOption := make(map[int64]*PollOptionResult, len(p.Options))
+	for i, option := range p.Options {
+		results.Options[i] = &PollOptionResult{OptionID: option.ID, Text: option.Text}
+		byOption[option.ID] = results.Options[i]
+	}
+
+	voters := make(map[string]bool)
+	for _, vote := range votes {
+		option := byOption[vote.OptionID]
+		if option == nil {
+			continue
+		}
+		option.Votes++
+		if !p.Anonymous {
+			option.Voters = append(option.Voters, vote.VoterName)
+		}
+		voters[vote.VoterKey] = true
+	}
+	results.TotalVoters = len(voters)
+	return results
+}
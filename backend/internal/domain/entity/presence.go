@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// プレゼンス関連のエラー
+var (
+	ErrInvalidPresenceStatus = errors.New("status must be one of online, away, dnd")
+	ErrUserDoNotDisturb      = errors.New("user does not want to be disturbed")
+)
+
+// PresenceStatus ユーザーの在席状態
+type PresenceStatus string
+
+const (
+	PresenceOffline      PresenceStatus = "offline" // 接続している端末がない
+	PresenceOnline       PresenceStatus = "online"  // オンライン
+	PresenceAway         PresenceStatus = "away"    // 離席中
+	PresenceInCall       PresenceStatus = "in-call" // 通話中
+	PresenceDoNotDisturb PresenceStatus = "dnd"     // 取り込み中（着信・招待を受けない）
+)
+
+// IsSettable ユーザーが自分で設定できる状態か（オフライン・通話中は接続状況から決まる）
+func (s PresenceStatus) IsSettable() bool {
+	switch s {
+	case PresenceOnline, PresenceAway, PresenceDoNotDisturb:
+		return true
+	default:
+		return false
+	}
+}
+
+// UserPresence ユーザーが設定した在席状態と最終接続日時
+type UserPresence struct {
+	UserID     int64
+	Status     PresenceStatus // ユーザーが設定した状態（online/away/dnd）
+	LastSeenAt *time.Time
+	UpdatedAt  time.Time
+}
+
+// IsDoNotDisturb 取り込み中に設定されているか
+func (p *UserPresence) IsDoNotDisturb() bool {
+	return p != nil && p.Status == PresenceDoNotDisturb
+}
+
+// Presence 他のユーザーに見える在席状態
+type Presence struct {
+	UserID     int64
+	Status     PresenceStatus
+	LastSeenAt *time.Time
+}
+
+// ResolvePresence 設定と接続状況から見える在席状態を決める
+// 取り込み中は通話中より優先し、未設定のユーザーはオンライン扱い
+func ResolvePresence(userID int64, setting *UserPresence, connected, inCall bool) *Presence {
+	presence := &Presence{UserID: userID, Status: PresenceOnline}
+	if setting != nil {
+		presence.LastSeenAt = setting.LastSeenAt
+	}
+
+	switch {
+	case !connected:
+		presence.Status = PresenceOffline
+	case setting.IsDoNotDisturb():
+		presence.Status = PresenceDoNotDisturb
+	case inCall:
+		presence.Status = PresenceInCall
+	case setting != nil && setting.Status == PresenceAway:
+		presence.Status = PresenceAway
+	}
+	return presence
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+// Web Push 関連のエラー
+var (
+	ErrPushNotConfigured       = errors.New("web push is not configured")
+	ErrInvalidPushSubscription = errors.New("push subscription requires a public https endpoint and p256dh/auth keys")
+	ErrPushSubscriptionGone    = errors.New("push subscription is no longer valid")
+)
+
+// PushSubscription 端末ごとのWeb Pushの購読（ブラウザのPushSubscription）
+type PushSubscription struct {
+	ID        int64
+	UserID    int64
+	Endpoint  string // プッシュサービスのURL（購読ごとに一意）
+	P256dh    string // 受信側の公開鍵（Base64）
+	Auth      string // 認証用シークレット（Base64）
+	UserAgent string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate 購読の内容を検証
+// エンドポイントへはサーバーから送信するため、内部向けのホストは受け付けない
+func (s *PushSubscription) Validate() error {
+	u, err := url.Parse(s.Endpoint)
+	if err != nil || u.Scheme != "https" || u.Host == "" || s.P256dh == "" || s.Auth == "" {
+		return ErrInvalidPushSubscription
+	}
+	if isPrivateHost(u.Hostname()) {
+		return ErrInvalidPushSubscription
+	}
+	return nil
+}
+
+// PushUrgency プッシュ通知の緊急度
+type PushUrgency string
+
+const (
+	PushUrgencyNormal PushUrgency = "normal"
+	PushUrgencyHigh   PushUrgency = "high" // 着信など、端末の省電力中でもすぐに届けるもの
+)
+
+// PushOptions プッシュサービスでの配信方法
+type PushOptions struct {
+	// 端末に届けられるまでプッシュサービスが保持する時間（過ぎたら破棄される）
+	TTL     time.Duration
+	Urgency PushUrgency
+	// 同じトピックの未配信の通知は新しいもので置き換えられる（32文字以内の英数字・-・_）
+	Topic string
+}
+
+// PushMessage 端末へ送るプッシュ通知
+type PushMessage struct {
+	Type    string // 通知の種類（Service Workerでの表示の切り替えに使う）
+	Title   string
+	Body    string
+	Data    map[string]interface{}
+	Options PushOptions
+}
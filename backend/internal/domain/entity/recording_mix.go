@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// 録音のミックス関連のエラー
+var (
+	ErrRecordingMixNotFound    = errors.New("recording mix not found")
+	ErrAudioMixerNotConfigured = errors.New("audio mixer is not configured")
+)
+
+// RecordingMixStatus ミックスのステータス
+type RecordingMixStatus string
+
+const (
+	RecordingMixQueued    RecordingMixStatus = "queued"    // 実行待ち
+	RecordingMixRunning   RecordingMixStatus = "running"   // ワーカーが実行中
+	RecordingMixSucceeded RecordingMixStatus = "succeeded" // ミックスを保存した
+	RecordingMixFailed    RecordingMixStatus = "failed"
+)
+
+// RecordingMixTrack ミックスに含めた参加者の録音
+type RecordingMixTrack struct {
+	RecordingID    int64
+	UserID         int64
+	Offset         time.Duration // ミックスの先頭からの開始位置
+	NormalizedPath *string       // 音量を正規化したコピーのキー
+}
+
+// RecordingMix 参加者ごとの録音を実時間で揃えて1つにまとめたルームの録音
+// ワーカーがリース（lease_expires_at）の期限まで実行し、期限切れのミックスは他のワーカーが引き継ぐ
+type RecordingMix struct {
+	ID          int64
+	RoomID      int64
+	RequestedBy *int64 // 依頼したユーザー（自動実行の場合はnil）
+	Status      RecordingMixStatus
+	Attempts    int
+	Tracks      []RecordingMixTrack
+	// ミックスの先頭の時刻（最も早く録音を開始した時刻）
+	RecordingStartedAt *time.Time
+	FilePath           *string // ミックスした録音のキー
+	FileSize           *int64
+	Format             string
+	LeaseOwner         *string
+	LeaseExpiresAt     *time.Time
+	LastError          *string
+	FinishedAt         *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// IsActive 実行待ちまたは実行中か
+func (m *RecordingMix) IsActive() bool {
+	return m.Status == RecordingMixQueued || m.Status == RecordingMixRunning
+}
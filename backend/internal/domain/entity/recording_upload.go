@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"errors"
+	"mime"
+	"time"
+)
+
+// 録音アップロード関連のエラー
+var (
+	ErrUploadNotFound         = errors.New("upload not found")
+	ErrUploadExpired          = errors.New("upload has expired")
+	ErrUploadOffsetMismatch   = errors.New("upload offset does not match")
+	ErrUploadTooLarge         = errors.New("upload exceeds the allowed size")
+	ErrInvalidUploadLength    = errors.New("upload length must be positive")
+	ErrUploadChecksumMismatch = errors.New("upload checksum mismatch")
+	ErrUnsupportedAudioType   = errors.New("unsupported recording content type")
+	ErrUploadNotReceived      = errors.New("object has not been uploaded yet")
+	ErrUploadSizeMismatch     = errors.New("uploaded object size does not match")
+	ErrUploadFinalizing       = errors.New("upload is being finalized")
+	ErrRecordingNotFound      = errors.New("recording not found")
+)
+
+// recordingFormats 録音として受け付けるContent-Typeと保存するフォーマット
+var recordingFormats = map[string]string{
+	"audio/webm": "webm",
+	"video/webm": "webm",
+	"audio/ogg":  "ogg",
+	"audio/mp4":  "m4a",
+	"audio/mpeg": "mp3",
+	"audio/wav":  "wav",
+}
+
+// RecordingFormat 録音のContent-Typeからフォーマット（拡張子）を取得
+// "audio/webm;codecs=opus" のようなパラメーター付きも受け付ける
+func RecordingFormat(contentType string) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", false
+	}
+	format, ok := recordingFormats[mediaType]
+	return format, ok
+}
+
+// RecordingUploadChunk ストレージに保存済みのチャンク
+type RecordingUploadChunk struct {
+	Key    string
+	Offset int64
+	Size   int64
+}
+
+// RecordingUpload 再開可能な録音アップロード（tus）
+// チャンクは受信するたびにストレージへ保存し、全体を受信したら1つの録音にまとめる
+type RecordingUpload struct {
+	ID              string
+	RoomID          int64
+	UserID          int64
+	Length          int64 // 全体のサイズ
+	Offset          int64 // 受信済みのサイズ
+	Chunks          []RecordingUploadChunk
+	ContentType     string
+	DurationSeconds *int
+	StartedAt       *time.Time // 録音を開始した時刻（クライアントの時計）
+	RecordingID     *int64     // 完了して作成した録音
+	ExpiresAt       time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// IsComplete 録音の作成まで完了したか
+func (u *RecordingUpload) IsComplete() bool {
+	return u.RecordingID != nil
+}
+
+// IsExpired 期限を過ぎた未完了のアップロードか
+func (u *RecordingUpload) IsExpired(now time.Time) bool {
+	return !u.IsComplete() && !now.Before(u.ExpiresAt)
+}
+
+// RecordingUploadSlot ストレージへ直接アップロードするための枠
+// クライアントは署名付きURLでObjectKeyにアップロードし、完了を通知すると録音として登録する
+type RecordingUploadSlot struct {
+	ID              string
+	RoomID          int64
+	UserID          int64
+	ObjectKey       string
+	ContentType     string
+	Size            int64
+	DurationSeconds *int
+	StartedAt       *time.Time // 録音を開始した時刻（クライアントの時計）
+	FinalizingAt    *time.Time // 完了の処理中（同時に完了を通知しても録音を1つだけ登録する）
+	RecordingID     *int64     // 完了して作成した録音
+	ExpiresAt       time.Time
+	CreatedAt       time.Time
+}
+
+// IsComplete 録音の作成まで完了したか
+func (s *RecordingUploadSlot) IsComplete() bool {
+	return s.RecordingID != nil
+}
+
+// IsExpired 期限を過ぎた未完了の枠か
+func (s *RecordingUploadSlot) IsExpired(now time.Time) bool {
+	return !s.IsComplete() && !now.Before(s.ExpiresAt)
+}
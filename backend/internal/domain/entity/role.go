@@ -0,0 +1,117 @@
+package entity
+
+import "errors"
+
+// ロール関連のエラー
+var (
+	ErrInvalidRole         = errors.New("invalid role")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrParticipantNotFound = errors.New("participant not found")
+	ErrCannotTargetSelf    = errors.New("cannot target yourself")
+)
+
+// ParticipantRole 通話ルーム内での参加者のロール
+type ParticipantRole string
+
+const (
+	RoleHost        ParticipantRole = "host"
+	RoleCoHost      ParticipantRole = "co_host"
+	RolePresenter   ParticipantRole = "presenter"
+	RoleParticipant ParticipantRole = "participant"
+	RoleViewer      ParticipantRole = "viewer"
+)
+
+// Permission ロールに付与される操作権限
+type Permission string
+
+const (
+	PermissionEndCall     Permission = "end_call"
+	PermissionRecord      Permission = "record"
+	PermissionTranscribe  Permission = "transcribe"
+	PermissionReadMinutes Permission = "read_minutes"
+	PermissionKick        Permission = "kick"
+	PermissionScreenShare Permission = "screen_share"
+	PermissionManageRoles Permission = "manage_roles"
+	PermissionManageRoom  Permission = "manage_room"
+	PermissionRunPolls    Permission = "run_polls"
+	PermissionEditNotes   Permission = "edit_notes"
+)
+
+// rolePermissions ロールごとの権限マトリクス
+var rolePermissions = map[ParticipantRole]map[Permission]bool{
+	RoleHost: {
+		PermissionEndCall:     true,
+		PermissionRecord:      true,
+		PermissionTranscribe:  true,
+		PermissionReadMinutes: true,
+		PermissionKick:        true,
+		PermissionScreenShare: true,
+		PermissionManageRoles: true,
+		PermissionManageRoom:  true,
+		PermissionRunPolls:    true,
+		PermissionEditNotes:   true,
+	},
+	RoleCoHost: {
+		PermissionEndCall:     true,
+		PermissionRecord:      true,
+		PermissionTranscribe:  true,
+		PermissionReadMinutes: true,
+		PermissionKick:        true,
+		PermissionScreenShare: true,
+		PermissionManageRoles: true,
+		PermissionRunPolls:    true,
+		PermissionEditNotes:   true,
+	},
+	RolePresenter: {
+		PermissionRecord:      true,
+		PermissionReadMinutes: true,
+		PermissionScreenShare: true,
+		PermissionEditNotes:   true,
+	},
+	RoleParticipant: {
+		PermissionRecord:      true,
+		PermissionReadMinutes: true,
+		PermissionEditNotes:   true,
+	},
+	RoleViewer: {
+		PermissionReadMinutes: true,
+	},
+}
+
+// roleRank ロールの序列（大きいほど上位）
+var roleRank = map[ParticipantRole]int{
+	RoleViewer:      1,
+	RoleParticipant: 2,
+	RolePresenter:   3,
+	RoleCoHost:      4,
+	RoleHost:        5,
+}
+
+// IsValid 有効なロールか
+func (r ParticipantRole) IsValid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// Can 権限を持っているか
+func (r ParticipantRole) Can(p Permission) bool {
+	return rolePermissions[r][p]
+}
+
+// Outranks 他のロールより上位か
+func (r ParticipantRole) Outranks(other ParticipantRole) bool {
+	return roleRank[r] > roleRank[other]
+}
+
+// CanAssign ロール変更が可能か
+// ロール管理権限を持ち、対象の現在のロールと新しいロールの両方より上位である必要がある
+// ホストのみ他の参加者をホストにできる（ホストの移譲）
+func (r ParticipantRole) CanAssign(current, next ParticipantRole) bool {
+	if !r.Can(PermissionManageRoles) || !next.IsValid() {
+		return false
+	}
+	if r == RoleHost {
+		return current != RoleHost
+	}
+	return r.Outranks(current) && r.Outranks(next)
+}
@@ -0,0 +1,196 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// ルームのアクセス制御関連のエラー
+var (
+	ErrRoomAccessDenied  = errors.New("room access denied")
+	ErrPasscodeRequired  = errors.New("passcode required")
+	ErrInvalidPasscode   = errors.New("invalid passcode")
+	ErrPasscodeLength    = errors.New("passcode must be 4-64 characters")
+	ErrInvalidVisibility = errors.New("invalid visibility")
+	ErrInvalidDomain     = errors.New("invalid email domain")
+)
+
+// パスコードの長さ制限
+const (
+	minPasscodeLen = 4
+	maxPasscodeLen = 64
+)
+
+// RoomVisibility ルームの公開範囲
+type RoomVisibility string
+
+const (
+	// RoomVisibilityPublic 一覧に表示され、誰でも参加可能
+	RoomVisibilityPublic RoomVisibility = "public"
+	// RoomVisibilityPrivate 一覧には表示されないが、リンクを知っていれば参加可能
+	RoomVisibilityPrivate RoomVisibility = "private"
+	// RoomVisibilityInviteOnly 許可リストのユーザーと招待されたゲストのみ参加可能
+	RoomVisibilityInviteOnly RoomVisibility = "invite_only"
+)
+
+// IsValid 有効な公開範囲か
+func (v RoomVisibility) IsValid() bool {
+	switch v {
+	case RoomVisibilityPublic, RoomVisibilityPrivate, RoomVisibilityInviteOnly:
+		return true
+	}
+	return false
+}
+
+// CallRoomAccessRule ルームの許可リストの1エントリ（ユーザーまたはメールドメイン）
+type CallRoomAccessRule struct {
+	ID          int64
+	RoomID      int64
+	UserID      *int64
+	EmailDomain *string
+	CreatedAt   time.Time
+}
+
+// Matches ユーザーが許可リストのエントリに該当するか
+// メールドメインのエントリは検証済みのメールアドレス（RoomPrincipal.VerifiedEmail）で照合する
+func (r *CallRoomAccessRule) Matches(userID int64, email string) bool {
+	if r.UserID != nil {
+		return *r.UserID == userID
+	}
+	if r.EmailDomain != nil {
+		at := strings.LastIndex(email, "@")
+		return at >= 0 && strings.EqualFold(email[at+1:], *r.EmailDomain)
+	}
+	return false
+}
+
+// RoomAccessSettings ルームのアクセス設定
+type RoomAccessSettings struct {
+	Visibility          RoomVisibility
+	Passcode            *string // nilは変更なし、空文字はパスコード解除
+	AllowedUserIDs      []int64
+	AllowedEmailDomains []string
+}
+
+// Rules 許可リストのエントリに変換
+func (s *RoomAccessSettings) Rules(roomID int64) ([]*CallRoomAccessRule, error) {
+	rules := make([]*CallRoomAccessRule, 0, len(s.AllowedUserIDs)+len(s.AllowedEmailDomains))
+	for _, id := range s.AllowedUserIDs {
+		userID := id
+		rules = append(rules, &CallRoomAccessRule{RoomID: roomID, UserID: &userID})
+	}
+	for _, d := range s.AllowedEmailDomains {
+		domain := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+		if domain == "" || !strings.Contains(domain, ".") || strings.ContainsAny(domain, "@ /") {
+			return nil, ErrInvalidDomain
+		}
+		rules = append(rules, &CallRoomAccessRule{RoomID: roomID, EmailDomain: &domain})
+	}
+	return rules, nil
+}
+
+// RoomPrincipal ルームにアクセスしようとしている主体（ユーザーまたはゲスト）
+type RoomPrincipal struct {
+	UserID int64
+	Email  string
+	// メールアドレスが検証済みか（トークンのメールアドレスは登録時に検証していないため、ユーザー情報から設定する）
+	EmailVerified bool
+	Guest         *GuestPrincipal
+}
+
+// VerifiedEmail メールドメインの許可リストの照合に使うメールアドレス（未検証の場合は空）
+func (p *RoomPrincipal) VerifiedEmail() string {
+	if !p.EmailVerified {
+		return ""
+	}
+	return p.Email
+}
+
+// SetPasscode パスコードをハッシュ化して設定（空文字で解除）
+func (r *CallRoom) SetPasscode(passcode string) error {
+	hash, err := hashPasscode(passcode)
+	if err != nil {
+		return err
+	}
+	r.PasscodeHash = hash
+	return nil
+}
+
+// hashPasscode パスコードを検証してハッシュ化（空文字の場合はnil）
+func hashPasscode(passcode string) (*string, error) {
+	if passcode == "" {
+		return nil, nil
+	}
+	if n := utf8.RuneCountInString(passcode); n < minPasscodeLen || n > maxPasscodeLen {
+		return nil, ErrPasscodeLength
+	}
+	hashed, err := bcrypt.GenerateFromPassword([]byte(passcode), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+	hash := string(hashed)
+	return &hash, nil
+}
+
+// HasPasscode パスコードが設定されているか
+func (r *CallRoom) HasPasscode() bool {
+	return r.PasscodeHash != nil
+}
+
+// CheckPasscode パスコードを検証
+func (r *CallRoom) CheckPasscode(passcode string) bool {
+	if r.PasscodeHash == nil {
+		return true
+	}
+	return bcrypt.CompareHashAndPassword([]byte(*r.PasscodeHash), []byte(passcode)) == nil
+}
+
+// Admits 公開範囲と許可リストに基づき参加を認めるか（パスコードは別途検証）
+func (r *CallRoom) Admits(rules []*CallRoomAccessRule, userID int64, email string) bool {
+	if r.CreatedBy == userID {
+		return true
+	}
+	if r.RestrictsAdmission(rules) {
+		return matchesAny(rules, userID, email)
+	}
+	return true
+}
+
+// RestrictsAdmission 許可リストのユーザーだけに参加を制限しているか
+// 許可リストが空の公開・非公開ルームはリンクを知っていれば誰でも参加できる
+func (r *CallRoom) RestrictsAdmission(rules []*CallRoomAccessRule) bool {
+	return len(rules) > 0 || r.Visibility == RoomVisibilityInviteOnly
+}
+
+// IsListedFor ルーム一覧に表示するか
+func (r *CallRoom) IsListedFor(rules []*CallRoomAccessRule, userID int64, email string) bool {
+	if r.CreatedBy == userID {
+		return true
+	}
+	if r.Visibility == RoomVisibilityPublic {
+		return r.Admits(rules, userID, email)
+	}
+	return matchesAny(rules, userID, email)
+}
+
+// IsVisibleTo 主体のルーム一覧に表示するか（ゲストは招待されたルームのみ）
+func (r *CallRoom) IsVisibleTo(rules []*CallRoomAccessRule, principal *RoomPrincipal) bool {
+	if principal.Guest != nil {
+		return r.RoomID == principal.Guest.RoomID
+	}
+	return r.IsListedFor(rules, principal.UserID, principal.VerifiedEmail())
+}
+
+// matchesAny いずれかのエントリに該当するか
+func matchesAny(rules []*CallRoomAccessRule, userID int64, email string) bool {
+	for _, rule := range rules {
+		if rule.Matches(userID, email) {
+			return true
+		}
+	}
+	return false
+}
@@ -6,6 +6,7 @@ import "time"
 type Todo struct {
 	ID          int
 	UserID      int64
+	AssigneeID  *int64 // 担当者のユーザーID（未割り当てならnil）
 	Title       string
 	Description string
 	Completed   bool
@@ -50,6 +51,16 @@ func (t *Todo) UpdateDescription(description string) {
 	t.Description = description
 }
 
+// Assign は担当者を設定する（nilで割り当てを解除）
+func (t *Todo) Assign(assigneeID *int64) {
+	t.AssigneeID = assigneeID
+}
+
+// IsAssignedTo は指定したユーザーが担当者かどうかを返す
+func (t *Todo) IsAssignedTo(userID int64) bool {
+	return t.AssigneeID != nil && *t.AssigneeID == userID
+}
+
 // Validate はエンティティのバリデーションを行う
 func (t *Todo) Validate() error {
 	if t.Title == "" {
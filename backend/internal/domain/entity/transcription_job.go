@@ -0,0 +1,96 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// 文字起こしジョブ関連のエラー
+var (
+	ErrTranscriptionJobNotFound = errors.New("transcription job not found")
+	ErrTranscriptionJobActive   = errors.New("transcription job is already active for the room")
+	ErrNoRecordings             = errors.New("no recordings found")
+	ErrTranscriberNotConfigured = errors.New("transcriber is not configured")
+)
+
+// TranscriptionJobStatus 文字起こしジョブのステータス
+type TranscriptionJobStatus string
+
+const (
+	TranscriptionJobQueued    TranscriptionJobStatus = "queued"    // 実行待ち（再試行待ちを含む）
+	TranscriptionJobRunning   TranscriptionJobStatus = "running"   // ワーカーが実行中
+	TranscriptionJobSucceeded TranscriptionJobStatus = "succeeded" // 議事録を作成した
+	TranscriptionJobFailed    TranscriptionJobStatus = "failed"    // 再試行しても失敗した、または再試行できないエラー
+)
+
+// TranscriptionItemStatus 録音ごとの文字起こしの進捗
+type TranscriptionItemStatus string
+
+const (
+	TranscriptionItemPending TranscriptionItemStatus = "pending"
+	TranscriptionItemRunning TranscriptionItemStatus = "running"
+	TranscriptionItemDone    TranscriptionItemStatus = "done"
+	TranscriptionItemSkipped TranscriptionItemStatus = "skipped" // エンジンが扱えない音声
+	TranscriptionItemFailed  TranscriptionItemStatus = "failed"
+)
+
+// TranscriptionJobItem ジョブに含まれる録音の進捗
+type TranscriptionJobItem struct {
+	RecordingID int64
+	Status      TranscriptionItemStatus
+	Segments    int // 文字起こしした区間数
+	Error       *string
+}
+
+// TranscriptionJob ルームの録音を文字起こしして議事録を作成するジョブ
+// ワーカーはリース（lease_expires_at）を更新しながら実行し、期限切れのジョブは他のワーカーが引き継ぐ
+type TranscriptionJob struct {
+	ID             int64
+	RoomID         int64
+	RequestedBy    *int64 // 依頼したユーザー（自動実行の場合はnil）
+	Status         TranscriptionJobStatus
+	Attempts       int
+	Items          []TranscriptionJobItem
+	NextAttemptAt  *time.Time
+	LeaseOwner     *string
+	LeaseExpiresAt *time.Time
+	LastError      *string
+	MinutesID      *int64
+	StartedAt      *time.Time
+	FinishedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// IsActive 実行待ちまたは実行中か
+func (j *TranscriptionJob) IsActive() bool {
+	return j.Status == TranscriptionJobQueued || j.Status == TranscriptionJobRunning
+}
+
+// Progress 処理を終えた録音の数と全体の数
+func (j *TranscriptionJob) Progress() (completed int, total int) {
+	for _, item := range j.Items {
+		switch item.Status {
+		case TranscriptionItemDone, TranscriptionItemSkipped, TranscriptionItemFailed:
+			completed++
+		}
+	}
+	return completed, len(j.Items)
+}
+
+// SetItem 録音の進捗を更新
+func (j *TranscriptionJob) SetItem(recordingID int64, status TranscriptionItemStatus, segments int, err error) {
+	for i := range j.Items {
+		if j.Items[i].RecordingID != recordingID {
+			continue
+		}
+		j.Items[i].Status = status
+		j.Items[i].Segments = segments
+		j.Items[i].Error = nil
+		if err != nil {
+			msg := err.Error()
+			j.Items[i].Error = &msg
+		}
+		return
+	}
+}
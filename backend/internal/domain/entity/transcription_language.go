@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// 文字起こしの言語設定関連のエラー
+var (
+	ErrInvalidTranscriptionLanguage = errors.New("invalid transcription language")
+	ErrTooManyAlternativeLanguages  = errors.New("too many alternative languages")
+)
+
+const (
+	// TranscriptionLanguageAuto 話された言語を自動で判定する
+	TranscriptionLanguageAuto = "auto"
+	// DefaultTranscriptionLanguage 言語を指定しない場合の文字起こしの言語
+	DefaultTranscriptionLanguage = "ja-JP"
+	// UndeterminedLanguage 言語を判定できなかった区間の言語（BCP-47の und）
+	UndeterminedLanguage = "und"
+	// MaxAlternativeLanguages 候補の言語の上限（Speech-to-Textの alternativeLanguageCodes の上限）
+	MaxAlternativeLanguages = 3
+)
+
+// languageCodePattern BCP-47の言語コード（言語 + 文字・地域などのサブタグ）
+var languageCodePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// TranscriptionLanguageSettings ルームの文字起こしの言語設定
+type TranscriptionLanguageSettings struct {
+	Language             string   // 主に話される言語（BCP-47）。auto の場合は候補の言語から判定する
+	AlternativeLanguages []string // 他に話される可能性のある言語（候補の中から区間ごとに判定する）
+}
+
+// Apply 言語設定を検証してルームに反映（言語を省略した場合は既定の言語）
+func (s *TranscriptionLanguageSettings) Apply(room *CallRoom) error {
+	language := DefaultTranscriptionLanguage
+	if s.Language != "" {
+		if strings.EqualFold(s.Language, TranscriptionLanguageAuto) {
+			language = TranscriptionLanguageAuto
+		} else {
+			code, err := NormalizeLanguageCode(s.Language)
+			if err != nil {
+				return err
+			}
+			language = code
+		}
+	}
+
+	if len(s.AlternativeLanguages) > MaxAlternativeLanguages {
+		return ErrTooManyAlternativeLanguages
+	}
+	alternatives := make([]string, 0, len(s.AlternativeLanguages))
+	seen := map[string]bool{language: true}
+	for _, l := range s.AlternativeLanguages {
+		code, err := NormalizeLanguageCode(l)
+		if err != nil {
+			return err
+		}
+		// 主言語と重複する候補は除く
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		alternatives = append(alternatives, code)
+	}
+
+	room.TranscriptionLanguage = language
+	room.TranscriptionAlternativeLanguages = alternatives
+	return nil
+}
+
+// NormalizeLanguageCode BCP-47の言語コードを検証して表記を揃える（en-us → en-US）
+func NormalizeLanguageCode(code string) (string, error) {
+	code = strings.TrimSpace(code)
+	if !languageCodePattern.MatchString(code) || len(code) > 35 {
+		return "", ErrInvalidTranscriptionLanguage
+	}
+	subtags := strings.Split(code, "-")
+	subtags[0] = strings.ToLower(subtags[0])
+	for i := 1; i < len(subtags); i++ {
+		switch len(subtags[i]) {
+		case 2:
+			// 地域（JP、US など）
+			subtags[i] = strings.ToUpper(subtags[i])
+		case 4:
+			// 文字（Hans、Latn など）
+			subtags[i] = strings.ToUpper(subtags[i][:1]) + strings.ToLower(subtags[i][1:])
+		default:
+			subtags[i] = strings.ToLower(subtags[i])
+		}
+	}
+	return strings.Join(subtags, "-"), nil
+}
+
+// SameLanguage 地域などを除いた言語が同じか（ja と ja-JP は同じ）
+func SameLanguage(a, b string) bool {
+	la, _, _ := strings.Cut(a, "-")
+	lb, _, _ := strings.Cut(b, "-")
+	return strings.EqualFold(la, lb)
+}
+
+// TranscriptionLanguageOrDefault ルームの文字起こしの言語（未設定の場合は既定の言語）
+func (r *CallRoom) TranscriptionLanguageOrDefault() string {
+	if r.TranscriptionLanguage == "" {
+		return DefaultTranscriptionLanguage
+	}
+	return r.TranscriptionLanguage
+}
+
+// DetectsTranscriptionLanguage 文字起こしで話された言語を判定するか（自動判定または候補の言語がある）
+func (r *CallRoom) DetectsTranscriptionLanguage() bool {
+	return r.TranscriptionLanguageOrDefault() == TranscriptionLanguageAuto || len(r.TranscriptionAlternativeLanguages) > 0
+}
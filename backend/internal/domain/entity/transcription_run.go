@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// 文字起こしの実行関連のエラー
+var (
+	ErrTranscriptionRunNotFound     = errors.New("transcription run not found")
+	ErrTranscriptionRunNotSucceeded = errors.New("transcription run has not succeeded")
+	ErrMinutesNotFound              = errors.New("minutes not found")
+)
+
+// TranscriptionRunStatus 文字起こしの実行の状態
+type TranscriptionRunStatus string
+
+const (
+	TranscriptionRunRunning   TranscriptionRunStatus = "running"
+	TranscriptionRunSucceeded TranscriptionRunStatus = "succeeded"
+	TranscriptionRunFailed    TranscriptionRunStatus = "failed"
+)
+
+// TranscriptionRun ルームの録音を1回文字起こしした結果
+// 実行ごとに条件と区間を残し、ルームごとに1つの現在の実行から議事録を作成する
+type TranscriptionRun struct {
+	ID                   int64
+	RoomID               int64
+	JobID                *int64   // 文字起こしジョブから実行した場合のジョブID
+	Engine               string   // 文字起こしエンジン（google、whisper）
+	Language             string   // ルームの文字起こしの言語（auto は自動判定）
+	AlternativeLanguages []string // 他に話される可能性のある言語
+	Status               TranscriptionRunStatus
+	IsCurrent            bool // 議事録の作成に使っている実行か
+	Segments             int  // 保存した区間数
+	Recordings           int  // 文字起こしした録音の数（飛ばした・失敗した録音は含めない）
+	Error                *string
+	StartedAt            time.Time
+	FinishedAt           *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// Succeed 実行を成功にする
+func (r *TranscriptionRun) Succeed(segments int, recordings int, now time.Time) {
+	r.Status = TranscriptionRunSucceeded
+	r.Segments = segments
+	r.Recordings = recordings
+	r.Error = nil
+	r.FinishedAt = &now
+}
+
+// Fail 実行を失敗にする
+func (r *TranscriptionRun) Fail(cause error, now time.Time) {
+	msg := cause.Error()
+	r.Status = TranscriptionRunFailed
+	r.Error = &msg
+	r.FinishedAt = &now
+}
@@ -0,0 +1,37 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// 端末関連のエラー
+var (
+	ErrUserDeviceNotFound = errors.New("user device not found")
+)
+
+// LoginDevice ログイン元の端末情報
+type LoginDevice struct {
+	UserAgent string
+	IPAddress string
+}
+
+// Hash 端末を識別するハッシュ（User-Agentから作る。IPアドレスは移動で変わるため含めない）
+func (d *LoginDevice) Hash() string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(d.UserAgent)))
+	return hex.EncodeToString(sum[:])
+}
+
+// UserDevice ユーザーがログインに使ったことのある端末
+type UserDevice struct {
+	ID          int64
+	UserID      int64
+	DeviceHash  string
+	UserAgent   string
+	IPAddress   string // 最後にログインしたIPアドレス
+	FirstSeenAt time.Time
+	LastSeenAt  time.Time
+}
@@ -0,0 +1,24 @@
+package entity
+
+// UserEventType ユーザーの全端末へ配信するイベントの種類
+type UserEventType string
+
+const (
+	UserEventIncomingCall UserEventType = "incoming-call" // 着信
+	UserEventCallAccepted UserEventType = "call-accepted" // 応答された（他の端末は着信を止める）
+	UserEventCallDeclined UserEventType = "call-declined" // 拒否された
+	UserEventCallMissed   UserEventType = "call-missed"   // 応答がないまま終了した
+	UserEventPresence     UserEventType = "presence"      // 連絡先の在席状態が変わった
+
+	UserEventNotification      UserEventType = "notification"       // 新しい通知
+	UserEventNotificationsRead UserEventType = "notifications-read" // 通知が既読になった（他の端末の未読表示を揃える）
+
+	UserEventTranscriptionJob UserEventType = "transcription-job" // 依頼した文字起こしジョブの進捗
+	UserEventRecordingMix     UserEventType = "recording-mix"     // 依頼した録音のミックスの状態
+)
+
+// UserEvent ユーザーの全端末へ配信するイベント
+type UserEvent struct {
+	Type UserEventType
+	Data interface{}
+}
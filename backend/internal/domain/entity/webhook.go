@@ -0,0 +1,131 @@
+package entity
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Webhook関連のエラー
+var (
+	ErrWebhookNotFound         = errors.New("webhook not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrInvalidWebhookURL       = errors.New("invalid webhook url")
+	ErrInvalidWebhookEvent     = errors.New("invalid webhook event")
+	ErrWebhookEventsRequired   = errors.New("at least one webhook event is required")
+	ErrPrivateWebhookURL       = errors.New("webhook url must not point to a private network")
+)
+
+// WebhookEvent Webhookで通知するイベント
+type WebhookEvent string
+
+const (
+	WebhookEventRoomCreated       WebhookEvent = "room.created"
+	WebhookEventRoomStarted       WebhookEvent = "room.started"
+	WebhookEventRoomUpdated       WebhookEvent = "room.updated"
+	WebhookEventParticipantJoined WebhookEvent = "participant.joined"
+	WebhookEventParticipantLeft   WebhookEvent = "participant.left"
+	WebhookEventRoomEnded         WebhookEvent = "room.ended"
+	WebhookEventRecordingUploaded WebhookEvent = "recording.uploaded"
+	WebhookEventMinutesReady      WebhookEvent = "minutes.ready"
+)
+
+// IsValid 有効なイベントか
+func (e WebhookEvent) IsValid() bool {
+	switch e {
+	case WebhookEventRoomCreated, WebhookEventRoomStarted, WebhookEventRoomUpdated, WebhookEventParticipantJoined,
+		WebhookEventParticipantLeft, WebhookEventRoomEnded, WebhookEventRecordingUploaded,
+		WebhookEventMinutesReady:
+		return true
+	}
+	return false
+}
+
+// Webhook ユーザーが登録したWebhookエンドポイント
+// 登録したユーザーが作成したルームのイベントが通知される
+type Webhook struct {
+	ID        int64
+	UserID    int64
+	URL       string
+	Secret    string // 署名用の共有シークレット
+	Events    []WebhookEvent
+	IsActive  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate URLと購読イベントを検証
+// 送信先がlocalhostや内部向けのIPアドレスの場合は他の検証を通過した上でErrPrivateWebhookURLを返す
+// （ホスト名は名前解決しないため、解決後のアドレスは送信時に検証する）
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidWebhookURL
+	}
+	if len(w.Events) == 0 {
+		return ErrWebhookEventsRequired
+	}
+	for _, e := range w.Events {
+		if !e.IsValid() {
+			return ErrInvalidWebhookEvent
+		}
+	}
+	if isPrivateHost(u.Hostname()) {
+		return ErrPrivateWebhookURL
+	}
+	return nil
+}
+
+// isPrivateHost ループバック・プライベート・リンクローカル・未指定のアドレスかlocalhostか
+func isPrivateHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsMulticast()
+}
+
+// Subscribes イベントを購読しているか
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus 配信ステータス
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // 配信待ち（再試行待ちを含む）
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // 2xxを受信
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // 再試行回数の上限に到達
+)
+
+// WebhookDelivery Webhookの配信記録
+// 再送時は同じイベントIDとペイロードで新しい配信記録を作成する
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int64
+	EventID        string
+	Event          WebhookEvent
+	Payload        []byte
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  *time.Time
+	LastStatusCode *int
+	LastError      *string
+	DeliveredAt    *time.Time
+	ReplayOf       *int64 // 再送元の配信ID
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
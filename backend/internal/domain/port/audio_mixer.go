@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// AudioMixerContentType ミックス・正規化した音声のContent-Type（Ogg・Opus）
+const AudioMixerContentType = "audio/ogg"
+
+// AudioTrack ミックス・正規化する音声
+type AudioTrack struct {
+	Offset time.Duration // ミックスの先頭から再生を始める位置
+	// Open 音声データを読み込む（呼び出し側で閉じる）
+	Open func(ctx context.Context) (io.ReadCloser, error)
+}
+
+// AudioMixer 音声をミックス・正規化するエンジンのインターフェース
+// 出力は呼び出し側で閉じる。変換に失敗した場合は読み込み中にエラーを返す
+type AudioMixer interface {
+	// トラックを開始位置に合わせて1つの音声にミックス
+	Mix(ctx context.Context, tracks []AudioTrack) (io.ReadCloser, error)
+	// 音量（ラウドネス）を正規化
+	Normalize(ctx context.Context, track AudioTrack) (io.ReadCloser, error)
+}
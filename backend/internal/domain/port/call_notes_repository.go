@@ -0,0 +1,15 @@
+package port
+
+import (
+	"context"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// CallNotesRepository 共有ノートリポジトリのインターフェース
+type CallNotesRepository interface {
+	// 共有ノートのスナップショットを保存（存在しない場合は作成）
+	Save(ctx context.Context, notes *entity.CallNotes) error
+	// ルームの共有ノートを取得（存在しない場合はentity.ErrCallNotesNotFound）
+	FindByRoomID(ctx context.Context, roomID int64) (*entity.CallNotes, error)
+}
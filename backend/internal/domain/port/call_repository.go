@@ -2,6 +2,8 @@ package port
 
 import (
 	"context"
+	"time"
+
 	"Go-Next-WebRTC/internal/domain/entity"
 )
 
@@ -15,10 +17,32 @@ type CallRoomRepository interface {
 	FindByID(ctx context.Context, id int64) (*entity.CallRoom, error)
 	// アクティブな通話ルーム一覧取得
 	FindActiveRooms(ctx context.Context) ([]*entity.CallRoom, error)
+	// アクティブな通話ルーム一覧を参加人数付きで取得
+	FindLobbyRooms(ctx context.Context) ([]*entity.LobbyRoom, error)
 	// 通話ルーム更新
 	Update(ctx context.Context, room *entity.CallRoom) error
 	// ユーザーが作成した通話ルーム一覧
 	FindByCreatedBy(ctx context.Context, userID int64) ([]*entity.CallRoom, error)
+	// シリーズの開催回に対応する通話ルーム取得
+	FindBySeriesOccurrence(ctx context.Context, seriesID int64, occurrenceStart time.Time) (*entity.CallRoom, error)
+	// シリーズから生成された通話ルーム一覧
+	FindBySeriesID(ctx context.Context, seriesID int64) ([]*entity.CallRoom, error)
+	// 個人ルームのセッション一覧（新しい順）
+	FindByPersonalRoomID(ctx context.Context, personalRoomID int64, limit int) ([]*entity.CallRoom, error)
+	// 通話履歴の検索（該当件数も返す）
+	FindHistory(ctx context.Context, filter *entity.CallHistoryFilter) ([]*entity.CallRoom, int, error)
+}
+
+// CallRoomAccessRepository ルームの許可リストリポジトリのインターフェース
+type CallRoomAccessRepository interface {
+	// 許可リストを置き換え
+	ReplaceRules(ctx context.Context, roomID int64, rules []*entity.CallRoomAccessRule) error
+	// 許可リストに1件追加（既に登録済みの場合は何もしない）
+	AddRule(ctx context.Context, rule *entity.CallRoomAccessRule) error
+	// ルームの許可リスト取得
+	FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallRoomAccessRule, error)
+	// 複数ルームの許可リストを一括取得
+	FindByRoomIDs(ctx context.Context, roomIDs []int64) ([]*entity.CallRoomAccessRule, error)
 }
 
 // CallParticipantRepository 通話参加者リポジトリのインターフェース
@@ -33,6 +57,16 @@ type CallParticipantRepository interface {
 	FindActiveByRoomID(ctx context.Context, roomID int64) ([]*entity.CallParticipant, error)
 	// 特定ユーザーの参加記録取得
 	FindByRoomIDAndUserID(ctx context.Context, roomID int64, userID int64) (*entity.CallParticipant, error)
+	// 特定ゲストの参加記録取得
+	FindByRoomIDAndGuestID(ctx context.Context, roomID int64, guestID string) (*entity.CallParticipant, error)
+	// 参加記録取得（IDで検索）
+	FindByID(ctx context.Context, id int64) (*entity.CallParticipant, error)
+	// 特定ユーザーの最新の参加記録取得（退出済みも含む）
+	FindLatestByRoomIDAndUserID(ctx context.Context, roomID int64, userID int64) (*entity.CallParticipant, error)
+	// 複数ルームの参加記録を一括取得
+	FindByRoomIDs(ctx context.Context, roomIDs []int64) ([]*entity.CallParticipant, error)
+	// 同じ通話に参加したことのあるユーザーID一覧（最近一緒になった順）
+	FindContactUserIDs(ctx context.Context, userID int64, limit int) ([]int64, error)
 }
 
 // CallRecordingRepository 録音リポジトリのインターフェース
@@ -43,6 +77,10 @@ type CallRecordingRepository interface {
 	FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallRecording, error)
 	// 録音取得（IDで検索）
 	FindByID(ctx context.Context, id int64) (*entity.CallRecording, error)
+	// ルームの録音取得（ファイルの場所で検索。存在しない場合はErrRecordingNotFound）
+	FindByRoomIDAndFilePath(ctx context.Context, roomID int64, filePath string) (*entity.CallRecording, error)
+	// 録音が存在するルームIDを取得
+	FindRoomIDsWithRecordings(ctx context.Context, roomIDs []int64) (map[int64]bool, error)
 }
 
 // CallTranscriptionRepository 文字起こしリポジトリのインターフェース
@@ -51,8 +89,10 @@ type CallTranscriptionRepository interface {
 	Create(ctx context.Context, transcription *entity.CallTranscription) error
 	// バッチ作成
 	CreateBatch(ctx context.Context, transcriptions []*entity.CallTranscription) error
-	// ルームの文字起こし一覧取得
+	// ルームの現在の実行の文字起こし一覧取得
 	FindByRoomID(ctx context.Context, roomID int64) ([]*entity.CallTranscription, error)
+	// 実行の文字起こし一覧取得
+	FindByRunID(ctx context.Context, runID int64) ([]*entity.CallTranscription, error)
 }
 
 // CallMinutesRepository 議事録リポジトリのインターフェース
@@ -61,8 +101,10 @@ type CallMinutesRepository interface {
 	Create(ctx context.Context, minutes *entity.CallMinutes) error
 	// 議事録更新
 	Update(ctx context.Context, minutes *entity.CallMinutes) error
-	// ルームの議事録取得
+	// ルームの議事録取得（ない場合はErrMinutesNotFound）
 	FindByRoomID(ctx context.Context, roomID int64) (*entity.CallMinutes, error)
 	// ユーザーの議事録一覧取得（参加した通話の議事録）
 	FindByUserID(ctx context.Context, userID int64) ([]*entity.CallMinutes, error)
+	// 議事録が存在するルームIDを取得
+	FindRoomIDsWithMinutes(ctx context.Context, roomIDs []int64) (map[int64]bool, error)
 }
@@ -0,0 +1,31 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// CallSeriesRepository 定例会議シリーズリポジトリのインターフェース
+type CallSeriesRepository interface {
+	// シリーズ作成
+	Create(ctx context.Context, series *entity.CallSeries) error
+	// シリーズ更新
+	Update(ctx context.Context, series *entity.CallSeries) error
+	// シリーズ分割（元シリーズの更新・新シリーズの作成・from以降の例外の移動または削除を1トランザクションで行う）
+	Split(ctx context.Context, series *entity.CallSeries, next *entity.CallSeries, from time.Time, moveExceptions bool) error
+	// シリーズ取得（series_idで検索）
+	FindBySeriesID(ctx context.Context, seriesID string) (*entity.CallSeries, error)
+	// シリーズ取得（IDで検索）
+	FindByID(ctx context.Context, id int64) (*entity.CallSeries, error)
+	// ユーザーが作成したシリーズ一覧
+	FindByCreatedBy(ctx context.Context, userID int64) ([]*entity.CallSeries, error)
+
+	// 例外の作成または更新（series_id と original_start で一意）
+	SaveException(ctx context.Context, exception *entity.CallSeriesException) error
+	// シリーズの例外一覧取得
+	FindExceptions(ctx context.Context, seriesID int64) ([]*entity.CallSeriesException, error)
+	// 指定日時以降の例外を削除
+	DeleteExceptionsFrom(ctx context.Context, seriesID int64, from time.Time) error
+}
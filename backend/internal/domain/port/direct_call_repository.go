@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// DirectCallRepository 1対1通話リポジトリのインターフェース
+type DirectCallRepository interface {
+	// 1対1通話作成
+	Create(ctx context.Context, call *entity.DirectCall) error
+	// 着信中の1対1通話の状態を更新（既に応答・終了していた場合はfalse）
+	UpdateFromRinging(ctx context.Context, call *entity.DirectCall) (bool, error)
+	// 1対1通話取得（call_idで検索）
+	FindByCallID(ctx context.Context, callID string) (*entity.DirectCall, error)
+	// 応答期限を過ぎた着信中の通話一覧
+	FindExpiredRinging(ctx context.Context, now time.Time, limit int) ([]*entity.DirectCall, error)
+	// ユーザーの不在着信一覧（新しい順）
+	FindMissedByCallee(ctx context.Context, calleeID int64, limit int) ([]*entity.DirectCall, error)
+}
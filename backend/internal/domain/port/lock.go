@@ -0,0 +1,10 @@
+package port
+
+import "context"
+
+// DistributedLock 複数レプリカ間で処理を排他するロックのインターフェース
+type DistributedLock interface {
+	// ロックの取得を試みる（他で保持中の場合は待たずにacquired=falseを返す）
+	// 取得できた場合は処理後にreleaseを呼び出すこと
+	TryLock(ctx context.Context, name string) (release func(), acquired bool, err error)
+}
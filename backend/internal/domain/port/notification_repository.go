@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// NotificationRepository 通知リポジトリのインターフェース
+type NotificationRepository interface {
+	// 通知作成
+	Create(ctx context.Context, notification *entity.Notification) error
+	// 通知取得
+	FindByID(ctx context.Context, id int64) (*entity.Notification, error)
+	// ユーザーの通知一覧（新しい順）
+	FindByUserID(ctx context.Context, filter *entity.NotificationFilter) ([]*entity.Notification, error)
+	// ユーザーの未読件数
+	CountUnread(ctx context.Context, userID int64) (int, error)
+	// 通知を既読にする（既読のものは変更しない）
+	MarkRead(ctx context.Context, id int64, at time.Time) error
+	// ユーザーの未読の通知をすべて既読にし、既読にした件数を返す
+	MarkAllRead(ctx context.Context, userID int64, at time.Time) (int64, error)
+}
@@ -0,0 +1,54 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrObjectNotFound オブジェクトが存在しない
+var ErrObjectNotFound = errors.New("object not found")
+
+// ObjectInfo 保存済みオブジェクトの情報
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string // 一覧取得では空の場合がある
+	ETag        string
+	URI         string // バックエンド固有の場所（gs://bucket/key、s3://bucket/key、file:///path）
+	UpdatedAt   time.Time
+}
+
+// PresignOptions 署名付きURLの発行条件
+type PresignOptions struct {
+	Method  string // http.MethodGet / http.MethodPut
+	Expires time.Duration
+	// PUTの場合にアップロードを許可するContent-Typeとサイズ（空・0は制限しない）
+	ContentType   string
+	ContentLength int64
+}
+
+// PresignedURL 発行した署名付きURL
+type PresignedURL struct {
+	URL string
+	// 送信時に付けるヘッダー（署名に含まれるため変更できない）
+	Headers map[string]string
+}
+
+// ObjectStorage 録音ファイルなどを保存するオブジェクトストレージのインターフェース
+// キーは "/" 区切りの相対パス（recordings/{room}/... など）
+type ObjectStorage interface {
+	// オブジェクトを保存（sizeが不明な場合は-1）
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*ObjectInfo, error)
+	// オブジェクトを取得（呼び出し側で本文を閉じる。存在しない場合はErrObjectNotFound）
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	// オブジェクトの情報を取得（存在しない場合はErrObjectNotFound）
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	// オブジェクトを削除（存在しない場合も成功）
+	Delete(ctx context.Context, key string) error
+	// プレフィックスに一致するオブジェクトをキー順に取得
+	List(ctx context.Context, prefix string) ([]*ObjectInfo, error)
+	// クライアントが直接取得・アップロードするための署名付きURLを発行
+	PresignURL(ctx context.Context, key string, opts PresignOptions) (*PresignedURL, error)
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// PersonalRoomRepository 個人ルームリポジトリのインターフェース
+type PersonalRoomRepository interface {
+	// 個人ルーム作成（スラッグまたはユーザーが重複する場合はErrSlugTaken）
+	Create(ctx context.Context, room *entity.PersonalRoom) error
+	// 個人ルーム更新（スラッグが重複する場合はErrSlugTaken）
+	Update(ctx context.Context, room *entity.PersonalRoom) error
+	// ユーザーの個人ルーム取得
+	FindByUserID(ctx context.Context, userID int64) (*entity.PersonalRoom, error)
+	// 個人ルーム取得（スラッグで検索）
+	FindBySlug(ctx context.Context, slug string) (*entity.PersonalRoom, error)
+}
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// PollRepository 投票リポジトリのインターフェース
+type PollRepository interface {
+	// 投票を選択肢と合わせて作成
+	Create(ctx context.Context, poll *entity.Poll) error
+	// 投票を選択肢と合わせて取得
+	FindByID(ctx context.Context, id int64) (*entity.Poll, error)
+	// ルームの投票一覧を作成順に取得
+	FindByRoomID(ctx context.Context, roomID int64) ([]*entity.Poll, error)
+	// 投票者の投票を置き換え（締め切り済みの場合はentity.ErrPollClosed）
+	ReplaceVotes(ctx context.Context, pollID int64, voterKey string, votes []*entity.PollVote) error
+	// 投票の一覧を取得
+	FindVotes(ctx context.Context, pollID int64) ([]*entity.PollVote, error)
+	// 投票を締め切り、選択肢ごとの最終得票数を集計して保存（締め切り済みの場合はentity.ErrPollClosed）
+	Close(ctx context.Context, pollID int64, closedAt time.Time) error
+}
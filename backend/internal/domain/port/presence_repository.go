@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// PresenceRepository 在席状態リポジトリのインターフェース
+type PresenceRepository interface {
+	// 在席状態を複数取得（保存されていないユーザーは含まない）
+	FindByUserIDs(ctx context.Context, userIDs []int64) ([]*entity.UserPresence, error)
+	// ユーザーが設定した状態を保存
+	SaveStatus(ctx context.Context, userID int64, status entity.PresenceStatus) error
+	// 最終接続日時を保存
+	UpdateLastSeen(ctx context.Context, userID int64, at time.Time) error
+}
@@ -0,0 +1,27 @@
+package port
+
+import (
+	"context"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// PushSubscriptionRepository Web Push購読リポジトリのインターフェース
+type PushSubscriptionRepository interface {
+	// 購読を保存（同じエンドポイントが登録済みの場合は鍵と所有者を更新）
+	Save(ctx context.Context, subscription *entity.PushSubscription) error
+	// ユーザーの購読一覧
+	FindByUserID(ctx context.Context, userID int64) ([]*entity.PushSubscription, error)
+	// ユーザーの購読をエンドポイントで削除
+	DeleteByEndpoint(ctx context.Context, userID int64, endpoint string) error
+	// 購読を削除（無効になった購読の掃除用）
+	Delete(ctx context.Context, id int64) error
+}
+
+// PushService Web Pushのプッシュサービスへ通知を送るインターフェース
+type PushService interface {
+	// 購読先へ通知を送信（購読が無効になっている場合はentity.ErrPushSubscriptionGone）
+	Send(ctx context.Context, subscription *entity.PushSubscription, payload []byte, options entity.PushOptions) error
+	// ブラウザの購読登録に使うVAPIDの公開鍵
+	PublicKey() string
+}
@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// RecordingMixRepository 録音のミックスリポジトリのインターフェース
+type RecordingMixRepository interface {
+	// ミックス作成
+	Create(ctx context.Context, mix *entity.RecordingMix) error
+	// ミックス更新（ステータス・トラック・保存したファイルなど）
+	Update(ctx context.Context, mix *entity.RecordingMix) error
+	// ミックス取得（IDで検索）
+	FindByID(ctx context.Context, id int64) (*entity.RecordingMix, error)
+	// ルームの最新のミックスを取得（ない場合はErrRecordingMixNotFound）
+	FindLatestByRoomID(ctx context.Context, roomID int64) (*entity.RecordingMix, error)
+	// 実行待ちのミックス、またはリースが切れた実行中のミックスを1件確保する
+	// 確保したミックスは実行中になり、実行回数が増える（ない場合はErrRecordingMixNotFound）
+	Claim(ctx context.Context, owner string, now time.Time, leaseUntil time.Time) (*entity.RecordingMix, error)
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// RecordingUploadRepository 録音アップロードリポジトリのインターフェース
+type RecordingUploadRepository interface {
+	// アップロード作成
+	Create(ctx context.Context, upload *entity.RecordingUpload) error
+	// アップロード取得（ない場合はErrUploadNotFound）
+	FindByID(ctx context.Context, id string) (*entity.RecordingUpload, error)
+	// 受信済みのサイズ・チャンク・期限を更新
+	// 受信済みのサイズがexpectedOffsetのまま（同時に届いた他のチャンクがない）場合のみ更新し、更新したらtrue
+	UpdateProgress(ctx context.Context, upload *entity.RecordingUpload, expectedOffset int64) (bool, error)
+	// 作成した録音を記録して完了にする
+	Complete(ctx context.Context, id string, recordingID int64) error
+	// アップロード削除
+	Delete(ctx context.Context, id string) error
+	// 期限を過ぎたアップロードを取得（完了済みを含む）
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.RecordingUpload, error)
+}
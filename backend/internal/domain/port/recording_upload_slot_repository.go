@@ -0,0 +1,26 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// RecordingUploadSlotRepository 直接アップロードの枠リポジトリのインターフェース
+type RecordingUploadSlotRepository interface {
+	// 枠作成
+	Create(ctx context.Context, slot *entity.RecordingUploadSlot) error
+	// 枠取得（ない場合はErrUploadNotFound）
+	FindByID(ctx context.Context, id string) (*entity.RecordingUploadSlot, error)
+	// 完了の処理を始める（未完了で、他に処理中でないかstaleBefore以前に始めた処理のみ。始めたらtrue）
+	StartFinalizing(ctx context.Context, id string, now time.Time, staleBefore time.Time) (bool, error)
+	// 完了の処理をやめる（録音の登録に失敗した場合）
+	CancelFinalizing(ctx context.Context, id string) error
+	// 作成した録音を記録して完了にする
+	Complete(ctx context.Context, id string, recordingID int64) error
+	// 枠削除
+	Delete(ctx context.Context, id string) error
+	// 期限を過ぎた枠を取得（完了済みを含む）
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]*entity.RecordingUploadSlot, error)
+}
@@ -0,0 +1,7 @@
+package port
+
+// RoomConnections ルームへのシグナリング接続の状態を参照するインターフェース
+type RoomConnections interface {
+	// ルームに接続中のクライアントがいるか
+	HasConnections(roomID string) bool
+}
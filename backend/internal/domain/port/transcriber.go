@@ -0,0 +1,50 @@
+package port
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrAudioNotSupported 文字起こしエンジンがこの音声の場所・形式を扱えない
+// （Google Speech-to-TextはGCS上のファイルのみ扱えるなど）
+var ErrAudioNotSupported = errors.New("audio is not supported by the transcriber")
+
+// ErrLanguageNotSupported 文字起こしエンジンが指定された言語の条件（自動判定・候補の言語）を扱えない
+var ErrLanguageNotSupported = errors.New("language options are not supported by the transcriber")
+
+// TranscriptionAudio 文字起こしする音声
+type TranscriptionAudio struct {
+	URI         string // オブジェクトストレージ上の場所（gs://bucket/key など）
+	ContentType string
+	// Open 音声データを読み込む（ローカルで処理するエンジンが使う。呼び出し側で閉じる）
+	Open func(ctx context.Context) (io.ReadCloser, error)
+}
+
+// TranscribeOptions 文字起こしの条件
+type TranscribeOptions struct {
+	Language string // BCP-47の言語コード（ja-JP など）。空の場合は話された言語を自動で判定する
+	// 他に話される可能性のある言語。指定した場合はLanguageと合わせた候補の中から話された言語を判定する
+	AlternativeLanguages []string
+	Diarization          bool // 話者を識別する
+}
+
+// TranscriptSegment 文字起こし結果の1区間
+type TranscriptSegment struct {
+	Text       string
+	SpeakerTag int     // 話者番号（1から。識別しない場合は0）
+	Confidence float64 // 0〜1（エンジンが返さない場合は0）
+	StartTime  float64 // 音声の先頭からの秒数
+	EndTime    float64
+	Language   string // 判定した言語（BCP-47。エンジンが返さない場合は空）
+}
+
+// Transcriber 音声を文字起こしするエンジンのインターフェース
+type Transcriber interface {
+	// エンジン名（google、whisper など。文字起こしの実行に記録する）
+	Name() string
+	// 言語の条件を扱えるか確認（扱えない場合はErrLanguageNotSupported）
+	SupportsLanguages(opts TranscribeOptions) error
+	// 音声を文字起こしし、時刻順の区間を返す
+	Transcribe(ctx context.Context, audio TranscriptionAudio, opts TranscribeOptions) ([]TranscriptSegment, error)
+}
@@ -0,0 +1,29 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// TranscriptionJobRepository 文字起こしジョブリポジトリのインターフェース
+type TranscriptionJobRepository interface {
+	// ジョブ作成（ルームに実行待ち・実行中のジョブがある場合はErrTranscriptionJobActive）
+	Create(ctx context.Context, job *entity.TranscriptionJob) error
+	// ジョブ更新（ステータス・進捗・リースなど）
+	Update(ctx context.Context, job *entity.TranscriptionJob) error
+	// ジョブ取得（IDで検索）
+	FindByID(ctx context.Context, id int64) (*entity.TranscriptionJob, error)
+	// ルームの実行待ち・実行中のジョブを取得（ない場合はErrTranscriptionJobNotFound）
+	FindActiveByRoomID(ctx context.Context, roomID int64) (*entity.TranscriptionJob, error)
+	// ルームのジョブ一覧（新しい順）
+	FindByRoomID(ctx context.Context, roomID int64, limit int) ([]*entity.TranscriptionJob, error)
+	// 実行予定時刻を過ぎたジョブ、またはリースが切れた実行中のジョブを1件確保する
+	// 確保したジョブは実行中になり、実行回数が増える（ない場合はErrTranscriptionJobNotFound）
+	Claim(ctx context.Context, owner string, now time.Time, leaseUntil time.Time) (*entity.TranscriptionJob, error)
+	// 実行中のジョブの録音ごとの進捗を更新（他のワーカーに引き継がれていた場合はfalse）
+	UpdateItems(ctx context.Context, id int64, owner string, items []entity.TranscriptionJobItem) (bool, error)
+	// リースを延長（他のワーカーに引き継がれていた場合はfalse）
+	RenewLease(ctx context.Context, id int64, owner string, leaseUntil time.Time) (bool, error)
+}
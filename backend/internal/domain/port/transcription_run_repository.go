@@ -0,0 +1,23 @@
+package port
+
+import (
+	"context"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// TranscriptionRunRepository 文字起こしの実行リポジトリのインターフェース
+type TranscriptionRunRepository interface {
+	// 実行作成
+	Create(ctx context.Context, run *entity.TranscriptionRun) error
+	// 実行更新（状態・区間数・エラーなど。現在の実行かはSetCurrentで変更する）
+	Update(ctx context.Context, run *entity.TranscriptionRun) error
+	// 実行取得（IDで検索。ない場合はErrTranscriptionRunNotFound）
+	FindByID(ctx context.Context, id int64) (*entity.TranscriptionRun, error)
+	// ルームの実行一覧（新しい順）
+	FindByRoomID(ctx context.Context, roomID int64) ([]*entity.TranscriptionRun, error)
+	// ルームの現在の実行を取得（ない場合はErrTranscriptionRunNotFound）
+	FindCurrentByRoomID(ctx context.Context, roomID int64) (*entity.TranscriptionRun, error)
+	// ルームの現在の実行を切り替える（前の現在の実行の解除と同じトランザクションで行う）
+	SetCurrent(ctx context.Context, roomID int64, runID int64) error
+}
@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// UserDeviceRepository ログインに使われた端末リポジトリのインターフェース
+type UserDeviceRepository interface {
+	// 端末取得（ユーザーIDとハッシュで検索）
+	FindByHash(ctx context.Context, userID int64, hash string) (*entity.UserDevice, error)
+	// ユーザーの端末数
+	CountByUserID(ctx context.Context, userID int64) (int, error)
+	// 端末作成
+	Create(ctx context.Context, device *entity.UserDevice) error
+	// 最終ログイン日時とIPアドレスを更新
+	UpdateLastSeen(ctx context.Context, device *entity.UserDevice) error
+}
@@ -12,6 +12,9 @@ type UserRepository interface {
 	
 	// IDによるユーザー検索
 	FindByID(ctx context.Context, id int64) (*entity.User, error)
+
+	// 複数IDによるユーザー一括検索
+	FindByIDs(ctx context.Context, ids []int64) ([]*entity.User, error)
 	
 	// メールアドレスによるユーザー検索
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
@@ -0,0 +1,35 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"Go-Next-WebRTC/internal/domain/entity"
+)
+
+// WebhookRepository Webhookリポジトリのインターフェース
+type WebhookRepository interface {
+	// Webhook作成
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	// Webhook更新
+	Update(ctx context.Context, webhook *entity.Webhook) error
+	// Webhook削除（配信記録も削除）
+	Delete(ctx context.Context, id int64) error
+	// Webhook取得（IDで検索）
+	FindByID(ctx context.Context, id int64) (*entity.Webhook, error)
+	// ユーザーのWebhook一覧
+	FindByUserID(ctx context.Context, userID int64) ([]*entity.Webhook, error)
+	// ユーザーの有効なWebhook一覧
+	FindActiveByUserID(ctx context.Context, userID int64) ([]*entity.Webhook, error)
+
+	// 配信記録の作成
+	CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	// 配信記録の更新
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	// 配信記録の取得（IDで検索）
+	FindDeliveryByID(ctx context.Context, id int64) (*entity.WebhookDelivery, error)
+	// Webhookの配信記録一覧（新しい順）
+	FindDeliveriesByWebhookID(ctx context.Context, webhookID int64, limit int) ([]*entity.WebhookDelivery, error)
+	// 配信予定時刻を過ぎた配信待ちの記録一覧（古い順）
+	FindDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+}
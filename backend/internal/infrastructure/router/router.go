@@ -33,14 +33,70 @@ func NewRouter(handlers *types.Handlers, authRepo port.AuthRepository) http.Hand
 
 	// Call API（認証必須）
 	mux.HandleFunc("/api/calls/rooms", handlers.AuthMiddleware.Middleware(handleCallRoomsRoot(handlers)))
-	mux.HandleFunc("/api/calls/rooms/", handlers.AuthMiddleware.Middleware(handleCallRooms(handlers)))
+	mux.HandleFunc("/api/calls/rooms/", handlers.AuthMiddleware.GuestMiddleware(handleCallRooms(handlers)))
+
+	// 再開可能な録音アップロード（tus）
+	// OPTIONSは対応機能の問い合わせのため認証不要、作成は /api/calls/rooms/{room_id}/uploads
+	mux.HandleFunc("/api/uploads", methodFilter(http.MethodOptions, handlers.RecordingUploadHandler.Options))
+	mux.HandleFunc("/api/uploads/", handlers.AuthMiddleware.Middleware(handleRecordingUpload(handlers)))
+
+	// 通話履歴（認証必須）
+	mux.HandleFunc("/api/calls/history", handlers.AuthMiddleware.Middleware(methodFilter(http.MethodGet, handlers.HistoryHandler.GetHistory)))
+
+	// 1対1通話 API（認証必須）
+	mux.HandleFunc("/api/calls/direct", handlers.AuthMiddleware.Middleware(methodFilter(http.MethodPost, handlers.DirectCallHandler.StartCall)))
+	mux.HandleFunc("/api/calls/direct/missed", handlers.AuthMiddleware.Middleware(methodFilter(http.MethodGet, handlers.DirectCallHandler.GetMissedCalls)))
+	mux.HandleFunc("/api/calls/direct/", handlers.AuthMiddleware.Middleware(handleDirectCall(handlers)))
+
+	// 招待受諾（認証不要、招待トークンで検証）
+	mux.HandleFunc("/api/calls/invites/accept", methodFilter(http.MethodPost, handlers.InviteHandler.AcceptInvite))
+
+	// 定例会議シリーズ API（認証必須）
+	mux.HandleFunc("/api/calls/series", handlers.AuthMiddleware.Middleware(handleCallSeriesRoot(handlers)))
+	mux.HandleFunc("/api/calls/series/", handlers.AuthMiddleware.Middleware(handleCallSeries(handlers)))
+
+	// 個人ルーム API（認証必須）
+	mux.HandleFunc("/api/personal-room", handlers.AuthMiddleware.Middleware(handlePersonalRoom(handlers)))
+	mux.HandleFunc("/api/personal-room/sessions", handlers.AuthMiddleware.Middleware(methodFilter(http.MethodGet, handlers.PersonalRoomHandler.GetMySessions)))
+	mux.HandleFunc("/api/r/", handlers.AuthMiddleware.Middleware(handlePersonalRoomSlug(handlers)))
+
+	// 在席状態 API（認証必須）
+	mux.HandleFunc("/api/presence", handlers.AuthMiddleware.Middleware(handlePresence(handlers)))
+	mux.HandleFunc("/api/presence/contacts", handlers.AuthMiddleware.Middleware(methodFilter(http.MethodGet, handlers.PresenceHandler.GetContacts)))
+
+	// 通知 API（認証必須）
+	mux.HandleFunc("/api/notifications", handlers.AuthMiddleware.Middleware(methodFilter(http.MethodGet, handlers.NotificationHandler.List)))
+	mux.HandleFunc("/api/notifications/read-all", handlers.AuthMiddleware.Middleware(methodFilter(http.MethodPost, handlers.NotificationHandler.MarkAllRead)))
+	mux.HandleFunc("/api/notifications/", handlers.AuthMiddleware.Middleware(handleNotification(handlers)))
+	mux.HandleFunc("/api/push/public-key", handlers.AuthMiddleware.Middleware(methodFilter(http.MethodGet, handlers.PushHandler.GetPublicKey)))
+	mux.HandleFunc("/api/push/subscriptions", handlers.AuthMiddleware.Middleware(handlePushSubscriptions(handlers)))
+
+	// Webhook API（認証必須）
+	mux.HandleFunc("/api/webhooks", handlers.AuthMiddleware.Middleware(handleWebhooksRoot(handlers)))
+	mux.HandleFunc("/api/webhooks/", handlers.AuthMiddleware.Middleware(handleWebhooks(handlers)))
 
 	// WebSocketシグナリングエンドポイント（認証はクエリパラメータで行う）
 	mux.HandleFunc("/ws/signaling/", handlers.CallHandler.HandleSignaling)
 
+	// ロビー（アクティブなルーム一覧）のリアルタイム配信（認証はクエリパラメータで行う）
+	mux.HandleFunc("/ws/lobby", handlers.LobbyHandler.HandleLobby)
+
+	// ユーザー宛てイベント（着信・連絡先の在席状態など）のリアルタイム配信（認証はクエリパラメータで行う）
+	// 接続している間はオンラインとして扱う
+	mux.HandleFunc("/ws/user", handlers.UserEventHandler.HandleUserEvents)
+
 	// ミドルウェアの適用
 	var handler http.Handler = mux
 	handler = middleware.MaxBytes(handler)
+
+	// ローカルストレージの署名付きURL（認証は署名で行う）
+	// 録音を直接アップロードするため、本文のサイズは MAX_REQUEST_BODY_SIZE ではなく署名したサイズで制限する
+	if handlers.StorageHandler != nil {
+		root := http.NewServeMux()
+		root.Handle("/api/storage/", handlers.StorageHandler)
+		root.Handle("/", handler)
+		handler = root
+	}
 	handler = middleware.CORS(handler)
 	handler = middleware.Logger(handler)
 
@@ -102,25 +158,274 @@ func handleCallRoomsRoot(handlers *types.Handlers) http.HandlerFunc {
 }
 
 // handleCallRooms コールルーム処理
+// ゲストが利用できるのは参加・退出・ルーム情報取得と投票のみ
 func handleCallRooms(handlers *types.Handlers) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if strings.HasSuffix(r.URL.Path, "/join") {
+		if strings.Contains(r.URL.Path, "/polls") {
+			// 通話中の投票
+			handlePolls(handlers)(w, r)
+		} else if strings.Contains(r.URL.Path, "/participants/") {
+			// 参加者のロール変更・強制退出
+			if strings.HasSuffix(r.URL.Path, "/role") {
+				methodFilter(http.MethodPut, middleware.RequireUser(handlers.CallHandler.ChangeParticipantRole))(w, r)
+			} else {
+				methodFilter(http.MethodDelete, middleware.RequireUser(handlers.CallHandler.KickParticipant))(w, r)
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/join") {
 			methodFilter(http.MethodPost, handlers.CallHandler.JoinRoom)(w, r)
 		} else if strings.HasSuffix(r.URL.Path, "/leave") {
 			methodFilter(http.MethodPost, handlers.CallHandler.LeaveRoom)(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/access") {
+			switch r.Method {
+			case http.MethodGet:
+				middleware.RequireUser(handlers.CallHandler.GetRoomAccess)(w, r)
+			case http.MethodPut:
+				middleware.RequireUser(handlers.CallHandler.UpdateRoomAccess)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/invites") {
+			methodFilter(http.MethodPost, middleware.RequireUser(handlers.InviteHandler.CreateInvite))(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/invites/users") {
+			methodFilter(http.MethodPost, middleware.RequireUser(handlers.InviteHandler.InviteUser))(w, r)
 		} else if strings.HasSuffix(r.URL.Path, "/recordings") {
-			methodFilter(http.MethodPost, handlers.CallHandler.UploadRecording)(w, r)
+			methodFilter(http.MethodPost, middleware.RequireUser(handlers.CallHandler.UploadRecording))(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/uploads") {
+			methodFilter(http.MethodPost, middleware.RequireUser(handlers.RecordingUploadHandler.CreateUpload))(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/recording-slots") {
+			// ストレージへ直接アップロードする録音（署名付きURLの発行）
+			methodFilter(http.MethodPost, middleware.RequireUser(handlers.DirectUploadHandler.CreateSlot))(w, r)
+		} else if strings.Contains(r.URL.Path, "/recording-slots/") {
+			methodFilter(http.MethodPost, middleware.RequireUser(handlers.DirectUploadHandler.Finalize))(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/mixdown") {
+			// 参加者ごとの録音のミックス
+			switch r.Method {
+			case http.MethodGet:
+				middleware.RequireUser(handlers.RecordingMixHandler.GetMixdown)(w, r)
+			case http.MethodPost:
+				middleware.RequireUser(handlers.RecordingMixHandler.RequestMixdown)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
 		} else if strings.HasSuffix(r.URL.Path, "/transcribe") {
-			methodFilter(http.MethodPost, handlers.CallHandler.TranscribeCall)(w, r)
+			methodFilter(http.MethodPost, middleware.RequireUser(handlers.TranscriptionHandler.TranscribeCall))(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/transcription-settings") {
+			// 文字起こしの言語設定
+			switch r.Method {
+			case http.MethodGet:
+				middleware.RequireUser(handlers.CallHandler.GetTranscriptionSettings)(w, r)
+			case http.MethodPut:
+				middleware.RequireUser(handlers.CallHandler.UpdateTranscriptionSettings)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		} else if strings.HasSuffix(r.URL.Path, "/transcription-jobs") {
+			methodFilter(http.MethodGet, middleware.RequireUser(handlers.TranscriptionHandler.ListTranscriptionJobs))(w, r)
+		} else if strings.Contains(r.URL.Path, "/transcription-jobs/") {
+			methodFilter(http.MethodGet, middleware.RequireUser(handlers.TranscriptionHandler.GetTranscriptionJob))(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/transcription-runs") {
+			// 文字起こしの実行（再実行しても前回までの結果を残す）
+			methodFilter(http.MethodGet, middleware.RequireUser(handlers.TranscriptionHandler.ListTranscriptionRuns))(w, r)
+		} else if strings.Contains(r.URL.Path, "/transcription-runs/") && strings.HasSuffix(r.URL.Path, "/current") {
+			methodFilter(http.MethodPost, middleware.RequireUser(handlers.TranscriptionHandler.SetCurrentTranscriptionRun))(w, r)
+		} else if strings.Contains(r.URL.Path, "/transcription-runs/") {
+			methodFilter(http.MethodGet, middleware.RequireUser(handlers.TranscriptionHandler.GetTranscriptionRun))(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/notes") {
+			// 共有ノート（通話中の編集はシグナリングで同期）
+			methodFilter(http.MethodGet, handlers.NotesHandler.GetNotes)(w, r)
 		} else if strings.HasSuffix(r.URL.Path, "/minutes") {
-			methodFilter(http.MethodGet, handlers.CallHandler.GetMinutes)(w, r)
+			methodFilter(http.MethodGet, middleware.RequireUser(handlers.CallHandler.GetMinutes))(w, r)
 		} else {
 			// ルームIDのみのパス: GET(取得) or DELETE(削除)
 			switch r.Method {
 			case http.MethodGet:
 				handlers.CallHandler.GetRoom(w, r)
 			case http.MethodDelete:
-				handlers.CallHandler.DeleteRoom(w, r)
+				middleware.RequireUser(handlers.CallHandler.DeleteRoom)(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		}
+	}
+}
+
+// handleRecordingUpload /api/uploads/{id} の処理
+func handleRecordingUpload(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodHead:
+			handlers.RecordingUploadHandler.GetOffset(w, r)
+		case http.MethodPatch:
+			handlers.RecordingUploadHandler.WriteChunk(w, r)
+		case http.MethodDelete:
+			handlers.RecordingUploadHandler.Terminate(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// handlePolls /api/calls/rooms/{room_id}/polls 以下の処理
+func handlePolls(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/polls"):
+			switch r.Method {
+			case http.MethodGet:
+				handlers.PollHandler.ListPolls(w, r)
+			case http.MethodPost:
+				handlers.PollHandler.CreatePoll(w, r)
+			default:
+				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			}
+		case strings.HasSuffix(r.URL.Path, "/votes"):
+			methodFilter(http.MethodPost, handlers.PollHandler.Vote)(w, r)
+		case strings.HasSuffix(r.URL.Path, "/close"):
+			methodFilter(http.MethodPost, handlers.PollHandler.ClosePoll)(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// handleCallSeriesRoot /api/calls/series のルート処理
+func handleCallSeriesRoot(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handlers.SeriesHandler.GetMySeries(w, r)
+		case http.MethodPost:
+			handlers.SeriesHandler.CreateSeries(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// handleCallSeries 定例会議シリーズ処理
+func handleCallSeries(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/occurrences/") && strings.HasSuffix(r.URL.Path, "/room") {
+			methodFilter(http.MethodPost, handlers.SeriesHandler.OpenOccurrence)(w, r)
+		} else if strings.Contains(r.URL.Path, "/occurrences/") {
+			methodFilter(http.MethodPatch, handlers.SeriesHandler.EditOccurrence)(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/occurrences") {
+			methodFilter(http.MethodGet, handlers.SeriesHandler.ListOccurrences)(w, r)
+		} else {
+			methodFilter(http.MethodGet, handlers.SeriesHandler.GetSeries)(w, r)
+		}
+	}
+}
+
+// handlePersonalRoom /api/personal-room の処理
+func handlePersonalRoom(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handlers.PersonalRoomHandler.GetMyRoom(w, r)
+		case http.MethodPatch:
+			handlers.PersonalRoomHandler.UpdateMyRoom(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// handlePersonalRoomSlug /api/r/{slug} の処理
+func handlePersonalRoomSlug(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/session") {
+			methodFilter(http.MethodPost, handlers.PersonalRoomHandler.OpenSession)(w, r)
+		} else {
+			methodFilter(http.MethodGet, handlers.PersonalRoomHandler.GetBySlug)(w, r)
+		}
+	}
+}
+
+// handlePresence /api/presence の処理
+func handlePresence(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handlers.PresenceHandler.GetMyPresence(w, r)
+		case http.MethodPut:
+			handlers.PresenceHandler.UpdateMyPresence(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// handleNotification /api/notifications/{id}/read の処理
+func handleNotification(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/read") {
+			methodFilter(http.MethodPost, handlers.NotificationHandler.MarkRead)(w, r)
+		} else {
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// handlePushSubscriptions /api/push/subscriptions の処理
+func handlePushSubscriptions(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodPost:
+			handlers.PushHandler.Subscribe(w, r)
+		case http.MethodDelete:
+			handlers.PushHandler.Unsubscribe(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// handleDirectCall /api/calls/direct/{call_id}/{action} の処理
+func handleDirectCall(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/accept"):
+			methodFilter(http.MethodPost, handlers.DirectCallHandler.Accept)(w, r)
+		case strings.HasSuffix(r.URL.Path, "/decline"):
+			methodFilter(http.MethodPost, handlers.DirectCallHandler.Decline)(w, r)
+		case strings.HasSuffix(r.URL.Path, "/cancel"):
+			methodFilter(http.MethodPost, handlers.DirectCallHandler.Cancel)(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+// handleWebhooksRoot /api/webhooks のルート処理
+func handleWebhooksRoot(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			handlers.WebhookHandler.GetWebhooks(w, r)
+		case http.MethodPost:
+			handlers.WebhookHandler.CreateWebhook(w, r)
+		default:
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// handleWebhooks Webhook処理
+func handleWebhooks(handlers *types.Handlers) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/replay") {
+			methodFilter(http.MethodPost, handlers.WebhookHandler.ReplayDelivery)(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/deliveries") {
+			methodFilter(http.MethodGet, handlers.WebhookHandler.GetDeliveries)(w, r)
+		} else {
+			switch r.Method {
+			case http.MethodGet:
+				handlers.WebhookHandler.GetWebhook(w, r)
+			case http.MethodPatch:
+				handlers.WebhookHandler.UpdateWebhook(w, r)
+			case http.MethodDelete:
+				handlers.WebhookHandler.DeleteWebhook(w, r)
 			default:
 				http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 			}
@@ -0,0 +1,278 @@
+// Package crdt 共同編集用のテキストCRDT
+//
+// RGA（Replicated Growable Array）で1文字を1要素として扱う。
+// 各要素は (Lamportクロック, サイト) の組のIDを持ち、直前の要素のIDを指定して挿入する。
+// 同じ位置への同時挿入はIDの大きい方を前に並べるため、操作の適用順によらず全員の文書が収束する。
+// 削除は墓標（tombstone）として残し、後から届いた挿入の位置を決められるようにする。
+package crdt
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	// ErrInvalidOp 操作の形式が不正
+	ErrInvalidOp = errors.New("invalid crdt operation")
+	// ErrUnknownElement 操作が参照する要素が存在しない（因果関係の順序が守られていない）
+	ErrUnknownElement = errors.New("unknown crdt element")
+	// ErrDocumentTooLarge 要素数（削除済みを含む）が上限を超える
+	ErrDocumentTooLarge = errors.New("crdt document too large")
+)
+
+// MaxElements 1つの文書が持てる要素数（削除済みを含む）の上限
+const MaxElements = 200000
+
+// ID 要素の識別子
+type ID struct {
+	Clock uint64 `json:"c"`
+	Site  string `json:"s"`
+}
+
+// IsZero 文書の先頭を表すゼロ値か
+func (id ID) IsZero() bool {
+	return id.Clock == 0 && id.Site == ""
+}
+
+// Less IDの順序（クロック、同じ場合はサイトで比較）
+func (id ID) Less(other ID) bool {
+	if id.Clock != other.Clock {
+		return id.Clock < other.Clock
+	}
+	return id.Site < other.Site
+}
+
+// OpType 操作の種類
+type OpType string
+
+const (
+	OpInsert OpType = "insert"
+	OpDelete OpType = "delete"
+)
+
+// Op 文書への操作
+type Op struct {
+	Type OpType `json:"type"`
+	// 挿入する要素のID、または削除する要素のID
+	ID ID `json:"id"`
+	// 挿入位置の直前の要素（ゼロ値は文書の先頭）
+	After ID `json:"after,omitempty"`
+	// 挿入する1文字
+	Char string `json:"char,omitempty"`
+}
+
+// Element 文書の要素（スナップショットの形式）
+type Element struct {
+	ID      ID     `json:"id"`
+	Char    string `json:"char"`
+	Deleted bool   `json:"deleted,omitempty"`
+}
+
+type element struct {
+	Element
+	next *element
+}
+
+// Document RGAの文書（並行して使う場合は呼び出し側で排他する）
+type Document struct {
+	head   element
+	index  map[ID]*element
+	clock  uint64
+	length int
+}
+
+// New 空の文書を作成
+func New() *Document {
+	return &Document{index: make(map[ID]*element)}
+}
+
+// Restore スナップショットの要素列から文書を復元
+func Restore(elements []Element) (*Document, error) {
+	if len(elements) > MaxElements {
+		return nil, ErrDocumentTooLarge
+	}
+	d := New()
+	last := &d.head
+	for _, e := range elements {
+		if err := validate(e.ID, e.Char); err != nil {
+			return nil, err
+		}
+		if _, ok := d.index[e.ID]; ok {
+			return nil, ErrInvalidOp
+		}
+		el := &element{Element: e}
+		last.next = el
+		last = el
+		d.index[e.ID] = el
+		d.observe(e.ID)
+		if !e.Deleted {
+			d.length++
+		}
+	}
+	return d, nil
+}
+
+// Apply 操作を適用（適用済みの操作は無視してfalseを返す）
+func (d *Document) Apply(op Op) (bool, error) {
+	switch op.Type {
+	case OpInsert:
+		return d.insert(op)
+	case OpDelete:
+		return d.delete(op)
+	default:
+		return false, ErrInvalidOp
+	}
+}
+
+func (d *Document) insert(op Op) (bool, error) {
+	if err := validate(op.ID, op.Char); err != nil {
+		return false, err
+	}
+	if _, ok := d.index[op.ID]; ok {
+		return false, nil
+	}
+	if len(d.index) >= MaxElements {
+		return false, ErrDocumentTooLarge
+	}
+
+	prev := &d.head
+	if !op.After.IsZero() {
+		ref, ok := d.index[op.After]
+		if !ok {
+			return false, ErrUnknownElement
+		}
+		prev = ref
+	}
+	// 同じ位置に同時に挿入された、より大きいIDの要素（とその後に続けて挿入された要素）の後ろに並べる
+	for prev.next != nil && op.ID.Less(prev.next.ID) {
+		prev = prev.next
+	}
+
+	el := &element{Element: Element{ID: op.ID, Char: op.Char}, next: prev.next}
+	prev.next = el
+	d.index[op.ID] = el
+	d.observe(op.ID)
+	d.length++
+	return true, nil
+}
+
+func (d *Document) delete(op Op) (bool, error) {
+	el, ok := d.index[op.ID]
+	if !ok {
+		return false, ErrUnknownElement
+	}
+	if el.Deleted {
+		return false, nil
+	}
+	el.Deleted = true
+	d.length--
+	return true, nil
+}
+
+// validate 要素のIDと文字を検証
+func validate(id ID, char string) error {
+	if id.Clock == 0 || id.Site == "" || utf8.RuneCountInString(char) != 1 || !utf8.ValidString(char) {
+		return ErrInvalidOp
+	}
+	return nil
+}
+
+// observe Lamportクロックを進める
+func (d *Document) observe(id ID) {
+	if id.Clock > d.clock {
+		d.clock = id.Clock
+	}
+}
+
+// Clock 文書内の最大のクロック（新しい要素はこれより大きいクロックで作る）
+func (d *Document) Clock() uint64 {
+	return d.clock
+}
+
+// Len 表示される文字数
+func (d *Document) Len() int {
+	return d.length
+}
+
+// Text 表示されるテキスト
+func (d *Document) Text() string {
+	var sb strings.Builder
+	for el := d.head.next; el != nil; el = el.next {
+		if !el.Deleted {
+			sb.WriteString(el.Char)
+		}
+	}
+	return sb.String()
+}
+
+// Elements 削除済みを含む要素列（スナップショット）
+func (d *Document) Elements() []Element {
+	elements := make([]Element, 0, len(d.index))
+	for el := d.head.next; el != nil; el = el.next {
+		elements = append(elements, el.Element)
+	}
+	return elements
+}
+
+// InsertText 表示位置posにテキストを挿入する操作を作成して適用
+// クライアントと同じ手順で操作を作るためのもの（サーバーはApplyのみ使う）
+func (d *Document) InsertText(site string, pos int, text string) ([]Op, error) {
+	after, err := d.visibleID(pos)
+	if err != nil {
+		return nil, err
+	}
+	ops := make([]Op, 0, utf8.RuneCountInString(text))
+	for _, r := range text {
+		op := Op{Type: OpInsert, ID: ID{Clock: d.clock + 1, Site: site}, After: after, Char: string(r)}
+		if _, err := d.Apply(op); err != nil {
+			return ops, err
+		}
+		ops = append(ops, op)
+		after = op.ID
+	}
+	return ops, nil
+}
+
+// DeleteText 表示位置posからn文字を削除する操作を作成して適用
+func (d *Document) DeleteText(pos, n int) ([]Op, error) {
+	if pos < 0 || n < 0 || pos+n > d.length {
+		return nil, ErrInvalidOp
+	}
+	ops := make([]Op, 0, n)
+	i := 0
+	for el := d.head.next; el != nil && len(ops) < n; el = el.next {
+		if el.Deleted {
+			continue
+		}
+		if i >= pos {
+			ops = append(ops, Op{Type: OpDelete, ID: el.ID})
+		}
+		i++
+	}
+	for _, op := range ops {
+		d.Apply(op)
+	}
+	return ops, nil
+}
+
+// visibleID 表示位置posの直前にある要素のID（先頭の場合はゼロ値）
+func (d *Document) visibleID(pos int) (ID, error) {
+	if pos < 0 || pos > d.length {
+		return ID{}, ErrInvalidOp
+	}
+	if pos == 0 {
+		return ID{}, nil
+	}
+	i := 0
+	for el := d.head.next; el != nil; el = el.next {
+		if el.Deleted {
+			continue
+		}
+		i++
+		if i == pos {
+			return el.ID, nil
+		}
+	}
+	return ID{}, ErrInvalidOp
+}
@@ -0,0 +1,180 @@
+package crdt
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+)
+
+// replica 同じ文書を編集する1人分の複製
+func replica(t *testing.T, base *Document) *Document {
+	t.Helper()
+	d, err := Restore(base.Elements())
+	if err != nil {
+		t.Fatalf("Restore() unexpected error = %v", err)
+	}
+	return d
+}
+
+// applyAll 操作をまとめて適用
+func applyAll(t *testing.T, d *Document, ops []Op) {
+	t.Helper()
+	for _, op := range ops {
+		if _, err := d.Apply(op); err != nil {
+			t.Fatalf("Apply(%+v) unexpected error = %v", op, err)
+		}
+	}
+}
+
+func TestDocument_LocalEdits(t *testing.T) {
+	d := New()
+	if _, err := d.InsertText("a", 0, "Hello world"); err != nil {
+		t.Fatalf("InsertText() unexpected error = %v", err)
+	}
+	if _, err := d.InsertText("a", 5, ","); err != nil {
+		t.Fatalf("InsertText() unexpected error = %v", err)
+	}
+	if _, err := d.DeleteText(0, 1); err != nil {
+		t.Fatalf("DeleteText() unexpected error = %v", err)
+	}
+	if _, err := d.InsertText("a", 0, "h"); err != nil {
+		t.Fatalf("InsertText() unexpected error = %v", err)
+	}
+
+	if got := d.Text(); got != "hello, world" {
+		t.Errorf("Text() = %q, want %q", got, "hello, world")
+	}
+	if d.Len() != 12 || len(d.Elements()) != 13 {
+		t.Errorf("Len() = %d, elements = %d, want 12 visible and 1 tombstone", d.Len(), len(d.Elements()))
+	}
+	if _, err := d.DeleteText(10, 5); !errors.Is(err, ErrInvalidOp) {
+		t.Errorf("DeleteText() past the end error = %v, want ErrInvalidOp", err)
+	}
+}
+
+func TestDocument_ConcurrentInsertsConverge(t *testing.T) {
+	base := New()
+	base.InsertText("server", 0, "ac")
+
+	alice, bob := replica(t, base), replica(t, base)
+	aliceOps, _ := alice.InsertText("alice", 1, "XY")
+	bobOps, _ := bob.InsertText("bob", 1, "12")
+
+	// 届く順序が逆でも同じ文書になる
+	applyAll(t, alice, bobOps)
+	applyAll(t, bob, aliceOps)
+	if alice.Text() != bob.Text() {
+		t.Fatalf("replicas diverged: %q vs %q", alice.Text(), bob.Text())
+	}
+	// 同じ位置への挿入は混ざらずにまとまる
+	if got := alice.Text(); got != "aXY12c" && got != "a12XYc" {
+		t.Errorf("Text() = %q, want the two insertions kept contiguous", got)
+	}
+}
+
+func TestDocument_ApplyIsIdempotent(t *testing.T) {
+	d := New()
+	ops, _ := d.InsertText("a", 0, "abc")
+	del, _ := d.DeleteText(1, 1)
+
+	other := New()
+	applyAll(t, other, ops)
+	applyAll(t, other, del)
+	for _, op := range append(ops, del...) {
+		applied, err := other.Apply(op)
+		if err != nil || applied {
+			t.Errorf("Apply(%+v) again = %v, %v, want ignored", op, applied, err)
+		}
+	}
+	if other.Text() != "ac" {
+		t.Errorf("Text() = %q, want %q", other.Text(), "ac")
+	}
+}
+
+func TestDocument_ApplyErrors(t *testing.T) {
+	d := New()
+	ops, _ := d.InsertText("a", 0, "a")
+
+	tests := []struct {
+		name        string
+		op          Op
+		expectedErr error
+	}{
+		{name: "unknown type", op: Op{Type: "move", ID: ID{Clock: 5, Site: "b"}}, expectedErr: ErrInvalidOp},
+		{name: "zero clock", op: Op{Type: OpInsert, ID: ID{Site: "b"}, Char: "x"}, expectedErr: ErrInvalidOp},
+		{name: "missing site", op: Op{Type: OpInsert, ID: ID{Clock: 5}, Char: "x"}, expectedErr: ErrInvalidOp},
+		{name: "two characters", op: Op{Type: OpInsert, ID: ID{Clock: 5, Site: "b"}, Char: "xy"}, expectedErr: ErrInvalidOp},
+		{name: "unknown reference", op: Op{Type: OpInsert, ID: ID{Clock: 5, Site: "b"}, After: ID{Clock: 9, Site: "z"}, Char: "x"}, expectedErr: ErrUnknownElement},
+		{name: "unknown delete", op: Op{Type: OpDelete, ID: ID{Clock: 9, Site: "z"}}, expectedErr: ErrUnknownElement},
+		{name: "multibyte character", op: Op{Type: OpInsert, ID: ID{Clock: 5, Site: "b"}, After: ops[0].ID, Char: "議"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := d.Apply(tt.op); !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Apply() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestDocument_RandomEditsConverge(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	base := New()
+	base.InsertText("server", 0, "shared notes")
+
+	for round := 0; round < 50; round++ {
+		sites := []string{"a", "b", "c"}
+		replicas := make([]*Document, len(sites))
+		edits := make([][]Op, len(sites))
+		for i, site := range sites {
+			replicas[i] = replica(t, base)
+			// 各サイトがほかのサイトの編集を知らないまま編集する
+			for n := 0; n < 5; n++ {
+				d := replicas[i]
+				var ops []Op
+				if d.Len() > 0 && rng.Intn(3) == 0 {
+					ops, _ = d.DeleteText(rng.Intn(d.Len()), 1)
+				} else {
+					ops, _ = d.InsertText(site, rng.Intn(d.Len()+1), string(rune('A'+rng.Intn(26))))
+				}
+				edits[i] = append(edits[i], ops...)
+			}
+		}
+
+		// 他のサイトの編集をそれぞれ異なる順序で受け取る
+		for i := range replicas {
+			order := rng.Perm(len(sites))
+			for _, j := range order {
+				if j != i {
+					applyAll(t, replicas[i], edits[j])
+				}
+			}
+		}
+		for i := 1; i < len(replicas); i++ {
+			if replicas[i].Text() != replicas[0].Text() {
+				t.Fatalf("round %d: replicas diverged: %q vs %q", round, replicas[i].Text(), replicas[0].Text())
+			}
+		}
+		base = replicas[0]
+	}
+}
+
+func TestRestore(t *testing.T) {
+	d := New()
+	d.InsertText("a", 0, "notes")
+	d.DeleteText(0, 1)
+
+	restored, err := Restore(d.Elements())
+	if err != nil {
+		t.Fatalf("Restore() unexpected error = %v", err)
+	}
+	if restored.Text() != "otes" || restored.Clock() != d.Clock() || restored.Len() != 4 {
+		t.Errorf("restored = %q (clock %d), want %q (clock %d)", restored.Text(), restored.Clock(), "otes", d.Clock())
+	}
+
+	duplicated := append(d.Elements(), d.Elements()[0])
+	if _, err := Restore(duplicated); !errors.Is(err, ErrInvalidOp) {
+		t.Errorf("Restore() with duplicate IDs error = %v, want ErrInvalidOp", err)
+	}
+}
@@ -0,0 +1,184 @@
+// Package ffmpeg ffmpeg をサブプロセスで実行する音声のミックス・ラウドネスの正規化
+//
+// 参加者ごとの録音を開始位置に合わせて1つにミックスしたり、音量を揃えたコピーを作ったりする。
+// 出力はOgg（Opus・モノラル）で、標準出力から読み込みながらストレージへ保存できる。
+// amix の normalize オプションを使うため ffmpeg 4.4 以降が必要。
+package ffmpeg
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContentType 出力する音声のContent-Type
+const ContentType = "audio/ogg"
+
+// Config 実行するコマンドと出力の設定
+type Config struct {
+	Binary   string  // ffmpeg
+	Bitrate  string  // Opusのビットレート（64k など）
+	Loudness float64 // 目標の統合ラウドネス（LUFS。-16 など）
+	TruePeak float64 // 目標のトゥルーピーク（dBTP。-1.5 など）
+}
+
+// Input ミックスする音声ファイル
+type Input struct {
+	Path   string
+	Offset time.Duration // ミックスの先頭から再生を始める位置
+}
+
+// Client ffmpegのクライアント
+type Client struct {
+	cfg Config
+}
+
+// NewClient 新しいクライアントを作成
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Binary == "" {
+		cfg.Binary = "ffmpeg"
+	}
+	if cfg.Bitrate == "" {
+		cfg.Bitrate = "64k"
+	}
+	if cfg.Loudness == 0 {
+		cfg.Loudness = -16
+	}
+	if cfg.TruePeak == 0 {
+		cfg.TruePeak = -1.5
+	}
+	if _, err := exec.LookPath(cfg.Binary); err != nil {
+		return nil, fmt.Errorf("ffmpeg: %s not found: %w", cfg.Binary, err)
+	}
+	return &Client{cfg: cfg}, nil
+}
+
+// Mix 音声を開始位置に合わせて1つにミックス
+// 各入力の音量を正規化してから重ね、ミックス全体も正規化する。
+// 呼び出し側で閉じる。変換に失敗した場合は読み込みの最後にエラーを返す
+func (c *Client) Mix(ctx context.Context, inputs []Input) (io.ReadCloser, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("ffmpeg: no inputs to mix")
+	}
+	args := []string{"-nostdin", "-loglevel", "error"}
+	for _, in := range inputs {
+		args = append(args, "-i", in.Path)
+	}
+	args = append(args,
+		"-filter_complex", c.mixFilter(inputs),
+		"-map", "[out]",
+	)
+	return c.start(ctx, append(args, c.outputArgs()...))
+}
+
+// Normalize 音声の音量（ラウドネス）を正規化
+// 呼び出し側で閉じる。変換に失敗した場合は読み込みの最後にエラーを返す
+func (c *Client) Normalize(ctx context.Context, path string) (io.ReadCloser, error) {
+	args := []string{
+		"-nostdin", "-loglevel", "error",
+		"-i", path,
+		"-af", c.loudnorm() + ",aresample=48000",
+	}
+	return c.start(ctx, append(args, c.outputArgs()...))
+}
+
+// mixFilter ミックスのフィルターグラフ
+// 入力ごとに正規化して開始位置まで無音で遅らせ、最も長い入力に合わせて重ねる
+func (c *Client) mixFilter(inputs []Input) string {
+	var sb strings.Builder
+	for i, in := range inputs {
+		fmt.Fprintf(&sb, "[%d:a]%s,aresample=48000,adelay=%d:all=1[a%d];", i, c.loudnorm(), in.Offset.Milliseconds(), i)
+	}
+	for i := range inputs {
+		fmt.Fprintf(&sb, "[a%d]", i)
+	}
+	// normalize=0: 入力数で音量を割らない（話していない参加者の分だけ小さくならないように）
+	fmt.Fprintf(&sb, "amix=inputs=%d:duration=longest:dropout_transition=0:normalize=0,%s,aresample=48000[out]",
+		len(inputs), c.loudnorm())
+	return sb.String()
+}
+
+// loudnorm EBU R128のラウドネス正規化（1パス）
+func (c *Client) loudnorm() string {
+	return "loudnorm=I=" + formatFloat(c.cfg.Loudness) + ":TP=" + formatFloat(c.cfg.TruePeak) + ":LRA=11"
+}
+
+// outputArgs Ogg（Opus・モノラル）で標準出力に書き出す
+func (c *Client) outputArgs() []string {
+	return []string{"-ac", "1", "-c:a", "libopus", "-b:a", c.cfg.Bitrate, "-f", "ogg", "pipe:1"}
+}
+
+// start コマンドを開始して標準出力を返す
+func (c *Client) start(ctx context.Context, args []string) (io.ReadCloser, error) {
+	cmd := exec.CommandContext(ctx, c.cfg.Binary, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	out := &output{stdout: stdout, cmd: cmd}
+	cmd.Stderr = &out.stderr
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("ffmpeg: failed to start: %w", err)
+	}
+	return out, nil
+}
+
+// output 実行中のffmpegの標準出力
+// 最後まで読み込んだときに終了を待ち、失敗していれば標準エラー出力をエラーとして返す
+type output struct {
+	stdout io.ReadCloser
+	cmd    *exec.Cmd
+	stderr bytes.Buffer
+	waited bool
+	err    error
+}
+
+func (o *output) Read(p []byte) (int, error) {
+	n, err := o.stdout.Read(p)
+	if err == io.EOF {
+		if werr := o.wait(); werr != nil {
+			return n, werr
+		}
+	}
+	return n, err
+}
+
+// Close 途中で閉じた場合はffmpegを終了させる
+func (o *output) Close() error {
+	if !o.waited && o.cmd.Process != nil {
+		o.cmd.Process.Kill()
+	}
+	o.wait()
+	return nil
+}
+
+func (o *output) wait() error {
+	if o.waited {
+		return o.err
+	}
+	o.waited = true
+	if err := o.cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(o.stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, lastLine(msg))
+		}
+		o.err = fmt.Errorf("ffmpeg: %w", err)
+	}
+	return o.err
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,90 @@
+package ffmpeg
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestMain FFMPEG_TEST_FAKE が設定されている場合はffmpegの代わりとして動く
+func TestMain(m *testing.M) {
+	if os.Getenv("FFMPEG_TEST_FAKE") != "" {
+		os.Exit(fakeCommand(os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+// fakeCommand 入力ファイルの内容を順に標準出力へ書き出す（読めない入力があれば失敗する）
+func fakeCommand(args []string) int {
+	for i := 0; i+1 < len(args); i++ {
+		if args[i] != "-i" {
+			continue
+		}
+		data, err := os.ReadFile(args[i+1])
+		if err != nil || len(data) == 0 {
+			fmt.Fprintln(os.Stderr, "first line")
+			fmt.Fprintf(os.Stderr, "%s: Invalid data found when processing input\n", args[i+1])
+			return 1
+		}
+		os.Stdout.Write(data)
+	}
+	return 0
+}
+
+func TestClient_MixFilter(t *testing.T) {
+	c := &Client{cfg: Config{Loudness: -16, TruePeak: -1.5}}
+	filter := c.mixFilter([]Input{
+		{Path: "a.webm"},
+		{Path: "b.webm", Offset: 1500 * time.Millisecond},
+	})
+
+	want := "[0:a]loudnorm=I=-16:TP=-1.5:LRA=11,aresample=48000,adelay=0:all=1[a0];" +
+		"[1:a]loudnorm=I=-16:TP=-1.5:LRA=11,aresample=48000,adelay=1500:all=1[a1];" +
+		"[a0][a1]amix=inputs=2:duration=longest:dropout_transition=0:normalize=0," +
+		"loudnorm=I=-16:TP=-1.5:LRA=11,aresample=48000[out]"
+	if filter != want {
+		t.Errorf("mixFilter() =\n%s\nwant\n%s", filter, want)
+	}
+}
+
+func TestClient_Mix(t *testing.T) {
+	t.Setenv("FFMPEG_TEST_FAKE", "1")
+	dir := t.TempDir()
+	a, b := dir+"/a.webm", dir+"/b.webm"
+	os.WriteFile(a, []byte("alice "), 0o644)
+	os.WriteFile(b, []byte("bob"), 0o644)
+
+	client, err := NewClient(Config{Binary: os.Args[0]})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error = %v", err)
+	}
+
+	out, err := client.Mix(context.Background(), []Input{{Path: a}, {Path: b, Offset: time.Second}})
+	if err != nil {
+		t.Fatalf("Mix() unexpected error = %v", err)
+	}
+	data, err := io.ReadAll(out)
+	out.Close()
+	if err != nil || string(data) != "alice bob" {
+		t.Errorf("Mix() output = %q, %v, want %q", data, err, "alice bob")
+	}
+
+	// 変換に失敗した場合は読み込みの最後にコマンドのエラー出力を返す
+	out, err = client.Normalize(context.Background(), dir+"/missing.webm")
+	if err != nil {
+		t.Fatalf("Normalize() unexpected error = %v", err)
+	}
+	_, err = io.ReadAll(out)
+	out.Close()
+	if err == nil || !strings.Contains(err.Error(), "Invalid data") {
+		t.Errorf("Normalize() of missing input error = %v, want the ffmpeg message", err)
+	}
+
+	if _, err := client.Mix(context.Background(), nil); err == nil {
+		t.Error("Mix() without inputs error = nil")
+	}
+}
@@ -0,0 +1,86 @@
+package invite
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestSigner(now time.Time) *Signer {
+	s := NewSigner([]byte("test-secret-key-that-is-long-enough"))
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestSigner_SignAndVerify(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	s := newTestSigner(now)
+
+	token, err := s.Sign(Claims{
+		Kind:      KindInvite,
+		RoomID:    "room-1",
+		IssuedBy:  42,
+		ExpiresAt: now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("Sign() unexpected error = %v", err)
+	}
+
+	claims, err := s.Verify(token, KindInvite)
+	if err != nil {
+		t.Fatalf("Verify() unexpected error = %v", err)
+	}
+	if claims.RoomID != "room-1" || claims.IssuedBy != 42 {
+		t.Errorf("Verify() claims = %+v", claims)
+	}
+	if claims.IssuedAt != now.Unix() {
+		t.Errorf("Verify() IssuedAt = %d, want %d", claims.IssuedAt, now.Unix())
+	}
+}
+
+func TestSigner_Verify(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	s := newTestSigner(now)
+
+	valid, _ := s.Sign(Claims{Kind: KindGuest, RoomID: "room-1", GuestID: "g1", ExpiresAt: now.Add(time.Hour).Unix()})
+	expired, _ := s.Sign(Claims{Kind: KindGuest, RoomID: "room-1", GuestID: "g1", ExpiresAt: now.Add(-time.Second).Unix()})
+	other, _ := NewSigner([]byte("another-secret")).Sign(Claims{Kind: KindGuest, RoomID: "room-1", ExpiresAt: now.Add(time.Hour).Unix()})
+
+	parts := strings.Split(valid, ".")
+	tampered := parts[0] + "." + parts[1] + "x." + parts[2]
+
+	tests := []struct {
+		name        string
+		token       string
+		kind        Kind
+		expectedErr error
+	}{
+		{name: "valid", token: valid, kind: KindGuest},
+		{name: "wrong kind", token: valid, kind: KindInvite, expectedErr: ErrInvalidToken},
+		{name: "expired", token: expired, kind: KindGuest, expectedErr: ErrExpiredToken},
+		{name: "different secret", token: other, kind: KindGuest, expectedErr: ErrInvalidToken},
+		{name: "tampered payload", token: tampered, kind: KindGuest, expectedErr: ErrInvalidToken},
+		{name: "malformed", token: "not-a-token", kind: KindGuest, expectedErr: ErrInvalidToken},
+		{name: "empty", token: "", kind: KindGuest, expectedErr: ErrInvalidToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.Verify(tt.token, tt.kind)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Verify() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestSigner_SignRequiresFields(t *testing.T) {
+	s := NewSigner([]byte("secret"))
+	if _, err := s.Sign(Claims{Kind: KindInvite, ExpiresAt: time.Now().Unix()}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Sign() without room error = %v, want ErrInvalidToken", err)
+	}
+	if _, err := s.Sign(Claims{Kind: KindInvite, RoomID: "room-1"}); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("Sign() without expiry error = %v, want ErrInvalidToken", err)
+	}
+}
@@ -0,0 +1,524 @@
+// Package rrule は RFC 5545 の RRULE（繰り返しルール）の解析と展開を行う
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency 繰り返しの頻度
+type Frequency int
+
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+var weekdayNames = map[time.Weekday]string{
+	time.Sunday:    "SU",
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+}
+
+// maxEmptyPeriods 該当日が見つからない期間が続いた場合に展開を打ち切る上限
+const maxEmptyPeriods = 1000
+
+// DateTimeFormat UNTIL / EXDATE で使う UTC の日時形式
+const DateTimeFormat = "20060102T150405Z"
+
+// エラー定義
+var (
+	ErrInvalidRule   = errors.New("invalid rrule")
+	ErrMissingFreq   = errors.New("rrule: FREQ is required")
+	ErrCountAndUntil = errors.New("rrule: COUNT and UNTIL must not be used together")
+)
+
+// Weekday 曜日指定（N は月内・年内の序数。0 の場合は全ての該当曜日）
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// String BYDAY 形式の文字列を返す
+func (w Weekday) String() string {
+	if w.N == 0 {
+		return weekdayNames[w.Day]
+	}
+	return strconv.Itoa(w.N) + weekdayNames[w.Day]
+}
+
+// Rule 繰り返しルール
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []Weekday
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+	WeekStart  time.Weekday
+}
+
+// Parse RRULE 文字列を解析する（"RRULE:" プレフィックスは省略可）
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(s, "RRULE:")
+	if s == "" {
+		return nil, ErrMissingFreq
+	}
+
+	r := &Rule{Interval: 1, WeekStart: time.Monday, Freq: -1}
+	for _, part := range strings.Split(s, ";") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		key, value := strings.ToUpper(kv[0]), strings.ToUpper(kv[1])
+
+		var err error
+		switch key {
+		case "FREQ":
+			r.Freq, err = parseFrequency(value)
+		case "INTERVAL":
+			r.Interval, err = parsePositiveInt(value)
+		case "COUNT":
+			r.Count, err = parsePositiveInt(value)
+		case "UNTIL":
+			r.Until, err = parseUntil(value)
+		case "BYDAY":
+			r.ByDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.ByMonthDay, err = parseIntList(value, -31, 31)
+		case "BYMONTH":
+			var months []int
+			months, err = parseIntList(value, 1, 12)
+			for _, m := range months {
+				r.ByMonth = append(r.ByMonth, time.Month(m))
+			}
+		case "BYSETPOS":
+			r.BySetPos, err = parseIntList(value, -366, 366)
+		case "WKST":
+			r.WeekStart, err = parseWeekday(value)
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %q", ErrInvalidRule, key)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.Freq < 0 {
+		return nil, ErrMissingFreq
+	}
+	if r.Count > 0 && !r.Until.IsZero() {
+		return nil, ErrCountAndUntil
+	}
+
+	return r, nil
+}
+
+// String RRULE 形式の文字列を返す（"RRULE:" プレフィックスなし）
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + frequencyNames[r.Freq]}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(DateTimeFormat))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, d := range r.ByDay {
+			days[i] = d.String()
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByMonth) > 0 {
+		months := make([]int, len(r.ByMonth))
+		for i, m := range r.ByMonth {
+			months[i] = int(m)
+		}
+		parts = append(parts, "BYMONTH="+joinInts(months))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayNames[r.WeekStart])
+	}
+	return strings.Join(parts, ";")
+}
+
+// Iterator DTSTART から順に発生日時を返すイテレーター
+type Iterator struct {
+	rule    *Rule
+	dtstart time.Time
+	period  int
+	buffer  []time.Time
+	emitted int
+	done    bool
+}
+
+// Iterator 指定した DTSTART（タイムゾーンを含む）で展開するイテレーターを返す
+func (r *Rule) Iterator(dtstart time.Time) *Iterator {
+	return &Iterator{rule: r, dtstart: dtstart}
+}
+
+// Next 次の発生日時を返す。これ以上ない場合は false を返す
+func (it *Iterator) Next() (time.Time, bool) {
+	if it.done {
+		return time.Time{}, false
+	}
+	if it.rule.Count > 0 && it.emitted >= it.rule.Count {
+		it.done = true
+		return time.Time{}, false
+	}
+
+	empty := 0
+	for len(it.buffer) == 0 {
+		if empty >= maxEmptyPeriods {
+			it.done = true
+			return time.Time{}, false
+		}
+		candidates := it.rule.expand(it.dtstart, it.period)
+		it.period++
+
+		for _, c := range candidates {
+			if c.Before(it.dtstart) {
+				continue
+			}
+			it.buffer = append(it.buffer, c)
+		}
+		if len(it.buffer) == 0 {
+			empty++
+		}
+	}
+
+	next := it.buffer[0]
+	it.buffer = it.buffer[1:]
+
+	if !it.rule.Until.IsZero() && next.After(it.rule.Until) {
+		it.done = true
+		return time.Time{}, false
+	}
+
+	it.emitted++
+	return next, true
+}
+
+// Between after 以上 before 未満の発生日時を最大 limit 件返す（limit <= 0 は無制限）
+func (r *Rule) Between(dtstart, after, before time.Time, limit int) []time.Time {
+	var result []time.Time
+	it := r.Iterator(dtstart)
+	for {
+		t, ok := it.Next()
+		if !ok || !t.Before(before) {
+			break
+		}
+		if t.Before(after) {
+			continue
+		}
+		result = append(result, t)
+		if limit > 0 && len(result) >= limit {
+			break
+		}
+	}
+	return result
+}
+
+// expand n 番目の期間に含まれる候補日時を昇順で返す
+func (r *Rule) expand(dtstart time.Time, n int) []time.Time {
+	loc := dtstart.Location()
+	hour, min, sec := dtstart.Clock()
+	step := n * r.Interval
+
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		day := dateOf(dtstart).AddDate(0, 0, step)
+		if r.matchesMonth(day) && r.matchesMonthDay(day) && r.matchesWeekday(day) {
+			days = append(days, day)
+		}
+	case Weekly:
+		offset := (int(dtstart.Weekday()) - int(r.WeekStart) + 7) % 7
+		weekStart := dateOf(dtstart).AddDate(0, 0, -offset+7*step)
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []Weekday{{Day: dtstart.Weekday()}}
+		}
+		for i := 0; i < 7; i++ {
+			day := weekStart.AddDate(0, 0, i)
+			if containsWeekday(byDay, day.Weekday()) && r.matchesMonth(day) {
+				days = append(days, day)
+			}
+		}
+	case Monthly:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(step), 1, 0, 0, 0, 0, time.UTC)
+		if r.matchesMonth(first) {
+			days = r.expandMonth(first, dtstart)
+		}
+	case Yearly:
+		year := dtstart.Year() + step
+		switch {
+		case len(r.ByMonth) > 0:
+			for _, m := range sortedMonths(r.ByMonth) {
+				days = append(days, r.expandMonth(time.Date(year, m, 1, 0, 0, 0, 0, time.UTC), dtstart)...)
+			}
+		case len(r.ByDay) > 0 || len(r.ByMonthDay) > 0:
+			days = r.expandYearByDay(year)
+		default:
+			day := time.Date(year, dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, time.UTC)
+			if day.Month() == dtstart.Month() {
+				days = append(days, day)
+			}
+		}
+	}
+
+	days = applySetPos(days, r.BySetPos)
+
+	result := make([]time.Time, 0, len(days))
+	for _, d := range days {
+		result = append(result, time.Date(d.Year(), d.Month(), d.Day(), hour, min, sec, 0, loc))
+	}
+	return result
+}
+
+// expandMonth 月内の該当日を返す（BYDAY / BYMONTHDAY が無い場合は DTSTART の日）
+func (r *Rule) expandMonth(first, dtstart time.Time) []time.Time {
+	monthDays := r.ByMonthDay
+	if len(monthDays) == 0 && len(r.ByDay) == 0 {
+		monthDays = []int{dtstart.Day()}
+	}
+
+	last := first.AddDate(0, 1, -1).Day()
+	var days []time.Time
+	for d := 1; d <= last; d++ {
+		day := first.AddDate(0, 0, d-1)
+		if len(monthDays) > 0 && !matchesDayList(monthDays, d, last) {
+			continue
+		}
+		if len(r.ByDay) > 0 && !matchesOrdinalWeekday(r.ByDay, day, d, last) {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+// expandYearByDay 年内の序数付き曜日・日付指定を展開する
+func (r *Rule) expandYearByDay(year int) []time.Time {
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	total := first.AddDate(1, 0, 0).Sub(first).Hours() / 24
+
+	var days []time.Time
+	for i := 0; i < int(total); i++ {
+		day := first.AddDate(0, 0, i)
+		if len(r.ByMonthDay) > 0 {
+			last := time.Date(year, day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+			if !matchesDayList(r.ByMonthDay, day.Day(), last) {
+				continue
+			}
+		}
+		if len(r.ByDay) > 0 && !matchesOrdinalWeekday(r.ByDay, day, i+1, int(total)) {
+			continue
+		}
+		days = append(days, day)
+	}
+	return days
+}
+
+func (r *Rule) matchesMonth(t time.Time) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if t.Month() == m {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchesMonthDay(t time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	last := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	return matchesDayList(r.ByMonthDay, t.Day(), last)
+}
+
+func (r *Rule) matchesWeekday(t time.Time) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	return containsWeekday(r.ByDay, t.Weekday())
+}
+
+// matchesDayList 正負の日付指定（-1 は最終日）に一致するか
+func matchesDayList(list []int, day, last int) bool {
+	for _, d := range list {
+		if d == day || (d < 0 && last+d+1 == day) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesOrdinalWeekday 序数付き曜日指定（例: 2MO, -1FR）に一致するか
+func matchesOrdinalWeekday(list []Weekday, t time.Time, index, total int) bool {
+	for _, w := range list {
+		if w.Day != t.Weekday() {
+			continue
+		}
+		if w.N == 0 {
+			return true
+		}
+		if w.N > 0 && (index-1)/7+1 == w.N {
+			return true
+		}
+		if w.N < 0 && (total-index)/7+1 == -w.N {
+			return true
+		}
+	}
+	return false
+}
+
+func containsWeekday(list []Weekday, day time.Weekday) bool {
+	for _, w := range list {
+		if w.Day == day {
+			return true
+		}
+	}
+	return false
+}
+
+// applySetPos BYSETPOS で期間内の候補を絞り込む
+func applySetPos(days []time.Time, setPos []int) []time.Time {
+	if len(setPos) == 0 || len(days) == 0 {
+		return days
+	}
+	var result []time.Time
+	for _, pos := range setPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			result = append(result, days[i])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+	return result
+}
+
+func sortedMonths(months []time.Month) []time.Month {
+	sorted := append([]time.Month(nil), months...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+// dateOf 日付部分のみを UTC の 0 時として返す（夏時間の影響を避けるため）
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func parseFrequency(s string) (Frequency, error) {
+	for f, name := range frequencyNames {
+		if name == s {
+			return f, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, s)
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	for d, name := range weekdayNames {
+		if name == s {
+			return d, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: invalid weekday %q", ErrInvalidRule, s)
+}
+
+func parseByDay(s string) ([]Weekday, error) {
+	var result []Weekday
+	for _, item := range strings.Split(s, ",") {
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%w: invalid BYDAY %q", ErrInvalidRule, item)
+		}
+		day, err := parseWeekday(item[len(item)-2:])
+		if err != nil {
+			return nil, err
+		}
+		n := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err = strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -53 || n > 53 {
+				return nil, fmt.Errorf("%w: invalid BYDAY ordinal %q", ErrInvalidRule, item)
+			}
+		}
+		result = append(result, Weekday{Day: day, N: n})
+	}
+	return result, nil
+}
+
+func parseUntil(s string) (time.Time, error) {
+	for _, layout := range []string{DateTimeFormat, "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: invalid UNTIL %q", ErrInvalidRule, s)
+}
+
+func parsePositiveInt(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%w: expected positive integer, got %q", ErrInvalidRule, s)
+	}
+	return n, nil
+}
+
+func parseIntList(s string, min, max int) ([]int, error) {
+	var result []int
+	for _, item := range strings.Split(s, ",") {
+		n, err := strconv.Atoi(item)
+		if err != nil || n == 0 || n < min || n > max {
+			return nil, fmt.Errorf("%w: value %q out of range", ErrInvalidRule, item)
+		}
+		result = append(result, n)
+	}
+	return result, nil
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ",")
+}
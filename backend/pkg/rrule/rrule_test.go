@@ -0,0 +1,205 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("timezone %s not available: %v", name, err)
+	}
+	return loc
+}
+
+func collect(t *testing.T, rule string, dtstart time.Time, max int) []time.Time {
+	t.Helper()
+	r, err := Parse(rule)
+	if err != nil {
+		t.Fatalf("Parse(%q) error = %v", rule, err)
+	}
+	var result []time.Time
+	it := r.Iterator(dtstart)
+	for len(result) < max {
+		next, ok := it.Next()
+		if !ok {
+			break
+		}
+		result = append(result, next)
+	}
+	return result
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{name: "daily", rule: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{name: "with prefix", rule: "RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR", want: "FREQ=WEEKLY;BYDAY=MO,WE,FR"},
+		{name: "ordinal weekday", rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", want: "FREQ=MONTHLY;COUNT=3;BYDAY=-1FR"},
+		{name: "until", rule: "FREQ=DAILY;INTERVAL=2;UNTIL=20261231T000000Z", want: "FREQ=DAILY;INTERVAL=2;UNTIL=20261231T000000Z"},
+		{name: "lowercase", rule: "freq=yearly;bymonth=3", want: "FREQ=YEARLY;BYMONTH=3"},
+		{name: "missing freq", rule: "INTERVAL=2", wantErr: true},
+		{name: "empty", rule: "", wantErr: true},
+		{name: "count and until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20261231T000000Z", wantErr: true},
+		{name: "unsupported part", rule: "FREQ=HOURLY", wantErr: true},
+		{name: "bad interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "bad weekday", rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "bad month day", rule: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := Parse(tt.rule)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Parse(%q) error = nil, wantErr true", tt.rule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error = %v", tt.rule, err)
+			}
+			if got := r.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIterator(t *testing.T) {
+	utc := time.UTC
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		max     int
+		want    []string
+	}{
+		{
+			name:    "daily count",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: time.Date(2026, 10, 19, 9, 0, 0, 0, utc),
+			max:     10,
+			want:    []string{"2026-10-19T09:00:00Z", "2026-10-20T09:00:00Z", "2026-10-21T09:00:00Z"},
+		},
+		{
+			name:    "weekdays only",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR",
+			dtstart: time.Date(2026, 10, 22, 9, 0, 0, 0, utc), // 木曜日
+			max:     4,
+			want:    []string{"2026-10-22T09:00:00Z", "2026-10-23T09:00:00Z", "2026-10-26T09:00:00Z", "2026-10-27T09:00:00Z"},
+		},
+		{
+			name:    "biweekly default weekday",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			dtstart: time.Date(2026, 10, 19, 9, 0, 0, 0, utc),
+			max:     10,
+			want:    []string{"2026-10-19T09:00:00Z", "2026-11-02T09:00:00Z", "2026-11-16T09:00:00Z"},
+		},
+		{
+			name:    "last friday of month",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: time.Date(2026, 10, 1, 17, 0, 0, 0, utc),
+			max:     3,
+			want:    []string{"2026-10-30T17:00:00Z", "2026-11-27T17:00:00Z", "2026-12-25T17:00:00Z"},
+		},
+		{
+			name:    "monthly skips invalid dates",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3",
+			dtstart: time.Date(2026, 10, 31, 10, 0, 0, 0, utc),
+			max:     10,
+			want:    []string{"2026-10-31T10:00:00Z", "2026-12-31T10:00:00Z", "2027-01-31T10:00:00Z"},
+		},
+		{
+			name:    "last weekday via setpos",
+			rule:    "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=2",
+			dtstart: time.Date(2026, 10, 1, 10, 0, 0, 0, utc),
+			max:     10,
+			want:    []string{"2026-10-30T10:00:00Z", "2026-11-30T10:00:00Z"},
+		},
+		{
+			name:    "yearly",
+			rule:    "FREQ=YEARLY;COUNT=2",
+			dtstart: time.Date(2026, 4, 1, 10, 0, 0, 0, utc),
+			max:     10,
+			want:    []string{"2026-04-01T10:00:00Z", "2027-04-01T10:00:00Z"},
+		},
+		{
+			name:    "until is inclusive",
+			rule:    "FREQ=DAILY;UNTIL=20261021T090000Z",
+			dtstart: time.Date(2026, 10, 19, 9, 0, 0, 0, utc),
+			max:     10,
+			want:    []string{"2026-10-19T09:00:00Z", "2026-10-20T09:00:00Z", "2026-10-21T09:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collect(t, tt.rule, tt.dtstart, tt.max)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d occurrences %v, want %d", len(got), got, len(tt.want))
+			}
+			for i, g := range got {
+				if g.UTC().Format(time.RFC3339) != tt.want[i] {
+					t.Errorf("occurrence[%d] = %s, want %s", i, g.UTC().Format(time.RFC3339), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIterator_KeepsWallClockAcrossDST(t *testing.T) {
+	ny := mustLoad(t, "America/New_York")
+	dtstart := time.Date(2026, 10, 30, 9, 30, 0, 0, ny)
+
+	got := collect(t, "FREQ=DAILY;COUNT=4", dtstart, 10)
+	if len(got) != 4 {
+		t.Fatalf("got %d occurrences, want 4", len(got))
+	}
+	for _, g := range got {
+		if h, m, _ := g.Clock(); h != 9 || m != 30 {
+			t.Errorf("occurrence %s lost wall clock time", g)
+		}
+	}
+	// 11/1 に夏時間が終わるため UTC オフセットが変わる
+	if got[0].UTC().Hour() == got[3].UTC().Hour() {
+		t.Errorf("expected UTC hour to shift across DST, got %s and %s", got[0].UTC(), got[3].UTC())
+	}
+}
+
+func TestBetween(t *testing.T) {
+	r, err := Parse("FREQ=DAILY")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dtstart := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
+	after := time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)
+
+	got := r.Between(dtstart, after, before, 0)
+	if len(got) != 10 {
+		t.Fatalf("Between() returned %d occurrences, want 10", len(got))
+	}
+	if !got[0].Equal(time.Date(2026, 10, 10, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("first occurrence = %s", got[0])
+	}
+
+	limited := r.Between(dtstart, after, before, 3)
+	if len(limited) != 3 {
+		t.Errorf("Between() with limit returned %d occurrences, want 3", len(limited))
+	}
+}
+
+func TestIterator_NoMatchTerminates(t *testing.T) {
+	// 2月30日は存在しないため発生日時は無い
+	got := collect(t, "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), 1)
+	if len(got) != 0 {
+		t.Errorf("expected no occurrences, got %v", got)
+	}
+}
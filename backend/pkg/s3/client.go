@@ -0,0 +1,271 @@
+// Package s3 S3互換ストレージ（AWS S3・MinIOなど）のクライアント
+//
+// 署名バージョン4でリクエストに署名し、オブジェクトの保存・取得・一覧と署名付きURLの発行を行う。
+// 本文は署名せず（UNSIGNED-PAYLOAD）にストリーミングで送信する。
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound オブジェクトが存在しない
+var ErrNotFound = errors.New("s3: object not found")
+
+// Config クライアントの設定
+type Config struct {
+	Endpoint        string // https://s3.ap-northeast-1.amazonaws.com、http://localhost:9000 など
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool // バケットをホスト名ではなくパスで指定する（MinIOなど）
+	HTTPClient      *http.Client
+}
+
+// Object オブジェクトの情報
+type Object struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// Client S3互換ストレージのクライアント
+type Client struct {
+	endpoint    *url.URL
+	bucket      string
+	pathStyle   bool
+	credentials Credentials
+	http        *http.Client
+	now         func() time.Time
+}
+
+// NewClient 新しいクライアントを作成
+func NewClient(cfg Config) (*Client, error) {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("s3: invalid endpoint %q", cfg.Endpoint)
+	}
+	if cfg.Bucket == "" || cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, errors.New("s3: bucket and credentials are required")
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		endpoint:  endpoint,
+		bucket:    cfg.Bucket,
+		pathStyle: cfg.PathStyle,
+		credentials: Credentials{
+			AccessKeyID:     cfg.AccessKeyID,
+			SecretAccessKey: cfg.SecretAccessKey,
+			Region:          region,
+		},
+		http: httpClient,
+		now:  time.Now,
+	}, nil
+}
+
+// Bucket バケット名
+func (c *Client) Bucket() string {
+	return c.bucket
+}
+
+// PutObject オブジェクトを保存（sizeは本文のバイト数）
+func (c *Client) PutObject(ctx context.Context, key string, body io.Reader, size int64, contentType string) (*Object, error) {
+	req, err := c.newRequest(ctx, http.MethodPut, key, nil, body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := c.do(req, unsignedPayload)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return &Object{
+		Key:          key,
+		Size:         size,
+		ContentType:  contentType,
+		ETag:         strings.Trim(resp.Header.Get("ETag"), `"`),
+		LastModified: c.now(),
+	}, nil
+}
+
+// GetObject オブジェクトを取得（呼び出し側で本文を閉じる）
+func (c *Client) GetObject(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, key, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := c.do(req, emptyPayload)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Body, objectFromHeader(key, resp), nil
+}
+
+// HeadObject オブジェクトの情報を取得
+func (c *Client) HeadObject(ctx context.Context, key string) (*Object, error) {
+	req, err := c.newRequest(ctx, http.MethodHead, key, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(req, emptyPayload)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return objectFromHeader(key, resp), nil
+}
+
+// DeleteObject オブジェクトを削除（存在しない場合も成功）
+func (c *Client) DeleteObject(ctx context.Context, key string) error {
+	req, err := c.newRequest(ctx, http.MethodDelete, key, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.do(req, emptyPayload)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// listBucketResult ListObjectsV2の応答
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		ETag         string    `xml:"ETag"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// ListObjects プレフィックスに一致するオブジェクトを全て取得（Content-Typeは含まない）
+func (c *Client) ListObjects(ctx context.Context, prefix string) ([]*Object, error) {
+	var objects []*Object
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := c.newRequest(ctx, http.MethodGet, "", query, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.do(req, emptyPayload)
+		if err != nil {
+			return nil, err
+		}
+
+		var result listBucketResult
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("s3: failed to decode list response: %w", err)
+		}
+		for _, content := range result.Contents {
+			objects = append(objects, &Object{
+				Key:          content.Key,
+				Size:         content.Size,
+				ETag:         strings.Trim(content.ETag, `"`),
+				LastModified: content.LastModified,
+			})
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return objects, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// PresignURL 署名付きURLを作成
+// headersに指定したヘッダー（Content-Type・Content-Lengthなど）は、URLの利用者が同じ値で送る必要がある
+func (c *Client) PresignURL(method, key string, headers http.Header, expires time.Duration) string {
+	return c.credentials.PresignURL(method, c.objectURL(key, nil), headers, expires, c.now())
+}
+
+// objectURL オブジェクト（keyが空の場合はバケット）のURL
+func (c *Client) objectURL(key string, query url.Values) *url.URL {
+	u := *c.endpoint
+	path := "/" + key
+	if c.pathStyle {
+		path = "/" + c.bucket + path
+	} else {
+		u.Host = c.bucket + "." + u.Host
+	}
+	u.Path = strings.TrimSuffix(c.endpoint.Path, "/") + path
+	u.RawPath = ""
+	u.RawQuery = query.Encode()
+	return &u
+}
+
+func (c *Client) newRequest(ctx context.Context, method, key string, query url.Values, body io.Reader) (*http.Request, error) {
+	return http.NewRequestWithContext(ctx, method, c.objectURL(key, query).String(), body)
+}
+
+// do リクエストに署名して送信し、エラー応答をエラーに変換
+func (c *Client) do(req *http.Request, payloadHash string) (*http.Response, error) {
+	c.credentials.SignRequest(req, payloadHash, c.now())
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 300 {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrNotFound
+	}
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, fmt.Errorf("s3: %s %s: unexpected status %d: %s", req.Method, req.URL.Path, resp.StatusCode, strings.TrimSpace(string(message)))
+}
+
+// objectFromHeader 応答ヘッダーからオブジェクトの情報を取得
+func objectFromHeader(key string, resp *http.Response) *Object {
+	object := &Object{
+		Key:         key,
+		Size:        resp.ContentLength,
+		ContentType: resp.Header.Get("Content-Type"),
+		ETag:        strings.Trim(resp.Header.Get("ETag"), `"`),
+	}
+	if size, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil {
+		object.Size = size
+	}
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		object.LastModified = modified
+	}
+	return object
+}
@@ -0,0 +1,188 @@
+// Package s3test テスト用のS3互換サーバー（MinIOの代わりに使うインメモリのスタンドイン）
+//
+// パス形式（/{bucket}/{key}）のPUT・GET・HEAD・DELETEとListObjectsV2に対応し、
+// 全てのリクエストの署名（Authorizationヘッダー・署名付きURL）を検証する。
+package s3test
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"Go-Next-WebRTC/pkg/s3"
+)
+
+// テスト用の認証情報
+const (
+	AccessKeyID     = "minioadmin"
+	SecretAccessKey = "minioadmin-secret"
+	Region          = "us-east-1"
+)
+
+type object struct {
+	data         []byte
+	contentType  string
+	etag         string
+	lastModified time.Time
+}
+
+// Server インメモリのS3互換サーバー
+type Server struct {
+	*httptest.Server
+	credentials s3.Credentials
+
+	mu      sync.Mutex
+	buckets map[string]map[string]*object
+}
+
+// NewServer バケットを作成してサーバーを起動（テスト終了時にCloseする）
+func NewServer(buckets ...string) *Server {
+	s := &Server{
+		credentials: s3.Credentials{AccessKeyID: AccessKeyID, SecretAccessKey: SecretAccessKey, Region: Region},
+		buckets:     make(map[string]map[string]*object),
+	}
+	for _, bucket := range buckets {
+		s.buckets[bucket] = make(map[string]*object)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Config サーバーに接続するクライアントの設定
+func (s *Server) Config(bucket string) s3.Config {
+	return s3.Config{
+		Endpoint:        s.URL,
+		Region:          Region,
+		Bucket:          bucket,
+		AccessKeyID:     AccessKeyID,
+		SecretAccessKey: SecretAccessKey,
+		PathStyle:       true,
+	}
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if err := s.credentials.Verify(r, time.Now()); err != nil {
+		writeError(w, http.StatusForbidden, "SignatureDoesNotMatch")
+		return
+	}
+
+	bucketName, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket, ok := s.buckets[bucketName]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	switch {
+	case key == "" && r.Method == http.MethodGet:
+		s.list(w, r, bucket)
+	case r.Method == http.MethodPut:
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+		sum := md5.Sum(data)
+		obj := &object{
+			data:         data,
+			contentType:  r.Header.Get("Content-Type"),
+			etag:         hex.EncodeToString(sum[:]),
+			lastModified: time.Now().UTC().Truncate(time.Second),
+		}
+		bucket[key] = obj
+		w.Header().Set("ETag", `"`+obj.etag+`"`)
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		obj, ok := bucket[key]
+		if !ok {
+			writeError(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.data)))
+		w.Header().Set("ETag", `"`+obj.etag+`"`)
+		w.Header().Set("Last-Modified", obj.lastModified.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			w.Write(obj.data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(bucket, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// listResult ListObjectsV2の応答（1ページ2件で返し、継続トークンも検証する）
+type listResult struct {
+	XMLName  xml.Name `xml:"ListBucketResult"`
+	Contents []struct {
+		Key          string `xml:"Key"`
+		Size         int    `xml:"Size"`
+		ETag         string `xml:"ETag"`
+		LastModified string `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken,omitempty"`
+}
+
+// listPageSize 1ページの件数（ページングを確認するため小さくしている）
+const listPageSize = 2
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request, bucket map[string]*object) {
+	prefix := r.URL.Query().Get("prefix")
+	keys := make([]string, 0, len(bucket))
+	for key := range bucket {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	start := 0
+	if token := r.URL.Query().Get("continuation-token"); token != "" {
+		start = sort.SearchStrings(keys, token)
+	}
+	end := start + listPageSize
+	if end > len(keys) {
+		end = len(keys)
+	}
+
+	var result listResult
+	for _, key := range keys[start:end] {
+		obj := bucket[key]
+		result.Contents = append(result.Contents, struct {
+			Key          string `xml:"Key"`
+			Size         int    `xml:"Size"`
+			ETag         string `xml:"ETag"`
+			LastModified string `xml:"LastModified"`
+		}{key, len(obj.data), `"` + obj.etag + `"`, obj.lastModified.Format(time.RFC3339)})
+	}
+	if end < len(keys) {
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[end]
+	}
+
+	w.Header().Set("Content-Type", "application/xml")
+	xml.NewEncoder(w).Encode(result)
+}
+
+func writeError(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	xml.NewEncoder(w).Encode(struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string   `xml:"Code"`
+	}{Code: code})
+}
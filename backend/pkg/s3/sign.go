@@ -0,0 +1,226 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 署名バージョン4（SigV4）の定数
+const (
+	algorithm       = "AWS4-HMAC-SHA256"
+	service         = "s3"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	emptyPayload    = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	amzDateFormat   = "20060102T150405Z"
+	shortDateFormat = "20060102"
+)
+
+// Credentials 署名に使うアクセスキー
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+}
+
+// scope 認証情報のスコープ（日付/リージョン/s3/aws4_request）
+func (c Credentials) scope(t time.Time) string {
+	return t.Format(shortDateFormat) + "/" + c.Region + "/" + service + "/aws4_request"
+}
+
+// signingKey 日付・リージョン・サービスで派生させた署名鍵
+func (c Credentials) signingKey(t time.Time) []byte {
+	key := hmacSHA256([]byte("AWS4"+c.SecretAccessKey), t.Format(shortDateFormat))
+	key = hmacSHA256(key, c.Region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+// SignRequest リクエストにAuthorizationヘッダーを付与
+// payloadHashは本文のSHA-256（16進）か、検証しない場合はUNSIGNED-PAYLOAD
+func (c Credentials) SignRequest(req *http.Request, payloadHash string, t time.Time) {
+	t = t.UTC()
+	req.Header.Set("X-Amz-Date", t.Format(amzDateFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := signedHeaderNames(req.Header)
+	signature := c.signature(req.Method, req.URL, req.Host, req.Header, headers, payloadHash, t)
+	req.Header.Set("Authorization", algorithm+
+		" Credential="+c.AccessKeyID+"/"+c.scope(t)+
+		", SignedHeaders="+strings.Join(headers, ";")+
+		", Signature="+signature)
+}
+
+// PresignURL 署名をクエリパラメータに含めたURLを作成
+// headersは利用者が同じ値で送る必要があるヘッダー（Content-Typeなど）
+func (c Credentials) PresignURL(method string, u *url.URL, headers http.Header, expires time.Duration, t time.Time) string {
+	t = t.UTC()
+	signed := u.Query()
+	names := signedHeaderNames(headers)
+
+	signed.Set("X-Amz-Algorithm", algorithm)
+	signed.Set("X-Amz-Credential", c.AccessKeyID+"/"+c.scope(t))
+	signed.Set("X-Amz-Date", t.Format(amzDateFormat))
+	signed.Set("X-Amz-Expires", strconv.Itoa(int(expires/time.Second)))
+	signed.Set("X-Amz-SignedHeaders", strings.Join(names, ";"))
+
+	presigned := *u
+	presigned.RawQuery = canonicalQuery(signed)
+	signature := c.signature(method, &presigned, u.Host, headers, names, unsignedPayload, t)
+	presigned.RawQuery += "&X-Amz-Signature=" + signature
+	return presigned.String()
+}
+
+// ErrSignatureMismatch 署名が一致しない、または署名付きURLの有効期限切れ
+var ErrSignatureMismatch = errors.New("s3: signature does not match")
+
+// Verify 受け取ったリクエストの署名を検証（S3互換サーバーのスタンドイン用）
+// Authorizationヘッダーと署名付きURLのどちらにも対応する
+func (c Credentials) Verify(req *http.Request, now time.Time) error {
+	query := req.URL.Query()
+	var (
+		credential, signedHeaders, signature, date, payloadHash string
+	)
+	if query.Get("X-Amz-Algorithm") == algorithm {
+		credential = query.Get("X-Amz-Credential")
+		signedHeaders = query.Get("X-Amz-SignedHeaders")
+		signature = query.Get("X-Amz-Signature")
+		date = query.Get("X-Amz-Date")
+		payloadHash = unsignedPayload
+	} else {
+		auth := strings.TrimPrefix(req.Header.Get("Authorization"), algorithm+" ")
+		for _, part := range strings.Split(auth, ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+			switch key {
+			case "Credential":
+				credential = value
+			case "SignedHeaders":
+				signedHeaders = value
+			case "Signature":
+				signature = value
+			}
+		}
+		date = req.Header.Get("X-Amz-Date")
+		payloadHash = req.Header.Get("X-Amz-Content-Sha256")
+	}
+
+	t, err := time.Parse(amzDateFormat, date)
+	if err != nil || signature == "" || credential != c.AccessKeyID+"/"+c.scope(t) {
+		return ErrSignatureMismatch
+	}
+	if expires := query.Get("X-Amz-Expires"); expires != "" {
+		seconds, err := strconv.Atoi(expires)
+		if err != nil || now.After(t.Add(time.Duration(seconds)*time.Second)) {
+			return ErrSignatureMismatch
+		}
+	}
+
+	header := req.Header.Clone()
+	if req.ContentLength >= 0 && strings.Contains(signedHeaders, "content-length") {
+		header.Set("Content-Length", strconv.FormatInt(req.ContentLength, 10))
+	}
+	expected := c.signature(req.Method, req.URL, req.Host, header, strings.Split(signedHeaders, ";"), payloadHash, t)
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
+
+// signature 正規化したリクエストの署名
+func (c Credentials) signature(method string, u *url.URL, host string, header http.Header, names []string, payloadHash string, t time.Time) string {
+	var canonical strings.Builder
+	canonical.WriteString(method + "\n")
+	canonical.WriteString(canonicalURI(u) + "\n")
+	canonical.WriteString(canonicalQuery(u.Query()) + "\n")
+	for _, name := range names {
+		value := host
+		if name != "host" {
+			value = strings.Join(header.Values(name), ",")
+		}
+		canonical.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	canonical.WriteString("\n" + strings.Join(names, ";") + "\n")
+	canonical.WriteString(payloadHash)
+
+	hash := sha256.Sum256([]byte(canonical.String()))
+	stringToSign := algorithm + "\n" + t.Format(amzDateFormat) + "\n" + c.scope(t) + "\n" + hex.EncodeToString(hash[:])
+	return hex.EncodeToString(hmacSHA256(c.signingKey(t), stringToSign))
+}
+
+// signedHeaderNames 署名対象のヘッダー名（hostと、x-amz-*・content-*）
+func signedHeaderNames(header http.Header) []string {
+	names := []string{"host"}
+	for name := range header {
+		lower := strings.ToLower(name)
+		if strings.HasPrefix(lower, "x-amz-") || lower == "content-type" || lower == "content-md5" || lower == "content-length" {
+			names = append(names, lower)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// canonicalURI パスの各セグメントをURIエンコード
+func canonicalURI(u *url.URL) string {
+	path := u.EscapedPath()
+	if path == "" {
+		return "/"
+	}
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segment = unescaped
+		}
+		segments[i] = uriEncode(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// canonicalQuery キーでソートしたクエリ文字列
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		if key == "X-Amz-Signature" {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values := append([]string(nil), query[key]...)
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, uriEncode(key)+"="+uriEncode(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// uriEncode RFC 3986の非予約文字以外をエンコード
+func uriEncode(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		if ('A' <= b && b <= 'Z') || ('a' <= b && b <= 'z') || ('0' <= b && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' {
+			sb.WriteByte(b)
+			continue
+		}
+		sb.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{b})))
+	}
+	return sb.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
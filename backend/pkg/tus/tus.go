@@ -0,0 +1,141 @@
+// Package tus tus再開可能アップロードプロトコル（1.0.0）のヘッダー
+//
+// アップロードの作成（POST）、受信済みサイズの確認（HEAD）、続きの送信（PATCH）、
+// 中止（DELETE）で使うヘッダーの定数と、Upload-Metadata・Upload-Checksum の解析を提供する。
+// https://tus.io/protocols/resumable-upload
+package tus
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Version 対応しているプロトコルのバージョン
+const Version = "1.0.0"
+
+// ヘッダー
+const (
+	HeaderResumable         = "Tus-Resumable"
+	HeaderVersion           = "Tus-Version"
+	HeaderExtension         = "Tus-Extension"
+	HeaderMaxSize           = "Tus-Max-Size"
+	HeaderChecksumAlgorithm = "Tus-Checksum-Algorithm"
+	HeaderUploadLength      = "Upload-Length"
+	HeaderUploadDeferLength = "Upload-Defer-Length"
+	HeaderUploadOffset      = "Upload-Offset"
+	HeaderUploadMetadata    = "Upload-Metadata"
+	HeaderUploadExpires     = "Upload-Expires"
+	HeaderUploadChecksum    = "Upload-Checksum"
+)
+
+// ContentTypeOffset PATCHの本文のContent-Type
+const ContentTypeOffset = "application/offset+octet-stream"
+
+// StatusChecksumMismatch 受信した本文がUpload-Checksumと一致しない場合のステータス
+const StatusChecksumMismatch = 460
+
+var (
+	ErrInvalidMetadata     = errors.New("tus: invalid Upload-Metadata")
+	ErrInvalidChecksum     = errors.New("tus: invalid Upload-Checksum")
+	ErrUnsupportedChecksum = errors.New("tus: unsupported checksum algorithm")
+)
+
+// checksumAlgorithms Upload-Checksumで受け付けるアルゴリズム
+var checksumAlgorithms = map[string]func() hash.Hash{
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"md5":    md5.New,
+}
+
+// ChecksumAlgorithms Tus-Checksum-Algorithm ヘッダーの値
+func ChecksumAlgorithms() string {
+	names := make([]string, 0, len(checksumAlgorithms))
+	for name := range checksumAlgorithms {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+// Checksum Upload-Checksum ヘッダーの内容
+type Checksum struct {
+	Algorithm string
+	Sum       []byte
+}
+
+// NewHash チェックサムを計算するハッシュを作成
+func (c *Checksum) NewHash() hash.Hash {
+	return checksumAlgorithms[c.Algorithm]()
+}
+
+// ParseChecksum "<algorithm> <base64>" 形式のUpload-Checksumを解析（空の場合はnil）
+func ParseChecksum(value string) (*Checksum, error) {
+	if value == "" {
+		return nil, nil
+	}
+	algorithm, encoded, ok := strings.Cut(value, " ")
+	if !ok {
+		return nil, ErrInvalidChecksum
+	}
+	if _, ok := checksumAlgorithms[algorithm]; !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChecksum, algorithm)
+	}
+	sum, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidChecksum
+	}
+	return &Checksum{Algorithm: algorithm, Sum: sum}, nil
+}
+
+// ParseMetadata "key base64value,key2 base64value2" 形式のUpload-Metadataを解析
+// 値のないキーは空文字列になる
+func ParseMetadata(value string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	if strings.TrimSpace(value) == "" {
+		return metadata, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, ErrInvalidMetadata
+		}
+		if _, exists := metadata[key]; exists {
+			return nil, fmt.Errorf("%w: duplicate key %q", ErrInvalidMetadata, key)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidMetadata, key)
+		}
+		metadata[key] = string(decoded)
+	}
+	return metadata, nil
+}
+
+// FormatMetadata Upload-Metadata ヘッダーの値を作成（キー順）
+func FormatMetadata(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + " " + base64.StdEncoding.EncodeToString([]byte(metadata[key]))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// FormatExpires Upload-Expires ヘッダーの値（RFC 7231の日時）
+func FormatExpires(t time.Time) string {
+	return t.UTC().Format(http.TimeFormat)
+}
@@ -0,0 +1,82 @@
+package tus
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseMetadata(t *testing.T) {
+	metadata, err := ParseMetadata("filename d29ybGRfZG9taW5hdGlvbl9wbGFuLnBkZg==,is_confidential, filetype YXVkaW8vd2VibQ==")
+	if err != nil {
+		t.Fatalf("ParseMetadata() unexpected error = %v", err)
+	}
+	want := map[string]string{
+		"filename":        "world_domination_plan.pdf",
+		"is_confidential": "",
+		"filetype":        "audio/webm",
+	}
+	if len(metadata) != len(want) {
+		t.Fatalf("ParseMetadata() = %v, want %v", metadata, want)
+	}
+	for key, value := range want {
+		if metadata[key] != value {
+			t.Errorf("metadata[%q] = %q, want %q", key, metadata[key], value)
+		}
+	}
+
+	// 作成した値を解析すると元に戻る
+	roundTrip, err := ParseMetadata(FormatMetadata(want))
+	if err != nil || roundTrip["filename"] != want["filename"] || roundTrip["is_confidential"] != "" {
+		t.Errorf("ParseMetadata(FormatMetadata()) = %v, %v", roundTrip, err)
+	}
+
+	empty, err := ParseMetadata("")
+	if err != nil || len(empty) != 0 {
+		t.Errorf("ParseMetadata(\"\") = %v, %v, want empty", empty, err)
+	}
+
+	for _, invalid := range []string{"filename !!!", "a YQ==,a Yg==", "a YQ==,,b Yg=="} {
+		if _, err := ParseMetadata(invalid); !errors.Is(err, ErrInvalidMetadata) {
+			t.Errorf("ParseMetadata(%q) error = %v, want ErrInvalidMetadata", invalid, err)
+		}
+	}
+}
+
+func TestParseChecksum(t *testing.T) {
+	sum := sha1.Sum([]byte("hello"))
+	checksum, err := ParseChecksum("sha1 " + base64.StdEncoding.EncodeToString(sum[:]))
+	if err != nil {
+		t.Fatalf("ParseChecksum() unexpected error = %v", err)
+	}
+	h := checksum.NewHash()
+	h.Write([]byte("hello"))
+	if string(h.Sum(nil)) != string(checksum.Sum) {
+		t.Error("NewHash() does not produce the declared checksum")
+	}
+
+	if checksum, err := ParseChecksum(""); checksum != nil || err != nil {
+		t.Errorf("ParseChecksum(\"\") = %v, %v, want nil", checksum, err)
+	}
+	if _, err := ParseChecksum("crc32 AAAA"); !errors.Is(err, ErrUnsupportedChecksum) {
+		t.Errorf("ParseChecksum(crc32) error = %v, want ErrUnsupportedChecksum", err)
+	}
+	for _, invalid := range []string{"sha1", "sha1 !!!"} {
+		if _, err := ParseChecksum(invalid); !errors.Is(err, ErrInvalidChecksum) {
+			t.Errorf("ParseChecksum(%q) error = %v, want ErrInvalidChecksum", invalid, err)
+		}
+	}
+
+	if got := ChecksumAlgorithms(); got != "md5,sha1,sha256" {
+		t.Errorf("ChecksumAlgorithms() = %q", got)
+	}
+}
+
+func TestFormatExpires(t *testing.T) {
+	at := time.Date(2025, 10, 21, 7, 28, 0, 0, time.FixedZone("JST", 9*3600))
+	if got := FormatExpires(at); got != "Mon, 20 Oct 2025 22:28:00 GMT" {
+		t.Errorf("FormatExpires() = %q", got)
+	}
+}
@@ -0,0 +1,236 @@
+// Package webhook Webhookリクエストの署名・検証と送信
+//
+// 署名は "<timestamp>.<body>" の HMAC-SHA256 を16進数で表したもので、
+// X-Webhook-Signature ヘッダーに "t=<timestamp>,v1=<signature>" 形式で付与する。
+// 受信側はタイムスタンプの鮮度と署名を検証することでリプレイと改ざんを防げる。
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// リクエストヘッダー
+const (
+	HeaderEvent      = "X-Webhook-Event"
+	HeaderEventID    = "X-Webhook-Id"
+	HeaderDeliveryID = "X-Webhook-Delivery"
+	HeaderSignature  = "X-Webhook-Signature"
+)
+
+const (
+	signatureVersion = "v1"
+	secretPrefix     = "whsec_"
+	userAgent        = "Go-Next-WebRTC-Webhook/1.0"
+	// maxResponseBody 配信ログに残すレスポンス本文の最大長
+	maxResponseBody = 512
+)
+
+var (
+	// ErrInvalidSignature 署名ヘッダーの形式・署名が不正
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrExpiredSignature 署名のタイムスタンプが許容範囲外
+	ErrExpiredSignature = errors.New("webhook signature expired")
+	// ErrForbiddenAddress 送信先がループバック・プライベート等の内部向けアドレス
+	ErrForbiddenAddress = errors.New("destination address is not allowed")
+)
+
+// GenerateSecret 署名用のシークレットを生成
+func GenerateSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return secretPrefix + hex.EncodeToString(b), nil
+}
+
+// Sign タイムスタンプと本文の署名を計算
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignatureHeader X-Webhook-Signature ヘッダーの値を作成
+func SignatureHeader(secret string, timestamp int64, body []byte) string {
+	return "t=" + strconv.FormatInt(timestamp, 10) + "," + signatureVersion + "=" + Sign(secret, timestamp, body)
+}
+
+// Verify X-Webhook-Signature ヘッダーを検証（受信側用）
+// toleranceが0以下の場合はタイムスタンプの鮮度を検証しない
+func Verify(secret, header string, body []byte, tolerance time.Duration, now time.Time) error {
+	var timestamp int64
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return ErrInvalidSignature
+		}
+		switch key {
+		case "t":
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return ErrInvalidSignature
+			}
+			timestamp = ts
+		case signatureVersion:
+			signatures = append(signatures, value)
+		}
+	}
+	if timestamp == 0 || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+
+	expected := Sign(secret, timestamp, body)
+	valid := false
+	for _, sig := range signatures {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			valid = true
+		}
+	}
+	if !valid {
+		return ErrInvalidSignature
+	}
+
+	if tolerance > 0 {
+		diff := now.Sub(time.Unix(timestamp, 0))
+		if diff < 0 {
+			diff = -diff
+		}
+		if diff > tolerance {
+			return ErrExpiredSignature
+		}
+	}
+	return nil
+}
+
+// Message 送信するWebhookリクエスト
+type Message struct {
+	URL        string
+	Secret     string
+	Event      string
+	EventID    string
+	DeliveryID int64
+	Body       []byte
+}
+
+// Response 受信側のレスポンス
+type Response struct {
+	StatusCode int
+	Body       string // 先頭 maxResponseBody バイトまで
+}
+
+// OK 2xxのレスポンスか
+func (r *Response) OK() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Client Webhookリクエストを署名して送信する
+type Client struct {
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewClient 新しいClientを作成
+// リダイレクトは追従しない（署名済みリクエストを別のURLへ転送しないため）
+func NewClient(timeout time.Duration, allowPrivateNetworks bool) *Client {
+	return &Client{
+		httpClient: NewOutboundHTTPClient(timeout, allowPrivateNetworks),
+		now:        time.Now,
+	}
+}
+
+// NewOutboundHTTPClient ユーザーが登録したURLへ送信するためのhttp.Clientを作成（Web Pushでも使う）
+// リダイレクトは追従せず、allowPrivateNetworksがfalseの場合は接続時に名前解決後のアドレスを検証し、
+// 内部向けのアドレスへは接続しない（登録時の検証だけではDNSの応答を差し替えて内部へ向けられるため）
+func NewOutboundHTTPClient(timeout time.Duration, allowPrivateNetworks bool) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !allowPrivateNetworks {
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Control:   controlPublicAddress,
+		}
+		transport.DialContext = dialer.DialContext
+		// プロキシ経由では接続先を検証できないため使わない
+		transport.Proxy = nil
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// IsPublicIP 送信先として許可するアドレスか
+// ループバック・プライベート・リンクローカル・未指定・マルチキャスト・CGNATのアドレスは許可しない
+func IsPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		// 100.64.0.0/10（CGNAT）
+		if ip[0] == 100 && ip[1]&0xc0 == 64 {
+			return false
+		}
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
+
+// controlPublicAddress 接続直前に接続先のアドレスを検証する（net.Dialer.Control）
+func controlPublicAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+// Send Webhookリクエストを送信
+// 接続エラー等でレスポンスを受け取れなかった場合のみエラーを返す
+func (c *Client) Send(ctx context.Context, msg *Message) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.URL, bytes.NewReader(msg.Body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, msg.Event)
+	req.Header.Set(HeaderEventID, msg.EventID)
+	req.Header.Set(HeaderDeliveryID, strconv.FormatInt(msg.DeliveryID, 10))
+	req.Header.Set(HeaderSignature, SignatureHeader(msg.Secret, c.now().Unix(), msg.Body))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	// 残りを読み捨てて接続を再利用できるようにする
+	io.Copy(io.Discard, resp.Body)
+
+	return &Response{StatusCode: resp.StatusCode, Body: string(body)}, nil
+}
@@ -0,0 +1,186 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"event":"room.created"}`)
+	header := SignatureHeader("secret", now.Unix(), body)
+
+	tests := []struct {
+		name        string
+		secret      string
+		header      string
+		body        []byte
+		now         time.Time
+		expectedErr error
+	}{
+		{name: "valid", secret: "secret", header: header, body: body, now: now},
+		{name: "within tolerance", secret: "secret", header: header, body: body, now: now.Add(4 * time.Minute)},
+		{name: "expired", secret: "secret", header: header, body: body, now: now.Add(6 * time.Minute), expectedErr: ErrExpiredSignature},
+		{name: "different secret", secret: "other", header: header, body: body, now: now, expectedErr: ErrInvalidSignature},
+		{name: "tampered body", secret: "secret", header: header, body: []byte(`{"event":"room.ended"}`), now: now, expectedErr: ErrInvalidSignature},
+		{name: "missing timestamp", secret: "secret", header: "v1=" + Sign("secret", now.Unix(), body), body: body, now: now, expectedErr: ErrInvalidSignature},
+		{name: "malformed", secret: "secret", header: "garbage", body: body, now: now, expectedErr: ErrInvalidSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.secret, tt.header, tt.body, 5*time.Minute, tt.now)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Verify() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() unexpected error = %v", err)
+	}
+	b, _ := GenerateSecret()
+	if !strings.HasPrefix(a, secretPrefix) || len(a) != len(secretPrefix)+64 {
+		t.Errorf("GenerateSecret() = %q", a)
+	}
+	if a == b {
+		t.Error("GenerateSecret() returned the same secret twice")
+	}
+}
+
+func TestClient_Send(t *testing.T) {
+	body := []byte(`{"id":"evt-1","event":"room.started"}`)
+
+	var received *http.Request
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		if err := Verify("secret", r.Header.Get(HeaderSignature), receivedBody, time.Minute, time.Now()); err != nil {
+			http.Error(w, "bad signature", http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(5*time.Second, true)
+	resp, err := client.Send(context.Background(), &Message{
+		URL:        server.URL,
+		Secret:     "secret",
+		Event:      "room.started",
+		EventID:    "evt-1",
+		DeliveryID: 7,
+		Body:       body,
+	})
+	if err != nil {
+		t.Fatalf("Send() unexpected error = %v", err)
+	}
+	if !resp.OK() {
+		t.Fatalf("Send() status = %d, body = %q", resp.StatusCode, resp.Body)
+	}
+	if string(receivedBody) != string(body) {
+		t.Errorf("received body = %s", receivedBody)
+	}
+	if received.Header.Get(HeaderEvent) != "room.started" || received.Header.Get(HeaderEventID) != "evt-1" || received.Header.Get(HeaderDeliveryID) != "7" {
+		t.Errorf("received headers = %v", received.Header)
+	}
+
+	// シークレットが一致しない場合は受信側で拒否される
+	resp, err = client.Send(context.Background(), &Message{URL: server.URL, Secret: "wrong", Body: body})
+	if err != nil {
+		t.Fatalf("Send() unexpected error = %v", err)
+	}
+	if resp.OK() || resp.StatusCode != http.StatusUnauthorized || !strings.Contains(resp.Body, "bad signature") {
+		t.Errorf("Send() with wrong secret = %+v", resp)
+	}
+}
+
+func TestClient_SendDoesNotFollowRedirects(t *testing.T) {
+	followed := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		followed = true
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	resp, err := NewClient(5*time.Second, true).Send(context.Background(), &Message{URL: server.URL, Secret: "secret", Body: []byte(`{}`)})
+	if err != nil {
+		t.Fatalf("Send() unexpected error = %v", err)
+	}
+	if resp.StatusCode != http.StatusTemporaryRedirect || followed {
+		t.Errorf("Send() status = %d, followed = %v", resp.StatusCode, followed)
+	}
+}
+
+func TestClient_SendConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	if _, err := NewClient(time.Second, true).Send(context.Background(), &Message{URL: url, Secret: "secret", Body: []byte(`{}`)}); err == nil {
+		t.Error("Send() to closed server expected error")
+	}
+}
+
+func TestClient_SendRejectsPrivateAddress(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	// 名前解決後のアドレスで判定するため、localhostのホスト名でも拒否される
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	for _, target := range []string{server.URL, url} {
+		_, err := NewClient(time.Second, false).Send(context.Background(), &Message{URL: target, Secret: "secret", Body: []byte(`{}`)})
+		if !errors.Is(err, ErrForbiddenAddress) {
+			t.Errorf("Send(%s) error = %v, want ErrForbiddenAddress", target, err)
+		}
+	}
+	if called {
+		t.Error("Send() reached a loopback server")
+	}
+}
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip       string
+		expected bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.1", false},
+		{"172.16.5.4", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"::ffff:127.0.0.1", false},
+		{"224.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsPublicIP(net.ParseIP(tt.ip)); got != tt.expected {
+			t.Errorf("IsPublicIP(%s) = %v, want %v", tt.ip, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+// Package webpush Web Push プロトコルによるプッシュ通知の送信
+//
+// ペイロードは RFC 8291（aes128gcm, RFC 8188）で購読ごとの鍵を使って暗号化し、
+// アプリケーションサーバーの認証には VAPID（RFC 8292）を使う。
+// VAPIDの鍵はP-256の鍵ペアで、公開鍵はブラウザの購読登録（applicationServerKey）に渡す。
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/hkdf"
+
+	"Go-Next-WebRTC/pkg/webhook"
+)
+
+// Urgency 配信の緊急度（RFC 8030 5.3）
+type Urgency string
+
+const (
+	UrgencyVeryLow Urgency = "very-low"
+	UrgencyLow     Urgency = "low"
+	UrgencyNormal  Urgency = "normal"
+	UrgencyHigh    Urgency = "high"
+)
+
+const (
+	// recordSize 暗号化レコードのサイズ（1レコードで送るためペイロードの上限になる）
+	recordSize = 4096
+	// maxMessageSize プッシュサービスが受け付ける暗号化後の本文の最大長（RFC 8030 で4096バイト以上とされる）
+	maxMessageSize = 4096
+	// headerSize 暗号化ヘッダーの長さ（salt 16 + rs 4 + idlen 1 + 公開鍵 65）
+	headerSize = 16 + 4 + 1 + 65
+	// MaxPayloadSize 暗号化前のペイロードの最大長（ヘッダー・タグ16バイト・区切り1バイトを除く）
+	MaxPayloadSize = maxMessageSize - headerSize - 16 - 1
+	// vapidTokenTTL VAPIDトークンの有効期間（RFC 8292 では最大24時間）
+	vapidTokenTTL = 12 * time.Hour
+	userAgent     = "Go-Next-WebRTC-WebPush/1.0"
+)
+
+var (
+	// ErrInvalidKey 鍵の形式が不正
+	ErrInvalidKey = errors.New("invalid web push key")
+	// ErrPayloadTooLarge ペイロードが1レコードに収まらない
+	ErrPayloadTooLarge = errors.New("web push payload too large")
+)
+
+// encoding 鍵・ペイロードはURL-safeなBase64（パディングなし）で表す
+var encoding = base64.RawURLEncoding
+
+// decodeBase64 パディングの有無にかかわらずURL-safeなBase64をデコード
+func decodeBase64(s string) ([]byte, error) {
+	return encoding.DecodeString(trimPadding(s))
+}
+
+func trimPadding(s string) string {
+	for len(s) > 0 && s[len(s)-1] == '=' {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// VAPIDKeys アプリケーションサーバーの鍵ペア
+type VAPIDKeys struct {
+	private *ecdsa.PrivateKey
+	public  []byte // 非圧縮形式の公開鍵（65バイト）
+}
+
+// GenerateVAPIDKeys 新しいVAPIDの鍵ペアを生成
+func GenerateVAPIDKeys() (*VAPIDKeys, error) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newVAPIDKeys(private)
+}
+
+// ParseVAPIDKeys Base64で表した秘密鍵（32バイトのスカラー）からVAPIDの鍵ペアを作成
+func ParseVAPIDKeys(privateKey string) (*VAPIDKeys, error) {
+	d, err := decodeBase64(privateKey)
+	if err != nil || len(d) != 32 {
+		return nil, ErrInvalidKey
+	}
+	// ecdhで検証してから公開鍵を求める（範囲外のスカラーを弾く）
+	key, err := ecdh.P256().NewPrivateKey(d)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	public := key.PublicKey().Bytes()
+
+	private := &ecdsa.PrivateKey{D: new(big.Int).SetBytes(d)}
+	private.PublicKey.Curve = elliptic.P256()
+	private.PublicKey.X = new(big.Int).SetBytes(public[1:33])
+	private.PublicKey.Y = new(big.Int).SetBytes(public[33:])
+	return newVAPIDKeys(private)
+}
+
+func newVAPIDKeys(private *ecdsa.PrivateKey) (*VAPIDKeys, error) {
+	public, err := private.PublicKey.ECDH()
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	return &VAPIDKeys{private: private, public: public.Bytes()}, nil
+}
+
+// PublicKey ブラウザの購読登録に渡す公開鍵（applicationServerKey）
+func (k *VAPIDKeys) PublicKey() string {
+	return encoding.EncodeToString(k.public)
+}
+
+// PrivateKey 設定に保存する秘密鍵
+func (k *VAPIDKeys) PrivateKey() string {
+	d := make([]byte, 32)
+	k.private.D.FillBytes(d)
+	return encoding.EncodeToString(d)
+}
+
+// Authorization プッシュサービスへのリクエストに付けるAuthorizationヘッダー（RFC 8292）
+// subjectは連絡先（mailto: または https: のURL）
+func (k *VAPIDKeys) Authorization(endpoint, subject string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid push endpoint: %q", endpoint)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": subject,
+	})
+	signed, err := token.SignedString(k.private)
+	if err != nil {
+		return "", err
+	}
+	return "vapid t=" + signed + ", k=" + k.PublicKey(), nil
+}
+
+// Encrypt ペイロードを購読の鍵で暗号化（RFC 8291）
+// p256dhとauthはブラウザの購読（PushSubscription.getKey）の値をBase64で表したもの
+func Encrypt(p256dh, auth string, payload []byte) ([]byte, error) {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return encrypt(p256dh, auth, payload, serverKey, salt)
+}
+
+// encrypt 送信側の一時鍵とソルトを指定して暗号化（テストベクターでの検証用に分けている）
+func encrypt(p256dh, auth string, payload []byte, serverKey *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if len(payload) > MaxPayloadSize {
+		return nil, ErrPayloadTooLarge
+	}
+	uaPublicBytes, err := decodeBase64(p256dh)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(uaPublicBytes)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	authSecret, err := decodeBase64(auth)
+	if err != nil || len(authSecret) == 0 {
+		return nil, ErrInvalidKey
+	}
+
+	sharedSecret, err := serverKey.ECDH(uaPublic)
+	if err != nil {
+		return nil, ErrInvalidKey
+	}
+	asPublic := serverKey.PublicKey().Bytes()
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public, 32)
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublicBytes...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm, err := hkdfRead(sharedSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdfRead(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdfRead(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// ヘッダー: salt(16) || rs(4) || idlen(1) || keyid(送信側の公開鍵)
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	// 最後のレコードであることを示す区切り（0x02）を付けて暗号化（パディングなし）
+	plaintext := append(append([]byte{}, payload...), 0x02)
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+// hkdfRead HKDF（SHA-256）でlength バイトの鍵を導出
+func hkdfRead(secret, salt, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, secret, salt, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// Message 送信するプッシュメッセージ
+type Message struct {
+	Endpoint string
+	P256dh   string
+	Auth     string
+	Payload  []byte
+	// プッシュサービスが端末へ届けるまで保持する時間（0の場合は接続中の端末にのみ届く）
+	TTL     time.Duration
+	Urgency Urgency
+	// 同じトピックの未配信メッセージは新しいもので置き換えられる
+	Topic string
+}
+
+// Response プッシュサービスのレスポンス
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+// OK プッシュサービスが受け付けたか
+func (r *Response) OK() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Gone 購読が無効になっているか（解除された購読は削除する）
+func (r *Response) Gone() bool {
+	return r.StatusCode == http.StatusNotFound || r.StatusCode == http.StatusGone
+}
+
+// Client プッシュメッセージを暗号化してプッシュサービスへ送信する
+type Client struct {
+	keys       *VAPIDKeys
+	subject    string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewClient 新しいClientを作成
+// 購読のエンドポイントはユーザーが登録したURLのため、Webhookと同じくリダイレクトを追従せず、
+// allowPrivateNetworksがfalseの場合は内部向けのアドレスへ送信しない
+func NewClient(keys *VAPIDKeys, subject string, timeout time.Duration, allowPrivateNetworks bool) *Client {
+	return &Client{
+		keys:       keys,
+		subject:    subject,
+		httpClient: webhook.NewOutboundHTTPClient(timeout, allowPrivateNetworks),
+		now:        time.Now,
+	}
+}
+
+// PublicKey VAPIDの公開鍵
+func (c *Client) PublicKey() string {
+	return c.keys.PublicKey()
+}
+
+// Send プッシュメッセージを送信
+// 接続エラー等でレスポンスを受け取れなかった場合のみエラーを返す
+func (c *Client) Send(ctx context.Context, msg *Message) (*Response, error) {
+	body, err := Encrypt(msg.P256dh, msg.Auth, msg.Payload)
+	if err != nil {
+		return nil, err
+	}
+	authorization, err := c.keys.Authorization(msg.Endpoint, c.subject, c.now())
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, msg.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("TTL", strconv.Itoa(int(msg.TTL/time.Second)))
+	if msg.Urgency != "" {
+		req.Header.Set("Urgency", string(msg.Urgency))
+	}
+	if msg.Topic != "" {
+		req.Header.Set("Topic", msg.Topic)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("push request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	io.Copy(io.Discard, resp.Body)
+
+	return &Response{StatusCode: resp.StatusCode, Body: string(respBody)}, nil
+}
@@ -0,0 +1,278 @@
+package webpush
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"Go-Next-WebRTC/pkg/webhook"
+)
+
+// RFC 8291 Appendix A のテストベクター
+const (
+	rfcPlaintext = "When I grow up, I want to be a watermelon"
+	rfcASPrivate = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	rfcUAPrivate = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	rfcUAPublic  = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	rfcAuth      = "BTBZMqHH6r4Tts7J_aSIgg"
+	rfcSalt      = "DGv6ra1nlYgDCS1FRnbzlw"
+	rfcBody      = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+// testSubscriber ブラウザ側の購読（受信側の鍵）
+type testSubscriber struct {
+	private *ecdh.PrivateKey
+	p256dh  string
+	auth    string
+}
+
+func newTestSubscriber(t *testing.T) *testSubscriber {
+	t.Helper()
+	private, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() unexpected error = %v", err)
+	}
+	auth := make([]byte, 16)
+	rand.Read(auth)
+	return &testSubscriber{
+		private: private,
+		p256dh:  encoding.EncodeToString(private.PublicKey().Bytes()),
+		auth:    encoding.EncodeToString(auth),
+	}
+}
+
+// decrypt 受信側としてaes128gcmの本文を復号
+func (s *testSubscriber) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	if len(body) < 21 {
+		t.Fatalf("body too short: %d bytes", len(body))
+	}
+	salt := body[:16]
+	if rs := binary.BigEndian.Uint32(body[16:20]); rs != recordSize {
+		t.Fatalf("record size = %d, want %d", rs, recordSize)
+	}
+	idLen := int(body[20])
+	asPublicBytes := body[21 : 21+idLen]
+	ciphertext := body[21+idLen:]
+
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		t.Fatalf("invalid sender key: %v", err)
+	}
+	shared, err := s.private.ECDH(asPublic)
+	if err != nil {
+		t.Fatalf("ECDH() unexpected error = %v", err)
+	}
+	authSecret, _ := decodeBase64(s.auth)
+	keyInfo := append([]byte("WebPush: info\x00"), s.private.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asPublicBytes...)
+	ikm, _ := hkdfRead(shared, authSecret, keyInfo, 32)
+	cek, _ := hkdfRead(ikm, salt, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce, _ := hkdfRead(ikm, salt, []byte("Content-Encoding: nonce\x00"), 12)
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		t.Fatalf("decrypt failed: %v", err)
+	}
+	if len(plaintext) == 0 || plaintext[len(plaintext)-1] != 0x02 {
+		t.Fatal("missing last record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+func TestEncrypt_RFC8291Vector(t *testing.T) {
+	asPrivate, _ := decodeBase64(rfcASPrivate)
+	serverKey, err := ecdh.P256().NewPrivateKey(asPrivate)
+	if err != nil {
+		t.Fatalf("NewPrivateKey() unexpected error = %v", err)
+	}
+	salt, _ := decodeBase64(rfcSalt)
+
+	body, err := encrypt(rfcUAPublic, rfcAuth, []byte(rfcPlaintext), serverKey, salt)
+	if err != nil {
+		t.Fatalf("encrypt() unexpected error = %v", err)
+	}
+	if got := encoding.EncodeToString(body); got != rfcBody {
+		t.Errorf("encrypt() = %s, want %s", got, rfcBody)
+	}
+
+	uaPrivate, _ := decodeBase64(rfcUAPrivate)
+	uaKey, _ := ecdh.P256().NewPrivateKey(uaPrivate)
+	subscriber := &testSubscriber{private: uaKey, p256dh: rfcUAPublic, auth: rfcAuth}
+	if got := string(subscriber.decrypt(t, body)); got != rfcPlaintext {
+		t.Errorf("decrypt() = %q, want %q", got, rfcPlaintext)
+	}
+}
+
+func TestEncrypt_Errors(t *testing.T) {
+	subscriber := newTestSubscriber(t)
+
+	tests := []struct {
+		name        string
+		p256dh      string
+		auth        string
+		payload     []byte
+		expectedErr error
+	}{
+		{name: "max size", p256dh: subscriber.p256dh, auth: subscriber.auth, payload: make([]byte, MaxPayloadSize)},
+		{name: "too large", p256dh: subscriber.p256dh, auth: subscriber.auth, payload: make([]byte, MaxPayloadSize+1), expectedErr: ErrPayloadTooLarge},
+		{name: "invalid p256dh", p256dh: "not-a-key", auth: subscriber.auth, expectedErr: ErrInvalidKey},
+		{name: "empty auth", p256dh: subscriber.p256dh, auth: "", expectedErr: ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := Encrypt(tt.p256dh, tt.auth, tt.payload)
+			if !errors.Is(err, tt.expectedErr) {
+				t.Errorf("Encrypt() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+			// プッシュサービスが受け付ける4096バイトに収まる
+			if len(body) > 4096 {
+				t.Errorf("Encrypt() body size = %d, want <= 4096", len(body))
+			}
+		})
+	}
+}
+
+func TestVAPIDKeys(t *testing.T) {
+	keys, err := GenerateVAPIDKeys()
+	if err != nil {
+		t.Fatalf("GenerateVAPIDKeys() unexpected error = %v", err)
+	}
+
+	// 保存した秘密鍵から同じ鍵ペアを復元できる
+	parsed, err := ParseVAPIDKeys(keys.PrivateKey())
+	if err != nil {
+		t.Fatalf("ParseVAPIDKeys() unexpected error = %v", err)
+	}
+	if parsed.PublicKey() != keys.PublicKey() {
+		t.Errorf("parsed public key = %s, want %s", parsed.PublicKey(), keys.PublicKey())
+	}
+	if _, err := ParseVAPIDKeys("short"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("ParseVAPIDKeys(short) error = %v, want ErrInvalidKey", err)
+	}
+
+	now := time.Now()
+	header, err := parsed.Authorization("https://push.example.com/send/abc", "mailto:ops@example.com", now)
+	if err != nil {
+		t.Fatalf("Authorization() unexpected error = %v", err)
+	}
+	verifyVAPID(t, header, keys, "https://push.example.com")
+}
+
+// verifyVAPID プッシュサービスとしてAuthorizationヘッダーを検証
+func verifyVAPID(t *testing.T, header string, keys *VAPIDKeys, audience string) {
+	t.Helper()
+	token, k, ok := strings.Cut(strings.TrimPrefix(header, "vapid t="), ", k=")
+	if !ok || !strings.HasPrefix(header, "vapid ") || k != keys.PublicKey() {
+		t.Fatalf("Authorization = %q, want vapid t=..., k=<public key>", header)
+	}
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return &keys.private.PublicKey, nil
+	}, jwt.WithValidMethods([]string{"ES256"}), jwt.WithAudience(audience), jwt.WithExpirationRequired())
+	if err != nil {
+		t.Fatalf("VAPID token invalid: %v", err)
+	}
+	if claims["sub"] != "mailto:ops@example.com" {
+		t.Errorf("sub = %v, want mailto:ops@example.com", claims["sub"])
+	}
+}
+
+func TestClient_Send(t *testing.T) {
+	keys, _ := GenerateVAPIDKeys()
+	subscriber := newTestSubscriber(t)
+
+	// ローカルのプッシュサービス代わり
+	var received []byte
+	var headers http.Header
+	status := http.StatusCreated
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := NewClient(keys, "mailto:ops@example.com", 5*time.Second, true)
+	msg := &Message{
+		Endpoint: server.URL + "/push/device-1",
+		P256dh:   subscriber.p256dh,
+		Auth:     subscriber.auth,
+		Payload:  []byte(`{"type":"incoming-call"}`),
+		TTL:      30 * time.Second,
+		Urgency:  UrgencyHigh,
+		Topic:    "call-1",
+	}
+
+	resp, err := client.Send(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Send() unexpected error = %v", err)
+	}
+	if !resp.OK() || resp.Gone() {
+		t.Errorf("response = %d, want accepted", resp.StatusCode)
+	}
+	if got := string(subscriber.decrypt(t, received)); got != `{"type":"incoming-call"}` {
+		t.Errorf("payload = %q", got)
+	}
+	if headers.Get("Content-Encoding") != "aes128gcm" || headers.Get("TTL") != "30" || headers.Get("Urgency") != "high" || headers.Get("Topic") != "call-1" {
+		t.Errorf("headers = %v", headers)
+	}
+	verifyVAPID(t, headers.Get("Authorization"), keys, server.URL)
+
+	// 解除された購読
+	status = http.StatusGone
+	resp, err = client.Send(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Send() unexpected error = %v", err)
+	}
+	if resp.OK() || !resp.Gone() {
+		t.Errorf("response = %d, want gone", resp.StatusCode)
+	}
+}
+
+func TestClient_SendRejectsInternalEndpoints(t *testing.T) {
+	keys, _ := GenerateVAPIDKeys()
+	subscriber := newTestSubscriber(t)
+
+	reached := false
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+	}))
+	defer internal.Close()
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirector.Close()
+
+	msg := &Message{Endpoint: internal.URL + "/push", P256dh: subscriber.p256dh, Auth: subscriber.auth, Payload: []byte(`{}`)}
+
+	// 内部向けのアドレスへは送信しない
+	if _, err := NewClient(keys, "mailto:ops@example.com", time.Second, false).Send(context.Background(), msg); !errors.Is(err, webhook.ErrForbiddenAddress) {
+		t.Errorf("Send() error = %v, want ErrForbiddenAddress", err)
+	}
+
+	// リダイレクトは追従しない
+	msg.Endpoint = redirector.URL + "/push"
+	resp, err := NewClient(keys, "mailto:ops@example.com", time.Second, true).Send(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Send() unexpected error = %v", err)
+	}
+	if resp.StatusCode != http.StatusTemporaryRedirect || reached {
+		t.Errorf("Send() status = %d, reached = %v, want redirect not followed", resp.StatusCode, reached)
+	}
+}
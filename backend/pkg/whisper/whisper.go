@@ -0,0 +1,212 @@
+// Package whisper whisper.cpp をサブプロセスで実行するオフラインの文字起こし
+//
+// 音声はffmpegで16kHzモノラルのWAVに変換してから whisper-cli に渡し、
+// JSON出力（-ojf）から区間ごとの時刻・テキスト・信頼度と、判定した言語を読み取る。
+// クラウドサービスに接続できない環境でも、モデルファイルがあれば文字起こしできる。
+package whisper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Config 実行するコマンドとモデルの設定
+type Config struct {
+	Binary      string // whisper.cppのCLI（whisper-cli）
+	Model       string // ggmlモデルファイルのパス
+	FFmpeg      string // 音声の変換に使うffmpeg
+	Threads     int    // 0の場合はwhisper.cppの既定値
+	TinyDiarize bool   // tinydiarize対応モデル（*-tdrz）で話者の交代を検出する
+}
+
+// Options 文字起こしの条件
+type Options struct {
+	Language    string // ja、en など（"auto"で自動判定。ja-JP のような地域付きも受け付ける）
+	Diarization bool
+}
+
+// Segment 文字起こし結果の1区間
+type Segment struct {
+	Text       string
+	Speaker    int     // 話者番号（話者を検出しない場合は0）
+	Confidence float64 // トークンの確率の平均
+	Start      float64 // 秒
+	End        float64
+	Language   string // 話された言語（ja、en など。whisper.cppは音声全体で1つの言語を判定する）
+}
+
+// Client whisper.cppのクライアント
+type Client struct {
+	cfg Config
+}
+
+// NewClient 新しいクライアントを作成
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Model == "" {
+		return nil, errors.New("whisper: model is required")
+	}
+	if cfg.Binary == "" {
+		cfg.Binary = "whisper-cli"
+	}
+	if cfg.FFmpeg == "" {
+		cfg.FFmpeg = "ffmpeg"
+	}
+	for _, name := range []string{cfg.Binary, cfg.FFmpeg} {
+		if _, err := exec.LookPath(name); err != nil {
+			return nil, fmt.Errorf("whisper: %s not found: %w", name, err)
+		}
+	}
+	if _, err := os.Stat(cfg.Model); err != nil {
+		return nil, fmt.Errorf("whisper: model not found: %w", err)
+	}
+	return &Client{cfg: cfg}, nil
+}
+
+// Transcribe 音声（ffmpegが読める形式）を文字起こし
+func (c *Client) Transcribe(ctx context.Context, audio io.Reader, opts Options) ([]Segment, error) {
+	dir, err := os.MkdirTemp("", "whisper-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// whisper.cppは16kHzのWAVのみ読めるため変換する
+	wav := filepath.Join(dir, "audio.wav")
+	convert := exec.CommandContext(ctx, c.cfg.FFmpeg,
+		"-nostdin", "-loglevel", "error", "-y",
+		"-i", "pipe:0",
+		"-ar", "16000", "-ac", "1", "-c:a", "pcm_s16le",
+		wav,
+	)
+	convert.Stdin = audio
+	if err := run(convert); err != nil {
+		return nil, fmt.Errorf("whisper: failed to convert audio: %w", err)
+	}
+
+	output := filepath.Join(dir, "result")
+	args := []string{
+		"-m", c.cfg.Model,
+		"-f", wav,
+		"-l", language(opts.Language),
+		"-ojf", "-of", output,
+		"-np",
+	}
+	if c.cfg.Threads > 0 {
+		args = append(args, "-t", strconv.Itoa(c.cfg.Threads))
+	}
+	diarize := opts.Diarization && c.cfg.TinyDiarize
+	if diarize {
+		args = append(args, "-tdrz")
+	}
+	if err := run(exec.CommandContext(ctx, c.cfg.Binary, args...)); err != nil {
+		return nil, fmt.Errorf("whisper: transcription failed: %w", err)
+	}
+
+	data, err := os.ReadFile(output + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("whisper: failed to read output: %w", err)
+	}
+	return ParseOutput(data, diarize)
+}
+
+// output whisper-cli のJSON出力（必要な項目のみ）
+type output struct {
+	Result struct {
+		Language string `json:"language"`
+	} `json:"result"`
+	Transcription []struct {
+		Offsets struct {
+			From int64 `json:"from"`
+			To   int64 `json:"to"`
+		} `json:"offsets"`
+		Text   string `json:"text"`
+		Tokens []struct {
+			Text string  `json:"text"`
+			P    float64 `json:"p"`
+		} `json:"tokens"`
+		SpeakerTurnNext bool `json:"speaker_turn_next"`
+	} `json:"transcription"`
+}
+
+// ParseOutput whisper-cli のJSON出力を区間に変換
+// tinydiarizeは話者の交代のみを検出するため、diarizeの場合は交代ごとに話者1と2を入れ替える
+func ParseOutput(data []byte, diarize bool) ([]Segment, error) {
+	var out output
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("whisper: invalid output: %w", err)
+	}
+
+	speaker := 0
+	if diarize {
+		speaker = 1
+	}
+	segments := make([]Segment, 0, len(out.Transcription))
+	for _, t := range out.Transcription {
+		text := strings.TrimSpace(strings.ReplaceAll(t.Text, "[SPEAKER_TURN]", ""))
+		if text != "" {
+			var sum float64
+			var count int
+			for _, token := range t.Tokens {
+				// [_BEG_]、[_TT_150] などの特殊トークンは除く
+				if strings.HasPrefix(token.Text, "[_") {
+					continue
+				}
+				sum += token.P
+				count++
+			}
+			segment := Segment{
+				Text:     text,
+				Speaker:  speaker,
+				Start:    float64(t.Offsets.From) / 1000,
+				End:      float64(t.Offsets.To) / 1000,
+				Language: out.Result.Language,
+			}
+			if count > 0 {
+				segment.Confidence = sum / float64(count)
+			}
+			segments = append(segments, segment)
+		}
+		if diarize && t.SpeakerTurnNext {
+			speaker = 3 - speaker
+		}
+	}
+	return segments, nil
+}
+
+// language BCP-47の言語コードからwhisperの言語コードを取り出す（ja-JP → ja）
+func language(code string) string {
+	if code == "" {
+		return "auto"
+	}
+	lang, _, _ := strings.Cut(code, "-")
+	return strings.ToLower(lang)
+}
+
+// run コマンドを実行し、失敗した場合は標準エラー出力をエラーに含める
+func run(cmd *exec.Cmd) error {
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, lastLine(msg))
+		}
+		return err
+	}
+	return nil
+}
+
+func lastLine(s string) string {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
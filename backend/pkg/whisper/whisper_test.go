@@ -0,0 +1,146 @@
+package whisper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+const sampleOutput = `{
+  "result": {"language": "ja"},
+  "transcription": [
+    {"offsets": {"from": 0, "to": 2400}, "text": " こんにちは",
+     "tokens": [{"text": "[_BEG_]", "p": 0.1}, {"text": " こん", "p": 0.9}, {"text": "にちは", "p": 0.7}],
+     "speaker_turn_next": true},
+    {"offsets": {"from": 2400, "to": 3000}, "text": " ", "tokens": []},
+    {"offsets": {"from": 3000, "to": 5200}, "text": " よろしくお願いします [SPEAKER_TURN]",
+     "tokens": [{"text": " よろしく", "p": 0.8}],
+     "speaker_turn_next": true},
+    {"offsets": {"from": 5200, "to": 6000}, "text": " はい", "tokens": [{"text": " はい", "p": 1.0}]}
+  ]
+}`
+
+// TestMain WHISPER_TEST_FAKE が設定されている場合はffmpeg・whisper-cliの代わりとして動く
+func TestMain(m *testing.M) {
+	if os.Getenv("WHISPER_TEST_FAKE") != "" {
+		os.Exit(fakeCommand(os.Args[1:]))
+	}
+	os.Exit(m.Run())
+}
+
+func fakeCommand(args []string) int {
+	flags := make(map[string]string)
+	for i := 0; i+1 < len(args); i++ {
+		if strings.HasPrefix(args[i], "-") {
+			flags[args[i]] = args[i+1]
+		}
+	}
+
+	// ffmpeg: 標準入力を出力ファイルにコピー
+	if flags["-i"] == "pipe:0" {
+		data, _ := io.ReadAll(os.Stdin)
+		if len(data) == 0 {
+			fmt.Fprintln(os.Stderr, "pipe:0: Invalid data found when processing input")
+			return 1
+		}
+		os.WriteFile(args[len(args)-1], data, 0o644)
+		return 0
+	}
+
+	// whisper-cli: 変換済みの音声と言語を確認して結果を書き出す
+	if data, err := os.ReadFile(flags["-f"]); err != nil || string(data) != "webm audio" {
+		fmt.Fprintln(os.Stderr, "error: failed to read WAV file")
+		return 1
+	}
+	output := sampleOutput
+	switch flags["-l"] {
+	case "ja":
+	case "auto":
+		// 自動判定では判定した言語を結果に書き出す
+		output = strings.Replace(sampleOutput, `"language": "ja"`, `"language": "en"`, 1)
+	default:
+		fmt.Fprintln(os.Stderr, "error: unexpected language", flags["-l"])
+		return 1
+	}
+	os.WriteFile(flags["-of"]+".json", []byte(output), 0o644)
+	return 0
+}
+
+func TestParseOutput(t *testing.T) {
+	segments, err := ParseOutput([]byte(sampleOutput), true)
+	if err != nil {
+		t.Fatalf("ParseOutput() unexpected error = %v", err)
+	}
+	if len(segments) != 3 {
+		t.Fatalf("ParseOutput() returned %d segments, want 3 (blank segment skipped)", len(segments))
+	}
+
+	first := segments[0]
+	if first.Text != "こんにちは" || first.Start != 0 || first.End != 2.4 || first.Speaker != 1 {
+		t.Errorf("segments[0] = %+v", first)
+	}
+	if first.Language != "ja" {
+		t.Errorf("segments[0].Language = %q, want the detected language ja", first.Language)
+	}
+	if first.Confidence < 0.799 || first.Confidence > 0.801 {
+		t.Errorf("segments[0].Confidence = %v, want 0.8 (special tokens excluded)", first.Confidence)
+	}
+	if segments[1].Text != "よろしくお願いします" || segments[1].Speaker != 2 {
+		t.Errorf("segments[1] = %+v, want speaker 2 without the turn marker", segments[1])
+	}
+	if segments[2].Speaker != 1 {
+		t.Errorf("segments[2].Speaker = %d, want 1", segments[2].Speaker)
+	}
+
+	plain, _ := ParseOutput([]byte(sampleOutput), false)
+	for _, s := range plain {
+		if s.Speaker != 0 {
+			t.Errorf("Speaker = %d without diarization, want 0", s.Speaker)
+		}
+	}
+
+	if _, err := ParseOutput([]byte("not json"), false); err == nil {
+		t.Error("ParseOutput() of invalid output error = nil")
+	}
+}
+
+func TestClient_Transcribe(t *testing.T) {
+	t.Setenv("WHISPER_TEST_FAKE", "1")
+	model := t.TempDir() + "/ggml-base.bin"
+	os.WriteFile(model, []byte("model"), 0o644)
+
+	client, err := NewClient(Config{Binary: os.Args[0], FFmpeg: os.Args[0], Model: model})
+	if err != nil {
+		t.Fatalf("NewClient() unexpected error = %v", err)
+	}
+
+	segments, err := client.Transcribe(context.Background(), strings.NewReader("webm audio"), Options{Language: "ja-JP"})
+	if err != nil {
+		t.Fatalf("Transcribe() unexpected error = %v", err)
+	}
+	if len(segments) != 3 || segments[2].Text != "はい" || segments[2].Start != 5.2 {
+		t.Errorf("Transcribe() = %+v", segments)
+	}
+
+	// 言語を指定しない場合は自動判定し、判定した言語を区間に付ける
+	detected, err := client.Transcribe(context.Background(), strings.NewReader("webm audio"), Options{})
+	if err != nil {
+		t.Fatalf("Transcribe() with auto detection unexpected error = %v", err)
+	}
+	if len(detected) == 0 || detected[0].Language != "en" {
+		t.Errorf("Transcribe() with auto detection = %+v, want language en", detected)
+	}
+
+	// 変換に失敗した場合はコマンドのエラー出力を含める
+	_, err = client.Transcribe(context.Background(), strings.NewReader(""), Options{Language: "ja"})
+	if err == nil || !strings.Contains(err.Error(), "Invalid data") {
+		t.Errorf("Transcribe() of empty audio error = %v, want the ffmpeg message", err)
+	}
+
+	if _, err := NewClient(Config{Binary: os.Args[0], FFmpeg: os.Args[0], Model: model + ".missing"}); err == nil {
+		t.Error("NewClient() with a missing model error = nil")
+	}
+}
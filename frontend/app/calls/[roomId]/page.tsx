@@ -3,6 +3,7 @@
 import { useEffect, useRef, useState } from 'react';
 import { useParams, useRouter } from 'next/navigation';
 import { WebRTCManager } from '@/lib/webrtc/WebRTCManager';
+import { uploadRecording as uploadRecordingResumable } from '@/lib/api/recordings';
 
 interface RemotePeer {
   id: string;
@@ -205,7 +206,7 @@ export default function CallRoomPage() {
           const duration = Math.floor((Date.now() - recordingStartTimeRef.current) / 1000);
 
           // バックエンドに録音データをアップロード
-          await uploadRecording(blob, duration);
+          await uploadRecording(blob, duration, new Date(recordingStartTimeRef.current));
 
           setIsRecording(false);
           mediaRecorderRef.current = null;
@@ -224,28 +225,11 @@ export default function CallRoomPage() {
   /**
    * 録音データをバックエンドにアップロード
    */
-  const uploadRecording = async (blob: Blob, duration: number) => {
+  const uploadRecording = async (blob: Blob, duration: number, startedAt: Date) => {
     try {
-      const token = localStorage.getItem('accessToken');
-      const API_BASE_URL = process.env.NEXT_PUBLIC_API_URL || 'http://localhost:8080';
-
-      const formData = new FormData();
-      formData.append('file', blob, `recording-${roomId}-${Date.now()}.webm`);
-      formData.append('duration', duration.toString());
-
-      const response = await fetch(`${API_BASE_URL}/api/calls/rooms/${roomId}/recordings`, {
-        method: 'POST',
-        headers: {
-          'Authorization': `Bearer ${token}`,
-        },
-        body: formData,
-      });
-
-      if (!response.ok) {
-        throw new Error('Failed to upload recording');
-      }
-
-      console.log('Recording uploaded successfully');
+      // 再開可能なアップロードでチャンクごとに送信（回線が途切れても続きから再送する）
+      const { recordingId } = await uploadRecordingResumable(roomId, blob, { duration, startedAt });
+      console.log('Recording uploaded successfully', recordingId);
     } catch (err) {
       console.error('Failed to upload recording:', err);
       setError(err instanceof Error ? err.message : 'Failed to upload recording');
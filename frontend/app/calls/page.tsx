@@ -2,7 +2,7 @@
 
 import { useEffect, useState } from 'react';
 import { useRouter } from 'next/navigation';
-import { getActiveRooms, createRoom, joinRoom, deleteRoom, type Room } from '@/lib/api/calls';
+import { getActiveRooms, createRoom, joinRoom, deleteRoom, subscribeLobby, type Room } from '@/lib/api/calls';
 import Navigation from '@/components/Navigation';
 import { useAuth } from '@/lib/hooks/useAuth';
 
@@ -19,6 +19,13 @@ export default function CallsPage() {
 
   useEffect(() => {
     loadRooms();
+
+    // 以降の変更はロビーの配信で反映する
+    const token = localStorage.getItem('accessToken');
+    if (!token) {
+      return;
+    }
+    return subscribeLobby(token, setRooms);
   }, []);
 
   /**
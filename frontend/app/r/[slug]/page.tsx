@@ -0,0 +1,48 @@
+'use client';
+
+import { useEffect, useState } from 'react';
+import { useParams, useRouter } from 'next/navigation';
+import { joinRoom, openPersonalRoomSession } from '@/lib/api/calls';
+
+/**
+ * 個人ルーム (/r/{slug})
+ * 進行中のセッションに参加し、なければ新しいセッションを開いて通話画面へ移動する
+ */
+export default function PersonalRoomPage() {
+  const params = useParams();
+  const router = useRouter();
+  const slug = params.slug as string;
+  const [error, setError] = useState<string | null>(null);
+
+  useEffect(() => {
+    const open = async () => {
+      try {
+        const session = await openPersonalRoomSession(slug);
+        if (!session.room_id) {
+          throw new Error('Failed to open session');
+        }
+        await joinRoom(session.room_id);
+        router.replace(`/calls/${session.room_id}`);
+      } catch (err) {
+        console.error('Failed to open personal room:', err);
+        setError(err instanceof Error ? err.message : 'Failed to open personal room');
+      }
+    };
+    open();
+  }, [slug, router]);
+
+  return (
+    <div className="min-h-screen bg-gray-900 text-white flex items-center justify-center">
+      {error ? (
+        <div className="text-center">
+          <div className="bg-red-500 text-white p-4 rounded mb-4">{error}</div>
+          <button onClick={() => router.push('/calls')} className="underline">
+            Back to rooms
+          </button>
+        </div>
+      ) : (
+        <div className="text-gray-400">Opening /r/{slug}...</div>
+      )}
+    </div>
+  );
+}
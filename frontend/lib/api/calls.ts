@@ -5,9 +5,30 @@
 
 import { apiClient } from './client';
 
+export type RoomVisibility = 'public' | 'private' | 'invite_only';
+
+export type ParticipantRole = 'host' | 'co_host' | 'presenter' | 'participant' | 'viewer';
+
+export interface ParticipantInfo {
+  participant_id: number;
+  user_id: number;
+  guest_id?: string;
+  name?: string;
+  role: ParticipantRole;
+  joined_at: string;
+}
+
 export interface CreateRoomRequest {
   name: string;
   maxParticipants?: number;
+  visibility?: RoomVisibility;
+  passcode?: string;
+  allowed_user_ids?: number[];
+  allowed_email_domains?: string[];
+  /** 文字起こしの言語（BCP-47。省略時は ja-JP、'auto' で自動判定） */
+  transcription_language?: string;
+  /** 他に話される可能性のある言語（3つまで） */
+  alternative_languages?: string[];
 }
 
 export interface CreateRoomResponse {
@@ -22,13 +43,16 @@ export interface Room {
   createdBy: number;
   createdAt: string;
   maxParticipants: number;
+  visibility?: RoomVisibility;
+  hasPasscode?: boolean;
   isActive: boolean;
   participantCount?: number;
 }
 
 export interface JoinRoomResponse {
-  roomId: string;
-  message: string;
+  success: boolean;
+  participant_id: number;
+  role: ParticipantRole;
 }
 
 export interface LeaveRoomResponse {
@@ -51,6 +75,65 @@ export async function getActiveRooms(): Promise<Room[]> {
   return response.data;
 }
 
+export type LobbyEventType = 'room-created' | 'room-updated' | 'participant-count' | 'room-ended';
+
+export type LobbyMessage =
+  | { type: 'snapshot'; rooms?: Room[] }
+  | { type: LobbyEventType; room: Room };
+
+/**
+ * ロビー（アクティブなルーム一覧）の変更を購読
+ * 接続直後のsnapshotで一覧を置き換え、以降のイベントで差分を反映する。
+ * 切断された場合は再接続してsnapshotから取り直す。戻り値で購読を終了する
+ */
+export function subscribeLobby(token: string, onRooms: (update: (rooms: Room[]) => Room[]) => void): () => void {
+  const wsUrl = process.env.NEXT_PUBLIC_WS_URL || 'ws://localhost:8080';
+  let ws: WebSocket | null = null;
+  let closed = false;
+  let reconnectTimer: ReturnType<typeof setTimeout> | null = null;
+
+  const connect = () => {
+    ws = new WebSocket(`${wsUrl}/ws/lobby?token=${encodeURIComponent(token)}`);
+
+    ws.onmessage = (event) => {
+      const msg = JSON.parse(event.data) as LobbyMessage;
+      if (msg.type === 'snapshot') {
+        onRooms(() => msg.rooms ?? []);
+        return;
+      }
+      const room = msg.room;
+      switch (msg.type) {
+        case 'room-created':
+          onRooms((rooms) => [room, ...rooms.filter((r) => r.id !== room.id)]);
+          break;
+        case 'room-updated':
+        case 'participant-count':
+          onRooms((rooms) => rooms.map((r) => (r.id === room.id ? room : r)));
+          break;
+        case 'room-ended':
+          onRooms((rooms) => rooms.filter((r) => r.id !== room.id));
+          break;
+      }
+    };
+
+    ws.onclose = () => {
+      if (!closed) {
+        reconnectTimer = setTimeout(connect, 3000);
+      }
+    };
+  };
+
+  connect();
+
+  return () => {
+    closed = true;
+    if (reconnectTimer) {
+      clearTimeout(reconnectTimer);
+    }
+    ws?.close();
+  };
+}
+
 /**
  * ルーム情報を取得
  */
@@ -60,10 +143,13 @@ export async function getRoom(roomId: string): Promise<Room> {
 }
 
 /**
- * ルームに参加
+ * ルームに参加（パスコード付きルームの場合はpasscodeを指定）
  */
-export async function joinRoom(roomId: string): Promise<JoinRoomResponse> {
-  const response = await apiClient.post<JoinRoomResponse>(`/api/calls/rooms/${roomId}/join`);
+export async function joinRoom(roomId: string, passcode?: string): Promise<JoinRoomResponse> {
+  const response = await apiClient.post<JoinRoomResponse>(
+    `/api/calls/rooms/${roomId}/join`,
+    passcode ? { passcode } : undefined
+  );
   return response.data;
 }
 
@@ -82,3 +168,600 @@ export async function deleteRoom(roomId: string): Promise<{ message: string }> {
   const response = await apiClient.delete<{ message: string }>(`/api/calls/rooms/${roomId}`);
   return response.data;
 }
+
+/**
+ * 参加者のロールを変更（ホスト・共同ホストのみ）
+ */
+export async function changeParticipantRole(
+  roomId: string,
+  participantId: number,
+  role: ParticipantRole
+): Promise<{ participants: ParticipantInfo[] }> {
+  const response = await apiClient.put<{ participants: ParticipantInfo[] }>(
+    `/api/calls/rooms/${roomId}/participants/${participantId}/role`,
+    { role }
+  );
+  return response.data;
+}
+
+/**
+ * 参加者を通話から退出させる（ホスト・共同ホストのみ）
+ */
+export async function kickParticipant(roomId: string, participantId: number): Promise<{ success: boolean }> {
+  const response = await apiClient.delete<{ success: boolean }>(
+    `/api/calls/rooms/${roomId}/participants/${participantId}`
+  );
+  return response.data;
+}
+
+export interface PollOption {
+  id: number;
+  text: string;
+  votes: number;
+  /** 投票者の表示名（匿名投票では省略） */
+  voters?: string[];
+}
+
+/**
+ * 通話中の投票と集計結果
+ * シグナリングの poll-created / poll-results / poll-closed でも同じ形式で届く（my_choicesは含まない）
+ */
+export interface Poll {
+  id: number;
+  question: string;
+  multiple_choice: boolean;
+  anonymous: boolean;
+  status: 'open' | 'closed';
+  total_voters: number;
+  options: PollOption[];
+  /** 自分が選んだ選択肢 */
+  my_choices?: number[];
+  closed_at?: string;
+  created_at: string;
+}
+
+export interface CreatePollRequest {
+  question: string;
+  options: string[];
+  multiple_choice?: boolean;
+  anonymous?: boolean;
+}
+
+/**
+ * ルームの投票一覧を取得
+ */
+export async function getPolls(roomId: string): Promise<Poll[]> {
+  const response = await apiClient.get<{ polls: Poll[] }>(`/api/calls/rooms/${roomId}/polls`);
+  return response.data.polls;
+}
+
+/**
+ * 投票を作成（ホスト・共同ホストのみ）
+ */
+export async function createPoll(roomId: string, data: CreatePollRequest): Promise<Poll> {
+  const response = await apiClient.post<Poll>(`/api/calls/rooms/${roomId}/polls`, data);
+  return response.data;
+}
+
+/**
+ * 投票する（締め切りまでは投票し直せる、単一選択の場合は1つだけ指定）
+ */
+export async function votePoll(roomId: string, pollId: number, optionIds: number[]): Promise<Poll> {
+  const response = await apiClient.post<Poll>(`/api/calls/rooms/${roomId}/polls/${pollId}/votes`, {
+    option_ids: optionIds,
+  });
+  return response.data;
+}
+
+/**
+ * 投票を締め切る（ホスト・共同ホストのみ、結果は議事録に追記される）
+ */
+export async function closePoll(roomId: string, pollId: number): Promise<Poll> {
+  const response = await apiClient.post<Poll>(`/api/calls/rooms/${roomId}/polls/${pollId}/close`);
+  return response.data;
+}
+
+/**
+ * 共有ノートの内容（通話後の閲覧用）
+ * 通話中の編集はシグナリングの notes-sync / notes-op で同期する（lib/webrtc/NotesDocument.ts）
+ */
+export interface CallNotes {
+  content: string;
+  /** 保存済みのスナップショットの版数 */
+  version: number;
+  updated_at?: string;
+}
+
+/**
+ * 共有ノートを取得
+ */
+export async function getNotes(roomId: string): Promise<CallNotes> {
+  const response = await apiClient.get<CallNotes>(`/api/calls/rooms/${roomId}/notes`);
+  return response.data;
+}
+
+/**
+ * ルームの文字起こしの言語設定
+ */
+export interface TranscriptionSettings {
+  /** BCP-47の言語コード、または 'auto'（候補の言語から判定） */
+  language: string;
+  alternative_languages: string[];
+  /** 話された言語を判定するか（更新時は無視される） */
+  auto_detect?: boolean;
+}
+
+/**
+ * 文字起こしの言語設定を取得（ホストのみ）
+ */
+export async function getTranscriptionSettings(roomId: string): Promise<TranscriptionSettings> {
+  const response = await apiClient.get<TranscriptionSettings>(`/api/calls/rooms/${roomId}/transcription-settings`);
+  return response.data;
+}
+
+/**
+ * 文字起こしの言語設定を更新（ホストのみ。以降の文字起こしに使われる）
+ */
+export async function updateTranscriptionSettings(
+  roomId: string,
+  settings: Pick<TranscriptionSettings, 'language' | 'alternative_languages'>
+): Promise<TranscriptionSettings> {
+  const response = await apiClient.put<TranscriptionSettings>(
+    `/api/calls/rooms/${roomId}/transcription-settings`,
+    settings
+  );
+  return response.data;
+}
+
+export type TranscriptionJobStatus = 'queued' | 'running' | 'succeeded' | 'failed';
+
+export interface TranscriptionRecordingState {
+  recording_id: number;
+  status: 'pending' | 'running' | 'done' | 'skipped' | 'failed';
+  /** 文字起こしした区間数 */
+  segments: number;
+  error?: string;
+}
+
+/**
+ * 文字起こしジョブ
+ * 進捗はユーザーイベント（subscribeUserEvents）の transcription-job でも届く
+ */
+export interface TranscriptionJob {
+  id: number;
+  status: TranscriptionJobStatus;
+  attempts: number;
+  progress: { completed: number; total: number };
+  recordings: TranscriptionRecordingState[];
+  last_error?: string;
+  /** 成功した場合に作成された議事録 */
+  minutes_id?: number;
+  /** 再試行待ちの場合の実行予定時刻 */
+  next_attempt_at?: string;
+  started_at?: string;
+  finished_at?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+/**
+ * 文字起こしを依頼（実行待ち・実行中のジョブがある場合はそのジョブが返る）
+ * 議事録が作成済みでも依頼でき、成功すると新しい実行から議事録が作り直される
+ */
+export async function startTranscription(roomId: string): Promise<TranscriptionJob> {
+  const response = await apiClient.post<TranscriptionJob>(`/api/calls/rooms/${roomId}/transcribe`);
+  return response.data;
+}
+
+/**
+ * 文字起こしジョブを取得
+ */
+export async function getTranscriptionJob(roomId: string, jobId: number): Promise<TranscriptionJob> {
+  const response = await apiClient.get<TranscriptionJob>(`/api/calls/rooms/${roomId}/transcription-jobs/${jobId}`);
+  return response.data;
+}
+
+/**
+ * ルームの文字起こしジョブ一覧を取得（新しい順）
+ */
+export async function getTranscriptionJobs(roomId: string, limit?: number): Promise<TranscriptionJob[]> {
+  const response = await apiClient.get<{ jobs: TranscriptionJob[] }>(`/api/calls/rooms/${roomId}/transcription-jobs`, {
+    params: { limit },
+  });
+  return response.data.jobs;
+}
+
+export type TranscriptionRunStatus = 'running' | 'succeeded' | 'failed';
+
+/**
+ * 文字起こしの実行（再実行しても前回までの実行は残る）
+ */
+export interface TranscriptionRun {
+  id: number;
+  /** 文字起こしジョブから実行した場合 */
+  job_id?: number;
+  engine: string;
+  /** 実行時のルームの文字起こしの言語 */
+  language: string;
+  alternative_languages: string[];
+  status: TranscriptionRunStatus;
+  /** 議事録の作成に使っている実行か */
+  is_current: boolean;
+  segments: number;
+  recordings: number;
+  error?: string;
+  started_at: string;
+  finished_at?: string;
+  created_at: string;
+}
+
+export interface TranscriptionSegment {
+  id: number;
+  recording_id?: number;
+  user_id?: number;
+  text: string;
+  confidence?: number;
+  language: string;
+  started_at?: string;
+  ended_at?: string;
+}
+
+/**
+ * ルームの文字起こしの実行一覧を取得（新しい順）
+ */
+export async function getTranscriptionRuns(roomId: string): Promise<TranscriptionRun[]> {
+  const response = await apiClient.get<{ runs: TranscriptionRun[] }>(`/api/calls/rooms/${roomId}/transcription-runs`);
+  return response.data.runs;
+}
+
+/**
+ * 文字起こしの実行とその区間を取得（実行どうしの比較に使う）
+ */
+export async function getTranscriptionRun(
+  roomId: string,
+  runId: number
+): Promise<TranscriptionRun & { transcriptions: TranscriptionSegment[] }> {
+  const response = await apiClient.get<TranscriptionRun & { transcriptions: TranscriptionSegment[] }>(
+    `/api/calls/rooms/${roomId}/transcription-runs/${runId}`
+  );
+  return response.data;
+}
+
+/**
+ * 現在の実行を切り替えて議事録を作り直す（成功した実行のみ）
+ */
+export async function setCurrentTranscriptionRun(roomId: string, runId: number): Promise<TranscriptionRun> {
+  const response = await apiClient.post<TranscriptionRun>(`/api/calls/rooms/${roomId}/transcription-runs/${runId}/current`);
+  return response.data;
+}
+
+export interface RecordingMixTrack {
+  recording_id: number;
+  user_id: number;
+  /** ミックスの先頭から再生を始める位置 */
+  offset_ms: number;
+  /** 音量を正規化したコピー（署名付きURL） */
+  normalized_url?: string;
+}
+
+/**
+ * 参加者ごとの録音を1つにまとめたミックス
+ * 進捗はユーザーイベント（subscribeUserEvents）の recording-mix でも届く
+ */
+export interface RecordingMix {
+  id: number;
+  status: 'queued' | 'running' | 'succeeded' | 'failed';
+  attempts: number;
+  format: string;
+  /** 成功した場合のみ（署名付きURL） */
+  download_url?: string;
+  file_size?: number;
+  /** ミックスの先頭の時刻 */
+  recording_started_at?: string;
+  tracks: RecordingMixTrack[];
+  last_error?: string;
+  finished_at?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+/**
+ * 録音のミックスを依頼（実行待ち・実行中のミックスがある場合はそのミックスが返る）
+ */
+export async function requestMixdown(roomId: string): Promise<RecordingMix> {
+  const response = await apiClient.post<RecordingMix>(`/api/calls/rooms/${roomId}/mixdown`);
+  return response.data;
+}
+
+/**
+ * ルームの最新のミックスを取得
+ */
+export async function getMixdown(roomId: string): Promise<RecordingMix> {
+  const response = await apiClient.get<RecordingMix>(`/api/calls/rooms/${roomId}/mixdown`);
+  return response.data;
+}
+
+export interface CallHistoryParticipant {
+  user_id?: number;
+  guest_id?: string;
+  name: string;
+  duration_seconds: number;
+  intervals: { joined_at: string; left_at?: string }[];
+}
+
+export interface CallHistoryItem {
+  room_id: string;
+  name: string;
+  status: 'waiting' | 'active' | 'ended';
+  created_by: number;
+  started_at?: string;
+  ended_at?: string;
+  duration_seconds: number;
+  participants: CallHistoryParticipant[];
+  has_recordings: boolean;
+  has_minutes: boolean;
+  created_at: string;
+}
+
+export interface CallHistoryResponse {
+  calls: CallHistoryItem[];
+  total: number;
+  limit: number;
+  offset: number;
+}
+
+export interface CallHistoryParams {
+  scope?: 'all' | 'created' | 'joined';
+  status?: 'waiting' | 'active' | 'ended';
+  from?: string;
+  to?: string;
+  q?: string;
+  limit?: number;
+  offset?: number;
+}
+
+/**
+ * 作成または参加した通話の履歴を取得
+ */
+export async function getCallHistory(params: CallHistoryParams = {}): Promise<CallHistoryResponse> {
+  const response = await apiClient.get<CallHistoryResponse>('/api/calls/history', { params });
+  return response.data;
+}
+
+export interface PersonalRoomAccess {
+  visibility: RoomVisibility;
+  has_passcode: boolean;
+  allowed_user_ids: number[];
+  allowed_email_domains: string[];
+}
+
+export interface PersonalRoom {
+  slug: string;
+  name: string;
+  max_participants: number;
+  /** 開いたセッションに適用するアクセス設定 */
+  access: PersonalRoomAccess;
+}
+
+export interface UpdatePersonalRoomRequest {
+  slug?: string;
+  name?: string;
+  max_participants?: number;
+  /** 進行中と以降のセッションに適用（passcodeは省略時は変更なし、空文字で解除。許可リストは置き換え） */
+  access?: {
+    visibility: RoomVisibility;
+    passcode?: string;
+    allowed_user_ids: number[];
+    allowed_email_domains: string[];
+  };
+}
+
+export interface PersonalRoomSession {
+  slug: string;
+  name: string;
+  owner_id: number;
+  room_id?: string;
+  status?: string;
+  session_number?: number;
+}
+
+export interface SessionInfo {
+  room_id: string;
+  session_number: number;
+  status: string;
+  started_at?: string;
+  ended_at?: string;
+  created_at: string;
+}
+
+/**
+ * 自分の個人ルームを取得（未作成の場合は作成される）
+ */
+export async function getMyPersonalRoom(): Promise<PersonalRoom> {
+  const response = await apiClient.get<PersonalRoom>('/api/personal-room');
+  return response.data;
+}
+
+/**
+ * 自分の個人ルームの設定を更新
+ */
+export async function updateMyPersonalRoom(data: UpdatePersonalRoomRequest): Promise<PersonalRoom> {
+  const response = await apiClient.patch<PersonalRoom>('/api/personal-room', data);
+  return response.data;
+}
+
+/**
+ * 自分の個人ルームのセッション一覧を取得
+ */
+export async function getMyPersonalRoomSessions(limit?: number): Promise<SessionInfo[]> {
+  const response = await apiClient.get<SessionInfo[]>('/api/personal-room/sessions', {
+    params: limit ? { limit } : undefined,
+  });
+  return response.data;
+}
+
+/**
+ * 個人ルームのセッションを開く（進行中のセッションがなければ作成される）
+ */
+export async function openPersonalRoomSession(slug: string): Promise<PersonalRoomSession> {
+  const response = await apiClient.post<PersonalRoomSession>(`/api/r/${encodeURIComponent(slug)}/session`);
+  return response.data;
+}
+
+export interface DirectCall {
+  call_id: string;
+  room_id: string;
+  caller_id: number;
+  callee_id: number;
+  status: 'ringing' | 'accepted' | 'declined' | 'missed';
+  expires_at: string;
+  answered_at?: string;
+  created_at: string;
+}
+
+export interface MissedCall {
+  call_id: string;
+  caller_id: number;
+  caller_name: string;
+  created_at: string;
+}
+
+/**
+ * 相手を指定して発信（相手の全端末に着信が届く）
+ */
+export async function startDirectCall(calleeId: number): Promise<DirectCall> {
+  const response = await apiClient.post<DirectCall>('/api/calls/direct', { callee_id: calleeId });
+  return response.data;
+}
+
+/**
+ * 着信に応答（返されたroom_idのルームに参加する）
+ */
+export async function acceptDirectCall(callId: string): Promise<DirectCall> {
+  const response = await apiClient.post<DirectCall>(`/api/calls/direct/${callId}/accept`);
+  return response.data;
+}
+
+/**
+ * 着信を拒否
+ */
+export async function declineDirectCall(callId: string): Promise<void> {
+  await apiClient.post(`/api/calls/direct/${callId}/decline`);
+}
+
+/**
+ * 発信を取り消し
+ */
+export async function cancelDirectCall(callId: string): Promise<void> {
+  await apiClient.post(`/api/calls/direct/${callId}/cancel`);
+}
+
+/**
+ * 自分宛ての不在着信一覧を取得
+ */
+export async function getMissedCalls(limit?: number): Promise<MissedCall[]> {
+  const response = await apiClient.get<MissedCall[]>('/api/calls/direct/missed', {
+    params: limit ? { limit } : undefined,
+  });
+  return response.data;
+}
+
+/**
+ * 登録ユーザーを通話ルームに招待（相手が取り込み中の場合は409）
+ */
+export async function inviteUserToRoom(roomId: string, userId: number): Promise<void> {
+  await apiClient.post(`/api/calls/rooms/${roomId}/invites/users`, { user_id: userId });
+}
+
+export type PresenceStatus = 'offline' | 'online' | 'away' | 'in-call' | 'dnd';
+
+export interface Presence {
+  user_id: number;
+  status: PresenceStatus;
+  last_seen_at?: string;
+}
+
+/**
+ * 自分の在席状態を取得
+ */
+export async function getMyPresence(): Promise<Presence> {
+  const response = await apiClient.get<Presence>('/api/presence');
+  return response.data;
+}
+
+/**
+ * 自分の在席状態を設定
+ */
+export async function updateMyPresence(status: 'online' | 'away' | 'dnd'): Promise<Presence> {
+  const response = await apiClient.put<Presence>('/api/presence', { status });
+  return response.data;
+}
+
+/**
+ * 連絡先（同じ通話に参加したことのあるユーザー）の在席状態一覧を取得
+ */
+export async function getContactsPresence(): Promise<Presence[]> {
+  const response = await apiClient.get<Presence[]>('/api/presence/contacts');
+  return response.data;
+}
+
+export interface UserEvent {
+  type: string;
+  data?: unknown;
+}
+
+/** transcription-job イベントのデータ（依頼した文字起こしジョブの進捗） */
+export interface TranscriptionJobEvent {
+  job_id: number;
+  room_id: string;
+  status: TranscriptionJobStatus;
+  attempts: number;
+  completed: number;
+  total: number;
+  minutes_id?: number;
+  error?: string;
+}
+
+/** recording-mix イベントのデータ（依頼したミックスの進捗） */
+export interface RecordingMixEvent {
+  mix_id: number;
+  room_id: string;
+  status: RecordingMix['status'];
+  error?: string;
+}
+
+/**
+ * 自分宛てのイベント（着信・通知・連絡先の在席状態・文字起こしの進捗など）を購読
+ * 接続している間はオンラインとして扱われる。接続直後にpresence-snapshotで連絡先の在席状態が届く。
+ * 切断された場合は再接続する。戻り値で購読を終了する
+ */
+export function subscribeUserEvents(token: string, onEvent: (event: UserEvent) => void): () => void {
+  const wsUrl = process.env.NEXT_PUBLIC_WS_URL || 'ws://localhost:8080';
+  let ws: WebSocket | null = null;
+  let closed = false;
+  let reconnectTimer: ReturnType<typeof setTimeout> | null = null;
+
+  const connect = () => {
+    ws = new WebSocket(`${wsUrl}/ws/user?token=${encodeURIComponent(token)}`);
+
+    ws.onmessage = (event) => {
+      onEvent(JSON.parse(event.data) as UserEvent);
+    };
+
+    ws.onclose = () => {
+      if (!closed) {
+        reconnectTimer = setTimeout(connect, 3000);
+      }
+    };
+  };
+
+  connect();
+
+  return () => {
+    closed = true;
+    if (reconnectTimer) {
+      clearTimeout(reconnectTimer);
+    }
+    ws?.close();
+  };
+}
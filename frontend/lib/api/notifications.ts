@@ -0,0 +1,59 @@
+/**
+ * Notification API Service
+ * 招待・不在着信・議事録の作成完了・Todoの割り当て・新しい端末からのログインなどの通知
+ * 新しい通知はsubscribeUserEventsの notification イベントでも届く
+ */
+
+import { apiClient } from './client';
+
+export type NotificationType = 'call-invite' | 'missed-call' | 'minutes-ready' | 'todo-assigned' | 'new-device-login';
+
+export interface Notification {
+  id: number;
+  type: NotificationType;
+  title: string;
+  body: string;
+  /** 種類ごとの付加情報（room_id, url など） */
+  data?: Record<string, unknown>;
+  read_at?: string;
+  created_at: string;
+}
+
+export interface NotificationList {
+  notifications: Notification[];
+  unread_count: number;
+  /** 続きを取得する場合のbefore（最後のページでは省略） */
+  next_before?: number;
+}
+
+export interface NotificationListParams {
+  unread?: boolean;
+  before?: number;
+  limit?: number;
+}
+
+/** notifications-read イベントのデータ（他の端末で既読にした通知） */
+export interface NotificationsReadEvent {
+  id?: number;
+  all?: boolean;
+}
+
+export const notificationsApi = {
+  // 通知一覧取得（新しい順）
+  getAll: async (params: NotificationListParams = {}): Promise<NotificationList> => {
+    const response = await apiClient.get<NotificationList>('/api/notifications', {
+      params: { ...params, unread: params.unread ? 'true' : undefined },
+    });
+    return response.data;
+  },
+
+  // 通知を既読にする
+  markRead: async (id: number): Promise<void> => {
+    await apiClient.post(`/api/notifications/${id}/read`);
+  },
+
+  // すべて既読にする
+  markAllRead: async (): Promise<void> => {
+    await apiClient.post('/api/notifications/read-all');
+  },
+};
@@ -0,0 +1,76 @@
+/**
+ * Web Push API Service
+ * アプリを開いていない間の着信・議事録の作成完了を端末へ届ける
+ * 通知の表示は public/sw.js のService Workerが行う
+ */
+
+import { apiClient } from './client';
+
+export interface PushPublicKey {
+  public_key: string;
+}
+
+/** Service Workerへ届くプッシュの本文 */
+export interface PushPayload {
+  type: 'incoming-call' | 'missed-call' | 'minutes-ready' | 'call-invite' | 'new-device-login';
+  title: string;
+  body?: string;
+  data?: Record<string, unknown>;
+}
+
+// URL-safeなBase64の公開鍵をPushManager.subscribeに渡す形式へ変換
+export const urlBase64ToUint8Array = (base64: string): Uint8Array => {
+  const padding = '='.repeat((4 - (base64.length % 4)) % 4);
+  const raw = atob((base64 + padding).replace(/-/g, '+').replace(/_/g, '/'));
+  return Uint8Array.from(raw, (c) => c.charCodeAt(0));
+};
+
+export const pushApi = {
+  // VAPIDの公開鍵取得（サーバーで未設定の場合は503）
+  getPublicKey: async (): Promise<string> => {
+    const response = await apiClient.get<PushPublicKey>('/api/push/public-key');
+    return response.data.public_key;
+  },
+
+  // 端末の購読を登録（PushSubscription.toJSON() をそのまま送る）
+  subscribe: async (subscription: PushSubscriptionJSON): Promise<void> => {
+    await apiClient.post('/api/push/subscriptions', subscription);
+  },
+
+  // 端末の購読を解除
+  unsubscribe: async (endpoint: string): Promise<void> => {
+    await apiClient.delete('/api/push/subscriptions', { data: { endpoint } });
+  },
+};
+
+// Service Workerを登録し、この端末でプッシュ通知を受け取る
+export const enablePushNotifications = async (): Promise<boolean> => {
+  if (!('serviceWorker' in navigator) || !('PushManager' in window)) {
+    return false;
+  }
+  if ((await Notification.requestPermission()) !== 'granted') {
+    return false;
+  }
+
+  const registration = await navigator.serviceWorker.register('/sw.js');
+  const publicKey = await pushApi.getPublicKey();
+  const subscription =
+    (await registration.pushManager.getSubscription()) ??
+    (await registration.pushManager.subscribe({
+      userVisibleOnly: true,
+      applicationServerKey: urlBase64ToUint8Array(publicKey),
+    }));
+  await pushApi.subscribe(subscription.toJSON());
+  return true;
+};
+
+// この端末のプッシュ通知を止める（ログアウト時など）
+export const disablePushNotifications = async (): Promise<void> => {
+  const registration = await navigator.serviceWorker?.getRegistration('/sw.js');
+  const subscription = await registration?.pushManager.getSubscription();
+  if (!subscription) {
+    return;
+  }
+  await pushApi.unsubscribe(subscription.endpoint);
+  await subscription.unsubscribe();
+};
@@ -0,0 +1,220 @@
+/**
+ * Recording Upload API Service
+ * 録音を再開可能なアップロード（tus 1.0.0）で送信する
+ *
+ * 録音をチャンクに分けて送信し、途中で失敗した場合はサーバーが受信済みのオフセット（HEAD）から再開する。
+ * チャンクの大きさはバックエンドの MAX_REQUEST_BODY_SIZE 以下にする。
+ *
+ * uploadRecordingDirect は署名付きURLでストレージへ直接送信し、APIサーバーを経由しない。
+ */
+
+import axios, { AxiosError } from 'axios';
+import { apiClient } from './client';
+
+const TUS_VERSION = '1.0.0';
+
+/** 1回のPATCHで送るサイズ（MAX_REQUEST_BODY_SIZEの既定10MBより小さくする） */
+const DEFAULT_CHUNK_SIZE = 5 * 1024 * 1024;
+
+/** 失敗したチャンクを再送するまでの待ち時間（回数ごと） */
+const RETRY_DELAYS_MS = [1000, 3000, 5000, 10000, 20000];
+
+export interface RecordingUploadOptions {
+  /** 録音時間（秒） */
+  duration?: number;
+  /** 録音を開始した時刻（ミックスで参加者の録音の位置を揃えるのに使う） */
+  startedAt?: Date;
+  chunkSize?: number;
+  /** 送信済みのバイト数 */
+  onProgress?: (uploaded: number, total: number) => void;
+  /** 中断したアップロードのURL（同じ録音の再送時に指定すると続きから送る） */
+  uploadUrl?: string;
+  /** アップロードを作成したときに呼ばれる（URLを保存しておけば再読み込み後も再開できる） */
+  onCreated?: (uploadUrl: string) => void;
+}
+
+export interface RecordingUploadResult {
+  uploadUrl: string;
+  recordingId: number | null;
+}
+
+const tusHeaders = (headers: Record<string, string> = {}) => ({
+  'Tus-Resumable': TUS_VERSION,
+  ...headers,
+});
+
+/** Upload-Metadata の値（キーと base64 の値をカンマ区切り） */
+const encodeMetadata = (metadata: Record<string, string>) =>
+  Object.entries(metadata)
+    .map(([key, value]) => `${key} ${btoa(unescape(encodeURIComponent(value)))}`)
+    .join(',');
+
+const recordingIdFrom = (value: unknown): number | null => {
+  const id = Number(value);
+  return value && Number.isFinite(id) ? id : null;
+};
+
+const sleep = (ms: number) => new Promise((resolve) => setTimeout(resolve, ms));
+
+/** 再送しても成功しないエラー（アップロードの期限切れ・権限なしなど） */
+const isPermanent = (error: unknown) => {
+  const status = (error as AxiosError).response?.status;
+  return status !== undefined && status >= 400 && status < 500 && status !== 409 && status !== 423 && status !== 460;
+};
+
+/**
+ * 録音をアップロードして作成された録音IDを返す
+ */
+export async function uploadRecording(
+  roomId: string,
+  blob: Blob,
+  options: RecordingUploadOptions = {}
+): Promise<RecordingUploadResult> {
+  const chunkSize = options.chunkSize ?? DEFAULT_CHUNK_SIZE;
+  const total = blob.size;
+
+  const uploadUrl = options.uploadUrl ?? (await createUpload(roomId, blob, options));
+
+  let { offset, recordingId } = await getState(uploadUrl);
+  let failures = 0;
+
+  // 全体を送信済みでも録音IDがなければ空のPATCHで録音の作成をやり直す
+  while (offset < total || recordingId === null) {
+    try {
+      const end = Math.min(offset + chunkSize, total);
+      const response = await apiClient.patch(uploadUrl, blob.slice(offset, end), {
+        headers: tusHeaders({
+          'Content-Type': 'application/offset+octet-stream',
+          'Upload-Offset': String(offset),
+        }),
+      });
+      offset = Number(response.headers['upload-offset']);
+      recordingId = recordingIdFrom(response.headers['x-recording-id']);
+      failures = 0;
+      options.onProgress?.(offset, total);
+    } catch (error) {
+      if (isPermanent(error) || failures >= RETRY_DELAYS_MS.length) {
+        throw error;
+      }
+      await sleep(RETRY_DELAYS_MS[failures]);
+      failures++;
+      // 途中まで受信されている可能性があるため、サーバーのオフセットから再開する
+      try {
+        ({ offset, recordingId } = await getState(uploadUrl));
+      } catch {
+        // 回線が戻っていなければ次の再送で確認する
+      }
+    }
+  }
+
+  return { uploadUrl, recordingId };
+}
+
+/**
+ * アップロードを作成してURLを返す
+ */
+async function createUpload(roomId: string, blob: Blob, options: RecordingUploadOptions): Promise<string> {
+  const metadata: Record<string, string> = { filetype: blob.type || 'audio/webm' };
+  if (options.duration !== undefined) {
+    metadata.duration = String(Math.round(options.duration));
+  }
+  if (options.startedAt) {
+    metadata.started_at = options.startedAt.toISOString();
+  }
+  const response = await apiClient.post(`/api/calls/rooms/${roomId}/uploads`, null, {
+    headers: tusHeaders({
+      'Upload-Length': String(blob.size),
+      'Upload-Metadata': encodeMetadata(metadata),
+    }),
+  });
+  const uploadUrl = response.headers['location'] as string;
+  options.onCreated?.(uploadUrl);
+  return uploadUrl;
+}
+
+/**
+ * サーバーが受信済みのオフセットと、完了していれば作成された録音IDを取得
+ */
+async function getState(uploadUrl: string): Promise<{ offset: number; recordingId: number | null }> {
+  const response = await apiClient.head(uploadUrl, { headers: tusHeaders() });
+  return {
+    offset: Number(response.headers['upload-offset']),
+    recordingId: recordingIdFrom(response.headers['x-recording-id']),
+  };
+}
+
+/**
+ * アップロードを中止
+ */
+export async function cancelRecordingUpload(uploadUrl: string): Promise<void> {
+  await apiClient.delete(uploadUrl, { headers: tusHeaders() });
+}
+
+export interface RecordingSlot {
+  slot_id: string;
+  upload_url: string;
+  method: string;
+  /** アップロード時にそのまま送るヘッダー（署名に含まれる） */
+  headers: Record<string, string>;
+  object_key: string;
+  size: number;
+  finalize_url: string;
+  expires_at: string;
+}
+
+export interface DirectUploadOptions {
+  /** 録音時間（秒） */
+  duration?: number;
+  /** 録音を開始した時刻 */
+  startedAt?: Date;
+  /** 送信済みのバイト数 */
+  onProgress?: (uploaded: number, total: number) => void;
+}
+
+/**
+ * 署名付きURLでストレージへ直接アップロードし、作成された録音IDを返す
+ */
+export async function uploadRecordingDirect(
+  roomId: string,
+  blob: Blob,
+  options: DirectUploadOptions = {}
+): Promise<number> {
+  const slot = await createRecordingSlot(roomId, blob, options.duration, options.startedAt);
+
+  // 署名にないヘッダー（Authorization など）を付けないよう apiClient は使わない
+  await axios.request({
+    method: slot.method,
+    url: slot.upload_url,
+    data: blob,
+    headers: slot.headers,
+    onUploadProgress: (event) => options.onProgress?.(event.loaded, blob.size),
+  });
+
+  return finalizeRecordingSlot(slot);
+}
+
+/**
+ * アップロードの枠を作成して署名付きURLを取得
+ */
+export async function createRecordingSlot(
+  roomId: string,
+  blob: Blob,
+  duration?: number,
+  startedAt?: Date
+): Promise<RecordingSlot> {
+  const response = await apiClient.post<RecordingSlot>(`/api/calls/rooms/${roomId}/recording-slots`, {
+    size: blob.size,
+    content_type: blob.type || 'audio/webm',
+    duration_seconds: duration !== undefined ? Math.round(duration) : undefined,
+    started_at: startedAt?.toISOString(),
+  });
+  return response.data;
+}
+
+/**
+ * アップロードの完了を通知して録音IDを返す（再送しても録音は1つ）
+ */
+export async function finalizeRecordingSlot(slot: RecordingSlot): Promise<number> {
+  const response = await apiClient.post<{ recording_id: number }>(slot.finalize_url);
+  return response.data.recording_id;
+}
@@ -0,0 +1,97 @@
+/**
+ * Webhook API Service
+ * 通話・議事録のイベントを外部ツールへ通知するWebhookの管理
+ */
+
+import { apiClient } from './client';
+
+export type WebhookEvent =
+  | 'room.created'
+  | 'room.started'
+  | 'room.updated'
+  | 'participant.joined'
+  | 'participant.left'
+  | 'room.ended'
+  | 'recording.uploaded'
+  | 'minutes.ready';
+
+export interface Webhook {
+  id: number;
+  url: string;
+  events: WebhookEvent[];
+  is_active: boolean;
+  /** 登録時とシークレット再生成時のみ返る */
+  secret?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateWebhookRequest {
+  url: string;
+  events: WebhookEvent[];
+}
+
+export interface UpdateWebhookRequest {
+  url?: string;
+  events?: WebhookEvent[];
+  is_active?: boolean;
+  rotate_secret?: boolean;
+}
+
+export type WebhookDeliveryStatus = 'pending' | 'succeeded' | 'failed';
+
+export interface WebhookDelivery {
+  id: number;
+  event_id: string;
+  event: WebhookEvent;
+  status: WebhookDeliveryStatus;
+  attempts: number;
+  next_attempt_at?: string;
+  last_status_code?: number;
+  last_error?: string;
+  delivered_at?: string;
+  replay_of?: number;
+  payload: unknown;
+  created_at: string;
+}
+
+export const webhooksApi = {
+  // Webhook一覧取得
+  getAll: async (): Promise<Webhook[]> => {
+    const response = await apiClient.get<Webhook[]>('/api/webhooks');
+    return response.data;
+  },
+
+  // Webhook登録
+  create: async (data: CreateWebhookRequest): Promise<Webhook> => {
+    const response = await apiClient.post<Webhook>('/api/webhooks', data);
+    return response.data;
+  },
+
+  // Webhook更新
+  update: async (id: number, data: UpdateWebhookRequest): Promise<Webhook> => {
+    const response = await apiClient.patch<Webhook>(`/api/webhooks/${id}`, data);
+    return response.data;
+  },
+
+  // Webhook削除
+  delete: async (id: number): Promise<void> => {
+    await apiClient.delete(`/api/webhooks/${id}`);
+  },
+
+  // 配信記録一覧取得
+  getDeliveries: async (id: number, limit?: number): Promise<WebhookDelivery[]> => {
+    const response = await apiClient.get<WebhookDelivery[]>(`/api/webhooks/${id}/deliveries`, {
+      params: { limit },
+    });
+    return response.data;
+  },
+
+  // 配信を再送
+  replay: async (id: number, deliveryId: number): Promise<WebhookDelivery> => {
+    const response = await apiClient.post<WebhookDelivery>(
+      `/api/webhooks/${id}/deliveries/${deliveryId}/replay`
+    );
+    return response.data;
+  },
+};
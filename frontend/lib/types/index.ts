@@ -37,6 +37,8 @@ export interface RefreshTokenRequest {
 // Todo types
 export interface Todo {
   id: number;
+  /** 担当者のユーザーID（未割り当てならnull） */
+  assignee_id: number | null;
   title: string;
   description: string;
   completed: boolean;
@@ -47,12 +49,15 @@ export interface Todo {
 export interface CreateTodoRequest {
   title: string;
   description: string;
+  assignee_id?: number;
 }
 
 export interface UpdateTodoRequest {
   title?: string;
   description?: string;
   completed?: boolean;
+  /** 0で割り当てを解除 */
+  assignee_id?: number;
 }
 
 // API Error type
@@ -0,0 +1,162 @@
+/**
+ * 共有ノートのCRDT文書（バックエンドの pkg/crdt と同じRGA）
+ *
+ * 1文字を1要素として扱い、各要素は (Lamportクロック, サイト) のIDを持つ。
+ * 同じ位置への同時挿入はIDの大きい方を前に並べるため、全員の文書が同じ内容に収束する。
+ *
+ * 使い方:
+ *   1. 接続後に { type: 'notes-sync' } を送り、応答の elements を restore() に渡す
+ *   2. 応答が届くまでに受け取った notes-op は保留し、restore() の後に applyRemote() する
+ *   3. ローカルの編集は insertText() / deleteText() が返す操作を { type: 'notes-op', data: { ops } } で送る
+ *   4. error（type: 'notes-op'）を受け取った場合は notes-sync からやり直す
+ */
+
+export interface NotesID {
+  c: number;
+  s: string;
+}
+
+export interface NotesOp {
+  type: 'insert' | 'delete';
+  id: NotesID;
+  after?: NotesID;
+  char?: string;
+}
+
+export interface NotesElement {
+  id: NotesID;
+  char: string;
+  deleted?: boolean;
+}
+
+interface Node extends NotesElement {
+  next: Node | null;
+}
+
+const key = (id: NotesID) => `${id.c}:${id.s}`;
+const isZero = (id?: NotesID) => !id || (id.c === 0 && id.s === '');
+const less = (a: NotesID, b: NotesID) => (a.c !== b.c ? a.c < b.c : a.s < b.s);
+
+export class NotesDocument {
+  private head: Node = { id: { c: 0, s: '' }, char: '', next: null };
+  private index = new Map<string, Node>();
+  private clock = 0;
+  private synced = false;
+  private pending: NotesOp[] = [];
+
+  /**
+   * @param site 自分のクライアントID（user-{id} / guest-{id}）。挿入する要素のIDに使う
+   */
+  constructor(private site: string) {}
+
+  /**
+   * notes-sync の要素列から文書を作り直し、保留していた操作を適用
+   */
+  restore(elements: NotesElement[]): void {
+    this.head.next = null;
+    this.index.clear();
+    this.clock = 0;
+    let last = this.head;
+    for (const e of elements) {
+      const node: Node = { ...e, next: null };
+      last.next = node;
+      last = node;
+      this.index.set(key(e.id), node);
+      this.clock = Math.max(this.clock, e.id.c);
+    }
+    this.synced = true;
+    const pending = this.pending;
+    this.pending = [];
+    this.applyRemote(pending);
+  }
+
+  /**
+   * 他の参加者の操作を適用（同期前は保留、適用済みの操作は無視）
+   */
+  applyRemote(ops: NotesOp[]): void {
+    if (!this.synced) {
+      this.pending.push(...ops);
+      return;
+    }
+    for (const op of ops) {
+      this.apply(op);
+    }
+  }
+
+  /**
+   * 表示位置posにテキストを挿入し、送信する操作を返す
+   */
+  insertText(pos: number, text: string): NotesOp[] {
+    let after = this.visibleID(pos);
+    const ops: NotesOp[] = [];
+    for (const char of Array.from(text)) {
+      const op: NotesOp = { type: 'insert', id: { c: this.clock + 1, s: this.site }, after, char };
+      this.apply(op);
+      ops.push(op);
+      after = op.id;
+    }
+    return ops;
+  }
+
+  /**
+   * 表示位置posからn文字を削除し、送信する操作を返す
+   */
+  deleteText(pos: number, n: number): NotesOp[] {
+    const ops: NotesOp[] = [];
+    let i = 0;
+    for (let node = this.head.next; node && ops.length < n; node = node.next) {
+      if (node.deleted) continue;
+      if (i >= pos) ops.push({ type: 'delete', id: node.id });
+      i++;
+    }
+    ops.forEach((op) => this.apply(op));
+    return ops;
+  }
+
+  /**
+   * 表示されるテキスト
+   */
+  text(): string {
+    let text = '';
+    for (let node = this.head.next; node; node = node.next) {
+      if (!node.deleted) text += node.char;
+    }
+    return text;
+  }
+
+  private apply(op: NotesOp): boolean {
+    if (op.type === 'delete') {
+      const node = this.index.get(key(op.id));
+      if (!node || node.deleted) return false;
+      node.deleted = true;
+      return true;
+    }
+
+    if (this.index.has(key(op.id))) return false;
+    let prev: Node | undefined = this.head;
+    if (!isZero(op.after)) {
+      prev = this.index.get(key(op.after!));
+      if (!prev) return false;
+    }
+    while (prev.next && less(op.id, prev.next.id)) {
+      prev = prev.next;
+    }
+    const node: Node = { id: op.id, char: op.char ?? '', next: prev.next };
+    prev.next = node;
+    this.index.set(key(op.id), node);
+    this.clock = Math.max(this.clock, op.id.c);
+    return true;
+  }
+
+  private visibleID(pos: number): NotesID | undefined {
+    if (pos <= 0) return undefined;
+    let i = 0;
+    let last: NotesID | undefined;
+    for (let node = this.head.next; node; node = node.next) {
+      if (node.deleted) continue;
+      last = node.id;
+      if (++i === pos) return node.id;
+    }
+    return last;
+  }
+}
@@ -4,7 +4,16 @@
  */
 
 export interface SignalingMessage {
-  type: 'offer' | 'answer' | 'ice-candidate' | 'join' | 'leave' | 'user-joined' | 'user-left';
+  type:
+    | 'offer'
+    | 'answer'
+    | 'ice-candidate'
+    | 'join'
+    | 'leave'
+    | 'user-joined'
+    | 'user-left'
+    | 'notes-sync'
+    | 'notes-op';
   from?: string;
   to?: string;
   data?: any;
@@ -0,0 +1,41 @@
+// Web Push を受け取って通知を表示するService Worker（lib/api/push.ts で登録）
+
+self.addEventListener('push', (event) => {
+  if (!event.data) {
+    return;
+  }
+  const payload = event.data.json();
+
+  event.waitUntil(
+    self.clients.matchAll({ type: 'window', includeUncontrolled: true }).then((clients) => {
+      // アプリを前面に表示している場合はアプリ内の通知に任せる（別のサーバーに接続中でも届くため）
+      if (clients.some((client) => client.focused)) {
+        return;
+      }
+      const data = payload.data || {};
+      return self.registration.showNotification(payload.title, {
+        body: payload.body,
+        data: { ...data, type: payload.type },
+        tag: data.call_id || data.notification_id?.toString() || payload.type,
+        requireInteraction: payload.type === 'incoming-call',
+      });
+    })
+  );
+});
+
+self.addEventListener('notificationclick', (event) => {
+  event.notification.close();
+  const data = event.notification.data || {};
+  const url = data.url || (data.room_id ? `/calls/${data.room_id}` : '/');
+
+  event.waitUntil(
+    self.clients.matchAll({ type: 'window', includeUncontrolled: true }).then((clients) => {
+      const target = new URL(url, self.location.origin).href;
+      const existing = clients.find((client) => client.url === target);
+      if (existing) {
+        return existing.focus();
+      }
+      return self.clients.openWindow(target);
+    })
+  );
+});